@@ -1,22 +1,35 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"mime"
-	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sachinthra/file-locker/backend/pkg/client"
 	"github.com/schollz/progressbar/v3"
 )
 
@@ -26,9 +39,48 @@ const (
 	apiBase    = "http://localhost:9010/api/v1"
 )
 
+// Version of the CLI, set with -ldflags "-X main.Version=..."
+var Version = "dev"
+
+// supportedAPIVersion is the server API version (GET /version api_version) this
+// CLI was written for
+const supportedAPIVersion = 1
+
+// serverInfoMaxAge is how long the cached server version and features are trusted
+const serverInfoMaxAge = 24 * time.Hour
+
 type CLIConfig struct {
-	BaseURL string `json:"base_url"`
-	Token   string `json:"token"`
+	BaseURL string      `json:"base_url"`
+	Token   string      `json:"token"`
+	Server  *ServerInfo `json:"server,omitempty"` // from GET /version, refreshed on login
+
+	// Password logins get a short-lived access token (Token) and a refresh token
+	// that renews it; personal access tokens have neither
+	RefreshToken   string     `json:"refresh_token,omitempty"`
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+}
+
+// setSession stores the tokens of a password login in cfg
+func (cfg *CLIConfig) setSession(tokens client.Session) {
+	cfg.Token = tokens.Token
+	cfg.RefreshToken = tokens.RefreshToken
+	cfg.TokenExpiresAt = nil
+	if tokens.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+		cfg.TokenExpiresAt = &expiresAt
+	}
+}
+
+// ServerInfo is what the server reported about itself. Legacy servers predate
+// GET /version; their features are unknown.
+type ServerInfo struct {
+	Version     string          `json:"version"`
+	GitCommit   string          `json:"git_commit,omitempty"`
+	APIVersion  int             `json:"api_version"`            // newest version the server speaks
+	APIVersions []int           `json:"api_versions,omitempty"` // all it serves (older servers: only APIVersion)
+	Features    map[string]bool `json:"features"`
+	Legacy      bool            `json:"legacy,omitempty"`
+	FetchedAt   time.Time       `json:"fetched_at"`
 }
 
 func cfgPath() (string, error) {
@@ -76,14 +128,113 @@ func loadToken() (string, error) {
 	if cfg.Token == "" {
 		return "", errors.New("no token found")
 	}
+
+	// Renew the access token shortly before it expires; when that fails the
+	// request reports the expired session
+	if cfg.RefreshToken != "" && cfg.TokenExpiresAt != nil && time.Until(*cfg.TokenExpiresAt) < time.Minute {
+		if err := refreshSession(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not refresh the session: %v\n", err)
+		}
+	}
 	return cfg.Token, nil
 }
 
+// refreshSession exchanges the refresh token for new tokens and saves them. Refresh
+// tokens work once, so the new one must be saved before the next run.
+func refreshSession(cfg *CLIConfig) error {
+	tokens, err := apiClient("").Refresh(context.Background(), cfg.RefreshToken)
+	if err != nil {
+		return err
+	}
+	cfg.setSession(*tokens)
+	return saveConfig(*cfg)
+}
+
 func httpClient(token string) *http.Client {
-	client := &http.Client{Timeout: 0}
+	client := &http.Client{Timeout: 0, Transport: requestIDTransport{base: http.DefaultTransport}}
 	return client
 }
 
+// apiClient is a client for the configured server whose failed requests are
+// reported like those of doRequest
+func apiClient(token string) *client.Client {
+	c := client.New(getBaseURL(), token)
+	c.HTTPClient = httpClient(token)
+	return c
+}
+
+// acceptLanguage turns the POSIX locale (LC_ALL, LC_MESSAGES or LANG, e.g.
+// de_DE.UTF-8) into a language tag, so server messages come in the user's
+// language; "" for the C locale
+func acceptLanguage() string {
+	locale := ""
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale = os.Getenv(name); locale != "" {
+			break
+		}
+	}
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return ""
+	}
+	return strings.ReplaceAll(locale, "_", "-")
+}
+
+// failedRequestID is the X-Request-ID of the last request the server answered with
+// an error. It is printed with the error so bug reports can be matched to the
+// server logs.
+var (
+	failedRequestMu sync.Mutex
+	failedRequestID string
+)
+
+// requestIDTransport records the request ID of error responses (and forgets it
+// once a later request succeeds)
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+func (t requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if lang := acceptLanguage(); lang != "" && req.Header.Get("Accept-Language") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Language", lang)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		failedRequestMu.Lock()
+		failedRequestID = ""
+		if resp.StatusCode >= 400 {
+			failedRequestID = resp.Header.Get("X-Request-ID")
+		}
+		failedRequestMu.Unlock()
+	}
+	return resp, err
+}
+
+// exitWithError prints err, with the request ID of the failed request if there was
+// one, and exits
+func exitWithError(err error) {
+	if client.IsStatus(err, http.StatusUnauthorized) {
+		fmt.Fprintln(os.Stderr, "Session expired or invalid token. Please run 'fl login'.")
+		printFailedRequestID()
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	printFailedRequestID()
+	os.Exit(1)
+}
+
+func printFailedRequestID() {
+	failedRequestMu.Lock()
+	id := failedRequestID
+	failedRequestMu.Unlock()
+	if id != "" {
+		fmt.Fprintf(os.Stderr, "Request ID: %s (include it when reporting a problem)\n", id)
+	}
+}
+
 func normalizeBaseURL(host string) string {
 	// Remove trailing slash
 	host = strings.TrimSuffix(host, "/")
@@ -115,25 +266,99 @@ func isAdmin() bool {
 		return false
 	}
 
-	resp, err := doRequest("GET", "/auth/me", token, nil, "")
+	user, err := apiClient(token).Me(context.Background())
 	if err != nil {
 		return false
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return user.Role == "admin"
+}
 
-	if resp.StatusCode != 200 {
-		return false
+// fetchServerInfo asks the server for its version and features
+func fetchServerInfo() (*ServerInfo, error) {
+	version, err := apiClient("").Version(context.Background())
+	if client.IsStatus(err, http.StatusNotFound) {
+		return &ServerInfo{Version: "unknown", Legacy: true, FetchedAt: time.Now()}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
 	}
 
-	var user struct {
-		Role string `json:"role"`
+	return &ServerInfo{
+		Version:     version.Version,
+		GitCommit:   version.GitCommit,
+		APIVersion:  version.APIVersion,
+		APIVersions: version.APIVersions,
+		Features:    version.Features,
+		FetchedAt:   time.Now(),
+	}, nil
+}
+
+// refreshServerInfo fetches the server's version and features into cfg and warns
+// when the server speaks a different API version than this CLI
+func refreshServerInfo(cfg *CLIConfig) {
+	info, err := fetchServerInfo()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not get the server version: %v\n", err)
+		return
 	}
+	cfg.Server = info
 
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return false
+	switch {
+	case info.Legacy:
+		fmt.Fprintln(os.Stderr, "Warning: the server predates version reporting; newer CLI features may not work. Consider upgrading the server.")
+	case slices.Contains(info.APIVersions, supportedAPIVersion):
+		// The server still serves the version this CLI speaks
+	case info.APIVersion > supportedAPIVersion:
+		fmt.Fprintf(os.Stderr, "Warning: the server uses API version %d, this CLI (%s) was built for %d. Please upgrade the CLI.\n",
+			info.APIVersion, Version, supportedAPIVersion)
+	case info.APIVersion < supportedAPIVersion:
+		fmt.Fprintf(os.Stderr, "Warning: the server (%s) uses API version %d, this CLI expects %d. Please upgrade the server.\n",
+			info.Version, info.APIVersion, supportedAPIVersion)
 	}
+}
 
-	return user.Role == "admin"
+// serverInfo returns the cached server version and features, refreshing them when
+// they are missing or stale. It returns nil when the server cannot be asked.
+func serverInfo() *ServerInfo {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+	if cfg.Server != nil && time.Since(cfg.Server.FetchedAt) < serverInfoMaxAge {
+		return cfg.Server
+	}
+	info, err := fetchServerInfo()
+	if err != nil {
+		return cfg.Server
+	}
+	cfg.Server = info
+	_ = saveConfig(*cfg)
+	return info
+}
+
+// hasFeature reports whether the server is known to offer feature. Optional
+// optimizations are skipped when it is not (or the server is too old to say).
+func hasFeature(feature string) bool {
+	info := serverInfo()
+	return info != nil && info.Features[feature]
+}
+
+// requireFeature fails with a clear error when the server is known not to offer
+// feature, which what (a command or flag) needs. Servers that do not report their
+// features are given the benefit of the doubt.
+func requireFeature(feature, what string) error {
+	info := serverInfo()
+	if info == nil || info.Legacy || info.Features == nil {
+		return nil
+	}
+	enabled, known := info.Features[feature]
+	if !known {
+		return fmt.Errorf("%s needs a newer server: server %s does not support %q", what, info.Version, feature)
+	}
+	if !enabled {
+		return fmt.Errorf("%s is not available: %q is disabled on this server", what, feature)
+	}
+	return nil
 }
 
 func doRequest(method, path, token string, body io.Reader, contentType string) (*http.Response, error) {
@@ -152,6 +377,7 @@ func doRequest(method, path, token string, body io.Reader, contentType string) (
 	// Handle 401 Unauthorized
 	if err == nil && resp.StatusCode == 401 {
 		fmt.Fprintln(os.Stderr, "Session expired or invalid token. Please run 'fl login'.")
+		printFailedRequestID()
 		os.Exit(1)
 	}
 
@@ -178,25 +404,25 @@ func cmdLogin(args []string) error {
 	// Update base URL if --host is provided
 	if *hostPtr != "" {
 		cfg.BaseURL = normalizeBaseURL(*hostPtr)
+		cfg.Server = nil
 		fmt.Printf("Using server: %s\n", cfg.BaseURL)
 	}
 
 	// Token-based login (preferred)
 	if *tokenPtr != "" {
 		// Save config with new host before validating token
-		cfg.Token = *tokenPtr
+		cfg.setSession(client.Session{Token: *tokenPtr})
 		if err := saveConfig(*cfg); err != nil {
 			return err
 		}
 
 		// Validate token by calling an auth-protected endpoint
-		resp, err := doRequest("GET", "/files", *tokenPtr, nil, "")
-		if err != nil {
-			return err
+		if _, err := apiClient(*tokenPtr).Me(context.Background()); err != nil {
+			return fmt.Errorf("invalid token: %v", err)
 		}
-		defer func() { _ = resp.Body.Close() }()
-		if resp.StatusCode != 200 {
-			return fmt.Errorf("invalid token (status %d)", resp.StatusCode)
+		refreshServerInfo(cfg)
+		if err := saveConfig(*cfg); err != nil {
+			return err
 		}
 		fmt.Println("✅ Successfully logged in with Personal Access Token!")
 		return nil
@@ -204,79 +430,73 @@ func cmdLogin(args []string) error {
 
 	// Username/Password login (legacy)
 	if *userPtr != "" && *passPtr != "" {
-		payload := map[string]string{
-			"username": *userPtr,
-			"password": *passPtr,
-		}
-		body, _ := json.Marshal(payload)
-
-		resp, err := doRequest("POST", "/auth/login", "", strings.NewReader(string(body)), "application/json")
+		api := apiClient("")
+		api.BaseURL = cfg.BaseURL // --host is not saved yet
+		session, err := api.Login(context.Background(), *userPtr, *passPtr)
 		if err != nil {
-			return err
-		}
-		defer func() { _ = resp.Body.Close() }()
-
-		if resp.StatusCode != 200 {
-			return fmt.Errorf("login failed (status %d)", resp.StatusCode)
-		}
-
-		var result struct {
-			Token string `json:"token"`
-			User  struct {
-				Username string `json:"username"`
-			} `json:"user"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return err
+			// Not wrapped: a 401 here is a wrong password, not an expired session
+			return fmt.Errorf("login failed: %v", err)
 		}
 
-		cfg.Token = result.Token
+		cfg.setSession(*session)
+		refreshServerInfo(cfg)
 		if err := saveConfig(*cfg); err != nil {
 			return err
 		}
-		fmt.Printf("✅ Successfully logged in as %s!\n", result.User.Username)
+		fmt.Printf("✅ Successfully logged in as %s!\n", *userPtr)
 		return nil
 	}
 
 	return errors.New("either --token or both -u and -p are required")
 }
 
-func cmdLs(jsonOut bool, wideOut bool) error {
+// cmdLs lists one page of files, as large as the user's page size preference
+// (everything by default); all follows the pages to the end
+func cmdLs(jsonOut, wideOut, all bool, cursor string) error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
-	resp, err := doRequest("GET", "/files", token, nil, "")
+	api := apiClient(token)
+	if all {
+		files, err := api.AllFiles(context.Background())
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			b, _ := json.Marshal(map[string]interface{}{"files": files, "count": len(files)})
+			fmt.Println(string(b))
+			return nil
+		}
+		printFileTable(files, wideOut)
+		return nil
+	}
+
+	page, err := api.ListFiles(context.Background(), client.ListOptions{Cursor: cursor})
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("error: %s", resp.Status)
-	}
-	body, _ := io.ReadAll(resp.Body)
 	if jsonOut {
-		fmt.Println(string(body))
+		response := map[string]interface{}{"files": page.Files, "count": len(page.Files)}
+		if page.NextCursor != "" {
+			response["next_cursor"] = page.NextCursor
+		}
+		b, _ := json.Marshal(response)
+		fmt.Println(string(b))
 		return nil
 	}
 
-	var parsed struct {
-		Files []struct {
-			ID        string     `json:"file_id"`
-			FileName  string     `json:"file_name"`
-			Size      int64      `json:"size"`
-			CreatedAt time.Time  `json:"created_at"`
-			ExpiresAt *time.Time `json:"expires_at"`
-		} `json:"files"`
-	}
-
-	if err := json.Unmarshal(body, &parsed); err != nil {
-		return err
+	printFileTable(page.Files, wideOut)
+	if page.NextCursor != "" {
+		fmt.Printf("\nMore files: fl ls --cursor %s (or fl ls --all)\n", page.NextCursor)
 	}
+	return nil
+}
 
-	if len(parsed.Files) == 0 {
+func printFileTable(files []client.File, wideOut bool) {
+	if len(files) == 0 {
 		fmt.Println("No files found.")
-		return nil
+		return
 	}
 
 	// Use tabwriter for clean table formatting
@@ -289,8 +509,8 @@ func cmdLs(jsonOut bool, wideOut bool) error {
 		_, _ = fmt.Fprintln(w, "---\t----\t----\t--------\t-------")
 	}
 
-	for _, f := range parsed.Files {
-		id := f.ID
+	for _, f := range files {
+		id := f.FileID
 		if !wideOut && len(id) > 8 {
 			id = id[:8] + "..."
 		}
@@ -310,25 +530,92 @@ func cmdLs(jsonOut bool, wideOut bool) error {
 		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, f.FileName, size, uploaded, expires)
 	}
 	_ = w.Flush()
-
-	return nil
 }
 
-func uploadWithProgress(token, path string, tags string, expireHours int) error {
+// uploadWithProgress uploads a file and returns its new file ID. A negative
+// expireHours leaves the expiry to the user's preference. A limit above zero caps
+// the transfer at that many bytes per second.
+func uploadWithProgress(token, path string, tags string, expireHours int, limit int64) (string, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer func() { _ = file.Close() }()
 
 	stat, err := file.Stat()
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// Create progress bar
+	// The local mtime goes along so sync tools see the file as unchanged
+	modTime := stat.ModTime()
+	upload := client.UploadRequest{
+		FileName:   filepath.Base(path),
+		Size:       stat.Size(),
+		ModifiedAt: &modTime,
+	}
+	if tags != "" {
+		upload.Tags = strings.Split(tags, ",")
+	}
+	if expireHours >= 0 {
+		upload.ExpireAfter = &expireHours
+	}
+	api := apiClient(token)
+
+	// Skip the transfer if the server already has this content (servers without
+	// precheck get the whole file)
+	if hasFeature("upload_precheck") {
+		if fileID, ok := precheckUpload(api, file, upload); ok {
+			return fileID, nil
+		}
+	}
+
+	// Retries after network errors and gateway failures send the same idempotency
+	// key, so the server creates the file at most once even if an earlier attempt
+	// reached it
+	upload.IdempotencyKey = newIdempotencyKey()
+	var result *client.UploadResult
+	for attempt := 1; ; attempt++ {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+		result, err = sendUpload(api, file, upload, limit)
+		if err == nil || !client.Retryable(err) || attempt == uploadAttempts {
+			break
+		}
+		wait := time.Duration(attempt) * 2 * time.Second
+		fmt.Fprintf(os.Stderr, "Upload attempt %d failed (%v), retrying in %s...\n", attempt, err, wait)
+		time.Sleep(wait)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if len(result.FileID) >= 8 {
+		fmt.Printf("Successfully uploaded: %s (ID: %s)\n", result.FileName, result.FileID[:8]+"...")
+	} else {
+		fmt.Println("Upload complete!")
+	}
+	if result.Quarantined {
+		fmt.Println("⚠️  Held back for review by an admin; share links will not serve it until approved")
+	}
+
+	return result.FileID, nil
+}
+
+// uploadAttempts is how often an upload is tried before giving up
+const uploadAttempts = 3
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// sendUpload makes one upload attempt from the current position of file
+func sendUpload(api *client.Client, file *os.File, upload client.UploadRequest, limit int64) (*client.UploadResult, error) {
 	bar := progressbar.NewOptions64(
-		stat.Size(),
+		upload.Size,
 		progressbar.OptionSetDescription("Uploading"),
 		progressbar.OptionSetWriter(os.Stderr),
 		progressbar.OptionShowBytes(true),
@@ -343,84 +630,36 @@ func uploadWithProgress(token, path string, tags string, expireHours int) error
 		progressbar.OptionSetRenderBlankState(true),
 	)
 
-	// Create pipe for streaming upload
-	pr, pw := io.Pipe()
-	writer := multipart.NewWriter(pw)
-
-	// Error channel for goroutine
-	done := make(chan error, 1)
-
-	// Write multipart form in goroutine
-	go func() {
-		defer func() { _ = pw.Close() }()
-
-		// Add file part
-		part, err := writer.CreateFormFile("file", filepath.Base(path))
-		if err != nil {
-			done <- err
-			return
-		}
-
-		// Copy file through progress bar
-		_, err = io.Copy(part, io.TeeReader(file, bar))
-		if err != nil {
-			done <- err
-			return
-		}
-
-		// Add optional fields
-		if tags != "" {
-			_ = writer.WriteField("tags", tags)
-		}
-		if expireHours > 0 {
-			_ = writer.WriteField("expire_after", fmt.Sprint(expireHours))
-		}
-
-		_ = writer.Close()
-		done <- nil
-	}()
-
-	// Get base URL
-	baseURL := getBaseURL()
-
-	// Create request
-	req, err := http.NewRequest("POST", baseURL+"/upload", pr)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	// Send request
-	client := httpClient(token)
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 201 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("upload failed (status %d): %s", resp.StatusCode, string(b))
-	}
-
-	// Wait for upload goroutine
-	if err := <-done; err != nil {
-		return err
-	}
+	upload.Body = newThrottledReader(file, limit)
+	upload.Progress = func(sent, total int64) { _ = bar.Set64(sent) }
+	return api.Upload(context.Background(), upload)
+}
 
-	// Parse response
-	var result struct {
-		FileID   string `json:"file_id"`
-		FileName string `json:"file_name"`
+// precheckUpload hashes the file and asks the server to create it from content
+// the user already stores. It reports false whenever the file has to be uploaded
+// (new content, or a server without /upload/precheck).
+func precheckUpload(api *client.Client, file *os.File, upload client.UploadRequest) (string, bool) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", false
+	}
+
+	result, err := api.Precheck(context.Background(), client.PrecheckRequest{
+		SHA256:      hex.EncodeToString(hasher.Sum(nil)),
+		Size:        upload.Size,
+		FileName:    upload.FileName,
+		Tags:        upload.Tags,
+		ExpireAfter: upload.ExpireAfter,
+		ModifiedAt:  upload.ModifiedAt,
+	})
+	if err != nil || result == nil || len(result.FileID) < 8 {
+		return "", false
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err == nil {
-		fmt.Printf("Successfully uploaded: %s (ID: %s)\n", result.FileName, result.FileID[:8]+"...")
-	} else {
-		fmt.Println("Upload complete!")
+	fmt.Printf("Already stored, uploaded instantly: %s (ID: %s)\n", result.FileName, result.FileID[:8]+"...")
+	if result.Quarantined {
+		fmt.Println("⚠️  Held back for review by an admin; share links will not serve it until approved")
 	}
-
-	return nil
+	return result.FileID, true
 }
 
 func cmdUpload(args []string) error {
@@ -428,8 +667,10 @@ func cmdUpload(args []string) error {
 
 	// Define your flags as usual
 	tags := fs.String("tags", "", "comma separated tags")
-	expire := fs.Int("expire", 0, "expiration time in hours")
+	expire := fs.Int("expire", -1, "expiration time in hours (0 = never; default: your default expiry preference)")
 	verbose := fs.Bool("verbose", false, "enable verbose output")
+	var limit rateFlag
+	fs.Var(&limit, "limit-rate", "maximum transfer rate in bytes per second (e.g. 500K, 5M)")
 
 	// Use our custom parser wrapper
 	if err := ParseInterspersed(fs, args); err != nil {
@@ -452,12 +693,15 @@ func cmdUpload(args []string) error {
 		fmt.Printf("DEBUG: uploading %s (tags=%s, expire=%d, verbose=%v)\n", path, *tags, *expire, *verbose)
 	}
 
-	return uploadWithProgress(token, path, *tags, *expire)
+	_, err = uploadWithProgress(token, path, *tags, *expire, int64(limit))
+	return err
 }
 
 func cmdDownload(args []string) error {
 	fs := flag.NewFlagSet("download", flag.ContinueOnError)
 	output := fs.String("o", "", "output filename (default: from server)")
+	var limit rateFlag
+	fs.Var(&limit, "limit-rate", "maximum transfer rate in bytes per second (e.g. 500K, 5M)")
 
 	// Use our custom parser wrapper
 	if err := ParseInterspersed(fs, args); err != nil {
@@ -466,40 +710,32 @@ func cmdDownload(args []string) error {
 
 	remainingArgs := fs.Args()
 	if len(remainingArgs) < 1 {
-		return errors.New("file id required")
+		return errors.New("file name or id required")
 	}
-	id := remainingArgs[0]
 
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("GET", "/download/"+id, token, nil, "")
+	id, err := resolveFile(token, remainingArgs[0])
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("download failed (status %d): %s", resp.StatusCode, string(b))
+	download, err := apiClient(token).Download(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("download failed: %w", err)
 	}
+	defer func() { _ = download.Body.Close() }()
 
-	// Determine output filename
+	// Determine output filename: the name on the server, or the file ID
 	filename := *output
 	if filename == "" {
-		// Try to get filename from Content-Disposition header
-		if cd := resp.Header.Get("Content-Disposition"); cd != "" {
-			_, params, err := mime.ParseMediaType(cd)
-			if err == nil && params["filename"] != "" {
-				filename = params["filename"]
-			}
-		}
-		// Fallback to file ID
-		if filename == "" {
-			filename = filepath.Base(id)
-		}
+		filename = download.FileName
+	}
+	if filename == "" {
+		filename = filepath.Base(id)
 	}
 
 	// Create output file
@@ -510,7 +746,7 @@ func cmdDownload(args []string) error {
 	defer func() { _ = f.Close() }()
 
 	// Create progress bar
-	total := resp.ContentLength
+	total := download.Size
 	if total < 0 {
 		total = 0
 	}
@@ -531,8 +767,13 @@ func cmdDownload(args []string) error {
 		progressbar.OptionSetRenderBlankState(true),
 	)
 
-	// Download with progress
-	_, err = io.Copy(io.MultiWriter(f, bar), resp.Body)
+	// Download with progress; the body checks the checksum at the end
+	_, err = io.Copy(newThrottledWriter(io.MultiWriter(f, bar), int64(limit)), download.Body)
+	if errors.Is(err, client.ErrChecksumMismatch) {
+		_ = f.Close()
+		_ = os.Remove(filename)
+		return fmt.Errorf("%w; removed %s", err, filename)
+	}
 	if err != nil {
 		return err
 	}
@@ -541,256 +782,440 @@ func cmdDownload(args []string) error {
 	return nil
 }
 
-func cmdRm(args []string) error {
-	fs := flag.NewFlagSet("rm", flag.ContinueOnError)
-	_ = fs.Parse(args)
-	args = fs.Args()
-	if len(args) < 1 {
-		return errors.New("file id required")
+// headChunkSize is how much `fl head` fetches per Range request
+const headChunkSize = 64 * 1024
+
+func cmdCat(args []string) error {
+	fs := flag.NewFlagSet("cat", flag.ContinueOnError)
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-	id := args[0]
+	if fs.NArg() < 1 {
+		return errors.New("file name or id required")
+	}
+
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
-
-	resp, err := doRequest("DELETE", "/files?id="+id, token, nil, "")
+	id, err := resolveFile(token, fs.Arg(0))
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 && resp.StatusCode != 204 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("delete failed (status %d): %s", resp.StatusCode, string(b))
+	download, err := apiClient(token).Download(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("download failed: %w", err)
 	}
+	defer func() { _ = download.Body.Close() }()
 
-	fmt.Printf("Successfully deleted file: %s\n", id)
-	return nil
+	_, err = io.Copy(os.Stdout, download.Body)
+	return err
 }
 
-func cmdLogout() error {
-	token, err := loadToken()
-	if err != nil {
-		return err
+func cmdHead(args []string) error {
+	fs := flag.NewFlagSet("head", flag.ContinueOnError)
+	lines := fs.Int("n", 10, "number of lines")
+	bytesOut := fs.Int64("c", 0, "number of bytes (instead of lines)")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-
-	resp, err := doRequest("POST", "/auth/logout", token, nil, "")
-	if err != nil {
-		return err
+	if fs.NArg() < 1 {
+		return errors.New("file name or id required")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// Clear config
-	cfg := CLIConfig{BaseURL: getBaseURL()}
-	if err := saveConfig(cfg); err != nil {
-		return err
+	if *lines < 0 || *bytesOut < 0 {
+		return errors.New("-n and -c must not be negative")
 	}
 
-	fmt.Println("✅ Logged out successfully")
-	return nil
-}
-
-func cmdMe() error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
-
-	resp, err := doRequest("GET", "/auth/me", token, nil, "")
+	id, err := resolveFile(token, fs.Arg(0))
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get user info (status %d): %s", resp.StatusCode, string(b))
-	}
+	r := &rangeReader{api: apiClient(token), id: id, size: -1}
+	defer r.Close()
 
-	var user struct {
-		ID        string    `json:"user_id"`
-		Username  string    `json:"username"`
-		Email     string    `json:"email"`
-		Role      string    `json:"role"`
-		CreatedAt time.Time `json:"created_at"`
-	}
+	out := bufio.NewWriter(os.Stdout)
+	defer func() { _ = out.Flush() }()
 
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+	if *bytesOut > 0 {
+		_, err = io.Copy(out, io.LimitReader(r, *bytesOut))
 		return err
 	}
 
-	fmt.Printf("User ID:      %s\n", user.ID)
-	fmt.Printf("Username:     %s\n", user.Username)
-	fmt.Printf("Email:        %s\n", user.Email)
-	fmt.Printf("Role:         %s\n", user.Role)
-	fmt.Printf("Member Since: %s\n", user.CreatedAt.Format("2006-01-02"))
+	in := bufio.NewReaderSize(r, headChunkSize)
+	for n := 0; n < *lines; n++ {
+		line, err := in.ReadSlice('\n')
+		for errors.Is(err, bufio.ErrBufferFull) {
+			// Longer than the buffer; write what we have and keep reading the line
+			_, _ = out.Write(line)
+			line, err = in.ReadSlice('\n')
+		}
+		_, _ = out.Write(line)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func cmdSearch(args []string) error {
-	fs := flag.NewFlagSet("search", flag.ContinueOnError)
-	jsonOut := fs.Bool("json", false, "output json")
-	wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
-	fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+// rangeReader reads a file through /stream with one Range request per chunk, so
+// only as much of the file as is read gets decrypted and transferred
+type rangeReader struct {
+	api    *client.Client
+	id     string
+	offset int64
+	size   int64 // -1 until the first response
+	body   io.ReadCloser
+	whole  bool // the server sent the whole file instead of a range
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+	for {
+		if r.body == nil {
+			if r.size >= 0 && r.offset >= r.size {
+				return 0, io.EOF
+			}
+			if err := r.fetch(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if err == io.EOF && !r.whole {
+			_ = r.body.Close()
+			r.body = nil
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+func (r *rangeReader) fetch() error {
+	part, err := r.api.ReadRange(context.Background(), r.id, r.offset, headChunkSize)
+	if err == io.EOF {
+		r.size = r.offset // empty file, or read to the end
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("read failed: %w", err)
+	}
+
+	// When the server ignores the range and sends everything, read as much as needed
+	r.whole = part.Whole
+	if !part.Whole && part.Total >= 0 {
+		r.size = part.Total
+	}
+	r.body = part.Body
+	return nil
+}
+
+func (r *rangeReader) Close() {
+	if r.body != nil {
+		_ = r.body.Close()
+	}
+}
+
+// fileList lists files of the user, e.g. (*client.Client).Trash
+type fileList func(*client.Client, context.Context) ([]client.File, error)
+
+// resolveFile turns a file name or the start of a file ID into a file ID, asking
+// which file is meant when several match
+func resolveFile(token, ref string) (string, error) {
+	return resolveFileIn(token, ref, (*client.Client).AllFiles)
+}
+
+// resolveFileIn resolves ref among the files of the given lists (e.g. the trash)
+func resolveFileIn(token, ref string, lists ...fileList) (string, error) {
+	if len(ref) == 36 && strings.Count(ref, "-") == 4 {
+		return ref, nil // already a full ID
+	}
+
+	api := apiClient(token)
+	var files []client.File
+	for _, list := range lists {
+		listed, err := list(api, context.Background())
+		if err != nil {
+			return "", err
+		}
+		files = append(files, listed...)
+	}
+
+	// An exact name wins over a case-insensitive name, which wins over an ID prefix
+	var exact, folded, prefix []client.File
+	for _, f := range files {
+		switch {
+		case f.FileName == ref:
+			exact = append(exact, f)
+		case strings.EqualFold(f.FileName, ref):
+			folded = append(folded, f)
+		case strings.HasPrefix(f.FileID, strings.ToLower(ref)):
+			prefix = append(prefix, f)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = folded
+	}
+	if len(matches) == 0 {
+		matches = prefix
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no file named %q or with an ID starting with %q", ref, ref)
+	case 1:
+		return matches[0].FileID, nil
+	}
+	return chooseFile(ref, matches)
+}
 
+// chooseFile lets the user pick one of several matching files; without a terminal
+// to ask on it lists them and fails
+func chooseFile(ref string, matches []client.File) (string, error) {
+	sort.Slice(matches, func(i, j int) bool { return matches[i].CreatedAt.After(matches[j].CreatedAt) })
+
+	fmt.Fprintf(os.Stderr, "%d files match %q:\n", len(matches), ref)
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 3, ' ', 0)
+	for i, f := range matches {
+		_, _ = fmt.Fprintf(w, "  %d)\t%s\t%s\t%s\t%s\n", i+1, f.FileID, f.FileName,
+			humanize.Bytes(uint64(f.Size)), humanize.Time(f.CreatedAt))
+	}
+	_ = w.Flush()
+
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return "", errors.New("ambiguous file; use a longer ID prefix")
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Fprintf(os.Stderr, "Choose a file [1-%d]: ", len(matches))
+		line, err := reader.ReadString('\n')
+		if n, convErr := strconv.Atoi(strings.TrimSpace(line)); convErr == nil && n >= 1 && n <= len(matches) {
+			return matches[n-1].FileID, nil
+		}
+		if err != nil {
+			return "", errors.New("no file chosen")
+		}
+	}
+}
+
+func cmdRm(args []string) error {
+	fs := flag.NewFlagSet("rm", flag.ContinueOnError)
+	force := fs.Bool("force", false, "delete permanently instead of moving to the trash")
+	fs.BoolVar(force, "f", false, "shorthand for --force")
 	if err := ParseInterspersed(fs, args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
+	args = fs.Args()
+	if len(args) < 1 {
+		return errors.New("file name or id required")
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
 
-	remainingArgs := fs.Args()
-	if len(remainingArgs) < 1 {
-		return errors.New("search query required")
+	// Files already in the trash can only be deleted permanently
+	lists := []fileList{(*client.Client).AllFiles}
+	if *force {
+		lists = append(lists, (*client.Client).Trash)
+	}
+	id, err := resolveFileIn(token, args[0], lists...)
+	if err != nil {
+		return err
 	}
-	query := remainingArgs[0]
 
-	token, err := loadToken()
+	trashed, err := deleteFile(token, id, *force)
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("GET", "/files/search?q="+query, token, nil, "")
+	if trashed {
+		fmt.Printf("Moved to trash: %s (undo with 'fl restore %s')\n", id, id[:min(8, len(id))])
+	} else {
+		fmt.Printf("Permanently deleted file: %s\n", id)
+	}
+	return nil
+}
+
+// deleteFile deletes a file, or moves it to the trash unless permanent is set,
+// and reports whether it went to the trash
+func deleteFile(token, id string, permanent bool) (bool, error) {
+	trashed, err := apiClient(token).DeleteFile(context.Background(), id, permanent)
 	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	return trashed, nil
+}
+
+func cmdTrash(args []string) error {
+	if len(args) < 1 {
+		return errors.New("usage: fl trash [ls|empty]")
+	}
+	if err := requireFeature("trash", "fl trash"); err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
+	switch args[0] {
+	case "ls", "list":
+		fs := flag.NewFlagSet("trash ls", flag.ContinueOnError)
+		jsonOut := fs.Bool("json", false, "output json")
+		wideOut := fs.Bool("wide", false, "show full IDs")
+		fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+		if err := ParseInterspersed(fs, args[1:]); err != nil {
+			return fmt.Errorf("failed to parse flags: %w", err)
+		}
+		return cmdTrashList(*jsonOut, *wideOut)
+	case "empty":
+		fs := flag.NewFlagSet("trash empty", flag.ContinueOnError)
+		yes := fs.Bool("yes", false, "do not ask for confirmation")
+		fs.BoolVar(yes, "y", false, "shorthand for --yes")
+		if err := ParseInterspersed(fs, args[1:]); err != nil {
+			return fmt.Errorf("failed to parse flags: %w", err)
+		}
+		return cmdTrashEmpty(*yes)
+	default:
+		return fmt.Errorf("unknown trash command: %s", args[0])
+	}
+}
+
+func cmdTrashList(jsonOut bool, wideOut bool) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+	resp, err := doRequest("GET", "/files/trash", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("search failed (status %d)", resp.StatusCode)
+		return fmt.Errorf("error: %s", resp.Status)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if jsonOut {
+		fmt.Println(string(body))
+		return nil
 	}
 
-	var result struct {
+	var parsed struct {
 		Files []struct {
 			ID        string    `json:"file_id"`
 			FileName  string    `json:"file_name"`
 			Size      int64     `json:"size"`
-			CreatedAt time.Time `json:"created_at"`
-			Tags      []string  `json:"tags"`
+			DeletedAt time.Time `json:"deleted_at"`
+			PurgeAt   time.Time `json:"purge_at"`
 		} `json:"files"`
-		Count int `json:"count"`
+		TotalSize int64 `json:"total_size"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &parsed); err != nil {
 		return err
 	}
 
-	if result.Count == 0 {
-		fmt.Println("No files found matching query.")
-		return nil
-	}
-
-	if *jsonOut {
-		b, _ := json.Marshal(result)
-		fmt.Println(string(b))
+	if len(parsed.Files) == 0 {
+		fmt.Println("Trash is empty.")
 		return nil
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	if *wideOut {
-		_, _ = fmt.Fprintf(w, "FILE ID\tNAME\tSIZE\tTAGS\n")
-		_, _ = fmt.Fprintf(w, "-------\t----\t----\t----\n")
-	} else {
-		_, _ = fmt.Fprintf(w, "ID\tNAME\tSIZE\tTAGS\n")
-		_, _ = fmt.Fprintf(w, "---\t----\t----\t----\n")
-	}
-
-	for _, f := range result.Files {
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tSIZE\tDELETED\tPURGED")
+	_, _ = fmt.Fprintln(w, "---\t----\t----\t-------\t------")
+	for _, f := range parsed.Files {
 		id := f.ID
-		if !*wideOut && len(id) > 8 {
+		if !wideOut && len(id) > 8 {
 			id = id[:8] + "..."
 		}
-		tags := strings.Join(f.Tags, ", ")
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, f.FileName, humanize.Bytes(uint64(f.Size)), tags)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, f.FileName, humanize.Bytes(uint64(f.Size)),
+			humanize.Time(f.DeletedAt), humanize.Time(f.PurgeAt))
 	}
 	_ = w.Flush()
 
-	fmt.Printf("\nFound %d file(s)\n", result.Count)
+	fmt.Printf("\n%d file(s), %s. Restore with 'fl restore <id>'.\n", len(parsed.Files), humanize.Bytes(uint64(parsed.TotalSize)))
 	return nil
 }
 
-func cmdExport(args []string) error {
-	fs := flag.NewFlagSet("export", flag.ContinueOnError)
-	output := fs.String("o", "filelocker-export.zip", "output filename")
-
-	if err := ParseInterspersed(fs, args); err != nil {
-		return fmt.Errorf("failed to parse flags: %w", err)
-	}
-
+func cmdTrashEmpty(yes bool) error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("GET", "/files/export", token, nil, "")
+	files, err := apiClient(token).Trash(context.Background())
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
+	if len(files) == 0 {
+		fmt.Println("Trash is empty.")
+		return nil
+	}
 
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("export failed (status %d): %s", resp.StatusCode, string(b))
+	if !yes {
+		var size int64
+		for _, f := range files {
+			size += f.Size
+		}
+		fmt.Printf("Permanently delete %d file(s) (%s) from the trash? This cannot be undone. [y/N]: ",
+			len(files), humanize.Bytes(uint64(size)))
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
 	}
 
-	f, err := os.Create(*output)
+	resp, err := doRequest("DELETE", "/files/trash", token, nil, "")
 	if err != nil {
 		return err
 	}
-	defer func() { _ = f.Close() }()
-
-	total := resp.ContentLength
-	bar := progressbar.NewOptions64(
-		total,
-		progressbar.OptionSetDescription("Exporting"),
-		progressbar.OptionSetWriter(os.Stderr),
-		progressbar.OptionShowBytes(true),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionOnCompletion(func() { fmt.Fprint(os.Stderr, "\n") }),
-	)
+	defer func() { _ = resp.Body.Close() }()
 
-	_, err = io.Copy(io.MultiWriter(f, bar), resp.Body)
-	if err != nil {
-		return err
+	var result struct {
+		Deleted    int    `json:"deleted"`
+		FreedBytes int64  `json:"freed_bytes"`
+		Error      string `json:"error"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("empty trash failed (status %d): %s (%d file(s) deleted)", resp.StatusCode, result.Error, result.Deleted)
 	}
 
-	fmt.Printf("Exported to: %s\n", *output)
+	fmt.Printf("Deleted %d file(s), freed %s\n", result.Deleted, humanize.Bytes(uint64(result.FreedBytes)))
 	return nil
 }
 
-func cmdUpdate(args []string) error {
-	fs := flag.NewFlagSet("update", flag.ContinueOnError)
-	tags := fs.String("tags", "", "comma separated tags")
-	name := fs.String("name", "", "new filename")
-
+func cmdRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
 	if err := ParseInterspersed(fs, args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-	remainingArgs := fs.Args()
-
-	if len(remainingArgs) < 1 {
-		return errors.New("file id required")
+	if fs.NArg() < 1 {
+		return errors.New("file name or id required")
 	}
-	id := remainingArgs[0]
-
-	if *tags == "" && *name == "" {
-		return errors.New("either --tags or --name required")
+	if err := requireFeature("trash", "fl restore"); err != nil {
+		return err
 	}
-
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	payload := make(map[string]interface{})
-	if *tags != "" {
-		payload["tags"] = strings.Split(*tags, ",")
-	}
-	if *name != "" {
-		payload["file_name"] = *name
+	id, err := resolveFileIn(token, fs.Arg(0), (*client.Client).Trash)
+	if err != nil {
+		return err
 	}
 
-	body, _ := json.Marshal(payload)
-	resp, err := doRequest("PATCH", "/files/"+id, token, strings.NewReader(string(body)), "application/json")
+	resp, err := doRequest("POST", "/files/"+id+"/restore", token, nil, "")
 	if err != nil {
 		return err
 	}
@@ -798,202 +1223,303 @@ func cmdUpdate(args []string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("update failed (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("restore failed (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ File updated successfully")
+	var restored client.File
+	_ = json.NewDecoder(resp.Body).Decode(&restored)
+	fmt.Printf("Restored: %s (ID: %s)\n", restored.FileName, id)
 	return nil
 }
 
-func cmdTokens(args []string) error {
-	if len(args) < 1 {
-		return errors.New("subcommand required: list, create, revoke")
-	}
-
-	subcmd := args[0]
-	switch subcmd {
-	case "list":
-		fs := flag.NewFlagSet("token_list", flag.ContinueOnError)
-		jsonOut := fs.Bool("json", false, "output json")
-		wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
-		fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
-		_ = fs.Parse(args[1:])
-		return cmdTokensList(*jsonOut, *wideOut)
-	case "create":
-		return cmdTokensCreate(args[1:])
-	case "revoke":
-		return cmdTokensRevoke(args[1:])
-	default:
-		return fmt.Errorf("unknown subcommand: %s", subcmd)
-	}
-}
-
-func cmdTokensList(jsonOut bool, wideOut bool) error {
+func cmdLogout() error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("GET", "/auth/tokens", token, nil, "")
-	if err != nil {
+	if err := apiClient(token).Logout(context.Background()); err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to list tokens (status %d)", resp.StatusCode)
+	// Clear config
+	cfg := CLIConfig{BaseURL: getBaseURL()}
+	if err := saveConfig(cfg); err != nil {
+		return err
 	}
 
-	var result struct {
-		Tokens []struct {
-			ID        string     `json:"id"`
-			Name      string     `json:"name"`
-			CreatedAt time.Time  `json:"created_at"`
-			ExpiresAt *time.Time `json:"expires_at"`
-			LastUsed  *time.Time `json:"last_used_at"`
-		} `json:"tokens"`
-	}
+	fmt.Println("✅ Logged out successfully")
+	return nil
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+func cmdMe() error {
+	token, err := loadToken()
+	if err != nil {
 		return err
 	}
 
-	if len(result.Tokens) == 0 {
-		fmt.Println("No tokens found.")
-		return nil
+	user, err := apiClient(token).Me(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get user info: %w", err)
 	}
 
-	if jsonOut {
-		b, _ := json.Marshal(result)
-		fmt.Println(string(b))
-		return nil
+	fmt.Printf("User ID:      %s\n", user.ID)
+	fmt.Printf("Username:     %s\n", user.Username)
+	fmt.Printf("Email:        %s\n", user.Email)
+	fmt.Printf("Role:         %s\n", user.Role)
+	fmt.Printf("Member Since: %s\n", user.CreatedAt.Format("2006-01-02"))
+	if q := user.Quota; q != nil {
+		fmt.Printf("Storage:      %s of %s\n", humanize.Bytes(uint64(q.UsedBytes)), humanize.Bytes(uint64(q.QuotaBytes)))
+		switch {
+		case q.State == client.QuotaBlocked:
+			fmt.Println("⚠️  Over your storage quota: uploads are refused until you free up space")
+		case q.State == client.QuotaGrace && q.GraceEndsAt != nil:
+			fmt.Printf("⚠️  Over your storage quota: uploads are refused from %s unless you free up space\n", q.GraceEndsAt.Local().Format("2006-01-02 15:04"))
+		case q.State == client.QuotaGrace:
+			fmt.Println("⚠️  Over your storage quota: free up space")
+		}
+	}
+	if t, err := apiClient(token).Transfer(context.Background()); err == nil {
+		if t.CapBytes > 0 {
+			fmt.Printf("Transfer:     %s of %s this month\n", humanize.Bytes(uint64(t.Bytes)), humanize.Bytes(uint64(t.CapBytes)))
+			if t.Bytes >= t.CapBytes {
+				fmt.Printf("⚠️  Monthly transfer limit reached: downloads resume on %s\n", t.ResetsAt.Local().Format("2006-01-02"))
+			}
+		} else {
+			fmt.Printf("Transfer:     %s this month\n", humanize.Bytes(uint64(t.Bytes)))
+		}
 	}
+	return nil
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	if wideOut {
-		_, _ = fmt.Fprintf(w, "TOKEN ID\tNAME\tCREATED\tEXPIRES\tLAST USED\n")
-		_, _ = fmt.Fprintf(w, "--------\t----\t-------\t-------\t---------\n")
-	} else {
-		_, _ = fmt.Fprintf(w, "ID\tNAME\tCREATED\tEXPIRES\tLAST USED\n")
-		_, _ = fmt.Fprintf(w, "---\t----\t-------\t-------\t---------\n")
+// cmdInfo shows the metadata of one file
+func cmdInfo(args []string) error {
+	fs := flag.NewFlagSet("info", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "output json")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if fs.NArg() < 1 {
+		return errors.New("file name or id required")
 	}
 
-	for _, t := range result.Tokens {
-		id := t.ID
-		if !wideOut && len(id) > 8 {
-			id = id[:8] + "..."
-		}
-		created := humanize.Time(t.CreatedAt)
-		expires := "Never"
-		if t.ExpiresAt != nil {
-			expires = humanize.Time(*t.ExpiresAt)
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+	id, err := resolveFile(token, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	f, err := apiClient(token).GetFile(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("failed to get file: %w", err)
+	}
+
+	if *jsonOut {
+		b, _ := json.MarshalIndent(f, "", "  ")
+		fmt.Println(string(b))
+		return nil
+	}
+	fmt.Printf("ID:          %s\n", f.FileID)
+	fmt.Printf("Name:        %s\n", f.FileName)
+	fmt.Printf("Folder:      %s\n", f.Folder)
+	fmt.Printf("Size:        %s (%d bytes)\n", humanize.Bytes(uint64(f.Size)), f.Size)
+	fmt.Printf("Type:        %s\n", f.MimeType)
+	if f.SHA256 != "" {
+		fmt.Printf("SHA-256:     %s\n", f.SHA256)
+	}
+	if f.Description != "" {
+		fmt.Printf("Description: %s\n", f.Description)
+	}
+	if len(f.Tags) > 0 {
+		fmt.Printf("Tags:        %s\n", strings.Join(f.Tags, ", "))
+	}
+	fmt.Printf("Uploaded:    %s\n", f.CreatedAt.Local().Format("2006-01-02 15:04"))
+	if f.ModifiedAt != nil {
+		fmt.Printf("Modified:    %s\n", f.ModifiedAt.Local().Format("2006-01-02 15:04"))
+	}
+	if f.ExpiresAt != nil {
+		fmt.Printf("Expires:     %s (%s)\n", f.ExpiresAt.Local().Format("2006-01-02 15:04"), humanize.Time(*f.ExpiresAt))
+	}
+	stats := f.DownloadStats
+	fmt.Printf("Downloads:   %d (%s, %d via share links, %d downloaders)\n",
+		stats.Downloads, humanize.Bytes(uint64(stats.Bytes)), stats.ViaShares, stats.Downloaders)
+	if f.PasswordProtected {
+		fmt.Println("🔒 Password protected")
+	}
+	if f.Quarantined {
+		fmt.Println("⚠️  Held back for review by an admin")
+	}
+
+	if len(f.Shares) > 0 {
+		fmt.Printf("\nShare links (%d):\n", len(f.Shares))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		for _, s := range f.Shares {
+			state := "active"
+			switch {
+			case s.DisabledAt != nil:
+				state = "disabled (" + s.DisabledReason + ")"
+			case s.ExpiresAt != nil && s.ExpiresAt.Before(time.Now()):
+				state = "expired"
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%d requests\t%s\n", s.ID[:8], state, s.RequestCount, humanize.Bytes(uint64(s.BytesServed)))
 		}
-		lastUsed := "Never"
-		if t.LastUsed != nil {
-			lastUsed = humanize.Time(*t.LastUsed)
+		_ = w.Flush()
+	}
+
+	if len(f.RecentDownloads) > 0 {
+		fmt.Println("\nRecent downloads:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		for _, d := range f.RecentDownloads {
+			via := "account"
+			if d.ShareID != "" {
+				via = "share " + d.ShareID[:8]
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", d.At.Local().Format("2006-01-02 15:04"), via, d.IPAddress, humanize.Bytes(uint64(d.Bytes)))
 		}
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, t.Name, created, expires, lastUsed)
+		_ = w.Flush()
 	}
-	_ = w.Flush()
 	return nil
 }
 
-func cmdTokensCreate(args []string) error {
-	fs := flag.NewFlagSet("create", flag.ContinueOnError)
-	expire := fs.String("expire", "", "expiration date (YYYY-MM-DD)")
+func cmdSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "output json")
+	wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
+	fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+	cursor := fs.String("cursor", "", "continue a paged search")
 
 	if err := ParseInterspersed(fs, args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
 
 	remainingArgs := fs.Args()
-
 	if len(remainingArgs) < 1 {
-		return errors.New("token name required")
+		return errors.New("search query required")
 	}
-	name := remainingArgs[0]
+	query := remainingArgs[0]
 
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	payload := map[string]interface{}{
-		"name": name,
-	}
-	if *expire != "" {
-		days, err := strconv.Atoi(*expire)
-		if err != nil {
-			return fmt.Errorf("invalid expiration days: %w", err)
-		}
-		payload["expires_in_days"] = days
+	page, err := apiClient(token).SearchFiles(context.Background(), query, client.ListOptions{Cursor: *cursor})
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
 	}
 
-	body, _ := json.Marshal(payload)
-	resp, err := doRequest("POST", "/auth/tokens", token, strings.NewReader(string(body)), "application/json")
-	if err != nil {
-		return err
+	if len(page.Files) == 0 {
+		fmt.Println("No files found matching query.")
+		return nil
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create token (status %d): %s", resp.StatusCode, string(b))
+	if *jsonOut {
+		response := map[string]interface{}{"files": page.Files, "count": len(page.Files)}
+		if page.NextCursor != "" {
+			response["next_cursor"] = page.NextCursor
+		}
+		b, _ := json.Marshal(response)
+		fmt.Println(string(b))
+		return nil
 	}
 
-	var result struct {
-		Token string `json:"token"`
-		Name  string `json:"name"`
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if *wideOut {
+		_, _ = fmt.Fprintf(w, "FILE ID\tNAME\tSIZE\tTAGS\n")
+		_, _ = fmt.Fprintf(w, "-------\t----\t----\t----\n")
+	} else {
+		_, _ = fmt.Fprintf(w, "ID\tNAME\tSIZE\tTAGS\n")
+		_, _ = fmt.Fprintf(w, "---\t----\t----\t----\n")
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
+	for _, f := range page.Files {
+		id := f.FileID
+		if !*wideOut && len(id) > 8 {
+			id = id[:8] + "..."
+		}
+		tags := strings.Join(f.Tags, ", ")
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, f.FileName, humanize.Bytes(uint64(f.Size)), tags)
 	}
+	_ = w.Flush()
 
-	fmt.Println("✅ Token created successfully!")
-	fmt.Printf("Name:  %s\n", result.Name)
-	fmt.Printf("Token: %s\n\n", result.Token)
-	fmt.Println("⚠️  Save this token now - you won't be able to see it again!")
+	fmt.Printf("\nFound %d file(s)\n", len(page.Files))
+	if page.NextCursor != "" {
+		fmt.Printf("More results: fl search %q --cursor %s\n", query, page.NextCursor)
+	}
 	return nil
 }
 
-func cmdTokensRevoke(args []string) error {
-	if len(args) < 1 {
-		return errors.New("token id required")
+func cmdExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := fs.String("o", "filelocker-export.zip", "output filename")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-	id := args[0]
 
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("DELETE", "/auth/tokens/"+id, token, nil, "")
+	resp, err := doRequest("GET", "/files/export", token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 204 {
+	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to revoke token (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("export failed (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ Token revoked successfully")
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	total := resp.ContentLength
+	bar := progressbar.NewOptions64(
+		total,
+		progressbar.OptionSetDescription("Exporting"),
+		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionShowBytes(true),
+		progressbar.OptionSetWidth(40),
+		progressbar.OptionOnCompletion(func() { fmt.Fprint(os.Stderr, "\n") }),
+	)
+
+	_, err = io.Copy(io.MultiWriter(f, bar), resp.Body)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported to: %s\n", *output)
+	if n, _ := strconv.Atoi(resp.Header.Get("X-Export-Skipped")); n > 0 {
+		fmt.Printf("⚠️  %d password-protected file(s) not exported (listed in README.txt); download them one by one with their password\n", n)
+	}
 	return nil
 }
 
-func cmdPassword(args []string) error {
-	fs := flag.NewFlagSet("password", flag.ContinueOnError)
-	old := fs.String("old", "", "old password")
-	new := fs.String("new", "", "new password")
+func cmdUpdate(args []string) error {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	tags := fs.String("tags", "", "comma separated tags")
+	name := fs.String("name", "", "new filename")
 
 	if err := ParseInterspersed(fs, args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
+	remainingArgs := fs.Args()
 
-	if *old == "" || *new == "" {
-		return errors.New("both --old and --new passwords required")
+	if len(remainingArgs) < 1 {
+		return errors.New("file id required")
+	}
+	id := remainingArgs[0]
+
+	if *tags == "" && *name == "" {
+		return errors.New("either --tags or --name required")
 	}
 
 	token, err := loadToken()
@@ -1001,13 +1527,16 @@ func cmdPassword(args []string) error {
 		return err
 	}
 
-	payload := map[string]string{
-		"old_password": *old,
-		"new_password": *new,
+	payload := make(map[string]interface{})
+	if *tags != "" {
+		payload["tags"] = strings.Split(*tags, ",")
+	}
+	if *name != "" {
+		payload["file_name"] = *name
 	}
 
 	body, _ := json.Marshal(payload)
-	resp, err := doRequest("PATCH", "/user/password", token, strings.NewReader(string(body)), "application/json")
+	resp, err := doRequest("PATCH", "/files/"+id, token, strings.NewReader(string(body)), "application/json")
 	if err != nil {
 		return err
 	}
@@ -1015,92 +1544,82 @@ func cmdPassword(args []string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to change password (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("update failed (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ Password changed successfully")
+	fmt.Println("✅ File updated successfully")
 	return nil
 }
 
-func cmdAnnouncements(args []string) error {
-	if len(args) == 0 {
-		return cmdAnnouncementsList()
+// cmdCopy copies (or, with move set, moves) a file on the server
+func cmdCopy(args []string, move bool) error {
+	op := "cp"
+	if move {
+		op = "mv"
 	}
-
-	subcmd := args[0]
-	if subcmd == "dismiss" {
-		return cmdAnnouncementsDismiss(args[1:])
+	fs := flag.NewFlagSet(op, flag.ContinueOnError)
+	name := fs.String("name", "", "new filename")
+	folder := fs.String("folder", "", "target folder, e.g. /archive")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if fs.NArg() < 1 {
+		return fmt.Errorf("usage: %s <name|id> [--folder /path] [--name newname]", op)
+	}
+	if move && *name == "" && *folder == "" {
+		return errors.New("either --folder or --name required")
 	}
 
-	return fmt.Errorf("unknown subcommand: %s", subcmd)
-}
-
-func cmdAnnouncementsList() error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
-
-	resp, err := doRequest("GET", "/announcements", token, nil, "")
+	id, err := resolveFile(token, fs.Arg(0))
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to list announcements (status %d)", resp.StatusCode)
-	}
-
-	var result struct {
-		Announcements []struct {
-			ID       string `json:"id"`
-			Title    string `json:"title"`
-			Message  string `json:"message"`
-			Severity string `json:"type"`
-		} `json:"announcements"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
-	}
 
-	if len(result.Announcements) == 0 {
-		fmt.Println("No announcements.")
+	api := apiClient(token)
+	if move {
+		file, err := api.MoveFile(context.Background(), id, *name, *folder)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Moved to %s\n", path.Join(file.Folder, file.FileName))
 		return nil
 	}
-
-	for i, a := range result.Announcements {
-		var emoji string
-		switch a.Severity {
-		case "warning":
-			emoji = "⚠️"
-		case "critical":
-			emoji = "❌"
-		default:
-			emoji = "ℹ️"
-		}
-		fmt.Printf("%s [%s] %s\n", emoji, a.Severity, a.Title)
-		fmt.Printf("   Message:%s\n", a.Message)
-		fmt.Printf("        ID: %s\n", a.ID)
-		if i < len(result.Announcements)-1 {
-			fmt.Println()
-		}
+	file, err := api.CopyFile(context.Background(), id, *name, *folder)
+	if err != nil {
+		return err
 	}
+	fmt.Printf("Copied to %s (ID: %s)\n", path.Join(file.Folder, file.FileName), file.FileID[:8]+"...")
 	return nil
 }
 
-func cmdAnnouncementsDismiss(args []string) error {
-	if len(args) < 1 {
-		return errors.New("announcement id required")
+// cmdTag adds or removes tags on several files at once: fl tag add work id1 id2
+func cmdTag(args []string) error {
+	if len(args) < 3 {
+		return errors.New("usage: tag <add|rm> <tag[,tag2]> <file_id>...")
+	}
+
+	tags := strings.Split(args[1], ",")
+	payload := map[string]interface{}{"file_ids": args[2:]}
+	switch args[0] {
+	case "add":
+		payload["add_tags"] = tags
+	case "rm", "remove":
+		payload["remove_tags"] = tags
+	default:
+		return fmt.Errorf("unknown subcommand: %s", args[0])
 	}
-	id := args[0]
 
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("POST", "/announcements/"+id+"/dismiss", token, nil, "")
+	body, _ := json.Marshal(payload)
+	resp, err := doRequest("POST", "/files/batch/update", token, strings.NewReader(string(body)), "application/json")
 	if err != nil {
 		return err
 	}
@@ -1108,195 +1627,168 @@ func cmdAnnouncementsDismiss(args []string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to dismiss announcement (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("tag update failed (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ Announcement dismissed")
-	return nil
-}
-
-func cmdAdmin(args []string) error {
-	if len(args) < 1 {
-		printAdminHelp()
-		return nil
-	}
-
-	subcmd := args[0]
-	if subcmd == "help" {
-		printAdminHelp()
-		return nil
-	}
-
-	switch subcmd {
-	case "stats":
-		return cmdAdminStats()
-	case "users":
-		return cmdAdminUsers(args[1:])
-	case "settings":
-		return cmdAdminSettings(args[1:])
-	case "files":
-		return cmdAdminFiles(args[1:])
-	case "storage":
-		return cmdAdminStorage(args[1:])
-	case "logs":
-		return cmdAdminLogs(args[1:])
-	case "announcements":
-		return cmdAdminAnnouncements(args[1:])
-	default:
-		return fmt.Errorf("unknown admin subcommand: %s", subcmd)
-	}
-}
-
-func cmdAdminStats() error {
-	token, err := loadToken()
-	if err != nil {
-		return err
-	}
-
-	resp, err := doRequest("GET", "/admin/stats", token, nil, "")
-	if err != nil {
-		return err
+	var result struct {
+		Results []struct {
+			FileID string `json:"file_id"`
+			OK     bool   `json:"ok"`
+			Error  string `json:"error"`
+		} `json:"results"`
+		Updated int `json:"updated"`
+		Failed  int `json:"failed"`
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to get stats (status %d)", resp.StatusCode)
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	var stats struct {
-		TotalUsers        int   `json:"total_users"`
-		TotalFiles        int   `json:"total_files"`
-		TotalStorageBytes int64 `json:"total_storage_bytes"`
-		ActiveSessions    int   `json:"active_sessions"`
+	for _, r := range result.Results {
+		if r.OK {
+			fmt.Printf("✅ %s\n", r.FileID)
+		} else {
+			fmt.Printf("❌ %s: %s\n", r.FileID, r.Error)
+		}
 	}
+	fmt.Printf("\n%d updated, %d failed\n", result.Updated, result.Failed)
 
-	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
-		return err
+	if result.Failed > 0 {
+		return errors.New("some files were not updated")
 	}
-
-	fmt.Println("📊 System Statistics")
-	fmt.Printf("Total Users:     %d\n", stats.TotalUsers)
-	fmt.Printf("Total Files:     %d\n", stats.TotalFiles)
-	fmt.Printf("Storage Used:    %s\n", humanize.Bytes(uint64(stats.TotalStorageBytes)))
-	fmt.Printf("Active Sessions: %d\n", stats.ActiveSessions)
 	return nil
 }
 
-func cmdAdminUsers(args []string) error {
-	if len(args) == 0 {
-		return cmdAdminUsersList(args)
+func cmdTokens(args []string) error {
+	if len(args) < 1 {
+		return errors.New("subcommand required: list, create, revoke")
 	}
 
 	subcmd := args[0]
 	switch subcmd {
-	case "approve":
-		return cmdAdminUsersApprove(args[1:])
-	case "reject":
-		return cmdAdminUsersReject(args[1:])
-	case "delete":
-		return cmdAdminUsersDelete(args[1:])
+	case "list":
+		fs := flag.NewFlagSet("token_list", flag.ContinueOnError)
+		jsonOut := fs.Bool("json", false, "output json")
+		wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
+		fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+		_ = fs.Parse(args[1:])
+		return cmdTokensList(*jsonOut, *wideOut)
+	case "create":
+		return cmdTokensCreate(args[1:])
+	case "revoke":
+		return cmdTokensRevoke(args[1:])
 	default:
-		// Check if it's a user ID for status/role/reset-password/logout
-		if len(args) >= 2 {
-			userID := args[0]
-			action := args[1]
-			switch action {
-			case "status":
-				return cmdAdminUsersStatus(userID, args[2:])
-			case "role":
-				return cmdAdminUsersRole(userID, args[2:])
-			case "reset-password":
-				return cmdAdminUsersResetPassword(userID)
-			case "logout":
-				return cmdAdminUsersLogout(userID)
-			}
-		}
-		return cmdAdminUsersList(args)
+		return fmt.Errorf("unknown subcommand: %s", subcmd)
 	}
 }
 
-func cmdAdminUsersList(args []string) error {
-	fs := flag.NewFlagSet("users", flag.ContinueOnError)
-	status := fs.String("status", "", "filter by status: active, inactive, pending")
-	jsonOut := fs.Bool("json", false, "output json")
-	wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
-	fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
-
-	if err := ParseInterspersed(fs, args); err != nil {
-		return fmt.Errorf("failed to parse flags: %w", err)
-	}
-
+func cmdTokensList(jsonOut bool, wideOut bool) error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	path := "/admin/users"
-	if *status != "" {
-		path += "?status=" + *status
-	}
-
-	resp, err := doRequest("GET", path, token, nil, "")
+	resp, err := doRequest("GET", "/auth/tokens", token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to list users (status %d)", resp.StatusCode)
+		return fmt.Errorf("failed to list tokens (status %d)", resp.StatusCode)
 	}
 
 	var result struct {
-		Users []struct {
-			ID       string `json:"id"`
-			Username string `json:"username"`
-			Email    string `json:"email"`
-			Role     string `json:"role"`
-		} `json:"users"`
+		Tokens []struct {
+			ID        string     `json:"id"`
+			Name      string     `json:"name"`
+			CreatedAt time.Time  `json:"created_at"`
+			ExpiresAt *time.Time `json:"expires_at"`
+			LastUsed  *time.Time `json:"last_used_at"`
+			Scopes    []string   `json:"scopes"`
+		} `json:"tokens"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return err
 	}
 
-	if *jsonOut {
+	if len(result.Tokens) == 0 {
+		fmt.Println("No tokens found.")
+		return nil
+	}
+
+	if jsonOut {
 		b, _ := json.Marshal(result)
 		fmt.Println(string(b))
 		return nil
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	if *wideOut {
-		_, _ = fmt.Fprintf(w, "USER ID\tUSERNAME\tEMAIL\tROLE\n")
-		_, _ = fmt.Fprintf(w, "-------\t--------\t-----\t----\n")
+	if wideOut {
+		_, _ = fmt.Fprintf(w, "TOKEN ID\tNAME\tSCOPES\tCREATED\tEXPIRES\tLAST USED\n")
+		_, _ = fmt.Fprintf(w, "--------\t----\t------\t-------\t-------\t---------\n")
 	} else {
-		_, _ = fmt.Fprintf(w, "ID\tUSERNAME\tEMAIL\tROLE\n")
-		_, _ = fmt.Fprintf(w, "---\t--------\t-----\t----\n")
+		_, _ = fmt.Fprintf(w, "ID\tNAME\tSCOPES\tCREATED\tEXPIRES\tLAST USED\n")
+		_, _ = fmt.Fprintf(w, "---\t----\t------\t-------\t-------\t---------\n")
 	}
 
-	for _, u := range result.Users {
-		id := u.ID
-		if !*wideOut && len(id) > 8 {
+	for _, t := range result.Tokens {
+		id := t.ID
+		if !wideOut && len(id) > 8 {
 			id = id[:8] + "..."
 		}
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, u.Username, u.Email, u.Role)
+		created := humanize.Time(t.CreatedAt)
+		expires := "Never"
+		if t.ExpiresAt != nil {
+			expires = humanize.Time(*t.ExpiresAt)
+		}
+		lastUsed := "Never"
+		if t.LastUsed != nil {
+			lastUsed = humanize.Time(*t.LastUsed)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", id, t.Name, strings.Join(t.Scopes, ","), created, expires, lastUsed)
 	}
 	_ = w.Flush()
 	return nil
 }
 
-func cmdAdminUsersApprove(args []string) error {
-	if len(args) < 1 {
-		return errors.New("user id required")
+func cmdTokensCreate(args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	expire := fs.String("expire", "", "expiration date (YYYY-MM-DD)")
+	scopes := fs.String("scopes", "", "comma-separated scopes (files:read,files:write,files:delete,admin; default: all files scopes)")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-	id := args[0]
+
+	remainingArgs := fs.Args()
+
+	if len(remainingArgs) < 1 {
+		return errors.New("token name required")
+	}
+	name := remainingArgs[0]
 
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("POST", "/admin/users/"+id+"/approve", token, nil, "")
+	payload := map[string]interface{}{
+		"name": name,
+	}
+	if *expire != "" {
+		days, err := strconv.Atoi(*expire)
+		if err != nil {
+			return fmt.Errorf("invalid expiration days: %w", err)
+		}
+		payload["expires_in_days"] = days
+	}
+	if *scopes != "" {
+		payload["scopes"] = strings.Split(*scopes, ",")
+	}
+
+	body, _ := json.Marshal(payload)
+	resp, err := doRequest("POST", "/auth/tokens", token, strings.NewReader(string(body)), "application/json")
 	if err != nil {
 		return err
 	}
@@ -1304,16 +1796,30 @@ func cmdAdminUsersApprove(args []string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to approve user (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("failed to create token (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ User approved")
+	var result struct {
+		Token  string   `json:"token"`
+		Name   string   `json:"name"`
+		Scopes []string `json:"scopes"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Token created successfully!")
+	fmt.Printf("Name:   %s\n", result.Name)
+	fmt.Printf("Scopes: %s\n", strings.Join(result.Scopes, ", "))
+	fmt.Printf("Token:  %s\n\n", result.Token)
+	fmt.Println("⚠️  Save this token now - you won't be able to see it again!")
 	return nil
 }
 
-func cmdAdminUsersReject(args []string) error {
+func cmdTokensRevoke(args []string) error {
 	if len(args) < 1 {
-		return errors.New("user id required")
+		return errors.New("token id required")
 	}
 	id := args[0]
 
@@ -1322,33 +1828,46 @@ func cmdAdminUsersReject(args []string) error {
 		return err
 	}
 
-	resp, err := doRequest("POST", "/admin/users/"+id+"/reject", token, nil, "")
+	resp, err := doRequest("DELETE", "/auth/tokens/"+id, token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
+	if resp.StatusCode != 204 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to reject user (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("failed to revoke token (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ User rejected")
+	fmt.Println("✅ Token revoked successfully")
 	return nil
 }
 
-func cmdAdminUsersDelete(args []string) error {
-	if len(args) < 1 {
-		return errors.New("user id required")
+func cmdPassword(args []string) error {
+	fs := flag.NewFlagSet("password", flag.ContinueOnError)
+	old := fs.String("old", "", "old password")
+	new := fs.String("new", "", "new password")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	if *old == "" || *new == "" {
+		return errors.New("both --old and --new passwords required")
 	}
-	id := args[0]
 
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("DELETE", "/admin/users/"+id, token, nil, "")
+	payload := map[string]string{
+		"old_password": *old,
+		"new_password": *new,
+	}
+
+	body, _ := json.Marshal(payload)
+	resp, err := doRequest("PATCH", "/user/password", token, strings.NewReader(string(body)), "application/json")
 	if err != nil {
 		return err
 	}
@@ -1356,78 +1875,107 @@ func cmdAdminUsersDelete(args []string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete user (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("failed to change password (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ User deleted")
+	fmt.Println("✅ Password changed successfully")
 	return nil
 }
 
-func cmdAdminUsersStatus(userID string, args []string) error {
-	if len(args) < 1 {
-		return errors.New("status required: active or inactive")
+// profileResult is the body of the profile endpoints
+type profileResult struct {
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	DisplayName  string `json:"display_name"`
+	PendingEmail string `json:"pending_email"`
+	AvatarURL    string `json:"avatar_url"`
+}
+
+func printProfile(p profileResult) {
+	fmt.Printf("Username:      %s\n", p.Username)
+	fmt.Printf("Display name:  %s\n", p.DisplayName)
+	fmt.Printf("Email:         %s\n", p.Email)
+	if p.PendingEmail != "" {
+		fmt.Printf("Pending email: %s (run 'fl profile verify-email <code>' with the code sent to it)\n", p.PendingEmail)
 	}
-	status := args[0]
+	if p.AvatarURL != "" {
+		fmt.Printf("Avatar:        %s\n", p.AvatarURL)
+	}
+}
 
-	token, err := loadToken()
-	if err != nil {
-		return err
+func cmdProfile(args []string) error {
+	if len(args) > 0 && args[0] == "verify-email" {
+		if len(args) < 2 {
+			return errors.New("verification code required")
+		}
+		return sendProfile("POST", "/user/profile/verify-email", map[string]interface{}{"code": args[1]})
+	}
+	if len(args) > 0 && args[0] == "avatar" {
+		return cmdProfileAvatar(args[1:])
 	}
 
-	payload := map[string]string{"status": status}
-	body, _ := json.Marshal(payload)
-
-	resp, err := doRequest("PATCH", "/admin/users/"+userID+"/status", token, strings.NewReader(string(body)), "application/json")
-	if err != nil {
-		return err
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	name := fs.String("name", "", "display name (--name \"\" removes it)")
+	email := fs.String("email", "", "new email address (confirmed with a code sent to it)")
+	password := fs.String("password", "", "current password (required with --email)")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update status (status %d): %s", resp.StatusCode, string(b))
+	payload := map[string]interface{}{}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "name" {
+			payload["display_name"] = *name
+		}
+	})
+	if *email != "" {
+		if *password == "" {
+			return errors.New("--password is required to change the email address")
+		}
+		payload["email"] = *email
+		payload["current_password"] = *password
 	}
-
-	fmt.Println("✅ User status updated")
-	return nil
+	if len(payload) == 0 {
+		return cmdMe()
+	}
+	return sendProfile("PATCH", "/user/profile", payload)
 }
 
-func cmdAdminUsersRole(userID string, args []string) error {
-	if len(args) < 1 {
-		return errors.New("role required: user or admin")
+// cmdProfileAvatar uploads an image as the avatar (the server crops and resizes
+// it), or removes the avatar with --remove
+func cmdProfileAvatar(args []string) error {
+	fs := flag.NewFlagSet("profile avatar", flag.ContinueOnError)
+	remove := fs.Bool("remove", false, "remove the avatar")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-	role := args[0]
-
-	token, err := loadToken()
-	if err != nil {
-		return err
+	if *remove {
+		return sendProfileRequest("DELETE", "/user/avatar", nil, "")
 	}
-
-	payload := map[string]string{"role": role}
-	body, _ := json.Marshal(payload)
-
-	resp, err := doRequest("PATCH", "/admin/users/"+userID+"/role", token, strings.NewReader(string(body)), "application/json")
-	if err != nil {
-		return err
+	if fs.NArg() < 1 {
+		return errors.New("image file required (JPEG, PNG or GIF, at most 2 MB), or --remove")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update role (status %d): %s", resp.StatusCode, string(b))
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
 	}
+	return sendProfileRequest("PUT", "/user/avatar", strings.NewReader(string(data)), http.DetectContentType(data))
+}
 
-	fmt.Println("✅ User role updated")
-	return nil
+// sendProfile sends a profile change and prints the resulting profile
+func sendProfile(method, path string, payload map[string]interface{}) error {
+	body, _ := json.Marshal(payload)
+	return sendProfileRequest(method, path, strings.NewReader(string(body)), "application/json")
 }
 
-func cmdAdminUsersResetPassword(userID string) error {
+func sendProfileRequest(method, path string, body io.Reader, contentType string) error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("POST", "/admin/users/"+userID+"/reset-password", token, nil, "")
+	resp, err := doRequest(method, path, token, body, contentType)
 	if err != nil {
 		return err
 	}
@@ -1435,30 +1983,75 @@ func cmdAdminUsersResetPassword(userID string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to reset password (status %d): %s", resp.StatusCode, string(b))
-	}
-
-	var result struct {
-		Message           string `json:"message"`
-		TemporaryPassword string `json:"temporary_password"`
+		return fmt.Errorf("failed to update profile (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	var profile profileResult
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
 		return err
 	}
-
-	fmt.Println("✅ Password reset successfully")
-	fmt.Printf("Temporary Password: %s\n", result.TemporaryPassword)
+	fmt.Println("✅ Profile updated")
+	printProfile(profile)
 	return nil
 }
 
-func cmdAdminUsersLogout(userID string) error {
+type preferencesResult struct {
+	Timezone           string `json:"timezone"`
+	Locale             string `json:"locale"`
+	DefaultExpiryHours int    `json:"default_expiry_hours"`
+	PageSize           int    `json:"page_size"`
+	Notifications      struct {
+		ShareDisabled bool `json:"share_disabled"`
+		StorageQuota  bool `json:"storage_quota"`
+	} `json:"notifications"`
+}
+
+// cmdPreferences shows the preferences, or changes the ones given as flags
+func cmdPreferences(args []string) error {
+	fs := flag.NewFlagSet("preferences", flag.ContinueOnError)
+	timezone := fs.String("timezone", "", "IANA timezone, e.g. Europe/Berlin")
+	locale := fs.String("locale", "", "language tag, e.g. en-US")
+	expiry := fs.Int("expiry", 0, "default expiry of uploads in hours (0 = never)")
+	pageSize := fs.Int("page-size", 0, "files per page of listings (0 = all)")
+	shareNotices := fs.Bool("share-notices", true, "announce share links disabled automatically")
+	quotaEmails := fs.Bool("quota-emails", true, "email when nearing the storage quota")
+	jsonOut := fs.Bool("json", false, "output json")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	payload := map[string]interface{}{}
+	notifications := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "timezone":
+			payload["timezone"] = *timezone
+		case "locale":
+			payload["locale"] = *locale
+		case "expiry":
+			payload["default_expiry_hours"] = *expiry
+		case "page-size":
+			payload["page_size"] = *pageSize
+		case "share-notices":
+			notifications["share_disabled"] = *shareNotices
+		case "quota-emails":
+			notifications["storage_quota"] = *quotaEmails
+		}
+	})
+	if len(notifications) > 0 {
+		payload["notifications"] = notifications
+	}
+
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
-
-	resp, err := doRequest("POST", "/admin/users/"+userID+"/logout", token, nil, "")
+	method, body, contentType := "GET", io.Reader(nil), ""
+	if len(payload) > 0 {
+		b, _ := json.Marshal(payload)
+		method, body, contentType = "PATCH", strings.NewReader(string(b)), "application/json"
+	}
+	resp, err := doRequest(method, "/user/preferences", token, body, contentType)
 	if err != nil {
 		return err
 	}
@@ -1466,63 +2059,117 @@ func cmdAdminUsersLogout(userID string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to logout user (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("preferences request failed (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ User logged out")
+	var prefs preferencesResult
+	if err := json.NewDecoder(resp.Body).Decode(&prefs); err != nil {
+		return err
+	}
+	if *jsonOut {
+		b, _ := json.Marshal(prefs)
+		fmt.Println(string(b))
+		return nil
+	}
+
+	if method == "PATCH" {
+		fmt.Println("✅ Preferences updated")
+	}
+	expires, pages := "never", "all files"
+	if prefs.DefaultExpiryHours > 0 {
+		expires = fmt.Sprintf("after %d hours", prefs.DefaultExpiryHours)
+	}
+	if prefs.PageSize > 0 {
+		pages = fmt.Sprintf("%d files per page", prefs.PageSize)
+	}
+	fmt.Printf("Timezone:       %s\n", prefs.Timezone)
+	fmt.Printf("Locale:         %s\n", prefs.Locale)
+	fmt.Printf("Uploads expire: %s\n", expires)
+	fmt.Printf("Listings:       %s\n", pages)
+	fmt.Printf("Share notices:  %v\n", prefs.Notifications.ShareDisabled)
+	fmt.Printf("Quota emails:   %v\n", prefs.Notifications.StorageQuota)
 	return nil
 }
 
-func cmdAdminSettings(args []string) error {
+func cmdAnnouncements(args []string) error {
 	if len(args) == 0 {
-		return cmdAdminSettingsGet()
+		return cmdAnnouncementsList()
 	}
 
-	if len(args) >= 2 {
-		return cmdAdminSettingsUpdate(args[0], args[1])
+	subcmd := args[0]
+	if subcmd == "dismiss" {
+		return cmdAnnouncementsDismiss(args[1:])
 	}
 
-	return errors.New("usage: admin settings [key value]")
+	return fmt.Errorf("unknown subcommand: %s", subcmd)
 }
 
-func cmdAdminSettingsGet() error {
+func cmdAnnouncementsList() error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("GET", "/admin/settings", token, nil, "")
+	resp, err := doRequest("GET", "/announcements", token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to get settings (status %d)", resp.StatusCode)
+		return fmt.Errorf("failed to list announcements (status %d)", resp.StatusCode)
 	}
 
-	var settings map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+	var result struct {
+		Announcements []struct {
+			ID       string `json:"id"`
+			Title    string `json:"title"`
+			Message  string `json:"message"`
+			Severity string `json:"type"`
+		} `json:"announcements"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return err
 	}
 
-	fmt.Println("⚙️  System Settings:")
-	for key, value := range settings {
-		fmt.Printf("%s: %v\n", key, value)
+	if len(result.Announcements) == 0 {
+		fmt.Println("No announcements.")
+		return nil
+	}
+
+	for i, a := range result.Announcements {
+		var emoji string
+		switch a.Severity {
+		case "warning":
+			emoji = "⚠️"
+		case "critical":
+			emoji = "❌"
+		default:
+			emoji = "ℹ️"
+		}
+		fmt.Printf("%s [%s] %s\n", emoji, a.Severity, a.Title)
+		fmt.Printf("   Message:%s\n", a.Message)
+		fmt.Printf("        ID: %s\n", a.ID)
+		if i < len(result.Announcements)-1 {
+			fmt.Println()
+		}
 	}
 	return nil
 }
 
-func cmdAdminSettingsUpdate(key, value string) error {
+func cmdAnnouncementsDismiss(args []string) error {
+	if len(args) < 1 {
+		return errors.New("announcement id required")
+	}
+	id := args[0]
+
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	payload := map[string]string{"key": key, "value": value}
-	body, _ := json.Marshal(payload)
-
-	resp, err := doRequest("PATCH", "/admin/settings", token, strings.NewReader(string(body)), "application/json")
+	resp, err := doRequest("POST", "/announcements/"+id+"/dismiss", token, nil, "")
 	if err != nil {
 		return err
 	}
@@ -1530,260 +2177,334 @@ func cmdAdminSettingsUpdate(key, value string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update setting (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("failed to dismiss announcement (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ Setting updated")
+	fmt.Println("✅ Announcement dismissed")
 	return nil
 }
 
-func cmdAdminFiles(args []string) error {
-	fs := flag.NewFlagSet("files", flag.ContinueOnError)
-	jsonOut := fs.Bool("json", false, "output json")
-	wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
-	fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+func cmdAdmin(args []string) error {
+	if len(args) < 1 {
+		printAdminHelp()
+		return nil
+	}
 
-	if err := ParseInterspersed(fs, args); err != nil {
-		return fmt.Errorf("failed to parse flags: %w", err)
+	subcmd := args[0]
+	if subcmd == "help" {
+		printAdminHelp()
+		return nil
 	}
 
-	remainingArgs := fs.Args()
-	if len(remainingArgs) >= 2 && remainingArgs[0] == "delete" {
-		return cmdAdminFilesDelete(remainingArgs[1])
+	switch subcmd {
+	case "stats":
+		if len(args) > 1 && args[1] == "content-types" {
+			return cmdAdminContentTypes(args[2:])
+		}
+		return cmdAdminStats()
+	case "users":
+		return cmdAdminUsers(args[1:])
+	case "settings":
+		return cmdAdminSettings(args[1:])
+	case "maintenance":
+		return cmdAdminMaintenance(args[1:])
+	case "read-only":
+		return cmdAdminReadOnly(args[1:])
+	case "sessions":
+		return cmdAdminSessions(args[1:])
+	case "signing-keys":
+		return cmdAdminSigningKeys(args[1:])
+	case "files":
+		return cmdAdminFiles(args[1:])
+	case "storage":
+		return cmdAdminStorage(args[1:])
+	case "logs":
+		return cmdAdminLogs(args[1:])
+	case "announcements":
+		return cmdAdminAnnouncements(args[1:])
+	case "rotate-keys":
+		return cmdAdminRotateKeys(args[1:])
+	case "replication":
+		return cmdAdminReplication(args[1:])
+	case "snapshots":
+		return cmdAdminSnapshots(args[1:])
+	case "quarantine":
+		return cmdAdminQuarantine(args[1:])
+	case "reports":
+		return cmdAdminReports(args[1:])
+	case "usage-report":
+		return cmdAdminUsageReport(args[1:])
+	case "usage":
+		return cmdAdminUsage(args[1:])
+	case "notifications":
+		return cmdAdminNotifications(args[1:])
+	default:
+		return fmt.Errorf("unknown admin subcommand: %s", subcmd)
 	}
-	return cmdAdminFilesList(*jsonOut, *wideOut)
 }
 
-func cmdAdminFilesList(jsonOut bool, wideOut bool) error {
+// cmdAdminContentTypes shows the storage of the instance by MIME type and
+// extension; the server caches it for a while unless --refresh is given
+func cmdAdminContentTypes(args []string) error {
+	fs := flag.NewFlagSet("content-types", flag.ContinueOnError)
+	refresh := fs.Bool("refresh", false, "compute again instead of using the cached result")
+	top := fs.Int("top", 15, "rows per table")
+	jsonOut := fs.Bool("json", false, "output json")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("GET", "/admin/files", token, nil, "")
+	path := "/admin/stats/content-types"
+	if *refresh {
+		path += "?refresh=true"
+	}
+	resp, err := doRequest("GET", path, token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to list files (status %d)", resp.StatusCode)
-	}
-
-	var result struct {
-		Files []struct {
-			ID       string `json:"id"`
-			FileName string `json:"filename"`
-			Size     int64  `json:"size"`
-		} `json:"files"`
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get content types (status %d): %s", resp.StatusCode, string(b))
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if *jsonOut {
+		_, err := io.Copy(os.Stdout, resp.Body)
 		return err
 	}
 
-	if jsonOut {
-		b, _ := json.Marshal(result)
-		fmt.Println(string(b))
-		return nil
+	type usage struct {
+		MimeType  string `json:"mime_type"`
+		Extension string `json:"extension"`
+		Files     int    `json:"files"`
+		Bytes     int64  `json:"bytes"`
 	}
-
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	if wideOut {
-		_, _ = fmt.Fprintf(w, "FILE ID\tFILENAME\tSIZE\n")
-		_, _ = fmt.Fprintf(w, "-------\t--------\t----\n")
-	} else {
-		_, _ = fmt.Fprintf(w, "ID\tFILENAME\tSIZE\n")
-		_, _ = fmt.Fprintf(w, "---\t--------\t----\n")
+	var stats struct {
+		Files       int       `json:"files"`
+		Bytes       int64     `json:"bytes"`
+		MimeTypes   []usage   `json:"mime_types"`
+		Extensions  []usage   `json:"extensions"`
+		GeneratedAt time.Time `json:"generated_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return err
 	}
 
-	for _, f := range result.Files {
-		id := f.ID
-		if !wideOut && len(id) > 8 {
-			id = id[:8] + "..."
+	fmt.Printf("📊 %d files, %s (as of %s)\n", stats.Files, humanize.Bytes(uint64(stats.Bytes)), humanize.Time(stats.GeneratedAt))
+	table := func(title string, rows []usage, label func(usage) string) {
+		fmt.Printf("\n%s:\n", title)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		for i, u := range rows {
+			if i == *top {
+				_, _ = fmt.Fprintf(w, "  ... %d more\n", len(rows)-i)
+				break
+			}
+			share := 0.0
+			if stats.Bytes > 0 {
+				share = float64(u.Bytes) * 100 / float64(stats.Bytes)
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%.1f%%\t%d files\n", label(u), humanize.Bytes(uint64(u.Bytes)), share, u.Files)
 		}
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", id, f.FileName, humanize.Bytes(uint64(f.Size)))
+		_ = w.Flush()
 	}
-	_ = w.Flush()
+	table("By MIME type", stats.MimeTypes, func(u usage) string { return u.MimeType })
+	table("By extension", stats.Extensions, func(u usage) string {
+		if u.Extension == "" {
+			return "(none)"
+		}
+		return u.Extension
+	})
 	return nil
 }
 
-func cmdAdminFilesDelete(id string) error {
+func cmdAdminStats() error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("DELETE", "/admin/files/"+id, token, nil, "")
+	resp, err := doRequest("GET", "/admin/stats", token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete file (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("failed to get stats (status %d)", resp.StatusCode)
 	}
 
-	fmt.Println("✅ File deleted")
-	return nil
-}
-
-func cmdAdminStorage(args []string) error {
-	if len(args) < 1 {
-		return errors.New("storage subcommand required: analyze or cleanup")
+	var stats struct {
+		TotalUsers        int   `json:"total_users"`
+		TotalFiles        int   `json:"total_files"`
+		TotalStorageBytes int64 `json:"total_storage_bytes"`
+		ActiveSessions    int   `json:"active_sessions"`
+		Downloads24h      int   `json:"downloads_24h"`
+		DownloadBytes24h  int64 `json:"download_bytes_24h"`
+		Database          struct {
+			Queries     int64 `json:"queries"`
+			Errors      int64 `json:"errors"`
+			SlowQueries int64 `json:"slow_queries"`
+		} `json:"database"`
+		DatabasePool struct {
+			MaxOpenConns int `json:"max_open_conns"`
+			InUse        int `json:"in_use"`
+			Idle         int `json:"idle"`
+		} `json:"database_pool"`
+		RedisPool struct {
+			TotalConns int `json:"total_conns"`
+			IdleConns  int `json:"idle_conns"`
+		} `json:"redis_pool"`
+		StorageBreaker *struct {
+			State               string `json:"state"`
+			ConsecutiveFailures int    `json:"consecutive_failures"`
+			Trips               int64  `json:"trips"`
+			RetryAfterSeconds   int    `json:"retry_after_seconds"`
+		} `json:"storage_breaker"`
+		StorageAlerts *struct {
+			Bucket    *storageUsage `json:"bucket"`
+			Database  *storageUsage `json:"database"`
+			UserQuota *struct {
+				QuotaBytes       int64 `json:"quota_bytes"`
+				ThresholdPercent int   `json:"threshold_percent"`
+				UsersOver        int   `json:"users_over"`
+			} `json:"user_quota"`
+		} `json:"storage_alerts"`
+		UploadCapacity *struct {
+			Temp    *uploadSpace `json:"temp"`
+			Storage *uploadSpace `json:"storage"`
+		} `json:"upload_capacity"`
 	}
 
-	subcmd := args[0]
-	switch subcmd {
-	case "analyze":
-		return cmdAdminStorageAnalyze()
-	case "cleanup":
-		return cmdAdminStorageCleanup()
-	default:
-		return fmt.Errorf("unknown storage subcommand: %s", subcmd)
-	}
-}
-
-func cmdAdminStorageAnalyze() error {
-	token, err := loadToken()
-	if err != nil {
-		return err
-	}
-
-	resp, err := doRequest("GET", "/admin/storage/analyze", token, nil, "")
-	if err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to analyze storage (status %d)", resp.StatusCode)
+	fmt.Println("📊 System Statistics")
+	fmt.Printf("Total Users:     %d\n", stats.TotalUsers)
+	fmt.Printf("Total Files:     %d\n", stats.TotalFiles)
+	fmt.Printf("Storage Used:    %s\n", humanize.Bytes(uint64(stats.TotalStorageBytes)))
+	fmt.Printf("Active Sessions: %d\n", stats.ActiveSessions)
+	fmt.Printf("Downloads (24h): %d (%s)\n", stats.Downloads24h, humanize.Bytes(uint64(stats.DownloadBytes24h)))
+	fmt.Printf("DB Queries:      %d (%d failed, %d slow)\n", stats.Database.Queries, stats.Database.Errors, stats.Database.SlowQueries)
+	fmt.Printf("DB Pool:         %d in use, %d idle (max %d)\n", stats.DatabasePool.InUse, stats.DatabasePool.Idle, stats.DatabasePool.MaxOpenConns)
+	fmt.Printf("Redis Pool:      %d open, %d idle\n", stats.RedisPool.TotalConns, stats.RedisPool.IdleConns)
+	if b := stats.StorageBreaker; b != nil {
+		line := fmt.Sprintf("%s (%d consecutive failures, tripped %d times)", b.State, b.ConsecutiveFailures, b.Trips)
+		if b.RetryAfterSeconds > 0 {
+			line += fmt.Sprintf(", retry in %ds", b.RetryAfterSeconds)
+		}
+		fmt.Printf("MinIO Breaker:   %s\n", line)
 	}
 
-	var analysis struct {
-		TotalFiles     int   `json:"total_files"`
-		TotalSizeBytes int64 `json:"total_size_bytes"`
-		OrphanedFiles  int   `json:"orphaned_files"`
-		ExpiredFiles   int   `json:"expired_files"`
+	if sa := stats.StorageAlerts; sa != nil {
+		printStorageUsage("Bucket:          ", sa.Bucket)
+		printStorageUsage("Database:        ", sa.Database)
+		if q := sa.UserQuota; q != nil && q.QuotaBytes > 0 {
+			fmt.Printf("Quota Alerts:    %d user(s) over %d%% of %s\n", q.UsersOver, q.ThresholdPercent, humanize.Bytes(uint64(q.QuotaBytes)))
+		}
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&analysis); err != nil {
-		return err
+	if uc := stats.UploadCapacity; uc != nil {
+		printUploadSpace("Temp Space:      ", uc.Temp)
+		printUploadSpace("Upload Space:    ", uc.Storage)
 	}
-
-	fmt.Println("💾 Storage Analysis:")
-	fmt.Printf("Total Files:     %d\n", analysis.TotalFiles)
-	fmt.Printf("Total Size:      %s\n", humanize.Bytes(uint64(analysis.TotalSizeBytes)))
-	fmt.Printf("Orphaned Files:  %d\n", analysis.OrphanedFiles)
-	fmt.Printf("Expired Files:   %d\n", analysis.ExpiredFiles)
 	return nil
 }
 
-func cmdAdminStorageCleanup() error {
-	token, err := loadToken()
-	if err != nil {
-		return err
-	}
-
-	resp, err := doRequest("POST", "/admin/storage/cleanup", token, nil, "")
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to cleanup storage (status %d): %s", resp.StatusCode, string(b))
-	}
-
-	var result struct {
-		Message         string `json:"message"`
-		FilesDeleted    int    `json:"files_deleted"`
-		SpaceFreedBytes int64  `json:"space_freed_bytes"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
-	}
-
-	fmt.Println("🧹 Cleanup completed!")
-	fmt.Printf("Files Deleted:  %d\n", result.FilesDeleted)
-	fmt.Printf("Space Freed:    %s\n", humanize.Bytes(uint64(result.SpaceFreedBytes)))
-	return nil
+// uploadSpace is the room left for uploads on the temp filesystem or in storage
+type uploadSpace struct {
+	Dir           string `json:"dir"`
+	FreeBytes     int64  `json:"free_bytes"`
+	TotalBytes    int64  `json:"total_bytes"`
+	ReservedBytes int64  `json:"reserved_bytes"`
+	Low           bool   `json:"low"`
 }
 
-func cmdAdminLogs(args []string) error {
-	fs := flag.NewFlagSet("logs", flag.ContinueOnError)
-	action := fs.String("action", "", "filter by action")
-	userID := fs.String("user_id", "", "filter by user id")
-
-	if err := ParseInterspersed(fs, args); err != nil {
-		return fmt.Errorf("failed to parse flags: %w", err)
-	}
-
-	token, err := loadToken()
-	if err != nil {
-		return err
+func printUploadSpace(label string, s *uploadSpace) {
+	if s == nil {
+		return
 	}
-
-	path := "/admin/logs?"
-	if *action != "" {
-		path += "action=" + *action + "&"
+	where := ""
+	if s.Dir != "" {
+		where = " in " + s.Dir
 	}
-	if *userID != "" {
-		path += "user_id=" + *userID
+	mark := ""
+	if s.Low {
+		mark = " ⚠️ uploads refused"
 	}
+	fmt.Printf("%s%s free of %s%s (reserve %s)%s\n", label, humanize.Bytes(uint64(s.FreeBytes)),
+		humanize.Bytes(uint64(s.TotalBytes)), where, humanize.Bytes(uint64(s.ReservedBytes)), mark)
+}
 
-	resp, err := doRequest("GET", path, token, nil, "")
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
+// storageUsage is a usage measured against a storage alert threshold
+type storageUsage struct {
+	UsedBytes        int64   `json:"used_bytes"`
+	CapacityBytes    int64   `json:"capacity_bytes"`
+	Percent          float64 `json:"percent"`
+	ThresholdPercent int     `json:"threshold_percent"`
+	Exceeded         bool    `json:"exceeded"`
+}
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to get logs (status %d)", resp.StatusCode)
+func printStorageUsage(label string, u *storageUsage) {
+	if u == nil {
+		return
 	}
-
-	var result struct {
-		Logs []struct {
-			ID        string `json:"id"`
-			UserID    string `json:"actor_id"`
-			Action    string `json:"action"`
-			Details   []byte `json:"metadata"`
-			IPAddress struct {
-				String string `json:"String"`
-				Valid  bool   `json:"Valid"`
-			} `json:"ip_address"`
-			Timestamp string `json:"created_at"`
-		} `json:"logs"`
+	mark := ""
+	if u.Exceeded {
+		mark = " ⚠️"
 	}
+	fmt.Printf("%s%s of %s (%.1f%%, alert at %d%%)%s\n", label, humanize.Bytes(uint64(u.UsedBytes)),
+		humanize.Bytes(uint64(u.CapacityBytes)), u.Percent, u.ThresholdPercent, mark)
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
+func cmdAdminUsers(args []string) error {
+	if len(args) == 0 {
+		return cmdAdminUsersList(args)
 	}
 
-	for _, log := range result.Logs {
-		ipAddr := "unknown"
-		if log.IPAddress.Valid {
-			ipAddr = log.IPAddress.String
-		}
-		details := string(log.Details)
-		if details == "" || details == "null" {
-			details = "-"
+	subcmd := args[0]
+	switch subcmd {
+	case "approve":
+		return cmdAdminUsersApprove(args[1:])
+	case "reject":
+		return cmdAdminUsersReject(args[1:])
+	case "delete":
+		return cmdAdminUsersDelete(args[1:])
+	default:
+		// Check if it's a user ID for status/role/reset-password/logout
+		if len(args) >= 2 {
+			userID := args[0]
+			action := args[1]
+			switch action {
+			case "status":
+				return cmdAdminUsersStatus(userID, args[2:])
+			case "role":
+				return cmdAdminUsersRole(userID, args[2:])
+			case "reset-password":
+				return cmdAdminUsersResetPassword(userID)
+			case "logout":
+				return cmdAdminUsersLogout(userID)
+			case "read-only":
+				return cmdAdminUsersReadOnly(userID, args[2:])
+			case "transfer-files":
+				return cmdAdminUsersTransferFiles(userID, args[2:])
+			case "profile":
+				return cmdAdminUsersProfile(userID, args[2:])
+			case "storage":
+				return cmdAdminUsersStorage(userID, args[2:])
+			}
 		}
-		fmt.Printf("[%s] %s - %s (%s) - %s\n",
-			log.Timestamp,
-			log.Action,
-			log.UserID[:8]+"...",
-			ipAddr,
-			details)
+		return cmdAdminUsersList(args)
 	}
-	return nil
 }
 
-func cmdAdminAnnouncements(args []string) error {
-	fs := flag.NewFlagSet("announcements", flag.ContinueOnError)
+func cmdAdminUsersList(args []string) error {
+	fs := flag.NewFlagSet("users", flag.ContinueOnError)
+	status := fs.String("status", "", "filter by status: active, inactive, pending")
 	jsonOut := fs.Bool("json", false, "output json")
 	wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
 	fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
@@ -1792,126 +2513,116 @@ func cmdAdminAnnouncements(args []string) error {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
 
-	remainingArgs := fs.Args()
-	if len(remainingArgs) == 0 {
-		return cmdAdminAnnouncementsList(*jsonOut, *wideOut)
-	}
-
-	subcmd := remainingArgs[0]
-	switch subcmd {
-	case "create":
-		return cmdAdminAnnouncementsCreate(remainingArgs[1:])
-	case "delete":
-		return cmdAdminAnnouncementsDelete(remainingArgs[1:])
-	default:
-		return fmt.Errorf("unknown announcements subcommand: %s", subcmd)
-	}
-}
-
-func cmdAdminAnnouncementsList(jsonOut bool, wideOut bool) error {
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	resp, err := doRequest("GET", "/admin/announcements", token, nil, "")
+	path := "/admin/users"
+	if *status != "" {
+		path += "?status=" + *status
+	}
+
+	resp, err := doRequest("GET", path, token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to list announcements (status %d)", resp.StatusCode)
+		return fmt.Errorf("failed to list users (status %d)", resp.StatusCode)
 	}
 
 	var result struct {
-		Announcements []struct {
-			ID       string `json:"id"`
-			Title    string `json:"title"`
-			Message  string `json:"message"`
-			Severity string `json:"severity"`
-		} `json:"announcements"`
+		Users []struct {
+			ID           string `json:"id"`
+			Username     string `json:"username"`
+			Email        string `json:"email"`
+			Role         string `json:"role"`
+			SessionCount int    `json:"session_count"`
+			AvatarURL    string `json:"avatar_url,omitempty"`
+			TotalStorage int64  `json:"total_storage"`
+			Quota        *struct {
+				State       string     `json:"state"`
+				GraceEndsAt *time.Time `json:"grace_ends_at"`
+			} `json:"quota"`
+		} `json:"users"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return err
 	}
 
-	if jsonOut {
+	if *jsonOut {
 		b, _ := json.Marshal(result)
 		fmt.Println(string(b))
 		return nil
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	if wideOut {
-		_, _ = fmt.Fprintf(w, "ANNOUNCEMENT ID\tSEVERITY\tTITLE\n")
-		_, _ = fmt.Fprintf(w, "---------------\t--------\t-----\n")
+	if *wideOut {
+		_, _ = fmt.Fprintf(w, "USER ID\tUSERNAME\tEMAIL\tROLE\tSESSIONS\tAVATAR\tSTORAGE\tQUOTA\n")
+		_, _ = fmt.Fprintf(w, "-------\t--------\t-----\t----\t--------\t------\t-------\t-----\n")
 	} else {
-		_, _ = fmt.Fprintf(w, "ID\tSEVERITY\tTITLE\n")
-		_, _ = fmt.Fprintf(w, "---\t--------\t-----\n")
-	}
-
-	if len(result.Announcements) == 0 {
-		fmt.Println("No announcements.")
-		return nil
+		_, _ = fmt.Fprintf(w, "ID\tUSERNAME\tEMAIL\tROLE\n")
+		_, _ = fmt.Fprintf(w, "---\t--------\t-----\t----\n")
 	}
 
-	for _, a := range result.Announcements {
-		id := a.ID
-		if !wideOut && len(id) > 8 {
+	for _, u := range result.Users {
+		id := u.ID
+		if !*wideOut && len(id) > 8 {
 			id = id[:8] + "..."
 		}
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", id, a.Severity, a.Title)
+		if *wideOut {
+			avatar := "-"
+			if u.AvatarURL != "" {
+				avatar = "yes"
+			}
+			quota := "-"
+			if q := u.Quota; q != nil {
+				quota = q.State
+				if q.State == "grace" && q.GraceEndsAt != nil {
+					quota += " until " + q.GraceEndsAt.Local().Format("2006-01-02")
+				}
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n", id, u.Username, u.Email, u.Role, u.SessionCount, avatar, humanize.Bytes(uint64(u.TotalStorage)), quota)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, u.Username, u.Email, u.Role)
 	}
 	_ = w.Flush()
 	return nil
 }
 
-func cmdAdminAnnouncementsCreate(args []string) error {
-	fs := flag.NewFlagSet("create", flag.ContinueOnError)
-	title := fs.String("title", "", "announcement title")
-	message := fs.String("message", "", "announcement message")
-	severity := fs.String("severity", "info", "severity: info, warning, error")
-
-	if err := ParseInterspersed(fs, args); err != nil {
-		return fmt.Errorf("failed to parse flags: %w", err)
-	}
-
-	if *title == "" || *message == "" {
-		return errors.New("--title and --message required")
+func cmdAdminUsersApprove(args []string) error {
+	if len(args) < 1 {
+		return errors.New("user id required")
 	}
+	id := args[0]
 
 	token, err := loadToken()
 	if err != nil {
 		return err
 	}
 
-	payload := map[string]string{
-		"title":    *title,
-		"message":  *message,
-		"severity": *severity,
-	}
-
-	body, _ := json.Marshal(payload)
-	resp, err := doRequest("POST", "/admin/announcements", token, strings.NewReader(string(body)), "application/json")
+	resp, err := doRequest("POST", "/admin/users/"+id+"/approve", token, nil, "")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 201 {
+	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create announcement (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("failed to approve user (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ Announcement created")
+	fmt.Println("✅ User approved")
 	return nil
 }
 
-func cmdAdminAnnouncementsDelete(args []string) error {
+func cmdAdminUsersReject(args []string) error {
 	if len(args) < 1 {
-		return errors.New("announcement id required")
+		return errors.New("user id required")
 	}
 	id := args[0]
 
@@ -1920,7 +2631,7 @@ func cmdAdminAnnouncementsDelete(args []string) error {
 		return err
 	}
 
-	resp, err := doRequest("DELETE", "/admin/announcements/"+id, token, nil, "")
+	resp, err := doRequest("POST", "/admin/users/"+id+"/reject", token, nil, "")
 	if err != nil {
 		return err
 	}
@@ -1928,200 +2639,3070 @@ func cmdAdminAnnouncementsDelete(args []string) error {
 
 	if resp.StatusCode != 200 {
 		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete announcement (status %d): %s", resp.StatusCode, string(b))
+		return fmt.Errorf("failed to reject user (status %d): %s", resp.StatusCode, string(b))
 	}
 
-	fmt.Println("✅ Announcement deleted")
+	fmt.Println("✅ User rejected")
 	return nil
 }
 
-func printAdminHelp() {
-	fmt.Println("fl admin - Admin Commands")
-	fmt.Println("\n📊 System Management:")
-	fmt.Println("  admin stats                        System statistics")
-	fmt.Println("\n👥 User Management:")
-	fmt.Println("  admin users [--status pending]     List users (supports --json, --wide/-w)")
-	fmt.Println("  admin users approve <id>           Approve user")
-	fmt.Println("  admin users reject <id>            Reject user")
-	fmt.Println("  admin users delete <id>            Delete user")
-	fmt.Println("  admin users <id> status <active>   Update user status")
-	fmt.Println("  admin users <id> role <admin>      Update user role")
-	fmt.Println("  admin users <id> reset-password    Reset user password")
-	fmt.Println("  admin users <id> logout            Force logout user")
-	fmt.Println("\n⚙️  Settings:")
-	fmt.Println("  admin settings                     View system settings")
-	fmt.Println("  admin settings <key> <value>       Update setting")
-	fmt.Println("\n📁 File Management:")
-	fmt.Println("  admin files [--json] [--wide/-w]   List all files")
-	fmt.Println("  admin files delete <id>            Delete any file")
-	fmt.Println("\n💾 Storage:")
-	fmt.Println("  admin storage analyze              Analyze storage usage")
-	fmt.Println("  admin storage cleanup              Cleanup orphaned files")
-	fmt.Println("\n📜 Audit Logs:")
-	fmt.Println("  admin logs [--action] [--user_id]  View audit logs")
-	fmt.Println("\n📢 Announcements:")
-	fmt.Println("  admin announcements [--json] [-w]  List all announcements")
-	fmt.Println("  admin announcements create         Create announcement")
-	fmt.Println("          --title <title> --message <msg> [--severity info|warning|error]")
-	fmt.Println("  admin announcements delete <id>    Delete announcement")
-	fmt.Println("\n📖 Examples:")
-	fmt.Println("  fl admin stats")
-	fmt.Println("  fl admin users --status pending --wide")
-	fmt.Println("  fl admin files --json")
-	fmt.Println("  fl admin storage cleanup")
-	fmt.Println("  fl admin logs --action upload")
-	fmt.Println("  fl admin announcements create --title \"Maintenance\" --message \"Scheduled downtime\" --severity warning")
+func cmdAdminUsersDelete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("user id required")
+	}
+	id := args[0]
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("DELETE", "/admin/users/"+id, token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete user (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Println("✅ User deleted")
+	return nil
 }
 
-func printUsage() {
-	fmt.Println("fl - File Locker CLI")
-	fmt.Println("\n🔐 Authentication:")
-	fmt.Println("  login --token <token>              Login with Personal Access Token")
-	fmt.Println("  login -u <user> -p <pass>          Login with username/password")
-	fmt.Println("  login --host <url>                 Set server URL")
-	fmt.Println("  logout                             Logout and clear credentials")
-	fmt.Println("  me                                 Show current user info")
-	fmt.Println("  whoami                             Alias for 'me'")
+func cmdAdminUsersStatus(userID string, args []string) error {
+	if len(args) < 1 {
+		return errors.New("status required: active or inactive")
+	}
+	status := args[0]
 
-	fmt.Println("\n📁 File Operations:")
-	fmt.Println("  ls [--json] [--wide/-w]            List files (table, JSON, or wide format)")
-	fmt.Println("  upload <file> [--tags t1,t2]       Upload file with optional tags")
-	fmt.Println("                [--expire 24]        Set expiration in hours")
-	fmt.Println("  download <file_id> [-o filename]   Download file")
-	fmt.Println("  rm <file_id>                       Delete file")
-	fmt.Println("  search <query> [--json]            Search files by name or tags")
-	fmt.Println("  export [-o output.zip]             Export all files as zip")
-	fmt.Println("  update <file_id> --tags t1,t2      Update file metadata")
-	fmt.Println("         <file_id> --name newname    Rename file")
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
 
-	fmt.Println("\n🔑 Personal Access Tokens:")
-	fmt.Println("  tokens list [--json] [--wide/-w]   List all PATs (supports wide format)")
-	fmt.Println("  tokens create <name> [--expire]    Create new PAT")
-	fmt.Println("  tokens revoke <token_id>           Revoke PAT")
+	payload := map[string]string{"status": status}
+	body, _ := json.Marshal(payload)
 
-	fmt.Println("\n👤 User Management:")
-	fmt.Println("  password --old <old> --new <new>   Change password")
+	resp, err := doRequest("PATCH", "/admin/users/"+userID+"/status", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
 
-	fmt.Println("\n📢 Announcements:")
-	fmt.Println("  announcements                      List announcements")
-	fmt.Println("  announcements dismiss <id>         Dismiss announcement")
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update status (status %d): %s", resp.StatusCode, string(b))
+	}
 
-	// Conditionally show admin section
-	if isAdmin() {
-		fmt.Println("\n🛡️  Admin Commands:")
-		fmt.Println("  admin help                         Show detailed admin commands")
-		fmt.Println("  admin stats                        System statistics")
-		fmt.Println("  admin users                        User management")
-		fmt.Println("  admin files                        File management")
-		fmt.Println("  admin storage [analyze/cleanup]    Storage operations")
-		fmt.Println("  admin logs                         Audit logs")
-		fmt.Println("  admin announcements                Announcement management")
-		fmt.Println("  admin settings                     System settings")
-	} else {
-		fmt.Println("\n🛡️  Admin: Login as admin to access admin commands")
+	fmt.Println("✅ User status updated")
+	return nil
+}
+
+func cmdAdminUsersRole(userID string, args []string) error {
+	if len(args) < 1 {
+		return errors.New("role required: user or admin")
 	}
+	role := args[0]
 
-	fmt.Println("\n📖 Examples:")
-	fmt.Println("  fl login --token fl_abc123...")
-	fmt.Println("  fl ls --wide                       # Show full file IDs")
-	fmt.Println("  fl upload document.pdf --tags work,important --expire 72")
-	fmt.Println("  fl search \"project files\" --json")
-	fmt.Println("  fl tokens list --wide              # Show full token IDs")
-	fmt.Println("  fl tokens create \"CI/CD Pipeline\"")
-	if isAdmin() {
-		fmt.Println("  fl admin users --status pending --wide")
-		fmt.Println("  fl admin storage cleanup")
+	token, err := loadToken()
+	if err != nil {
+		return err
 	}
 
-	fmt.Println("\n💡 Tip: Use --wide or -w to see full IDs (useful for copy-paste)")
-	fmt.Println("💡 Tip: Flags can be placed before or after arguments")
+	payload := map[string]string{"role": role}
+	body, _ := json.Marshal(payload)
+
+	resp, err := doRequest("PATCH", "/admin/users/"+userID+"/role", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update role (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Println("✅ User role updated")
+	return nil
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		printUsage()
-		return
+func cmdAdminUsersProfile(userID string, args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	username := fs.String("username", "", "new username")
+	email := fs.String("email", "", "new email address (no verification)")
+	name := fs.String("name", "", "new display name")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
 	}
-	cmd := os.Args[1]
-	switch cmd {
-	case "login":
-		if err := cmdLogin(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "ls":
-		fs := flag.NewFlagSet("ls", flag.ContinueOnError)
-		jsonOut := fs.Bool("json", false, "output json")
-		wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
-		fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
-		_ = fs.Parse(os.Args[2:])
-		if err := cmdLs(*jsonOut, *wideOut); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "upload":
-		if err := cmdUpload(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "download":
-		if err := cmdDownload(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "rm":
-		if err := cmdRm(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "logout":
-		if err := cmdLogout(); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "me", "whoami":
-		if err := cmdMe(); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "search":
-		if err := cmdSearch(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "export":
-		if err := cmdExport(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "update":
-		if err := cmdUpdate(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "tokens":
-		if err := cmdTokens(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "password":
-		if err := cmdPassword(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "announcements":
-		if err := cmdAnnouncements(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
-		}
-	case "admin":
-		if err := cmdAdmin(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "Error:", err)
-			os.Exit(1)
+
+	payload := map[string]interface{}{}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "username":
+			payload["username"] = *username
+		case "email":
+			payload["email"] = *email
+		case "name":
+			payload["display_name"] = *name
 		}
-	default:
-		printUsage()
+	})
+	if len(payload) == 0 {
+		return errors.New("at least one of --username, --email or --name is required")
+	}
+	return sendProfile("PATCH", "/admin/users/"+userID+"/profile", payload)
+}
+
+func cmdAdminUsersResetPassword(userID string) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("POST", "/admin/users/"+userID+"/reset-password", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to reset password (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Message           string `json:"message"`
+		TemporaryPassword string `json:"temporary_password"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Password reset successfully")
+	fmt.Printf("Temporary Password: %s\n", result.TemporaryPassword)
+	return nil
+}
+
+func cmdAdminUsersLogout(userID string) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("POST", "/admin/users/"+userID+"/logout", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to logout user (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Println("✅ User logged out")
+	return nil
+}
+
+func cmdAdminUsersReadOnly(userID string, args []string) error {
+	if len(args) < 1 || (args[0] != "on" && args[0] != "off") {
+		return errors.New("usage: admin users <id> read-only <on|off>")
+	}
+	if err := requireFeature("read_only_mode", "fl admin users read-only"); err != nil {
+		return err
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	body, _ := json.Marshal(map[string]bool{"read_only": args[0] == "on"})
+	resp, err := doRequest("PUT", "/admin/users/"+userID+"/read-only", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update user (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Printf("✅ Read-only %s for user\n", args[0])
+	return nil
+}
+
+// cmdAdminUsersTransferFiles gives a user's files (all, or those of --files) to another user
+func cmdAdminUsersTransferFiles(userID string, args []string) error {
+	fs := flag.NewFlagSet("transfer-files", flag.ContinueOnError)
+	to := fs.String("to", "", "new owner (username or user ID)")
+	files := fs.String("files", "", "comma separated file IDs (default: all files)")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if *to == "" {
+		return errors.New("usage: admin users <id> transfer-files --to <user> [--files id1,id2]")
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{}
+	if len(*to) == 36 && strings.Count(*to, "-") == 4 {
+		payload["to_user_id"] = *to
+	} else {
+		payload["to_username"] = *to
+	}
+	if *files != "" {
+		payload["file_ids"] = strings.Split(*files, ",")
+	}
+
+	body, _ := json.Marshal(payload)
+	resp, err := doRequest("POST", "/admin/users/"+userID+"/transfer-files", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to transfer files (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Transferred int `json:"transferred"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	fmt.Printf("✅ %d files transferred to %s\n", result.Transferred, *to)
+	return nil
+}
+
+// cmdAdminUsersStorage shows where a user's storage goes: totals, growth per
+// month, the largest files and the breakdown by type
+func cmdAdminUsersStorage(userID string, args []string) error {
+	fs := flag.NewFlagSet("storage", flag.ContinueOnError)
+	months := fs.Int("months", 12, "months of growth to show")
+	jsonOut := fs.Bool("json", false, "output json")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("GET", fmt.Sprintf("/admin/users/%s/storage?months=%d", userID, *months), token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get storage report (status %d): %s", resp.StatusCode, string(b))
+	}
+	if *jsonOut {
+		_, err := io.Copy(os.Stdout, resp.Body)
+		return err
+	}
+
+	var report struct {
+		Username     string `json:"username"`
+		Files        int    `json:"files"`
+		Bytes        int64  `json:"bytes"`
+		TrashedFiles int    `json:"trashed_files"`
+		TrashedBytes int64  `json:"trashed_bytes"`
+		QuotaBytes   int64  `json:"quota_bytes"`
+		Growth       []struct {
+			Month      string `json:"month"`
+			AddedFiles int    `json:"added_files"`
+			AddedBytes int64  `json:"added_bytes"`
+			TotalBytes int64  `json:"total_bytes"`
+		} `json:"growth"`
+		LargestFiles []struct {
+			FileID   string    `json:"file_id"`
+			FileName string    `json:"file_name"`
+			Size     int64     `json:"size"`
+			Created  time.Time `json:"created_at"`
+			Trashed  bool      `json:"trashed"`
+		} `json:"largest_files"`
+		ContentTypes []struct {
+			MimeType string `json:"mime_type"`
+			Files    int    `json:"files"`
+			Bytes    int64  `json:"bytes"`
+		} `json:"content_types"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return err
+	}
+
+	fmt.Printf("💾 Storage of %s\n\n", report.Username)
+	fmt.Printf("Used:     %s in %d files\n", humanize.Bytes(uint64(report.Bytes)), report.Files)
+	if report.TrashedFiles > 0 {
+		fmt.Printf("Trash:    %s in %d files (included above)\n", humanize.Bytes(uint64(report.TrashedBytes)), report.TrashedFiles)
+	}
+	if report.QuotaBytes > 0 {
+		fmt.Printf("Quota:    %s (%.0f%% used)\n", humanize.Bytes(uint64(report.QuotaBytes)), float64(report.Bytes)*100/float64(report.QuotaBytes))
+	}
+
+	fmt.Println("\nGrowth (files still stored):")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	for _, g := range report.Growth {
+		_, _ = fmt.Fprintf(w, "  %s\t+%s\t+%d files\t%s\n", g.Month, humanize.Bytes(uint64(g.AddedBytes)), g.AddedFiles, humanize.Bytes(uint64(g.TotalBytes)))
+	}
+	_ = w.Flush()
+
+	if len(report.LargestFiles) > 0 {
+		fmt.Println("\nLargest files:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		for _, f := range report.LargestFiles {
+			name := f.FileName
+			if f.Trashed {
+				name += " (trash)"
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", f.FileID[:8], humanize.Bytes(uint64(f.Size)), f.Created.Local().Format("2006-01-02"), name)
+		}
+		_ = w.Flush()
+	}
+
+	if len(report.ContentTypes) > 0 {
+		fmt.Println("\nBy type:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		for _, t := range report.ContentTypes {
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%d files\n", t.MimeType, humanize.Bytes(uint64(t.Bytes)), t.Files)
+		}
+		_ = w.Flush()
+	}
+	return nil
+}
+
+func cmdAdminSettings(args []string) error {
+	if len(args) == 0 {
+		return cmdAdminSettingsGet()
+	}
+
+	if len(args) >= 2 {
+		return cmdAdminSettingsUpdate(args[0], args[1])
+	}
+
+	return errors.New("usage: admin settings [key value]")
+}
+
+func cmdAdminSettingsGet() error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("GET", "/admin/settings", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("failed to get settings (status %d)", resp.StatusCode)
+	}
+
+	var settings map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return err
+	}
+
+	fmt.Println("⚙️  System Settings:")
+	for key, value := range settings {
+		fmt.Printf("%s: %v\n", key, value)
+	}
+	return nil
+}
+
+func cmdAdminSettingsUpdate(key, value string) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{"key": key, "value": value}
+	body, _ := json.Marshal(payload)
+
+	resp, err := doRequest("PATCH", "/admin/settings", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update setting (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Println("✅ Setting updated")
+	return nil
+}
+
+func cmdAdminMaintenance(args []string) error {
+	fs := flag.NewFlagSet("maintenance", flag.ContinueOnError)
+	message := fs.String("message", "", "message shown to users while maintenance mode is on")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if err := requireFeature("maintenance_mode", "fl admin maintenance"); err != nil {
+		return err
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+	switch fs.Arg(0) {
+	case "":
+		resp, err = doRequest("GET", "/admin/maintenance", token, nil, "")
+	case "on", "off":
+		body, _ := json.Marshal(map[string]interface{}{"enabled": fs.Arg(0) == "on", "message": *message})
+		resp, err = doRequest("PUT", "/admin/maintenance", token, strings.NewReader(string(body)), "application/json")
+	default:
+		return errors.New("usage: admin maintenance [on|off] [--message <msg>]")
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("maintenance request failed (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var state struct {
+		Enabled bool   `json:"enabled"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return err
+	}
+	if state.Enabled {
+		fmt.Printf("🚧 Maintenance mode is ON: %s\n", state.Message)
+	} else {
+		fmt.Println("✅ Maintenance mode is off")
+	}
+	return nil
+}
+
+func cmdAdminSessions(args []string) error {
+	fs := flag.NewFlagSet("sessions", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "revoke without asking")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	switch fs.Arg(0) {
+	case "":
+		return cmdAdminSessionsList()
+	case "revoke-all":
+		return cmdAdminSessionsRevokeAll(*yes)
+	default:
+		return errors.New("usage: admin sessions [revoke-all [--yes]]")
+	}
+}
+
+func cmdAdminSessionsList() error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("GET", "/admin/sessions", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to list sessions (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Users []struct {
+			UserID   string `json:"user_id"`
+			Username string `json:"username"`
+			Orphaned bool   `json:"orphaned"`
+			Reason   string `json:"reason"`
+			Sessions []struct {
+				ID        string    `json:"id"`
+				ExpiresAt time.Time `json:"expires_at"`
+			} `json:"sessions"`
+		} `json:"users"`
+		TotalSessions    int    `json:"total_sessions"`
+		OrphanedSessions int    `json:"orphaned_sessions"`
+		SigningKey       string `json:"signing_key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "USER\tSESSIONS\tLATEST EXPIRY\tSTATUS\n")
+	_, _ = fmt.Fprintf(w, "----\t--------\t-------------\t------\n")
+	for _, u := range result.Users {
+		name := u.Username
+		if name == "" {
+			name = u.UserID
+		}
+		status := "active"
+		if u.Orphaned {
+			status = "orphaned (" + u.Reason + ")"
+		}
+		expiry := "-"
+		if len(u.Sessions) > 0 {
+			expiry = u.Sessions[0].ExpiresAt.Local().Format("2006-01-02 15:04")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", name, len(u.Sessions), expiry, status)
+	}
+	_ = w.Flush()
+	fmt.Printf("\n%d session(s), %d orphaned; tokens signed with key %q\n",
+		result.TotalSessions, result.OrphanedSessions, result.SigningKey)
+	return nil
+}
+
+func cmdAdminSessionsRevokeAll(yes bool) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	if !yes {
+		fmt.Print("Log out every user (you included) and rotate the signing key? [y/N]: ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	resp, err := doRequest("POST", "/admin/sessions/revoke-all", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to revoke sessions (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		SessionsRevoked int    `json:"sessions_revoked"`
+		KeyRotated      bool   `json:"key_rotated"`
+		SigningKey      string `json:"signing_key"`
+		Warning         string `json:"warning"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Revoked %d session(s)\n", result.SessionsRevoked)
+	if result.KeyRotated {
+		fmt.Printf("🔑 Tokens are now signed with key %q\n", result.SigningKey)
+	} else {
+		fmt.Printf("⚠️  %s\n", result.Warning)
+	}
+	fmt.Println("Log in again to continue.")
+	return nil
+}
+
+const replicationUsage = `usage: admin replication [add <name> --url <api-url> --token <pat> | rm <remote> | sync <remote> (--user <id> | --files id1,id2)]`
+
+// cmdAdminReplication lists, adds and removes the remotes files are replicated to,
+// and queues replications to them
+func cmdAdminReplication(args []string) error {
+	if err := requireFeature("replication", "fl admin replication"); err != nil {
+		return err
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return cmdAdminReplicationList(token)
+	}
+	if len(args) < 2 {
+		return errors.New(replicationUsage)
+	}
+
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("replication add", flag.ContinueOnError)
+		remoteURL := fs.String("url", "", "API root of the remote, e.g. https://backup.example.com/api/v1")
+		remoteToken := fs.String("token", "", "personal access token on the remote (files:write)")
+		if err := ParseInterspersed(fs, args[2:]); err != nil {
+			return fmt.Errorf("failed to parse flags: %w", err)
+		}
+		if *remoteURL == "" || *remoteToken == "" {
+			return errors.New(replicationUsage)
+		}
+		body, _ := json.Marshal(map[string]string{"name": args[1], "url": *remoteURL, "token": *remoteToken})
+		resp, err := doRequest("POST", "/admin/replication/remotes", token, strings.NewReader(string(body)), "application/json")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != 201 {
+			b, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("failed to add remote (status %d): %s", resp.StatusCode, string(b))
+		}
+		var remote struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&remote)
+		fmt.Printf("✅ Remote %s added (%s)\n", args[1], remote.ID)
+		return nil
+
+	case "rm":
+		remoteID, err := resolveRemote(token, args[1])
+		if err != nil {
+			return err
+		}
+		resp, err := doRequest("DELETE", "/admin/replication/remotes/"+remoteID, token, nil, "")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != 200 {
+			b, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("failed to delete remote (status %d): %s", resp.StatusCode, string(b))
+		}
+		fmt.Printf("✅ Remote %s deleted (files on it were kept)\n", args[1])
+		return nil
+
+	case "sync":
+		fs := flag.NewFlagSet("replication sync", flag.ContinueOnError)
+		user := fs.String("user", "", "replicate all files of this user ID")
+		files := fs.String("files", "", "comma separated file IDs")
+		if err := ParseInterspersed(fs, args[2:]); err != nil {
+			return fmt.Errorf("failed to parse flags: %w", err)
+		}
+		if (*user == "") == (*files == "") {
+			return errors.New(replicationUsage)
+		}
+		remoteID, err := resolveRemote(token, args[1])
+		if err != nil {
+			return err
+		}
+		payload := map[string]interface{}{}
+		if *user != "" {
+			payload["user_id"] = *user
+		} else {
+			payload["file_ids"] = strings.Split(*files, ",")
+		}
+		body, _ := json.Marshal(payload)
+		resp, err := doRequest("POST", "/admin/replication/remotes/"+remoteID+"/sync", token, strings.NewReader(string(body)), "application/json")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != 202 {
+			b, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("failed to start replication (status %d): %s", resp.StatusCode, string(b))
+		}
+		var result struct {
+			Queued int `json:"queued"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		if result.Queued == 0 {
+			fmt.Printf("✅ %s is up to date\n", args[1])
+			return nil
+		}
+		fmt.Printf("✅ %d files queued for replication to %s\n", result.Queued, args[1])
+		return nil
+	}
+	return errors.New(replicationUsage)
+}
+
+type replicationRemote struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	URL              string     `json:"url"`
+	ReplicatedFiles  int        `json:"replicated_files"`
+	LastReplicatedAt *time.Time `json:"last_replicated_at"`
+}
+
+func listReplicationRemotes(token string) ([]replicationRemote, error) {
+	resp, err := doRequest("GET", "/admin/replication/remotes", token, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list remotes (status %d): %s", resp.StatusCode, string(b))
+	}
+	var result struct {
+		Remotes []replicationRemote `json:"remotes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Remotes, nil
+}
+
+// resolveRemote returns the ID of the remote with the given name or ID
+func resolveRemote(token, ref string) (string, error) {
+	remotes, err := listReplicationRemotes(token)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range remotes {
+		if r.ID == ref || r.Name == ref {
+			return r.ID, nil
+		}
+	}
+	return "", fmt.Errorf("no remote named %s", ref)
+}
+
+func cmdAdminReplicationList(token string) error {
+	remotes, err := listReplicationRemotes(token)
+	if err != nil {
+		return err
+	}
+	if len(remotes) == 0 {
+		fmt.Println("No remotes. Add one with: fl admin replication add <name> --url <api-url> --token <pat>")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "NAME\tURL\tFILES\tLAST REPLICATED\tID\n")
+	_, _ = fmt.Fprintf(w, "----\t---\t-----\t---------------\t--\n")
+	for _, r := range remotes {
+		last := "never"
+		if r.LastReplicatedAt != nil {
+			last = r.LastReplicatedAt.Local().Format("2006-01-02 15:04")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", r.Name, r.URL, r.ReplicatedFiles, last, r.ID)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+const snapshotsUsage = `usage: admin snapshots [create | restorable <id> | restore <id> --files id1,id2]`
+
+// cmdAdminSnapshots lists and takes metadata snapshots, and restores deleted
+// files from them
+func cmdAdminSnapshots(args []string) error {
+	if err := requireFeature("metadata_snapshots", "fl admin snapshots"); err != nil {
+		return err
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return cmdAdminSnapshotsList(token)
+	}
+
+	switch args[0] {
+	case "create":
+		resp, err := doRequest("POST", "/admin/snapshots", token, nil, "")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != 201 {
+			b, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("failed to take snapshot (status %d): %s", resp.StatusCode, string(b))
+		}
+		var snap metadataSnapshot
+		_ = json.NewDecoder(resp.Body).Decode(&snap)
+		fmt.Printf("✅ Snapshot %s taken: %d files, %d objects (WAL %s)\n", snap.ID, snap.Files, snap.Objects, snap.WALLSN)
+		return nil
+
+	case "restorable":
+		if len(args) < 2 {
+			return errors.New(snapshotsUsage)
+		}
+		return cmdAdminSnapshotsRestorable(token, args[1])
+
+	case "restore":
+		if len(args) < 2 {
+			return errors.New(snapshotsUsage)
+		}
+		fs := flag.NewFlagSet("snapshots restore", flag.ContinueOnError)
+		files := fs.String("files", "", "comma separated file IDs")
+		if err := ParseInterspersed(fs, args[2:]); err != nil {
+			return fmt.Errorf("failed to parse flags: %w", err)
+		}
+		if *files == "" {
+			return errors.New(snapshotsUsage)
+		}
+		body, _ := json.Marshal(map[string][]string{"file_ids": strings.Split(*files, ",")})
+		resp, err := doRequest("POST", "/admin/snapshots/"+url.PathEscape(args[1])+"/restore", token, strings.NewReader(string(body)), "application/json")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != 200 {
+			b, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("failed to restore files (status %d): %s", resp.StatusCode, string(b))
+		}
+		var result struct {
+			Restored []string          `json:"restored"`
+			Failed   map[string]string `json:"failed"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return err
+		}
+		for _, id := range result.Restored {
+			fmt.Printf("✅ %s restored\n", id)
+		}
+		for id, reason := range result.Failed {
+			fmt.Printf("❌ %s: %s\n", id, reason)
+		}
+		if len(result.Failed) > 0 {
+			return fmt.Errorf("%d of %d files not restored", len(result.Failed), len(result.Failed)+len(result.Restored))
+		}
+		return nil
+	}
+	return errors.New(snapshotsUsage)
+}
+
+type metadataSnapshot struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+	WALLSN      string    `json:"wal_lsn"`
+	Files       int       `json:"files"`
+	Objects     int       `json:"objects"`
+	ObjectBytes int64     `json:"object_bytes"`
+}
+
+func cmdAdminSnapshotsList(token string) error {
+	resp, err := doRequest("GET", "/admin/snapshots", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to list snapshots (status %d): %s", resp.StatusCode, string(b))
+	}
+	var result struct {
+		Snapshots []metadataSnapshot `json:"snapshots"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Snapshots) == 0 {
+		fmt.Println("No snapshots. Take one with: fl admin snapshots create")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "ID\tTAKEN\tFILES\tOBJECTS\tWAL LSN\tBY\n")
+	_, _ = fmt.Fprintf(w, "--\t-----\t-----\t-------\t-------\t--\n")
+	for _, s := range result.Snapshots {
+		by := "schedule"
+		if s.CreatedBy != "" {
+			by = s.CreatedBy
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d (%s)\t%s\t%s\n", s.ID, humanize.Time(s.CreatedAt), s.Files, s.Objects, humanize.Bytes(uint64(s.ObjectBytes)), s.WALLSN, by)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+// cmdAdminSnapshotsRestorable lists the files of a snapshot that were deleted since
+func cmdAdminSnapshotsRestorable(token, id string) error {
+	resp, err := doRequest("GET", "/admin/snapshots/"+url.PathEscape(id)+"/restorable", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to read snapshot (status %d): %s", resp.StatusCode, string(b))
+	}
+	var result struct {
+		Files []struct {
+			FileID       string `json:"file_id"`
+			FileName     string `json:"file_name"`
+			UserID       string `json:"user_id"`
+			Size         int64  `json:"size"`
+			ObjectExists bool   `json:"object_exists"`
+			OwnerExists  bool   `json:"owner_exists"`
+		} `json:"files"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Files) == 0 {
+		fmt.Printf("No files of snapshot %s were deleted since\n", id)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "FILE ID\tNAME\tSIZE\tOWNER\tRESTORABLE\n")
+	_, _ = fmt.Fprintf(w, "-------\t----\t----\t-----\t----------\n")
+	for _, f := range result.Files {
+		restorable := "yes"
+		switch {
+		case !f.ObjectExists:
+			restorable = "no (object gone)"
+		case !f.OwnerExists:
+			restorable = "no (owner gone)"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.FileID, f.FileName, humanize.Bytes(uint64(f.Size)), f.UserID, restorable)
+	}
+	_ = w.Flush()
+	fmt.Printf("\nRestore with: fl admin snapshots restore %s --files <id1,id2>\n", id)
+	return nil
+}
+
+const quarantineUsage = `usage: admin quarantine [approve <file-id> | reject <file-id>]`
+
+// cmdAdminQuarantine lists the uploads held back for review, and approves or
+// rejects them
+func cmdAdminQuarantine(args []string) error {
+	if err := requireFeature("upload_quarantine", "fl admin quarantine"); err != nil {
+		return err
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return cmdAdminQuarantineList(token)
+	}
+	if len(args) < 2 || (args[0] != "approve" && args[0] != "reject") {
+		return errors.New(quarantineUsage)
+	}
+
+	resp, err := doRequest("POST", "/admin/quarantine/"+url.PathEscape(args[1])+"/"+args[0], token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to %s file (status %d): %s", args[0], resp.StatusCode, string(b))
+	}
+	if args[0] == "approve" {
+		fmt.Printf("✅ File %s approved\n", args[1])
+	} else {
+		fmt.Printf("✅ File %s rejected and deleted\n", args[1])
+	}
+	return nil
+}
+
+func cmdAdminQuarantineList(token string) error {
+	resp, err := doRequest("GET", "/admin/quarantine", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to list quarantined files (status %d): %s", resp.StatusCode, string(b))
+	}
+	var result struct {
+		Files []struct {
+			FileID        string    `json:"file_id"`
+			FileName      string    `json:"file_name"`
+			Username      string    `json:"username"`
+			Size          int64     `json:"size"`
+			QuarantinedAt time.Time `json:"quarantined_at"`
+			Reason        string    `json:"reason"`
+		} `json:"files"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Files) == 0 {
+		fmt.Println("No files awaiting review")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "FILE ID\tNAME\tOWNER\tSIZE\tRULE\tSINCE\n")
+	_, _ = fmt.Fprintf(w, "-------\t----\t-----\t----\t----\t-----\n")
+	for _, f := range result.Files {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", f.FileID, f.FileName, f.Username, humanize.Bytes(uint64(f.Size)), f.Reason, humanize.Time(f.QuarantinedAt))
+	}
+	_ = w.Flush()
+	return nil
+}
+
+const reportsUsage = `usage: admin reports [--all] | admin reports (disable | dismiss) <report-id> [--note <text>]`
+
+// cmdAdminReports lists the abuse reports about share links, and resolves them
+// by disabling the reported link or dismissing the report
+func cmdAdminReports(args []string) error {
+	if err := requireFeature("share_reports", "fl admin reports"); err != nil {
+		return err
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fs := flag.NewFlagSet("reports", flag.ContinueOnError)
+		all := fs.Bool("all", false, "include resolved reports")
+		if err := ParseInterspersed(fs, args); err != nil {
+			return fmt.Errorf("failed to parse flags: %w", err)
+		}
+		return cmdAdminReportsList(token, *all)
+	}
+	if len(args) < 2 || (args[0] != "disable" && args[0] != "dismiss") {
+		return errors.New(reportsUsage)
+	}
+
+	fs := flag.NewFlagSet("reports "+args[0], flag.ContinueOnError)
+	note := fs.String("note", "", "note kept with the report")
+	if err := ParseInterspersed(fs, args[2:]); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	body, _ := json.Marshal(map[string]string{"action": args[0], "note": *note})
+	resp, err := doRequest("POST", "/admin/reports/"+url.PathEscape(args[1])+"/resolve", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to resolve report (status %d): %s", resp.StatusCode, string(b))
+	}
+	var result struct {
+		ReportsClosed int `json:"reports_closed"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if args[0] == "disable" {
+		fmt.Printf("✅ Share link disabled (%d report(s) closed)\n", result.ReportsClosed)
+	} else {
+		fmt.Printf("✅ Report %s dismissed\n", args[1])
+	}
+	return nil
+}
+
+func cmdAdminReportsList(token string, all bool) error {
+	path := "/admin/reports"
+	if all {
+		path += "?status=all"
+	}
+	resp, err := doRequest("GET", path, token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to list reports (status %d): %s", resp.StatusCode, string(b))
+	}
+	var result struct {
+		Reports []struct {
+			ID            string    `json:"id"`
+			FileName      string    `json:"file_name"`
+			OwnerUsername string    `json:"owner_username"`
+			Category      string    `json:"category"`
+			Message       string    `json:"message"`
+			ReporterEmail string    `json:"reporter_email"`
+			Status        string    `json:"status"`
+			CreatedAt     time.Time `json:"created_at"`
+		} `json:"reports"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Reports) == 0 && all {
+		fmt.Println("No reports")
+		return nil
+	}
+	if len(result.Reports) == 0 {
+		fmt.Println("No reports awaiting review")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "REPORT ID\tFILE\tOWNER\tCATEGORY\tSTATUS\tREPORTER\tFILED\tMESSAGE\n")
+	_, _ = fmt.Fprintf(w, "---------\t----\t-----\t--------\t------\t--------\t-----\t-------\n")
+	for _, r := range result.Reports {
+		message := strings.Join(strings.Fields(r.Message), " ")
+		if len([]rune(message)) > 50 {
+			message = string([]rune(message)[:47]) + "..."
+		}
+		reporter := r.ReporterEmail
+		if reporter == "" {
+			reporter = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.FileName, r.OwnerUsername, r.Category, r.Status, reporter, humanize.Time(r.CreatedAt), message)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+const usageReportUsage = `usage: admin usage-report (weekly | monthly) [--send]`
+
+// cmdAdminUsageReport shows the usage report of the last finished week or month,
+// or has the server send it to the configured recipients
+func cmdAdminUsageReport(args []string) error {
+	if err := requireFeature("usage_reports", "fl admin usage-report"); err != nil {
+		return err
+	}
+	if len(args) == 0 || (args[0] != "weekly" && args[0] != "monthly") {
+		return errors.New(usageReportUsage)
+	}
+	fs := flag.NewFlagSet("usage-report", flag.ContinueOnError)
+	send := fs.Bool("send", false, "send the report instead of showing it")
+	if err := ParseInterspersed(fs, args[1:]); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	path := "/admin/usage-reports/" + args[0]
+	method := "GET"
+	if *send {
+		method, path = "POST", path+"/send"
+	}
+	resp, err := doRequest(method, path, token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 && resp.StatusCode != 202 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get %s report (status %d): %s", args[0], resp.StatusCode, string(b))
+	}
+
+	if *send {
+		var result struct {
+			JobID string `json:"job_id"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Report queued (job %s)\n", result.JobID)
+		return nil
+	}
+	var result struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	fmt.Printf("📊 %s\n\n%s", result.Title, result.Text)
+	return nil
+}
+
+const adminUsageUsage = `usage: admin usage [export | send] [--month YYYY-MM] [-o file] [--json]`
+
+// cmdAdminUsage shows the usage records of a month (the last finished one by
+// default), saves them as CSV or has the server post them to the billing webhook
+func cmdAdminUsage(args []string) error {
+	if err := requireFeature("usage_records", "fl admin usage"); err != nil {
+		return err
+	}
+	action := ""
+	if len(args) > 0 && (args[0] == "export" || args[0] == "send") {
+		action, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("usage", flag.ContinueOnError)
+	month := fs.String("month", "", "month as YYYY-MM (default: the last finished month)")
+	output := fs.String("o", "", "CSV file for export (default: usage-<month>.csv)")
+	jsonOut := fs.Bool("json", false, "output json")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if fs.NArg() > 0 {
+		return errors.New(adminUsageUsage)
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	query := ""
+	if *month != "" {
+		query = "?month=" + url.QueryEscape(*month)
+	}
+	method, path := "GET", "/admin/usage"
+	switch action {
+	case "export":
+		path += "/export"
+	case "send":
+		method, path = "POST", path+"/send"
+	}
+	resp, err := doRequest(method, path+query, token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 && resp.StatusCode != 202 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get usage records (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	switch action {
+	case "export":
+		name := *output
+		if name == "" {
+			name = "usage.csv"
+			if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+				name = filepath.Base(params["filename"])
+			}
+		}
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		if _, err := io.Copy(f, resp.Body); err != nil {
+			return err
+		}
+		fmt.Printf("Exported to: %s\n", name)
+		return nil
+	case "send":
+		var result struct {
+			JobID string `json:"job_id"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Usage records queued for the billing webhook (job %s)\n", result.JobID)
+		return nil
+	}
+
+	if *jsonOut {
+		_, err := io.Copy(os.Stdout, resp.Body)
+		return err
+	}
+	var result struct {
+		Month    string `json:"month"`
+		Finished bool   `json:"finished"`
+		Records  []struct {
+			Username        string `json:"username"`
+			Email           string `json:"email"`
+			StorageByteDays int64  `json:"storage_byte_days"`
+			PeakBytes       int64  `json:"peak_storage_bytes"`
+			DownloadBytes   int64  `json:"download_bytes"`
+			Downloads       int    `json:"downloads"`
+		} `json:"records"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	state := ""
+	if !result.Finished {
+		state = " (in progress)"
+	}
+	fmt.Printf("📊 Usage for %s%s\n\n", result.Month, state)
+	if len(result.Records) == 0 {
+		fmt.Println("No usage recorded")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "USERNAME\tEMAIL\tSTORAGE\tPEAK\tDOWNLOADED\tDOWNLOADS")
+	for _, r := range result.Records {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s-days\t%s\t%s\t%d\n", r.Username, r.Email,
+			humanize.Bytes(uint64(r.StorageByteDays)), humanize.Bytes(uint64(r.PeakBytes)), humanize.Bytes(uint64(r.DownloadBytes)), r.Downloads)
+	}
+	return w.Flush()
+}
+
+const notificationsUsage = `usage: admin notifications [test <channel>]`
+
+// cmdAdminNotifications lists the chat channels events are posted to, or sends a
+// test message to one of them
+func cmdAdminNotifications(args []string) error {
+	if err := requireFeature("chat_notifications", "fl admin notifications"); err != nil {
+		return err
+	}
+	if len(args) > 0 && (args[0] != "test" || len(args) != 2) {
+		return errors.New(notificationsUsage)
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 2 {
+		resp, err := doRequest("POST", "/admin/notifications/"+url.PathEscape(args[1])+"/test", token, nil, "")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != 202 {
+			b, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("failed to send test notification (status %d): %s", resp.StatusCode, string(b))
+		}
+		var result struct {
+			JobID string `json:"job_id"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Test notification queued (job %s); a failed post shows up in GET /admin/jobs\n", result.JobID)
+		return nil
+	}
+
+	resp, err := doRequest("GET", "/admin/notifications", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to list notification channels (status %d): %s", resp.StatusCode, string(b))
+	}
+	var result struct {
+		Channels []struct {
+			Name     string   `json:"name"`
+			Provider string   `json:"provider"`
+			Events   []string `json:"events"`
+		} `json:"channels"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "CHANNEL\tPROVIDER\tEVENTS\n")
+	_, _ = fmt.Fprintf(w, "-------\t--------\t------\n")
+	for _, c := range result.Channels {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", c.Name, c.Provider, strings.Join(c.Events, ", "))
+	}
+	_ = w.Flush()
+	return nil
+}
+
+func cmdAdminSigningKeys(args []string) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+	switch {
+	case len(args) == 0:
+		resp, err = doRequest("GET", "/admin/signing-keys", token, nil, "")
+	case args[0] == "rotate":
+		resp, err = doRequest("POST", "/admin/signing-keys/rotate", token, nil, "")
+	default:
+		return errors.New("usage: admin signing-keys [rotate]")
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("signing key request failed (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Keys []struct {
+			ID     string     `json:"id"`
+			Status string     `json:"status"`
+			Since  *time.Time `json:"since"`
+			Until  *time.Time `json:"until"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(w, "KEY\tSTATUS\tDETAIL\n")
+	_, _ = fmt.Fprintf(w, "---\t------\t------\n")
+	for _, k := range result.Keys {
+		detail := ""
+		switch {
+		case k.Since != nil:
+			detail = "since " + k.Since.Local().Format("2006-01-02 15:04")
+		case k.Until != nil:
+			detail = "honoured until " + k.Until.Local().Format("2006-01-02 15:04")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", k.ID, k.Status, detail)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+func cmdAdminReadOnly(args []string) error {
+	if err := requireFeature("read_only_mode", "fl admin read-only"); err != nil {
+		return err
+	}
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+	switch {
+	case len(args) == 0:
+		resp, err = doRequest("GET", "/admin/read-only", token, nil, "")
+	case args[0] == "on" || args[0] == "off":
+		body, _ := json.Marshal(map[string]bool{"enabled": args[0] == "on"})
+		resp, err = doRequest("PUT", "/admin/read-only", token, strings.NewReader(string(body)), "application/json")
+	default:
+		return errors.New("usage: admin read-only [on|off]")
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("read-only request failed (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var state struct {
+		Enabled bool `json:"enabled"`
+		Users   []struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		} `json:"users"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return err
+	}
+	if state.Enabled {
+		fmt.Println("🔒 Read-only mode is ON for all users")
+	} else {
+		fmt.Println("✅ Read-only mode is off")
+	}
+	for _, u := range state.Users {
+		fmt.Printf("  read-only user: %s (%s)\n", u.Username, u.ID)
+	}
+	return nil
+}
+
+func cmdAdminFiles(args []string) error {
+	fs := flag.NewFlagSet("files", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "output json")
+	wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
+	fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	remainingArgs := fs.Args()
+	if len(remainingArgs) >= 2 && remainingArgs[0] == "delete" {
+		return cmdAdminFilesDelete(remainingArgs[1])
+	}
+	return cmdAdminFilesList(*jsonOut, *wideOut)
+}
+
+func cmdAdminFilesList(jsonOut bool, wideOut bool) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("GET", "/admin/files", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("failed to list files (status %d)", resp.StatusCode)
+	}
+
+	var result struct {
+		Files []struct {
+			ID       string `json:"id"`
+			FileName string `json:"filename"`
+			Size     int64  `json:"size"`
+		} `json:"files"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		b, _ := json.Marshal(result)
+		fmt.Println(string(b))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if wideOut {
+		_, _ = fmt.Fprintf(w, "FILE ID\tFILENAME\tSIZE\n")
+		_, _ = fmt.Fprintf(w, "-------\t--------\t----\n")
+	} else {
+		_, _ = fmt.Fprintf(w, "ID\tFILENAME\tSIZE\n")
+		_, _ = fmt.Fprintf(w, "---\t--------\t----\n")
+	}
+
+	for _, f := range result.Files {
+		id := f.ID
+		if !wideOut && len(id) > 8 {
+			id = id[:8] + "..."
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", id, f.FileName, humanize.Bytes(uint64(f.Size)))
+	}
+	_ = w.Flush()
+	return nil
+}
+
+func cmdAdminFilesDelete(id string) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("DELETE", "/admin/files/"+id, token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete file (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Println("✅ File deleted")
+	return nil
+}
+
+func cmdAdminStorage(args []string) error {
+	if len(args) < 1 {
+		return errors.New("storage subcommand required: analyze, cleanup, replica or reconcile")
+	}
+
+	subcmd := args[0]
+	switch subcmd {
+	case "analyze":
+		return cmdAdminStorageAnalyze()
+	case "cleanup":
+		return cmdAdminStorageCleanup()
+	case "replica":
+		return cmdAdminStorageReplica()
+	case "reconcile":
+		return cmdAdminStorageReconcile(args[1:])
+	default:
+		return fmt.Errorf("unknown storage subcommand: %s", subcmd)
+	}
+}
+
+// cmdAdminStorageReplica shows how far the standby storage lags behind
+func cmdAdminStorageReplica() error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("GET", "/admin/storage/replica", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get replica status (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var status struct {
+		Enabled          bool       `json:"enabled"`
+		Endpoint         string     `json:"endpoint"`
+		Pending          int        `json:"pending"`
+		Failed           int        `json:"failed"`
+		LagSeconds       float64    `json:"lag_seconds"`
+		LastReplicatedAt *time.Time `json:"last_replicated_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return err
+	}
+	if !status.Enabled {
+		fmt.Println("No standby storage configured (storage.minio.replica)")
+		return nil
+	}
+
+	last := "never"
+	if status.LastReplicatedAt != nil {
+		last = status.LastReplicatedAt.Local().Format("2006-01-02 15:04:05")
+	}
+	fmt.Println("🪞 Standby Storage:")
+	fmt.Printf("Endpoint:         %s\n", status.Endpoint)
+	fmt.Printf("Pending:          %d\n", status.Pending)
+	fmt.Printf("Lag:              %s\n", (time.Duration(status.LagSeconds) * time.Second).String())
+	fmt.Printf("Failed:           %d\n", status.Failed)
+	fmt.Printf("Last Replicated:  %s\n", last)
+	if status.Failed > 0 {
+		fmt.Println("\n⚠️  Some objects could not be replicated; run: fl admin storage reconcile")
+	}
+	return nil
+}
+
+// cmdAdminStorageReconcile queues the replication of every object the standby
+// storage is missing or has wrong
+func cmdAdminStorageReconcile(args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only report the differences")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	body, _ := json.Marshal(map[string]bool{"dry_run": *dryRun})
+	resp, err := doRequest("POST", "/admin/storage/replica/reconcile", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to reconcile the replica (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		PrimaryObjects int      `json:"primary_objects"`
+		ReplicaObjects int      `json:"replica_objects"`
+		Missing        []string `json:"missing"`
+		Different      []string `json:"different"`
+		Extra          []string `json:"extra"`
+		Queued         int      `json:"queued"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	fmt.Printf("Primary: %d objects, standby: %d objects\n", result.PrimaryObjects, result.ReplicaObjects)
+	fmt.Printf("Missing:    %d\n", len(result.Missing))
+	fmt.Printf("Different:  %d\n", len(result.Different))
+	fmt.Printf("Extra:      %d\n", len(result.Extra))
+	if *dryRun {
+		for _, p := range result.Missing {
+			fmt.Printf("  + %s\n", p)
+		}
+		for _, p := range result.Different {
+			fmt.Printf("  ~ %s\n", p)
+		}
+		for _, p := range result.Extra {
+			fmt.Printf("  - %s\n", p)
+		}
+		return nil
+	}
+	if result.Queued == 0 {
+		fmt.Println("✅ Standby is in sync")
+		return nil
+	}
+	fmt.Printf("✅ %d objects queued; follow with: fl admin storage replica\n", result.Queued)
+	return nil
+}
+
+func cmdAdminStorageAnalyze() error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("GET", "/admin/storage/analyze", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("failed to analyze storage (status %d)", resp.StatusCode)
+	}
+
+	var analysis struct {
+		TotalFiles     int   `json:"total_files"`
+		TotalSizeBytes int64 `json:"total_size_bytes"`
+		OrphanedFiles  int   `json:"orphaned_files"`
+		ExpiredFiles   int   `json:"expired_files"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&analysis); err != nil {
+		return err
+	}
+
+	fmt.Println("💾 Storage Analysis:")
+	fmt.Printf("Total Files:     %d\n", analysis.TotalFiles)
+	fmt.Printf("Total Size:      %s\n", humanize.Bytes(uint64(analysis.TotalSizeBytes)))
+	fmt.Printf("Orphaned Files:  %d\n", analysis.OrphanedFiles)
+	fmt.Printf("Expired Files:   %d\n", analysis.ExpiredFiles)
+	return nil
+}
+
+func cmdAdminStorageCleanup() error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("POST", "/admin/storage/cleanup", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to cleanup storage (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Message         string `json:"message"`
+		FilesDeleted    int    `json:"files_deleted"`
+		SpaceFreedBytes int64  `json:"space_freed_bytes"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	fmt.Println("🧹 Cleanup completed!")
+	fmt.Printf("Files Deleted:  %d\n", result.FilesDeleted)
+	fmt.Printf("Space Freed:    %s\n", humanize.Bytes(uint64(result.SpaceFreedBytes)))
+	return nil
+}
+
+type keyRotation struct {
+	ID               string `json:"id"`
+	KEKID            string `json:"kek_id"`
+	ReencryptObjects bool   `json:"reencrypt_objects"`
+	Status           string `json:"status"`
+	TotalFiles       int    `json:"total_files"`
+	ProcessedFiles   int    `json:"processed_files"`
+	FailedFiles      int    `json:"failed_files"`
+	LastError        string `json:"last_error"`
+}
+
+func cmdAdminRotateKeys(args []string) error {
+	fs := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	reencrypt := fs.Bool("reencrypt", false, "also re-encrypt stored objects with new data keys")
+	statusOnly := fs.Bool("status", false, "show the progress of the latest rotation")
+	wait := fs.Bool("wait", false, "wait until the rotation finishes")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	if !*statusOnly {
+		body, _ := json.Marshal(map[string]bool{"reencrypt_objects": *reencrypt})
+		resp, err := doRequest("POST", "/admin/keys/rotate", token, strings.NewReader(string(body)), "application/json")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusAccepted {
+			b, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("failed to start key rotation (status %d): %s", resp.StatusCode, string(b))
+		}
+
+		var job keyRotation
+		if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+			return err
+		}
+		fmt.Printf("🔑 Key rotation %s started: %d files to KEK %s\n", job.ID, job.TotalFiles, job.KEKID)
+		if !*wait {
+			fmt.Println("Check progress with: fl admin rotate-keys --status")
+			return nil
+		}
+	}
+
+	for {
+		job, err := getKeyRotation(token)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\r%-10s %d/%d files (%d failed)", job.Status, job.ProcessedFiles, job.TotalFiles, job.FailedFiles)
+		if !*wait || job.Status != "running" {
+			fmt.Println()
+			if job.LastError != "" {
+				fmt.Printf("Last error: %s\n", job.LastError)
+			}
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func getKeyRotation(token string) (*keyRotation, error) {
+	resp, err := doRequest("GET", "/admin/keys/rotation", token, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get key rotation (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Rotation keyRotation `json:"rotation"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result.Rotation, nil
+}
+
+func cmdAdminLogs(args []string) error {
+	fs := flag.NewFlagSet("logs", flag.ContinueOnError)
+	action := fs.String("action", "", "filter by action")
+	userID := fs.String("user_id", "", "filter by user id")
+	limit := fs.Int("limit", 0, "entries per page (default 50, at most 500)")
+	cursor := fs.String("cursor", "", "continue a paged listing")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if *action != "" {
+		query.Set("action", *action)
+	}
+	if *userID != "" {
+		query.Set("user_id", *userID)
+	}
+	if *limit > 0 {
+		query.Set("limit", strconv.Itoa(*limit))
+	}
+	if *cursor != "" {
+		query.Set("cursor", *cursor)
+	}
+
+	resp, err := doRequest("GET", "/admin/logs?"+query.Encode(), token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("failed to get logs (status %d)", resp.StatusCode)
+	}
+
+	var result struct {
+		Logs []struct {
+			ID        string `json:"id"`
+			UserID    string `json:"actor_id"`
+			Action    string `json:"action"`
+			Details   []byte `json:"metadata"`
+			IPAddress struct {
+				String string `json:"String"`
+				Valid  bool   `json:"Valid"`
+			} `json:"ip_address"`
+			Timestamp string `json:"created_at"`
+		} `json:"logs"`
+		NextCursor string `json:"next_cursor"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	for _, log := range result.Logs {
+		ipAddr := "unknown"
+		if log.IPAddress.Valid {
+			ipAddr = log.IPAddress.String
+		}
+		details := string(log.Details)
+		if details == "" || details == "null" {
+			details = "-"
+		}
+		fmt.Printf("[%s] %s - %s (%s) - %s\n",
+			log.Timestamp,
+			log.Action,
+			log.UserID[:8]+"...",
+			ipAddr,
+			details)
+	}
+	if result.NextCursor != "" {
+		fmt.Printf("\nMore entries: fl admin logs --cursor %s\n", result.NextCursor)
+	}
+	return nil
+}
+
+func cmdAdminAnnouncements(args []string) error {
+	fs := flag.NewFlagSet("announcements", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "output json")
+	wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
+	fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	remainingArgs := fs.Args()
+	if len(remainingArgs) == 0 {
+		return cmdAdminAnnouncementsList(*jsonOut, *wideOut)
+	}
+
+	subcmd := remainingArgs[0]
+	switch subcmd {
+	case "create":
+		return cmdAdminAnnouncementsCreate(remainingArgs[1:])
+	case "delete":
+		return cmdAdminAnnouncementsDelete(remainingArgs[1:])
+	default:
+		return fmt.Errorf("unknown announcements subcommand: %s", subcmd)
+	}
+}
+
+func cmdAdminAnnouncementsList(jsonOut bool, wideOut bool) error {
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("GET", "/admin/announcements", token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("failed to list announcements (status %d)", resp.StatusCode)
+	}
+
+	var result struct {
+		Announcements []struct {
+			ID       string `json:"id"`
+			Title    string `json:"title"`
+			Message  string `json:"message"`
+			Severity string `json:"severity"`
+		} `json:"announcements"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		b, _ := json.Marshal(result)
+		fmt.Println(string(b))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if wideOut {
+		_, _ = fmt.Fprintf(w, "ANNOUNCEMENT ID\tSEVERITY\tTITLE\n")
+		_, _ = fmt.Fprintf(w, "---------------\t--------\t-----\n")
+	} else {
+		_, _ = fmt.Fprintf(w, "ID\tSEVERITY\tTITLE\n")
+		_, _ = fmt.Fprintf(w, "---\t--------\t-----\n")
+	}
+
+	if len(result.Announcements) == 0 {
+		fmt.Println("No announcements.")
+		return nil
+	}
+
+	for _, a := range result.Announcements {
+		id := a.ID
+		if !wideOut && len(id) > 8 {
+			id = id[:8] + "..."
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", id, a.Severity, a.Title)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+func cmdAdminAnnouncementsCreate(args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	title := fs.String("title", "", "announcement title")
+	message := fs.String("message", "", "announcement message")
+	severity := fs.String("severity", "info", "severity: info, warning, error")
+
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	if *title == "" || *message == "" {
+		return errors.New("--title and --message required")
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{
+		"title":    *title,
+		"message":  *message,
+		"severity": *severity,
+	}
+
+	body, _ := json.Marshal(payload)
+	resp, err := doRequest("POST", "/admin/announcements", token, strings.NewReader(string(body)), "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 201 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to create announcement (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Println("✅ Announcement created")
+	return nil
+}
+
+func cmdAdminAnnouncementsDelete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("announcement id required")
+	}
+	id := args[0]
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest("DELETE", "/admin/announcements/"+id, token, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete announcement (status %d): %s", resp.StatusCode, string(b))
+	}
+
+	fmt.Println("✅ Announcement deleted")
+	return nil
+}
+
+func printAdminHelp() {
+	fmt.Println("fl admin - Admin Commands")
+	fmt.Println("\n📊 System Management:")
+	fmt.Println("  admin stats                        System statistics")
+	fmt.Println("  admin stats content-types          Storage by MIME type and extension [--refresh] [--top n]")
+	fmt.Println("  admin usage-report weekly|monthly  Usage report of the last week/month [--send]")
+	fmt.Println("  admin usage [export|send]          Monthly usage per user [--month YYYY-MM] [-o file]")
+	fmt.Println("  admin notifications [test <name>]  Chat channels events are posted to (test: send a message)")
+	fmt.Println("\n👥 User Management:")
+	fmt.Println("  admin users [--status pending]     List users (supports --json, --wide/-w)")
+	fmt.Println("  admin users approve <id>           Approve user")
+	fmt.Println("  admin users reject <id>            Reject user")
+	fmt.Println("  admin users delete <id>            Delete user")
+	fmt.Println("  admin users <id> status <active>   Update user status")
+	fmt.Println("  admin users <id> role <admin>      Update user role")
+	fmt.Println("  admin users <id> reset-password    Reset user password")
+	fmt.Println("  admin users <id> logout            Force logout user")
+	fmt.Println("  admin users <id> profile [--username u] [--email e] [--name n]  Change a user's profile")
+	fmt.Println("  admin sessions                     Sessions by user (orphaned ones flagged)")
+	fmt.Println("  admin sessions revoke-all [--yes]  Log everyone out and rotate the signing key")
+	fmt.Println("  admin signing-keys [rotate]        Show or rotate the session signing keys (no logout)")
+	fmt.Println("  admin users <id> read-only <on|off> Block uploads, deletes and edits for a user")
+	fmt.Println("  admin users <id> transfer-files --to <user> [--files id1,id2]  Give a user's files to another user")
+	fmt.Println("  admin users <id> storage [--months n] Storage report: growth, largest files, types (--json)")
+	fmt.Println("\n⚙️  Settings:")
+	fmt.Println("  admin settings                     View system settings")
+	fmt.Println("  admin settings <key> <value>       Update setting")
+	fmt.Println("  admin maintenance [on|off]         Show or toggle maintenance mode (503 for non-admins)")
+	fmt.Println("          [--message <msg>]          Message shown to users")
+	fmt.Println("  admin read-only [on|off]           Show or toggle read-only mode for all users")
+	fmt.Println("\n📁 File Management:")
+	fmt.Println("  admin files [--json] [--wide/-w]   List all files")
+	fmt.Println("  admin files delete <id>            Delete any file")
+	fmt.Println("  admin quarantine                   Uploads held back for review")
+	fmt.Println("  admin quarantine approve <id>      Let share links serve it")
+	fmt.Println("  admin quarantine reject <id>       Delete it for good")
+	fmt.Println("  admin reports [--all]              Abuse reports about share links")
+	fmt.Println("  admin reports disable <id>         Disable the reported link [--note <text>]")
+	fmt.Println("  admin reports dismiss <id>         Close the report, keep the link [--note <text>]")
+	fmt.Println("\n💾 Storage:")
+	fmt.Println("  admin storage analyze              Analyze storage usage")
+	fmt.Println("  admin storage cleanup              Cleanup orphaned files")
+	fmt.Println("  admin storage replica              Standby storage lag")
+	fmt.Println("  admin storage reconcile [--dry-run]  Re-sync the standby storage (before failover)")
+	fmt.Println("\n🔑 Encryption Keys:")
+	fmt.Println("  admin rotate-keys [--reencrypt]    Re-wrap all data keys under the current KEK")
+	fmt.Println("          [--wait]                   Wait and show progress until done")
+	fmt.Println("  admin rotate-keys --status         Show progress of the latest rotation")
+	fmt.Println("\n🛰️  Replication (off-site copies on another File Locker):")
+	fmt.Println("  admin replication                  List remotes")
+	fmt.Println("  admin replication add <name> --url <api-url> --token <pat>  Add a remote")
+	fmt.Println("  admin replication rm <remote>      Delete a remote (files on it stay)")
+	fmt.Println("  admin replication sync <remote> --user <id> | --files id1,id2  Copy new files to it")
+	fmt.Println("\n🗂️  Metadata Snapshots:")
+	fmt.Println("  admin snapshots                    List snapshots")
+	fmt.Println("  admin snapshots create             Take a snapshot now")
+	fmt.Println("  admin snapshots restorable <id>    Files of a snapshot deleted since")
+	fmt.Println("  admin snapshots restore <id> --files id1,id2  Bring deleted files back")
+	fmt.Println("\n📜 Audit Logs:")
+	fmt.Println("  admin logs [--action] [--user_id]  View audit logs")
+	fmt.Println("          [--limit n] [--cursor c]   Page through older entries")
+	fmt.Println("\n📢 Announcements:")
+	fmt.Println("  admin announcements [--json] [-w]  List all announcements")
+	fmt.Println("  admin announcements create         Create announcement")
+	fmt.Println("          --title <title> --message <msg> [--severity info|warning|error]")
+	fmt.Println("  admin announcements delete <id>    Delete announcement")
+	fmt.Println("\n📖 Examples:")
+	fmt.Println("  fl admin stats")
+	fmt.Println("  fl admin users --status pending --wide")
+	fmt.Println("  fl admin files --json")
+	fmt.Println("  fl admin storage cleanup")
+	fmt.Println("  fl admin rotate-keys --wait")
+	fmt.Println("  fl admin maintenance on --message \"Upgrading storage, back in 10 minutes\"")
+	fmt.Println("  fl admin logs --action upload")
+	fmt.Println("  fl admin announcements create --title \"Maintenance\" --message \"Scheduled downtime\" --severity warning")
+}
+
+func cmdVersion() error {
+	fmt.Printf("fl %s (API version %d)\n", Version, supportedAPIVersion)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		cfg = &CLIConfig{BaseURL: apiBase}
+	}
+	refreshServerInfo(cfg)
+	if cfg.Server == nil {
+		return nil
+	}
+	_ = saveConfig(*cfg)
+
+	info := cfg.Server
+	if info.Legacy {
+		fmt.Printf("Server: %s (does not report its version)\n", getBaseURL())
+		return nil
+	}
+	fmt.Printf("Server: %s %s (API version %d)\n", getBaseURL(), info.Version, info.APIVersion)
+
+	var enabled []string
+	for feature, on := range info.Features {
+		if on {
+			enabled = append(enabled, feature)
+		}
+	}
+	sort.Strings(enabled)
+	fmt.Printf("Features: %s\n", strings.Join(enabled, ", "))
+	return nil
+}
+
+func printUsage() {
+	fmt.Println("fl - File Locker CLI")
+	fmt.Println("\n🔐 Authentication:")
+	fmt.Println("  login --token <token>              Login with Personal Access Token")
+	fmt.Println("  login -u <user> -p <pass>          Login with username/password")
+	fmt.Println("  login --host <url>                 Set server URL")
+	fmt.Println("  logout                             Logout and clear credentials")
+	fmt.Println("  me                                 Show current user info")
+	fmt.Println("  whoami                             Alias for 'me'")
+	fmt.Println("  version                            Show CLI and server versions and server features")
+
+	fmt.Println("\n📁 File Operations:")
+	fmt.Println("  ls [--json] [--wide/-w]            List files (table, JSON, or wide format)")
+	fmt.Println("  ls --all | --cursor <c>            Every file / the next page (with a page size preference)")
+	fmt.Println("  upload <file> [--tags t1,t2]       Upload file with optional tags")
+	fmt.Println("                [--expire 24]        Set expiration in hours (0 = never; default: preference)")
+	fmt.Println("                [--limit-rate 5M]    Cap upload speed (bytes/s; K, M, G suffixes)")
+	fmt.Println("  watch <dir> [--tags t1,t2]         Upload new and modified files in a directory")
+	fmt.Println("              [--debounce 2s]        Wait until a file is quiet (.flignore skips files)")
+	fmt.Println("  watch status [--all]               Show the upload queue of watched directories")
+	fmt.Println("  download <name|id> [-o filename]   Download file by name or ID prefix")
+	fmt.Println("                     [--limit-rate]  Cap download speed")
+	fmt.Println("  cat <name|id>                      Write a file to stdout")
+	fmt.Println("  head <name|id> [-n 10] [-c bytes]  Show the start of a file (fetches only that part)")
+	fmt.Println("  info <name|id> [--json]            Show the details of a file")
+	fmt.Println("  rm <name|id> [--force/-f]          Move file to trash (--force: delete permanently)")
+	fmt.Println("  trash ls [--json] [--wide/-w]      List deleted files")
+	fmt.Println("  trash empty [--yes/-y]             Permanently delete everything in the trash")
+	fmt.Println("  restore <name|id>                  Restore file from the trash")
+	fmt.Println("  search <query> [--json] [--cursor] Search files by name or tags")
+	fmt.Println("  export [-o output.zip]             Export all files as zip")
+	fmt.Println("  update <file_id> --tags t1,t2      Update file metadata")
+	fmt.Println("         <file_id> --name newname    Rename file")
+	fmt.Println("  cp <name|id> [--folder] [--name]   Copy a file on the server (no re-upload)")
+	fmt.Println("  mv <name|id> [--folder] [--name]   Move or rename a file")
+	fmt.Println("  tag add <tag[,tag2]> <id>...       Add tags to several files")
+	fmt.Println("  tag rm <tag[,tag2]> <id>...        Remove tags from several files")
+
+	fmt.Println("\n🔑 Personal Access Tokens:")
+	fmt.Println("  tokens list [--json] [--wide/-w]   List all PATs (supports wide format)")
+	fmt.Println("  tokens create <name> [--expire] [--scopes]  Create new PAT")
+	fmt.Println("  tokens revoke <token_id>           Revoke PAT")
+
+	fmt.Println("\n👤 User Management:")
+	fmt.Println("  password --old <old> --new <new>   Change password")
+	fmt.Println("  profile [--name <n>] [--email <e> --password <p>]  Update display name / start email change")
+	fmt.Println("  profile verify-email <code>        Confirm a new email address")
+	fmt.Println("  profile avatar <image> | --remove  Set (JPEG/PNG/GIF, max 2 MB) or remove your avatar")
+	fmt.Println("  preferences [--timezone tz] [--locale l] [--expiry h] [--page-size n] [--share-notices=false] [--quota-emails=false]")
+	fmt.Println("                                     Show or change your preferences (alias: prefs)")
+
+	fmt.Println("\n📢 Announcements:")
+	fmt.Println("  announcements                      List announcements")
+	fmt.Println("  announcements dismiss <id>         Dismiss announcement")
+
+	// Conditionally show admin section
+	if isAdmin() {
+		fmt.Println("\n🛡️  Admin Commands:")
+		fmt.Println("  admin help                         Show detailed admin commands")
+		fmt.Println("  admin stats                        System statistics")
+		fmt.Println("  admin users                        User management")
+		fmt.Println("  admin files                        File management")
+		fmt.Println("  admin storage [analyze/cleanup]    Storage operations")
+		fmt.Println("  admin logs                         Audit logs")
+		fmt.Println("  admin announcements                Announcement management")
+		fmt.Println("  admin settings                     System settings")
+		fmt.Println("  admin maintenance [on|off]         Maintenance mode")
+	} else {
+		fmt.Println("\n🛡️  Admin: Login as admin to access admin commands")
+	}
+
+	fmt.Println("\n📖 Examples:")
+	fmt.Println("  fl login --token fl_abc123...")
+	fmt.Println("  fl ls --wide                       # Show full file IDs")
+	fmt.Println("  fl upload document.pdf --tags work,important --expire 72")
+	fmt.Println("  fl upload backup.tar --limit-rate 5M")
+	fmt.Println("  fl watch ./folder --tags autosync")
+	fmt.Println("  fl search \"project files\" --json")
+	fmt.Println("  fl tag add work <id1> <id2>")
+	fmt.Println("  fl tokens list --wide              # Show full token IDs")
+	fmt.Println("  fl tokens create \"CI/CD Pipeline\"")
+	if isAdmin() {
+		fmt.Println("  fl admin users --status pending --wide")
+		fmt.Println("  fl admin storage cleanup")
+	}
+
+	fmt.Println("\n💡 Tip: Use --wide or -w to see full IDs (useful for copy-paste)")
+	fmt.Println("💡 Tip: Flags can be placed before or after arguments")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		return
+	}
+	cmd := os.Args[1]
+	switch cmd {
+	case "login":
+		if err := cmdLogin(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "ls":
+		fs := flag.NewFlagSet("ls", flag.ContinueOnError)
+		jsonOut := fs.Bool("json", false, "output json")
+		wideOut := fs.Bool("wide", false, "show full IDs and additional columns")
+		fs.BoolVar(wideOut, "w", false, "shorthand for --wide")
+		all := fs.Bool("all", false, "list every file, whatever your page size preference")
+		cursor := fs.String("cursor", "", "continue a paged listing")
+		_ = fs.Parse(os.Args[2:])
+		if err := cmdLs(*jsonOut, *wideOut, *all, *cursor); err != nil {
+			exitWithError(err)
+		}
+	case "upload":
+		if err := cmdUpload(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "watch":
+		if err := cmdWatch(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "download":
+		if err := cmdDownload(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "cat":
+		if err := cmdCat(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "head":
+		if err := cmdHead(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "rm":
+		if err := cmdRm(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "trash":
+		if err := cmdTrash(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "restore":
+		if err := cmdRestore(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "logout":
+		if err := cmdLogout(); err != nil {
+			exitWithError(err)
+		}
+	case "version", "--version":
+		if err := cmdVersion(); err != nil {
+			exitWithError(err)
+		}
+	case "me", "whoami":
+		if err := cmdMe(); err != nil {
+			exitWithError(err)
+		}
+	case "info":
+		if err := cmdInfo(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "search":
+		if err := cmdSearch(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "export":
+		if err := cmdExport(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "update":
+		if err := cmdUpdate(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "cp":
+		if err := cmdCopy(os.Args[2:], false); err != nil {
+			exitWithError(err)
+		}
+	case "mv":
+		if err := cmdCopy(os.Args[2:], true); err != nil {
+			exitWithError(err)
+		}
+	case "tag":
+		if err := cmdTag(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "tokens":
+		if err := cmdTokens(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "password":
+		if err := cmdPassword(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "profile":
+		if err := cmdProfile(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "preferences", "prefs":
+		if err := cmdPreferences(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "announcements":
+		if err := cmdAnnouncements(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	case "admin":
+		if err := cmdAdmin(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+	default:
+		printUsage()
+	}
+}
+
+// ----------------------------------------------------------------
+// Watch Mode
+// ----------------------------------------------------------------
+
+// Statuses of files in the watch queue
+const (
+	watchQueued    = "queued"
+	watchUploading = "uploading"
+	watchUploaded  = "uploaded"
+	watchFailed    = "failed"
+)
+
+const (
+	watchIgnoreFile = ".flignore"
+	watchHeartbeat  = 30 * time.Second
+)
+
+// watchState is the queue of one `fl watch` process, saved to
+// ~/.filelocker/watch/<hash>.json so `fl watch status` can show it and a restarted
+// watch only uploads files that changed in between
+type watchState struct {
+	Dir       string                  `json:"dir"`
+	PID       int                     `json:"pid"`
+	Tags      string                  `json:"tags"`
+	StartedAt time.Time               `json:"started_at"`
+	UpdatedAt time.Time               `json:"updated_at"` // heartbeat while running
+	Files     map[string]*watchedFile `json:"files"`      // by path relative to Dir
+}
+
+type watchedFile struct {
+	Status    string    `json:"status"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"mod_time"`
+	FileID    string    `json:"file_id,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func watchStateDir() (string, error) {
+	p, err := cfgPath()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(filepath.Dir(p), "watch")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func watchStatePath(dir string) (string, error) {
+	stateDir, err := watchStateDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(dir))
+	return filepath.Join(stateDir, hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func loadWatchState(path string) (*watchState, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var st watchState
+	if err := json.Unmarshal(b, &st); err != nil {
+		return nil, err
+	}
+	if st.Files == nil {
+		st.Files = make(map[string]*watchedFile)
+	}
+	return &st, nil
+}
+
+// ignoreRules holds the patterns of a .flignore file: one glob per line, matched
+// against the file name or, if it contains a slash, the path relative to the
+// watched directory; a trailing slash matches directories only; # starts a comment
+type ignoreRules []string
+
+func loadIgnoreRules(dir string) ignoreRules {
+	b, err := os.ReadFile(filepath.Join(dir, watchIgnoreFile))
+	if err != nil {
+		return nil
+	}
+	var rules ignoreRules
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, strings.TrimPrefix(line, "/"))
+	}
+	return rules
+}
+
+// match reports whether rel (slash separated, relative to the watched directory)
+// or one of its parent directories is ignored
+func (rules ignoreRules) match(rel string, isDir bool) bool {
+	parts := strings.Split(rel, "/")
+	if parts[len(parts)-1] == watchIgnoreFile {
+		return true
+	}
+	for i := range parts {
+		dirOnly := i < len(parts)-1 || isDir
+		sub := strings.Join(parts[:i+1], "/")
+		for _, rule := range rules {
+			pattern := strings.TrimSuffix(rule, "/")
+			if pattern != rule && !dirOnly {
+				continue
+			}
+			name := parts[i]
+			if strings.Contains(pattern, "/") {
+				name = sub
+			}
+			if ok, _ := filepath.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type watcher struct {
+	dir         string
+	token       string
+	tags        string
+	expireHours int
+	limit       int64
+	replace     bool
+	debounce    time.Duration
+	statePath   string
+
+	fsw    *fsnotify.Watcher
+	queue  chan string
+	mu     sync.Mutex
+	state  *watchState
+	rules  ignoreRules
+	timers map[string]*time.Timer
+}
+
+func cmdWatch(args []string) error {
+	if len(args) > 0 && args[0] == "status" {
+		return cmdWatchStatus(args[1:])
+	}
+
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	tags := fs.String("tags", "", "comma separated tags for uploaded files")
+	expire := fs.Int("expire", -1, "expiration time in hours (0 = never; default: your default expiry preference)")
+	debounce := fs.Duration("debounce", 2*time.Second, "wait until a file has not changed for this long")
+	replace := fs.Bool("replace", false, "move the previous upload of a modified file to the trash")
+	skipExisting := fs.Bool("skip-existing", false, "only upload files that change after the watch starts")
+	var limit rateFlag
+	fs.Var(&limit, "limit-rate", "maximum upload rate in bytes per second (e.g. 500K, 5M)")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if fs.NArg() < 1 {
+		return errors.New("directory required")
+	}
+
+	dir, err := filepath.Abs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(dir); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	token, err := loadToken()
+	if err != nil {
+		return err
+	}
+
+	statePath, err := watchStatePath(dir)
+	if err != nil {
+		return err
+	}
+	state, err := loadWatchState(statePath)
+	if err != nil {
+		state = &watchState{Files: make(map[string]*watchedFile)}
+	}
+	state.Dir = dir
+	state.PID = os.Getpid()
+	state.Tags = *tags
+	state.StartedAt = time.Now()
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fsw.Close() }()
+
+	w := &watcher{
+		dir:         dir,
+		token:       token,
+		tags:        *tags,
+		expireHours: *expire,
+		limit:       int64(limit),
+		replace:     *replace,
+		debounce:    *debounce,
+		statePath:   statePath,
+		fsw:         fsw,
+		queue:       make(chan string, 1024),
+		state:       state,
+		rules:       loadIgnoreRules(dir),
+		timers:      make(map[string]*time.Timer),
+	}
+
+	// Uploads interrupted last time are tried again
+	for _, f := range state.Files {
+		if f.Status == watchUploading || f.Status == watchQueued {
+			f.Status = watchFailed
+			f.Error = "interrupted"
+		}
+	}
+	w.checkUploaded()
+
+	go w.uploadLoop()
+
+	if err := w.addTree(dir, !*skipExisting); err != nil {
+		return err
+	}
+	w.save()
+
+	fmt.Printf("👀 Watching %s (Ctrl+C to stop, 'fl watch status' to see the queue)\n", dir)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	heartbeat := time.NewTicker(watchHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case ev, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			w.handleEvent(ev)
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintln(os.Stderr, "Watch error:", err)
+		case <-heartbeat.C:
+			w.save()
+		case <-sigs:
+			fmt.Println("\nStopped watching.")
+			w.mu.Lock()
+			w.state.PID = 0
+			w.mu.Unlock()
+			w.save()
+			return nil
+		}
+	}
+}
+
+// addTree watches dir and its subdirectories (fsnotify is not recursive) and, if
+// scan is set, queues the files in them that are new or changed since last upload
+func (w *watcher) addTree(dir string, scan bool) error {
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // vanished or unreadable, skip it
+		}
+		rel := w.rel(path)
+		if rel != "" && w.ignored(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if err := w.fsw.Add(path); err != nil {
+				return fmt.Errorf("failed to watch %s: %w", path, err)
+			}
+			return nil
+		}
+		if scan && d.Type().IsRegular() {
+			w.enqueue(rel)
+		} else if !scan {
+			w.baseline(rel)
+		}
+		return nil
+	})
+}
+
+// checkUploaded asks the server whether the files uploaded in earlier runs are
+// still there; those deleted or expired since are uploaded again
+func (w *watcher) checkUploaded() {
+	api := apiClient(w.token)
+	for _, f := range w.state.Files {
+		if f.Status != watchUploaded || f.FileID == "" {
+			continue
+		}
+		_, err := api.StatFile(context.Background(), f.FileID)
+		if client.IsStatus(err, http.StatusNotFound) || client.IsStatus(err, http.StatusGone) {
+			f.Status = watchFailed
+			f.Error = "no longer on the server"
+			f.FileID = ""
+		}
+	}
+}
+
+func (w *watcher) rel(path string) string {
+	rel, err := filepath.Rel(w.dir, path)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (w *watcher) ignored(rel string, isDir bool) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rules.match(rel, isDir)
+}
+
+// baseline records an existing file as uploaded without uploading it
+func (w *watcher) baseline(rel string) {
+	info, err := os.Stat(filepath.Join(w.dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.state.Files[rel]; !ok {
+		w.state.Files[rel] = &watchedFile{Status: watchUploaded, Size: info.Size(), ModTime: info.ModTime(), UpdatedAt: time.Now()}
+	}
+}
+
+func (w *watcher) handleEvent(ev fsnotify.Event) {
+	rel := w.rel(ev.Name)
+	if rel == "" {
+		return
+	}
+
+	if rel == watchIgnoreFile {
+		w.mu.Lock()
+		w.rules = loadIgnoreRules(w.dir)
+		w.mu.Unlock()
+		return
+	}
+
+	if ev.Has(fsnotify.Remove) || ev.Has(fsnotify.Rename) {
+		// Uploads are kept on the server; just stop waiting for the file
+		w.mu.Lock()
+		if t := w.timers[rel]; t != nil {
+			t.Stop()
+			delete(w.timers, rel)
+		}
+		if f := w.state.Files[rel]; f != nil && f.Status == watchQueued {
+			delete(w.state.Files, rel)
+		}
+		w.mu.Unlock()
+		return
+	}
+	if !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Write) {
+		return
+	}
+
+	info, err := os.Stat(ev.Name)
+	if err != nil {
+		return
+	}
+	if info.IsDir() {
+		if ev.Has(fsnotify.Create) && !w.ignored(rel, true) {
+			if err := w.addTree(ev.Name, true); err != nil {
+				fmt.Fprintln(os.Stderr, "Watch error:", err)
+			}
+		}
+		return
+	}
+	if !info.Mode().IsRegular() || w.ignored(rel, false) {
+		return
+	}
+
+	// Debounce: upload once the file has been quiet for a while, so a file being
+	// written or saved in several steps is uploaded once
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t := w.timers[rel]; t != nil {
+		t.Reset(w.debounce)
+		return
+	}
+	w.timers[rel] = time.AfterFunc(w.debounce, func() {
+		w.mu.Lock()
+		delete(w.timers, rel)
+		w.mu.Unlock()
+		w.enqueue(rel)
+	})
+}
+
+// enqueue queues a file unless it is unchanged since its last upload
+func (w *watcher) enqueue(rel string) {
+	info, err := os.Stat(filepath.Join(w.dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	f := w.state.Files[rel]
+	if f != nil && (f.Status == watchQueued ||
+		f.Status == watchUploaded && f.Size == info.Size() && f.ModTime.Equal(info.ModTime())) {
+		w.mu.Unlock()
+		return
+	}
+	if f == nil {
+		f = &watchedFile{}
+		w.state.Files[rel] = f
+	}
+	f.Status = watchQueued
+	f.Error = ""
+	f.UpdatedAt = time.Now()
+	w.mu.Unlock()
+
+	w.save()
+	w.queue <- rel
+}
+
+// uploadLoop uploads queued files one at a time
+func (w *watcher) uploadLoop() {
+	for rel := range w.queue {
+		path := filepath.Join(w.dir, filepath.FromSlash(rel))
+		info, err := os.Stat(path)
+
+		w.mu.Lock()
+		f := w.state.Files[rel]
+		if f == nil || f.Status != watchQueued || err != nil {
+			if f != nil && err != nil {
+				delete(w.state.Files, rel) // removed while queued
+			}
+			w.mu.Unlock()
+			continue
+		}
+		previousID := f.FileID
+		f.Status = watchUploading
+		f.UpdatedAt = time.Now()
+		w.mu.Unlock()
+		w.save()
+
+		fmt.Printf("⬆️  %s\n", rel)
+		fileID, err := uploadWithProgress(w.token, path, w.tags, w.expireHours, w.limit)
+
+		w.mu.Lock()
+		f.UpdatedAt = time.Now()
+		if err != nil {
+			f.Status = watchFailed
+			f.Error = err.Error()
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", rel, err)
+		} else {
+			f.Status = watchUploaded
+			f.FileID = fileID
+			f.Size = info.Size()
+			f.ModTime = info.ModTime()
+		}
+		w.mu.Unlock()
+		w.save()
+
+		if err == nil && w.replace && previousID != "" && previousID != fileID {
+			if _, err := deleteFile(w.token, previousID, false); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to delete previous upload of %s: %v\n", rel, err)
+			}
+		}
+	}
+}
+
+func (w *watcher) save() {
+	w.mu.Lock()
+	w.state.UpdatedAt = time.Now()
+	b, err := json.MarshalIndent(w.state, "", "  ")
+	w.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	// Write and rename so `fl watch status` never reads a partial file
+	tmp := w.statePath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err == nil {
+		_ = os.Rename(tmp, w.statePath)
+	}
+}
+
+func cmdWatchStatus(args []string) error {
+	fs := flag.NewFlagSet("watch status", flag.ContinueOnError)
+	all := fs.Bool("all", false, "also list uploaded files")
+	jsonOut := fs.Bool("json", false, "output json")
+	if err := ParseInterspersed(fs, args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	stateDir, err := watchStateDir()
+	if err != nil {
+		return err
+	}
+	paths, _ := filepath.Glob(filepath.Join(stateDir, "*.json"))
+
+	var states []*watchState
+	for _, path := range paths {
+		if st, err := loadWatchState(path); err == nil {
+			states = append(states, st)
+		}
+	}
+	if *jsonOut {
+		b, _ := json.MarshalIndent(states, "", "  ")
+		fmt.Println(string(b))
+		return nil
+	}
+	if len(states) == 0 {
+		fmt.Println("No watched directories. Start one with 'fl watch <dir>'.")
+		return nil
+	}
+
+	for _, st := range states {
+		running := st.PID != 0 && time.Since(st.UpdatedAt) < 2*watchHeartbeat
+		state := "stopped"
+		if running {
+			state = fmt.Sprintf("running (pid %d)", st.PID)
+		}
+		fmt.Printf("\n📂 %s — %s, last active %s\n", st.Dir, state, humanize.Time(st.UpdatedAt))
+
+		counts := make(map[string]int)
+		names := make([]string, 0, len(st.Files))
+		for name, f := range st.Files {
+			counts[f.Status]++
+			if *all || f.Status != watchUploaded {
+				names = append(names, name)
+			}
+		}
+		fmt.Printf("   %d queued, %d uploading, %d failed, %d uploaded\n",
+			counts[watchQueued], counts[watchUploading], counts[watchFailed], counts[watchUploaded])
+		if len(names) == 0 {
+			continue
+		}
+		sort.Strings(names)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		_, _ = fmt.Fprintln(w, "   FILE\tSTATUS\tSINCE\tDETAIL")
+		for _, name := range names {
+			f := st.Files[name]
+			detail := f.Error
+			if detail == "" && f.FileID != "" {
+				detail = f.FileID
+				if len(detail) > 8 {
+					detail = detail[:8] + "..."
+				}
+			}
+			_, _ = fmt.Fprintf(w, "   %s\t%s\t%s\t%s\n", name, f.Status, humanize.Time(f.UpdatedAt), detail)
+		}
+		_ = w.Flush()
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------
+// Rate Limiting
+// ----------------------------------------------------------------
+
+// rateFlag is a --limit-rate value in bytes per second. It accepts plain
+// numbers and size suffixes ("500K", "5M", "1MiB"); 0 means unlimited.
+type rateFlag int64
+
+func (f *rateFlag) String() string {
+	if *f == 0 {
+		return "0"
+	}
+	return humanize.Bytes(uint64(*f))
+}
+
+func (f *rateFlag) Set(s string) error {
+	n, err := humanize.ParseBytes(s)
+	if err != nil {
+		return fmt.Errorf("invalid rate %q (examples: 500K, 5M)", s)
+	}
+	*f = rateFlag(n)
+	return nil
+}
+
+// throttle paces a transfer to a fixed number of bytes per second. It tracks
+// the bytes moved since start and sleeps whenever the transfer gets ahead of
+// schedule, so a slow stretch (e.g. waiting on the server) is not made up for
+// by a burst afterwards beyond one chunk.
+type throttle struct {
+	rate  int64
+	start time.Time
+	moved int64
+}
+
+func newThrottle(rate int64) *throttle {
+	return &throttle{rate: rate}
+}
+
+// chunk is the most a single read or write may move: a tenth of a second's
+// worth, so the rate stays smooth instead of arriving in one-second bursts.
+func (t *throttle) chunk() int {
+	c := t.rate / 10
+	if c < 1 {
+		c = 1
+	}
+	return int(c)
+}
+
+func (t *throttle) wait(n int) {
+	if t.start.IsZero() {
+		t.start = time.Now()
+	}
+	t.moved += int64(n)
+
+	due := t.start.Add(time.Duration(float64(t.moved) / float64(t.rate) * float64(time.Second)))
+	if d := time.Until(due); d > 0 {
+		time.Sleep(d)
+	} else if d < -time.Second {
+		// Don't bank more than a second of idle time
+		t.start = t.start.Add(-d - time.Second)
+	}
+}
+
+type throttledReader struct {
+	r io.Reader
+	t *throttle
+}
+
+// newThrottledReader limits reads from r to rate bytes per second. A rate of
+// zero or less returns r unchanged.
+func newThrottledReader(r io.Reader, rate int64) io.Reader {
+	if rate <= 0 {
+		return r
+	}
+	return &throttledReader{r: r, t: newThrottle(rate)}
+}
+
+func (tr *throttledReader) Read(p []byte) (int, error) {
+	if c := tr.t.chunk(); len(p) > c {
+		p = p[:c]
+	}
+	n, err := tr.r.Read(p)
+	if n > 0 {
+		tr.t.wait(n)
+	}
+	return n, err
+}
+
+type throttledWriter struct {
+	w io.Writer
+	t *throttle
+}
+
+// newThrottledWriter limits writes to w to rate bytes per second. A rate of
+// zero or less returns w unchanged.
+func newThrottledWriter(w io.Writer, rate int64) io.Writer {
+	if rate <= 0 {
+		return w
+	}
+	return &throttledWriter{w: w, t: newThrottle(rate)}
+}
+
+func (tw *throttledWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		c := tw.t.chunk()
+		if c > len(p) {
+			c = len(p)
+		}
+		n, err := tw.w.Write(p[:c])
+		written += n
+		if n > 0 {
+			tw.t.wait(n)
+		}
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
 	}
+	return written, nil
 }
 
 // ----------------------------------------------------------------
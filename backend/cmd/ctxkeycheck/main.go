@@ -0,0 +1,16 @@
+// Command ctxkeycheck runs the ctxkey analyzer under go vet:
+//
+//	go build -o bin/ctxkeycheck ./cmd/ctxkeycheck
+//	go vet -vettool=$(pwd)/bin/ctxkeycheck ./...
+//
+// or simply make vet.
+package main
+
+import (
+	"github.com/sachinthra/file-locker/backend/internal/ctxkeycheck"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(ctxkeycheck.Analyzer)
+}
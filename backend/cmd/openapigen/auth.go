@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"gopkg.in/yaml.v3"
+)
+
+var methods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// generateAuthorization sets the security of every operation from auth.Policy and
+// documents its rule in x-authorization. Routes missing from the spec, and
+// operations the server does not route, are reported.
+func generateAuthorization(spec *yaml.Node) []string {
+	var problems []string
+	seen := make(map[string]bool)
+
+	paths := lookup(spec, "paths")
+	for i := 0; paths != nil && i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i].Value, paths.Content[i+1]
+		for _, method := range methods {
+			op := lookup(item, strings.ToLower(method))
+			if op == nil {
+				continue
+			}
+			key := method + " " + path
+			rule, ok := auth.Policy[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s is documented but not in auth.Policy", key))
+				continue
+			}
+			seen[key] = true
+
+			// Operations without security inherit the global BearerAuth
+			current := lookup(op, "security")
+			public := current != nil && len(current.Content) == 0
+			switch {
+			case rule.Public && !public:
+				security := toNode([]map[string][]string{})
+				security.Style = yaml.FlowStyle
+				set(op, "security", security, "tags")
+			case !rule.Public && public:
+				remove(op, "security")
+			}
+			set(op, "x-authorization", ruleNode(rule), "security")
+		}
+	}
+
+	var missing []string
+	for key := range auth.Policy {
+		if !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		problems = append(problems, fmt.Sprintf("%s is routed but not documented", key))
+	}
+	return problems
+}
+
+// ruleNode is a rule as the server publishes it in GET /permissions
+func ruleNode(rule auth.Rule) *yaml.Node {
+	data, _ := json.Marshal(rule)
+	var fields map[string]interface{}
+	_ = json.Unmarshal(data, &fields)
+
+	n := &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+	for _, key := range []string{"public", "role", "scope", "human_only"} {
+		if v, ok := fields[key]; ok {
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, toNode(v))
+		}
+	}
+	return n
+}
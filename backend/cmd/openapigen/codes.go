@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errorCodes reads the Code* constants of the apierror package, in source order
+func errorCodes(dir string) ([]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", dir, err)
+	}
+
+	pkg, ok := pkgs["apierror"]
+	if !ok {
+		return nil, fmt.Errorf("package apierror not found in %s", dir)
+	}
+	var files []string
+	for name := range pkg.Files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	var codes []string
+	for _, name := range files {
+		for _, decl := range pkg.Files[name].Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value := spec.(*ast.ValueSpec)
+				for i, ident := range value.Names {
+					if !strings.HasPrefix(ident.Name, "Code") || i >= len(value.Values) {
+						continue
+					}
+					if lit, ok := value.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						code, _ := strconv.Unquote(lit.Value)
+						codes = append(codes, code)
+					}
+				}
+			}
+		}
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("no error codes found in %s", dir)
+	}
+	return codes, nil
+}
+
+// generateErrorCodes sets the enum of the ErrorCode schema
+func generateErrorCodes(spec *yaml.Node, codes []string) []string {
+	schema := lookup(lookup(lookup(spec, "components"), "schemas"), "ErrorCode")
+	if schema == nil {
+		return []string{"components.schemas.ErrorCode is missing"}
+	}
+	if enum := toNode(codes); !equal(lookup(schema, "enum"), enum) {
+		set(schema, "enum", enum, "")
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Command openapigen brings docs/openapi.yaml in line with the code. The parts of
+// the spec the code defines are generated:
+//
+//   - security and x-authorization of every operation, from auth.Policy
+//   - the ErrorCode enum, from the constants of internal/apierror
+//   - the properties of the schemas in schemaTypes, from the Go types the
+//     handlers encode
+//
+// Descriptions, examples and everything else stay hand-written in the spec. Run
+// it through go generate (make generate); -check changes nothing and fails when
+// the spec is out of date:
+//
+//	go run ./cmd/openapigen -check
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	root := flag.String("root", ".", "backend module directory")
+	check := flag.Bool("check", false, "fail if the spec is out of date instead of updating it")
+	flag.Parse()
+
+	specPath := filepath.Join(*root, "docs", "openapi.yaml")
+	original, err := os.ReadFile(specPath)
+	if err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(original, &doc); err != nil {
+		log.Fatalf("openapigen: %s: %v", specPath, err)
+	}
+	spec := doc.Content[0]
+
+	var problems []string
+	problems = append(problems, generateAuthorization(spec)...)
+	codes, err := errorCodes(filepath.Join(*root, "internal", "apierror"))
+	if err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+	problems = append(problems, generateErrorCodes(spec, codes)...)
+	problems = append(problems, generateSchemas(spec)...)
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", specPath, p)
+		}
+		os.Exit(1)
+	}
+
+	generated, err := encode(&doc)
+	if err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+	if bytes.Equal(generated, original) {
+		return
+	}
+	if *check {
+		fmt.Fprintf(os.Stderr, "%s is out of date with the code; run make generate\n", specPath)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(specPath, generated, 0644); err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+}
+
+// encode writes the spec back with a blank line between top-level sections,
+// paths and components, as the hand-written file had them
+func encode(doc *yaml.Node) ([]byte, error) {
+	normalizeStrings(doc)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	section, component := "", ""
+	for i, line := range strings.SplitAfter(buf.String(), "\n") {
+		blank := false
+		switch {
+		case i > 0 && isKeyAt(line, 0):
+			section = strings.TrimSuffix(strings.TrimSpace(line), ":")
+			blank = section != "info" && section != "servers"
+		case section == "paths" && strings.HasPrefix(line, "  /"):
+			blank = true
+		case section == "components" && isKeyAt(line, 2):
+			component = strings.TrimSuffix(strings.TrimSpace(line), ":")
+			blank = true
+		case section == "components" && component == "schemas" && isKeyAt(line, 4):
+			blank = true
+		}
+		if blank && !bytes.HasSuffix(out.Bytes(), []byte(":\n")) {
+			out.WriteByte('\n')
+		}
+		out.WriteString(line)
+	}
+	return out.Bytes(), nil
+}
+
+// isKeyAt reports whether line is a mapping key indented by n spaces
+func isKeyAt(line string, n int) bool {
+	return len(line) > n && strings.TrimLeft(line[:n], " ") == "" && !strings.ContainsRune(" -#\n", rune(line[n]))
+}
+
+// normalizeStrings writes multi-line strings as literal blocks; trailing spaces
+// would force them into quoted strings
+func normalizeStrings(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && strings.Contains(strings.TrimSuffix(n.Value, "\n"), "\n") {
+		lines := strings.Split(n.Value, "\n")
+		for i := range lines {
+			lines[i] = strings.TrimRight(lines[i], " \t")
+		}
+		n.Value = strings.Join(lines, "\n")
+		n.Style = yaml.LiteralStyle
+	}
+	for _, c := range n.Content {
+		normalizeStrings(c)
+	}
+}
+
+// lookup returns the value of key in a mapping node, or nil
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// set replaces the value of key in a mapping node, or adds key after the key
+// named after (at the end if after is "" or missing)
+func set(m *yaml.Node, key string, value *yaml.Node, after string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	entry := []*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if after != "" && m.Content[i].Value == after {
+			m.Content = append(m.Content[:i+2], append(entry, m.Content[i+2:]...)...)
+			return
+		}
+	}
+	m.Content = append(m.Content, entry...)
+}
+
+// remove deletes key from a mapping node
+func remove(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// toNode converts a Go value to a YAML node
+func toNode(v interface{}) *yaml.Node {
+	var n yaml.Node
+	if err := n.Encode(v); err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+	return &n
+}
+
+// equal reports whether two nodes hold the same data, ignoring style and comments
+func equal(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	var x, y interface{}
+	if a.Decode(&x) != nil || b.Decode(&y) != nil {
+		return false
+	}
+	return fmt.Sprint(x) == fmt.Sprint(y)
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/alerts"
+	"github.com/sachinthra/file-locker/backend/internal/api"
+	"github.com/sachinthra/file-locker/backend/internal/capacity"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaTypes are the component schemas generated from the Go types handlers
+// encode. A field of one of these types becomes a $ref to its schema.
+var schemaTypes = map[string]reflect.Type{
+	"AuthResponse":        reflect.TypeOf(api.AuthResponse{}),
+	"FileMetadata":        reflect.TypeOf(api.FileInfo{}),
+	"FileDetails":         reflect.TypeOf(api.FileDetails{}),
+	"ContentStats":        reflect.TypeOf(api.ContentStatsResponse{}),
+	"Profile":             reflect.TypeOf(api.ProfileResponse{}),
+	"UserInfo":            reflect.TypeOf(api.UserInfo{}),
+	"StorageQuota":        reflect.TypeOf(storage.QuotaStatus{}),
+	"UserStorageReport":   reflect.TypeOf(api.UserStorageReport{}),
+	"UserPreferences":     reflect.TypeOf(storage.UserPreferences{}),
+	"UsageRecord":         reflect.TypeOf(storage.UsageRecord{}),
+	"TransferStatus":      reflect.TypeOf(api.TransferStatus{}),
+	"ShareLink":           reflect.TypeOf(storage.ShareLink{}),
+	"ShareStats":          reflect.TypeOf(storage.ShareStats{}),
+	"ServiceAccount":      reflect.TypeOf(storage.ServiceAccount{}),
+	"ServiceAccountKey":   reflect.TypeOf(storage.ServiceAccountKey{}),
+	"Job":                 reflect.TypeOf(storage.Job{}),
+	"FileStage":           reflect.TypeOf(storage.FileStage{}),
+	"KeyRotation":         reflect.TypeOf(storage.KeyRotation{}),
+	"MaintenanceState":    reflect.TypeOf(storage.MaintenanceState{}),
+	"IPAccessRules":       reflect.TypeOf(storage.IPAccessRules{}),
+	"NotificationChannel": reflect.TypeOf(notify.ChannelInfo{}),
+	"StorageAlertStatus":  reflect.TypeOf(alerts.Status{}),
+	"StorageBreaker":      reflect.TypeOf(storage.BreakerStats{}),
+	"UploadCapacity":      reflect.TypeOf(capacity.Status{}),
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// structural are the keys of a schema that follow from the Go type; the others
+// (description, example, enum, ...) are kept from the spec
+var structural = []string{"$ref", "type", "format", "items", "properties", "additionalProperties"}
+
+// generateSchemas updates the properties of the schemas in schemaTypes
+func generateSchemas(spec *yaml.Node) []string {
+	schemas := lookup(lookup(spec, "components"), "schemas")
+	if schemas == nil {
+		return []string{"components.schemas is missing"}
+	}
+
+	names := make([]string, 0, len(schemaTypes))
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		current := lookup(schemas, name)
+		if current == nil {
+			problems = append(problems, fmt.Sprintf("components.schemas.%s is missing", name))
+			continue
+		}
+		merge(current, schemaOf(schemaTypes[name], false))
+	}
+	return problems
+}
+
+// schema is the part of an OpenAPI schema derived from a Go type
+type schema struct {
+	Ref                  string
+	Type                 string
+	Format               string
+	Nullable             bool
+	Items                *schema
+	Properties           []property
+	AdditionalProperties *schema
+	Any                  bool // any JSON value: nothing to generate
+}
+
+type property struct {
+	Name   string
+	Schema *schema
+}
+
+// schemaOf derives the schema of t; asRef makes registered types a $ref
+func schemaOf(t reflect.Type, asRef bool) *schema {
+	if t.Kind() == reflect.Pointer {
+		s := schemaOf(t.Elem(), true)
+		s.Nullable = true
+		return s
+	}
+	if asRef {
+		for name, registered := range schemaTypes {
+			if registered == t {
+				return &schema{Ref: "#/components/schemas/" + name}
+			}
+		}
+	}
+
+	switch {
+	case t == timeType:
+		return &schema{Type: "string", Format: "date-time"}
+	case t == rawType:
+		return &schema{Any: true}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &schema{Type: "string", Format: "byte"}
+		}
+		return &schema{Type: "array", Items: schemaOf(t.Elem(), true)}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), true)}
+	case reflect.Struct:
+		return &schema{Type: "object", Properties: propertiesOf(t)}
+	}
+	return &schema{Any: true}
+}
+
+// propertiesOf lists the JSON fields of a struct as encoding/json writes them
+func propertiesOf(t reflect.Type) []property {
+	var props []property
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			props = append(props, propertiesOf(f.Type)...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s := schemaOf(f.Type, true)
+		if strings.Contains(options, "omitempty") {
+			s.Nullable = false // nil is left out rather than null
+		}
+		props = append(props, property{Name: name, Schema: s})
+	}
+	return props
+}
+
+// merge writes the structural keys of s into the spec schema n. Existing
+// properties keep their order and hand-written keys; new ones are appended,
+// and properties the Go type lacks are removed (also from required).
+func merge(n *yaml.Node, s *schema) {
+	if s.Any {
+		return
+	}
+	if s.Nullable {
+		set(n, "nullable", toNode(true), "")
+	}
+	if s.Ref != "" {
+		for _, key := range structural {
+			remove(n, key)
+		}
+		set(n, "$ref", toNode(s.Ref), "")
+		moveFirst(n, "$ref")
+		return
+	}
+	if lookup(n, "$ref") != nil && s.Type == "object" && len(s.Properties) > 0 {
+		return // an inline struct the spec documents as its own schema
+	}
+
+	remove(n, "$ref")
+	if current := lookup(n, "type"); current == nil || current.Value != s.Type {
+		set(n, "type", toNode(s.Type), "")
+		moveFirst(n, "type")
+	}
+	if s.Format != "" {
+		if current := lookup(n, "format"); current == nil || current.Value != s.Format {
+			set(n, "format", toNode(s.Format), "type")
+		}
+	} else if current := lookup(n, "format"); current != nil && s.Type != "string" {
+		remove(n, "format")
+	}
+
+	if s.Items != nil {
+		items := lookup(n, "items")
+		if items == nil {
+			items = &yaml.Node{Kind: yaml.MappingNode}
+			set(n, "items", items, "format")
+		}
+		merge(items, s.Items)
+	} else {
+		remove(n, "items")
+	}
+
+	if s.AdditionalProperties != nil {
+		if s.AdditionalProperties.Any {
+			set(n, "additionalProperties", toNode(true), "")
+		} else {
+			additional := lookup(n, "additionalProperties")
+			if additional == nil || additional.Kind != yaml.MappingNode {
+				additional = &yaml.Node{Kind: yaml.MappingNode}
+				set(n, "additionalProperties", additional, "")
+			}
+			merge(additional, s.AdditionalProperties)
+		}
+	}
+
+	if s.Type != "object" || s.AdditionalProperties != nil {
+		remove(n, "properties")
+		return
+	}
+	props := lookup(n, "properties")
+	if props == nil {
+		props = &yaml.Node{Kind: yaml.MappingNode}
+		set(n, "properties", props, "")
+	}
+	wanted := make(map[string]bool, len(s.Properties))
+	for _, p := range s.Properties {
+		wanted[p.Name] = true
+		current := lookup(props, p.Name)
+		if current == nil {
+			current = &yaml.Node{Kind: yaml.MappingNode}
+			set(props, p.Name, current, "")
+		}
+		merge(current, p.Schema)
+	}
+	for i := 0; i+1 < len(props.Content); {
+		if !wanted[props.Content[i].Value] {
+			props.Content = append(props.Content[:i], props.Content[i+2:]...)
+			continue
+		}
+		i += 2
+	}
+
+	if required := lookup(n, "required"); required != nil {
+		kept := required.Content[:0]
+		for _, name := range required.Content {
+			if wanted[name.Value] {
+				kept = append(kept, name)
+			}
+		}
+		required.Content = kept
+	}
+}
+
+// moveFirst moves key to the start of a mapping node
+func moveFirst(n *yaml.Node, key string) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			entry := []*yaml.Node{n.Content[i], n.Content[i+1]}
+			rest := append(append([]*yaml.Node{}, n.Content[:i]...), n.Content[i+2:]...)
+			n.Content = append(entry, rest...)
+			return
+		}
+	}
+}
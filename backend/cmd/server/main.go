@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
@@ -9,31 +11,73 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/sachinthra/file-locker/backend/internal/alerts"
 	"github.com/sachinthra/file-locker/backend/internal/api"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/capacity"
+	"github.com/sachinthra/file-locker/backend/internal/captcha"
 	"github.com/sachinthra/file-locker/backend/internal/config"
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
 	"github.com/sachinthra/file-locker/backend/internal/db"
 	grpcService "github.com/sachinthra/file-locker/backend/internal/grpc"
+	"github.com/sachinthra/file-locker/backend/internal/i18n"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/kms"
 	"github.com/sachinthra/file-locker/backend/internal/logger"
+	"github.com/sachinthra/file-locker/backend/internal/mail"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/pipeline"
+	"github.com/sachinthra/file-locker/backend/internal/quarantine"
+	"github.com/sachinthra/file-locker/backend/internal/reports"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/internal/usage"
 	"github.com/sachinthra/file-locker/backend/internal/worker"
 	pb "github.com/sachinthra/file-locker/backend/pkg/proto"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"google.golang.org/grpc"
 )
 
+// Build information, set with -ldflags "-X main.Version=... -X main.GitCommit=... -X main.BuildTime=...".
+// Without them the commit and time are taken from the Go build info when available.
+var (
+	Version   = "dev"
+	GitCommit = ""
+	BuildTime = ""
+)
+
 func main() {
+	// Schema management: fl-server migrate <status|up|down|force|create>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(os.Args[2:]); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
+
+	waitForDeps := flag.Duration("wait-for-deps", 0,
+		"keep retrying Postgres, MinIO and Redis this long before giving up (overrides server.startup.wait_for_deps)")
+	flag.Parse()
+
 	// Load configuration (with strict validation)
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("❌ Failed to load configuration: %v", err)
 	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "wait-for-deps" {
+			cfg.Server.Startup.WaitForDeps = *waitForDeps
+		}
+	})
 
 	// Initialize structured logger
 	appLogger, err := logger.New(cfg.Logging)
@@ -47,21 +91,73 @@ func main() {
 		slog.String("log_level", cfg.Logging.Level),
 	)
 
-	// Run database migrations
-	dbURL := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		cfg.Storage.Database.User,
-		cfg.Storage.Database.Password,
-		cfg.Storage.Database.Host,
-		cfg.Storage.Database.Port,
-		cfg.Storage.Database.DBName,
+	// Translations of API errors, emails and announcements
+	catalog, err := i18n.Load(cfg.I18n.Dir)
+	if err != nil {
+		log.Fatalf("❌ Failed to load translations: %v", err)
+	}
+	i18n.SetDefault(catalog)
+	appLogger.Info("Translations loaded", slog.Any("languages", catalog.Languages()))
+
+	// Dependencies may still be starting (e.g. under docker-compose): retry them
+	// until server.startup.wait_for_deps (or --wait-for-deps) runs out
+	depsCtx, stopDeps := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	deps := newDepWaiter(cfg.Server.Startup, appLogger)
+
+	// Initialize storage services
+	appLogger.Info("Initializing storage services")
+
+	// Initialize PostgreSQL
+	var pgStore *storage.PostgresStore
+	err = deps.wait(depsCtx, "PostgreSQL", func() error {
+		var err error
+		pgStore, err = storage.NewPostgresStore(
+			cfg.Storage.Database.Host,
+			fmt.Sprintf("%d", cfg.Storage.Database.Port),
+			cfg.Storage.Database.User,
+			cfg.Storage.Database.Password,
+			cfg.Storage.Database.DBName,
+			storage.PostgresPool{
+				MaxOpenConns:    cfg.Storage.Database.MaxOpenConns,
+				MaxIdleConns:    cfg.Storage.Database.MaxIdleConns,
+				ConnMaxLifetime: time.Duration(cfg.Storage.Database.ConnMaxLifetime) * time.Second,
+			},
+			cfg.Storage.Database.SlowQueryThreshold,
+		)
+		return err
+	})
+	if err != nil {
+		appLogger.Error("Failed to initialize PostgreSQL", slog.String("error", err.Error()))
+		log.Fatalf("Failed to initialize PostgreSQL: %v", err)
+	}
+	appLogger.Info("PostgreSQL connected successfully",
+		slog.String("host", cfg.Storage.Database.Host),
+		slog.String("database", cfg.Storage.Database.DBName),
 	)
+	defer func() { _ = pgStore.Close() }()
 
-	appLogger.Info("Running database migrations")
-	if err := db.RunMigrations(dbURL, appLogger); err != nil {
-		appLogger.Error("Failed to run database migrations", slog.String("error", err.Error()))
-		log.Fatalf("❌ Database migration failed: %v\nPlease check your database configuration and try again.", err)
+	// Run database migrations (unless the schema is managed with fl-server migrate)
+	dbURL := databaseURL(cfg.Storage.Database)
+	if cfg.Storage.Database.SkipMigrations {
+		status, err := db.GetMigrationStatus(dbURL)
+		if err != nil {
+			log.Fatalf("❌ Failed to check migration status: %v", err)
+		}
+		if status.Dirty || len(status.Pending) > 0 {
+			appLogger.Warn("Database schema is not up to date; run fl-server migrate up",
+				slog.Uint64("version", uint64(status.Version)),
+				slog.Uint64("latest", uint64(status.Latest)),
+				slog.Bool("dirty", status.Dirty),
+			)
+		}
+	} else {
+		appLogger.Info("Running database migrations")
+		if err := db.RunMigrations(dbURL, appLogger); err != nil {
+			appLogger.Error("Failed to run database migrations", slog.String("error", err.Error()))
+			log.Fatalf("❌ Database migration failed: %v\nPlease check your database configuration and try again.", err)
+		}
+		appLogger.Info("✅ Database migrations completed successfully")
 	}
-	appLogger.Info("✅ Database migrations completed successfully")
 
 	// Create default admin user
 	if err := db.CreateDefaultAdmin(
@@ -75,36 +171,25 @@ func main() {
 		log.Fatalf("❌ Failed to create default admin: %v", err)
 	}
 
-	// Initialize storage services
-	appLogger.Info("Initializing storage services")
-
-	// Initialize PostgreSQL
-	pgStore, err := storage.NewPostgresStore(
-		cfg.Storage.Database.Host,
-		fmt.Sprintf("%d", cfg.Storage.Database.Port),
-		cfg.Storage.Database.User,
-		cfg.Storage.Database.Password,
-		cfg.Storage.Database.DBName,
-	)
-	if err != nil {
-		appLogger.Error("Failed to initialize PostgreSQL", slog.String("error", err.Error()))
-		log.Fatalf("Failed to initialize PostgreSQL: %v", err)
+	if err := configureKeys(context.Background(), cfg.Security, pgStore, appLogger); err != nil {
+		log.Fatalf("Failed to initialize key encryption: %v", err)
 	}
-	appLogger.Info("PostgreSQL connected successfully",
-		slog.String("host", cfg.Storage.Database.Host),
-		slog.String("database", cfg.Storage.Database.DBName),
-	)
-	defer func() { _ = pgStore.Close() }()
 
 	// Initialize MinIO
-	minioStorage, err := storage.NewMinIOStorage(
-		cfg.Storage.MinIO.Endpoint,
-		cfg.Storage.MinIO.AccessKey,
-		cfg.Storage.MinIO.SecretKey,
-		cfg.Storage.MinIO.Bucket,
-		cfg.Storage.MinIO.UseSSL,
-		cfg.Storage.MinIO.Region,
-	)
+	var minioStorage *storage.MinIOStorage
+	err = deps.wait(depsCtx, "MinIO", func() error {
+		var err error
+		minioStorage, err = storage.NewMinIOStorage(
+			cfg.Storage.MinIO.Endpoint,
+			cfg.Storage.MinIO.AccessKey,
+			cfg.Storage.MinIO.SecretKey,
+			cfg.Storage.MinIO.Bucket,
+			cfg.Storage.MinIO.UseSSL,
+			cfg.Storage.MinIO.Region,
+			cfg.Storage.MinIO.Isolation,
+		)
+		return err
+	})
 	if err != nil {
 		appLogger.Error("Failed to initialize MinIO", slog.String("error", err.Error()))
 		log.Fatalf("Failed to initialize MinIO: %v", err)
@@ -112,59 +197,221 @@ func main() {
 	appLogger.Info("MinIO connected successfully",
 		slog.String("endpoint", cfg.Storage.MinIO.Endpoint),
 		slog.String("bucket", cfg.Storage.MinIO.Bucket),
+		slog.String("isolation", cfg.Storage.MinIO.Isolation),
 	)
 
 	// Initialize Redis
-	redisCache, err := storage.NewRedisCache(
-		cfg.Storage.Redis.Addr,
-		cfg.Storage.Redis.Password,
-		cfg.Storage.Redis.DB,
-	)
+	var redisCache *storage.RedisCache
+	err = deps.wait(depsCtx, "Redis", func() error {
+		var err error
+		redisCache, err = storage.NewRedisCache(
+			cfg.Storage.Redis.Addr,
+			cfg.Storage.Redis.Password,
+			cfg.Storage.Redis.DB,
+			storage.RedisPool{
+				PoolSize:     cfg.Storage.Redis.PoolSize,
+				MinIdleConns: cfg.Storage.Redis.MinIdleConns,
+				PoolTimeout:  cfg.Storage.Redis.PoolTimeout,
+			},
+		)
+		return err
+	})
 	if err != nil {
 		appLogger.Error("Failed to initialize Redis", slog.String("error", err.Error()))
 		log.Fatalf("Failed to initialize Redis: %v", err)
 	}
+	stopDeps()
 	appLogger.Info("Redis connected successfully", slog.String("addr", cfg.Storage.Redis.Addr))
 
 	// Initialize JWT service
 	jwtService := auth.NewJWTService(
-		cfg.Security.JWTSecret,
-		cfg.Security.SessionTimeout,
+		signingKeys(cfg.Security),
+		cfg.Security.AccessTokenTTL,
+		pgStore,
 	)
 	appLogger.Info("JWT service initialized")
 
+	// Share links, tickets and signed URLs lock out addresses that guess tokens
+	tokenGuard := auth.NewTokenGuard(redisCache, cfg.Security.TokenGuard.MaxFailures,
+		cfg.Security.TokenGuard.Window, cfg.Security.TokenGuard.Lockout)
+
+	// Anonymous routes (share abuse reports) are challenged with a captcha when
+	// a provider is configured
+	cc := cfg.Features.Captcha
+	captchaVerifier, err := captcha.New(cc.Provider, cc.SiteKey, cc.SecretKey)
+	if err != nil {
+		appLogger.Error("Failed to initialize captcha", slog.String("error", err.Error()))
+		log.Fatalf("Failed to initialize captcha: %v", err)
+	}
+
 	// Initialize auth middleware
-	authMiddleware := auth.NewAuthMiddleware(jwtService, redisCache, pgStore)
+	authMiddleware := auth.NewAuthMiddleware(jwtService, redisCache, pgStore, tokenGuard)
 
 	// Initialize API handlers
-	authHandler := api.NewAuthHandler(jwtService, redisCache, pgStore)
-	userHandler := api.NewUserHandler(pgStore)
+	mailer := mail.New(cfg.Mail.SMTPHost, cfg.Mail.SMTPPort, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)
+	userHandler := api.NewUserHandler(pgStore, minioStorage, mailer)
 	tokensHandler := api.NewTokensHandler(pgStore)
-	uploadHandler := api.NewUploadHandler(minioStorage, redisCache, pgStore)
-	downloadHandler := api.NewDownloadHandler(minioStorage, redisCache, pgStore)
-	streamHandler := api.NewStreamHandler(minioStorage, redisCache, pgStore)
-	filesHandler := api.NewFilesHandler(redisCache, minioStorage, pgStore)
+	downloadHandler := api.NewDownloadHandler(minioStorage, redisCache, pgStore,
+		auth.NewURLSigner(cfg.Security.JWTSecret),
+		cfg.Features.DownloadURLs.DefaultTTL, cfg.Features.DownloadURLs.MaxTTL, tokenGuard)
+	streamHandler := api.NewStreamHandler(minioStorage, redisCache, pgStore, cfg.Features.VideoStreaming.BlockCacheSize, cfg.Features.VideoStreaming.PrefetchBlocks)
+	fileNamePolicy := api.FileNamePolicy{
+		Mode:      cfg.Features.FileNames.Policy,
+		MaxLength: cfg.Features.FileNames.MaxLength,
+	}
+	filesHandler := api.NewFilesHandler(redisCache, minioStorage, pgStore, fileNamePolicy, cfg.Features.Trash.Retention)
 	exportHandler := api.NewExportHandler(minioStorage, pgStore)
-	adminHandler := api.NewAdminHandler(pgStore, minioStorage, redisCache)
+	keyRotator := worker.NewKeyRotator(minioStorage, pgStore, redisCache, api.NewAuditLogger(pgStore).LogAdminAction)
+	pollInterval := cfg.Features.Jobs.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	jobQueue := jobs.NewQueue(pgStore, cfg.Features.Jobs.Workers, pollInterval, cfg.Features.Jobs.Retention)
+	jobQueue.Register(jobs.TypeFileReencrypt, jobs.ReencryptFileHandler(pgStore, redisCache, keyRotator))
+	jobQueue.Register(jobs.TypeFileRelocate, jobs.RelocateFileHandler(pgStore, redisCache, minioStorage))
+	jobQueue.Register(jobs.TypeFileReplicate, jobs.ReplicateFileHandler(pgStore, minioStorage))
+
+	// Weekly/monthly usage reports for admins, generated by a job
+	reportsCfg := cfg.Features.Reports
+	reporter := reports.New(pgStore, jobQueue, mailer, reports.Config{
+		Weekly:        reportsCfg.Weekly,
+		Monthly:       reportsCfg.Monthly,
+		EmailAdmins:   reportsCfg.EmailAdmins,
+		Recipients:    reportsCfg.Recipients,
+		WebhookURL:    reportsCfg.Webhook.URL,
+		WebhookFormat: reportsCfg.Webhook.Format,
+		TopUploaders:  reportsCfg.TopUploaders,
+	})
+	jobQueue.Register(reports.JobType, reporter.HandleJob)
+
+	// Monthly usage records per user for chargeback, posted to a billing webhook by a job
+	usageRecorder := usage.New(pgStore, jobQueue, usage.Config{
+		WebhookURL:    cfg.Features.Usage.Webhook.URL,
+		WebhookSecret: cfg.Features.Usage.Webhook.Secret,
+	})
+	jobQueue.Register(usage.JobType, usageRecorder.HandleJob)
+
+	// Events posted to Slack/Discord/Matrix channels, one job per message
+	notifier := notify.New(jobQueue, notificationChannels(cfg.Features.Notifications))
+	jobQueue.Register(notify.JobType, notifier.HandleJob)
+	if n := len(cfg.Features.Notifications.Channels); n > 0 {
+		appLogger.Info("Chat notifications enabled", slog.Int("channels", n))
+	}
+
+	// Storage usage alerts (checked on the elected replica, see below)
+	ac := cfg.Features.StorageAlerts
+	storageMonitor := alerts.New(pgStore, mailer, notifier, alerts.Config{
+		Interval:         ac.Interval,
+		UserQuotaPercent: ac.UserQuotaPercent,
+		Bucket:           alerts.Threshold{Capacity: ac.Bucket.Capacity, Percent: ac.Bucket.Percent},
+		Database:         alerts.Threshold{Capacity: ac.Database.Capacity, Percent: ac.Database.Percent},
+		EmailAdmins:      ac.EmailAdmins,
+		EmailUsers:       ac.EmailUsers,
+	})
+
+	// Room for uploads; the bucket capacity of the storage alerts applies unless set
+	sc := cfg.Features.Uploads.Space
+	if sc.StorageCapacity == 0 {
+		sc.StorageCapacity = ac.Bucket.Capacity
+	}
+	uploadSpace := capacity.New(pgStore, capacity.Config{
+		TempDir:         sc.TempDir,
+		MinTempFree:     sc.MinTempFree,
+		StorageCapacity: sc.StorageCapacity,
+		MinStorageFree:  sc.MinStorageFree,
+	})
+	authHandler := api.NewAuthHandler(jwtService, redisCache, pgStore, time.Duration(cfg.Security.SessionTimeout)*time.Second, notifier, storageMonitor)
+
+	// Cold standby: mirror every object write and delete to a second endpoint
+	var minioReplica *storage.MinIOStorage
+	minioStorage.SetVerifyUploads(cfg.Storage.MinIO.VerifyUploads)
+	minioStorage.SetLabelObjects(cfg.Storage.MinIO.LabelObjects)
+	uc := cfg.Storage.MinIO.Upload
+	minioStorage.SetUploadTuning(storage.UploadTuning{PartSize: uc.PartSize, Concurrency: uc.Concurrency, SinglePutMax: uc.SinglePutMax})
+	if rc := cfg.Storage.MinIO.Retry; rc.Attempts > 1 {
+		minioStorage.SetRetryPolicy(storage.RetryPolicy{Attempts: rc.Attempts, BaseDelay: rc.BaseDelay, MaxDelay: rc.MaxDelay})
+	}
+	if bc := cfg.Storage.MinIO.Breaker; bc.Failures > 0 {
+		minioStorage.SetBreaker(storage.NewBreaker("MinIO", bc.Failures, bc.Cooldown))
+		appLogger.Info("MinIO circuit breaker enabled", slog.Int("failures", bc.Failures), slog.Duration("cooldown", bc.Cooldown))
+	}
+	if rc := cfg.Storage.MinIO.Replica; rc.Enabled {
+		minioReplica, err = storage.NewMinIOReplica(minioStorage, rc.Endpoint, rc.AccessKey, rc.SecretKey, rc.UseSSL, rc.Region)
+		if err != nil {
+			log.Fatalf("Failed to initialize the MinIO replica: %v", err)
+		}
+		jobQueue.Register(jobs.TypeObjectReplicate, jobs.ReplicateObjectHandler(minioStorage, minioReplica))
+		minioStorage.SetChangeHook(func(ctx context.Context, path string) {
+			// Missed changes are caught up on by POST /admin/storage/replica/reconcile
+			if _, err := jobQueue.Enqueue(context.WithoutCancel(ctx), jobs.TypeObjectReplicate, jobs.ObjectPayload{Path: path}); err != nil {
+				log.Printf("[replica] Failed to queue replication of %s: %v", path, err)
+			}
+		})
+		appLogger.Info("MinIO replica enabled", slog.String("endpoint", rc.Endpoint))
+	}
+	uploadPipeline := pipeline.New(pgStore, minioStorage, jobQueue, pipelineStages(cfg.Features.Pipeline, pgStore)...)
+	if stages := uploadPipeline.Stages(); len(stages) > 0 {
+		appLogger.Info("Upload processing enabled", slog.Any("stages", stages))
+	}
+	var uploadQuarantine *quarantine.Quarantine
+	if qc := cfg.Features.Quarantine; len(qc.Rules) > 0 {
+		uploadQuarantine = quarantine.New(pgStore, redisCache, quarantineRules(qc), qc.TrustedAfter, notifier)
+		uploadPipeline.SetStageHook(uploadQuarantine.StageDone)
+		for _, rule := range qc.Rules {
+			if rule.ScanVerdict != "" && !cfg.Features.Pipeline.VirusScan.Enabled {
+				appLogger.Warn("Quarantine rule on scan verdicts never matches without features.pipeline.virus_scan", slog.String("rule", rule.Name))
+			}
+		}
+		appLogger.Info("Upload quarantine enabled", slog.Int("rules", len(qc.Rules)))
+	}
+	uploadHandler := api.NewUploadHandler(minioStorage, redisCache, pgStore, fileNamePolicy, uploadPipeline, uploadQuarantine, jobQueue, cfg.Features.Uploads.EncryptionWorkers, cfg.Features.Uploads.CopyKeys)
+	maintenance := api.NewMaintenance(pgStore, cfg.Features.Maintenance.Allowlist)
+	permissionsHandler := api.NewPermissionsHandler(pgStore)
+	versionHandler := api.NewVersionHandler(pgStore, buildInfo(), enabledFeatures(cfg))
+	ipAccess := api.NewIPAccess(pgStore, redisCache, cfg.Security.IPAccess.CountryHeader)
+	snapshotter := worker.NewSnapshotter(minioStorage, pgStore, cfg.Features.Snapshots.Interval, cfg.Features.Snapshots.Keep)
+	adminHandler := api.NewAdminHandler(pgStore, minioStorage, minioReplica, redisCache, keyRotator, snapshotter, reporter, usageRecorder, notifier, storageMonitor, uploadSpace, jobQueue, maintenance, ipAccess, jwtService)
+	shareHandler := api.NewShareHandler(minioStorage, redisCache, pgStore, cfg.Features.Shares.SpikeRequestsPerMinute, cfg.Features.Shares.ReportsPerHour, tokenGuard, auth.NewVisitorHasher(cfg.Security.JWTSecret), notifier)
 
 	appLogger.Info("API handlers initialized")
 
 	// Setup HTTP Router
 	r := chi.NewRouter()
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		apierror.Respond(w, r, http.StatusNotFound, "Not found")
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		apierror.Respond(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	})
 
-	// Global middleware
-	r.Use(middleware.Logger)
+	// Global middleware. Every request gets an ID, returned in X-Request-ID and
+	// included in error bodies and the access log.
+	realIP, err := api.RealIP(cfg.Server.TrustedProxies, cfg.Security.IPAccess.CountryHeader)
+	if err != nil {
+		log.Fatalf("❌ server.trusted_proxies: %v", err)
+	}
+	r.Use(api.RequestID)
+	r.Use(realIP)
+	r.Use(api.AccessLog(appLogger))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(api.ConcurrencyPerIP(cfg.Server.MaxConcurrentRequestsIP))
+	r.Use(api.BodyLimit(cfg.Server.MaxBodyBytes, map[string]int64{
+		"/api/v1/upload": cfg.Server.MaxUploadBytes, // also /api/v2/upload
+	}))
+	if cfg.Server.Compression.Enabled {
+		level := cfg.Server.Compression.Level
+		if level == 0 {
+			level = 5
+		}
+		r.Use(api.Compress(cfg.Server.Compression.MinSize, level))
+	}
 
 	// CORS middleware (frontend accessed through nginx on port 80)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost", "http://localhost:80", "http://localhost:5173"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Requested-With", "X-Real-IP", "X-Forwarded-For"},
-		ExposedHeaders:   []string{"Content-Length", "Content-Range"},
+		AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Requested-With", "X-Real-IP", "X-Forwarded-For", "If-None-Match", "If-Modified-Since", "If-Match", "If-Unmodified-Since", api.FilePasswordHeader, api.RequestIDHeader, api.IdempotencyKeyHeader},
+		ExposedHeaders:   []string{"Content-Length", "Content-Range", "ETag", "Last-Modified", "Location", "X-Checksum-SHA256", api.FileSizeHeader, "Deprecation", "Sunset", "Link", api.RequestIDHeader, "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -177,6 +424,8 @@ func main() {
 	r.Head("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	// Ready to serve: database, Redis and MinIO (circuit breaker) reachable
+	r.Get("/health/ready", api.Readiness(pgStore, redisCache, minioStorage))
 
 	// Swagger UI (accessible at /swagger/index.html)
 	r.Get("/swagger/*", httpSwagger.Handler(
@@ -185,12 +434,63 @@ func main() {
 
 	appLogger.Info("Swagger documentation configured", slog.String("endpoint", "/swagger/index.html"))
 
-	// API routes
-	r.Route("/api/v1", func(r chi.Router) {
+	// Regular API calls get a fixed per-request timeout. Large transfers (upload,
+	// download, stream, export) are mounted without it: their connection deadlines
+	// move forward as bytes flow, so only a stalled transfer is cut off.
+	requestTimeout := api.RouteTimeout(cfg.Server.RequestTimeout, cfg.Server.RouteTimeouts)
+	transferDeadlines := api.TransferDeadlines(cfg.Server.TransferStallTimeout)
+
+	// During maintenance only admins and allowlisted paths are served; the check
+	// runs after authentication wherever there is one
+	inMaintenance := maintenance.Middleware
+
+	// Apply rate limiting if enabled (after authentication: it is per user)
+	rateLimit := func(next http.Handler) http.Handler { return next }
+	if cfg.Security.RateLimit.Enabled {
+		rateLimit = authMiddleware.RateLimitMiddleware(
+			cfg.Security.RateLimit.RequestsPerMinute,
+			1*time.Minute,
+		)
+	}
+
+	// File writes are blocked while files are read-only (globally or for the
+	// user); downloads and account operations are not
+	readOnly := api.ReadOnly(pgStore)
+
+	// Users over the storage quota past its grace period cannot add files
+	overQuota := api.QuotaGuard(storageMonitor)
+
+	// Transfers and copies get 503 right away while the MinIO circuit breaker is open
+	storageUp := api.StorageAvailable(minioStorage)
+
+	// Writes clients retry (uploads, deletes, batches) accept an Idempotency-Key
+	idempotent := api.Idempotency(redisCache, cfg.Features.Idempotency.TTL)
+
+	// API routes, mounted under /api/v1 and /api/v2 below
+	apiRoutes := func(r chi.Router) {
+		// Addresses and countries refused by the admin's IP access rules get 403
+		// before anything else runs
+		r.Use(ipAccess.Middleware)
+
+		// Build, API and schema versions and enabled features (served even during maintenance)
+		r.With(requestTimeout).Get("/version", versionHandler.HandleVersion)
+
+		// Public share links and signed download URLs (transfer)
+		r.With(inMaintenance, transferDeadlines, storageUp).Get("/s/{token}", shareHandler.HandleShareDownload)
+		r.With(inMaintenance, transferDeadlines, storageUp).Get("/dl/{token}", downloadHandler.HandleSignedDownload)
+
+		// Abuse reports about public share links (anonymous, so behind the captcha)
+		r.With(inMaintenance, requestTimeout, api.RequireCaptcha(captchaVerifier)).Post("/s/{token}/report", shareHandler.HandleReportShare)
+
 		// Public routes (no authentication required)
 		r.Group(func(r chi.Router) {
+			r.Use(inMaintenance)
+			r.Use(requestTimeout)
+
 			r.Post("/auth/login", authHandler.HandleLogin)
 			r.Post("/auth/register", authHandler.HandleRegister)
+			r.Post("/auth/refresh", authHandler.HandleRefresh)
+			r.Post("/auth/revoke", authHandler.HandleRevoke)
 
 			// Serve OpenAPI documentation
 			r.Get("/docs/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
@@ -198,70 +498,142 @@ func main() {
 			})
 		})
 
+		// Download and stream accept a one-time ticket instead of the Authorization header
+		r.Group(func(r chi.Router) {
+			r.Use(transferDeadlines)
+			r.Use(storageUp)
+
+			r.With(authMiddleware.RequireAuthOrTicket(auth.TicketPurposeDownload), authMiddleware.Authorize, inMaintenance, rateLimit).
+				Get("/download/{id}", downloadHandler.HandleDownload)
+			r.With(authMiddleware.RequireAuthOrTicket(auth.TicketPurposeStream), authMiddleware.Authorize, inMaintenance, rateLimit).
+				Get("/stream/{id}", streamHandler.HandleStream)
+		})
+
 		// Protected routes (authentication required)
 		r.Group(func(r chi.Router) {
-			// Apply auth middleware
+			// Apply auth middleware; Authorize checks each route against auth.Policy
 			r.Use(authMiddleware.RequireAuth)
+			r.Use(authMiddleware.Authorize)
+			r.Use(inMaintenance)
+			r.Use(rateLimit)
 
-			// Apply rate limiting if enabled
-			if cfg.Security.RateLimit.Enabled {
-				r.Use(authMiddleware.RateLimitMiddleware(
-					cfg.Security.RateLimit.RequestsPerMinute,
-					1*time.Minute,
-				))
-			}
+			// Large transfers
+			r.Group(func(r chi.Router) {
+				r.Use(transferDeadlines)
+				r.Use(storageUp)
+
+				r.With(readOnly, overQuota, idempotent, api.UploadWatermark(cfg.Features.Uploads.InFlightWatermark), api.UploadSpaceGuard(uploadSpace)).Post("/upload", uploadHandler.HandleUpload)
+				r.Get("/files/export", exportHandler.HandleExportAll)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(requestTimeout)
+
+				r.With(readOnly, overQuota, idempotent, storageUp).Post("/upload/precheck", uploadHandler.HandlePrecheck)
+
+				// File operations
+				r.Get("/files", filesHandler.HandleListFiles)
+				r.Get("/files/search", filesHandler.HandleSearchFiles)
+				r.Get("/files/expiring", filesHandler.HandleListExpiring)
+				r.Get("/files/starred", filesHandler.HandleListStarred)
+				r.Get("/files/recent", filesHandler.HandleListRecent)
+				r.With(readOnly, idempotent).Delete("/files", filesHandler.HandleDeleteFile)
+				r.Get("/files/trash", filesHandler.HandleListTrash)
+				r.With(readOnly, idempotent).Delete("/files/trash", filesHandler.HandleEmptyTrash)
+				r.With(readOnly, idempotent).Post("/files/batch/update", filesHandler.HandleBatchUpdate)
+				r.Get("/files/{fileID}", filesHandler.HandleGetFile)
+				r.Head("/files/{fileID}", filesHandler.HandleGetFile)
+				r.With(readOnly).Patch("/files/{fileID}", filesHandler.HandleUpdateFile)
+				r.With(readOnly, overQuota, idempotent, storageUp).Post("/files/{fileID}/copy", uploadHandler.HandleCopyFile)
+				r.With(readOnly).Post("/files/{fileID}/move", filesHandler.HandleMoveFile)
+				r.With(readOnly).Patch("/files/{fileID}/expiry", filesHandler.HandleUpdateExpiry)
+				r.With(readOnly).Put("/files/{fileID}/password", filesHandler.HandleSetFilePassword)
+				r.With(readOnly).Delete("/files/{fileID}/password", filesHandler.HandleRemoveFilePassword)
+				r.With(readOnly).Post("/files/{fileID}/star", filesHandler.HandleStarFile)
+				r.With(readOnly).Delete("/files/{fileID}/star", filesHandler.HandleStarFile)
+				r.Post("/files/{fileID}/download-url", downloadHandler.HandleCreateDownloadURL)
+				r.Post("/files/{fileID}/ticket", downloadHandler.HandleCreateTicket)
+				r.Post("/files/{fileID}/shares", shareHandler.HandleCreateShare)
+				r.Get("/files/{fileID}/shares", shareHandler.HandleListShares)
+				r.Get("/files/{fileID}/shares/{shareID}/stats", shareHandler.HandleGetShareStats)
+				r.Get("/files/{fileID}/processing", filesHandler.HandleGetProcessing)
+				r.Get("/files/{fileID}/thumbnail", filesHandler.HandleGetThumbnail)
+				r.With(readOnly).Post("/files/{fileID}/restore", filesHandler.HandleRestoreFile)
+				r.Patch("/shares/{id}", shareHandler.HandleUpdateShare)
+				r.Delete("/shares/{id}", shareHandler.HandleDeleteShare)
+
+				// Auth operations
+				r.Get("/auth/me", authHandler.HandleGetMe)
+				r.Get("/permissions", permissionsHandler.HandleGetPermissions)
+				r.Get("/users/{id}/avatar", userHandler.HandleGetAvatar)
+				r.Get("/user/preferences", userHandler.HandleGetPreferences)
+				r.Get("/user/transfer", userHandler.HandleGetTransfer)
 
-			// File operations
-			r.Post("/upload", uploadHandler.HandleUpload)
-			r.Get("/files", filesHandler.HandleListFiles)
-			r.Get("/files/search", filesHandler.HandleSearchFiles)
-			r.Get("/files/export", exportHandler.HandleExportAll)
-			r.Delete("/files", filesHandler.HandleDeleteFile)
-			r.Patch("/files/{fileID}", filesHandler.HandleUpdateFile)
-			r.Get("/download/{id}", downloadHandler.HandleDownload)
-			r.Get("/stream/{id}", streamHandler.HandleStream)
-
-			// User operations
-			r.Patch("/user/password", userHandler.HandleChangePassword)
-
-			// Auth operations
-			r.Post("/auth/logout", authHandler.HandleLogout)
-			r.Get("/auth/me", authHandler.HandleGetMe)
-
-			// Personal Access Tokens (PATs)
-			r.Post("/auth/tokens", tokensHandler.HandleCreateToken)
-			r.Get("/auth/tokens", tokensHandler.HandleListTokens)
-			r.Delete("/auth/tokens/{id}", tokensHandler.HandleRevokeToken)
-
-			// Announcements (user operations)
-			r.Get("/announcements", adminHandler.HandleGetAnnouncements)
-			r.Post("/announcements/{id}/dismiss", adminHandler.HandleDismissAnnouncement)
+				// User operations (human-only in the policy)
+				r.Patch("/user/password", userHandler.HandleChangePassword)
+				r.Patch("/user/profile", userHandler.HandleUpdateProfile)
+				r.Post("/user/profile/verify-email", userHandler.HandleVerifyEmail)
+				r.Patch("/user/preferences", userHandler.HandleUpdatePreferences)
+				r.With(readOnly).Put("/user/avatar", userHandler.HandleSetAvatar)
+				r.Delete("/user/avatar", userHandler.HandleDeleteAvatar)
+				r.Post("/auth/logout", authHandler.HandleLogout)
+
+				// Personal Access Tokens (PATs)
+				r.Post("/auth/tokens", tokensHandler.HandleCreateToken)
+				r.Get("/auth/tokens", tokensHandler.HandleListTokens)
+				r.Delete("/auth/tokens/{id}", tokensHandler.HandleRevokeToken)
+
+				// Announcements (user operations)
+				r.Get("/announcements", adminHandler.HandleGetAnnouncements)
+				r.Post("/announcements/{id}/dismiss", adminHandler.HandleDismissAnnouncement)
+			})
 		})
 
 		// Admin routes (authentication + admin role required)
 		r.Group(func(r chi.Router) {
 			// Apply auth middleware
 			r.Use(authMiddleware.RequireAuth)
-			// Apply admin-only middleware
-			r.Use(authMiddleware.RequireAdmin)
+			// Admin role (and, for tokens, the admin scope) per auth.Policy
+			r.Use(authMiddleware.Authorize)
+			r.Use(requestTimeout)
 
 			// System statistics
 			r.Get("/admin/stats", adminHandler.HandleGetStats)
+			r.Get("/admin/stats/content-types", adminHandler.HandleGetContentStats)
 
 			// User management
 			r.Get("/admin/users", adminHandler.HandleGetUsers)
 			r.Get("/admin/users/pending", adminHandler.HandleGetPendingUsers)
+			r.Get("/admin/users/{id}/files", adminHandler.HandleGetUserFiles)
+			r.Get("/admin/users/{id}/storage", adminHandler.HandleGetUserStorage)
 			r.Post("/admin/users/{id}/approve", adminHandler.HandleApproveUser)
 			r.Post("/admin/users/{id}/reject", adminHandler.HandleRejectUser)
 			r.Delete("/admin/users/{id}", adminHandler.HandleDeleteUser)
 			r.Patch("/admin/users/{id}/status", adminHandler.HandleUpdateUserStatus)
 			r.Patch("/admin/users/{id}/role", adminHandler.HandleUpdateUserRole)
+			r.Patch("/admin/users/{id}/profile", adminHandler.HandleUpdateUserProfile)
 			r.Post("/admin/users/{id}/reset-password", adminHandler.HandleResetUserPassword)
 			r.Post("/admin/users/{id}/logout", adminHandler.HandleForceLogoutUser)
+			r.Put("/admin/users/{id}/read-only", adminHandler.HandleSetUserReadOnly)
+			r.Post("/admin/users/{id}/transfer-files", adminHandler.HandleTransferFiles)
+
+			// Service accounts
+			r.Get("/admin/service-accounts", adminHandler.HandleListServiceAccounts)
+			r.Post("/admin/service-accounts", adminHandler.HandleCreateServiceAccount)
+			r.Delete("/admin/service-accounts/{id}", adminHandler.HandleDeleteServiceAccount)
+			r.Get("/admin/service-accounts/{id}/keys", adminHandler.HandleListServiceAccountKeys)
+			r.Post("/admin/service-accounts/{id}/keys", adminHandler.HandleCreateServiceAccountKey)
+			r.Delete("/admin/service-accounts/{id}/keys/{keyID}", adminHandler.HandleRevokeServiceAccountKey)
 
 			// Settings management
 			r.Get("/admin/settings", adminHandler.HandleGetSettings)
 			r.Patch("/admin/settings", adminHandler.HandleUpdateSetting)
+			r.Get("/admin/maintenance", adminHandler.HandleGetMaintenance)
+			r.Put("/admin/maintenance", adminHandler.HandleSetMaintenance)
+			r.Get("/admin/read-only", adminHandler.HandleGetReadOnly)
+			r.Put("/admin/read-only", adminHandler.HandleSetReadOnly)
+			r.Get("/admin/ip-access", adminHandler.HandleGetIPAccess)
+			r.Put("/admin/ip-access", adminHandler.HandleSetIPAccess)
 
 			// Announcements management
 			r.Get("/admin/announcements", adminHandler.HandleGetAnnouncements)
@@ -275,17 +647,95 @@ func main() {
 			// Storage cleanup
 			r.Get("/admin/storage/analyze", adminHandler.HandleAnalyzeStorage)
 			r.Post("/admin/storage/cleanup", adminHandler.HandleCleanupStorage)
+			r.Get("/admin/storage/replica", adminHandler.HandleGetReplicaStatus)
+			r.Post("/admin/storage/replica/reconcile", adminHandler.HandleReconcileReplica)
+
+			// Metadata snapshots
+			r.Get("/admin/snapshots", adminHandler.HandleListSnapshots)
+			r.Post("/admin/snapshots", adminHandler.HandleCreateSnapshot)
+			r.Get("/admin/snapshots/{id}/restorable", adminHandler.HandleGetRestorableFiles)
+			r.Post("/admin/snapshots/{id}/restore", adminHandler.HandleRestoreFromSnapshot)
+
+			// Upload quarantine
+			r.Get("/admin/quarantine", adminHandler.HandleListQuarantine)
+			r.Post("/admin/quarantine/{id}/approve", adminHandler.HandleApproveQuarantined)
+			r.Post("/admin/quarantine/{id}/reject", adminHandler.HandleRejectQuarantined)
+
+			// Abuse reports about share links
+			r.Get("/admin/reports", adminHandler.HandleListShareReports)
+			r.Post("/admin/reports/{id}/resolve", adminHandler.HandleResolveShareReport)
+
+			// Usage reports (weekly/monthly summaries)
+			r.Get("/admin/usage-reports/{period}", adminHandler.HandleGetUsageReport)
+			r.Post("/admin/usage-reports/{period}/send", adminHandler.HandleSendUsageReport)
+
+			// Monthly usage records per user (chargeback)
+			r.Get("/admin/usage", adminHandler.HandleGetUsageRecords)
+			r.Get("/admin/usage/export", adminHandler.HandleExportUsageRecords)
+			r.Post("/admin/usage/send", adminHandler.HandleSendUsageRecords)
+
+			// Chat notification channels
+			r.Get("/admin/notifications", adminHandler.HandleListNotificationChannels)
+			r.Post("/admin/notifications/{channel}/test", adminHandler.HandleTestNotificationChannel)
+
+			// Key rotation
+			r.Post("/admin/keys/rotate", adminHandler.HandleStartKeyRotation)
+			r.Get("/admin/keys/rotation", adminHandler.HandleGetKeyRotation)
+
+			// Background jobs
+			r.Get("/admin/jobs", adminHandler.HandleListJobs)
+			r.Post("/admin/jobs/{id}/retry", adminHandler.HandleRetryJob)
+			r.Post("/admin/files/{id}/reencrypt", adminHandler.HandleReencryptFile)
+
+			// Replication to other instances
+			r.Get("/admin/replication/remotes", adminHandler.HandleListReplicationRemotes)
+			r.Post("/admin/replication/remotes", adminHandler.HandleCreateReplicationRemote)
+			r.Delete("/admin/replication/remotes/{id}", adminHandler.HandleDeleteReplicationRemote)
+			r.Post("/admin/replication/remotes/{id}/sync", adminHandler.HandleReplicate)
+
+			// Sessions
+			r.Get("/admin/sessions", adminHandler.HandleListSessions)
+			r.Post("/admin/sessions/revoke-all", adminHandler.HandleRevokeAllSessions)
+			r.Get("/admin/signing-keys", adminHandler.HandleListSigningKeys)
+			r.Post("/admin/signing-keys/rotate", adminHandler.HandleRotateSigningKey)
 
 			// Audit logs
 			r.Get("/admin/logs", adminHandler.HandleGetAuditLogs)
 		})
+	}
+
+	// Both versions share the handlers: v2 wraps JSON responses in a {data, error,
+	// meta} envelope with error codes, v1 is deprecated in its favour
+	var v1Sunset time.Time
+	if cfg.Server.APIV1Sunset != "" {
+		v1Sunset, _ = time.Parse("2006-01-02", cfg.Server.APIV1Sunset) // validated with the config
+	}
+	r.Route(api.APIV1Prefix, func(r chi.Router) {
+		r.Use(api.Deprecated(v1Sunset))
+		apiRoutes(r)
+	})
+	r.Route(api.APIV2Prefix, func(r chi.Router) {
+		r.Use(api.EnvelopeV2)
+		apiRoutes(r)
 	})
 
+	// Every API route needs an authorization rule; Authorize refuses routes without one
+	missing, stale, err := auth.CheckPolicy(r, api.APIV1Prefix)
+	if err != nil {
+		log.Fatalf("Failed to check authorization policy: %v", err)
+	}
+	if len(missing) > 0 {
+		log.Fatalf("Routes missing from the authorization policy (internal/auth/policy.go): %v", missing)
+	}
+	if len(stale) > 0 {
+		appLogger.Warn("Authorization policy lists routes that do not exist", slog.Any("routes", stale))
+	}
+
 	appLogger.Info("HTTP routes configured")
 
 	// Initialize gRPC server
 	grpcServer := grpc.NewServer()
-	fileServiceServer := grpcService.NewFileServiceServer(pgStore)
+	fileServiceServer := grpcService.NewFileServiceServer(pgStore, redisCache, minioStorage)
 	pb.RegisterFileServiceServer(grpcServer, fileServiceServer)
 	appLogger.Info("gRPC server initialized")
 
@@ -295,9 +745,88 @@ func main() {
 
 	if cfg.Features.AutoDelete.Enabled {
 		cleanupInterval := time.Duration(cfg.Features.AutoDelete.CheckInterval) * time.Minute
-		cleanupWorker := worker.NewCleanupWorker(minioStorage, pgStore, cleanupInterval)
-		go cleanupWorker.Start(ctx)
-		appLogger.Info("Cleanup worker started", slog.Duration("interval", cleanupInterval))
+		warnBefore := time.Duration(cfg.Features.AutoDelete.WarnBeforeHours) * time.Hour
+		cleanupWorker := worker.NewCleanupWorker(minioStorage, pgStore, redisCache, cleanupInterval, warnBefore, cfg.Features.Trash.Retention)
+		cleanupWorker.SetRunHook(func(ctx context.Context, kind string, files int, bytes int64) {
+			what := "expired files"
+			if kind == storage.CleanupTrash {
+				what = "files from the trash"
+			}
+			notifier.Notify(ctx, notify.EventCleanup, notify.Message{
+				Title: "Cleanup finished",
+				Text:  fmt.Sprintf("Deleted %d %s, freeing %s.", files, what, humanize.Bytes(uint64(bytes))),
+			})
+		})
+		// Only the elected replica runs scheduled cleanup
+		go worker.RunAsLeader(ctx, redisCache, "cleanup", cleanupWorker.Start)
+		appLogger.Info("Cleanup worker started",
+			slog.Duration("interval", cleanupInterval),
+			slog.Duration("warn_before", warnBefore),
+			slog.Duration("trash_retention", cfg.Features.Trash.Retention),
+		)
+	}
+
+	// Run key rotations (including ones interrupted by a shutdown) on one replica
+	keyRotator.Start(ctx)
+
+	// Scheduled signing key rotation (only the elected replica checks)
+	if interval := cfg.Security.JWTRotationInterval; interval > 0 {
+		go worker.RunAsLeader(ctx, redisCache, "signing-key-rotation", jwtService.RotateEvery(interval))
+		appLogger.Info("Signing key rotation scheduled", slog.Duration("interval", interval))
+	}
+
+	// Scheduled metadata snapshots (only the elected replica takes them)
+	if interval := cfg.Features.Snapshots.Interval; interval > 0 {
+		go worker.RunAsLeader(ctx, redisCache, "snapshots", snapshotter.Start)
+		appLogger.Info("Metadata snapshots scheduled", slog.Duration("interval", interval), slog.Int("keep", cfg.Features.Snapshots.Keep))
+	}
+
+	// Scheduled usage reports (only the elected replica queues them)
+	if reporter.Scheduled() {
+		go worker.RunAsLeader(ctx, redisCache, "usage-reports", reporter.Start)
+		appLogger.Info("Usage reports scheduled", slog.Bool("weekly", reportsCfg.Weekly), slog.Bool("monthly", reportsCfg.Monthly))
+		if !reportsCfg.EmailAdmins && len(reportsCfg.Recipients) == 0 && reportsCfg.Webhook.URL == "" {
+			appLogger.Warn("Usage reports have no recipients or webhook; they are generated but go nowhere")
+		}
+	}
+
+	// Usage records (only the elected replica adds the finished days)
+	go worker.RunAsLeader(ctx, redisCache, "usage-records", usageRecorder.Start)
+	if usageRecorder.Webhook() {
+		appLogger.Info("Usage records are posted monthly to the billing webhook")
+	}
+
+	// Storage usage alerts (only the elected replica checks)
+	if storageMonitor.Enabled() {
+		go worker.RunAsLeader(ctx, redisCache, "storage-alerts", storageMonitor.Start)
+		appLogger.Info("Storage alerts enabled",
+			slog.Int("user_quota_percent", ac.UserQuotaPercent),
+			slog.Int64("bucket_capacity", ac.Bucket.Capacity),
+			slog.Int64("database_capacity", ac.Database.Capacity),
+		)
+	}
+
+	// Background job workers (every replica takes part)
+	jobQueue.Start(ctx)
+	appLogger.Info("Job workers started", slog.Int("workers", cfg.Features.Jobs.Workers))
+
+	// Encrypt metadata of files stored before encryption was enabled (on one replica)
+	if pgStore.MetadataEncrypted() {
+		go func() {
+			lockCtx, release, ok, err := worker.HoldLock(ctx, redisCache, "metadata-encryption")
+			if err != nil || !ok {
+				return
+			}
+			defer release()
+
+			converted, err := pgStore.EncryptPlaintextMetadata(lockCtx)
+			if len(converted) > 0 {
+				_ = redisCache.InvalidateFileMetadata(context.Background(), converted...)
+			}
+			if err != nil {
+				appLogger.Error("Failed to encrypt existing file metadata", slog.String("error", err.Error()))
+			}
+		}()
 	}
 
 	// Start gRPC server in a goroutine
@@ -316,18 +845,36 @@ func main() {
 
 	// Start HTTP server
 	httpServer := &http.Server{
-		Addr:           fmt.Sprintf(":%d", cfg.Server.Port),
-		Handler:        r,
-		ReadTimeout:    cfg.Server.ReadTimeout,
-		WriteTimeout:   cfg.Server.WriteTimeout,
-		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           r,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
 	}
 
+	// HTTP/2 is negotiated via ALPN, so it is only offered over TLS
+	tlsCfg := cfg.Security.TLS
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(tlsCfg.Enabled)
+	httpServer.Protocols = protocols
+
 	// Start HTTP server in a goroutine
 	go func() {
-		appLogger.Info("🚀 HTTP server listening", slog.Int("port", cfg.Server.Port))
+		appLogger.Info("🚀 HTTP server listening",
+			slog.Int("port", cfg.Server.Port),
+			slog.Bool("tls", tlsCfg.Enabled),
+		)
 		appLogger.Info("File Locker Backend is ready!")
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if tlsCfg.Enabled {
+			err = httpServer.ListenAndServeTLS(tlsCfg.CertFile, tlsCfg.KeyFile)
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			appLogger.Error("HTTP server failed", slog.String("error", err.Error()))
 			log.Fatalf("HTTP server failed: %v", err)
 		}
@@ -356,3 +903,397 @@ func main() {
 
 	appLogger.Info("Servers stopped gracefully")
 }
+
+// buildInfo returns the version of this binary
+func buildInfo() api.BuildInfo {
+	info := api.BuildInfo{Version: Version, GitCommit: GitCommit, BuildDate: BuildTime}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.GitCommit == "":
+				info.GitCommit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	return info
+}
+
+// enabledFeatures lists the optional features and API capabilities of this server
+// for GET /version. Clients check them before using a feature; add a key here with
+// every new capability they may need to detect.
+func enabledFeatures(cfg *config.Config) map[string]bool {
+	p := cfg.Features.Pipeline
+	sa := cfg.Features.StorageAlerts
+	return map[string]bool{
+		"streaming_upload":     true,
+		"upload_precheck":      true,
+		"download_tickets":     true,
+		"signed_download_urls": true,
+		"file_list_cursor":     true,
+		"maintenance_mode":     true,
+		"read_only_mode":       true,
+		"idempotency_keys":     true,
+		"replication":          true,
+		"object_replica":       cfg.Storage.MinIO.Replica.Enabled,
+		"metadata_snapshots":   true,
+		"upload_quarantine":    true,
+		"share_reports":        true,
+		"share_stats":          true,
+		"usage_reports":        true,
+		"usage_records":        true,
+		"chat_notifications":   len(cfg.Features.Notifications.Channels) > 0,
+		"storage_alerts":       sa.UserQuotaPercent > 0 || sa.Bucket.Capacity > 0 || sa.Database.Capacity > 0,
+		"trash":                cfg.Features.Trash.Retention > 0,
+		"auto_delete":          cfg.Features.AutoDelete.Enabled,
+		"video_streaming":      cfg.Features.VideoStreaming.Enabled,
+		"batch_uploads":        cfg.Features.BatchUploads.Enabled,
+		"parallel_encryption":  cfg.Features.Uploads.EncryptionWorkers > 1,
+		"metadata_encryption":  cfg.Security.EncryptMetadata,
+		"checksums":            p.Checksum,
+		"virus_scan":           p.VirusScan.Enabled,
+		"thumbnails":           p.Thumbnails.Enabled,
+		"upload_webhook":       p.Webhook.Enabled,
+		"compression":          cfg.Server.Compression.Enabled,
+		"rate_limiting":        cfg.Security.RateLimit.Enabled,
+	}
+}
+
+// signingKeys is the JWT key ring: security.jwt_keys, or jwt_secret alone
+func signingKeys(cfg config.SecurityConfig) []auth.SigningKey {
+	if len(cfg.JWTKeys) == 0 {
+		return []auth.SigningKey{{ID: auth.DefaultKeyID, Secret: []byte(cfg.JWTSecret)}}
+	}
+	keys := make([]auth.SigningKey, len(cfg.JWTKeys))
+	for i, k := range cfg.JWTKeys {
+		keys[i] = auth.SigningKey{ID: k.ID, Secret: []byte(k.Secret)}
+	}
+	return keys
+}
+
+// databaseURL returns the connection URL used for migrations
+func databaseURL(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		cfg.User,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.DBName,
+	)
+}
+
+// runMigrateCommand implements fl-server migrate, for operators who manage the schema
+// explicitly (storage.database.skip_migrations) instead of migrating at startup
+func runMigrateCommand(args []string) error {
+	usage := `usage: fl-server migrate <command>
+
+  status              Show the schema version and pending migrations
+  up [N]              Apply all pending migrations, or the next N
+  down [N]            Roll back the last N migrations (default 1)
+  force <version>     Mark version as applied and clear the dirty flag (after a manual fix)
+  create <name>       Write a new empty migration pair (--dir, default internal/db/migrations)`
+
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	// create works on the source tree and needs no database
+	if args[0] == "create" {
+		fs := flag.NewFlagSet("create", flag.ContinueOnError)
+		dir := fs.String("dir", "internal/db/migrations", "migrations source directory")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return errors.New("usage: fl-server migrate create [--dir <dir>] <name>")
+		}
+		paths, err := db.CreateMigration(*dir, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			fmt.Printf("✅ Created %s\n", path)
+		}
+		return nil
+	}
+
+	// count parses the optional step count of up/down
+	count := func(def int) (int, error) {
+		if len(args) < 2 {
+			return def, nil
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid number of migrations: %s", args[1])
+		}
+		return n, nil
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	dbURL := databaseURL(cfg.Storage.Database)
+
+	switch args[0] {
+	case "status":
+		status, err := db.GetMigrationStatus(dbURL)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Schema version: %d (latest available: %d)\n", status.Version, status.Latest)
+		if status.Dirty {
+			fmt.Printf("⚠️  Version %d is dirty: it failed halfway. Repair the schema, then run: fl-server migrate force <version>\n", status.Version)
+		}
+		if len(status.Pending) == 0 {
+			fmt.Println("✅ No pending migrations")
+		} else {
+			fmt.Printf("Pending migrations: %v\n", status.Pending)
+		}
+		return nil
+
+	case "up":
+		n, err := count(0)
+		if err != nil {
+			return err
+		}
+		version, err := db.MigrateUp(dbURL, n)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Schema at version %d\n", version)
+		return nil
+
+	case "down":
+		n, err := count(1)
+		if err != nil {
+			return err
+		}
+		version, err := db.MigrateDown(dbURL, n)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Schema at version %d\n", version)
+		return nil
+
+	case "force":
+		if len(args) != 2 {
+			return errors.New("usage: fl-server migrate force <version>")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < -1 {
+			return fmt.Errorf("invalid version: %s", args[1])
+		}
+		if err := db.ForceMigrationVersion(dbURL, version); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Schema version forced to %d\n", version)
+		return nil
+
+	default:
+		return errors.New(usage)
+	}
+}
+
+// pipelineStages returns the enabled upload processing stages
+func pipelineStages(cfg config.PipelineConfig, pgStore *storage.PostgresStore) []pipeline.Stage {
+	var stages []pipeline.Stage
+	if cfg.Checksum {
+		stages = append(stages, pipeline.Checksum{})
+	}
+	if cfg.VirusScan.Enabled {
+		stages = append(stages, &pipeline.VirusScan{Address: cfg.VirusScan.ClamdAddress})
+	}
+	if cfg.Thumbnails.Enabled {
+		stages = append(stages, pipeline.NewThumbnail(pgStore, cfg.Thumbnails.Size))
+	}
+	if cfg.Webhook.Enabled {
+		stages = append(stages, pipeline.NewWebhook(cfg.Webhook.URL, cfg.Webhook.Secret))
+	}
+	return stages
+}
+
+// quarantineRules converts the configured quarantine rules
+func quarantineRules(cfg config.QuarantineConfig) []quarantine.Rule {
+	rules := make([]quarantine.Rule, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		rules[i] = quarantine.Rule{
+			Name:        r.Name,
+			MinSize:     r.MinSize,
+			MimeTypes:   r.MimeTypes,
+			Extensions:  r.Extensions,
+			ScanVerdict: r.ScanVerdict,
+			TrustLevels: r.TrustLevels,
+		}
+	}
+	return rules
+}
+
+// notificationChannels converts features.notifications.channels
+func notificationChannels(cfg config.NotificationsConfig) []notify.Channel {
+	channels := make([]notify.Channel, len(cfg.Channels))
+	for i, c := range cfg.Channels {
+		channels[i] = notify.Channel{
+			Name:        c.Name,
+			Provider:    c.Provider,
+			URL:         c.URL,
+			Homeserver:  c.Homeserver,
+			RoomID:      c.RoomID,
+			AccessToken: c.AccessToken,
+			Events:      c.Events,
+		}
+	}
+	return channels
+}
+
+// configureKeys sets up wrapping of per-file data keys (with the external KMS when
+// configured, otherwise the local KEK) and, if enabled, metadata encryption. With a
+// KMS and no local KEK the metadata key is generated once and stored KMS-wrapped.
+func configureKeys(ctx context.Context, sec config.SecurityConfig, pgStore *storage.PostgresStore, appLogger *slog.Logger) error {
+	var keys [][]byte // current local key first, then retired KEKs
+	if sec.KEK != "" {
+		kek, err := crypto.ParseKEK(sec.KEK)
+		if err != nil {
+			return fmt.Errorf("invalid security.kek: %w", err)
+		}
+		keys = append(keys, kek)
+	}
+
+	if sec.KMS.Provider != "" {
+		svc, err := kms.New(kms.Config{
+			Provider:           sec.KMS.Provider,
+			VaultAddress:       sec.KMS.Vault.Address,
+			VaultToken:         sec.KMS.Vault.Token,
+			VaultMount:         sec.KMS.Vault.Mount,
+			VaultKey:           sec.KMS.Vault.KeyName,
+			AWSRegion:          sec.KMS.AWS.Region,
+			AWSKeyID:           sec.KMS.AWS.KeyID,
+			AWSAccessKeyID:     sec.KMS.AWS.AccessKeyID,
+			AWSSecretAccessKey: sec.KMS.AWS.SecretAccessKey,
+			AWSSessionToken:    sec.KMS.AWS.SessionToken,
+			AWSEndpoint:        sec.KMS.AWS.Endpoint,
+		})
+		if err != nil {
+			return err
+		}
+		pgStore.SetKeyService(svc, sec.KMS.CacheTTL)
+
+		if len(keys) == 0 && sec.EncryptMetadata {
+			metadataKey, err := pgStore.LoadOrCreateWrappedKey(ctx, "metadata", svc)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, metadataKey)
+		}
+		appLogger.Info("External KMS enabled",
+			slog.String("provider", sec.KMS.Provider),
+			slog.String("key_id", svc.KeyID()),
+			slog.Duration("cache_ttl", sec.KMS.CacheTTL),
+		)
+	} else if sec.EncryptMetadata && len(keys) == 0 {
+		return errors.New("security.encrypt_metadata requires security.kek or security.kms")
+	}
+
+	for i, encoded := range sec.PreviousKEKs {
+		old, err := crypto.ParseKEK(encoded)
+		if err != nil {
+			return fmt.Errorf("invalid security.previous_keks[%d]: %w", i, err)
+		}
+		keys = append(keys, old)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	fieldCipher, err := crypto.NewFieldCipher(keys[0], keys[1:]...)
+	if err != nil {
+		return err
+	}
+
+	var blindIndex *crypto.BlindIndexer
+	if sec.EncryptMetadata {
+		if blindIndex, err = crypto.NewBlindIndexer(keys[0]); err != nil {
+			return fmt.Errorf("failed to initialize metadata encryption: %w", err)
+		}
+	}
+	pgStore.SetEncryption(fieldCipher, crypto.KEKID(keys[0]), blindIndex)
+	appLogger.Info("Key encryption enabled",
+		slog.String("kek_id", pgStore.CurrentKEKID()),
+		slog.Int("previous_keks", len(sec.PreviousKEKs)),
+		slog.Bool("encrypt_metadata", sec.EncryptMetadata),
+	)
+	return nil
+}
+
+// depWaiter retries connecting to the server's dependencies (Postgres, MinIO,
+// Redis) with exponential backoff, so the server can start before they are up
+// (e.g. under docker-compose). All dependencies share one deadline.
+type depWaiter struct {
+	deadline       time.Time // zero = try once
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	logger         *slog.Logger
+}
+
+func newDepWaiter(cfg config.StartupConfig, logger *slog.Logger) *depWaiter {
+	w := &depWaiter{
+		initialBackoff: cfg.InitialBackoff,
+		maxBackoff:     cfg.MaxBackoff,
+		logger:         logger,
+	}
+	if cfg.WaitForDeps > 0 {
+		w.deadline = time.Now().Add(cfg.WaitForDeps)
+	}
+	if w.initialBackoff <= 0 {
+		w.initialBackoff = time.Second
+	}
+	if w.maxBackoff <= 0 {
+		w.maxBackoff = 30 * time.Second
+	}
+	if w.maxBackoff < w.initialBackoff {
+		w.maxBackoff = w.initialBackoff
+	}
+	return w
+}
+
+// wait calls connect until it succeeds, the deadline passes or ctx is cancelled,
+// and returns the last error in the latter cases
+func (w *depWaiter) wait(ctx context.Context, name string, connect func() error) error {
+	backoff := w.initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		if err == nil {
+			if attempt > 1 {
+				w.logger.Info("Dependency is up", slog.String("dependency", name), slog.Int("attempts", attempt))
+			}
+			return nil
+		}
+
+		remaining := time.Until(w.deadline)
+		if w.deadline.IsZero() || remaining <= 0 {
+			if attempt > 1 {
+				return fmt.Errorf("%s not reachable after %d attempts: %w", name, attempt, err)
+			}
+			return err
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		w.logger.Warn("Dependency not ready, retrying",
+			slog.String("dependency", name),
+			slog.Int("attempt", attempt),
+			slog.String("retry_in", backoff.Round(time.Millisecond).String()),
+			slog.String("error", err.Error()),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up waiting for %s: %w", name, ctx.Err())
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, w.maxBackoff)
+	}
+}
@@ -0,0 +1,395 @@
+// Package alerts watches storage usage: each user's files against the per-user
+// quota (the storage_quota_per_user_bytes setting), all stored files against the
+// capacity of the bucket, and the size of the Postgres database. A monitor on the
+// elected replica checks the thresholds periodically. Crossing one raises an alert
+// once (kept in storage_alerts until usage drops below it again): users get an
+// announcement and an email, admins an email and a chat notification. The current
+// state is part of GET /admin/stats.
+//
+// The quota itself is soft: users over it are warned once and keep uploading
+// for the grace period of the storage_quota_grace_days setting, then uploads are
+// refused until they are back under it (never, while the setting is "off").
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sachinthra/file-locker/backend/internal/i18n"
+	"github.com/sachinthra/file-locker/backend/internal/mail"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const (
+	defaultInterval = 15 * time.Minute
+	defaultPercent  = 90
+
+	// User announcements expire after this long; they are not repeated while the
+	// user stays over the threshold
+	announcementTTL = 7 * 24 * time.Hour
+)
+
+// Threshold alerts when usage exceeds Percent of Capacity
+type Threshold struct {
+	Capacity int64 // bytes; 0 = not checked
+	Percent  int   // 0 = 90
+}
+
+// Config selects the thresholds and who is told
+type Config struct {
+	Interval         time.Duration // 0 = 15m
+	UserQuotaPercent int           // of the per-user quota; 0 = not checked
+	Bucket           Threshold
+	Database         Threshold
+	EmailAdmins      bool
+	EmailUsers       bool // users who opted in (the default) get an email too
+}
+
+// Usage is a measured value against its threshold
+type Usage struct {
+	UsedBytes        int64   `json:"used_bytes"`
+	CapacityBytes    int64   `json:"capacity_bytes"`
+	Percent          float64 `json:"percent"`
+	ThresholdPercent int     `json:"threshold_percent"`
+	Exceeded         bool    `json:"exceeded"`
+}
+
+// QuotaUsage is how many users are over the quota threshold
+type QuotaUsage struct {
+	QuotaBytes       int64 `json:"quota_bytes"` // 0 = no quota set
+	ThresholdPercent int   `json:"threshold_percent"`
+	UsersOver        int   `json:"users_over"`
+}
+
+// Status is the current storage usage and the raised alerts
+type Status struct {
+	Bucket    *Usage                 `json:"bucket,omitempty"`
+	Database  *Usage                 `json:"database,omitempty"`
+	UserQuota *QuotaUsage            `json:"user_quota,omitempty"`
+	Alerts    []storage.StorageAlert `json:"alerts"`
+}
+
+// Monitor checks the thresholds and raises alerts
+type Monitor struct {
+	pgStore  *storage.PostgresStore
+	mailer   mail.Sender
+	notifier *notify.Notifier
+	cfg      Config
+}
+
+// New creates a monitor; Start checks the configured thresholds periodically
+func New(pgStore *storage.PostgresStore, mailer mail.Sender, notifier *notify.Notifier, cfg Config) *Monitor {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.Bucket.Percent <= 0 {
+		cfg.Bucket.Percent = defaultPercent
+	}
+	if cfg.Database.Percent <= 0 {
+		cfg.Database.Percent = defaultPercent
+	}
+	return &Monitor{pgStore: pgStore, mailer: mailer, notifier: notifier, cfg: cfg}
+}
+
+// Enabled reports whether any threshold is configured
+func (m *Monitor) Enabled() bool {
+	return m.cfg.UserQuotaPercent > 0 || m.cfg.Bucket.Capacity > 0 || m.cfg.Database.Capacity > 0
+}
+
+// Start checks the thresholds every interval until ctx is cancelled; run it on
+// one replica
+func (m *Monitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Check(ctx); err != nil {
+			log.Printf("[alerts] %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// measurement is the usage at the time of a check
+type measurement struct {
+	bucket, database *Usage
+	quota            *QuotaUsage
+	usersOver        []storage.UserUsage
+}
+
+func (m *Monitor) measure(ctx context.Context) (*measurement, error) {
+	var ms measurement
+	if t := m.cfg.Bucket; t.Capacity > 0 {
+		used, err := m.pgStore.GetStoredBytes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ms.bucket = usage(used, t)
+	}
+	if t := m.cfg.Database; t.Capacity > 0 {
+		used, err := m.pgStore.GetDatabaseSize(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ms.database = usage(used, t)
+	}
+	if m.cfg.UserQuotaPercent > 0 {
+		quota, err := m.pgStore.GetStorageQuota(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ms.quota = &QuotaUsage{QuotaBytes: quota, ThresholdPercent: m.cfg.UserQuotaPercent}
+		if quota > 0 {
+			ms.usersOver, err = m.pgStore.ListUsersOverStorage(ctx, quota*int64(m.cfg.UserQuotaPercent)/100)
+			if err != nil {
+				return nil, err
+			}
+			ms.quota.UsersOver = len(ms.usersOver)
+		}
+	}
+	return &ms, nil
+}
+
+func usage(used int64, t Threshold) *Usage {
+	percent := float64(used) * 100 / float64(t.Capacity)
+	return &Usage{
+		UsedBytes:        used,
+		CapacityBytes:    t.Capacity,
+		Percent:          float64(int(percent*10)) / 10,
+		ThresholdPercent: t.Percent,
+		Exceeded:         percent >= float64(t.Percent),
+	}
+}
+
+// Status measures the current usage; nil if no threshold is configured
+func (m *Monitor) Status(ctx context.Context) (*Status, error) {
+	if !m.Enabled() {
+		return nil, nil
+	}
+	ms, err := m.measure(ctx)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := m.pgStore.ListStorageAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{Bucket: ms.bucket, Database: ms.database, UserQuota: ms.quota, Alerts: alerts}, nil
+}
+
+// Check measures the usage, raises the alerts of thresholds newly exceeded and
+// clears those no longer exceeded
+func (m *Monitor) Check(ctx context.Context) error {
+	ms, err := m.measure(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.checkGlobal(ctx, storage.AlertBucket, ms.bucket, "Stored files")
+	m.checkGlobal(ctx, storage.AlertDatabase, ms.database, "Database")
+
+	keep := make([]string, 0, len(ms.usersOver))
+	for _, u := range ms.usersOver {
+		keep = append(keep, u.UserID)
+		limit := ms.quota.QuotaBytes * int64(m.cfg.UserQuotaPercent) / 100
+		raised, err := m.pgStore.RaiseStorageAlert(ctx, storage.AlertUserQuota, u.UserID, u.Bytes, limit)
+		if err != nil {
+			return err
+		}
+		if raised {
+			m.warnUser(ctx, u, ms.quota.QuotaBytes)
+		}
+	}
+	cleared, err := m.pgStore.ClearStorageAlerts(ctx, storage.AlertUserQuota, keep)
+	if err != nil {
+		return err
+	}
+	if len(cleared) > 0 {
+		log.Printf("[alerts] %d user(s) back under the quota threshold", len(cleared))
+	}
+	return m.checkOverruns(ctx)
+}
+
+// checkOverruns starts the grace period of users who went over the quota, and
+// ends it for those back under it
+func (m *Monitor) checkOverruns(ctx context.Context) error {
+	policy, err := m.pgStore.GetQuotaPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	over, err := m.pgStore.SyncQuotaOverruns(ctx, policy.QuotaBytes)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, u := range over {
+		m.warnOverQuota(ctx, u, policy.Status(u.Bytes, &now, now))
+	}
+	return nil
+}
+
+// QuotaStatus measures a user's storage against the quota (nil without one). The
+// first check to find them over it starts their grace period and warns them.
+func (m *Monitor) QuotaStatus(ctx context.Context, userID string) (*storage.QuotaStatus, error) {
+	policy, err := m.pgStore.GetQuotaPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, entered, err := m.pgStore.UpdateQuotaStatus(ctx, userID, policy)
+	if err != nil {
+		return nil, err
+	}
+	if entered {
+		// Off the request: mail can be slow
+		go func(ctx context.Context) {
+			user, err := m.pgStore.GetUserByID(ctx, userID)
+			if err != nil {
+				log.Printf("[alerts] %v", err)
+				return
+			}
+			if user.Role == storage.RoleService {
+				return
+			}
+			m.warnOverQuota(ctx, storage.UserUsage{UserID: user.ID, Username: user.Username, Email: user.Email, Bytes: status.UsedBytes}, status)
+		}(context.WithoutCancel(ctx))
+	}
+	return status, nil
+}
+
+// checkGlobal raises or clears the alert of the bucket or the database
+func (m *Monitor) checkGlobal(ctx context.Context, kind string, u *Usage, what string) {
+	var keep []string
+	if u != nil && u.Exceeded {
+		keep = []string{""}
+		limit := u.CapacityBytes * int64(u.ThresholdPercent) / 100
+		raised, err := m.pgStore.RaiseStorageAlert(ctx, kind, "", u.UsedBytes, limit)
+		if err != nil {
+			log.Printf("[alerts] %v", err)
+			return
+		}
+		if raised {
+			m.warnAdmins(ctx, kind, fmt.Sprintf("%s: %s of %s (%.1f%%), above the %d%% threshold.",
+				what, humanize.Bytes(uint64(u.UsedBytes)), humanize.Bytes(uint64(u.CapacityBytes)), u.Percent, u.ThresholdPercent))
+		}
+	}
+	cleared, err := m.pgStore.ClearStorageAlerts(ctx, kind, keep)
+	if err != nil {
+		log.Printf("[alerts] %v", err)
+		return
+	}
+	if len(cleared) > 0 {
+		log.Printf("[alerts] %s usage back under the threshold", kind)
+	}
+}
+
+// warnAdmins mails the admins and posts to the chat channels
+func (m *Monitor) warnAdmins(ctx context.Context, kind, text string) {
+	title := "File Locker storage alert: " + kind
+	log.Printf("[alerts] %s", text)
+	m.notifier.Notify(ctx, notify.EventStorageThreshold, notify.Message{Title: title, Text: text})
+
+	if !m.cfg.EmailAdmins {
+		return
+	}
+	admins, err := m.pgStore.ListAdminEmails(ctx)
+	if err != nil {
+		log.Printf("[alerts] %v", err)
+		return
+	}
+	for _, to := range admins {
+		if err := m.mailer.Send(ctx, to, title, text+"\n"); err != nil {
+			log.Printf("[alerts] Failed to mail %s: %v", to, err)
+		}
+	}
+}
+
+// warnOverQuota announces to a user that they are over the quota and when their
+// uploads will be refused, and mails them
+func (m *Monitor) warnOverQuota(ctx context.Context, u storage.UserUsage, status *storage.QuotaStatus) {
+	prefs, err := m.pgStore.GetUserPreferences(ctx, u.UserID)
+	if err != nil {
+		prefs = storage.DefaultUserPreferences()
+	}
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	catalog := i18n.Default()
+	lang := catalog.ForLocale(prefs.Locale)
+	args := []string{
+		"username", u.Username,
+		"used", humanize.Bytes(uint64(u.Bytes)),
+		"quota", humanize.Bytes(uint64(status.QuotaBytes)),
+	}
+	key := "announcement.storage_quota_exceeded.message"
+	switch {
+	case status.State == storage.QuotaBlocked:
+		key += "_blocked"
+	case status.GraceEndsAt != nil:
+		key += "_grace"
+		args = append(args, "deadline", status.GraceEndsAt.In(loc).Format("2006-01-02 15:04 MST"))
+	}
+
+	expires := time.Now().Add(announcementTTL)
+	if status.GraceEndsAt != nil && status.GraceEndsAt.After(expires) {
+		expires = *status.GraceEndsAt
+	}
+	title := catalog.Text(lang, "announcement.storage_quota_exceeded.title")
+	message := catalog.Text(lang, key, args...)
+	if _, err := m.pgStore.CreateSystemAnnouncement(ctx, title, message, "warning", []string{u.UserID}, &expires); err != nil {
+		log.Printf("[alerts] Failed to warn user %s: %v", u.UserID, err)
+	}
+	log.Printf("[alerts] User %s is over the %s quota with %s (%s)", u.Username, humanize.Bytes(uint64(status.QuotaBytes)), humanize.Bytes(uint64(u.Bytes)), status.State)
+
+	if !m.cfg.EmailUsers || !prefs.Notifications.StorageQuota || u.Email == "" {
+		return
+	}
+	body := catalog.Text(lang, "email.storage_quota_exceeded.body", "username", u.Username, "message", message)
+	if err := m.mailer.Send(ctx, u.Email, catalog.Text(lang, "email.storage_quota_exceeded.subject"), body); err != nil {
+		log.Printf("[alerts] Failed to mail %s: %v", u.Email, err)
+	}
+}
+
+// warnUser announces to a user that they near their quota, and mails them
+func (m *Monitor) warnUser(ctx context.Context, u storage.UserUsage, quota int64) {
+	prefs, err := m.pgStore.GetUserPreferences(ctx, u.UserID)
+	if err != nil {
+		prefs = storage.DefaultUserPreferences()
+	}
+
+	// Written in the user's language; announcements are stored as text
+	catalog := i18n.Default()
+	lang := catalog.ForLocale(prefs.Locale)
+	args := []string{
+		"username", u.Username,
+		"used", humanize.Bytes(uint64(u.Bytes)),
+		"quota", humanize.Bytes(uint64(quota)),
+		"percent", strconv.FormatInt(u.Bytes*100/quota, 10),
+	}
+
+	expires := time.Now().Add(announcementTTL)
+	title := catalog.Text(lang, "announcement.storage_quota.title")
+	message := catalog.Text(lang, "announcement.storage_quota.message", args...)
+	if _, err := m.pgStore.CreateSystemAnnouncement(ctx, title, message, "warning", []string{u.UserID}, &expires); err != nil {
+		log.Printf("[alerts] Failed to warn user %s: %v", u.UserID, err)
+	}
+	log.Printf("[alerts] User %s uses %s of the %s quota", u.Username, humanize.Bytes(uint64(u.Bytes)), humanize.Bytes(uint64(quota)))
+
+	if !m.cfg.EmailUsers || !prefs.Notifications.StorageQuota || u.Email == "" {
+		return
+	}
+	body := catalog.Text(lang, "email.storage_quota.body", args...)
+	if err := m.mailer.Send(ctx, u.Email, catalog.Text(lang, "email.storage_quota.subject"), body); err != nil {
+		log.Printf("[alerts] Failed to mail %s: %v", u.Email, err)
+	}
+}
@@ -7,28 +7,62 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/lib/pq"
+	"github.com/sachinthra/file-locker/backend/internal/alerts"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/capacity"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/reports"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/internal/usage"
+	"github.com/sachinthra/file-locker/backend/internal/worker"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AdminHandler struct {
 	pg          *storage.PostgresStore
 	minioStore  *storage.MinIOStorage
+	replica     *storage.MinIOStorage // standby storage; nil unless storage.minio.replica is enabled
 	redisCache  *storage.RedisCache
 	auditLogger *AuditLogger
+	keyRotator  *worker.KeyRotator
+	snapshots   *worker.Snapshotter
+	reporter    *reports.Reporter
+	usage       *usage.Recorder
+	notifier    *notify.Notifier
+	monitor     *alerts.Monitor
+	space       *capacity.Guard
+	jobQueue    *jobs.Queue
+	maintenance *Maintenance
+	ipAccess    *IPAccess
+	jwtService  *auth.JWTService
 }
 
-func NewAdminHandler(pg *storage.PostgresStore, minioStore *storage.MinIOStorage, redisCache *storage.RedisCache) *AdminHandler {
+func NewAdminHandler(pg *storage.PostgresStore, minioStore, replica *storage.MinIOStorage, redisCache *storage.RedisCache, keyRotator *worker.KeyRotator, snapshots *worker.Snapshotter, reporter *reports.Reporter, usageRecorder *usage.Recorder, notifier *notify.Notifier, monitor *alerts.Monitor, space *capacity.Guard, jobQueue *jobs.Queue, maintenance *Maintenance, ipAccess *IPAccess, jwtService *auth.JWTService) *AdminHandler {
 	return &AdminHandler{
 		pg:          pg,
 		minioStore:  minioStore,
+		replica:     replica,
 		redisCache:  redisCache,
 		auditLogger: NewAuditLogger(pg),
+		keyRotator:  keyRotator,
+		snapshots:   snapshots,
+		reporter:    reporter,
+		usage:       usageRecorder,
+		notifier:    notifier,
+		monitor:     monitor,
+		space:       space,
+		jobQueue:    jobQueue,
+		maintenance: maintenance,
+		ipAccess:    ipAccess,
+		jwtService:  jwtService,
 	}
 }
 
@@ -38,6 +72,19 @@ type Stats struct {
 	TotalFiles        int   `json:"total_files"`
 	TotalStorageBytes int64 `json:"total_storage_bytes"`
 	ActiveUsers24h    int   `json:"active_users_24h"`
+	Downloads24h      int   `json:"downloads_24h"`
+	DownloadBytes24h  int64 `json:"download_bytes_24h"`
+
+	Database     storage.QueryStats     `json:"database"`
+	DatabasePool storage.DBPoolStats    `json:"database_pool"`
+	RedisPool    storage.RedisPoolStats `json:"redis_pool"`
+	// MinIO circuit breaker; unset when storage.minio.breaker is off
+	StorageBreaker *storage.BreakerStats `json:"storage_breaker,omitempty"`
+
+	// Usage against the thresholds of features.storage_alerts; unset when none is configured
+	StorageAlerts *alerts.Status `json:"storage_alerts,omitempty"`
+	// Room left for uploads (features.uploads.space); unset when nothing is checked
+	UploadCapacity *capacity.Status `json:"upload_capacity,omitempty"`
 }
 
 // UserInfo represents user information for admin panel
@@ -50,7 +97,12 @@ type UserInfo struct {
 	AccountStatus string `json:"account_status"`
 	FileCount     int    `json:"file_count"`
 	TotalStorage  int64  `json:"total_storage"`
+	SessionCount  int    `json:"session_count"`
+	AvatarURL     string `json:"avatar_url,omitempty"`
 	CreatedAt     string `json:"created_at"`
+
+	// Storage against the quota; unset when no quota is set
+	Quota *storage.QuotaStatus `json:"quota,omitempty"`
 }
 
 // HandleGetStats returns system statistics
@@ -59,10 +111,10 @@ func (h *AdminHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
 
 	// Get total users
 	var totalUsers int
-	err := h.pg.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&totalUsers)
+	err := h.pg.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE role <> 'service'").Scan(&totalUsers)
 	if err != nil {
 		log.Printf("[admin] Failed to get total users: %v", err)
-		http.Error(w, `{"error":"Failed to get statistics"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
 		return
 	}
 
@@ -71,7 +123,7 @@ func (h *AdminHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
 	err = h.pg.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM files").Scan(&totalFiles)
 	if err != nil {
 		log.Printf("[admin] Failed to get total files: %v", err)
-		http.Error(w, `{"error":"Failed to get statistics"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
 		return
 	}
 
@@ -80,21 +132,39 @@ func (h *AdminHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
 	err = h.pg.DB().QueryRowContext(ctx, "SELECT SUM(size) FROM files").Scan(&totalStorage)
 	if err != nil {
 		log.Printf("[admin] Failed to get total storage: %v", err)
-		http.Error(w, `{"error":"Failed to get statistics"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
 		return
 	}
 
 	// Get active users in last 24 hours (based on file uploads or downloads)
 	var activeUsers int
 	query := `
-		SELECT COUNT(DISTINCT user_id) 
-		FROM files 
-		WHERE created_at > NOW() - INTERVAL '24 hours'
+		SELECT COUNT(DISTINCT user_id) FROM (
+			SELECT user_id FROM files WHERE created_at > NOW() - INTERVAL '24 hours'
+			UNION
+			SELECT user_id FROM file_downloads
+			WHERE downloaded_at > NOW() - INTERVAL '24 hours' AND user_id IS NOT NULL
+		) active
 	`
 	err = h.pg.DB().QueryRowContext(ctx, query).Scan(&activeUsers)
 	if err != nil {
 		log.Printf("[admin] Failed to get active users: %v", err)
-		http.Error(w, `{"error":"Failed to get statistics"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
+		return
+	}
+
+	// Downloads in the last 24 hours
+	var downloads int
+	var downloadBytes int64
+	query = `
+		SELECT COUNT(*), COALESCE(SUM(bytes), 0)
+		FROM file_downloads
+		WHERE downloaded_at > NOW() - INTERVAL '24 hours'
+	`
+	err = h.pg.DB().QueryRowContext(ctx, query).Scan(&downloads, &downloadBytes)
+	if err != nil {
+		log.Printf("[admin] Failed to get download stats: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
 		return
 	}
 
@@ -103,13 +173,28 @@ func (h *AdminHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
 		TotalFiles:        totalFiles,
 		TotalStorageBytes: totalStorage.Int64,
 		ActiveUsers24h:    activeUsers,
+		Downloads24h:      downloads,
+		DownloadBytes24h:  downloadBytes,
+		Database:          h.pg.QueryStats(),
+		DatabasePool:      h.pg.PoolStats(),
+		RedisPool:         h.redisCache.PoolStats(),
+		StorageBreaker:    h.minioStore.BreakerStats(),
+	}
+	stats.StorageAlerts, err = h.monitor.Status(ctx)
+	if err != nil {
+		log.Printf("[admin] Failed to get storage alert status: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
+		return
+	}
+	if stats.UploadCapacity, err = h.space.Status(ctx); err != nil {
+		log.Printf("[admin] Failed to estimate upload capacity: %v", err)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(stats)
 	if err != nil {
 		log.Printf("[admin] Failed to encode stats response: %v", err)
-		http.Error(w, `{"error":"Failed to get statistics"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
 	}
 }
 
@@ -125,19 +210,29 @@ func (h *AdminHandler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
 			u.role,
 			u.is_active,
 			u.account_status,
+			u.avatar_updated_at,
 			u.created_at,
+			u.over_quota_since,
 			COALESCE(COUNT(f.id), 0) as file_count,
 			COALESCE(SUM(f.size), 0) as total_storage
 		FROM users u
 		LEFT JOIN files f ON u.id = f.user_id
-		GROUP BY u.id, u.username, u.email, u.role, u.is_active, u.account_status, u.created_at
+		WHERE u.role <> 'service'
+		GROUP BY u.id, u.username, u.email, u.role, u.is_active, u.account_status, u.avatar_updated_at, u.created_at, u.over_quota_since
 		ORDER BY u.created_at DESC
 	`
 
+	// Quota states are judged from the recorded overruns; a failure leaves them out
+	policy, err := h.pg.GetQuotaPolicy(ctx)
+	if err != nil {
+		log.Printf("[admin] Failed to get quota policy: %v", err)
+	}
+	now := time.Now()
+
 	rows, err := h.pg.DB().QueryContext(ctx, query)
 	if err != nil {
 		log.Printf("[admin] Failed to get users: %v", err)
-		http.Error(w, `{"error":"Failed to get users"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get users")
 		return
 	}
 	defer func() { _ = rows.Close() }()
@@ -145,6 +240,7 @@ func (h *AdminHandler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
 	var users []UserInfo
 	for rows.Next() {
 		var user UserInfo
+		var avatarUpdatedAt, overQuotaSince *time.Time
 		var createdAt sql.NullTime
 		err := rows.Scan(
 			&user.ID,
@@ -153,7 +249,9 @@ func (h *AdminHandler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
 			&user.Role,
 			&user.IsActive,
 			&user.AccountStatus,
+			&avatarUpdatedAt,
 			&createdAt,
+			&overQuotaSince,
 			&user.FileCount,
 			&user.TotalStorage,
 		)
@@ -161,6 +259,8 @@ func (h *AdminHandler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
 			log.Printf("[admin] Failed to scan user: %v", err)
 			continue
 		}
+		user.AvatarURL = avatarURL(user.ID, avatarUpdatedAt)
+		user.Quota = policy.Status(user.TotalStorage, overQuotaSince, now)
 		if createdAt.Valid {
 			user.CreatedAt = createdAt.Time.Format("2006-01-02 15:04:05")
 		}
@@ -171,13 +271,26 @@ func (h *AdminHandler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
 		users = []UserInfo{}
 	}
 
+	// Live sessions per user; a Redis failure leaves the counts at zero
+	userIDs := make([]string, len(users))
+	for i := range users {
+		userIDs[i] = users[i].ID
+	}
+	counts, err := h.redisCache.SessionCounts(ctx, userIDs)
+	if err != nil {
+		log.Printf("[admin] Failed to count sessions: %v", err)
+	}
+	for i := range users {
+		users[i].SessionCount = counts[users[i].ID]
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(map[string]interface{}{
 		"users": users,
 	})
 	if err != nil {
 		log.Printf("[admin] Failed to encode users response: %v", err)
-		http.Error(w, `{"error":"Failed to get users"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get users")
 	}
 }
 
@@ -187,20 +300,20 @@ func (h *AdminHandler) HandleDeleteUser(w http.ResponseWriter, r *http.Request)
 	userID := chi.URLParam(r, "id")
 
 	if userID == "" {
-		http.Error(w, `{"error":"User ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User ID required")
 		return
 	}
 
 	// Get the requesting admin's user ID
 	adminUserID := r.Context().Value(constants.UserIDKey)
 	if adminUserID == nil {
-		http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+		respondError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	// Prevent admin from deleting themselves
 	if adminUserID.(string) == userID {
-		http.Error(w, `{"error":"Cannot delete your own account"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Cannot delete your own account")
 		return
 	}
 
@@ -208,15 +321,16 @@ func (h *AdminHandler) HandleDeleteUser(w http.ResponseWriter, r *http.Request)
 	user, err := h.pg.GetUserByID(ctx, userID)
 	if err != nil {
 		log.Printf("[admin] User not found: %v", err)
-		http.Error(w, `{"error":"User not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
 		return
 	}
 
-	// Get all files for this user
-	files, err := h.pg.ListUserFiles(ctx, userID)
+	// Get all files for this user, trashed ones included: the cascade drops their
+	// rows too, and nothing would point at their objects afterwards
+	files, err := h.pg.ListUserFilesIncludingTrash(ctx, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to list user files: %v", err)
-		http.Error(w, `{"error":"Failed to delete user files"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete user files")
 		return
 	}
 
@@ -234,15 +348,30 @@ func (h *AdminHandler) HandleDeleteUser(w http.ResponseWriter, r *http.Request)
 
 	log.Printf("[admin] Deleted %d/%d files from MinIO for user %s", deletedCount, len(files), userID)
 
+	if path, err := h.pg.RemoveAvatar(ctx, userID); err != nil {
+		log.Printf("[admin] Failed to remove avatar of user %s: %v", userID, err)
+	} else if path != "" {
+		if err := h.minioStore.DeleteFile(ctx, path); err != nil {
+			log.Printf("[admin] Failed to delete avatar %s: %v", path, err)
+		}
+	}
+
 	// Delete user from database (CASCADE will delete files table entries)
 	query := "DELETE FROM users WHERE id = $1"
 	_, err = h.pg.DB().ExecContext(ctx, query, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to delete user from database: %v", err)
-		http.Error(w, `{"error":"Failed to delete user"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete user")
 		return
 	}
 
+	fileIDs := make([]string, len(files))
+	for i, file := range files {
+		fileIDs[i] = file.FileID
+	}
+	invalidateFileMetadata(ctx, h.redisCache, fileIDs...)
+	invalidateAccountStatus(ctx, h.redisCache, userID)
+
 	log.Printf("[admin] Successfully deleted user %s (%s) with %d files", user.Username, userID, len(files))
 
 	// Log audit action
@@ -263,6 +392,14 @@ func (h *AdminHandler) HandleDeleteUser(w http.ResponseWriter, r *http.Request)
 // ADMIN GOVERNANCE FEATURES
 // ================================================================
 
+// invalidateAccountStatus drops the cached status of a user, so a suspension or
+// approval applies on the user's next request on every replica
+func invalidateAccountStatus(ctx context.Context, cache *storage.RedisCache, userID string) {
+	if err := cache.InvalidateAccountStatus(ctx, userID); err != nil {
+		log.Printf("[WARN] Failed to invalidate cached account status of %s: %v", userID, err)
+	}
+}
+
 // HandleUpdateUserStatus toggles user account active/suspended status
 func (h *AdminHandler) HandleUpdateUserStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
@@ -270,13 +407,13 @@ func (h *AdminHandler) HandleUpdateUserStatus(w http.ResponseWriter, r *http.Req
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if userID == "" {
-		http.Error(w, `{"error":"User ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User ID required")
 		return
 	}
 
 	// Prevent admin from suspending themselves
 	if adminID == userID {
-		http.Error(w, `{"error":"Cannot modify your own account status"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Cannot modify your own account status")
 		return
 	}
 
@@ -284,14 +421,14 @@ func (h *AdminHandler) HandleUpdateUserStatus(w http.ResponseWriter, r *http.Req
 		IsActive bool `json:"is_active"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"Invalid request body"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Get user info before update
 	user, err := h.pg.GetUserByID(ctx, userID)
 	if err != nil {
-		http.Error(w, `{"error":"User not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
 		return
 	}
 
@@ -300,9 +437,10 @@ func (h *AdminHandler) HandleUpdateUserStatus(w http.ResponseWriter, r *http.Req
 	_, err = h.pg.DB().ExecContext(ctx, query, req.IsActive, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to update user status: %v", err)
-		http.Error(w, `{"error":"Failed to update user status"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update user status")
 		return
 	}
+	invalidateAccountStatus(ctx, h.redisCache, userID)
 
 	// If suspending user, revoke all their sessions
 	if !req.IsActive {
@@ -335,13 +473,13 @@ func (h *AdminHandler) HandleUpdateUserRole(w http.ResponseWriter, r *http.Reque
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if userID == "" {
-		http.Error(w, `{"error":"User ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User ID required")
 		return
 	}
 
 	// Prevent admin from demoting themselves
 	if adminID == userID {
-		http.Error(w, `{"error":"Cannot change your own role"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Cannot change your own role")
 		return
 	}
 
@@ -349,20 +487,26 @@ func (h *AdminHandler) HandleUpdateUserRole(w http.ResponseWriter, r *http.Reque
 		Role string `json:"role"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"Invalid request body"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate role
 	if req.Role != "admin" && req.Role != "user" {
-		http.Error(w, `{"error":"Invalid role. Must be 'admin' or 'user'"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid role. Must be 'admin' or 'user'")
 		return
 	}
 
 	// Get user info before update
 	user, err := h.pg.GetUserByID(ctx, userID)
 	if err != nil {
-		http.Error(w, `{"error":"User not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+
+	// Service accounts keep their role; they are managed under /admin/service-accounts
+	if user.Role == storage.RoleService {
+		respondError(w, r, http.StatusBadRequest, "Cannot change the role of a service account")
 		return
 	}
 
@@ -373,7 +517,7 @@ func (h *AdminHandler) HandleUpdateUserRole(w http.ResponseWriter, r *http.Reque
 	_, err = h.pg.DB().ExecContext(ctx, query, req.Role, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to update user role: %v", err)
-		http.Error(w, `{"error":"Failed to update user role"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update user role")
 		return
 	}
 
@@ -400,7 +544,7 @@ func (h *AdminHandler) HandleResetUserPassword(w http.ResponseWriter, r *http.Re
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if userID == "" {
-		http.Error(w, `{"error":"User ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User ID required")
 		return
 	}
 
@@ -408,20 +552,20 @@ func (h *AdminHandler) HandleResetUserPassword(w http.ResponseWriter, r *http.Re
 		NewPassword string `json:"new_password"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"Invalid request body"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate password strength
 	if len(req.NewPassword) < 8 {
-		http.Error(w, `{"error":"Password must be at least 8 characters"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Password must be at least 8 characters")
 		return
 	}
 
 	// Get user info
 	user, err := h.pg.GetUserByID(ctx, userID)
 	if err != nil {
-		http.Error(w, `{"error":"User not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
 		return
 	}
 
@@ -429,7 +573,7 @@ func (h *AdminHandler) HandleResetUserPassword(w http.ResponseWriter, r *http.Re
 	hashedPassword, err := hashPassword(req.NewPassword)
 	if err != nil {
 		log.Printf("[admin] Failed to hash password: %v", err)
-		http.Error(w, `{"error":"Failed to process password"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to process password")
 		return
 	}
 
@@ -438,7 +582,7 @@ func (h *AdminHandler) HandleResetUserPassword(w http.ResponseWriter, r *http.Re
 	_, err = h.pg.DB().ExecContext(ctx, query, hashedPassword, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to update password: %v", err)
-		http.Error(w, `{"error":"Failed to reset password"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to reset password")
 		return
 	}
 
@@ -465,14 +609,14 @@ func (h *AdminHandler) HandleForceLogoutUser(w http.ResponseWriter, r *http.Requ
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if userID == "" {
-		http.Error(w, `{"error":"User ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User ID required")
 		return
 	}
 
 	// Get user info
 	user, err := h.pg.GetUserByID(ctx, userID)
 	if err != nil {
-		http.Error(w, `{"error":"User not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
 		return
 	}
 
@@ -480,7 +624,7 @@ func (h *AdminHandler) HandleForceLogoutUser(w http.ResponseWriter, r *http.Requ
 	count, err := h.redisCache.DeleteUserSessions(ctx, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to revoke user sessions: %v", err)
-		http.Error(w, `{"error":"Failed to logout user"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to logout user")
 		return
 	}
 
@@ -499,36 +643,53 @@ func (h *AdminHandler) HandleForceLogoutUser(w http.ResponseWriter, r *http.Requ
 	})
 }
 
-// HandleGetAuditLogs returns paginated audit logs
+// Audit log pagination bounds
+const (
+	defaultAuditLogsLimit = 50
+	maxAuditLogsLimit     = 500
+)
+
+// HandleGetAuditLogs returns audit logs, newest first, a page at a time
+// (?limit=, ?cursor=)
 func (h *AdminHandler) HandleGetAuditLogs(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
-	// Pagination parameters
-	limit := 50
-	offset := 0
-	// TODO: Add query parameters for limit/offset/filtering
+	limit, after, err := pageParams(r, defaultAuditLogsLimit, maxAuditLogsLimit)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
 
+	// Fetch one extra row to know whether another page follows
+	args := []interface{}{limit + 1}
+	where := ""
+	if after != nil {
+		where = "WHERE (al.created_at, al.id) < ($2, $3)"
+		args = append(args, after.CreatedAt, after.ID)
+	}
 	query := `
 		SELECT 
 			al.id,
-			al.actor_id,
+			COALESCE(al.actor_id::text, ''),
 			al.action,
 			al.target_type,
-			al.target_id,
+			COALESCE(al.target_id::text, al.metadata->>'target_id'),
 			al.metadata,
 			al.ip_address,
 			al.created_at,
-			u.username as actor_username
+			COALESCE(u.username, '') as actor_username,
+			u.role as actor_role
 		FROM audit_logs al
 		LEFT JOIN users u ON al.actor_id = u.id
-		ORDER BY al.created_at DESC
-		LIMIT $1 OFFSET $2
+		` + where + `
+		ORDER BY al.created_at DESC, al.id DESC
+		LIMIT $1
 	`
 
-	rows, err := h.pg.DB().QueryContext(ctx, query, limit, offset)
+	rows, err := h.pg.DB().QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Printf("[admin] Failed to get audit logs: %v", err)
-		http.Error(w, `{"error":"Failed to get audit logs"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get audit logs")
 		return
 	}
 	defer func() { _ = rows.Close() }()
@@ -537,18 +698,30 @@ func (h *AdminHandler) HandleGetAuditLogs(w http.ResponseWriter, r *http.Request
 		ID            string         `json:"id"`
 		ActorID       string         `json:"actor_id"`
 		ActorUsername string         `json:"actor_username"`
+		ActorType     string         `json:"actor_type"` // "user", "service_account" or "anonymous"
 		Action        string         `json:"action"`
 		TargetType    sql.NullString `json:"target_type"`
 		TargetID      sql.NullString `json:"target_id"`
 		Metadata      []byte         `json:"metadata"`
 		IPAddress     sql.NullString `json:"ip_address"`
 		CreatedAt     string         `json:"created_at"`
+
+		createdAt time.Time
 	}
 
 	var logs []AuditLogEntry
+	var next *storage.Cursor
 	for rows.Next() {
+		if len(logs) == limit {
+			// The extra row: there is a next page, after the last entry shown
+			last := logs[limit-1]
+			next = &storage.Cursor{CreatedAt: last.createdAt, ID: last.ID}
+			break
+		}
+
 		var log AuditLogEntry
 		var createdAt sql.NullTime
+		var actorRole sql.NullString
 
 		err := rows.Scan(
 			&log.ID,
@@ -560,6 +733,7 @@ func (h *AdminHandler) HandleGetAuditLogs(w http.ResponseWriter, r *http.Request
 			&log.IPAddress,
 			&createdAt,
 			&log.ActorUsername,
+			&actorRole,
 		)
 		if err != nil {
 			log := log
@@ -569,6 +743,15 @@ func (h *AdminHandler) HandleGetAuditLogs(w http.ResponseWriter, r *http.Request
 
 		if createdAt.Valid {
 			log.CreatedAt = createdAt.Time.Format("2006-01-02 15:04:05")
+			log.createdAt = createdAt.Time
+		}
+
+		log.ActorType = "user"
+		if log.ActorID == "" {
+			log.ActorType = "anonymous"
+		} else if actorRole.String == storage.RoleService {
+			log.ActorType = "service_account"
+			log.ActorUsername = "[service] " + log.ActorUsername
 		}
 
 		logs = append(logs, log)
@@ -578,19 +761,196 @@ func (h *AdminHandler) HandleGetAuditLogs(w http.ResponseWriter, r *http.Request
 		logs = []AuditLogEntry{}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+	response := map[string]interface{}{
 		"logs":  logs,
 		"count": len(logs),
 		"limit": limit,
-	})
+	}
+	addNextCursor(response, next)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Admin file listing pagination bounds
+const (
+	defaultAdminFilesLimit = 50
+	maxAdminFilesLimit     = 500
+)
+
+// adminFileFilter holds the parsed query parameters of the admin file listing
+type adminFileFilter struct {
+	UserID         string
+	MinSize        *int64
+	MaxSize        *int64
+	MimeType       string // exact type, or a prefix such as "image/*"
+	UploadedAfter  *time.Time
+	UploadedBefore *time.Time
+	Limit          int
+	Offset         int
+	Cursor         *storage.Cursor
+}
+
+// parseAdminTime accepts either RFC 3339 timestamps or plain dates (YYYY-MM-DD)
+func parseAdminTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// parseAdminFileFilter reads limit/offset/cursor and the filter parameters from the query string
+func parseAdminFileFilter(r *http.Request) (*adminFileFilter, error) {
+	q := r.URL.Query()
+	f := &adminFileFilter{
+		UserID:   q.Get("user_id"),
+		MimeType: q.Get("mime_type"),
+		Limit:    defaultAdminFilesLimit,
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxAdminFilesLimit {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxAdminFilesLimit)
+		}
+		f.Limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("offset must be a non-negative integer")
+		}
+		f.Offset = n
+	}
+	if v := q.Get("cursor"); v != "" {
+		if f.Offset > 0 {
+			return nil, fmt.Errorf("cursor and offset cannot be combined")
+		}
+		c, err := storage.ParseCursor(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+		f.Cursor = c
+	}
+	for name, dst := range map[string]**int64{"min_size": &f.MinSize, "max_size": &f.MaxSize} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%s must be a non-negative integer", name)
+			}
+			*dst = &n
+		}
+	}
+	for name, dst := range map[string]**time.Time{"uploaded_after": &f.UploadedAfter, "uploaded_before": &f.UploadedBefore} {
+		if v := q.Get(name); v != "" {
+			t, err := parseAdminTime(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be RFC 3339 or YYYY-MM-DD", name)
+			}
+			*dst = &t
+		}
+	}
+
+	return f, nil
+}
+
+// where builds the WHERE clause (without cursor) and its arguments
+func (f *adminFileFilter) where() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.UserID != "" {
+		add("f.user_id = $%d", f.UserID)
+	}
+	if f.MinSize != nil {
+		add("f.size >= $%d", *f.MinSize)
+	}
+	if f.MaxSize != nil {
+		add("f.size <= $%d", *f.MaxSize)
+	}
+	if f.MimeType != "" {
+		if prefix, ok := strings.CutSuffix(f.MimeType, "*"); ok {
+			add("f.mime_type LIKE $%d", prefix+"%")
+		} else {
+			add("f.mime_type = $%d", f.MimeType)
+		}
+	}
+	if f.UploadedAfter != nil {
+		add("f.created_at >= $%d", *f.UploadedAfter)
+	}
+	if f.UploadedBefore != nil {
+		add("f.created_at < $%d", *f.UploadedBefore)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
 }
 
-// HandleGetAllFiles returns all files in the system (admin view)
+// HandleGetAllFiles returns files in the system (admin view), paginated and filterable
 func (h *AdminHandler) HandleGetAllFiles(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAdminFileFilter(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.writeAdminFiles(w, r, filter)
+}
+
+// HandleGetUserFiles returns the files of a single user (admin drill-down)
+func (h *AdminHandler) HandleGetUserFiles(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
+	userID := chi.URLParam(r, "id")
 
-	query := `
+	filter, err := parseAdminFileFilter(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.UserID = userID
+
+	var exists bool
+	if err := h.pg.DB().QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, userID).Scan(&exists); err != nil || !exists {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+
+	h.writeAdminFiles(w, r, filter)
+}
+
+// writeAdminFiles runs the filtered listing and writes the page as JSON
+func (h *AdminHandler) writeAdminFiles(w http.ResponseWriter, r *http.Request, filter *adminFileFilter) {
+	ctx := context.Background()
+
+	where, args := filter.where()
+
+	// Total matching rows, independent of the page
+	var total int
+	countQuery := `SELECT COUNT(*) FROM files f ` + where
+	if err := h.pg.DB().QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		log.Printf("[admin] Failed to count files: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get files")
+		return
+	}
+
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		cond := fmt.Sprintf("(f.created_at, f.id) < ($%d, $%d)", len(args)-1, len(args))
+		if where == "" {
+			where = "WHERE " + cond
+		} else {
+			where += " AND " + cond
+		}
+	}
+
+	// Fetch one extra row to know whether another page follows
+	args = append(args, filter.Limit+1, filter.Offset)
+	query := fmt.Sprintf(`
 		SELECT 
 			f.id,
 			f.user_id,
@@ -601,14 +961,15 @@ func (h *AdminHandler) HandleGetAllFiles(w http.ResponseWriter, r *http.Request)
 			u.username
 		FROM files f
 		LEFT JOIN users u ON f.user_id = u.id
-		ORDER BY f.created_at DESC
-		LIMIT 100
-	`
+		%s
+		ORDER BY f.created_at DESC, f.id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
 
-	rows, err := h.pg.DB().QueryContext(ctx, query)
+	rows, err := h.pg.DB().QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Printf("[admin] Failed to get all files: %v", err)
-		http.Error(w, `{"error":"Failed to get files"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get files")
 		return
 	}
 	defer func() { _ = rows.Close() }()
@@ -624,6 +985,8 @@ func (h *AdminHandler) HandleGetAllFiles(w http.ResponseWriter, r *http.Request)
 	}
 
 	var files []FileEntry
+	var last storage.Cursor
+	hasMore := false
 	for rows.Next() {
 		var file FileEntry
 		var createdAt sql.NullTime
@@ -641,8 +1004,20 @@ func (h *AdminHandler) HandleGetAllFiles(w http.ResponseWriter, r *http.Request)
 			continue
 		}
 
+		if len(files) == filter.Limit {
+			hasMore = true // the extra row only signals that there is a next page
+			break
+		}
+
+		if name, err := h.pg.DecryptFileName(file.ID, file.Filename); err == nil {
+			file.Filename = name
+		} else {
+			log.Printf("[admin] Failed to decrypt filename of file %s: %v", file.ID, err)
+		}
+
 		if createdAt.Valid {
 			file.CreatedAt = createdAt.Time.Format("2006-01-02 15:04:05")
+			last = storage.Cursor{CreatedAt: createdAt.Time, ID: file.ID}
 		}
 
 		files = append(files, file)
@@ -652,10 +1027,21 @@ func (h *AdminHandler) HandleGetAllFiles(w http.ResponseWriter, r *http.Request)
 		files = []FileEntry{}
 	}
 
+	// Cursors cannot be combined with offsets, so only offer one to cursor/first-page callers
+	var nextCursor string
+	if hasMore && filter.Offset == 0 && last.ID != "" {
+		nextCursor = last.Encode()
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{
-		"files": files,
-		"count": len(files),
+		"files":       files,
+		"count":       len(files),
+		"total":       total,
+		"limit":       filter.Limit,
+		"offset":      filter.Offset,
+		"has_more":    hasMore,
+		"next_cursor": nextCursor,
 	})
 }
 
@@ -666,14 +1052,14 @@ func (h *AdminHandler) HandleDeleteAnyFile(w http.ResponseWriter, r *http.Reques
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if fileID == "" {
-		http.Error(w, `{"error":"File ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "File ID required")
 		return
 	}
 
 	// Get file info
 	file, err := h.pg.GetFileMetadata(ctx, fileID)
 	if err != nil {
-		http.Error(w, `{"error":"File not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
 		return
 	}
 
@@ -689,9 +1075,10 @@ func (h *AdminHandler) HandleDeleteAnyFile(w http.ResponseWriter, r *http.Reques
 	_, err = h.pg.DB().ExecContext(ctx, query, fileID)
 	if err != nil {
 		log.Printf("[admin] Failed to delete file from database: %v", err)
-		http.Error(w, `{"error":"Failed to delete file"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete file")
 		return
 	}
+	invalidateFileMetadata(ctx, h.redisCache, fileID)
 
 	// Log audit action
 	_ = h.auditLogger.LogAdminAction(ctx, adminID, "FILE_DELETED", "file", fileID, map[string]interface{}{
@@ -731,7 +1118,7 @@ func (h *AdminHandler) HandleGetPendingUsers(w http.ResponseWriter, r *http.Requ
 	rows, err := h.pg.DB().QueryContext(ctx, query)
 	if err != nil {
 		log.Printf("[admin] Failed to get pending users: %v", err)
-		http.Error(w, `{"error":"Failed to get pending users"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get pending users")
 		return
 	}
 	defer func() { _ = rows.Close() }()
@@ -785,20 +1172,20 @@ func (h *AdminHandler) HandleApproveUser(w http.ResponseWriter, r *http.Request)
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if userID == "" {
-		http.Error(w, `{"error":"User ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User ID required")
 		return
 	}
 
 	// Get user info
 	user, err := h.pg.GetUserByID(ctx, userID)
 	if err != nil {
-		http.Error(w, `{"error":"User not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
 		return
 	}
 
 	// Check if user is in pending state
 	if user.AccountStatus != "pending" {
-		http.Error(w, `{"error":"User is not in pending state"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User is not in pending state")
 		return
 	}
 
@@ -807,9 +1194,10 @@ func (h *AdminHandler) HandleApproveUser(w http.ResponseWriter, r *http.Request)
 	_, err = h.pg.DB().ExecContext(ctx, query, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to approve user: %v", err)
-		http.Error(w, `{"error":"Failed to approve user"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to approve user")
 		return
 	}
+	invalidateAccountStatus(ctx, h.redisCache, userID)
 
 	// Log audit action
 	_ = h.auditLogger.LogAdminAction(ctx, adminID, "USER_APPROVED", "user", userID, map[string]interface{}{
@@ -833,20 +1221,20 @@ func (h *AdminHandler) HandleRejectUser(w http.ResponseWriter, r *http.Request)
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if userID == "" {
-		http.Error(w, `{"error":"User ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User ID required")
 		return
 	}
 
 	// Get user info
 	user, err := h.pg.GetUserByID(ctx, userID)
 	if err != nil {
-		http.Error(w, `{"error":"User not found"}`, http.StatusNotFound)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
 		return
 	}
 
 	// Check if user is in pending state
 	if user.AccountStatus != "pending" {
-		http.Error(w, `{"error":"User is not in pending state"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "User is not in pending state")
 		return
 	}
 
@@ -855,9 +1243,10 @@ func (h *AdminHandler) HandleRejectUser(w http.ResponseWriter, r *http.Request)
 	_, err = h.pg.DB().ExecContext(ctx, query, userID)
 	if err != nil {
 		log.Printf("[admin] Failed to reject user: %v", err)
-		http.Error(w, `{"error":"Failed to reject user"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to reject user")
 		return
 	}
+	invalidateAccountStatus(ctx, h.redisCache, userID)
 
 	// Log audit action
 	_ = h.auditLogger.LogAdminAction(ctx, adminID, "USER_REJECTED", "user", userID, map[string]interface{}{
@@ -886,7 +1275,7 @@ func (h *AdminHandler) HandleGetSettings(w http.ResponseWriter, r *http.Request)
 	rows, err := h.pg.DB().QueryContext(ctx, query)
 	if err != nil {
 		log.Printf("[admin] Failed to get settings: %v", err)
-		http.Error(w, `{"error":"Failed to get settings"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get settings")
 		return
 	}
 	defer func() { _ = rows.Close() }()
@@ -912,6 +1301,14 @@ func (h *AdminHandler) HandleGetSettings(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// managedSettings are validated by their own endpoints and cannot be set directly
+var managedSettings = map[string]string{
+	storage.SettingIPAllowlist:      "PUT /admin/ip-access",
+	storage.SettingIPDenylist:       "PUT /admin/ip-access",
+	storage.SettingBlockedCountries: "PUT /admin/ip-access",
+	storage.SettingJWTSigningKey:    "POST /admin/signing-keys/rotate",
+}
+
 // HandleUpdateSetting updates a system setting
 func (h *AdminHandler) HandleUpdateSetting(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
@@ -922,12 +1319,16 @@ func (h *AdminHandler) HandleUpdateSetting(w http.ResponseWriter, r *http.Reques
 		Value string `json:"value"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"Invalid request body"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	if req.Key == "" {
-		http.Error(w, `{"error":"Setting key required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Setting key required")
+		return
+	}
+	if endpoint, ok := managedSettings[req.Key]; ok {
+		respondError(w, r, http.StatusBadRequest, fmt.Sprintf("Setting %s is changed with %s", req.Key, endpoint))
 		return
 	}
 
@@ -936,15 +1337,18 @@ func (h *AdminHandler) HandleUpdateSetting(w http.ResponseWriter, r *http.Reques
 	result, err := h.pg.DB().ExecContext(ctx, query, req.Value, adminID, req.Key)
 	if err != nil {
 		log.Printf("[admin] Failed to update setting: %v", err)
-		http.Error(w, `{"error":"Failed to update setting"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update setting")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		http.Error(w, `{"error":"Setting not found"}`, http.StatusNotFound)
+		respondError(w, r, http.StatusNotFound, "Setting not found")
 		return
 	}
+	if strings.HasPrefix(req.Key, "maintenance_") {
+		h.maintenance.Invalidate()
+	}
 
 	// Log audit action
 	_ = h.auditLogger.LogAdminAction(ctx, adminID, "SETTING_UPDATED", "system", "", map[string]interface{}{
@@ -1034,7 +1438,7 @@ func (h *AdminHandler) HandleGetAnnouncements(w http.ResponseWriter, r *http.Req
 	rows, err := h.pg.DB().QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Printf("[admin] Failed to get announcements: %v", err)
-		http.Error(w, `{"error":"Failed to get announcements"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get announcements")
 		return
 	}
 	defer func() { _ = rows.Close() }()
@@ -1058,6 +1462,7 @@ func (h *AdminHandler) HandleGetAnnouncements(w http.ResponseWriter, r *http.Req
 		var ann Announcement
 		var createdAt sql.NullTime
 		var targetUserIDs sql.NullString
+		var createdBy, creatorUsername sql.NullString
 
 		err := rows.Scan(
 			&ann.ID,
@@ -1068,15 +1473,22 @@ func (h *AdminHandler) HandleGetAnnouncements(w http.ResponseWriter, r *http.Req
 			&targetUserIDs,
 			&ann.IsActive,
 			&ann.ExpiresAt,
-			&ann.CreatedBy,
+			&createdBy,
 			&createdAt,
-			&ann.CreatorUsername,
+			&creatorUsername,
 		)
 		if err != nil {
 			log.Printf("[admin] Failed to scan announcement: %v", err)
 			continue
 		}
 
+		// System announcements (e.g. expiry warnings) have no author
+		ann.CreatedBy = createdBy.String
+		ann.CreatorUsername = "system"
+		if creatorUsername.Valid {
+			ann.CreatorUsername = creatorUsername.String
+		}
+
 		if createdAt.Valid {
 			ann.CreatedAt = createdAt.Time.Format("2006-01-02 15:04:05")
 		}
@@ -1121,13 +1533,13 @@ func (h *AdminHandler) HandleCreateAnnouncement(w http.ResponseWriter, r *http.R
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"Invalid request body"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate required fields
 	if req.Title == "" || req.Message == "" {
-		http.Error(w, `{"error":"Title and message are required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Title and message are required")
 		return
 	}
 
@@ -1151,14 +1563,14 @@ func (h *AdminHandler) HandleCreateAnnouncement(w http.ResponseWriter, r *http.R
 			VALUES ($1, $2, $3, $4, $5, $6, $7)
 			RETURNING id, created_at
 		`
-		args = []interface{}{req.Title, req.Message, req.Type, req.TargetType, pq.Array(req.TargetUserIDs), *req.ExpiresAt, adminID}
+		args = []interface{}{req.Title, req.Message, req.Type, req.TargetType, req.TargetUserIDs, *req.ExpiresAt, adminID}
 	} else {
 		query = `
 			INSERT INTO announcements (title, message, type, target_type, target_user_ids, created_by)
 			VALUES ($1, $2, $3, $4, $5, $6)
 			RETURNING id, created_at
 		`
-		args = []interface{}{req.Title, req.Message, req.Type, req.TargetType, pq.Array(req.TargetUserIDs), adminID}
+		args = []interface{}{req.Title, req.Message, req.Type, req.TargetType, req.TargetUserIDs, adminID}
 	}
 
 	var announcementID string
@@ -1166,7 +1578,7 @@ func (h *AdminHandler) HandleCreateAnnouncement(w http.ResponseWriter, r *http.R
 	err := h.pg.DB().QueryRowContext(ctx, query, args...).Scan(&announcementID, &createdAt)
 	if err != nil {
 		log.Printf("[admin] Failed to create announcement: %v", err)
-		http.Error(w, `{"error":"Failed to create announcement"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create announcement")
 		return
 	}
 
@@ -1192,7 +1604,7 @@ func (h *AdminHandler) HandleDeleteAnnouncement(w http.ResponseWriter, r *http.R
 	adminID := r.Context().Value(constants.UserIDKey).(string)
 
 	if announcementID == "" {
-		http.Error(w, `{"error":"Announcement ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Announcement ID required")
 		return
 	}
 
@@ -1201,13 +1613,13 @@ func (h *AdminHandler) HandleDeleteAnnouncement(w http.ResponseWriter, r *http.R
 	result, err := h.pg.DB().ExecContext(ctx, query, announcementID)
 	if err != nil {
 		log.Printf("[admin] Failed to delete announcement: %v", err)
-		http.Error(w, `{"error":"Failed to delete announcement"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete announcement")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		http.Error(w, `{"error":"Announcement not found"}`, http.StatusNotFound)
+		respondError(w, r, http.StatusNotFound, "Announcement not found")
 		return
 	}
 
@@ -1229,7 +1641,7 @@ func (h *AdminHandler) HandleDismissAnnouncement(w http.ResponseWriter, r *http.
 	userID := r.Context().Value(constants.UserIDKey).(string)
 
 	if announcementID == "" {
-		http.Error(w, `{"error":"Announcement ID required"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Announcement ID required")
 		return
 	}
 
@@ -1242,7 +1654,7 @@ func (h *AdminHandler) HandleDismissAnnouncement(w http.ResponseWriter, r *http.
 	_, err := h.pg.DB().ExecContext(ctx, query, userID, announcementID)
 	if err != nil {
 		log.Printf("[user] Failed to dismiss announcement: %v", err)
-		http.Error(w, `{"error":"Failed to dismiss announcement"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to dismiss announcement")
 		return
 	}
 
@@ -1270,7 +1682,7 @@ func (h *AdminHandler) HandleAnalyzeStorage(w http.ResponseWriter, r *http.Reque
 	dbRows, err := h.pg.DB().QueryContext(ctx, dbQuery)
 	if err != nil {
 		log.Printf("[admin] Failed to query database files: %v", err)
-		http.Error(w, `{"error":"Failed to query database"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to query database")
 		return
 	}
 	defer func() { _ = dbRows.Close() }()
@@ -1284,11 +1696,23 @@ func (h *AdminHandler) HandleAnalyzeStorage(w http.ResponseWriter, r *http.Reque
 		dbFiles[minioPath] = fileID
 	}
 
+	// Avatars are stored objects too, without a files row
+	avatarPaths, err := h.pg.AvatarPaths(ctx)
+	if err != nil {
+		log.Printf("[admin] Failed to list avatars: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to query database")
+		return
+	}
+	avatars := make(map[string]bool, len(avatarPaths))
+	for _, path := range avatarPaths {
+		avatars[path] = true
+	}
+
 	// Get all objects from MinIO bucket
 	minioObjects, err := h.minioStore.ListAllObjects(ctx)
 	if err != nil {
 		log.Printf("[admin] Failed to list MinIO objects: %v", err)
-		http.Error(w, `{"error":"Failed to list MinIO objects"}`, http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list MinIO objects")
 		return
 	}
 
@@ -1302,7 +1726,7 @@ func (h *AdminHandler) HandleAnalyzeStorage(w http.ResponseWriter, r *http.Reque
 	var orphanedTotalSize int64
 
 	for _, obj := range minioObjects {
-		if _, exists := dbFiles[obj.Key]; !exists {
+		if _, exists := dbFiles[obj.Key]; !exists && !avatars[obj.Key] && !strings.HasPrefix(obj.Key, storage.SnapshotPrefix) {
 			orphanedFiles = append(orphanedFiles, OrphanedFile{
 				Path: obj.Key,
 				Size: obj.Size,
@@ -1366,7 +1790,7 @@ func (h *AdminHandler) HandleCleanupStorage(w http.ResponseWriter, r *http.Reque
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"Invalid request body"}`, http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -1397,6 +1821,7 @@ func (h *AdminHandler) HandleCleanupStorage(w http.ResponseWriter, r *http.Reque
 			deletedGhosts++
 		}
 	}
+	invalidateFileMetadata(ctx, h.redisCache, req.GhostFileIDs...)
 
 	// Log audit action
 	_ = h.auditLogger.LogAdminAction(ctx, adminID, "STORAGE_CLEANED", "system", "", map[string]interface{}{
@@ -27,12 +27,14 @@ func (a *AuditLogger) LogAdminAction(ctx context.Context, actorID, action, targe
 		metadataJSON = []byte("{}")
 	}
 
-	query := `
-		INSERT INTO audit_logs (actor_id, action, target_type, target_id, metadata, ip_address)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-
-	_, err = a.pg.DB().ExecContext(ctx, query, actorID, action, targetType, targetID, metadataJSON, ipAddress)
+	err = a.pg.LogAudit(ctx, storage.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Metadata:   metadataJSON,
+		IPAddress:  ipAddress,
+	})
 	if err != nil {
 		log.Printf("[audit] Failed to log action: %v", err)
 		return err
@@ -42,16 +44,10 @@ func (a *AuditLogger) LogAdminAction(ctx context.Context, actorID, action, targe
 	return nil
 }
 
-// GetClientIP extracts IP address from request
+// GetClientIP extracts IP address from request. The forwarding headers are not read
+// here: RealIP applies them to RemoteAddr when they come from a trusted proxy.
 func GetClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header first (proxy/nginx)
-	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
-		return ip
-	}
-	if ip := r.Header.Get("X-Real-IP"); ip != "" {
-		return ip
-	}
-	return r.RemoteAddr
+	return clientIP(r)
 }
 
 // AuditLog represents a single audit log entry
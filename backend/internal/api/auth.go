@@ -1,29 +1,41 @@
 package api
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 
+	"github.com/sachinthra/file-locker/backend/internal/alerts"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/auth"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthHandler struct {
-	jwtService *auth.JWTService
-	redisCache *storage.RedisCache
-	pgStore    *storage.PostgresStore
+	jwtService  *auth.JWTService
+	redisCache  *storage.RedisCache
+	pgStore     *storage.PostgresStore
+	sessionTTL  time.Duration // refresh token lifetime, extended on every refresh
+	auditLogger *AuditLogger
+	notifier    *notify.Notifier
+	monitor     *alerts.Monitor
 }
 
-func NewAuthHandler(jwtService *auth.JWTService, redisCache *storage.RedisCache, pgStore *storage.PostgresStore) *AuthHandler {
+func NewAuthHandler(jwtService *auth.JWTService, redisCache *storage.RedisCache, pgStore *storage.PostgresStore, sessionTTL time.Duration, notifier *notify.Notifier, monitor *alerts.Monitor) *AuthHandler {
 	return &AuthHandler{
-		jwtService: jwtService,
-		redisCache: redisCache,
-		pgStore:    pgStore,
+		jwtService:  jwtService,
+		redisCache:  redisCache,
+		pgStore:     pgStore,
+		sessionTTL:  sessionTTL,
+		auditLogger: NewAuditLogger(pgStore),
+		notifier:    notifier,
+		monitor:     monitor,
 	}
 }
 
@@ -38,118 +50,177 @@ type RegisterRequest struct {
 	Email    string `json:"email"`
 }
 
+// AuthResponse carries a short-lived access token (Token) and the refresh token
+// that renews it through POST /auth/refresh
 type AuthResponse struct {
-	Token  string `json:"token"`
-	UserID string `json:"user_id"`
-	Email  string `json:"email,omitempty"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // seconds until Token expires
+	UserID       string `json:"user_id"`
+	Email        string `json:"email,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RevokeRequest struct {
+	Token string `json:"token"`
+}
+
+// startSession creates a session for a user and returns its first tokens
+func (h *AuthHandler) startSession(r *http.Request, userID, email string) (*AuthResponse, error) {
+	sessionID, err := auth.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, refreshHash, err := auth.NewRefreshToken(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	session := storage.Session{ID: sessionID, UserID: userID, RefreshHash: refreshHash, CreatedAt: time.Now()}
+	if err := h.redisCache.SaveSession(r.Context(), session, h.sessionTTL); err != nil {
+		return nil, err
+	}
+
+	token, err := h.jwtService.GenerateToken(r.Context(), userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(h.jwtService.AccessTTL().Seconds()),
+		UserID:       userID,
+		Email:        email,
+	}, nil
 }
 
 func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate input
 	if req.Username == "" || req.Password == "" {
-		respondError(w, http.StatusBadRequest, "Username and password required")
+		respondError(w, r, http.StatusBadRequest, "Username and password required")
 		return
 	}
 
 	// Get user from PostgreSQL
 	user, err := h.pgStore.GetUserByUsername(r.Context(), req.Username)
 	if err != nil {
-		respondError(w, http.StatusUnauthorized, "Invalid credentials")
+		h.loginFailed(r, "", req.Username)
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidCredentials, "Invalid credentials")
+		return
+	}
+
+	// Service accounts authenticate with API keys only
+	if auth.IsServiceAccount(user) {
+		h.loginFailed(r, user.ID, req.Username)
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidCredentials, "Invalid credentials")
 		return
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		respondError(w, http.StatusUnauthorized, "Invalid credentials")
+		h.loginFailed(r, user.ID, req.Username)
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidCredentials, "Invalid credentials")
 		return
 	}
 
 	// Check account status
 	if user.AccountStatus == "pending" {
-		respondError(w, http.StatusForbidden, "Account awaiting admin approval")
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeAccountPending, "Account awaiting admin approval")
 		return
 	}
 	if user.AccountStatus == "rejected" {
-		respondError(w, http.StatusForbidden, "Account has been rejected by administrator")
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeAccountRejected, "Account has been rejected by administrator")
 		return
 	}
 	if user.AccountStatus == "suspended" || !user.IsActive {
-		respondError(w, http.StatusForbidden, "Account has been suspended")
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeAccountSuspended, "Account has been suspended")
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user.ID)
+	// Start a session in Redis with its first access and refresh tokens
+	resp, err := h.startSession(r, user.ID, user.Email)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to generate token")
+		log.Printf("[auth] Failed to create session for %s: %v", user.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create session")
 		return
 	}
 
-	// Save session in Redis (24 hour expiry)
-	if err := h.redisCache.SaveSession(r.Context(), token, user.ID, 24*time.Hour); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create session")
-		return
-	}
+	respondJSON(w, http.StatusOK, resp)
+}
 
-	respondJSON(w, http.StatusOK, AuthResponse{
-		Token:  token,
-		UserID: user.ID,
-		Email:  user.Email,
-	})
+// loginFailed records a failed login in the audit log (for the usage reports);
+// userID is "" when no account has the username
+func (h *AuthHandler) loginFailed(r *http.Request, userID, username string) {
+	if len(username) > 255 {
+		username = username[:255]
+	}
+	_ = h.auditLogger.LogAdminAction(r.Context(), "", "LOGIN_FAILED", "user", userID, map[string]interface{}{
+		"username": username,
+	}, clientIP(r)) // not X-Forwarded-For: anyone can set it
 }
 
 func (h *AuthHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate input
 	if req.Username == "" || req.Password == "" {
-		respondError(w, http.StatusBadRequest, "Username and password required")
+		respondError(w, r, http.StatusBadRequest, "Username and password required")
 		return
 	}
 
 	if len(req.Password) < 8 {
-		respondError(w, http.StatusBadRequest, "Password must be at least 8 characters")
+		respondError(w, r, http.StatusBadRequest, "Password must be at least 8 characters")
 		return
 	}
 
 	// Check if user already exists
 	exists, err := h.pgStore.UserExists(r.Context(), req.Username)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to check user existence")
+		respondError(w, r, http.StatusInternalServerError, "Failed to check user existence")
 		return
 	}
 	if exists {
-		respondError(w, http.StatusConflict, "Username already exists")
+		respondErrorCode(w, r, http.StatusConflict, apierror.CodeUsernameTaken, "Username already exists")
 		return
 	}
 
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to hash password")
+		respondError(w, r, http.StatusInternalServerError, "Failed to hash password")
 		return
 	}
 
 	// Create user in PostgreSQL
 	user, err := h.pgStore.CreateUser(r.Context(), req.Username, req.Email, string(hashedPassword))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create user")
+		respondError(w, r, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
 
 	// If account is pending, return success but no token
 	if user.AccountStatus == "pending" {
 		log.Printf("User %s registered (pending approval)", user.Username)
+		who := user.Username
+		if req.Email != "" {
+			who += " (" + req.Email + ")"
+		}
+		h.notifier.Notify(r.Context(), notify.EventUserPending, notify.Message{
+			Title: "New account awaiting approval",
+			Text:  who + " registered and waits for an admin: fl admin users approve " + user.ID,
+		})
 		respondJSON(w, http.StatusCreated, map[string]interface{}{
 			"message":        "Registration successful. Your account is awaiting admin approval.",
 			"status":         "pending",
@@ -159,59 +230,141 @@ func (h *AuthHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user.ID)
+	// Start a session
+	resp, err := h.startSession(r, user.ID, req.Email)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to generate token")
+		log.Printf("[auth] Failed to create session for %s: %v", user.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create session")
 		return
 	}
 
-	log.Printf("Token %v", token)
+	respondJSON(w, http.StatusCreated, resp)
+}
 
-	// Save session
-	if err := h.redisCache.SaveSession(r.Context(), token, user.ID, 24*time.Hour); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create session")
+// HandleRefresh exchanges a refresh token for a new access token and a new refresh
+// token. Each refresh token works once: presenting one the session already rotated
+// out means it leaked, so the session is revoked. Any other unknown token is just
+// refused, so guessing cannot end someone's session.
+func (h *AuthHandler) HandleRefresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, AuthResponse{
-		Token:  token,
-		UserID: user.ID,
-		Email:  req.Email,
-	})
-}
+	sessionID, ok := auth.SplitRefreshToken(req.RefreshToken)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid refresh token")
+		return
+	}
 
-func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
-	// Get token from Authorization header
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		respondError(w, http.StatusUnauthorized, "Authorization header required")
+	ctx := r.Context()
+	session, err := h.redisCache.GetSession(ctx, sessionID)
+	if errors.Is(err, storage.ErrSessionNotFound) {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Session not found or expired")
+		return
+	}
+	if err != nil {
+		log.Printf("[auth] Failed to get session: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to refresh session")
 		return
 	}
 
-	parts := strings.Split(authHeader, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		respondError(w, http.StatusUnauthorized, "Invalid authorization format")
+	user, err := h.pgStore.GetUserByID(ctx, session.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[auth] Failed to get user %s: %v", session.UserID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to refresh session")
+		return
+	}
+	if err != nil || user.Status() != "active" {
+		_ = h.redisCache.DeleteSession(ctx, sessionID)
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeAccountSuspended, "Account suspended. Contact administrator.")
 		return
 	}
 
-	token := parts[1]
+	refreshToken, refreshHash, err := auth.NewRefreshToken(sessionID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to refresh session")
+		return
+	}
+	rotated, reused, err := h.redisCache.RotateRefreshToken(ctx, session, auth.HashRefreshToken(req.RefreshToken), refreshHash, h.sessionTTL)
+	if err != nil {
+		log.Printf("[auth] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to refresh session")
+		return
+	}
+	if reused {
+		log.Printf("[auth] Reused refresh token for session of %s from %s; revoking the session", session.UserID, GetClientIP(r))
+		_ = h.redisCache.DeleteSession(ctx, sessionID)
+	}
+	if !rotated {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid refresh token")
+		return
+	}
 
-	// Validate token to get userID
-	claims, err := h.jwtService.ValidateToken(token)
+	token, err := h.jwtService.GenerateToken(ctx, session.UserID, sessionID)
 	if err != nil {
-		respondError(w, http.StatusUnauthorized, "Invalid token")
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
-	// Delete session from Redis
-	if err := h.redisCache.DeleteSession(r.Context(), token); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to delete session")
+	respondJSON(w, http.StatusOK, AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(h.jwtService.AccessTTL().Seconds()),
+		UserID:       user.ID,
+		Email:        user.Email,
+	})
+}
+
+// HandleRevoke revokes an access token (until it expires) or a refresh token (with
+// its whole session). It answers 200 whether or not the token was valid, so it
+// cannot be used to test tokens.
+func (h *AuthHandler) HandleRevoke(w http.ResponseWriter, r *http.Request) {
+	var req RevokeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Keep claims for potential logging
-	_ = claims
+	ctx := r.Context()
+	if sessionID, ok := auth.SplitRefreshToken(req.Token); ok {
+		session, err := h.redisCache.GetSession(ctx, sessionID)
+		if err == nil && auth.TokenEqual(session.RefreshHash, auth.HashRefreshToken(req.Token)) {
+			if err := h.redisCache.DeleteSession(ctx, sessionID); err != nil {
+				respondError(w, r, http.StatusInternalServerError, "Failed to revoke token")
+				return
+			}
+		}
+	} else if claims, err := h.jwtService.ValidateToken(ctx, req.Token); err == nil && claims.ID != "" {
+		if err := h.redisCache.RevokeAccessToken(ctx, claims.ID, time.Until(claims.ExpiresAt.Time)); err != nil {
+			respondError(w, r, http.StatusInternalServerError, "Failed to revoke token")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Token revoked",
+	})
+}
+
+func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	// Claims of the access token (set by RequireAuth)
+	claims, ok := r.Context().Value(constants.SessionClaimsKey).(*auth.Claims)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	// Refuse the access token at once and end its session, revoking the refresh token
+	if err := h.redisCache.RevokeAccessToken(r.Context(), claims.ID, time.Until(claims.ExpiresAt.Time)); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to revoke token")
+		return
+	}
+	if err := h.redisCache.DeleteSession(r.Context(), claims.SessionID); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete session")
+		return
+	}
 
 	respondJSON(w, http.StatusOK, map[string]string{
 		"message": "Logged out successfully",
@@ -222,22 +375,38 @@ func (h *AuthHandler) HandleGetMe(w http.ResponseWriter, r *http.Request) {
 	// Get userID from context (set by RequireAuth middleware)
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
 	// Get user from database
 	user, err := h.pgStore.GetUserByID(r.Context(), userID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "User not found")
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
 		return
 	}
 
+	// Lets clients disable uploads and edits up front; a failed lookup is not fatal
+	readOnly, err := h.pgStore.GetReadOnly(r.Context(), userID)
+	if err != nil {
+		log.Printf("[WARN] Failed to get read-only mode for %s: %v", userID, err)
+	}
+	// Likewise warn about the quota before uploads are refused; nil without a quota
+	quota, err := h.monitor.QuotaStatus(r.Context(), userID)
+	if err != nil {
+		log.Printf("[WARN] Failed to get storage quota of %s: %v", userID, err)
+	}
+
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"user_id":    user.ID,
-		"username":   user.Username,
-		"email":      user.Email,
-		"role":       user.Role,
-		"created_at": user.CreatedAt,
+		"user_id":       user.ID,
+		"username":      user.Username,
+		"email":         user.Email,
+		"display_name":  user.DisplayName,
+		"pending_email": user.PendingEmail,
+		"avatar_url":    avatarURL(user.ID, user.AvatarUpdatedAt),
+		"role":          user.Role,
+		"created_at":    user.CreatedAt,
+		"read_only":     readOnly.Blocked(),
+		"quota":         quota,
 	})
 }
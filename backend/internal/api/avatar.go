@@ -0,0 +1,201 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/pipeline"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const (
+	// maxAvatarBytes bounds avatar uploads
+	maxAvatarBytes = 2 << 20
+	// maxAvatarPixels guards against decompression bombs
+	maxAvatarPixels = 25 * 1000000
+	// avatarSize is the side of the stored (square) avatar, in pixels
+	avatarSize = 256
+)
+
+// avatarURL is where a user's avatar is served, versioned by its update time so
+// clients can cache it; "" without an avatar
+func avatarURL(userID string, updatedAt *time.Time) string {
+	if updatedAt == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/users/%s/avatar?v=%d", APIV1Prefix, userID, updatedAt.Unix())
+}
+
+// HandleSetAvatar replaces the caller's avatar with the JPEG, PNG or GIF image in
+// the request body. It is cropped to a square, resized to avatarSize and stored as
+// a JPEG encrypted with a key of its own.
+func (h *UserHandler) HandleSetAvatar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAvatarBytes))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondErrorCode(w, r, http.StatusRequestEntityTooLarge, apierror.CodeFileTooLarge,
+				fmt.Sprintf("Avatar too large. Max size: %d MB", maxAvatarBytes>>20))
+			return
+		}
+		respondError(w, r, http.StatusBadRequest, "Failed to read avatar")
+		return
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		respondErrorCode(w, r, http.StatusUnsupportedMediaType, apierror.CodeUnsupportedMedia, "Avatar must be a JPEG, PNG or GIF image")
+		return
+	}
+	if cfg.Width*cfg.Height > maxAvatarPixels {
+		respondError(w, r, http.StatusBadRequest, fmt.Sprintf("Avatar image too large (%dx%d)", cfg.Width, cfg.Height))
+		return
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, fmt.Sprintf("Failed to decode %s image", format))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, pipeline.ScaleDown(pipeline.CropSquare(src), avatarSize), &jpeg.Options{Quality: 85}); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to encode avatar")
+		return
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to encrypt avatar")
+		return
+	}
+	encrypted, err := crypto.EncryptBytes(buf.Bytes(), key)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to encrypt avatar")
+		return
+	}
+
+	ctx := r.Context()
+	path := h.minioStore.SystemObjectPath("avatars/" + userID + "/" + uuid.New().String())
+	if err := h.minioStore.SaveFile(ctx, path, bytes.NewReader(encrypted), int64(len(encrypted)), "application/octet-stream", storage.ObjectLabels{Owner: userID, Tier: storage.TierAvatar}); err != nil {
+		log.Printf("[ERROR] Failed to store avatar of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to store avatar")
+		return
+	}
+	previous, err := h.pgStore.SetAvatar(ctx, userID, path, key)
+	if err != nil {
+		log.Printf("[ERROR] Failed to set avatar of %s: %v", userID, err)
+		h.deleteAvatarObject(ctx, path)
+		respondError(w, r, http.StatusInternalServerError, "Failed to set avatar")
+		return
+	}
+	h.deleteAvatarObject(ctx, previous)
+
+	log.Printf("[INFO] Avatar of %s updated (%s, %dx%d)", userID, format, cfg.Width, cfg.Height)
+	h.respondProfile(w, r, userID)
+}
+
+// HandleDeleteAvatar removes the caller's avatar
+func (h *UserHandler) HandleDeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	previous, err := h.pgStore.RemoveAvatar(r.Context(), userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to remove avatar of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to remove avatar")
+		return
+	}
+	h.deleteAvatarObject(r.Context(), previous)
+
+	h.respondProfile(w, r, userID)
+}
+
+// HandleGetAvatar serves a user's avatar (a JPEG) to any signed-in user. The
+// ETag changes with every new avatar; the versioned URLs in profiles and user
+// listings can be cached for longer.
+func (h *UserHandler) HandleGetAvatar(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(userID); err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+
+	avatar, err := h.pgStore.GetAvatar(r.Context(), userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, r, http.StatusNotFound, "No avatar for this user")
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to get avatar of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get avatar")
+		return
+	}
+
+	etag := fmt.Sprintf(`"%s-%d"`, userID, avatar.UpdatedAt.UnixNano())
+	if checkNotModified(w, r, etag, avatar.UpdatedAt) {
+		return
+	}
+
+	obj, err := h.minioStore.GetFile(r.Context(), avatar.Path)
+	if err != nil {
+		log.Printf("[ERROR] Failed to read avatar %s: %v", avatar.Path, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get avatar")
+		return
+	}
+	defer func() { _ = obj.Close() }()
+	encrypted, err := io.ReadAll(obj)
+	if err != nil {
+		log.Printf("[ERROR] Failed to read avatar %s: %v", avatar.Path, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get avatar")
+		return
+	}
+	img, err := crypto.DecryptBytes(encrypted, avatar.Key)
+	if err != nil {
+		log.Printf("[ERROR] Failed to decrypt avatar of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to decrypt avatar")
+		return
+	}
+
+	if r.URL.Query().Get("v") != "" {
+		w.Header().Set("Cache-Control", "private, max-age=86400")
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Content-Length", strconv.Itoa(len(img)))
+	_, _ = w.Write(img)
+}
+
+// deleteAvatarObject deletes a replaced avatar; failures only leave an orphan
+// behind for storage cleanup
+func (h *UserHandler) deleteAvatarObject(ctx context.Context, path string) {
+	if path == "" {
+		return
+	}
+	if err := h.minioStore.DeleteFile(ctx, path); err != nil {
+		log.Printf("[WARN] Failed to delete avatar %s: %v", path, err)
+	}
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// maxBatchFiles caps how many files one batch request may touch
+const maxBatchFiles = 500
+
+// maxFolderLength caps folder paths
+const maxFolderLength = 1024
+
+// BatchUpdateRequest lists files and the operations to apply to each of them.
+// At most one of ExpiresAt and ClearExpiry may be set.
+type BatchUpdateRequest struct {
+	FileIDs     []string   `json:"file_ids"`
+	AddTags     []string   `json:"add_tags,omitempty"`
+	RemoveTags  []string   `json:"remove_tags,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ClearExpiry bool       `json:"clear_expiry,omitempty"`
+	Folder      *string    `json:"folder,omitempty"`
+}
+
+// BatchUpdateResult reports the outcome for one file
+type BatchUpdateResult struct {
+	FileID string    `json:"file_id"`
+	OK     bool      `json:"ok"`
+	Error  string    `json:"error,omitempty"`
+	File   *FileInfo `json:"file,omitempty"`
+}
+
+// errBatchSkip aborts a single file's update with a message for the result list
+type errBatchSkip string
+
+func (e errBatchSkip) Error() string { return string(e) }
+
+// HandleBatchUpdate applies tag, expiry and folder changes to several files.
+// Each file is updated atomically on its own: a failure on one file does not
+// affect the others, and the response lists the outcome per file.
+func (h *FilesHandler) HandleBatchUpdate(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	var req BatchUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.FileIDs) == 0 {
+		respondError(w, r, http.StatusBadRequest, "file_ids required")
+		return
+	}
+	if len(req.FileIDs) > maxBatchFiles {
+		respondError(w, r, http.StatusBadRequest, "Too many files in one batch")
+		return
+	}
+
+	addTags := normalizeTags(req.AddTags)
+	removeTags := normalizeTags(req.RemoveTags)
+	if len(addTags) == 0 && len(removeTags) == 0 && req.ExpiresAt == nil && !req.ClearExpiry && req.Folder == nil {
+		respondError(w, r, http.StatusBadRequest, "No operations specified")
+		return
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && req.ClearExpiry {
+		respondError(w, r, http.StatusBadRequest, "Specify at most one of expires_at and clear_expiry")
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		respondError(w, r, http.StatusBadRequest, "expires_at must be in the future")
+		return
+	}
+
+	var folder string
+	if req.Folder != nil {
+		var err error
+		if folder, err = normalizeFolder(*req.Folder); err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	results := make([]BatchUpdateResult, 0, len(req.FileIDs))
+	var updatedIDs []string
+	seen := make(map[string]bool, len(req.FileIDs))
+
+	for _, fileID := range req.FileIDs {
+		if seen[fileID] {
+			continue
+		}
+		seen[fileID] = true
+
+		updated, err := h.pgStore.ModifyFile(r.Context(), fileID, func(metadata *storage.FileMetadata) error {
+			if metadata.UserID != userID {
+				return errBatchSkip("Access denied")
+			}
+			// Expired files are waiting for the cleanup worker
+			if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(now) {
+				return errBatchSkip("File has expired")
+			}
+
+			metadata.Tags = applyTagChanges(metadata.Tags, addTags, removeTags)
+			switch {
+			case req.ClearExpiry:
+				metadata.ExpiresAt = nil
+			case req.ExpiresAt != nil:
+				metadata.ExpiresAt = req.ExpiresAt
+			}
+			if req.Folder != nil {
+				metadata.Folder = folder
+			}
+			return nil
+		})
+
+		result := BatchUpdateResult{FileID: fileID}
+		var skip errBatchSkip
+		switch {
+		case err == nil:
+			info := newFileInfo(updated)
+			result.OK, result.File = true, &info
+			updatedIDs = append(updatedIDs, fileID)
+			if req.ClearExpiry || req.ExpiresAt != nil {
+				labelFileObject(r.Context(), h.minioStorage, updated)
+			}
+		case errors.As(err, &skip):
+			result.Error = string(skip)
+		case errors.Is(err, sql.ErrNoRows):
+			result.Error = "File not found"
+		default:
+			log.Printf("[ERROR] Batch update of file %s failed: %v", fileID, err)
+			result.Error = "Failed to update file"
+		}
+		results = append(results, result)
+	}
+
+	invalidateFileMetadata(r.Context(), h.redisCache, updatedIDs...)
+
+	log.Printf("[INFO] Batch update by user %s: %d/%d files updated", userID, len(updatedIDs), len(results))
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"results": results,
+		"updated": len(updatedIDs),
+		"failed":  len(results) - len(updatedIDs),
+	})
+}
+
+// normalizeTags trims tags and drops empty entries and duplicates
+func normalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	return out
+}
+
+// applyTagChanges returns tags with add appended (if missing) and remove dropped,
+// keeping the existing order
+func applyTagChanges(tags, add, remove []string) []string {
+	drop := make(map[string]bool, len(remove))
+	for _, tag := range remove {
+		drop[tag] = true
+	}
+
+	out := make([]string, 0, len(tags)+len(add))
+	have := make(map[string]bool, len(tags)+len(add))
+	for _, tag := range append(append([]string{}, tags...), add...) {
+		if drop[tag] || have[tag] {
+			continue
+		}
+		have[tag] = true
+		out = append(out, tag)
+	}
+	return out
+}
+
+// normalizeFolder cleans a folder path into "/a/b" form ("/" is the root)
+func normalizeFolder(folder string) (string, error) {
+	folder = strings.TrimSpace(folder)
+	if len(folder) > maxFolderLength {
+		return "", errors.New("folder path too long")
+	}
+	if strings.ContainsAny(folder, "\\\x00") {
+		return "", errors.New("folder path contains invalid characters")
+	}
+	return path.Clean("/" + folder), nil
+}
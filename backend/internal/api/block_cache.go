@@ -0,0 +1,95 @@
+package api
+
+import (
+	"container/list"
+	"sync"
+)
+
+// streamBlockSize is the unit range requests are decrypted and cached in (plaintext bytes)
+const streamBlockSize = 64 << 10
+
+// ivBlock is the block index under which a file's IV is cached
+const ivBlock = -1
+
+// blockKey identifies a decrypted block. Keyed by object path rather than file ID:
+// key rotation re-encrypts a file to a new object (with a new IV), so entries of
+// the old object can never be mixed with the new one.
+type blockKey struct {
+	object string
+	index  int64
+}
+
+type blockEntry struct {
+	key  blockKey
+	data []byte
+}
+
+// blockCache is a size-bounded LRU of decrypted file blocks. Browsers seeking in a
+// video (Safari in particular) send many small, overlapping range requests; serving
+// those from memory saves a MinIO round trip and a CTR setup per probe. A nil cache
+// stores nothing.
+type blockCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	order    *list.List // front = most recently used
+	entries  map[blockKey]*list.Element
+}
+
+// newBlockCache returns a cache holding up to maxBytes of plaintext, or nil when
+// maxBytes is 0
+func newBlockCache(maxBytes int64) *blockCache {
+	if maxBytes <= 0 {
+		return nil
+	}
+	return &blockCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[blockKey]*list.Element),
+	}
+}
+
+// get returns a cached block. The slice is shared; callers must not modify it.
+func (c *blockCache) get(key blockKey) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*blockEntry).data, true
+}
+
+// put stores a block, evicting the least recently used ones to stay within maxBytes
+func (c *blockCache) put(key blockKey, data []byte) {
+	if c == nil || int64(len(data)) > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= int64(len(el.Value.(*blockEntry).data))
+		el.Value.(*blockEntry).data = data
+		c.size += int64(len(data))
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[key] = c.order.PushFront(&blockEntry{key: key, data: data})
+		c.size += int64(len(data))
+	}
+
+	for c.size > c.maxBytes {
+		oldest := c.order.Back()
+		e := oldest.Value.(*blockEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, e.key)
+		c.size -= int64(len(e.data))
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/captcha"
+)
+
+// CaptchaTokenHeader carries the token of the solved captcha widget
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// RequireCaptcha makes anonymous callers of the wrapped routes solve a captcha: the
+// widget's token must come in the X-Captcha-Token header (the body is an upload and
+// is not parsed here). A missing or rejected token gets 403 with the provider and
+// site key, so the client can render the widget. A nil verifier (no provider
+// configured) lets every request through.
+func RequireCaptcha(verifier captcha.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if verifier == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := verifier.Verify(r.Context(), r.Header.Get(CaptchaTokenHeader), clientIP(r))
+			if err == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !errors.Is(err, captcha.ErrFailed) {
+				log.Printf("[ERROR] Failed to verify captcha: %v", err)
+				respondError(w, r, http.StatusServiceUnavailable, "Failed to verify captcha; try again later")
+				return
+			}
+			apierror.Write(w, r, apierror.New(http.StatusForbidden, apierror.CodeCaptchaRequired, "Solve the captcha to continue").
+				With("captcha", map[string]string{"provider": verifier.Provider(), "site_key": verifier.SiteKey()}))
+		})
+	}
+}
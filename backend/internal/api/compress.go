@@ -0,0 +1,211 @@
+package api
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// compressibleTypes are the response types worth compressing. File downloads are
+// excluded by construction: they carry the file's own type and a Content-Disposition.
+var compressibleTypes = []string{
+	"application/json",
+	"application/yaml",
+	"application/x-yaml",
+	"text/",
+}
+
+// Compress compresses eligible responses with brotli or gzip, as negotiated through
+// Accept-Encoding. Responses smaller than minSize bytes are sent as-is.
+func Compress(minSize, level int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: minSize, level: level}
+			defer cw.Close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding picks br or gzip from an Accept-Encoding header ("" = none).
+// Brotli wins ties because it compresses JSON noticeably better.
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "br" && name != "gzip" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+
+		if q > bestQ || (q == bestQ && name == "br") {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// compressWriter buffers the start of a response until it knows whether the
+// response is eligible and large enough, then commits to compressing or not
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+	level    int
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser // nil when passing through
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status == 0 {
+		cw.status = status
+	}
+	// Informational and bodiless responses go straight through
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	if !cw.decided {
+		if !cw.eligible() {
+			cw.decide(false)
+		} else {
+			cw.buf = append(cw.buf, p...)
+			if len(cw.buf) < cw.minSize {
+				return len(p), nil
+			}
+			cw.decide(true)
+			return len(p), nil
+		}
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// eligible reports whether the headers set so far allow compression
+func (cw *compressWriter) eligible() bool {
+	h := cw.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Disposition") != "" {
+		return false
+	}
+	if cw.status != http.StatusOK && cw.status < 400 {
+		return false // partial content, redirects, etc.
+	}
+
+	ct := strings.ToLower(h.Get("Content-Type"))
+	for _, t := range compressibleTypes {
+		if strings.HasPrefix(ct, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// decide writes the status line and flushes any buffered bytes, compressed or not
+func (cw *compressWriter) decide(compress bool) {
+	if cw.decided {
+		return
+	}
+	cw.decided = true
+
+	h := cw.Header()
+	if compress {
+		h.Set("Content-Encoding", cw.encoding)
+		h.Del("Content-Length")
+		h.Add("Vary", "Accept-Encoding")
+
+		switch cw.encoding {
+		case "br":
+			cw.encoder = brotli.NewWriterLevel(cw.ResponseWriter, cw.level)
+		default:
+			gz, err := gzip.NewWriterLevel(cw.ResponseWriter, cw.level)
+			if err != nil {
+				gz = gzip.NewWriter(cw.ResponseWriter)
+			}
+			cw.encoder = gz
+		}
+	}
+
+	if cw.status != 0 {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+
+	if len(cw.buf) > 0 {
+		if cw.encoder != nil {
+			_, _ = cw.encoder.Write(cw.buf)
+		} else {
+			_, _ = cw.ResponseWriter.Write(cw.buf)
+		}
+		cw.buf = nil
+	}
+}
+
+// Close flushes a response that stayed below minSize and finishes the compressed stream
+func (cw *compressWriter) Close() {
+	if !cw.decided {
+		if cw.status == 0 && len(cw.buf) == 0 {
+			return // handler wrote nothing (e.g. hijacked connection)
+		}
+		cw.decide(false)
+	}
+	if cw.encoder != nil {
+		_ = cw.encoder.Close()
+	}
+}
+
+// Flush sends buffered data now; a small buffered prefix is sent uncompressed
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.decide(len(cw.buf) >= cw.minSize && cw.eligible())
+	}
+	if f, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack keeps websocket-style upgrades working through the wrapper
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := cw.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// etagMatches reports whether an If-None-Match header matches etag (weak comparison)
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// preconditionMet reports whether the write preconditions of r hold for the current
+// state of a file: If-Match (strong comparison against its ETag) or, without it,
+// If-Unmodified-Since. Requests without either always pass.
+func preconditionMet(r *http.Request, metadata *storage.FileMetadata) bool {
+	if im := r.Header.Get("If-Match"); im != "" {
+		etag := metadata.ETag()
+		for _, candidate := range strings.Split(im, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ius := r.Header.Get("If-Unmodified-Since"); ius != "" {
+		if t, err := http.ParseTime(ius); err == nil {
+			// HTTP dates have second precision
+			return !metadata.UpdatedAt.Truncate(time.Second).After(t)
+		}
+	}
+	return true
+}
+
+// checkNotModified sets the validators on the response and answers 304 when the
+// client's cached copy is current. If-None-Match takes precedence over If-Modified-Since.
+// A zero lastModified disables Last-Modified handling.
+func checkNotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	notModified := false
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		notModified = etagMatches(inm, etag)
+	} else if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil {
+			// HTTP dates have second precision
+			notModified = !lastModified.Truncate(time.Second).After(t)
+		}
+	}
+
+	if notModified {
+		w.WriteHeader(http.StatusNotModified)
+	}
+	return notModified
+}
+
+// respondJSONConditional writes a JSON payload with a weak ETag over its encoding,
+// answering 304 when the client already has it. Used by listings that clients poll;
+// they only carry an ETag because deletions cannot be expressed as a Last-Modified time.
+func respondJSONConditional(w http.ResponseWriter, r *http.Request, status int, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	if checkNotModified(w, r, `W/"`+hex.EncodeToString(sum[:16])+`"`, time.Time{}) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
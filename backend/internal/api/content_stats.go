@@ -0,0 +1,48 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// contentStatsCacheTTL is how long the content statistics are served from Redis
+// before they are computed again; they read every file row
+const contentStatsCacheTTL = 15 * time.Minute
+
+// ContentStatsResponse is the storage of the instance by MIME type and extension
+type ContentStatsResponse struct {
+	storage.ContentStats
+	Cached bool `json:"cached"` // served from the cache; generated_at tells how old it is
+}
+
+// HandleGetContentStats breaks the storage of the instance down by MIME type
+// and file extension, for capacity planning. The result is cached for
+// contentStatsCacheTTL across replicas; ?refresh=true computes it again.
+func (h *AdminHandler) HandleGetContentStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.URL.Query().Get("refresh") != "true" {
+		cached, err := h.redisCache.CachedContentStats(ctx)
+		if err != nil {
+			log.Printf("[admin] Failed to get cached content stats: %v", err)
+		}
+		if cached != nil {
+			respondJSON(w, http.StatusOK, ContentStatsResponse{ContentStats: *cached, Cached: true})
+			return
+		}
+	}
+
+	stats, err := h.pg.GetContentStats(ctx)
+	if err != nil {
+		log.Printf("[admin] Failed to get content stats: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get statistics")
+		return
+	}
+	if err := h.redisCache.CacheContentStats(ctx, stats, contentStatsCacheTTL); err != nil {
+		log.Printf("[admin] Failed to cache content stats: %v", err)
+	}
+	respondJSON(w, http.StatusOK, ContentStatsResponse{ContentStats: *stats})
+}
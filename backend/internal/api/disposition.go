@@ -0,0 +1,69 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// sanitizeFileName makes a stored filename safe to put in a header or archive entry:
+// control characters (including CR/LF) are dropped and path separators replaced
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsControl(r):
+			return -1
+		case r == '/' || r == '\\':
+			return '_'
+		}
+		return r
+	}, name)
+
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "download"
+	}
+	return name
+}
+
+// contentDisposition builds a Content-Disposition header value (RFC 6266) carrying
+// both an ASCII-only filename for old clients and the exact UTF-8 name in filename*
+// (RFC 5987). disposition is "attachment" or "inline".
+func contentDisposition(disposition, name string) string {
+	name = sanitizeFileName(name)
+
+	fallback := strings.Map(func(r rune) rune {
+		// '%' is excluded because some browsers percent-decode the plain filename
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' || r == '%' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	return fmt.Sprintf(`%s; filename="%s"; filename*=UTF-8''%s`, disposition, fallback, encodeRFC5987(name))
+}
+
+// encodeRFC5987 percent-encodes every byte that is not an RFC 5987 attr-char
+func encodeRFC5987(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isAttrChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+func isAttrChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
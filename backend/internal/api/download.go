@@ -1,13 +1,16 @@
 package api
 
 import (
-	"encoding/base64"
+	"context"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
 	"github.com/sachinthra/file-locker/backend/internal/crypto"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
@@ -17,13 +20,31 @@ type DownloadHandler struct {
 	minioStorage *storage.MinIOStorage
 	redisCache   *storage.RedisCache
 	pgStore      *storage.PostgresStore
+	auditLogger  *AuditLogger
+	urlSigner    *auth.URLSigner
+	urlTTL       time.Duration // default lifetime of signed download URLs
+	urlMaxTTL    time.Duration
+	guard        *auth.TokenGuard // signed URL guessing
 }
 
-func NewDownloadHandler(minioStorage *storage.MinIOStorage, redisCache *storage.RedisCache, pgStore *storage.PostgresStore) *DownloadHandler {
+// NewDownloadHandler creates the download handler. urlTTL and urlMaxTTL bound
+// signed download URLs; zero values select the defaults.
+func NewDownloadHandler(minioStorage *storage.MinIOStorage, redisCache *storage.RedisCache, pgStore *storage.PostgresStore, urlSigner *auth.URLSigner, urlTTL, urlMaxTTL time.Duration, guard *auth.TokenGuard) *DownloadHandler {
+	if urlTTL <= 0 {
+		urlTTL = defaultDownloadURLTTL
+	}
+	if urlMaxTTL <= 0 {
+		urlMaxTTL = defaultDownloadURLMaxTTL
+	}
 	return &DownloadHandler{
 		minioStorage: minioStorage,
 		redisCache:   redisCache,
 		pgStore:      pgStore,
+		auditLogger:  NewAuditLogger(pgStore),
+		urlSigner:    urlSigner,
+		urlTTL:       urlTTL,
+		urlMaxTTL:    max(urlMaxTTL, urlTTL),
+		guard:        guard,
 	}
 }
 
@@ -31,71 +52,122 @@ func (h *DownloadHandler) HandleDownload(w http.ResponseWriter, r *http.Request)
 	// Get fileID from URL
 	fileID := chi.URLParam(r, "id")
 	if fileID == "" {
-		respondError(w, http.StatusBadRequest, "File ID required")
+		respondError(w, r, http.StatusBadRequest, "File ID required")
 		return
 	}
 
 	// Get userID from context (set by auth middleware)
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
 	// Get metadata from PostgreSQL
-	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	metadata, err := storage.LoadFileMetadata(r.Context(), h.redisCache, h.pgStore, fileID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "File not found")
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
 		return
 	}
 
 	// Ownership check
 	if metadata.UserID != userID {
-		respondError(w, http.StatusForbidden, "Access denied")
+		respondError(w, r, http.StatusForbidden, "Access denied")
 		return
 	}
 
 	// Check if file is expired
 	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(time.Now()) {
-		respondError(w, http.StatusGone, "File has expired")
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
 		return
 	}
 
-	// Decode encryption key
-	keyBytes, err := base64.StdEncoding.DecodeString(metadata.EncryptionKey)
+	// Per-file download password (independent of account auth)
+	if !checkFilePassword(w, r, h.auditLogger, userID, metadata) {
+		return
+	}
+
+	// Client already has this version; no download is counted
+	if checkNotModified(w, r, metadata.ETag(), metadata.UpdatedAt) {
+		return
+	}
+
+	mw, ok := beginTransfer(w, r, h.pgStore, metadata.UserID, storage.TransferDownload)
+	if !ok {
+		return
+	}
+	err = serveDecrypted(mw, r, h.minioStorage, h.pgStore, metadata, "attachment")
+	recordTransfer(r, h.pgStore, mw)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to decode encryption key")
 		return
 	}
 
+	recordDownload(r, h.pgStore, h.redisCache, storage.DownloadRecord{
+		FileID:    fileID,
+		UserID:    userID,
+		IPAddress: GetClientIP(r),
+		Bytes:     metadata.Size,
+	})
+}
+
+// recordDownloadTimeout bounds the bookkeeping done after a download completed
+const recordDownloadTimeout = 5 * time.Second
+
+// recordDownload stores a completed download and drops the now-stale cached metadata.
+// The body has already been sent, so the write must survive the client going away.
+func recordDownload(r *http.Request, pgStore *storage.PostgresStore, cache *storage.RedisCache, rec storage.DownloadRecord) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), recordDownloadTimeout)
+	defer cancel()
+
+	if err := pgStore.RecordDownload(ctx, rec); err != nil {
+		log.Printf("[ERROR] Failed to record download of file %s: %v", rec.FileID, err)
+		return
+	}
+	invalidateFileMetadata(ctx, cache, rec.FileID)
+}
+
+// serveDecrypted decrypts a stored file and streams it to the client with the given
+// Content-Disposition type ("attachment" or "inline"). Errors before the first byte
+// are reported to the client; the returned error tells the caller whether the copy completed.
+func serveDecrypted(w http.ResponseWriter, r *http.Request, minioStorage *storage.MinIOStorage, pgStore *storage.PostgresStore, metadata *storage.FileMetadata, disposition string) error {
+	// Unwrap encryption key
+	keyBytes, err := pgStore.DataKey(r.Context(), metadata)
+	if err != nil {
+		log.Printf("[ERROR] Failed to unwrap encryption key of file %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to decode encryption key")
+		return err
+	}
+
 	// Get encrypted stream from MinIO
-	encryptedStream, err := h.minioStorage.GetFile(r.Context(), metadata.MinIOPath)
+	encryptedStream, err := minioStorage.GetFile(r.Context(), metadata.MinIOPath)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve file from storage")
-		return
+		if !respondStorageUnavailable(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, "Failed to retrieve file from storage")
+		}
+		return err
 	}
 	defer func() { _ = encryptedStream.Close() }()
 
 	// Decrypt stream
 	decryptedStream, err := crypto.DecryptStream(encryptedStream, keyBytes)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to decrypt file")
-		return
+		respondError(w, r, http.StatusInternalServerError, "Failed to decrypt file")
+		return err
 	}
 
 	// Set response headers
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", metadata.FileName))
+	w.Header().Set("Content-Disposition", contentDisposition(disposition, metadata.FileName))
 	w.Header().Set("Content-Type", metadata.MimeType)
 	w.Header().Set("Content-Length", fmt.Sprintf("%d", metadata.Size))
+	if metadata.SHA256 != "" {
+		w.Header().Set("X-Checksum-SHA256", metadata.SHA256) // lets clients verify what they received
+	}
 
 	// Stream to client
 	if _, err := io.Copy(w, decryptedStream); err != nil {
 		// Log error but can't send response as headers already sent
-		return
+		return err
 	}
 
-	// Increment download counter (fire and forget)
-	go func() {
-		_ = h.pgStore.IncrementDownloadCount(r.Context(), fileID)
-	}()
+	return nil
 }
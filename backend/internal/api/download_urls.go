@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const (
+	defaultDownloadURLTTL    = time.Hour
+	defaultDownloadURLMaxTTL = 7 * 24 * time.Hour
+)
+
+// CreateDownloadURLRequest configures a signed download URL. Both fields are optional.
+type CreateDownloadURLRequest struct {
+	ExpiresIn   int    `json:"expires_in"`  // seconds; defaults to the configured TTL
+	Disposition string `json:"disposition"` // "attachment" (default) or "inline" for media players
+}
+
+// HandleCreateDownloadURL issues a signed, expiring URL for one of the user's files.
+// The URL works without an Authorization header. Password-protected files need the
+// password (X-File-Password) here; the URL itself then carries no password.
+// Any later change to the file (rename, new password, ...) revokes issued URLs.
+func (h *DownloadHandler) HandleCreateDownloadURL(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return
+	}
+
+	var req CreateDownloadURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ttl := h.urlTTL
+	if req.ExpiresIn != 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+		if ttl <= 0 || ttl > h.urlMaxTTL {
+			respondError(w, r, http.StatusBadRequest, "expires_in must be between 1 and "+formatSeconds(h.urlMaxTTL)+" seconds")
+			return
+		}
+	}
+
+	switch req.Disposition {
+	case "":
+		req.Disposition = "attachment"
+	case "attachment", "inline":
+	default:
+		respondError(w, r, http.StatusBadRequest, "disposition must be attachment or inline")
+		return
+	}
+
+	metadata, err := storage.LoadFileMetadata(r.Context(), h.redisCache, h.pgStore, fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	// Signed URLs work anonymously, like share links, so quarantined files get none
+	if metadata.QuarantinedAt != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(time.Now()) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
+		return
+	}
+
+	if !checkFilePassword(w, r, h.auditLogger, userID, metadata) {
+		return
+	}
+
+	// The URL never outlives the file
+	expiresAt := time.Now().Add(ttl)
+	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(expiresAt) {
+		expiresAt = *metadata.ExpiresAt
+	}
+
+	token := h.urlSigner.Sign(auth.SignedDownload{
+		FileID:      fileID,
+		UserID:      userID,
+		Version:     metadata.UpdatedAt.UnixNano(),
+		Disposition: req.Disposition,
+		ExpiresAt:   expiresAt,
+	})
+	path := apiBase(r) + "/dl/" + token
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), userID, "DOWNLOAD_URL_CREATED", "file", fileID, map[string]interface{}{
+		"filename":   metadata.FileName,
+		"expires_at": expiresAt,
+	}, GetClientIP(r))
+
+	respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"url":        requestOrigin(r) + path,
+		"path":       path,
+		"expires_at": expiresAt,
+	})
+}
+
+// HandleSignedDownload serves a file through a signed download URL (no Authorization header)
+func (h *DownloadHandler) HandleSignedDownload(w http.ResponseWriter, r *http.Request) {
+	if !h.guard.Allow(w, r, auth.GuardSignedURL) {
+		return
+	}
+	signed, err := h.urlSigner.Verify(chi.URLParam(r, "token"))
+	if errors.Is(err, auth.ErrSignedURLExpired) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeLinkExpired, "Download link has expired")
+		return
+	}
+	if err != nil {
+		h.guard.Fail(r.Context(), r, auth.GuardSignedURL)
+		log.Printf("[WARN] Rejected signed download URL from %s: %v", GetClientIP(r), err)
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeLinkInvalid, "Invalid download link")
+		return
+	}
+
+	// URLs issued before the file was quarantined stop working until it is approved
+	metadata, err := storage.LoadFileMetadata(r.Context(), h.redisCache, h.pgStore, signed.FileID)
+	if err != nil || metadata.UserID != signed.UserID || metadata.QuarantinedAt != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UpdatedAt.UnixNano() != signed.Version {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeLinkInvalid, "Download link is no longer valid")
+		return
+	}
+
+	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(time.Now()) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
+		return
+	}
+
+	// URLs stop working while the issuing account is suspended
+	owner, err := h.pgStore.GetUserByID(r.Context(), signed.UserID)
+	if err != nil || !owner.IsActive {
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeLinkInvalid, "Download link is no longer valid")
+		return
+	}
+
+	if checkNotModified(w, r, metadata.ETag(), metadata.UpdatedAt) {
+		return
+	}
+
+	mw, ok := beginTransfer(w, r, h.pgStore, metadata.UserID, storage.TransferDownload)
+	if !ok {
+		return
+	}
+	err = serveDecrypted(mw, r, h.minioStorage, h.pgStore, metadata, signed.Disposition)
+	recordTransfer(r, h.pgStore, mw)
+	if err != nil {
+		return
+	}
+
+	recordDownload(r, h.pgStore, h.redisCache, storage.DownloadRecord{
+		FileID:    metadata.FileID,
+		IPAddress: GetClientIP(r),
+		Bytes:     metadata.Size,
+	})
+}
+
+// requestOrigin reconstructs scheme://host of the request, honouring a reverse proxy's
+// X-Forwarded-Proto
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "https" || proto == "http" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
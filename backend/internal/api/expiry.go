@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const (
+	defaultExpiringWithin = 72 * time.Hour
+	maxExpiringWithin     = 365 * 24 * time.Hour
+)
+
+// UpdateExpiryRequest changes a file's expiration. Exactly one of the fields should be set.
+type UpdateExpiryRequest struct {
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`   // absolute expiry (RFC 3339)
+	ExtendHours int        `json:"extend_hours,omitempty"` // push the current expiry back by N hours
+	Clear       bool       `json:"clear,omitempty"`        // remove the expiry entirely
+}
+
+// HandleUpdateExpiry extends, sets or clears the expiration of a file
+func (h *FilesHandler) HandleUpdateExpiry(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return
+	}
+
+	var req UpdateExpiryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	set := 0
+	if req.ExpiresAt != nil {
+		set++
+	}
+	if req.ExtendHours != 0 {
+		set++
+	}
+	if req.Clear {
+		set++
+	}
+	if set != 1 {
+		respondError(w, r, http.StatusBadRequest, "Specify exactly one of expires_at, extend_hours or clear")
+		return
+	}
+	if req.ExtendHours < 0 {
+		respondError(w, r, http.StatusBadRequest, "extend_hours must be positive")
+		return
+	}
+
+	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	now := time.Now()
+
+	// Expired files are waiting for the cleanup worker and cannot be revived
+	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(now) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
+		return
+	}
+
+	var expiresAt *time.Time
+	switch {
+	case req.Clear:
+		expiresAt = nil
+	case req.ExtendHours > 0:
+		base := now
+		if metadata.ExpiresAt != nil {
+			base = *metadata.ExpiresAt
+		}
+		t := base.Add(time.Duration(req.ExtendHours) * time.Hour)
+		expiresAt = &t
+	default:
+		if !req.ExpiresAt.After(now) {
+			respondError(w, r, http.StatusBadRequest, "expires_at must be in the future")
+			return
+		}
+		expiresAt = req.ExpiresAt
+	}
+
+	if err := h.pgStore.UpdateFileExpiry(r.Context(), fileID, expiresAt); err != nil {
+		log.Printf("[ERROR] Failed to update expiry for file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update file expiry")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+	updated := *metadata
+	updated.ExpiresAt = expiresAt
+	labelFileObject(r.Context(), h.minioStorage, &updated)
+
+	log.Printf("[INFO] File %s expiry changed from %v to %v by user %s", fileID, metadata.ExpiresAt, expiresAt, userID)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":    "File expiry updated successfully",
+		"file_id":    fileID,
+		"expires_at": expiresAt,
+	})
+}
+
+// labelFileObject brings the tags of a file's object in line with its expiry.
+// Stale tags only mislead lifecycle rules and tooling, so failures are logged.
+func labelFileObject(ctx context.Context, minioStorage *storage.MinIOStorage, metadata *storage.FileMetadata) {
+	if err := minioStorage.LabelObject(ctx, metadata.MinIOPath, metadata.ObjectLabels()); err != nil {
+		log.Printf("[WARN] Failed to tag the object of file %s: %v", metadata.FileID, err)
+	}
+}
+
+// HandleListExpiring lists the user's files that expire within the given window (default 72h)
+func (h *FilesHandler) HandleListExpiring(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	within := defaultExpiringWithin
+	if v := r.URL.Query().Get("within"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 || d > maxExpiringWithin {
+			respondError(w, r, http.StatusBadRequest, "Invalid within duration (e.g. 24h, 72h)")
+			return
+		}
+		within = d
+	}
+
+	metadataList, err := h.pgStore.ListExpiringFiles(r.Context(), userID, time.Now().Add(within))
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve files")
+		return
+	}
+
+	files := make([]FileInfo, 0, len(metadataList))
+	for _, metadata := range metadataList {
+		files = append(files, newFileInfo(metadata))
+	}
+
+	respondJSONConditional(w, r, http.StatusOK, map[string]interface{}{
+		"files":  files,
+		"count":  len(files),
+		"within": within.String(),
+	})
+}
@@ -2,13 +2,15 @@ package api
 
 import (
 	"archive/zip"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
 	"github.com/sachinthra/file-locker/backend/internal/crypto"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
@@ -26,12 +28,19 @@ func NewExportHandler(minioStorage *storage.MinIOStorage, pgStore *storage.Postg
 	}
 }
 
-// HandleExportAll exports all user files as a ZIP archive
+// ExportSkippedHeader carries the number of password-protected files left out of
+// an export
+const ExportSkippedHeader = "X-Export-Skipped"
+
+// HandleExportAll exports all user files as a ZIP archive. Password-protected
+// files are left out: their password guards them independently of the session,
+// so they are only served one by one with X-File-Password. README.txt in the
+// archive lists them.
 func (h *ExportHandler) HandleExportAll(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
@@ -41,20 +50,38 @@ func (h *ExportHandler) HandleExportAll(w http.ResponseWriter, r *http.Request)
 	files, err := h.pgStore.ListUserFiles(r.Context(), userID)
 	if err != nil {
 		log.Printf("[ERROR] Failed to list user files for export: %v", err)
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve files")
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve files")
 		return
 	}
 
 	if len(files) == 0 {
-		respondError(w, http.StatusNotFound, "No files to export")
+		respondError(w, r, http.StatusNotFound, "No files to export")
 		return
 	}
 
-	log.Printf("[INFO] Found %d files to export for user: %s", len(files), userID)
+	var skipped []string
+	exportable := files[:0:0]
+	for _, metadata := range files {
+		if metadata.PasswordHash != "" {
+			skipped = append(skipped, metadata.FileName)
+			continue
+		}
+		exportable = append(exportable, metadata)
+	}
+
+	log.Printf("[INFO] Found %d files to export for user: %s (%d password-protected skipped)", len(exportable), userID, len(skipped))
+
+	mw, ok := beginTransfer(w, r, h.pgStore, userID, storage.TransferExport)
+	if !ok {
+		return
+	}
+	defer recordTransfer(r, h.pgStore, mw)
+	w = mw
 
 	// Set response headers for ZIP download
 	w.Header().Set("Content-Type", "application/zip")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"filelocker-export-%s.zip\"", userID[:8]))
+	w.Header().Set("Content-Disposition", contentDisposition("attachment", fmt.Sprintf("filelocker-export-%s.zip", userID[:8])))
+	w.Header().Set(ExportSkippedHeader, strconv.Itoa(len(skipped)))
 	w.WriteHeader(http.StatusOK)
 
 	// Create ZIP writer that writes directly to response
@@ -65,7 +92,7 @@ func (h *ExportHandler) HandleExportAll(w http.ResponseWriter, r *http.Request)
 	failCount := 0
 
 	// Process each file
-	for _, metadata := range files {
+	for _, metadata := range exportable {
 		log.Printf("[DEBUG] Exporting file: %s (ID: %s)", metadata.FileName, metadata.FileID)
 
 		// Download encrypted file from MinIO
@@ -76,8 +103,8 @@ func (h *ExportHandler) HandleExportAll(w http.ResponseWriter, r *http.Request)
 			continue
 		}
 
-		// Decode encryption key
-		key, err := base64.StdEncoding.DecodeString(metadata.EncryptionKey)
+		// Unwrap encryption key
+		key, err := h.pgStore.DataKey(r.Context(), metadata)
 		if err != nil {
 			log.Printf("[ERROR] Failed to decode encryption key for file %s: %v", metadata.FileID, err)
 			defer func() { _ = encryptedReader.Close() }()
@@ -94,8 +121,8 @@ func (h *ExportHandler) HandleExportAll(w http.ResponseWriter, r *http.Request)
 			continue
 		}
 
-		// Create a sanitized filename (avoid path traversal)
-		safeFileName := filepath.Base(metadata.FileName)
+		// Create a sanitized filename (avoid path traversal and control characters)
+		safeFileName := sanitizeFileName(filepath.Base(metadata.FileName))
 
 		// Create entry in ZIP
 		zipFileWriter, err := zipWriter.Create(safeFileName)
@@ -127,9 +154,14 @@ func (h *ExportHandler) HandleExportAll(w http.ResponseWriter, r *http.Request)
 			"Total Files: %d\n"+
 			"Successfully Exported: %d\n"+
 			"Failed: %d\n"+
-			"\nAll files have been decrypted and are ready to use.\n",
-		len(files), successCount, failCount,
+			"Skipped (password-protected): %d\n"+
+			"\nAll exported files have been decrypted and are ready to use.\n",
+		len(files), successCount, failCount, len(skipped),
 	)
+	if len(skipped) > 0 {
+		readmeContent += "\nPassword-protected files are not exported; download them one by one\n" +
+			"with their password:\n  " + strings.Join(skipped, "\n  ") + "\n"
+	}
 
 	readmeWriter, err := zipWriter.Create("README.txt")
 	if err == nil {
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// FilePasswordHeader carries the per-file download password
+const FilePasswordHeader = "X-File-Password"
+
+// checkFilePassword enforces the per-file download password, if one is set.
+// It writes the error response itself and returns false when the request must stop.
+func checkFilePassword(w http.ResponseWriter, r *http.Request, audit *AuditLogger, userID string, metadata *storage.FileMetadata) bool {
+	// Tickets are only minted after the password was checked; a later change to
+	// the file (e.g. a new password) invalidates them
+	if t, ok := r.Context().Value(constants.AccessTicketKey).(storage.AccessTicket); ok &&
+		t.FileID == metadata.FileID && t.Version == metadata.UpdatedAt.UnixNano() {
+		return true
+	}
+	return verifyFilePassword(w, r, audit, userID, metadata, r.Header.Get(FilePasswordHeader))
+}
+
+// verifyFilePassword checks password against the file's hash and audits failures.
+// Files without a password always pass.
+func verifyFilePassword(w http.ResponseWriter, r *http.Request, audit *AuditLogger, userID string, metadata *storage.FileMetadata, password string) bool {
+	if metadata.PasswordHash == "" {
+		return true
+	}
+
+	if password == "" {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeFilePasswordRequired, "File password required")
+		return false
+	}
+
+	ok, err := crypto.VerifyPassword(password, metadata.PasswordHash)
+	if err != nil {
+		log.Printf("[ERROR] Failed to verify password for file %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to verify file password")
+		return false
+	}
+
+	if !ok {
+		log.Printf("[WARN] Invalid file password for file %s by user %s from %s", metadata.FileID, userID, GetClientIP(r))
+		_ = audit.LogAdminAction(r.Context(), userID, "FILE_PASSWORD_FAILED", "file", metadata.FileID, map[string]interface{}{
+			"filename": metadata.FileName,
+			"path":     r.URL.Path,
+		}, GetClientIP(r))
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeFilePasswordInvalid, "Invalid file password")
+		return false
+	}
+
+	return true
+}
+
+type SetFilePasswordRequest struct {
+	Password        string `json:"password"`
+	CurrentPassword string `json:"current_password"`
+}
+
+// HandleSetFilePassword sets or replaces the download password of a file
+func (h *FilesHandler) HandleSetFilePassword(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return
+	}
+
+	var req SetFilePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.Password) < 8 {
+		respondError(w, r, http.StatusBadRequest, "Password must be at least 8 characters")
+		return
+	}
+
+	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	// Replacing an existing password requires knowing it
+	if !verifyFilePassword(w, r, h.auditLogger, userID, metadata, req.CurrentPassword) {
+		return
+	}
+
+	hash, err := crypto.HashPassword(req.Password)
+	if err != nil {
+		log.Printf("[ERROR] Failed to hash file password: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to set file password")
+		return
+	}
+
+	if err := h.pgStore.SetFilePassword(r.Context(), fileID, hash); err != nil {
+		log.Printf("[ERROR] Failed to save file password: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to set file password")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), userID, "FILE_PASSWORD_SET", "file", fileID, map[string]interface{}{
+		"filename": metadata.FileName,
+	}, GetClientIP(r))
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":            "File password set successfully",
+		"file_id":            fileID,
+		"password_protected": true,
+	})
+}
+
+// HandleRemoveFilePassword removes the download password of a file
+func (h *FilesHandler) HandleRemoveFilePassword(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return
+	}
+
+	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	if metadata.PasswordHash == "" {
+		respondError(w, r, http.StatusBadRequest, "File is not password protected")
+		return
+	}
+
+	if !checkFilePassword(w, r, h.auditLogger, userID, metadata) {
+		return
+	}
+
+	if err := h.pgStore.SetFilePassword(r.Context(), fileID, ""); err != nil {
+		log.Printf("[ERROR] Failed to remove file password: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to remove file password")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), userID, "FILE_PASSWORD_REMOVED", "file", fileID, map[string]interface{}{
+		"filename": metadata.FileName,
+	}, GetClientIP(r))
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":            "File password removed successfully",
+		"file_id":            fileID,
+		"password_protected": false,
+	})
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// TransferFilesRequest names the new owner (by ID or username) and, optionally,
+// which files to hand over; without file_ids all of the user's files go
+type TransferFilesRequest struct {
+	ToUserID   string   `json:"to_user_id"`
+	ToUsername string   `json:"to_username"`
+	FileIDs    []string `json:"file_ids"`
+}
+
+// HandleTransferFiles makes another user the owner of some or all files of a user,
+// e.g. when someone leaves the team. Files keep their IDs, content and share links
+// (which now belong to the new owner); stars are cleared. Objects are moved to the
+// new owner's location by file.relocate jobs, so per-user buckets stay accurate.
+func (h *AdminHandler) HandleTransferFiles(w http.ResponseWriter, r *http.Request) {
+	fromUserID := chi.URLParam(r, "id")
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req TransferFilesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	for _, id := range req.FileIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid file ID: "+id)
+			return
+		}
+	}
+
+	from, err := h.pg.GetUserByID(r.Context(), fromUserID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+	var to *storage.User
+	switch {
+	case req.ToUserID != "":
+		to, err = h.pg.GetUserByID(r.Context(), req.ToUserID)
+	case req.ToUsername != "":
+		to, err = h.pg.GetUserByUsername(r.Context(), req.ToUsername)
+	default:
+		respondError(w, r, http.StatusBadRequest, "to_user_id or to_username required")
+		return
+	}
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "New owner not found")
+		return
+	}
+	if to.ID == from.ID {
+		respondError(w, r, http.StatusBadRequest, "Files already belong to this user")
+		return
+	}
+	if to.Role == storage.RoleService {
+		respondError(w, r, http.StatusBadRequest, "Cannot transfer files to a service account")
+		return
+	}
+
+	files, err := h.pg.TransferFiles(r.Context(), from.ID, to.ID, req.FileIDs)
+	if errors.Is(err, storage.ErrFileNotFound) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "Some of the files do not belong to this user")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] Failed to transfer files of %s to %s: %v", from.ID, to.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to transfer files")
+		return
+	}
+
+	fileIDs := make([]string, len(files))
+	for i, f := range files {
+		fileIDs[i] = f.FileID
+		if _, err := h.jobQueue.Enqueue(r.Context(), jobs.TypeFileRelocate, jobs.FilePayload{FileID: f.FileID}); err != nil {
+			log.Printf("[admin] Failed to queue relocation of file %s: %v", f.FileID, err)
+		}
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileIDs...)
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "FILES_TRANSFERRED", "user", from.ID, map[string]interface{}{
+		"from_username": from.Username,
+		"to_user_id":    to.ID,
+		"to_username":   to.Username,
+		"file_ids":      fileIDs,
+	}, GetClientIP(r))
+	log.Printf("[admin] %d files of %s transferred to %s by %s", len(files), from.Username, to.Username, adminID)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":     "Files transferred successfully",
+		"transferred": len(files),
+		"file_ids":    fileIDs,
+		"to_user_id":  to.ID,
+	})
+}
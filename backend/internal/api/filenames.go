@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Filename policy modes
+const (
+	FileNamePolicySanitize = "sanitize" // repair unsafe names (default)
+	FileNamePolicyReject   = "reject"   // refuse uploads whose name needs repairing
+)
+
+// FileNamePolicy decides how client-supplied filenames (uploads, copies, renames
+// and moves) are stored
+type FileNamePolicy struct {
+	Mode      string
+	MaxLength int // bytes; 0 = maxFileNameLength
+}
+
+// Apply returns the name to store for a file. The name is NFC-normalized;
+// control characters and directory components are removed and overlong names are
+// shortened (keeping the extension). In reject mode any such repair is an error.
+func (p FileNamePolicy) Apply(name string) (string, error) {
+	maxLen := p.MaxLength
+	if maxLen <= 0 || maxLen > maxFileNameLength {
+		maxLen = maxFileNameLength
+	}
+
+	if p.Mode == FileNamePolicyReject {
+		name, err := validateFileName(norm.NFC.String(name))
+		if err != nil {
+			return "", err
+		}
+		if len(name) > maxLen {
+			return "", errors.New("file_name too long")
+		}
+		return name, nil
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, norm.NFC.String(name))
+
+	// Keep only the last path component, whichever separator the client used
+	if i := strings.LastIndexAny(name, "/\\"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "", errors.New("file_name must not be empty")
+	}
+
+	return truncateFileName(name, maxLen), nil
+}
+
+// truncateFileName cuts name to at most maxLen bytes on a rune boundary,
+// preserving a short extension
+func truncateFileName(name string, maxLen int) string {
+	if len(name) <= maxLen {
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	if len(ext) > maxLen/2 {
+		ext = ""
+	}
+	base := name[:len(name)-len(ext)]
+	limit := maxLen - len(ext)
+	for limit > 0 && !utf8.RuneStart(base[limit]) {
+		limit--
+	}
+	return strings.TrimSpace(base[:limit]) + ext
+}
+
+// validateFileName trims a filename and rejects names that cannot be a single
+// path component or that contain control characters
+func validateFileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "" || name == "." || name == "..":
+		return "", errors.New("file_name must not be empty")
+	case len(name) > maxFileNameLength:
+		return "", errors.New("file_name too long")
+	case strings.ContainsAny(name, "/\\"):
+		return "", errors.New("file_name must not contain path separators")
+	}
+	for _, c := range name {
+		if unicode.IsControl(c) {
+			return "", errors.New("file_name must not contain control characters")
+		}
+	}
+	return name, nil
+}
@@ -1,11 +1,18 @@
 package api
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"path"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
 )
@@ -14,161 +21,290 @@ type FilesHandler struct {
 	redisCache   *storage.RedisCache
 	minioStorage *storage.MinIOStorage
 	pgStore      *storage.PostgresStore
+	auditLogger  *AuditLogger
+	namePolicy   FileNamePolicy
+
+	// trashRetention is how long deleted files can be restored; 0 deletes immediately
+	trashRetention time.Duration
 }
 
-func NewFilesHandler(redisCache *storage.RedisCache, minioStorage *storage.MinIOStorage, pgStore *storage.PostgresStore) *FilesHandler {
+func NewFilesHandler(redisCache *storage.RedisCache, minioStorage *storage.MinIOStorage, pgStore *storage.PostgresStore, namePolicy FileNamePolicy, trashRetention time.Duration) *FilesHandler {
 	return &FilesHandler{
-		redisCache:   redisCache,
-		minioStorage: minioStorage,
-		pgStore:      pgStore,
+		redisCache:     redisCache,
+		minioStorage:   minioStorage,
+		pgStore:        pgStore,
+		auditLogger:    NewAuditLogger(pgStore),
+		namePolicy:     namePolicy,
+		trashRetention: trashRetention,
 	}
 }
 
 type FileInfo struct {
-	FileID        string     `json:"file_id"`
-	FileName      string     `json:"file_name"`
-	Description   string     `json:"description,omitempty"`
-	MimeType      string     `json:"mime_type"`
-	Size          int64      `json:"size"`
-	CreatedAt     time.Time  `json:"created_at"`
-	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
-	Tags          []string   `json:"tags,omitempty"`
-	DownloadCount int        `json:"download_count"`
+	FileID            string     `json:"file_id"`
+	FileName          string     `json:"file_name"`
+	Description       string     `json:"description,omitempty"`
+	MimeType          string     `json:"mime_type"`
+	Size              int64      `json:"size"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	Tags              []string   `json:"tags,omitempty"`
+	Folder            string     `json:"folder"`
+	DownloadCount     int        `json:"download_count"`
+	PasswordProtected bool       `json:"password_protected"`
+	Starred           bool       `json:"starred"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+	SHA256            string     `json:"sha256,omitempty"`
+	ModifiedAt        *time.Time `json:"modified_at,omitempty"`
+	Quarantined       bool       `json:"quarantined,omitempty"` // held back for review by an admin
+}
+
+// newFileInfo converts stored metadata into the public API representation
+func newFileInfo(metadata *storage.FileMetadata) FileInfo {
+	return FileInfo{
+		FileID:            metadata.FileID,
+		FileName:          metadata.FileName,
+		Description:       metadata.Description,
+		MimeType:          metadata.MimeType,
+		Size:              metadata.Size,
+		CreatedAt:         metadata.CreatedAt,
+		ExpiresAt:         metadata.ExpiresAt,
+		Tags:              metadata.Tags,
+		Folder:            metadata.Folder,
+		DownloadCount:     metadata.DownloadCount,
+		PasswordProtected: metadata.PasswordHash != "",
+		Starred:           metadata.StarredAt != nil,
+		LastDownloadedAt:  metadata.LastDownloadedAt,
+		SHA256:            metadata.SHA256,
+		ModifiedAt:        metadata.ModifiedAt,
+		Quarantined:       metadata.QuarantinedAt != nil,
+	}
 }
 
+// invalidateFileMetadata drops cached metadata after the database rows changed.
+// Failures are only logged: the cache entry expires on its own.
+func invalidateFileMetadata(ctx context.Context, cache *storage.RedisCache, fileIDs ...string) {
+	if err := cache.InvalidateFileMetadata(ctx, fileIDs...); err != nil {
+		log.Printf("[WARN] Failed to invalidate cached metadata for %v: %v", fileIDs, err)
+	}
+}
+
+// fileLocation is the URL of a file's metadata, for the Location header of the
+// responses that create one
+func fileLocation(r *http.Request, fileID string) string {
+	return apiBase(r) + "/files/" + fileID
+}
+
+// FileSizeHeader carries the size of a file (unencrypted bytes) in answers to
+// HEAD /files/{fileID}
+const FileSizeHeader = "X-File-Size"
+
+// recentDownloadsLimit is how many downloads GET /files/{fileID} lists
+const recentDownloadsLimit = 20
+
+// FileDetails is a file's metadata with its share links and downloads, for its
+// owner
+type FileDetails struct {
+	FileInfo
+	Shares          []*storage.ShareLink  `json:"shares"`
+	DownloadStats   storage.DownloadStats `json:"download_stats"`
+	RecentDownloads []storage.AccessEvent `json:"recent_downloads"` // newest first
+}
+
+// HandleGetFile returns the metadata of one file with its share links and
+// download statistics. HEAD answers with the headers only (ETag, Last-Modified,
+// X-File-Size, X-Checksum-SHA256), a cheap existence and change check for
+// clients that sync, and 304 to a current copy.
+func (h *FilesHandler) HandleGetFile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	metadata, err := storage.LoadFileMetadata(r.Context(), h.redisCache, h.pgStore, fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+	// Expired files are waiting for the cleanup worker
+	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(time.Now()) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
+		return
+	}
+
+	w.Header().Set(FileSizeHeader, strconv.FormatInt(metadata.Size, 10))
+	if metadata.SHA256 != "" {
+		w.Header().Set("X-Checksum-SHA256", metadata.SHA256)
+	}
+	if r.Method == http.MethodHead {
+		if !checkNotModified(w, r, metadata.ETag(), metadata.UpdatedAt) {
+			w.WriteHeader(http.StatusOK)
+		}
+		return
+	}
+
+	// Shares and downloads change while the file does not, so GET is never
+	// answered with 304; the ETag is for If-Match on PATCH
+	w.Header().Set("ETag", metadata.ETag())
+	details := FileDetails{FileInfo: newFileInfo(metadata)}
+	details.Shares, err = h.pgStore.ListShareLinks(r.Context(), fileID)
+	if err == nil {
+		details.DownloadStats, err = h.pgStore.FileDownloadStats(r.Context(), fileID)
+	}
+	if err == nil {
+		details.RecentDownloads, err = h.pgStore.RecentDownloads(r.Context(), fileID, recentDownloadsLimit)
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to get details of file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve file")
+		return
+	}
+	if details.Shares == nil {
+		details.Shares = []*storage.ShareLink{}
+	}
+	respondJSON(w, http.StatusOK, details)
+}
+
+// maxListLimit caps the page size of a paginated file listing
+const maxListLimit = 1000
+
 func (h *FilesHandler) HandleListFiles(w http.ResponseWriter, r *http.Request) {
 	// Get userID from context
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
-	// Get files from PostgreSQL
-	metadataList, err := h.pgStore.ListUserFiles(r.Context(), userID)
+	// Optional pagination: without a limit the user's page size applies (by
+	// default every file is returned)
+	limit, after, err := pageParams(r, userPreferences(r.Context(), h.pgStore, userID).PageSize, maxListLimit)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve files")
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Convert to FileInfo and filter expired files
-	files := make([]FileInfo, 0)
-	now := time.Now()
+	// Get unexpired files from PostgreSQL
+	metadataList, next, err := h.pgStore.ListActiveFilesPage(r.Context(), userID, after, 0, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve files")
+		return
+	}
 
+	files := make([]FileInfo, 0, len(metadataList))
 	for _, metadata := range metadataList {
-		// Filter out expired files
-		if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(now) {
-			continue
-		}
-
-		files = append(files, FileInfo{
-			FileID:        metadata.FileID,
-			FileName:      metadata.FileName,
-			Description:   metadata.Description,
-			MimeType:      metadata.MimeType,
-			Size:          metadata.Size,
-			CreatedAt:     metadata.CreatedAt,
-			ExpiresAt:     metadata.ExpiresAt,
-			Tags:          metadata.Tags,
-			DownloadCount: metadata.DownloadCount,
-		})
+		files = append(files, newFileInfo(metadata))
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"files": files,
 		"count": len(files),
-	})
+	}
+	addNextCursor(response, next)
+	respondJSONConditional(w, r, http.StatusOK, response)
 }
 
 func (h *FilesHandler) HandleSearchFiles(w http.ResponseWriter, r *http.Request) {
 	// Get userID from context
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
 	// Get search query from URL parameter
 	query := r.URL.Query().Get("q")
 	if query == "" {
-		respondError(w, http.StatusBadRequest, "Search query required")
+		respondError(w, r, http.StatusBadRequest, "Search query required")
 		return
 	}
 
-	// Search files in PostgreSQL
-	metadataList, err := h.pgStore.SearchFiles(r.Context(), userID, query)
+	// Paged like the file listing
+	limit, after, err := pageParams(r, userPreferences(r.Context(), h.pgStore, userID).PageSize, maxListLimit)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to search files")
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Convert to FileInfo and filter expired files
-	matchingFiles := make([]FileInfo, 0)
-	now := time.Now()
+	// Search files in PostgreSQL
+	metadataList, next, err := h.pgStore.SearchFiles(r.Context(), userID, query, after, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to search files")
+		return
+	}
 
+	matchingFiles := make([]FileInfo, 0, len(metadataList))
 	for _, metadata := range metadataList {
-		// Skip expired files
-		if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(now) {
-			continue
-		}
-
-		matchingFiles = append(matchingFiles, FileInfo{
-			FileID:        metadata.FileID,
-			FileName:      metadata.FileName,
-			Description:   metadata.Description,
-			MimeType:      metadata.MimeType,
-			Size:          metadata.Size,
-			CreatedAt:     metadata.CreatedAt,
-			ExpiresAt:     metadata.ExpiresAt,
-			Tags:          metadata.Tags,
-			DownloadCount: metadata.DownloadCount,
-		})
+		matchingFiles = append(matchingFiles, newFileInfo(metadata))
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"files": matchingFiles,
 		"count": len(matchingFiles),
 		"query": query,
-	})
+	}
+	addNextCursor(response, next)
+	respondJSONConditional(w, r, http.StatusOK, response)
 }
 
 func (h *FilesHandler) HandleDeleteFile(w http.ResponseWriter, r *http.Request) {
 	// Get userID from context
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
 	// Get fileID from URL
 	fileID := r.URL.Query().Get("id")
 	if fileID == "" {
-		respondError(w, http.StatusBadRequest, "File ID required")
+		respondError(w, r, http.StatusBadRequest, "File ID required")
 		return
 	}
 
+	// ?permanent=true skips the trash (and deletes files that are already in it)
+	permanent := r.URL.Query().Get("permanent") == "true" || h.trashRetention <= 0
+
 	// Get metadata to verify ownership
 	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if errors.Is(err, storage.ErrFileNotFound) && permanent {
+		metadata, err = h.pgStore.GetTrashedFile(r.Context(), fileID)
+	}
 	if err != nil {
-		respondError(w, http.StatusNotFound, "File not found")
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
 		return
 	}
 
 	// Verify ownership
 	if metadata.UserID != userID {
-		respondError(w, http.StatusForbidden, "Access denied")
+		respondError(w, r, http.StatusForbidden, "Access denied")
 		return
 	}
 
-	// Delete file from MinIO storage
-	if err := h.minioStorage.DeleteFile(r.Context(), metadata.MinIOPath); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to delete file from storage")
+	if !permanent {
+		if err := h.pgStore.TrashFile(r.Context(), fileID, userID); err != nil {
+			respondError(w, r, http.StatusInternalServerError, "Failed to move file to trash")
+			return
+		}
+		invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"message":  "File moved to trash",
+			"file_id":  fileID,
+			"purge_at": time.Now().Add(h.trashRetention),
+		})
 		return
 	}
 
-	// Delete metadata from PostgreSQL
-	if err := h.pgStore.DeleteFileMetadata(r.Context(), fileID); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to delete file metadata")
+	if err := h.deletePermanently(r.Context(), metadata); err != nil {
+		if !respondStorageUnavailable(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, "Failed to delete file")
+		}
 		return
 	}
 
@@ -178,56 +314,212 @@ func (h *FilesHandler) HandleDeleteFile(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// UpdateFileRequest changes a file's metadata. Omitted fields are left unchanged.
+// FileName and Folder together move a file anywhere in the user's tree.
 type UpdateFileRequest struct {
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
+	FileName    *string    `json:"file_name"`
+	Description *string    `json:"description"`
+	Tags        *[]string  `json:"tags"`
+	Folder      *string    `json:"folder"`
+	ModifiedAt  *time.Time `json:"modified_at"`
 }
 
+// maxFileNameLength matches common filesystem limits
+const maxFileNameLength = 255
+
 func (h *FilesHandler) HandleUpdateFile(w http.ResponseWriter, r *http.Request) {
 	// Get userID from context
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
 	// Get fileID from URL
 	fileID := chi.URLParam(r, "fileID")
 	if fileID == "" {
-		respondError(w, http.StatusBadRequest, "File ID required")
+		respondError(w, r, http.StatusBadRequest, "File ID required")
 		return
 	}
 
 	// Parse request body
 	var req UpdateFileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
+	var newName string
+	if req.FileName != nil {
+		var err error
+		if newName, err = h.namePolicy.Apply(*req.FileName); err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	var folder string
+	if req.Folder != nil {
+		var err error
+		if folder, err = normalizeFolder(*req.Folder); err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	// Get existing metadata to verify ownership
 	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "File not found")
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
 		return
 	}
 
 	// Verify ownership
 	if metadata.UserID != userID {
-		respondError(w, http.StatusForbidden, "Access denied")
+		respondError(w, r, http.StatusForbidden, "Access denied")
 		return
 	}
 
-	// Update metadata in PostgreSQL
-	if err := h.pgStore.UpdateFileMetadata(r.Context(), fileID, req.Description, req.Tags); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update file metadata")
+	// Update metadata in PostgreSQL. With If-Match / If-Unmodified-Since the change
+	// only applies if nobody else changed the file since the client read it.
+	oldName := metadata.FileName
+	var current *storage.FileMetadata
+	updated, err := h.pgStore.ModifyFile(r.Context(), fileID, func(m *storage.FileMetadata) error {
+		current = m
+		if !preconditionMet(r, m) {
+			return storage.ErrPreconditionFailed
+		}
+		oldName = m.FileName
+		if req.FileName != nil {
+			m.FileName = newName
+		}
+		if req.Description != nil {
+			m.Description = *req.Description
+		}
+		if req.Tags != nil {
+			m.Tags = normalizeTags(*req.Tags)
+		}
+		if req.Folder != nil {
+			m.Folder = folder
+		}
+		if req.ModifiedAt != nil {
+			modifiedAt := req.ModifiedAt.UTC()
+			m.ModifiedAt = &modifiedAt
+		}
+		return nil
+	})
+	if errors.Is(err, storage.ErrPreconditionFailed) {
+		w.Header().Set("ETag", current.ETag())
+		respondError(w, r, http.StatusPreconditionFailed, "File was modified since it was read; reload it and try again")
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to update file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update file metadata")
 		return
 	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+	w.Header().Set("ETag", updated.ETag())
+
+	if updated.FileName != oldName {
+		_ = h.auditLogger.LogAdminAction(r.Context(), userID, "FILE_RENAMED", "file", fileID, map[string]interface{}{
+			"old_name": oldName,
+			"new_name": updated.FileName,
+		}, GetClientIP(r))
+	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"message":     "File updated successfully",
 		"file_id":     fileID,
-		"description": req.Description,
-		"tags":        req.Tags,
+		"file_name":   updated.FileName,
+		"description": updated.Description,
+		"tags":        updated.Tags,
+		"folder":      updated.Folder,
+		"modified_at": updated.ModifiedAt,
 	})
 }
+
+// MoveFileRequest is the new place of a file; omitted fields are left unchanged
+type MoveFileRequest struct {
+	FileName *string `json:"file_name"`
+	Folder   *string `json:"folder"`
+}
+
+// HandleMoveFile moves and/or renames a file. Only metadata changes: the stored
+// object stays where it is. Honors If-Match like HandleUpdateFile.
+func (h *FilesHandler) HandleMoveFile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+	fileID := chi.URLParam(r, "fileID")
+
+	var req MoveFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.FileName == nil && req.Folder == nil {
+		respondError(w, r, http.StatusBadRequest, "folder or file_name required")
+		return
+	}
+
+	var newName, folder string
+	var err error
+	if req.FileName != nil {
+		if newName, err = h.namePolicy.Apply(*req.FileName); err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if req.Folder != nil {
+		if folder, err = normalizeFolder(*req.Folder); err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	var current, before *storage.FileMetadata
+	updated, err := h.pgStore.ModifyFile(r.Context(), fileID, func(m *storage.FileMetadata) error {
+		current = m
+		if m.UserID != userID {
+			return storage.ErrFileNotFound
+		}
+		if !preconditionMet(r, m) {
+			return storage.ErrPreconditionFailed
+		}
+		old := *m
+		before = &old
+		if req.FileName != nil {
+			m.FileName = newName
+		}
+		if req.Folder != nil {
+			m.Folder = folder
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, storage.ErrFileNotFound):
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	case errors.Is(err, storage.ErrPreconditionFailed):
+		w.Header().Set("ETag", current.ETag())
+		respondError(w, r, http.StatusPreconditionFailed, "File was modified since it was read; reload it and try again")
+		return
+	case err != nil:
+		log.Printf("[ERROR] Failed to move file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to move file")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+
+	if updated.FileName != before.FileName || updated.Folder != before.Folder {
+		_ = h.auditLogger.LogAdminAction(r.Context(), userID, "FILE_MOVED", "file", fileID, map[string]interface{}{
+			"from": path.Join(before.Folder, before.FileName),
+			"to":   path.Join(updated.Folder, updated.FileName),
+		}, GetClientIP(r))
+	}
+
+	w.Header().Set("ETag", updated.ETag())
+	respondJSON(w, http.StatusOK, newFileInfo(updated))
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// readinessTimeout bounds the dependency checks of GET /health/ready
+const readinessTimeout = 3 * time.Second
+
+// storageRetryAfter is the Retry-After (seconds) of a storage operation that
+// failed on every attempt
+const storageRetryAfter = 5
+
+// Readiness serves GET /health/ready for load balancers and orchestrators: 200
+// when Postgres and Redis answer and the MinIO circuit breaker is not open, 503
+// otherwise, with the result of each check. GET /health only says the process runs.
+func Readiness(pg *storage.PostgresStore, redisCache *storage.RedisCache, minioStorage *storage.MinIOStorage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		ready := true
+		checks := map[string]string{"database": "ok", "redis": "ok", "storage": "ok"}
+		if err := pg.DB().PingContext(ctx); err != nil {
+			log.Printf("[health] Database not ready: %v", err)
+			checks["database"], ready = "unavailable", false
+		}
+		if err := redisCache.Ping(ctx); err != nil {
+			log.Printf("[health] Redis not ready: %v", err)
+			checks["redis"], ready = "unavailable", false
+		}
+		breaker := minioStorage.BreakerStats()
+		if breaker != nil && breaker.State == storage.BreakerOpen {
+			checks["storage"], ready = "unavailable", false
+		}
+
+		response := map[string]interface{}{"status": "ready", "checks": checks}
+		if breaker != nil {
+			response["storage_breaker"] = breaker
+		}
+		status := http.StatusOK
+		if !ready {
+			response["status"] = "not_ready"
+			status = http.StatusServiceUnavailable
+		}
+		respondJSON(w, status, response)
+	}
+}
+
+// StorageAvailable answers requests that need MinIO with 503 and Retry-After while
+// its circuit breaker is open, instead of letting each of them fail on its own
+func StorageAvailable(minioStorage *storage.MinIOStorage) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if b := minioStorage.BreakerStats(); b != nil && b.State == storage.BreakerOpen {
+				respondStorageUnavailable(w, r, &storage.UnavailableError{RetryAfter: time.Duration(b.RetryAfterSeconds) * time.Second})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// respondStorageUnavailable sends 503 with Retry-After if err comes from the open
+// circuit breaker of MinIO or from an operation that failed on every retry, and
+// reports whether it did
+func respondStorageUnavailable(w http.ResponseWriter, r *http.Request, err error) bool {
+	apiErr := apierror.New(http.StatusServiceUnavailable, apierror.CodeUnavailable, "File storage is temporarily unavailable, try again later")
+	seconds := storageRetryAfter
+	if unavailable, ok := storage.IsUnavailable(err); ok {
+		seconds = int(math.Ceil(unavailable.RetryAfter.Seconds()))
+	} else if retryErr, ok := storage.IsRetryExhausted(err); ok {
+		apiErr = apiErr.With("attempts", retryErr.Attempts)
+	} else {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	apierror.Write(w, r, apiErr.With("retry_after", seconds))
+	return true
+}
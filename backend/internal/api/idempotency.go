@@ -0,0 +1,264 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// IdempotencyKeyHeader lets clients retry a write safely: a request repeated with
+// the same key (by the same user) gets the first response instead of running again
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	// defaultIdempotencyTTL applies when no TTL is configured
+	defaultIdempotencyTTL = 24 * time.Hour
+	// idempotencyClaimTTL bounds how long a request may hold its key; a server that
+	// dies mid-request must not block the key for the whole TTL
+	idempotencyClaimTTL = time.Hour
+	// maxIdempotencyKeyLength bounds client-chosen keys
+	maxIdempotencyKeyLength = 255
+	// maxReplayBody is the largest response kept for replay (writes answer with
+	// small JSON bodies); of a larger one only the top-level fields are kept
+	maxReplayBody = 64 << 10
+	// maxSummaryString is the longest string field kept from a larger response
+	maxSummaryString = 1024
+)
+
+// Idempotency makes the wrapped write routes honour the Idempotency-Key header.
+// Successful responses are stored in Redis for ttl and replayed (with an
+// Idempotent-Replayed header) to requests with the same key; failed requests
+// release the key so they can be retried. A successful request never releases
+// its key, even when its response cannot be stored: running it again is the
+// duplicate the key exists to prevent. Requests without the header are not
+// affected. Mount it after authentication: keys are per user.
+func Idempotency(redisCache *storage.RedisCache, ttl time.Duration) func(http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				respondError(w, r, http.StatusBadRequest, "Idempotency-Key too long")
+				return
+			}
+			userID, _ := r.Context().Value(constants.UserIDKey).(string)
+
+			record := storage.IdempotencyRecord{Method: r.Method, URI: idempotencyURI(r), CreatedAt: time.Now()}
+			earlier, err := redisCache.BeginIdempotent(r.Context(), userID, key, record, idempotencyClaimTTL)
+			if err != nil {
+				log.Printf("[ERROR] Failed to check idempotency key for user %s: %v", userID, err)
+				respondError(w, r, http.StatusInternalServerError, "Failed to check Idempotency-Key")
+				return
+			}
+			if earlier != nil {
+				replayIdempotent(w, r, earlier)
+				return
+			}
+
+			rec := &replayRecorder{ResponseWriter: w, status: http.StatusOK}
+			completed := false
+			defer rec.close()
+			defer func() {
+				// Also runs when the handler panics, so the key is not left claimed
+				if !completed {
+					if err := redisCache.ReleaseIdempotent(context.Background(), userID, key); err != nil {
+						log.Printf("[WARN] Failed to release idempotency key for user %s: %v", userID, err)
+					}
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status < 200 || rec.status >= 300 {
+				return
+			}
+			completed = true
+			record.Status = rec.status
+			record.ContentType = rec.Header().Get("Content-Type")
+			record.Location = rec.Header().Get("Location")
+			if rec.overflow {
+				record.BodyOmitted = true
+				record.Body = rec.summary()
+			} else {
+				record.Body = rec.body.Bytes()
+			}
+			if err := redisCache.CompleteIdempotent(context.Background(), userID, key, record, ttl); err != nil {
+				// The claim stays until idempotencyClaimTTL; retries get 409 meanwhile
+				log.Printf("[WARN] Failed to store idempotent response for user %s: %v", userID, err)
+			}
+		})
+	}
+}
+
+// idempotencyURI identifies the request a key was used for; /api/v1 and /api/v2
+// count as the same request
+func idempotencyURI(r *http.Request) string {
+	uri := canonicalPath(r.URL.Path)
+	if r.URL.RawQuery != "" {
+		uri += "?" + r.URL.RawQuery
+	}
+	return uri
+}
+
+// replayIdempotent answers a request whose key was used before
+func replayIdempotent(w http.ResponseWriter, r *http.Request, earlier *storage.IdempotencyRecord) {
+	if earlier.Method != r.Method || earlier.URI != idempotencyURI(r) {
+		respondErrorCode(w, r, http.StatusUnprocessableEntity, apierror.CodeIdempotencyKeyReused,
+			"Idempotency-Key was already used for a different request")
+		return
+	}
+	if !earlier.Done {
+		w.Header().Set("Retry-After", "5")
+		respondErrorCode(w, r, http.StatusConflict, apierror.CodeIdempotencyInProgress,
+			"A request with this Idempotency-Key is still in progress")
+		return
+	}
+
+	if earlier.Location != "" {
+		w.Header().Set("Location", earlier.Location)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	if earlier.BodyOmitted && len(earlier.Body) == 0 {
+		respondJSON(w, earlier.Status, map[string]string{
+			"message": omittedBodyMessage,
+		})
+		return
+	}
+	if earlier.ContentType != "" {
+		w.Header().Set("Content-Type", earlier.ContentType)
+	}
+	w.WriteHeader(earlier.Status)
+	_, _ = w.Write(earlier.Body)
+}
+
+const omittedBodyMessage = "Request already completed; its response was too large to replay"
+
+// replaySummary reads a JSON response too large to keep and returns its
+// top-level scalar fields (IDs, names, counts); the nested lists and objects
+// that made it large are dropped. It returns nil for anything but a JSON object.
+func replaySummary(body io.Reader) []byte {
+	dec := json.NewDecoder(body)
+	dec.UseNumber()
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil
+	}
+
+	fields := map[string]interface{}{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		name, _ := t.(string)
+		if t, err = dec.Token(); err != nil {
+			return nil
+		}
+		switch v := t.(type) {
+		case json.Delim:
+			// Skip the nested value
+			for depth := 1; depth > 0; {
+				if t, err = dec.Token(); err != nil {
+					return nil
+				}
+				switch t {
+				case json.Delim('{'), json.Delim('['):
+					depth++
+				case json.Delim('}'), json.Delim(']'):
+					depth--
+				}
+			}
+		case string:
+			if len(v) <= maxSummaryString {
+				fields[name] = v
+			}
+		default:
+			fields[name] = v
+		}
+	}
+	if _, ok := fields["message"]; !ok {
+		fields["message"] = omittedBodyMessage
+	}
+
+	summary, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return summary
+}
+
+// replayRecorder passes a response through and keeps a copy for replay. A body
+// that outgrows maxReplayBody is streamed through replaySummary instead.
+type replayRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	overflow    bool // body too large to keep
+
+	pipe      *io.PipeWriter // into replaySummary, once overflowed
+	summaryCh chan []byte
+}
+
+func (rr *replayRecorder) WriteHeader(status int) {
+	if !rr.wroteHeader {
+		rr.wroteHeader = true
+		rr.status = status
+	}
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *replayRecorder) Write(p []byte) (int, error) {
+	rr.wroteHeader = true
+	switch {
+	case rr.overflow:
+		_, _ = rr.pipe.Write(p)
+	case rr.body.Len()+len(p) > maxReplayBody:
+		rr.overflow = true
+		pr, pw := io.Pipe()
+		rr.pipe = pw
+		rr.summaryCh = make(chan []byte, 1)
+		go func() {
+			rr.summaryCh <- replaySummary(pr)
+			_, _ = io.Copy(io.Discard, pr) // whatever follows the object
+		}()
+		_, _ = pw.Write(rr.body.Bytes())
+		_, _ = pw.Write(p)
+		rr.body.Reset()
+	default:
+		rr.body.Write(p)
+	}
+	return rr.ResponseWriter.Write(p)
+}
+
+// summary returns the summary of an overflowed body once the handler is done
+func (rr *replayRecorder) summary() []byte {
+	rr.close()
+	return <-rr.summaryCh
+}
+
+// close ends the stream into replaySummary, so it does not outlive the request
+func (rr *replayRecorder) close() {
+	if rr.pipe != nil {
+		_ = rr.pipe.Close()
+	}
+}
+
+// Unwrap lets http.ResponseController (used for transfer deadlines) reach the connection
+func (rr *replayRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+)
+
+func TestReplaySummary(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"scalars kept, lists dropped", `{"file_id":"f1","items":[1,[2],{"a":3}],"count":3,"ok":true,"message":"done"}`, `{"count":3,"file_id":"f1","message":"done","ok":true}`},
+		{"message added", `{"id":"s1","meta":{"a":[1]}}`, `{"id":"s1","message":"` + omittedBodyMessage + `"}`},
+		{"long string dropped", `{"id":"s1","note":"` + strings.Repeat("x", maxSummaryString+1) + `"}`, `{"id":"s1","message":"` + omittedBodyMessage + `"}`},
+		{"truncated", `{"file_id":"f1","items":[{"a":1},{"a"`, ``},
+		{"not an object", `[1,2,3]`, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(replaySummary(strings.NewReader(tt.body))); got != tt.want {
+				t.Errorf("replaySummary = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// A replay of a response too large to keep still names what the first request created
+func TestIdempotencyReplaysLargeResponse(t *testing.T) {
+	runs := 0
+	handler := Idempotency(newTestRedis(t), 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs++
+		w.Header().Set("Location", "/api/v1/files/file-1")
+		respondJSON(w, http.StatusCreated, map[string]interface{}{
+			"file_id": "file-1",
+			"chunks":  strings.Split(strings.Repeat("x", 2*maxReplayBody), ""),
+			"size":    2 * maxReplayBody,
+		})
+	}))
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/upload", nil)
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, "user-1"))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	send()
+	rec := send()
+	if runs != 1 {
+		t.Fatalf("handler ran %d times, want 1", runs)
+	}
+	if rec.Code != http.StatusCreated || rec.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("replay: status %d, headers %v", rec.Code, rec.Header())
+	}
+	if got := rec.Header().Get("Location"); got != "/api/v1/files/file-1" {
+		t.Errorf("Location = %q, want /api/v1/files/file-1", got)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("replay body %q: %v", rec.Body.String(), err)
+	}
+	if body["file_id"] != "file-1" {
+		t.Errorf("file_id = %v, want file-1", body["file_id"])
+	}
+	if _, ok := body["chunks"]; ok {
+		t.Error("replay kept the bulky chunks field")
+	}
+}
@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const (
+	// ipAccessRefresh is how long a replica uses its parsed rules before reading
+	// them again (from Redis, or the settings on a cache miss)
+	ipAccessRefresh = 5 * time.Second
+	// ipAccessCacheTTL bounds how long Redis serves the rules without the settings
+	ipAccessCacheTTL = 10 * time.Minute
+	// ipBlockAuditInterval limits IP_BLOCKED audit entries to one per address per
+	// interval, so a blocked client does not flood the audit log
+	ipBlockAuditInterval = 10 * time.Minute
+)
+
+// Reasons a request is refused, recorded in the IP_BLOCKED audit entry
+const (
+	blockedDenylist  = "denylist"
+	blockedAllowlist = "not_allowlisted"
+	blockedCountry   = "country"
+)
+
+// ipRules are IP access rules parsed for matching
+type ipRules struct {
+	allow     []netip.Prefix
+	deny      []netip.Prefix
+	countries map[string]bool
+}
+
+// parseIPRules checks and parses rules; entries are CIDRs or single addresses and
+// country codes two letters
+func parseIPRules(rules storage.IPAccessRules) (*ipRules, error) {
+	parsed := &ipRules{countries: make(map[string]bool)}
+	var err error
+	if parsed.allow, err = parsePrefixes(rules.Allow); err != nil {
+		return nil, fmt.Errorf("allow: %w", err)
+	}
+	if parsed.deny, err = parsePrefixes(rules.Deny); err != nil {
+		return nil, fmt.Errorf("deny: %w", err)
+	}
+	for _, country := range rules.BlockedCountries {
+		code := strings.ToUpper(strings.TrimSpace(country))
+		if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+			return nil, fmt.Errorf("blocked_countries: %q is not an ISO 3166 country code", country)
+		}
+		parsed.countries[code] = true
+	}
+	return parsed, nil
+}
+
+func parsePrefixes(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a CIDR", entry)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address", entry)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// blocked returns why addr (in country, which may be unknown) is refused, or ""
+func (rules *ipRules) blocked(addr netip.Addr, country string) string {
+	switch {
+	case containsAddr(rules.deny, addr):
+		return blockedDenylist
+	case len(rules.allow) > 0 && !containsAddr(rules.allow, addr):
+		return blockedAllowlist
+	case country != "" && rules.countries[country]:
+		return blockedCountry
+	}
+	return ""
+}
+
+// IPAccess refuses API requests from denied addresses, from addresses missing
+// from a non-empty allowlist and from blocked countries, before authentication.
+// The rules are admin settings, cached in Redis for all replicas. Countries come
+// from a header set by the reverse proxy or CDN (e.g. CF-IPCountry); without one
+// configured, country blocking is off. RealIP drops that header from requests not
+// sent by a trusted proxy, and the proxy must overwrite or strip any copy sent by
+// the client, or clients can claim any country.
+type IPAccess struct {
+	pg            *storage.PostgresStore
+	redisCache    *storage.RedisCache
+	countryHeader string
+
+	mu        sync.Mutex
+	rules     *ipRules
+	checkedAt time.Time
+}
+
+func NewIPAccess(pg *storage.PostgresStore, redisCache *storage.RedisCache, countryHeader string) *IPAccess {
+	return &IPAccess{pg: pg, redisCache: redisCache, countryHeader: countryHeader, rules: &ipRules{}}
+}
+
+// current returns the parsed rules, read at most every ipAccessRefresh. When they
+// cannot be read the last known rules are kept.
+func (a *IPAccess) current(ctx context.Context) *ipRules {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if time.Since(a.checkedAt) < ipAccessRefresh {
+		return a.rules
+	}
+	rules, err := a.load(ctx)
+	if err != nil {
+		log.Printf("[WARN] Failed to read IP access rules, keeping the last known ones: %v", err)
+	} else {
+		a.rules = rules
+	}
+	a.checkedAt = time.Now()
+	return a.rules
+}
+
+// load reads the rules from Redis, falling back to (and caching) the settings
+func (a *IPAccess) load(ctx context.Context) (*ipRules, error) {
+	cached, err := a.redisCache.CachedIPAccessRules(ctx)
+	if err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+	if cached != nil {
+		return parseIPRules(*cached)
+	}
+
+	rules, err := a.pg.GetIPAccessRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.redisCache.CacheIPAccessRules(ctx, rules, ipAccessCacheTTL); err != nil {
+		log.Printf("[WARN] Failed to cache IP access rules: %v", err)
+	}
+	return parseIPRules(rules)
+}
+
+// Invalidate drops the cached rules after they changed; other replicas pick up the
+// change within ipAccessRefresh
+func (a *IPAccess) Invalidate(ctx context.Context) {
+	if err := a.redisCache.InvalidateIPAccessRules(ctx); err != nil {
+		log.Printf("[WARN] Failed to invalidate cached IP access rules: %v", err)
+	}
+	a.mu.Lock()
+	a.checkedAt = time.Time{}
+	a.mu.Unlock()
+}
+
+// country is the client's country from the configured header ("" if unknown)
+func (a *IPAccess) country(r *http.Request) string {
+	if a.countryHeader == "" {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(r.Header.Get(a.countryHeader)))
+}
+
+// Middleware answers refused requests with 403 IP_BLOCKED. Mount it before
+// authentication (and after RealIP, which resolves the client address and drops
+// the country header of untrusted peers).
+func (a *IPAccess) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, err := netip.ParseAddr(clientIP(r))
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		addr = addr.Unmap()
+
+		country := a.country(r)
+		reason := a.current(r.Context()).blocked(addr, country)
+		if reason == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		a.auditBlocked(r, addr.String(), country, reason)
+		apierror.RespondCode(w, r, http.StatusForbidden, apierror.CodeIPBlocked, "Access from your network is not allowed")
+	})
+}
+
+// auditBlocked records a refused request (once per address per ipBlockAuditInterval)
+func (a *IPAccess) auditBlocked(r *http.Request, ip, country, reason string) {
+	first, err := a.redisCache.FirstIPBlock(r.Context(), ip, ipBlockAuditInterval)
+	if err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+	if !first {
+		return
+	}
+
+	fields := map[string]interface{}{"reason": reason, "method": r.Method, "path": r.URL.Path}
+	if country != "" {
+		fields["country"] = country
+	}
+	metadata, _ := json.Marshal(fields)
+	err = a.pg.LogAudit(r.Context(), storage.AuditEntry{
+		Action:     "IP_BLOCKED",
+		TargetType: "ip",
+		Metadata:   metadata,
+		IPAddress:  ip,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to audit blocked address %s: %v", ip, err)
+	}
+	log.Printf("[WARN] Blocked %s (%s) on %s %s", ip, reason, r.Method, r.URL.Path)
+}
+
+// HandleGetIPAccess returns the IP allow/deny lists and blocked countries
+func (h *AdminHandler) HandleGetIPAccess(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.pg.GetIPAccessRules(r.Context())
+	if err != nil {
+		log.Printf("[admin] Failed to get IP access rules: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get IP access rules")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(rules)
+}
+
+// HandleSetIPAccess replaces the IP allow/deny lists and blocked countries. Rules
+// that would block the admin's own request are refused, so an admin cannot lock
+// themselves out.
+func (h *AdminHandler) HandleSetIPAccess(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req storage.IPAccessRules
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	parsed, err := parseIPRules(req)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid IP access rules: "+err.Error())
+		return
+	}
+	for i, country := range req.BlockedCountries {
+		req.BlockedCountries[i] = strings.ToUpper(strings.TrimSpace(country))
+	}
+
+	if addr, err := netip.ParseAddr(clientIP(r)); err == nil {
+		if reason := parsed.blocked(addr.Unmap(), h.ipAccess.country(r)); reason != "" {
+			respondError(w, r, http.StatusBadRequest,
+				fmt.Sprintf("These rules would block your own address %s (%s)", addr.Unmap(), reason))
+			return
+		}
+	}
+
+	if err := h.pg.SetIPAccessRules(r.Context(), req, adminID); err != nil {
+		log.Printf("[admin] Failed to set IP access rules: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to set IP access rules")
+		return
+	}
+	h.ipAccess.Invalidate(r.Context())
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "IP_ACCESS_UPDATED", "system", "", map[string]interface{}{
+		"allow":             req.Allow,
+		"deny":              req.Deny,
+		"blocked_countries": req.BlockedCountries,
+	}, GetClientIP(r))
+	log.Printf("[admin] IP access rules updated by %s", adminID)
+
+	h.HandleGetIPAccess(w, r)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const maxJobsLimit = 200
+
+// HandleListJobs lists background jobs, newest first, with the number of jobs per
+// status. Filters: ?status=pending|running|succeeded|dead (dead = dead letters),
+// ?type=, ?limit= (1-200, default 50), and ?cursor= (next_cursor of the previous
+// page) or ?offset=.
+func (h *AdminHandler) HandleListJobs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	status := q.Get("status")
+	switch status {
+	case "", storage.JobPending, storage.JobRunning, storage.JobSucceeded, storage.JobDead:
+	default:
+		respondError(w, r, http.StatusBadRequest, "Invalid status (pending, running, succeeded, dead)")
+		return
+	}
+
+	limit, after, err := pageParams(r, 50, maxJobsLimit)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			respondError(w, r, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+		offset = n
+	}
+	if after != nil && offset > 0 {
+		respondError(w, r, http.StatusBadRequest, "Cursor and offset cannot be combined")
+		return
+	}
+
+	list, next, err := h.pg.ListJobs(r.Context(), status, q.Get("type"), after, limit, offset)
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list jobs")
+		return
+	}
+	counts, err := h.pg.CountJobsByStatus(r.Context())
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list jobs")
+		return
+	}
+
+	response := map[string]interface{}{
+		"jobs":   list,
+		"counts": counts,
+		"limit":  limit,
+		"offset": offset,
+	}
+	addNextCursor(response, next)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleRetryJob puts a dead job back in the queue with a fresh set of attempts
+func (h *AdminHandler) HandleRetryJob(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	jobID := chi.URLParam(r, "id")
+
+	job, err := h.pg.RetryJob(r.Context(), jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, r, http.StatusNotFound, "No dead job with this ID")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] Failed to retry job %s: %v", jobID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to retry job")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "JOB_RETRIED", "job", jobID, map[string]interface{}{
+		"type": job.Type,
+	}, GetClientIP(r))
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(job)
+}
+
+// HandleReencryptFile queues re-encryption of one file's object with a new data key
+// (e.g. after a suspected key leak). Progress: GET /admin/jobs?type=file.reencrypt.
+func (h *AdminHandler) HandleReencryptFile(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	fileID := chi.URLParam(r, "id")
+
+	if _, err := h.pg.GetFileMetadata(r.Context(), fileID); err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	job, err := h.jobQueue.Enqueue(r.Context(), jobs.TypeFileReencrypt, jobs.FilePayload{FileID: fileID})
+	if err != nil {
+		log.Printf("[admin] Failed to queue re-encryption of file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to queue re-encryption")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "FILE_REENCRYPT_QUEUED", "file", fileID, map[string]interface{}{
+		"job_id": job.ID,
+	}, GetClientIP(r))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(job)
+}
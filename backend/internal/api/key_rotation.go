@@ -0,0 +1,68 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// HandleStartKeyRotation starts a background job that re-wraps every file's data key
+// under the current KEK (security.kek). With reencrypt_objects the stored objects are
+// also re-encrypted with new data keys. Progress: GET /admin/keys/rotation.
+func (h *AdminHandler) HandleStartKeyRotation(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		ReencryptObjects bool `json:"reencrypt_objects"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.pg.CurrentKEKID() == "" {
+		respondError(w, r, http.StatusConflict, "No key-encryption key configured (security.kek)")
+		return
+	}
+
+	job, err := h.keyRotator.Rotate(adminID, req.ReencryptObjects, GetClientIP(r))
+	if errors.Is(err, storage.ErrKeyRotationRunning) {
+		respondError(w, r, http.StatusConflict, "A key rotation is already running")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] Failed to start key rotation: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to start key rotation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(job)
+}
+
+// HandleGetKeyRotation returns the progress of the latest key rotation job
+func (h *AdminHandler) HandleGetKeyRotation(w http.ResponseWriter, r *http.Request) {
+	job, err := h.pg.GetLatestKeyRotation(r.Context())
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, r, http.StatusNotFound, "No key rotation has been run")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] Failed to get key rotation: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get key rotation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"rotation":       job,
+		"current_kek_id": h.pg.CurrentKEKID(),
+	})
+}
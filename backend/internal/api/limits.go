@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sachinthra/file-locker/backend/internal/alerts"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/capacity"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// BodyLimit caps request bodies at limit bytes. Paths starting with a prefix in
+// overrides get that limit instead (streaming uploads need far more than JSON APIs).
+// A limit of 0 means unlimited.
+func BodyLimit(limit int64, overrides map[string]int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			max := limit
+			path := canonicalPath(r.URL.Path)
+			for prefix, l := range overrides {
+				if strings.HasPrefix(path, prefix) {
+					max = l
+					break
+				}
+			}
+
+			if max > 0 && r.Body != nil && r.Body != http.NoBody {
+				// Reject declared oversize bodies before reading anything
+				if r.ContentLength > max {
+					respondError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body too large. Max size: %d bytes", max))
+					return
+				}
+				r.Body = http.MaxBytesReader(w, r.Body, max)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ConcurrencyPerIP limits how many requests a single client address may have in flight.
+// Slow clients (slowloris-style trickled bodies) tie up a slot each, so one address
+// cannot exhaust the server. A limit of 0 disables the check.
+func ConcurrencyPerIP(limit int) func(http.Handler) http.Handler {
+	var mu sync.Mutex
+	inFlight := make(map[string]int)
+
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// RealIP middleware has already resolved the client address into RemoteAddr
+			ip, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				ip = r.RemoteAddr
+			}
+
+			mu.Lock()
+			if inFlight[ip] >= limit {
+				mu.Unlock()
+				w.Header().Set("Retry-After", "1")
+				respondError(w, r, http.StatusTooManyRequests, "Too many concurrent requests")
+				return
+			}
+			inFlight[ip]++
+			mu.Unlock()
+
+			defer func() {
+				mu.Lock()
+				if inFlight[ip]--; inFlight[ip] <= 0 {
+					delete(inFlight, ip)
+				}
+				mu.Unlock()
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// UploadWatermark accounts for the request bytes received by uploads in progress
+// and refuses new uploads with 503 and Retry-After while they add up to watermark
+// bytes or more, so a burst of concurrent large uploads cannot exhaust the server.
+// Uploads already running are not interrupted. A watermark of 0 disables the check.
+func UploadWatermark(watermark int64) func(http.Handler) http.Handler {
+	var inFlight atomic.Int64
+
+	return func(next http.Handler) http.Handler {
+		if watermark <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if inFlight.Load() >= watermark {
+				w.Header().Set("Retry-After", "5")
+				respondError(w, r, http.StatusServiceUnavailable, "Server is busy with other uploads, try again later")
+				return
+			}
+
+			body := &accountedBody{ReadCloser: r.Body, total: &inFlight}
+			r.Body = body
+			defer func() { inFlight.Add(-body.n.Load()) }()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// UploadSpaceGuard refuses uploads with 507 when the temp filesystem or the object
+// storage would be left with less than its reserve, judged by the declared request
+// size. If the space cannot be estimated the upload goes ahead.
+func UploadSpaceGuard(guard *capacity.Guard) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !guard.Enabled() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := guard.Check(r.Context(), r.ContentLength)
+			var insufficient *capacity.InsufficientError
+			switch {
+			case errors.As(err, &insufficient):
+				log.Printf("[WARN] Refused upload: %v", err)
+				apierror.Write(w, r, apierror.New(http.StatusInsufficientStorage, apierror.CodeInsufficientStorage,
+					"Not enough storage space left for this upload, try again later or contact an administrator").
+					With("resource", insufficient.Resource))
+				return
+			case err != nil:
+				log.Printf("[WARN] Failed to estimate upload space: %v", err)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// QuotaGuard refuses uploads with 507 from users who stayed over the storage quota
+// past the grace period (the storage_quota_grace_days setting). Users within it
+// can still upload; the first upload to find them over the quota starts it and
+// warns them. If the quota cannot be checked the upload goes ahead.
+func QuotaGuard(monitor *alerts.Monitor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, _ := r.Context().Value(constants.UserIDKey).(string)
+			status, err := monitor.QuotaStatus(r.Context(), userID)
+			switch {
+			case err != nil:
+				log.Printf("[WARN] Failed to check storage quota of %s: %v", userID, err)
+			case status != nil && status.State == storage.QuotaBlocked:
+				apierror.Write(w, r, apierror.New(http.StatusInsufficientStorage, apierror.CodeQuotaExceeded,
+					"You are over your storage quota; delete files or empty the trash to upload again").
+					With("quota", status))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// accountedBody adds the bytes read from a request body to a shared total
+type accountedBody struct {
+	io.ReadCloser
+	total *atomic.Int64
+	n     atomic.Int64
+}
+
+func (b *accountedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	b.total.Add(int64(n))
+	return n, err
+}
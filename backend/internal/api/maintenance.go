@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// maintenanceRefresh is how long a replica serves its cached maintenance state
+// before reading the settings again (toggles on other replicas apply after this)
+const maintenanceRefresh = 5 * time.Second
+
+// DefaultMaintenanceAllowlist keeps login working during maintenance, so admins can
+// sign in, and lets the frontend find out who is signed in
+var DefaultMaintenanceAllowlist = []string{"/api/v1/auth/login", "/api/v1/auth/me", "/api/v1/auth/logout"}
+
+// Maintenance answers non-admin API requests with 503 while maintenance mode (the
+// maintenance_mode setting) is on. Paths starting with an allowlisted prefix are
+// always served (the allowlist is written with /api/v1 paths and covers /api/v2 too).
+type Maintenance struct {
+	pg        *storage.PostgresStore
+	allowlist []string
+
+	mu        sync.Mutex
+	state     storage.MaintenanceState
+	checkedAt time.Time
+}
+
+func NewMaintenance(pg *storage.PostgresStore, allowlist []string) *Maintenance {
+	if allowlist == nil {
+		allowlist = DefaultMaintenanceAllowlist
+	}
+	return &Maintenance{pg: pg, allowlist: allowlist}
+}
+
+// State returns the maintenance state, read from the settings at most every
+// maintenanceRefresh. When they cannot be read the last known state is kept.
+func (m *Maintenance) State(ctx context.Context) storage.MaintenanceState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if time.Since(m.checkedAt) < maintenanceRefresh {
+		return m.state
+	}
+	state, err := m.pg.GetMaintenanceMode(ctx)
+	if err != nil {
+		log.Printf("[WARN] Failed to read maintenance mode, keeping enabled=%t: %v", m.state.Enabled, err)
+	} else {
+		m.state = state
+	}
+	m.checkedAt = time.Now()
+	return m.state
+}
+
+// Invalidate makes the next request read the settings again
+func (m *Maintenance) Invalidate() {
+	m.mu.Lock()
+	m.checkedAt = time.Time{}
+	m.mu.Unlock()
+}
+
+// Middleware rejects requests during maintenance unless their path is allowlisted
+// or they come from an admin. Mount it after authentication so the user is known;
+// unauthenticated requests count as non-admin.
+func (m *Maintenance) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := m.State(r.Context())
+		if !state.Enabled || m.allowed(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Retry-After", "60")
+		apierror.Write(w, r, apierror.New(http.StatusServiceUnavailable, apierror.CodeMaintenance, "Service under maintenance").
+			With("message", state.Message).
+			With("maintenance", true))
+	})
+}
+
+func (m *Maintenance) allowed(r *http.Request) bool {
+	path := canonicalPath(r.URL.Path)
+	for _, prefix := range m.allowlist {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		return false
+	}
+	user, err := m.pg.GetUserByID(r.Context(), userID)
+	return err == nil && user.Role == "admin"
+}
+
+// HandleGetMaintenance returns whether maintenance mode is on
+func (h *AdminHandler) HandleGetMaintenance(w http.ResponseWriter, r *http.Request) {
+	state, err := h.pg.GetMaintenanceMode(r.Context())
+	if err != nil {
+		log.Printf("[admin] Failed to get maintenance mode: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get maintenance mode")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// HandleSetMaintenance turns maintenance mode on or off (and optionally changes the
+// message shown to users)
+func (h *AdminHandler) HandleSetMaintenance(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		Enabled *bool  `json:"enabled"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+		respondError(w, r, http.StatusBadRequest, "Request body must set enabled")
+		return
+	}
+	req.Message = strings.TrimSpace(req.Message)
+
+	if err := h.pg.SetMaintenanceMode(r.Context(), *req.Enabled, req.Message, adminID); err != nil {
+		log.Printf("[admin] Failed to set maintenance mode: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to set maintenance mode")
+		return
+	}
+	h.maintenance.Invalidate()
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "MAINTENANCE_MODE_UPDATED", "system", "", map[string]interface{}{
+		"enabled": *req.Enabled,
+		"message": req.Message,
+	}, GetClientIP(r))
+	log.Printf("[admin] Maintenance mode set to %t by %s", *req.Enabled, adminID)
+
+	h.HandleGetMaintenance(w, r)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+)
+
+// HandleListNotificationChannels returns the chat channels events are posted to
+// (features.notifications), without their URLs and tokens
+func (h *AdminHandler) HandleListNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"channels": h.notifier.Channels(),
+		"events":   notify.Events,
+	})
+}
+
+// HandleTestNotificationChannel queues a test message for one channel, to check
+// its webhook URL or Matrix token and room
+func (h *AdminHandler) HandleTestNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	channel := chi.URLParam(r, "channel")
+
+	known := false
+	for _, c := range h.notifier.Channels() {
+		known = known || c.Name == channel
+	}
+	if !known {
+		respondError(w, r, http.StatusNotFound, "Notification channel not found")
+		return
+	}
+
+	job, err := h.notifier.Send(r.Context(), channel, notify.EventTest, notify.Message{
+		Title: "File Locker test notification",
+		Text:  "Notifications for this channel are set up correctly.",
+	})
+	if err != nil {
+		log.Printf("[admin] Failed to queue test notification for %s: %v", channel, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to queue test notification")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "NOTIFICATION_TESTED", "job", job.ID, map[string]interface{}{
+		"channel": channel,
+	}, GetClientIP(r))
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "Test notification queued",
+		"job_id":  job.ID,
+	})
+}
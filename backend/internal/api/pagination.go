@@ -0,0 +1,43 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// pageParams reads the paging parameters shared by the newest-first listings:
+// ?limit= (1 to max; def when missing) and ?cursor=, the next_cursor of the
+// previous page. The error is meant for a 400 response.
+func pageParams(r *http.Request, def, max int) (int, *storage.Cursor, error) {
+	q := r.URL.Query()
+	limit := def
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > max {
+			return 0, nil, fmt.Errorf("Invalid limit (1-%d)", max)
+		}
+		limit = n
+	}
+
+	var after *storage.Cursor
+	if v := q.Get("cursor"); v != "" {
+		cursor, err := storage.ParseCursor(v)
+		if err != nil {
+			return 0, nil, errors.New("Invalid cursor")
+		}
+		after = cursor
+	}
+	return limit, after, nil
+}
+
+// addNextCursor adds the next_cursor of a page to a listing response; it is left
+// out on the last page
+func addNextCursor(response map[string]interface{}, next *storage.Cursor) {
+	if next != nil {
+		response["next_cursor"] = next.Encode()
+	}
+}
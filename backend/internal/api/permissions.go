@@ -0,0 +1,39 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// PermissionsHandler serves GET /permissions: the authorization policy (auth.Policy)
+// with, for every route, whether the caller may use it, so clients can hide actions
+// that would be refused
+type PermissionsHandler struct {
+	pg *storage.PostgresStore
+}
+
+func NewPermissionsHandler(pg *storage.PostgresStore) *PermissionsHandler {
+	return &PermissionsHandler{pg: pg}
+}
+
+func (h *PermissionsHandler) HandleGetPermissions(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(constants.UserIDKey).(string)
+	user, err := h.pg.GetUserByID(r.Context(), userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get user %s for permissions: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get permissions")
+		return
+	}
+
+	caller := auth.CallerFromRequest(r, user.Role)
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"role":            user.Role,
+		"scopes":          caller.Scopes, // null: not limited (session)
+		"service_account": caller.ServiceAccount,
+		"permissions":     auth.Permissions(caller),
+	})
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	_ "time/tzdata" // timezones are validated without relying on the host's zoneinfo
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"golang.org/x/text/language"
+)
+
+// maxDefaultExpiryHours caps the default upload expiry (one year)
+const maxDefaultExpiryHours = 365 * 24
+
+// UpdatePreferencesRequest changes the caller's preferences; omitted fields are kept
+type UpdatePreferencesRequest struct {
+	Timezone           *string `json:"timezone"`
+	Locale             *string `json:"locale"`
+	DefaultExpiryHours *int    `json:"default_expiry_hours"`
+	PageSize           *int    `json:"page_size"`
+	Notifications      *struct {
+		ShareDisabled *bool `json:"share_disabled"`
+		StorageQuota  *bool `json:"storage_quota"`
+	} `json:"notifications"`
+}
+
+// apply validates the changes and makes them on prefs
+func (req *UpdatePreferencesRequest) apply(prefs *storage.UserPreferences) error {
+	if req.Timezone != nil {
+		tz := strings.TrimSpace(*req.Timezone)
+		if _, err := time.LoadLocation(tz); err != nil || tz == "" || strings.EqualFold(tz, "Local") {
+			return fmt.Errorf("unknown timezone %q (use an IANA name such as Europe/Berlin)", *req.Timezone)
+		}
+		prefs.Timezone = tz
+	}
+	if req.Locale != nil {
+		tag, err := language.Parse(strings.TrimSpace(*req.Locale))
+		if err != nil {
+			return fmt.Errorf("invalid locale %q (use a language tag such as en-US)", *req.Locale)
+		}
+		prefs.Locale = tag.String()
+	}
+	if req.DefaultExpiryHours != nil {
+		if *req.DefaultExpiryHours < 0 || *req.DefaultExpiryHours > maxDefaultExpiryHours {
+			return fmt.Errorf("default_expiry_hours must be 0-%d", maxDefaultExpiryHours)
+		}
+		prefs.DefaultExpiryHours = *req.DefaultExpiryHours
+	}
+	if req.PageSize != nil {
+		if *req.PageSize < 0 || *req.PageSize > maxListLimit {
+			return fmt.Errorf("page_size must be 0-%d", maxListLimit)
+		}
+		prefs.PageSize = *req.PageSize
+	}
+	if req.Notifications != nil && req.Notifications.ShareDisabled != nil {
+		prefs.Notifications.ShareDisabled = *req.Notifications.ShareDisabled
+	}
+	if req.Notifications != nil && req.Notifications.StorageQuota != nil {
+		prefs.Notifications.StorageQuota = *req.Notifications.StorageQuota
+	}
+	return nil
+}
+
+// userPreferences returns a user's preferences for applying server-side defaults.
+// A failed lookup falls back to the defaults rather than failing the request.
+func userPreferences(ctx context.Context, pg *storage.PostgresStore, userID string) storage.UserPreferences {
+	prefs, err := pg.GetUserPreferences(ctx, userID)
+	if err != nil {
+		log.Printf("[WARN] Failed to get preferences of %s, using the defaults: %v", userID, err)
+		return storage.DefaultUserPreferences()
+	}
+	return prefs
+}
+
+// HandleGetPreferences returns the caller's preferences (the defaults if never set)
+func (h *UserHandler) HandleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	prefs, err := h.pgStore.GetUserPreferences(r.Context(), userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get preferences of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get preferences")
+		return
+	}
+	respondJSON(w, http.StatusOK, prefs)
+}
+
+// HandleUpdatePreferences changes the caller's preferences
+func (h *UserHandler) HandleUpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx := r.Context()
+	prefs, err := h.pgStore.GetUserPreferences(ctx, userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get preferences of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update preferences")
+		return
+	}
+	if err := req.apply(&prefs); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.pgStore.SaveUserPreferences(ctx, userID, prefs); err != nil {
+		log.Printf("[ERROR] Failed to save preferences of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update preferences")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, prefs)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// ownedFile loads a file of the authenticated user, writing the error response if
+// it cannot be used
+func (h *FilesHandler) ownedFile(w http.ResponseWriter, r *http.Request) (string, *storage.FileMetadata, bool) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return "", nil, false
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return "", nil, false
+	}
+
+	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return "", nil, false
+	}
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return "", nil, false
+	}
+	return userID, metadata, true
+}
+
+// HandleGetProcessing returns the status of each upload processing stage of a file
+// (checksum, virus scan, thumbnail, webhook, as configured)
+func (h *FilesHandler) HandleGetProcessing(w http.ResponseWriter, r *http.Request) {
+	_, metadata, ok := h.ownedFile(w, r)
+	if !ok {
+		return
+	}
+
+	stages, err := h.pgStore.GetFileStages(r.Context(), metadata.FileID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get processing status of file %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get processing status")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"file_id": metadata.FileID,
+		"stages":  stages,
+	})
+}
+
+// HandleGetThumbnail serves the JPEG preview made by the thumbnail stage. Files with
+// a download password need it (or a ticket) here too.
+func (h *FilesHandler) HandleGetThumbnail(w http.ResponseWriter, r *http.Request) {
+	userID, metadata, ok := h.ownedFile(w, r)
+	if !ok {
+		return
+	}
+	if !checkFilePassword(w, r, h.auditLogger, userID, metadata) {
+		return
+	}
+
+	data, mimeType, err := h.pgStore.GetThumbnail(r.Context(), metadata.FileID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, r, http.StatusNotFound, "No thumbnail for this file")
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to get thumbnail of file %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get thumbnail")
+		return
+	}
+
+	key, err := h.pgStore.DataKey(r.Context(), metadata)
+	if err != nil {
+		log.Printf("[ERROR] Failed to unwrap encryption key of file %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to decode encryption key")
+		return
+	}
+	thumbnail, err := crypto.DecryptBytes(data, key)
+	if err != nil {
+		log.Printf("[ERROR] Failed to decrypt thumbnail of file %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to decrypt thumbnail")
+		return
+	}
+
+	w.Header().Set("Content-Type", mimeType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(thumbnail)))
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	_, _ = w.Write(thumbnail)
+}
@@ -0,0 +1,356 @@
+package api
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	netmail "net/mail"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/i18n"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// emailVerificationTTL is how long the code mailed to a new address stays valid
+const emailVerificationTTL = 24 * time.Hour
+
+const maxDisplayNameLength = 100
+
+// UpdateProfileRequest changes the caller's profile; omitted fields are kept. A
+// new email needs the current password and only applies once verified.
+type UpdateProfileRequest struct {
+	DisplayName     *string `json:"display_name"`
+	Email           *string `json:"email"`
+	CurrentPassword string  `json:"current_password"`
+}
+
+// VerifyEmailRequest confirms a pending email change
+type VerifyEmailRequest struct {
+	Code string `json:"code"`
+}
+
+// AdminUpdateProfileRequest changes a user's profile without verification
+type AdminUpdateProfileRequest struct {
+	Username    *string `json:"username"`
+	Email       *string `json:"email"`
+	DisplayName *string `json:"display_name"`
+}
+
+// ProfileResponse is a user's profile
+type ProfileResponse struct {
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	DisplayName  string `json:"display_name"`
+	PendingEmail string `json:"pending_email,omitempty"`
+	AvatarURL    string `json:"avatar_url,omitempty"`
+}
+
+func profileResponse(user *storage.User) ProfileResponse {
+	return ProfileResponse{
+		UserID:       user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		DisplayName:  user.DisplayName,
+		PendingEmail: user.PendingEmail,
+		AvatarURL:    avatarURL(user.ID, user.AvatarUpdatedAt),
+	}
+}
+
+// normalizeEmail returns email trimmed, or an error unless it is a bare address
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := netmail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", fmt.Errorf("invalid email address")
+	}
+	return email, nil
+}
+
+// normalizeDisplayName returns name trimmed ("" clears it), or an error when it is
+// too long or has control characters
+func normalizeDisplayName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len([]rune(name)) > maxDisplayNameLength {
+		return "", fmt.Errorf("display name must be at most %d characters", maxDisplayNameLength)
+	}
+	for _, c := range name {
+		if unicode.IsControl(c) {
+			return "", fmt.Errorf("display name must not contain control characters")
+		}
+	}
+	return name, nil
+}
+
+// normalizeUsername returns username, or an error unless it is 3-255 characters
+// without spaces
+func normalizeUsername(username string) (string, error) {
+	username = strings.TrimSpace(username)
+	if len(username) < 3 || len(username) > 255 || strings.ContainsFunc(username, unicode.IsSpace) {
+		return "", fmt.Errorf("username must be 3-255 characters without spaces")
+	}
+	return username, nil
+}
+
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// respondProfileConflict answers ErrUsernameTaken and ErrEmailTaken with 409 and
+// returns true, or returns false for other errors
+func respondProfileConflict(w http.ResponseWriter, r *http.Request, err error) bool {
+	switch {
+	case errors.Is(err, storage.ErrUsernameTaken):
+		respondErrorCode(w, r, http.StatusConflict, apierror.CodeUsernameTaken, "Username already exists")
+	case errors.Is(err, storage.ErrEmailTaken):
+		respondErrorCode(w, r, http.StatusConflict, apierror.CodeEmailTaken, "Email address already in use")
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleUpdateProfile changes the caller's display name and starts an email change:
+// the new address gets a verification code and replaces the current one only once
+// the code is confirmed (POST /user/profile/verify-email)
+func (h *UserHandler) HandleUpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var update storage.ProfileUpdate
+	if req.DisplayName != nil {
+		name, err := normalizeDisplayName(*req.DisplayName)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		update.DisplayName = &name
+	}
+	var newEmail string
+	if req.Email != nil {
+		email, err := normalizeEmail(*req.Email)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid email address")
+			return
+		}
+		newEmail = email
+	}
+
+	ctx := r.Context()
+	user, err := h.pgStore.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get user: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user")
+		return
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		newEmail = ""
+	}
+
+	// An email change can take over account recovery: confirm it is the owner
+	if newEmail != "" {
+		if req.CurrentPassword == "" {
+			respondError(w, r, http.StatusBadRequest, "Current password is required to change the email address")
+			return
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+			respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidCredentials, "Current password is incorrect")
+			return
+		}
+	}
+
+	if err := h.pgStore.UpdateUserProfile(ctx, userID, update); err != nil {
+		log.Printf("[ERROR] Failed to update profile of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update profile")
+		return
+	}
+
+	if newEmail != "" {
+		code, err := auth.GenerateToken()
+		if err != nil {
+			respondError(w, r, http.StatusInternalServerError, "Failed to start email change")
+			return
+		}
+		err = h.pgStore.RequestEmailChange(ctx, userID, newEmail, hashVerificationCode(code), time.Now().Add(emailVerificationTTL))
+		if err != nil {
+			if !respondProfileConflict(w, r, err) {
+				log.Printf("[ERROR] Failed to record email change of %s: %v", userID, err)
+				respondError(w, r, http.StatusInternalServerError, "Failed to start email change")
+			}
+			return
+		}
+
+		catalog := i18n.Default()
+		lang := catalog.ForLocale(userPreferences(ctx, h.pgStore, userID).Locale)
+		body := catalog.Text(lang, "email.verify.body", "username", user.Username, "email", newEmail,
+			"code", code, "ttl", emailVerificationTTL.String())
+		if err := h.mailer.Send(ctx, newEmail, catalog.Text(lang, "email.verify.subject"), body); err != nil {
+			log.Printf("[ERROR] Failed to send email verification to %s: %v", newEmail, err)
+			respondError(w, r, http.StatusBadGateway, "Failed to send the verification email")
+			return
+		}
+		log.Printf("[INFO] Email change to %s requested by %s", newEmail, userID)
+	}
+
+	h.respondProfile(w, r, userID)
+}
+
+// HandleVerifyEmail confirms a pending email change with the code mailed to the
+// new address
+func (h *UserHandler) HandleVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		respondError(w, r, http.StatusBadRequest, "Verification code is required")
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.pgStore.GetUserByID(ctx, userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user")
+		return
+	}
+
+	email, err := h.pgStore.ConfirmEmailChange(ctx, userID, hashVerificationCode(strings.TrimSpace(req.Code)))
+	if errors.Is(err, storage.ErrVerificationInvalid) {
+		respondErrorCode(w, r, http.StatusBadRequest, apierror.CodeVerificationInvalid, "Invalid or expired verification code")
+		return
+	}
+	if err != nil {
+		if !respondProfileConflict(w, r, err) {
+			log.Printf("[ERROR] Failed to confirm email change of %s: %v", userID, err)
+			respondError(w, r, http.StatusInternalServerError, "Failed to change email address")
+		}
+		return
+	}
+	log.Printf("[INFO] Email of %s changed to %s", userID, email)
+
+	// Tell the previous address, in case the change was not the owner's doing
+	if user.Email != "" {
+		catalog := i18n.Default()
+		lang := catalog.ForLocale(userPreferences(ctx, h.pgStore, userID).Locale)
+		body := catalog.Text(lang, "email.changed.body", "username", user.Username, "email", email)
+		if err := h.mailer.Send(ctx, user.Email, catalog.Text(lang, "email.changed.subject"), body); err != nil {
+			log.Printf("[WARN] Failed to notify %s of the email change: %v", user.Email, err)
+		}
+	}
+
+	h.respondProfile(w, r, userID)
+}
+
+func (h *UserHandler) respondProfile(w http.ResponseWriter, r *http.Request, userID string) {
+	user, err := h.pgStore.GetUserByID(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user")
+		return
+	}
+	respondJSON(w, http.StatusOK, profileResponse(user))
+}
+
+// HandleUpdateUserProfile lets an admin change a user's username, email and
+// display name directly (no verification); a pending email change is dropped
+func (h *AdminHandler) HandleUpdateUserProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+	adminID := ctx.Value(constants.UserIDKey).(string)
+
+	var req AdminUpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var update storage.ProfileUpdate
+	var err error
+	if req.Username != nil {
+		username, err := normalizeUsername(*req.Username)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		update.Username = &username
+	}
+	if req.Email != nil {
+		email, err := normalizeEmail(*req.Email)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid email address")
+			return
+		}
+		update.Email = &email
+	}
+	if req.DisplayName != nil {
+		name, err := normalizeDisplayName(*req.DisplayName)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		update.DisplayName = &name
+	}
+
+	before, err := h.pg.GetUserByID(ctx, userID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+
+	err = h.pg.UpdateUserProfile(ctx, userID, update)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		if !respondProfileConflict(w, r, err) {
+			log.Printf("[admin] Failed to update profile of %s: %v", userID, err)
+			respondError(w, r, http.StatusInternalServerError, "Failed to update profile")
+		}
+		return
+	}
+
+	changes := map[string]interface{}{"username": before.Username}
+	if update.Username != nil && *update.Username != before.Username {
+		changes["new_username"] = *update.Username
+	}
+	if update.Email != nil && *update.Email != before.Email {
+		changes["old_email"], changes["new_email"] = before.Email, *update.Email
+	}
+	if update.DisplayName != nil && *update.DisplayName != before.DisplayName {
+		changes["display_name"] = *update.DisplayName
+	}
+	_ = h.auditLogger.LogAdminAction(ctx, adminID, "USER_PROFILE_UPDATED", "user", userID, changes, GetClientIP(r))
+	log.Printf("[admin] Profile of %s updated by %s", before.Username, adminID)
+
+	user, err := h.pg.GetUserByID(ctx, userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user")
+		return
+	}
+	respondJSON(w, http.StatusOK, profileResponse(user))
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// QuarantinedFile is an upload awaiting review
+type QuarantinedFile struct {
+	FileID        string    `json:"file_id"`
+	FileName      string    `json:"file_name"`
+	UserID        string    `json:"user_id"`
+	Username      string    `json:"username"`
+	MimeType      string    `json:"mime_type"`
+	Size          int64     `json:"size"`
+	SHA256        string    `json:"sha256,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+	Reason        string    `json:"reason"` // the rule that matched
+}
+
+// HandleListQuarantine returns the uploads held back for review, oldest first
+func (h *AdminHandler) HandleListQuarantine(w http.ResponseWriter, r *http.Request) {
+	files, err := h.pg.ListQuarantinedFiles(r.Context())
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list quarantined files")
+		return
+	}
+
+	usernames := map[string]string{}
+	result := make([]QuarantinedFile, 0, len(files))
+	for _, f := range files {
+		username, known := usernames[f.UserID]
+		if !known {
+			if user, err := h.pg.GetUserByID(r.Context(), f.UserID); err == nil {
+				username = user.Username
+			}
+			usernames[f.UserID] = username
+		}
+		result = append(result, QuarantinedFile{
+			FileID:        f.FileID,
+			FileName:      f.FileName,
+			UserID:        f.UserID,
+			Username:      username,
+			MimeType:      f.MimeType,
+			Size:          f.Size,
+			SHA256:        f.SHA256,
+			CreatedAt:     f.CreatedAt,
+			QuarantinedAt: *f.QuarantinedAt,
+			Reason:        f.QuarantineReason,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"files": result})
+}
+
+// HandleApproveQuarantined releases a quarantined file: share links serve it again
+func (h *AdminHandler) HandleApproveQuarantined(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	fileID := chi.URLParam(r, "id")
+
+	file, err := h.pg.ReleaseFile(r.Context(), fileID)
+	if errors.Is(err, storage.ErrFileNotFound) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "No such file in quarantine")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to approve file")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "QUARANTINE_APPROVED", "file", fileID, map[string]interface{}{
+		"filename": file.FileName,
+		"owner_id": file.UserID,
+	}, GetClientIP(r))
+	log.Printf("[admin] Quarantined file %s approved by %s", fileID, adminID)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "File approved",
+		"file_id": fileID,
+	})
+}
+
+// HandleRejectQuarantined deletes a quarantined file for good, content included
+func (h *AdminHandler) HandleRejectQuarantined(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	fileID := chi.URLParam(r, "id")
+
+	file, err := h.pg.GetQuarantinedFile(r.Context(), fileID)
+	if errors.Is(err, storage.ErrFileNotFound) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "No such file in quarantine")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to reject file")
+		return
+	}
+
+	if err := h.pg.DeleteFileMetadata(r.Context(), fileID); err != nil {
+		log.Printf("[admin] Failed to delete quarantined file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to reject file")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+	if err := h.minioStore.DeleteFile(r.Context(), file.MinIOPath); err != nil {
+		// Left for storage cleanup, which deletes objects without a file
+		log.Printf("[admin] Failed to delete object of rejected file %s: %v", fileID, err)
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "QUARANTINE_REJECTED", "file", fileID, map[string]interface{}{
+		"filename": file.FileName,
+		"owner_id": file.UserID,
+		"reason":   file.QuarantineReason,
+	}, GetClientIP(r))
+	log.Printf("[admin] Quarantined file %s rejected by %s", fileID, adminID)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "File rejected and deleted",
+		"file_id": fileID,
+	})
+}
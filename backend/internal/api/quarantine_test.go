@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// newTestRedis returns a RedisCache backed by an in-memory Redis
+func newTestRedis(t *testing.T) *storage.RedisCache {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	cache, err := storage.NewRedisCache(mr.Addr(), "", 0, storage.RedisPool{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cache
+}
+
+// withURLParam adds a chi URL parameter to r
+func withURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+// Quarantined files must not be reachable anonymously: no new share links, no
+// new signed URLs, and signed URLs issued before the quarantine stop working.
+// The metadata is served from the Redis cache, so no handler reaches Postgres.
+func TestQuarantinedFileNotShared(t *testing.T) {
+	const owner = "user-1"
+	cache := newTestRedis(t)
+	guard := auth.NewTokenGuard(cache, 0, 0, 0)
+	signer := auth.NewURLSigner("test-secret")
+
+	quarantinedAt := time.Now().Add(-time.Minute)
+	file := &storage.FileMetadata{
+		FileID:           "file-1",
+		UserID:           owner,
+		FileName:         "report.pdf",
+		UpdatedAt:        time.Now().Add(-time.Hour),
+		QuarantinedAt:    &quarantinedAt,
+		QuarantineReason: "rule: executables",
+	}
+	if err := cache.CacheFileMetadata(context.Background(), file); err != nil {
+		t.Fatal(err)
+	}
+
+	shares := NewShareHandler(nil, cache, nil, 0, 0, guard, nil, nil)
+	downloads := NewDownloadHandler(nil, cache, nil, signer, 0, 0, guard)
+
+	asOwner := func(r *http.Request) *http.Request {
+		return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, owner))
+	}
+	signed := signer.Sign(auth.SignedDownload{
+		FileID:      file.FileID,
+		UserID:      owner,
+		Version:     file.UpdatedAt.UnixNano(),
+		Disposition: "attachment",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		request *http.Request
+	}{
+		{
+			"create share link",
+			shares.HandleCreateShare,
+			asOwner(withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/files/file-1/share", strings.NewReader(`{}`)), "fileID", file.FileID)),
+		},
+		{
+			"create signed download URL",
+			downloads.HandleCreateDownloadURL,
+			asOwner(withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/files/file-1/download-url", strings.NewReader(`{}`)), "fileID", file.FileID)),
+		},
+		{
+			"download through a signed URL",
+			downloads.HandleSignedDownload,
+			withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/dl/"+signed, nil), "token", signed),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler(rec, tt.request)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusNotFound, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), "FILE_NOT_FOUND") {
+				t.Errorf("body = %s, want FILE_NOT_FOUND", rec.Body.String())
+			}
+		})
+	}
+}
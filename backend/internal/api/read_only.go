@@ -0,0 +1,130 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// ReadOnly rejects file writes (uploads, deletes, metadata changes) with 403 while
+// the global read-only switch or the user's own is on. Downloads are not affected:
+// mount it on write routes only, after authentication.
+func ReadOnly(pg *storage.PostgresStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, _ := r.Context().Value(constants.UserIDKey).(string)
+			state, err := pg.GetReadOnly(r.Context(), userID)
+			if err != nil {
+				log.Printf("[ERROR] Failed to check read-only mode for %s: %v", userID, err)
+				respondError(w, r, http.StatusInternalServerError, "Failed to check read-only mode")
+				return
+			}
+			if state.Blocked() {
+				respondReadOnly(w, r, state)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func respondReadOnly(w http.ResponseWriter, r *http.Request, state storage.ReadOnlyState) {
+	message := "Your files are read-only; uploads, deletes and changes are disabled. Contact an administrator."
+	if state.Global {
+		message = "File Locker is read-only right now; uploads, deletes and changes are disabled. Downloads still work."
+	}
+	apierror.Write(w, r, apierror.New(http.StatusForbidden, apierror.CodeReadOnly, message).With("read_only", state))
+}
+
+// HandleGetReadOnly returns the global read-only switch and the users whose own
+// switch is on
+func (h *AdminHandler) HandleGetReadOnly(w http.ResponseWriter, r *http.Request) {
+	state, err := h.pg.GetReadOnly(r.Context(), "")
+	if err != nil {
+		log.Printf("[admin] Failed to get read-only mode: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get read-only mode")
+		return
+	}
+	users, err := h.pg.ListReadOnlyUsers(r.Context())
+	if err != nil {
+		log.Printf("[admin] Failed to list read-only users: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get read-only mode")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"enabled": state.Global,
+		"users":   users,
+	})
+}
+
+// HandleSetReadOnly turns the global read-only switch on or off
+func (h *AdminHandler) HandleSetReadOnly(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+		respondError(w, r, http.StatusBadRequest, "Request body must set enabled")
+		return
+	}
+
+	if err := h.pg.SetGlobalReadOnly(r.Context(), *req.Enabled, adminID); err != nil {
+		log.Printf("[admin] Failed to set read-only mode: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to set read-only mode")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "READ_ONLY_MODE_UPDATED", "system", "", map[string]interface{}{
+		"enabled": *req.Enabled,
+	}, GetClientIP(r))
+	log.Printf("[admin] Global read-only mode set to %t by %s", *req.Enabled, adminID)
+
+	h.HandleGetReadOnly(w, r)
+}
+
+// HandleSetUserReadOnly turns the read-only switch of one user on or off
+func (h *AdminHandler) HandleSetUserReadOnly(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		ReadOnly *bool `json:"read_only"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ReadOnly == nil {
+		respondError(w, r, http.StatusBadRequest, "Request body must set read_only")
+		return
+	}
+
+	err := h.pg.SetUserReadOnly(r.Context(), userID, *req.ReadOnly)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] Failed to set read-only for user %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update user")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "USER_READ_ONLY_UPDATED", "user", userID, map[string]interface{}{
+		"read_only": *req.ReadOnly,
+	}, GetClientIP(r))
+	log.Printf("[admin] Read-only for user %s set to %t by %s", userID, *req.ReadOnly, adminID)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":   "User updated successfully",
+		"user_id":   userID,
+		"read_only": *req.ReadOnly,
+	})
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// forwardingHeaders name the client of a request that came through a proxy
+var forwardingHeaders = []string{"X-Forwarded-For", "X-Real-IP", "True-Client-IP"}
+
+// RealIP resolves the client address into RemoteAddr, like chi's RealIP, but
+// believes the forwarding headers only when the connection comes from one of
+// trustedProxies (CIDRs or addresses). From any other peer those headers, and
+// the headers in proxyOnly (e.g. the country header), are removed, so nothing
+// later in the chain (IP rules, service key CIDRs, rate limits, token lockouts,
+// audit log) can be fooled by a client setting them. Mount it first.
+func RealIP(trustedProxies []string, proxyOnly ...string) (func(http.Handler) http.Handler, error) {
+	trusted, err := parsePrefixes(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !containsPeer(trusted, r.RemoteAddr) {
+				for _, h := range forwardingHeaders {
+					r.Header.Del(h)
+				}
+				for _, h := range proxyOnly {
+					if h != "" {
+						r.Header.Del(h)
+					}
+				}
+			} else if ip := forwardedClient(r, trusted); ip != "" {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func containsPeer(trusted []netip.Prefix, remoteAddr string) bool {
+	if len(trusted) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && containsAddr(trusted, addr.Unmap())
+}
+
+// forwardedClient returns the client named by a trusted proxy. X-Forwarded-For is
+// read from the right: each proxy appends the address it got the request from, so
+// the first untrusted entry is the client and anything left of it is client input.
+func forwardedClient(r *http.Request, trusted []netip.Prefix) string {
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		client := ""
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			client = addr.Unmap().String()
+			if !containsAddr(trusted, addr.Unmap()) {
+				return client
+			}
+		}
+		if client != "" {
+			return client // every hop is a trusted proxy
+		}
+	}
+	for _, h := range forwardingHeaders[1:] {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get(h))); err == nil {
+			return addr.Unmap().String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/pkg/client"
+)
+
+// remoteCheckTimeout bounds the request that checks a new remote's URL and token
+const remoteCheckTimeout = 10 * time.Second
+
+// HandleListReplicationRemotes returns the remotes files are replicated to, with
+// how many files each has
+func (h *AdminHandler) HandleListReplicationRemotes(w http.ResponseWriter, r *http.Request) {
+	remotes, err := h.pg.ListReplicationRemotes(r.Context())
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list remotes")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{"remotes": remotes})
+}
+
+// HandleCreateReplicationRemote adds another File Locker instance to replicate to:
+// its API root and a personal access token (files:write) of the account on it that
+// receives the files. The token is checked against the remote before it is stored.
+func (h *AdminHandler) HandleCreateReplicationRemote(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		Name  string `json:"name"`
+		URL   string `json:"url"`
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.URL = strings.TrimSuffix(strings.TrimSpace(req.URL), "/")
+	if req.Name == "" || req.Token == "" {
+		respondError(w, r, http.StatusBadRequest, "Name and token required")
+		return
+	}
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		respondError(w, r, http.StatusBadRequest, "URL must be an http(s) URL of the remote's API, e.g. https://backup.example.com/api/v1")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), remoteCheckTimeout)
+	defer cancel()
+	account, err := client.New(req.URL, req.Token).Me(ctx)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Remote rejected the request: "+err.Error())
+		return
+	}
+
+	remote := &storage.ReplicationRemote{Name: req.Name, URL: req.URL, Token: req.Token, CreatedBy: adminID}
+	err = h.pg.CreateReplicationRemote(r.Context(), remote)
+	if errors.Is(err, storage.ErrRemoteNameTaken) {
+		respondErrorCode(w, r, http.StatusConflict, apierror.CodeConflict, "A remote with this name already exists")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create remote")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "REPLICATION_REMOTE_CREATED", "replication_remote", remote.ID, map[string]interface{}{
+		"name":            remote.Name,
+		"url":             remote.URL,
+		"remote_username": account.Username,
+	}, GetClientIP(r))
+	log.Printf("[admin] Replication remote %s (%s) created by %s", remote.Name, remote.URL, adminID)
+
+	respondJSON(w, http.StatusCreated, remote)
+}
+
+// HandleDeleteReplicationRemote stops replicating to a remote. Files already on it
+// stay there; queued replications of it fail.
+func (h *AdminHandler) HandleDeleteReplicationRemote(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	remoteID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(remoteID); err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeNotFound, "Remote not found")
+		return
+	}
+
+	err := h.pg.DeleteReplicationRemote(r.Context(), remoteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeNotFound, "Remote not found")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete remote")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "REPLICATION_REMOTE_DELETED", "replication_remote", remoteID, nil, GetClientIP(r))
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Remote deleted"})
+}
+
+// HandleReplicate queues the replication of files to a remote: those in file_ids,
+// or all files of user_id. Files the remote already has are skipped, so repeating
+// a sync only sends what is new.
+func (h *AdminHandler) HandleReplicate(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	remoteID := chi.URLParam(r, "id")
+
+	var req struct {
+		UserID  string   `json:"user_id"`
+		FileIDs []string `json:"file_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if (req.UserID == "") == (len(req.FileIDs) == 0) {
+		respondError(w, r, http.StatusBadRequest, "Either user_id or file_ids required")
+		return
+	}
+	for _, id := range req.FileIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid file ID: "+id)
+			return
+		}
+	}
+
+	if _, err := uuid.Parse(remoteID); err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeNotFound, "Remote not found")
+		return
+	}
+	remote, err := h.pg.GetReplicationRemote(r.Context(), remoteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeNotFound, "Remote not found")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] Failed to get remote %s: %v", remoteID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get remote")
+		return
+	}
+	if req.UserID != "" {
+		if _, err := uuid.Parse(req.UserID); err != nil {
+			respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+			return
+		}
+		if _, err := h.pg.GetUserByID(r.Context(), req.UserID); err != nil {
+			respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+			return
+		}
+	}
+
+	fileIDs, err := h.pg.UnreplicatedFiles(r.Context(), remote.ID, req.UserID, req.FileIDs)
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list files")
+		return
+	}
+	queued := 0
+	for _, id := range fileIDs {
+		if _, err := h.jobQueue.Enqueue(r.Context(), jobs.TypeFileReplicate, jobs.ReplicatePayload{RemoteID: remote.ID, FileID: id}); err != nil {
+			log.Printf("[admin] Failed to queue replication of file %s: %v", id, err)
+			continue
+		}
+		queued++
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "REPLICATION_STARTED", "replication_remote", remote.ID, map[string]interface{}{
+		"remote":   remote.Name,
+		"user_id":  req.UserID,
+		"file_ids": req.FileIDs,
+		"queued":   queued,
+	}, GetClientIP(r))
+
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "Replication queued",
+		"queued":  queued,
+	})
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDHeader carries the request ID to the client (and, optionally, from it)
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds request IDs sent by clients or proxies
+const maxRequestIDLength = 128
+
+// RequestID gives every request an ID (chi's middleware.RequestID) and returns it in
+// the X-Request-ID response header. An ID sent by the client or a proxy is kept when
+// it is short and made of safe characters, so it can be traced across services;
+// anything else is replaced.
+func RequestID(next http.Handler) http.Handler {
+	withHeader := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r)
+	})
+	assign := middleware.RequestID(withHeader)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(middleware.RequestIDHeader); id != "" && !validRequestID(id) {
+			r.Header.Del(middleware.RequestIDHeader)
+		}
+		assign.ServeHTTP(w, r)
+	})
+}
+
+func validRequestID(id string) bool {
+	if len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == '/' || c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog writes one structured entry per request, tagged with its request ID.
+// Mount it after RequestID (and RealIP, for the client address).
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			switch {
+			case status >= 500:
+				level = slog.LevelError
+			case status >= 400:
+				level = slog.LevelWarn
+			}
+
+			logger.LogAttrs(r.Context(), level, "HTTP request",
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+				slog.String("remote_ip", GetClientIP(r)),
+			)
+		})
+	}
+}
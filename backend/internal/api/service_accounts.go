@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// HandleListServiceAccounts returns all service accounts
+func (h *AdminHandler) HandleListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.pg.ListServiceAccounts(context.Background())
+	if err != nil {
+		log.Printf("[admin] Failed to list service accounts: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get service accounts")
+		return
+	}
+
+	if accounts == nil {
+		accounts = []*storage.ServiceAccount{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"service_accounts": accounts,
+	})
+}
+
+// HandleCreateServiceAccount creates a new service account (no keys yet)
+func (h *AdminHandler) HandleCreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Name) < 3 {
+		respondError(w, r, http.StatusBadRequest, "Name must be at least 3 characters")
+		return
+	}
+
+	exists, err := h.pg.UserExists(ctx, req.Name)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to create service account")
+		return
+	}
+	if exists {
+		respondError(w, r, http.StatusConflict, "Name already in use")
+		return
+	}
+
+	account, err := h.pg.CreateServiceAccount(ctx, req.Name)
+	if err != nil {
+		log.Printf("[admin] Failed to create service account: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create service account")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(ctx, adminID, "SERVICE_ACCOUNT_CREATED", "user", account.ID, map[string]interface{}{
+		"name": account.Name,
+	}, GetClientIP(r))
+
+	log.Printf("[admin] Service account %s (%s) created by %s", account.Name, account.ID, adminID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(account)
+}
+
+// HandleCreateServiceAccountKey issues a new API key for a service account.
+// The raw key is only returned in this response.
+func (h *AdminHandler) HandleCreateServiceAccountKey(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	accountID := chi.URLParam(r, "id")
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		Name          string   `json:"name"`
+		Scopes        []string `json:"scopes"`
+		AllowedCIDRs  []string `json:"allowed_cidrs"`
+		ExpiresInDays int      `json:"expires_in_days"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Name == "" {
+		respondError(w, r, http.StatusBadRequest, "Key name required")
+		return
+	}
+	if len(req.Scopes) == 0 {
+		respondError(w, r, http.StatusBadRequest, "At least one scope required")
+		return
+	}
+	for _, s := range req.Scopes {
+		if !auth.ValidScopes[s] {
+			respondError(w, r, http.StatusBadRequest, "Invalid scope: "+s)
+			return
+		}
+	}
+	// Keys are always bound to source networks; use 0.0.0.0/0 and ::/0 to allow any
+	if len(req.AllowedCIDRs) == 0 {
+		respondError(w, r, http.StatusBadRequest, "At least one allowed CIDR required")
+		return
+	}
+	for i, c := range req.AllowedCIDRs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid CIDR: "+c)
+			return
+		}
+		req.AllowedCIDRs[i] = n.String()
+	}
+	if req.ExpiresInDays < 0 {
+		respondError(w, r, http.StatusBadRequest, "expires_in_days must not be negative")
+		return
+	}
+
+	account, err := h.pg.GetServiceAccount(ctx, accountID)
+	if err != nil {
+		respondError(w, r, http.StatusNotFound, "Service account not found")
+		return
+	}
+
+	rawKey, lookupPrefix, err := auth.GenerateServiceKey()
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate key")
+		return
+	}
+
+	key := &storage.ServiceAccountKey{
+		ServiceAccountID: account.ID,
+		Name:             req.Name,
+		KeyPrefix:        lookupPrefix,
+		Scopes:           req.Scopes,
+		AllowedCIDRs:     req.AllowedCIDRs,
+		CreatedBy:        adminID,
+	}
+	if req.ExpiresInDays > 0 {
+		t := time.Now().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour).UTC()
+		key.ExpiresAt = &t
+	}
+
+	if err := h.pg.CreateServiceAccountKey(ctx, key, rawKey); err != nil {
+		log.Printf("[admin] Failed to create service account key: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create key")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(ctx, adminID, "SERVICE_KEY_CREATED", "user", account.ID, map[string]interface{}{
+		"service_account": account.Name,
+		"key_id":          key.ID,
+		"key_name":        key.Name,
+		"scopes":          key.Scopes,
+		"allowed_cidrs":   key.AllowedCIDRs,
+	}, GetClientIP(r))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"key":     key,
+		"api_key": rawKey,
+	})
+}
+
+// HandleListServiceAccountKeys lists the keys of a service account (never the raw keys)
+func (h *AdminHandler) HandleListServiceAccountKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	accountID := chi.URLParam(r, "id")
+
+	if _, err := h.pg.GetServiceAccount(ctx, accountID); err != nil {
+		respondError(w, r, http.StatusNotFound, "Service account not found")
+		return
+	}
+
+	keys, err := h.pg.ListServiceAccountKeys(ctx, accountID)
+	if err != nil {
+		log.Printf("[admin] Failed to list service account keys: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get keys")
+		return
+	}
+
+	if keys == nil {
+		keys = []*storage.ServiceAccountKey{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": keys,
+	})
+}
+
+// HandleRevokeServiceAccountKey revokes a service account key
+func (h *AdminHandler) HandleRevokeServiceAccountKey(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	accountID := chi.URLParam(r, "id")
+	keyID := chi.URLParam(r, "keyID")
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	if err := h.pg.RevokeServiceAccountKey(ctx, accountID, keyID); err != nil {
+		respondError(w, r, http.StatusNotFound, "Key not found")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(ctx, adminID, "SERVICE_KEY_REVOKED", "user", accountID, map[string]interface{}{
+		"key_id": keyID,
+	}, GetClientIP(r))
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Key revoked successfully",
+	})
+}
+
+// HandleDeleteServiceAccount deletes a service account, its keys and its files
+func (h *AdminHandler) HandleDeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.pg.GetServiceAccount(context.Background(), chi.URLParam(r, "id")); err != nil {
+		respondError(w, r, http.StatusNotFound, "Service account not found")
+		return
+	}
+
+	h.HandleDeleteUser(w, r)
+}
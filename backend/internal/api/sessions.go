@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// userSessions are the live sessions of one user. Sessions are orphaned when their
+// user was deleted or suspended: they can no longer be used, but they are worth a
+// look (and a revoke) after an incident.
+type userSessions struct {
+	UserID   string                `json:"user_id"`
+	Username string                `json:"username,omitempty"`
+	Orphaned bool                  `json:"orphaned"`
+	Reason   string                `json:"reason,omitempty"` // user_deleted or user_suspended
+	Sessions []storage.SessionInfo `json:"sessions"`
+}
+
+// HandleListSessions lists the live sessions by user, orphaned ones included
+func (h *AdminHandler) HandleListSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sessions, err := h.redisCache.ListSessions(ctx)
+	if err != nil {
+		log.Printf("[admin] Failed to list sessions: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list sessions")
+		return
+	}
+
+	byUser := make(map[string]*userSessions)
+	for _, s := range sessions {
+		us, ok := byUser[s.UserID]
+		if !ok {
+			us = &userSessions{UserID: s.UserID}
+			byUser[s.UserID] = us
+		}
+		us.Sessions = append(us.Sessions, s)
+	}
+
+	users := make([]*userSessions, 0, len(byUser))
+	orphaned := 0
+	for _, us := range byUser {
+		user, err := h.pg.GetUserByID(ctx, us.UserID)
+		switch {
+		case err != nil:
+			us.Orphaned, us.Reason = true, "user_deleted"
+		case !user.IsActive:
+			us.Username = user.Username
+			us.Orphaned, us.Reason = true, "user_suspended"
+		default:
+			us.Username = user.Username
+		}
+		if us.Orphaned {
+			orphaned += len(us.Sessions)
+		}
+		sort.Slice(us.Sessions, func(i, j int) bool { return us.Sessions[i].ExpiresAt.After(us.Sessions[j].ExpiresAt) })
+		users = append(users, us)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Orphaned != users[j].Orphaned {
+			return users[i].Orphaned
+		}
+		return users[i].Username < users[j].Username
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"users":             users,
+		"total_sessions":    len(sessions),
+		"orphaned_sessions": orphaned,
+		"signing_key":       h.jwtService.ActiveKeyID(ctx),
+	})
+}
+
+// HandleRevokeAllSessions is the panic button after a JWT secret leak: it rotates
+// the signing key to the next one of the key ring and refuses every earlier key at
+// once (no retiring period), and deletes every session, logging everyone out (the caller included).
+// Sessions are deleted even when the ring has no spare key left.
+func (h *AdminHandler) HandleRevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	response := map[string]interface{}{}
+	keyID, err := h.jwtService.Rotate(ctx, adminID, true)
+	switch {
+	case errors.Is(err, auth.ErrKeyRingExhausted):
+		keyID = h.jwtService.ActiveKeyID(ctx)
+		response["warning"] = "Signing key not rotated: add a new key to security.jwt_keys and restart, then revoke again"
+	case err != nil:
+		log.Printf("[admin] Failed to rotate the signing key: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to rotate the signing key")
+		return
+	}
+	rotated := err == nil
+
+	count, err := h.redisCache.DeleteAllSessions(ctx)
+	if err != nil {
+		log.Printf("[admin] Failed to revoke all sessions (%d revoked): %v", count, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to revoke all sessions")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(ctx, adminID, "SESSIONS_REVOKED_ALL", "system", "", map[string]interface{}{
+		"sessions_revoked": count,
+		"key_rotated":      rotated,
+		"signing_key":      keyID,
+	}, GetClientIP(r))
+	log.Printf("[admin] All sessions revoked (%d) by %s; signing key %s (rotated: %t)", count, adminID, keyID, rotated)
+
+	response["message"] = "All sessions revoked"
+	response["sessions_revoked"] = count
+	response["key_rotated"] = rotated
+	response["signing_key"] = keyID
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleListSigningKeys lists the JWT key ring (IDs and status, never secrets)
+func (h *AdminHandler) HandleListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": h.jwtService.Keys(r.Context()),
+	})
+}
+
+// HandleRotateSigningKey makes the next key of the ring sign new tokens. The
+// previous key keeps verifying the tokens it signed until they expire, so nobody
+// is logged out.
+func (h *AdminHandler) HandleRotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	previous := h.jwtService.ActiveKeyID(ctx)
+	keyID, err := h.jwtService.Rotate(ctx, adminID, false)
+	if errors.Is(err, auth.ErrKeyRingExhausted) {
+		respondError(w, r, http.StatusConflict, "No spare signing key: add a new key to security.jwt_keys and restart first")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] Failed to rotate the signing key: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to rotate the signing key")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(ctx, adminID, "SIGNING_KEY_ROTATED", "system", "", map[string]interface{}{
+		"signing_key":  keyID,
+		"previous_key": previous,
+		"trigger":      "admin",
+	}, GetClientIP(r))
+	log.Printf("[admin] Signing key rotated from %s to %s by %s", previous, keyID, adminID)
+
+	h.HandleListSigningKeys(w, r)
+}
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// ReportCategories are the reasons a share link can be reported for
+var ReportCategories = []string{"copyright", "malware", "illegal", "harassment", "spam", "other"}
+
+const (
+	// defaultReportsPerHour limits the reports one address may file when
+	// features.shares.reports_per_hour is not set
+	defaultReportsPerHour = 5
+	maxReportMessage      = 5000 // characters
+	maxReportBody         = 16 << 10
+)
+
+// ShareReportRequest is an abuse report about a share link
+type ShareReportRequest struct {
+	Category string `json:"category"`
+	Message  string `json:"message,omitempty"`
+	Email    string `json:"email,omitempty"` // to contact the reporter, e.g. for a DMCA notice
+}
+
+// HandleReportShare files an abuse report about a public share link (no
+// authentication). Reports wait for an admin at GET /admin/reports; the link
+// keeps working until one disables it.
+func (h *ShareHandler) HandleReportShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Reports count as guesses too, so they cannot be used to probe for tokens
+	if !h.guard.Allow(w, r, auth.GuardShare) {
+		return
+	}
+
+	var req ShareReportRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReportBody)).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Message = strings.TrimSpace(req.Message)
+	req.Email = strings.TrimSpace(req.Email)
+	if !slices.Contains(ReportCategories, req.Category) {
+		apierror.Write(w, r, apierror.New(http.StatusBadRequest, apierror.CodeBadRequest, "Invalid category").
+			With("categories", ReportCategories))
+		return
+	}
+	if len([]rune(req.Message)) > maxReportMessage {
+		respondError(w, r, http.StatusBadRequest, "Message too long")
+		return
+	}
+	if req.Email != "" {
+		if addr, err := mail.ParseAddress(req.Email); err != nil || addr.Address != req.Email || len(req.Email) > 255 {
+			respondError(w, r, http.StatusBadRequest, "Invalid email address")
+			return
+		}
+	}
+
+	token := chi.URLParam(r, "token")
+	var link *storage.ShareLink
+	if auth.WellFormedToken(token) {
+		link, _ = h.pgStore.GetShareLinkByToken(ctx, token)
+	}
+	if link == nil || !auth.TokenEqual(link.Token, token) {
+		h.guard.Fail(ctx, r, auth.GuardShare)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeShareNotFound, "Share link not found")
+		return
+	}
+
+	count, err := h.redisCache.IncrShareReports(ctx, clientIP(r), time.Hour)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count share reports of %s: %v", clientIP(r), err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to file report")
+		return
+	}
+	if count > int64(h.reportLimit) {
+		apierror.Write(w, r, apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, "Too many reports; try again later").
+			With("retry_after", int(time.Hour.Seconds())))
+		return
+	}
+
+	report := &storage.ShareReport{
+		ShareID:       link.ID,
+		Category:      req.Category,
+		Message:       req.Message,
+		ReporterEmail: req.Email,
+		IPAddress:     clientIP(r),
+	}
+	if err := h.pgStore.CreateShareReport(ctx, report); err != nil {
+		log.Printf("[ERROR] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to file report")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(ctx, "", "SHARE_REPORTED", "share", link.ID, map[string]interface{}{
+		"report_id": report.ID,
+		"file_id":   link.FileID,
+		"category":  report.Category,
+	}, clientIP(r))
+	log.Printf("[WARN] Share link %s reported (%s) from %s", link.ID, report.Category, clientIP(r))
+	text := fmt.Sprintf("Share link %s of file %s was reported for %s.", link.ID, link.FileID, report.Category)
+	if message := []rune(report.Message); len(message) > 500 {
+		text += "\n\n" + string(message[:500]) + "…"
+	} else if len(message) > 0 {
+		text += "\n\n" + report.Message
+	}
+	h.notifier.Notify(ctx, notify.EventShareReported, notify.Message{
+		Title: "Share link reported",
+		Text:  text + "\n\nReview it with fl admin reports (report " + report.ID + ").",
+	})
+
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message":   "Report received",
+		"report_id": report.ID,
+	})
+}
+
+// ShareReportInfo is a report with the file and owner it concerns, for review
+type ShareReportInfo struct {
+	*storage.ShareReport
+	FileName      string `json:"file_name"`
+	OwnerUsername string `json:"owner_username"`
+}
+
+// HandleListShareReports returns the share reports with ?status= (open by
+// default; "all" for every report), oldest first
+func (h *AdminHandler) HandleListShareReports(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = storage.ReportOpen
+	case "all":
+		status = ""
+	case storage.ReportOpen, storage.ReportDismissed, storage.ReportActioned:
+	default:
+		respondError(w, r, http.StatusBadRequest, "status must be open, dismissed, actioned or all")
+		return
+	}
+
+	reports, err := h.pg.ListShareReports(r.Context(), status)
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list reports")
+		return
+	}
+
+	fileNames := map[string]string{}
+	usernames := map[string]string{}
+	result := make([]ShareReportInfo, 0, len(reports))
+	for _, report := range reports {
+		name, known := fileNames[report.FileID]
+		if !known {
+			if file, err := h.pg.GetFileMetadata(r.Context(), report.FileID); err == nil {
+				name = file.FileName
+			}
+			fileNames[report.FileID] = name
+		}
+		username, known := usernames[report.OwnerID]
+		if !known {
+			if user, err := h.pg.GetUserByID(r.Context(), report.OwnerID); err == nil {
+				username = user.Username
+			}
+			usernames[report.OwnerID] = username
+		}
+		result = append(result, ShareReportInfo{ShareReport: report, FileName: name, OwnerUsername: username})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"reports": result})
+}
+
+// HandleResolveShareReport closes an open report: {"action": "disable"} disables
+// the share link (closing the other open reports about it) and tells its owner,
+// {"action": "dismiss"} leaves it working
+func (h *AdminHandler) HandleResolveShareReport(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	reportID := chi.URLParam(r, "id")
+
+	var req struct {
+		Action string `json:"action"`
+		Note   string `json:"note,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	var status string
+	switch req.Action {
+	case "disable":
+		status = storage.ReportActioned
+	case "dismiss":
+		status = storage.ReportDismissed
+	default:
+		respondError(w, r, http.StatusBadRequest, "action must be disable or dismiss")
+		return
+	}
+
+	report, err := h.pg.GetShareReport(r.Context(), reportID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeNotFound, "Report not found")
+		return
+	}
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to resolve report")
+		return
+	}
+	if report.Status != storage.ReportOpen {
+		respondErrorCode(w, r, http.StatusConflict, apierror.CodeConflict, "Report already "+report.Status)
+		return
+	}
+
+	if status == storage.ReportActioned {
+		if err := h.disableReportedShare(r.Context(), report); err != nil {
+			log.Printf("[admin] %v", err)
+			respondError(w, r, http.StatusInternalServerError, "Failed to disable share link")
+			return
+		}
+	}
+	closed, err := h.pg.ResolveShareReport(r.Context(), report.ID, status, adminID, strings.TrimSpace(req.Note))
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to resolve report")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "SHARE_REPORT_RESOLVED", "share", report.ShareID, map[string]interface{}{
+		"report_id":      report.ID,
+		"category":       report.Category,
+		"action":         req.Action,
+		"note":           req.Note,
+		"reports_closed": closed,
+	}, GetClientIP(r))
+	log.Printf("[admin] Report %s on share %s resolved by %s: %s", report.ID, report.ShareID, adminID, req.Action)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":        "Report resolved",
+		"status":         status,
+		"reports_closed": closed,
+	})
+}
+
+// disableReportedShare disables the share link of a report and tells its owner
+func (h *AdminHandler) disableReportedShare(ctx context.Context, report *storage.ShareReport) error {
+	disabled, err := h.pg.DisableShareLink(ctx, report.ShareID, shareDisabledReported)
+	if err != nil || !disabled {
+		return err // already disabled: nothing to tell
+	}
+	link, err := h.pg.GetShareLink(ctx, report.ShareID)
+	if err != nil {
+		return err
+	}
+	if prefs := userPreferences(ctx, h.pg, link.CreatedBy); prefs.Notifications.ShareDisabled {
+		announceShareDisabled(ctx, h.pg, link, shareDisabledReported)
+	}
+	return nil
+}
@@ -0,0 +1,620 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/i18n"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Reasons recorded when a share link is disabled
+const (
+	shareDisabledByOwner   = "revoked by owner"
+	shareDisabledRequests  = "request cap reached"
+	shareDisabledBandwidth = "bandwidth cap reached"
+	shareDisabledSpike     = "download spike detected"
+	shareDisabledReported  = "disabled after an abuse report"
+)
+
+// shareDisabledReasonKeys are the catalog keys of the reasons, for announcements
+var shareDisabledReasonKeys = map[string]string{
+	shareDisabledByOwner:   "share_disabled.reason.owner",
+	shareDisabledRequests:  "share_disabled.reason.requests",
+	shareDisabledBandwidth: "share_disabled.reason.bandwidth",
+	shareDisabledSpike:     "share_disabled.reason.spike",
+	shareDisabledReported:  "share_disabled.reason.report",
+}
+
+type ShareHandler struct {
+	minioStorage *storage.MinIOStorage
+	redisCache   *storage.RedisCache
+	pgStore      *storage.PostgresStore
+	auditLogger  *AuditLogger
+	spikeLimit   int // requests per minute before a link is auto-disabled (0 = off)
+	reportLimit  int // abuse reports per hour from one address
+	guard        *auth.TokenGuard
+	visitors     *auth.VisitorHasher // hashes addresses for the share statistics
+	notifier     *notify.Notifier
+}
+
+func NewShareHandler(minioStorage *storage.MinIOStorage, redisCache *storage.RedisCache, pgStore *storage.PostgresStore, spikeLimit, reportLimit int, guard *auth.TokenGuard, visitors *auth.VisitorHasher, notifier *notify.Notifier) *ShareHandler {
+	if reportLimit <= 0 {
+		reportLimit = defaultReportsPerHour
+	}
+	return &ShareHandler{
+		minioStorage: minioStorage,
+		redisCache:   redisCache,
+		pgStore:      pgStore,
+		auditLogger:  NewAuditLogger(pgStore),
+		spikeLimit:   spikeLimit,
+		reportLimit:  reportLimit,
+		guard:        guard,
+		visitors:     visitors,
+		notifier:     notifier,
+	}
+}
+
+type CreateShareRequest struct {
+	ExpiresInHours  int      `json:"expires_in_hours"`
+	MaxRequests     *int     `json:"max_requests,omitempty"`
+	MaxBytes        *int64   `json:"max_bytes,omitempty"`
+	AllowedReferers []string `json:"allowed_referers,omitempty"` // hostnames; subdomains match too
+	AllowedIPs      []string `json:"allowed_ips,omitempty"`      // IPs or CIDRs
+}
+
+// HandleCreateShare creates a public share link for a file
+func (h *ShareHandler) HandleCreateShare(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return
+	}
+
+	var req CreateShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ExpiresInHours < 0 {
+		respondError(w, r, http.StatusBadRequest, "expires_in_hours must not be negative")
+		return
+	}
+	if req.MaxRequests != nil && *req.MaxRequests <= 0 {
+		respondError(w, r, http.StatusBadRequest, "max_requests must be positive")
+		return
+	}
+	if req.MaxBytes != nil && *req.MaxBytes <= 0 {
+		respondError(w, r, http.StatusBadRequest, "max_bytes must be positive")
+		return
+	}
+	for _, entry := range req.AllowedIPs {
+		if parseIPRule(entry) == nil {
+			respondError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid IP or CIDR: %s", entry))
+			return
+		}
+	}
+
+	metadata, err := storage.LoadFileMetadata(r.Context(), h.redisCache, h.pgStore, fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	// Files held back for review cannot be shared until an admin approves them
+	if metadata.QuarantinedAt != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	token, err := auth.GenerateToken()
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate share token")
+		return
+	}
+
+	link := &storage.ShareLink{
+		FileID:          fileID,
+		CreatedBy:       userID,
+		Token:           token,
+		MaxRequests:     req.MaxRequests,
+		MaxBytes:        req.MaxBytes,
+		AllowedReferers: normalizeHosts(req.AllowedReferers),
+		AllowedIPs:      req.AllowedIPs,
+	}
+	if req.ExpiresInHours > 0 {
+		t := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		link.ExpiresAt = &t
+	}
+
+	if err := h.pgStore.CreateShareLink(r.Context(), link); err != nil {
+		log.Printf("[ERROR] Failed to create share link for file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create share link")
+		return
+	}
+
+	log.Printf("[INFO] Share link %s created for file %s by user %s", link.ID, fileID, userID)
+
+	respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"share": link,
+		"url":   apiBase(r) + "/s/" + token,
+	})
+}
+
+// HandleListShares lists the share links of a file
+func (h *ShareHandler) HandleListShares(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+
+	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	links, err := h.pgStore.ListShareLinks(r.Context(), fileID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve share links")
+		return
+	}
+	if links == nil {
+		links = []*storage.ShareLink{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"shares": links,
+		"count":  len(links),
+	})
+}
+
+// HandleUpdateShare enables or disables a share link owned by the user, or resets
+// its counters. Fields left out of the body are not changed.
+func (h *ShareHandler) HandleUpdateShare(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.ownedShare(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Enabled       *bool `json:"enabled"`
+		ResetCounters bool  `json:"reset_counters"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Enabled == nil && !req.ResetCounters {
+		respondError(w, r, http.StatusBadRequest, "Nothing to update: set enabled or reset_counters")
+		return
+	}
+
+	// Only an admin can bring back a link taken down after an abuse report
+	if req.Enabled != nil && *req.Enabled && link.DisabledAt != nil && link.DisabledReason == shareDisabledReported {
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeForbidden, "Share link was disabled after an abuse report; contact an administrator")
+		return
+	}
+
+	enabled := link.DisabledAt == nil
+	var err error
+	switch {
+	case req.Enabled != nil && *req.Enabled:
+		err = h.pgStore.EnableShareLink(r.Context(), link.ID, req.ResetCounters)
+		enabled = true
+	case req.Enabled != nil:
+		if _, err = h.pgStore.DisableShareLink(r.Context(), link.ID, shareDisabledByOwner); err == nil && req.ResetCounters {
+			err = h.pgStore.ResetShareLinkCounters(r.Context(), link.ID)
+		}
+		enabled = false
+	default:
+		err = h.pgStore.ResetShareLinkCounters(r.Context(), link.ID)
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to update share link %s: %v", link.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update share link")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Share link updated successfully",
+		"id":      link.ID,
+		"enabled": enabled,
+	})
+}
+
+// HandleDeleteShare permanently deletes a share link owned by the user
+func (h *ShareHandler) HandleDeleteShare(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.ownedShare(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.pgStore.DeleteShareLink(r.Context(), link.ID); err != nil {
+		log.Printf("[ERROR] Failed to delete share link %s: %v", link.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to delete share link")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": "Share link deleted successfully",
+		"id":      link.ID,
+	})
+}
+
+// ownedShare loads the {id} share link and verifies the caller created it
+func (h *ShareHandler) ownedShare(w http.ResponseWriter, r *http.Request) (*storage.ShareLink, bool) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return nil, false
+	}
+
+	link, err := h.pgStore.GetShareLink(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeShareNotFound, "Share link not found")
+		return nil, false
+	}
+
+	if link.CreatedBy != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return nil, false
+	}
+
+	return link, true
+}
+
+// HandleShareDownload serves a file through a public share link (no authentication)
+func (h *ShareHandler) HandleShareDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Guessing tokens locks the address out; malformed ones are not looked up
+	if !h.guard.Allow(w, r, auth.GuardShare) {
+		return
+	}
+	token := chi.URLParam(r, "token")
+	var link *storage.ShareLink
+	if auth.WellFormedToken(token) {
+		link, _ = h.pgStore.GetShareLinkByToken(ctx, token)
+	}
+	if link == nil || !auth.TokenEqual(link.Token, token) {
+		h.guard.Fail(ctx, r, auth.GuardShare)
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeShareNotFound, "Share link not found")
+		return
+	}
+
+	// Every request with the token is a view of the link, refused ones included;
+	// it is a download once the file was served
+	visit := storage.ShareVisit{ShareID: link.ID, IPHash: h.visitors.Hash(link.ID, clientIP(r))}
+	defer h.recordVisit(r, &visit)
+
+	if link.DisabledAt != nil {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeShareDisabled, "Share link has been disabled")
+		return
+	}
+	if link.ExpiresAt != nil && link.ExpiresAt.Before(time.Now()) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeShareExpired, "Share link has expired")
+		return
+	}
+
+	// Hotlink protection
+	if len(link.AllowedReferers) > 0 && !refererAllowed(r.Referer(), link.AllowedReferers) {
+		log.Printf("[WARN] Share %s: referer %q not allowed", link.ID, r.Referer())
+		respondError(w, r, http.StatusForbidden, "Referer not allowed")
+		return
+	}
+	if len(link.AllowedIPs) > 0 && !ipAllowed(clientIP(r), link.AllowedIPs) {
+		log.Printf("[WARN] Share %s: IP %s not allowed", link.ID, clientIP(r))
+		respondError(w, r, http.StatusForbidden, "IP address not allowed")
+		return
+	}
+
+	// Spike detection: too many requests in a minute disables the link outright
+	if h.spikeLimit > 0 {
+		hits, err := h.redisCache.IncrShareHits(ctx, link.ID, time.Minute)
+		if err != nil {
+			log.Printf("[ERROR] Share %s: spike check failed: %v", link.ID, err)
+		} else if hits > int64(h.spikeLimit) {
+			h.disableAndNotify(ctx, link, shareDisabledSpike)
+			respondErrorCode(w, r, http.StatusTooManyRequests, apierror.CodeShareDisabled, "Share link disabled due to unusual activity")
+			return
+		}
+	}
+
+	// Files held back for review do not exist as far as share links go
+	metadata, err := storage.LoadFileMetadata(ctx, h.redisCache, h.pgStore, link.FileID)
+	if err != nil || metadata.QuarantinedAt != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(time.Now()) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
+		return
+	}
+
+	// Password-protected files still need the file password
+	if !h.checkSharePassword(w, r, link, metadata) {
+		return
+	}
+
+	// The owner's monthly transfer cap comes first, so a refused download does not
+	// count against the link's caps
+	mw, ok := beginTransfer(w, r, h.pgStore, metadata.UserID, storage.TransferDownload)
+	if !ok {
+		return
+	}
+
+	// Count the request and its bytes against the caps before sending anything
+	reserved, err := h.pgStore.ReserveShareDownload(ctx, link.ID, metadata.Size)
+	if err != nil {
+		log.Printf("[ERROR] Share %s: failed to reserve download: %v", link.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to process download")
+		return
+	}
+	if !reserved {
+		reason := shareDisabledRequests
+		if link.MaxBytes != nil && link.BytesServed+metadata.Size > *link.MaxBytes {
+			reason = shareDisabledBandwidth
+		}
+		h.disableAndNotify(ctx, link, reason)
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeShareDisabled, "Share link has been disabled")
+		return
+	}
+
+	err = serveDecrypted(mw, r, h.minioStorage, h.pgStore, metadata, "attachment")
+	recordTransfer(r, h.pgStore, mw)
+	if err != nil {
+		return
+	}
+
+	visit.Downloaded = true
+	recordDownload(r, h.pgStore, h.redisCache, storage.DownloadRecord{
+		FileID:    metadata.FileID,
+		ShareID:   link.ID,
+		IPAddress: clientIP(r),
+		Bytes:     metadata.Size,
+	})
+}
+
+// recordVisit adds a request to the statistics of its share link. Like
+// recordDownload it must survive the client going away.
+func (h *ShareHandler) recordVisit(r *http.Request, visit *storage.ShareVisit) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), recordDownloadTimeout)
+	defer cancel()
+
+	if err := h.pgStore.RecordShareVisit(ctx, *visit); err != nil {
+		log.Printf("[ERROR] Failed to record visit of share link %s: %v", visit.ShareID, err)
+	}
+}
+
+// HandleGetShareStats returns the views, downloads and unique visitors of a
+// share link of one of the user's files
+func (h *ShareHandler) HandleGetShareStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+
+	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	link, err := h.pgStore.GetShareLink(r.Context(), chi.URLParam(r, "shareID"))
+	if err != nil || link.FileID != fileID {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeShareNotFound, "Share link not found")
+		return
+	}
+
+	stats, err := h.pgStore.GetShareStats(r.Context(), link)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get stats of share link %s: %v", link.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get share statistics")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, stats)
+}
+
+// checkSharePassword enforces the file password on a share link. Guesses are
+// anonymous, so they are throttled per address and per link like invalid tokens
+// (each one costs an argon2id verification), and audited against the link
+// rather than the file's owner.
+func (h *ShareHandler) checkSharePassword(w http.ResponseWriter, r *http.Request, link *storage.ShareLink, metadata *storage.FileMetadata) bool {
+	if metadata.PasswordHash == "" {
+		return true
+	}
+	if !h.guard.AllowPassword(w, r, auth.GuardSharePassword, link.ID) {
+		return false
+	}
+
+	password := r.Header.Get(FilePasswordHeader)
+	if password == "" {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeFilePasswordRequired, "File password required")
+		return false
+	}
+
+	ok, err := crypto.VerifyPassword(password, metadata.PasswordHash)
+	if err != nil {
+		log.Printf("[ERROR] Failed to verify password for file %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to verify file password")
+		return false
+	}
+
+	if !ok {
+		h.guard.FailPassword(r.Context(), r, auth.GuardSharePassword, link.ID)
+		log.Printf("[WARN] Invalid file password for share link %s from %s", link.ID, GetClientIP(r))
+		_ = h.auditLogger.LogAdminAction(r.Context(), "", "FILE_PASSWORD_FAILED", "share", link.ID, map[string]interface{}{
+			"file_id": metadata.FileID,
+			"path":    r.URL.Path,
+		}, GetClientIP(r))
+		respondErrorCode(w, r, http.StatusForbidden, apierror.CodeFilePasswordInvalid, "Invalid file password")
+		return false
+	}
+
+	return true
+}
+
+// disableAndNotify disables a share link and tells the owner why, once
+func (h *ShareHandler) disableAndNotify(ctx context.Context, link *storage.ShareLink, reason string) {
+	disabled, err := h.pgStore.DisableShareLink(ctx, link.ID, reason)
+	if err != nil {
+		log.Printf("[ERROR] Failed to disable share link %s: %v", link.ID, err)
+		return
+	}
+	if !disabled {
+		return // already disabled by a concurrent request
+	}
+
+	log.Printf("[WARN] Share link %s for file %s disabled: %s", link.ID, link.FileID, reason)
+
+	if prefs := userPreferences(ctx, h.pgStore, link.CreatedBy); !prefs.Notifications.ShareDisabled {
+		log.Printf("[INFO] Owner %s of share link %s opted out of share notices", link.CreatedBy, link.ID)
+	} else {
+		announceShareDisabled(ctx, h.pgStore, link, reason)
+	}
+
+	_ = h.auditLogger.LogAdminAction(ctx, link.CreatedBy, "SHARE_AUTO_DISABLED", "share", link.ID, map[string]interface{}{
+		"file_id": link.FileID,
+		"reason":  reason,
+	}, "")
+}
+
+// announceShareDisabled tells the owner of a share link why it was disabled
+func announceShareDisabled(ctx context.Context, pg *storage.PostgresStore, link *storage.ShareLink, reason string) {
+	fileName := link.FileID
+	if metadata, err := pg.GetFileMetadata(ctx, link.FileID); err == nil {
+		fileName = metadata.FileName
+	}
+
+	// Written in the owner's language; announcements are stored as text
+	catalog := i18n.Default()
+	lang := catalog.ForLocale(userPreferences(ctx, pg, link.CreatedBy).Locale)
+	messageKey := "announcement.share_disabled.message"
+	if reason == shareDisabledReported {
+		messageKey = "announcement.share_reported.message"
+	}
+	if key, ok := shareDisabledReasonKeys[reason]; ok {
+		reason = catalog.Text(lang, key)
+	}
+	message := catalog.Text(lang, messageKey, "file", fileName, "reason", reason,
+		"requests", strconv.Itoa(link.RequestCount), "bytes", strconv.FormatInt(link.BytesServed, 10))
+	title := catalog.Text(lang, "announcement.share_disabled.title")
+	if _, err := pg.CreateSystemAnnouncement(ctx, title, message, "warning", []string{link.CreatedBy}, nil); err != nil {
+		log.Printf("[ERROR] Failed to notify owner of share link %s: %v", link.ID, err)
+	}
+}
+
+// normalizeHosts lower-cases allow-list hostnames and strips schemes/paths users paste in
+func normalizeHosts(hosts []string) []string {
+	out := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.TrimSpace(strings.ToLower(h))
+		if u, err := url.Parse(h); err == nil && u.Host != "" {
+			h = u.Hostname()
+		}
+		if h != "" {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// refererAllowed reports whether the Referer host is in the list (or a subdomain of an entry)
+func refererAllowed(referer string, allowed []string) bool {
+	u, err := url.Parse(referer)
+	if err != nil || u.Hostname() == "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, a := range allowed {
+		if host == a || strings.HasSuffix(host, "."+a) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPRule parses an allow-list entry (single IP or CIDR)
+func parseIPRule(entry string) *net.IPNet {
+	if _, n, err := net.ParseCIDR(entry); err == nil {
+		return n
+	}
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil
+	}
+	bits := 128
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+}
+
+// ipAllowed reports whether ip matches any allow-list entry
+func ipAllowed(ip string, allowed []string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, entry := range allowed {
+		if n := parseIPRule(entry); n != nil && n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the client address without port (RealIP has already applied the
+// forwarding headers of trusted proxies to RemoteAddr)
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
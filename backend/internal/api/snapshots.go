@@ -0,0 +1,199 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/internal/worker"
+)
+
+// RestorableFile is a file of a snapshot that no longer exists
+type RestorableFile struct {
+	FileID       string     `json:"file_id"`
+	FileName     string     `json:"file_name"`
+	UserID       string     `json:"user_id"`
+	Size         int64      `json:"size"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"` // in the trash when the snapshot was taken
+	ObjectExists bool       `json:"object_exists"`
+	OwnerExists  bool       `json:"owner_exists"`
+}
+
+// HandleListSnapshots returns the metadata snapshots, newest first
+func (h *AdminHandler) HandleListSnapshots(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := h.snapshots.List(r.Context())
+	if err != nil {
+		log.Printf("[admin] Failed to list snapshots: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list snapshots")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{"snapshots": snapshots})
+}
+
+// HandleCreateSnapshot takes a metadata snapshot now
+func (h *AdminHandler) HandleCreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	snap, err := h.snapshots.Take(r.Context(), adminID)
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to take snapshot")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "SNAPSHOT_CREATED", "snapshot", snap.ID, map[string]interface{}{
+		"files":   snap.Files,
+		"wal_lsn": snap.WALLSN,
+	}, GetClientIP(r))
+	respondJSON(w, http.StatusCreated, snap)
+}
+
+// HandleGetRestorableFiles lists the files of a snapshot that have been deleted
+// since, and whether their objects and owners still exist (both are needed to
+// restore them)
+func (h *AdminHandler) HandleGetRestorableFiles(w http.ResponseWriter, r *http.Request) {
+	snap, ok := h.getSnapshot(w, r)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.snapshots.DeletedFiles(r.Context(), snap.ID, nil)
+	if err != nil {
+		log.Printf("[admin] Failed to read snapshot %s: %v", snap.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to read snapshot")
+		return
+	}
+
+	owners := map[string]bool{}
+	files := make([]RestorableFile, 0, len(deleted))
+	for _, f := range deleted {
+		name, err := h.pg.DecryptFileName(f.FileID, f.FileName)
+		if err != nil {
+			name = "" // e.g. encrypted under a key that is gone
+		}
+		exists, known := owners[f.UserID]
+		if !known {
+			_, err := h.pg.GetUserByID(r.Context(), f.UserID)
+			exists = err == nil
+			owners[f.UserID] = exists
+		}
+		_, statErr := h.minioStore.GetFileInfo(r.Context(), f.MinIOPath)
+
+		files = append(files, RestorableFile{
+			FileID:       f.FileID,
+			FileName:     name,
+			UserID:       f.UserID,
+			Size:         f.Size,
+			CreatedAt:    f.CreatedAt,
+			DeletedAt:    f.DeletedAt,
+			ObjectExists: statErr == nil,
+			OwnerExists:  exists,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"snapshot": snap,
+		"files":    files,
+	})
+}
+
+// HandleRestoreFromSnapshot brings back the files rows of deleted files from a
+// snapshot, with their IDs, names, keys and everything else as they were then.
+// Only files whose object is still stored and whose owner still exists can be
+// restored; share links, download history and thumbnails are not part of snapshots.
+func (h *AdminHandler) HandleRestoreFromSnapshot(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+
+	var req struct {
+		FileIDs []string `json:"file_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.FileIDs) == 0 {
+		respondError(w, r, http.StatusBadRequest, "file_ids required")
+		return
+	}
+	wanted := make(map[string]bool, len(req.FileIDs))
+	for _, id := range req.FileIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid file ID: "+id)
+			return
+		}
+		wanted[id] = true
+	}
+
+	snap, ok := h.getSnapshot(w, r)
+	if !ok {
+		return
+	}
+	deleted, err := h.snapshots.DeletedFiles(r.Context(), snap.ID, wanted)
+	if err != nil {
+		log.Printf("[admin] Failed to read snapshot %s: %v", snap.ID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to read snapshot")
+		return
+	}
+
+	restored := []string{}
+	failed := map[string]string{}
+	for _, f := range deleted {
+		delete(wanted, f.FileID)
+		if _, err := h.minioStore.GetFileInfo(r.Context(), f.MinIOPath); err != nil {
+			failed[f.FileID] = "object no longer in storage"
+			continue
+		}
+		err := h.pg.RestoreSnapshotFile(r.Context(), f)
+		switch {
+		case errors.Is(err, storage.ErrOwnerNotFound):
+			failed[f.FileID] = "owner no longer exists"
+		case errors.Is(err, storage.ErrFileExists):
+			failed[f.FileID] = "file exists"
+		case err != nil:
+			log.Printf("[admin] Failed to restore file %s from snapshot %s: %v", f.FileID, snap.ID, err)
+			failed[f.FileID] = "restore failed"
+		default:
+			restored = append(restored, f.FileID)
+		}
+	}
+	for id := range wanted {
+		failed[id] = "not in the snapshot, or not deleted"
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, restored...)
+
+	if len(restored) > 0 {
+		_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "FILES_RESTORED_FROM_SNAPSHOT", "snapshot", snap.ID, map[string]interface{}{
+			"file_ids": restored,
+			"wal_lsn":  snap.WALLSN,
+		}, GetClientIP(r))
+	}
+	log.Printf("[admin] %d files restored from snapshot %s by %s (%d failed)", len(restored), snap.ID, adminID, len(failed))
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"restored": restored,
+		"failed":   failed,
+	})
+}
+
+// getSnapshot returns the snapshot named in the URL, or responds 404
+func (h *AdminHandler) getSnapshot(w http.ResponseWriter, r *http.Request) (*worker.Snapshot, bool) {
+	snap, err := h.snapshots.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		log.Printf("[admin] Failed to get snapshot: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get snapshot")
+		return nil, false
+	}
+	if snap == nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeNotFound, "Snapshot not found")
+		return nil, false
+	}
+	return snap, true
+}
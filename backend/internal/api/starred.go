@@ -0,0 +1,113 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+)
+
+const (
+	defaultRecentLimit = 20
+	maxRecentLimit     = 100
+)
+
+// HandleStarFile stars a file (POST) or removes the star (DELETE)
+func (h *FilesHandler) HandleStarFile(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return
+	}
+
+	starred := r.Method != http.MethodDelete
+	if err := h.pgStore.SetFileStarred(r.Context(), fileID, userID, starred); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+			return
+		}
+		log.Printf("[ERROR] Failed to update star for file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to update star")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"file_id": fileID,
+		"starred": starred,
+	})
+}
+
+// HandleListStarred lists the user's starred files, most recently starred first
+func (h *FilesHandler) HandleListStarred(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	metadataList, err := h.pgStore.ListStarredFiles(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve files")
+		return
+	}
+
+	files := make([]FileInfo, 0, len(metadataList))
+	for _, metadata := range metadataList {
+		files = append(files, newFileInfo(metadata))
+	}
+
+	respondJSONConditional(w, r, http.StatusOK, map[string]interface{}{
+		"files": files,
+		"count": len(files),
+	})
+}
+
+// HandleListRecent lists the user's most recently uploaded or downloaded files
+func (h *FilesHandler) HandleListRecent(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	limit := defaultRecentLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxRecentLimit {
+			respondError(w, r, http.StatusBadRequest, "Invalid limit (1-100)")
+			return
+		}
+		limit = n
+	}
+
+	metadataList, err := h.pgStore.ListRecentFiles(r.Context(), userID, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve files")
+		return
+	}
+
+	files := make([]FileInfo, 0, len(metadataList))
+	for _, metadata := range metadataList {
+		files = append(files, newFileInfo(metadata))
+	}
+
+	respondJSONConditional(w, r, http.StatusOK, map[string]interface{}{
+		"files": files,
+		"count": len(files),
+	})
+}
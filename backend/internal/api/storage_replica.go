@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+)
+
+// HandleGetReplicaStatus reports how far the standby storage lags behind: the
+// object.replicate jobs not done yet, how long the oldest has been waiting, and
+// those that failed for good (which POST /admin/storage/replica/reconcile makes up for)
+func (h *AdminHandler) HandleGetReplicaStatus(w http.ResponseWriter, r *http.Request) {
+	if h.replica == nil {
+		respondJSON(w, http.StatusOK, map[string]interface{}{"enabled": false})
+		return
+	}
+
+	backlog, err := h.pg.GetJobBacklog(r.Context(), jobs.TypeObjectReplicate)
+	if err != nil {
+		log.Printf("[admin] %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get replica status")
+		return
+	}
+	lag := 0.0
+	if backlog.OldestPendingAt != nil {
+		lag = time.Since(*backlog.OldestPendingAt).Seconds()
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"enabled":            true,
+		"endpoint":           h.replica.Endpoint(),
+		"pending":            backlog.Pending,
+		"failed":             backlog.Dead,
+		"oldest_pending_at":  backlog.OldestPendingAt,
+		"lag_seconds":        lag,
+		"last_replicated_at": backlog.LastFinishedAt,
+	})
+}
+
+// HandleReconcileReplica compares every object of the primary storage with the
+// standby and queues an object.replicate job for each that is missing, differs
+// or should no longer be there. With {"dry_run": true} it only reports. Run it
+// after the standby was unreachable for long, and before failing over.
+func (h *AdminHandler) HandleReconcileReplica(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	if h.replica == nil {
+		respondErrorCode(w, r, http.StatusConflict, apierror.CodeConflict, "No replica configured (storage.minio.replica)")
+		return
+	}
+
+	var req struct {
+		DryRun bool `json:"dry_run"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	diff, err := h.minioStore.DiffReplica(r.Context(), h.replica)
+	if err != nil {
+		log.Printf("[admin] Failed to compare the replica: %v", err)
+		respondError(w, r, http.StatusBadGateway, "Failed to compare the replica")
+		return
+	}
+
+	queued := 0
+	if !req.DryRun {
+		for _, list := range [][]string{diff.Missing, diff.Different, diff.Extra} {
+			for _, path := range list {
+				if _, err := h.jobQueue.Enqueue(r.Context(), jobs.TypeObjectReplicate, jobs.ObjectPayload{Path: path}); err != nil {
+					log.Printf("[admin] Failed to queue replication of %s: %v", path, err)
+					continue
+				}
+				queued++
+			}
+		}
+		_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "REPLICA_RECONCILED", "system", "", map[string]interface{}{
+			"missing":   len(diff.Missing),
+			"different": len(diff.Different),
+			"extra":     len(diff.Extra),
+			"queued":    queued,
+		}, GetClientIP(r))
+	}
+	log.Printf("[admin] Replica reconciliation by %s: %d missing, %d different, %d extra, %d queued",
+		adminID, len(diff.Missing), len(diff.Different), len(diff.Extra), queued)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"dry_run":         req.DryRun,
+		"primary_objects": diff.PrimaryObjects,
+		"replica_objects": diff.ReplicaObjects,
+		"missing":         diff.Missing,
+		"different":       diff.Different,
+		"extra":           diff.Extra,
+		"queued":          queued,
+	})
+}
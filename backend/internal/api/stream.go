@@ -1,17 +1,19 @@
 package api
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
-	"encoding/base64"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
 	"github.com/sachinthra/file-locker/backend/internal/crypto"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
@@ -21,13 +23,23 @@ type StreamHandler struct {
 	minioStorage *storage.MinIOStorage
 	redisCache   *storage.RedisCache
 	pgStore      *storage.PostgresStore
+	auditLogger  *AuditLogger
+	blocks       *blockCache // nil = no block cache
+	prefetch     int         // blocks read ahead of the client within a range; 0 = none
 }
 
-func NewStreamHandler(minioStorage *storage.MinIOStorage, redisCache *storage.RedisCache, pgStore *storage.PostgresStore) *StreamHandler {
+// NewStreamHandler creates the stream handler. blockCacheSize bounds the memory
+// (in bytes) used to cache decrypted blocks for range requests; 0 disables it.
+// prefetchBlocks is how many blocks of a range are fetched from MinIO ahead of
+// what has been sent to the client. With neither, ranges stream straight from MinIO.
+func NewStreamHandler(minioStorage *storage.MinIOStorage, redisCache *storage.RedisCache, pgStore *storage.PostgresStore, blockCacheSize int64, prefetchBlocks int) *StreamHandler {
 	return &StreamHandler{
 		minioStorage: minioStorage,
 		redisCache:   redisCache,
 		pgStore:      pgStore,
+		auditLogger:  NewAuditLogger(pgStore),
+		blocks:       newBlockCache(blockCacheSize),
+		prefetch:     prefetchBlocks,
 	}
 }
 
@@ -35,49 +47,61 @@ func (h *StreamHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
 	// 1. Get fileID from URL
 	fileID := chi.URLParam(r, "id")
 	if fileID == "" {
-		respondError(w, http.StatusBadRequest, "File ID required")
+		respondError(w, r, http.StatusBadRequest, "File ID required")
 		return
 	}
 
 	// 2. Get userID from context (Security Check)
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
 	// 3. Get metadata from PostgreSQL
-	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	metadata, err := storage.LoadFileMetadata(r.Context(), h.redisCache, h.pgStore, fileID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "File not found")
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
 		return
 	}
 
 	// 4. Verify Ownership
 	if metadata.UserID != userID {
-		respondError(w, http.StatusForbidden, "Access denied")
+		respondError(w, r, http.StatusForbidden, "Access denied")
 		return
 	}
 
 	// 5. Check Expiration
 	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(time.Now()) {
-		respondError(w, http.StatusGone, "File has expired")
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
 		return
 	}
 
-	// 6. Decode the Master Encryption Key
-	keyBytes, err := base64.StdEncoding.DecodeString(metadata.EncryptionKey)
+	// 5b. Per-file download password (independent of account auth)
+	if !checkFilePassword(w, r, h.auditLogger, userID, metadata) {
+		return
+	}
+
+	// 6. Unwrap the file's data key
+	keyBytes, err := h.pgStore.DataKey(r.Context(), metadata)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to decode encryption key")
+		respondError(w, r, http.StatusInternalServerError, "Failed to decode encryption key")
+		return
+	}
+
+	// 7. Count what is sent against the monthly transfer cap
+	mw, ok := beginTransfer(w, r, h.pgStore, metadata.UserID, storage.TransferStream)
+	if !ok {
 		return
 	}
+	defer recordTransfer(r, h.pgStore, mw)
 
-	// 7. Handle Range Request (Seeking) vs Full Request
+	// 8. Handle Range Request (Seeking) vs Full Request
 	rangeHeader := r.Header.Get("Range")
 	if rangeHeader != "" {
-		h.handleRangeRequest(w, r, metadata, keyBytes, rangeHeader)
+		h.handleRangeRequest(mw, r, metadata, keyBytes, rangeHeader)
 	} else {
-		h.handleFullStream(w, r, metadata, keyBytes)
+		h.handleFullStream(mw, r, metadata, keyBytes)
 	}
 }
 
@@ -86,7 +110,9 @@ func (h *StreamHandler) handleFullStream(w http.ResponseWriter, r *http.Request,
 	// Fetch entire encrypted stream from MinIO
 	encryptedStream, err := h.minioStorage.GetFile(r.Context(), metadata.MinIOPath)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve file")
+		if !respondStorageUnavailable(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, "Failed to retrieve file")
+		}
 		return
 	}
 	defer func() { _ = encryptedStream.Close() }()
@@ -94,7 +120,7 @@ func (h *StreamHandler) handleFullStream(w http.ResponseWriter, r *http.Request,
 	// Use our existing helper which reads the IV from the first 16 bytes automatically
 	decryptedStream, err := crypto.DecryptStream(encryptedStream, keyBytes)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to decrypt file")
+		respondError(w, r, http.StatusInternalServerError, "Failed to decrypt file")
 		return
 	}
 
@@ -102,7 +128,7 @@ func (h *StreamHandler) handleFullStream(w http.ResponseWriter, r *http.Request,
 	w.Header().Set("Content-Type", metadata.MimeType)
 	w.Header().Set("Content-Length", fmt.Sprintf("%d", metadata.Size))
 	w.Header().Set("Accept-Ranges", "bytes") // Tells browser we support seeking
-	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", metadata.FileName))
+	w.Header().Set("Content-Disposition", contentDisposition("inline", metadata.FileName))
 	w.Header().Set("Cache-Control", "no-cache")
 	w.WriteHeader(http.StatusOK)
 
@@ -121,7 +147,7 @@ func (h *StreamHandler) handleRangeRequest(w http.ResponseWriter, r *http.Reques
 
 	start, err := strconv.ParseInt(rangeParts[0], 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid range start")
+		respondError(w, r, http.StatusBadRequest, "Invalid range start")
 		return
 	}
 
@@ -129,16 +155,24 @@ func (h *StreamHandler) handleRangeRequest(w http.ResponseWriter, r *http.Reques
 	if len(rangeParts) > 1 && rangeParts[1] != "" {
 		end, err = strconv.ParseInt(rangeParts[1], 10, 64)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid range end")
+			respondError(w, r, http.StatusBadRequest, "Invalid range end")
 			return
 		}
 	} else {
 		end = metadata.Size - 1 // Default to end of file
 	}
+	if end >= metadata.Size {
+		end = metadata.Size - 1 // A range past the end is served up to the end
+	}
 
 	if start > end || start >= metadata.Size {
 		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", metadata.Size))
-		respondError(w, http.StatusRequestedRangeNotSatisfiable, "Invalid range")
+		respondError(w, r, http.StatusRequestedRangeNotSatisfiable, "Invalid range")
+		return
+	}
+
+	if h.blocks != nil || h.prefetch > 0 {
+		h.serveBlocks(w, r, metadata, keyBytes, start, end)
 		return
 	}
 
@@ -154,13 +188,15 @@ func (h *StreamHandler) handleRangeRequest(w http.ResponseWriter, r *http.Reques
 	// We need this to calculate the specific counter for our block.
 	ivStream, err := h.minioStorage.GetFileRange(r.Context(), metadata.MinIOPath, 0, int64(ivSize-1))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve IV")
+		if !respondStorageUnavailable(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, "Failed to retrieve IV")
+		}
 		return
 	}
 	iv := make([]byte, ivSize)
 	if _, err := io.ReadFull(ivStream, iv); err != nil {
 		defer func() { _ = ivStream.Close() }()
-		respondError(w, http.StatusInternalServerError, "Failed to read IV")
+		respondError(w, r, http.StatusInternalServerError, "Failed to read IV")
 		return
 	}
 	defer func() { _ = ivStream.Close() }()
@@ -177,7 +213,7 @@ func (h *StreamHandler) handleRangeRequest(w http.ResponseWriter, r *http.Reques
 
 	encryptedStream, err := h.minioStorage.GetFileRange(r.Context(), metadata.MinIOPath, fetchStart, fetchEnd)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve file range")
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve file range")
 		return
 	}
 	defer func() { _ = encryptedStream.Close() }()
@@ -185,7 +221,7 @@ func (h *StreamHandler) handleRangeRequest(w http.ResponseWriter, r *http.Reques
 	// 6. Initialize Cipher
 	block, err := aes.NewCipher(keyBytes)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create cipher")
+		respondError(w, r, http.StatusInternalServerError, "Failed to create cipher")
 		return
 	}
 
@@ -241,6 +277,188 @@ func (h *StreamHandler) handleRangeRequest(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// blocksPerFetch is how many blocks a cache miss reads from MinIO in one request
+const blocksPerFetch = 16
+
+// cachedBlocksPerRequest limits how much of a single range is kept in the block
+// cache, so one long sequential read doesn't evict the blocks other requests use
+const cachedBlocksPerRequest = 16
+
+// serveBlocks answers a range request from cached decrypted blocks, fetching and
+// decrypting missing ones from MinIO. With a prefetch window, blocks further into
+// the range are fetched in the background while earlier ones are being written.
+func (h *StreamHandler) serveBlocks(w http.ResponseWriter, r *http.Request, metadata *storage.FileMetadata, keyBytes []byte, start, end int64) {
+	first := start / streamBlockSize
+	last := end / streamBlockSize
+
+	// Stops the prefetcher when we return, including when the client disconnects
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// Fetch the first block before writing headers so a failure still gets an error response
+	blocks, err := h.readBlocks(ctx, metadata, keyBytes, first, last, true)
+	if err != nil {
+		log.Printf("[ERROR] Failed to read blocks of %s: %v", metadata.FileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve file range")
+		return
+	}
+
+	w.Header().Set("Content-Type", metadata.MimeType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", end-start+1))
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, metadata.Size))
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.WriteHeader(http.StatusPartialContent)
+
+	var prefetched <-chan prefetchedBlock
+	if h.prefetch > 0 && first+int64(len(blocks)) <= last {
+		prefetched = h.prefetchBlocks(ctx, metadata, keyBytes, first+int64(len(blocks)), last, first)
+	}
+
+	for index := first; index <= last; index++ {
+		if len(blocks) == 0 {
+			if prefetched != nil {
+				b, ok := <-prefetched
+				if !ok || b.err != nil {
+					return // Stream broken mid-way
+				}
+				blocks = [][]byte{b.data}
+			} else {
+				blocks, err = h.readBlocks(ctx, metadata, keyBytes, index, last, index-first < cachedBlocksPerRequest)
+				if err != nil {
+					return // Stream broken mid-way
+				}
+			}
+		}
+		data := blocks[0]
+		blocks = blocks[1:]
+
+		lo, hi := int64(0), int64(len(data))
+		if index == first {
+			lo = start - index*streamBlockSize
+		}
+		if index == last {
+			hi = end - index*streamBlockSize + 1
+		}
+		if _, err := w.Write(data[lo:hi]); err != nil {
+			return // Client disconnected
+		}
+	}
+}
+
+type prefetchedBlock struct {
+	data []byte
+	err  error
+}
+
+// prefetchBlocks reads blocks from..to in the background and delivers them in
+// order, staying at most the prefetch window ahead of the reader. The channel is
+// closed after the last block or an error; cancel ctx to stop early. first is the
+// first block of the request, for the cachedBlocksPerRequest limit.
+func (h *StreamHandler) prefetchBlocks(ctx context.Context, metadata *storage.FileMetadata, keyBytes []byte, from, to, first int64) <-chan prefetchedBlock {
+	out := make(chan prefetchedBlock, h.prefetch)
+
+	go func() {
+		defer close(out)
+
+		for index := from; index <= to; {
+			blocks, err := h.readBlocks(ctx, metadata, keyBytes, index, min(to, index+int64(h.prefetch)-1), index-first < cachedBlocksPerRequest)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("[WARN] Prefetch of %s stopped at block %d: %v", metadata.FileID, index, err)
+				}
+				select {
+				case out <- prefetchedBlock{err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			for _, data := range blocks {
+				select {
+				case out <- prefetchedBlock{data: data}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			index += int64(len(blocks))
+		}
+	}()
+
+	return out
+}
+
+// readBlocks returns decrypted blocks starting at index from: the cached block if
+// there is one, otherwise up to blocksPerFetch blocks (not past to) read from MinIO
+// in one request. Fetched blocks are cached when cache is set.
+func (h *StreamHandler) readBlocks(ctx context.Context, metadata *storage.FileMetadata, keyBytes []byte, from, to int64, cache bool) ([][]byte, error) {
+	if data, ok := h.blocks.get(blockKey{metadata.MinIOPath, from}); ok {
+		return [][]byte{data}, nil
+	}
+
+	const ivSize = 16
+	iv, err := h.fileIV(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if to > from+blocksPerFetch-1 {
+		to = from + blocksPerFetch - 1
+	}
+	fetchStart := from * streamBlockSize
+	fetchEnd := min((to+1)*streamBlockSize, metadata.Size) - 1
+
+	encrypted, err := h.minioStorage.GetFileRange(ctx, metadata.MinIOPath, ivSize+fetchStart, ivSize+fetchEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = encrypted.Close() }()
+
+	buf := make([]byte, fetchEnd-fetchStart+1)
+	if _, err := io.ReadFull(encrypted, buf); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	// Blocks are a multiple of the AES block size, so the counter starts exactly at fetchStart
+	cipher.NewCTR(block, addCounter(iv, uint64(fetchStart/aes.BlockSize))).XORKeyStream(buf, buf)
+
+	var blocks [][]byte
+	for index := from; index <= to; index++ {
+		lo := (index - from) * streamBlockSize
+		hi := min(lo+streamBlockSize, int64(len(buf)))
+		data := buf[lo:hi:hi]
+		if cache {
+			h.blocks.put(blockKey{metadata.MinIOPath, index}, data)
+		}
+		blocks = append(blocks, data)
+	}
+	return blocks, nil
+}
+
+// fileIV returns the IV stored in the first 16 bytes of a file's object
+func (h *StreamHandler) fileIV(ctx context.Context, metadata *storage.FileMetadata) ([]byte, error) {
+	key := blockKey{metadata.MinIOPath, ivBlock}
+	if iv, ok := h.blocks.get(key); ok {
+		return iv, nil
+	}
+
+	stream, err := h.minioStorage.GetFileRange(ctx, metadata.MinIOPath, 0, aes.BlockSize-1)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(stream, iv); err != nil {
+		return nil, err
+	}
+	h.blocks.put(key, iv)
+	return iv, nil
+}
+
 // addCounter increments an AES-CTR 16-byte counter by a specific value (Big Endian addition)
 func addCounter(iv []byte, delta uint64) []byte {
 	// Create a copy so we don't modify the original IV
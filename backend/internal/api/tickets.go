@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// CreateTicketRequest selects what the ticket is for ("download" or "stream", default stream)
+type CreateTicketRequest struct {
+	Purpose string `json:"purpose"`
+}
+
+// HandleCreateTicket mints a one-time ticket for /download/{id} or /stream/{id}, for
+// clients that cannot send an Authorization header (e.g. <video src>). Password-protected
+// files need the password (X-File-Password) here instead of on the stream request.
+func (h *DownloadHandler) HandleCreateTicket(w http.ResponseWriter, r *http.Request) {
+	// Get userID from context
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" {
+		respondError(w, r, http.StatusBadRequest, "File ID required")
+		return
+	}
+
+	var req CreateTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var path string
+	switch req.Purpose {
+	case "", auth.TicketPurposeStream:
+		req.Purpose = auth.TicketPurposeStream
+		path = apiBase(r) + "/stream/" + fileID
+	case auth.TicketPurposeDownload:
+		path = apiBase(r) + "/download/" + fileID
+	default:
+		respondError(w, r, http.StatusBadRequest, "purpose must be download or stream")
+		return
+	}
+
+	metadata, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	if metadata.UserID != userID {
+		respondError(w, r, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(time.Now()) {
+		respondErrorCode(w, r, http.StatusGone, apierror.CodeFileExpired, "File has expired")
+		return
+	}
+
+	if !checkFilePassword(w, r, h.auditLogger, userID, metadata) {
+		return
+	}
+
+	ticket, err := auth.GenerateToken()
+	if err == nil {
+		err = h.redisCache.SaveAccessTicket(r.Context(), ticket, storage.AccessTicket{
+			FileID:  fileID,
+			UserID:  userID,
+			Purpose: req.Purpose,
+			Version: metadata.UpdatedAt.UnixNano(),
+		}, auth.TicketTTL)
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to create %s ticket for file %s: %v", req.Purpose, fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to create ticket")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"ticket":     ticket,
+		"purpose":    req.Purpose,
+		"path":       path + "?ticket=" + ticket,
+		"expires_at": time.Now().Add(auth.TicketTTL),
+	})
+}
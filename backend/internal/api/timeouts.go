@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultRequestTimeout applies when no request timeout is configured
+const defaultRequestTimeout = 60 * time.Second
+
+// RouteTimeout cancels the request context after a timeout, like chi's middleware.Timeout,
+// but lets path prefixes override it. Overridden routes also get their connection
+// read/write deadlines moved, since the server-wide ReadTimeout/WriteTimeout would
+// otherwise still cut large transfers off. A timeout of 0 means no limit.
+func RouteTimeout(timeout time.Duration, overrides map[string]time.Duration) func(http.Handler) http.Handler {
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d, overridden := routeTimeoutFor(canonicalPath(r.URL.Path), overrides)
+			if !overridden {
+				d = timeout
+			} else {
+				var deadline time.Time // zero = no deadline
+				if d > 0 {
+					deadline = time.Now().Add(d)
+				}
+				rc := http.NewResponseController(w)
+				_ = rc.SetReadDeadline(deadline)
+				_ = rc.SetWriteDeadline(deadline)
+			}
+
+			if d <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer func() {
+				cancel()
+				if ctx.Err() == context.DeadlineExceeded {
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// routeTimeoutFor returns the override of the longest matching path prefix
+func routeTimeoutFor(path string, overrides map[string]time.Duration) (time.Duration, bool) {
+	best, found := -1, false
+	var d time.Duration
+	for prefix, t := range overrides {
+		if strings.HasPrefix(path, prefix) && len(prefix) > best {
+			best, d, found = len(prefix), t, true
+		}
+	}
+	return d, found
+}
+
+// defaultTransferStall applies when no transfer stall timeout is configured
+const defaultTransferStall = 60 * time.Second
+
+// TransferDeadlines is used instead of RouteTimeout for large uploads and downloads.
+// There is no overall limit; instead the connection's read and write deadlines are
+// pushed forward whenever body bytes move, so a transfer only fails once it has
+// made no progress for stall.
+func TransferDeadlines(stall time.Duration) func(http.Handler) http.Handler {
+	if stall <= 0 {
+		stall = defaultTransferStall
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rc := http.NewResponseController(w)
+			deadline := time.Now().Add(stall)
+			_ = rc.SetReadDeadline(deadline)
+			_ = rc.SetWriteDeadline(deadline)
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = &progressReader{ReadCloser: r.Body, rc: rc, stall: stall}
+			}
+
+			next.ServeHTTP(&progressWriter{ResponseWriter: w, rc: rc, stall: stall}, r)
+		})
+	}
+}
+
+// deadlineRefresh limits how often deadlines are moved (each move resets a timer)
+const deadlineRefresh = time.Second
+
+type progressReader struct {
+	io.ReadCloser
+	rc       *http.ResponseController
+	stall    time.Duration
+	extended time.Time
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	if n > 0 {
+		if now := time.Now(); now.Sub(p.extended) >= deadlineRefresh {
+			p.extended = now
+			// The response is written after the upload is consumed, so keep
+			// the write deadline ahead of the read deadline too
+			_ = p.rc.SetReadDeadline(now.Add(p.stall))
+			_ = p.rc.SetWriteDeadline(now.Add(2 * p.stall))
+		}
+	}
+	return n, err
+}
+
+type progressWriter struct {
+	http.ResponseWriter
+	rc       *http.ResponseController
+	stall    time.Duration
+	extended time.Time
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	if now := time.Now(); now.Sub(p.extended) >= deadlineRefresh {
+		p.extended = now
+		_ = p.rc.SetWriteDeadline(now.Add(p.stall))
+	}
+	return p.ResponseWriter.Write(b)
+}
+
+// Flush passes through to the underlying writer when it supports flushing
+func (p *progressWriter) Flush() {
+	_ = p.rc.Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (p *progressWriter) Unwrap() http.ResponseWriter {
+	return p.ResponseWriter
+}
@@ -5,11 +5,12 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/sachinthra/file-locker/backend/internal/auth"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
 	"golang.org/x/crypto/bcrypt"
 
@@ -25,8 +26,9 @@ func NewTokensHandler(pg *storage.PostgresStore) *TokensHandler {
 }
 
 type createTokenReq struct {
-	Name          string `json:"name"`
-	ExpiresInDays int    `json:"expires_in_days"`
+	Name          string   `json:"name"`
+	ExpiresInDays int      `json:"expires_in_days"`
+	Scopes        []string `json:"scopes"` // defaults to auth.DefaultPATScopes
 }
 
 // POST /api/auth/tokens
@@ -36,20 +38,46 @@ func (h *TokensHandler) HandleCreateToken(w http.ResponseWriter, r *http.Request
 	var req createTokenReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[tokens] CreateToken decode error: %v", err)
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	if req.Name == "" {
-		http.Error(w, "name required", http.StatusBadRequest)
+		respondError(w, r, http.StatusBadRequest, "Name required")
 		return
 	}
+	if len(req.Scopes) == 0 {
+		req.Scopes = auth.DefaultPATScopes
+	}
+	for _, s := range req.Scopes {
+		if !auth.ValidPATScopes[s] {
+			respondError(w, r, http.StatusBadRequest, "Invalid scope: "+s)
+			return
+		}
+	}
 
-	// generate raw token: fl_ + 32 chars random
-	rawUUID := strings.ReplaceAll(uuid.New().String(), "-", "")
-	raw := "fl_" + rawUUID[:32]
+	// A token calling here may only hand out its own scopes
+	role := ""
+	if slices.Contains(req.Scopes, auth.ScopeAdmin) {
+		if err := h.DB.QueryRowContext(r.Context(), `SELECT role FROM users WHERE id = $1`, uid).Scan(&role); err != nil {
+			log.Printf("[tokens] Failed to get role of user=%s: %v", uid, err)
+			respondError(w, r, http.StatusInternalServerError, "Failed to create token")
+			return
+		}
+	}
+	if code, message := auth.CheckTokenGrant(auth.CallerFromRequest(r, role), req.Scopes); code != "" {
+		respondErrorCode(w, r, http.StatusForbidden, code, message)
+		return
+	}
+
+	// generate raw token: fl_<lookup prefix>_<secret>
+	raw, lookupPrefix, err := auth.GeneratePAT()
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
 	hashed, err := bcrypt.GenerateFromPassword([]byte(raw), bcrypt.DefaultCost)
 	if err != nil {
-		http.Error(w, "failed generate token", http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
@@ -61,10 +89,10 @@ func (h *TokensHandler) HandleCreateToken(w http.ResponseWriter, r *http.Request
 
 	id := uuid.New().String()
 	createdAt := time.Now().UTC()
-	_, err = h.DB.Exec(`INSERT INTO personal_access_tokens (id, user_id, name, token_hash, created_at, expires_at) VALUES ($1,$2,$3,$4,$5,$6)`, id, uid, req.Name, string(hashed), createdAt, expiresAt)
+	_, err = h.DB.Exec(`INSERT INTO personal_access_tokens (id, user_id, name, token_hash, token_prefix, created_at, expires_at, scopes) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`, id, uid, req.Name, string(hashed), lookupPrefix, createdAt, expiresAt, req.Scopes)
 	if err != nil {
 		log.Printf("[tokens] DB insert error for user=%s: %v", uid, err)
-		http.Error(w, "failed save token", http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to save token")
 		return
 	}
 
@@ -73,6 +101,7 @@ func (h *TokensHandler) HandleCreateToken(w http.ResponseWriter, r *http.Request
 		"name":       req.Name,
 		"created_at": createdAt,
 		"expires_at": expiresAt,
+		"scopes":     req.Scopes,
 		"token":      raw,
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -83,10 +112,10 @@ func (h *TokensHandler) HandleCreateToken(w http.ResponseWriter, r *http.Request
 func (h *TokensHandler) HandleListTokens(w http.ResponseWriter, r *http.Request) {
 	uid, _ := r.Context().Value(constants.UserIDKey).(string)
 	log.Printf("[tokens] %s %s ListTokens request by user=%s from=%s", r.Method, r.URL.Path, uid, r.RemoteAddr)
-	rows, err := h.DB.Query(`SELECT id, name, created_at, last_used_at, expires_at FROM personal_access_tokens WHERE user_id = $1 ORDER BY created_at DESC`, uid)
+	rows, err := h.DB.Query(`SELECT id, name, created_at, last_used_at, expires_at, scopes FROM personal_access_tokens WHERE user_id = $1 ORDER BY created_at DESC`, uid)
 	if err != nil {
 		log.Printf("[tokens] DB list error for user=%s: %v", uid, err)
-		http.Error(w, "failed list tokens", http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to list tokens")
 		return
 	}
 	defer func() { _ = rows.Close() }()
@@ -96,10 +125,11 @@ func (h *TokensHandler) HandleListTokens(w http.ResponseWriter, r *http.Request)
 		var created time.Time
 		var lastUsed sql.NullTime
 		var expires sql.NullTime
-		if err := rows.Scan(&id, &name, &created, &lastUsed, &expires); err != nil {
+		var scopes []string
+		if err := rows.Scan(&id, &name, &created, &lastUsed, &expires, storage.StringArray(&scopes)); err != nil {
 			continue
 		}
-		rec := map[string]interface{}{"id": id, "name": name, "created_at": created}
+		rec := map[string]interface{}{"id": id, "name": name, "created_at": created, "scopes": scopes}
 		if lastUsed.Valid {
 			rec["last_used_at"] = lastUsed.Time
 		} else {
@@ -124,12 +154,12 @@ func (h *TokensHandler) HandleRevokeToken(w http.ResponseWriter, r *http.Request
 	res, err := h.DB.Exec(`DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2`, id, uid)
 	if err != nil {
 		log.Printf("[tokens] DB delete error for id=%s user=%s: %v", id, uid, err)
-		http.Error(w, "failed revoke", http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "Failed to revoke token")
 		return
 	}
 	n, _ := res.RowsAffected()
 	if n == 0 {
-		http.Error(w, "not found", http.StatusNotFound)
+		respondError(w, r, http.StatusNotFound, "Token not found")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// TransferStatus is the bytes served from a user's files this month against the
+// monthly transfer cap
+type TransferStatus struct {
+	Month    string                `json:"month"` // YYYY-MM, UTC
+	Bytes    int64                 `json:"bytes"`
+	CapBytes int64                 `json:"cap_bytes,omitempty"` // 0 = no cap
+	ResetsAt time.Time             `json:"resets_at"`           // start of the next month
+	Days     []storage.TransferDay `json:"days,omitempty"`      // days of the month with any transfer
+}
+
+// monthStart returns the first instant of the calendar month (UTC) of t
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// meteredWriter counts the body bytes of a successful response, for the transfer
+// of the owner of the files served
+type meteredWriter struct {
+	http.ResponseWriter
+	ownerID string
+	kind    string
+	status  int
+	bytes   int64
+}
+
+func (m *meteredWriter) WriteHeader(code int) {
+	if m.status == 0 {
+		m.status = code
+	}
+	m.ResponseWriter.WriteHeader(code)
+}
+
+func (m *meteredWriter) Write(p []byte) (int, error) {
+	if m.status == 0 {
+		m.status = http.StatusOK
+	}
+	n, err := m.ResponseWriter.Write(p)
+	if m.status < http.StatusMultipleChoices {
+		m.bytes += int64(n)
+	}
+	return n, err
+}
+
+// Flush passes through to the underlying writer when it supports flushing
+func (m *meteredWriter) Flush() {
+	_ = http.NewResponseController(m.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (m *meteredWriter) Unwrap() http.ResponseWriter {
+	return m.ResponseWriter
+}
+
+// beginTransfer is called before serving the files of ownerID. It refuses with
+// 429 once the owner's monthly transfer cap is used up (ok is false), and
+// otherwise returns the writer to serve through; recordTransfer adds what it
+// sent. If the cap cannot be checked the files are served.
+func beginTransfer(w http.ResponseWriter, r *http.Request, pgStore *storage.PostgresStore, ownerID, kind string) (*meteredWriter, bool) {
+	mw := &meteredWriter{ResponseWriter: w, ownerID: ownerID, kind: kind}
+
+	limit, err := pgStore.GetTransferCap(r.Context())
+	if err != nil {
+		log.Printf("[WARN] Failed to get transfer cap: %v", err)
+		return mw, true
+	}
+	if limit == 0 {
+		return mw, true
+	}
+	now := time.Now()
+	used, err := pgStore.MonthTransferBytes(r.Context(), ownerID, now)
+	if err != nil {
+		log.Printf("[WARN] Failed to check transfer of %s: %v", ownerID, err)
+		return mw, true
+	}
+	if used < limit {
+		return mw, true
+	}
+
+	resets := monthStart(now).AddDate(0, 1, 0)
+	w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(resets).Seconds())+1))
+	apierror.Write(w, r, apierror.New(http.StatusTooManyRequests, apierror.CodeTransferCapExceeded,
+		"The monthly transfer limit for these files is used up").
+		With("resets_at", resets))
+	return nil, false
+}
+
+// recordTransfer adds the bytes sent through mw to its owner's day. Like
+// recordDownload it runs after the body was sent, so it must survive the client
+// going away.
+func recordTransfer(r *http.Request, pgStore *storage.PostgresStore, mw *meteredWriter) {
+	if mw.bytes == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), recordDownloadTimeout)
+	defer cancel()
+
+	rec := storage.TransferRecord{UserID: mw.ownerID, Kind: mw.kind, Bytes: mw.bytes}
+	if err := pgStore.RecordTransfer(ctx, rec); err != nil {
+		log.Printf("[ERROR] Failed to record %d bytes of %s transfer for %s: %v", mw.bytes, mw.kind, mw.ownerID, err)
+	}
+}
+
+// HandleGetTransfer returns the bytes served from the user's files this month,
+// per day and kind, against the monthly transfer cap
+func (h *UserHandler) HandleGetTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctx.Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	now := time.Now()
+	month := monthStart(now)
+	days, err := h.pgStore.UserTransferDays(ctx, userID, month, now)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get transfer of %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get transfer")
+		return
+	}
+	status := TransferStatus{Month: month.Format("2006-01"), ResetsAt: month.AddDate(0, 1, 0), Days: days}
+	for _, d := range days {
+		status.Bytes += d.Bytes
+	}
+	if status.CapBytes, err = h.pgStore.GetTransferCap(ctx); err != nil {
+		log.Printf("[ERROR] Failed to get transfer cap: %v", err)
+	}
+	respondJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// TrashedFileInfo is a file in the trash
+type TrashedFileInfo struct {
+	FileInfo
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"` // deleted for good after this
+}
+
+// deletePermanently removes a file's object and metadata
+func (h *FilesHandler) deletePermanently(ctx context.Context, metadata *storage.FileMetadata) error {
+	// Delete file from MinIO storage
+	if err := h.minioStorage.DeleteFile(ctx, metadata.MinIOPath); err != nil {
+		log.Printf("[ERROR] Failed to delete object of file %s: %v", metadata.FileID, err)
+		return err
+	}
+
+	// Delete metadata from PostgreSQL
+	if err := h.pgStore.DeleteFileMetadata(ctx, metadata.FileID); err != nil {
+		log.Printf("[ERROR] Failed to delete metadata of file %s: %v", metadata.FileID, err)
+		return err
+	}
+	invalidateFileMetadata(ctx, h.redisCache, metadata.FileID)
+	return nil
+}
+
+// HandleListTrash lists the user's deleted files that can still be restored
+func (h *FilesHandler) HandleListTrash(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	metadataList, err := h.pgStore.ListTrashedFiles(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve trash")
+		return
+	}
+
+	files := make([]TrashedFileInfo, 0, len(metadataList))
+	var size int64
+	for _, metadata := range metadataList {
+		files = append(files, TrashedFileInfo{
+			FileInfo:  newFileInfo(metadata),
+			DeletedAt: *metadata.DeletedAt,
+			PurgeAt:   metadata.DeletedAt.Add(h.trashRetention),
+		})
+		size += metadata.Size
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"files":      files,
+		"count":      len(files),
+		"total_size": size,
+	})
+}
+
+// HandleRestoreFile moves a file out of the trash
+func (h *FilesHandler) HandleRestoreFile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	metadata, err := h.pgStore.RestoreFile(r.Context(), fileID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found in trash")
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to restore file %s: %v", fileID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to restore file")
+		return
+	}
+	invalidateFileMetadata(r.Context(), h.redisCache, fileID)
+
+	respondJSON(w, http.StatusOK, newFileInfo(metadata))
+}
+
+// HandleEmptyTrash permanently deletes every file in the user's trash
+func (h *FilesHandler) HandleEmptyTrash(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	metadataList, err := h.pgStore.ListTrashedFiles(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve trash")
+		return
+	}
+
+	deleted := 0
+	var freed int64
+	for _, metadata := range metadataList {
+		if err := h.deletePermanently(r.Context(), metadata); err != nil {
+			// Keep going; whatever is left stays in the trash for another try
+			continue
+		}
+		deleted++
+		freed += metadata.Size
+	}
+
+	if deleted < len(metadataList) {
+		apierror.Write(w, r, apierror.New(http.StatusInternalServerError, "", "Failed to delete some files").
+			With("deleted", deleted).
+			With("freed_bytes", freed))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":     "Trash emptied",
+		"deleted":     deleted,
+		"freed_bytes": freed,
+	})
+}
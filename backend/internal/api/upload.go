@@ -1,17 +1,31 @@
 package api
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
 	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/pipeline"
+	"github.com/sachinthra/file-locker/backend/internal/quarantine"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
 )
 
@@ -19,13 +33,27 @@ type UploadHandler struct {
 	minioStorage *storage.MinIOStorage
 	redisCache   *storage.RedisCache
 	pgStore      *storage.PostgresStore
+	namePolicy   FileNamePolicy
+	pipeline     *pipeline.Pipeline
+	quarantine   *quarantine.Quarantine // nil when no rules are configured
+	jobQueue     *jobs.Queue
+	workers      int // parallel encryption workers per upload
+
+	// regenerateCopyKeys gives copies their own data key (features.uploads.copy_keys)
+	regenerateCopyKeys bool
 }
 
-func NewUploadHandler(minioStorage *storage.MinIOStorage, redisCache *storage.RedisCache, pgStore *storage.PostgresStore) *UploadHandler {
+func NewUploadHandler(minioStorage *storage.MinIOStorage, redisCache *storage.RedisCache, pgStore *storage.PostgresStore, namePolicy FileNamePolicy, pipeline *pipeline.Pipeline, quarantine *quarantine.Quarantine, jobQueue *jobs.Queue, workers int, copyKeys string) *UploadHandler {
 	return &UploadHandler{
-		minioStorage: minioStorage,
-		redisCache:   redisCache,
-		pgStore:      pgStore,
+		minioStorage:       minioStorage,
+		redisCache:         redisCache,
+		pgStore:            pgStore,
+		namePolicy:         namePolicy,
+		pipeline:           pipeline,
+		quarantine:         quarantine,
+		jobQueue:           jobQueue,
+		workers:            workers,
+		regenerateCopyKeys: copyKeys == "regenerate",
 	}
 }
 
@@ -37,133 +65,594 @@ type UploadResponse struct {
 	CreatedAt     time.Time  `json:"created_at"`
 	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
 	DownloadCount int        `json:"download_count"`
+	SHA256        string     `json:"sha256"`
+	Folder        string     `json:"folder"`
+	ModifiedAt    *time.Time `json:"modified_at,omitempty"`
+	Instant       bool       `json:"instant,omitempty"`     // created from content the user already had
+	Quarantined   bool       `json:"quarantined,omitempty"` // held back for review; share links do not serve it yet
+}
+
+// PrecheckRequest describes a file the client is about to upload
+type PrecheckRequest struct {
+	SHA256      string     `json:"sha256"`
+	Size        int64      `json:"size"`
+	FileName    string     `json:"file_name"`
+	Description string     `json:"description"`
+	Tags        []string   `json:"tags"`
+	ExpireAfter *int       `json:"expire_after"` // in hours; omitted = the user's default expiry, 0 = never
+	Folder      string     `json:"folder"`       // "" for the root
+	ModifiedAt  *time.Time `json:"modified_at"`
 }
 
+// maxUploadFileSize caps a single uploaded file
+const maxUploadFileSize = int64(500 << 20)
+
+// maxUploadFieldsSize caps the form fields other than the file, combined
+const maxUploadFieldsSize = 1 << 20
+
+// errFileTooLarge ends the upload of a file larger than maxUploadFileSize
+var errFileTooLarge = errors.New("file too large")
+
 func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 	// Get userID from context
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
-	// 10 MB is plenty for headers and small fields. Large files will stream from disk.
-	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		respondError(w, http.StatusBadRequest, "Failed to parse form")
+	// The form is read part by part: fields are kept in memory and the file is
+	// encrypted and sent to MinIO as it arrives, without a temp file. Fields may
+	// come before or after the file (the CLI sends sha256 last).
+	mr, err := r.MultipartReader()
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Failed to parse form")
 		return
 	}
-
-	// Get file from form
-	file, header, err := r.FormFile("file")
+	fields := url.Values{}
+	part, err := nextFilePart(mr, fields)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "No file provided")
+		respondFormError(w, r, err)
 		return
 	}
-	defer func() { _ = file.Close() }()
+	defer func() { _ = part.Close() }()
 
-	// Check file size limit (500MB)
-	maxSize := int64(500 << 20)
-	if header.Size > maxSize {
-		respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("File too large. Max size: %d MB", maxSize/(1<<20)))
+	fileName, err := h.namePolicy.Apply(part.FileName())
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid filename: "+err.Error())
 		return
 	}
 
-	// Get optional parameters
-	expireAfterStr := r.FormValue("expire_after") // in hours
-	tagsStr := r.FormValue("tags")                // comma-separated
-	description := r.FormValue("description")     // file description
-
-	// Parse tags
-	var tags []string
-	if tagsStr != "" {
-		tags = strings.Split(tagsStr, ",")
-		for i := range tags {
-			tags[i] = strings.TrimSpace(tags[i])
-		}
-	}
-
-	// Parse expiration
-	var expiresAt *time.Time
-	if expireAfterStr != "" {
-		hours, err := strconv.Atoi(expireAfterStr)
-		if err == nil && hours > 0 {
-			expiry := time.Now().Add(time.Duration(hours) * time.Hour)
-			expiresAt = &expiry
-		}
-	}
-
 	// Generate unique fileID
 	fileID := uuid.New().String()
 
 	// Generate encryption key
 	key, err := crypto.GenerateKey()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to generate encryption key")
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate encryption key")
 		return
 	}
 
-	// Create encrypted stream
-	encryptedReader, err := crypto.EncryptStream(file, key)
+	// Create encrypted stream, hashing the plaintext on the way. The parallel
+	// encryptor's workers stop with encryptCtx if MinIO gives up early.
+	encryptCtx, cancelEncrypt := context.WithCancel(r.Context())
+	defer cancelEncrypt()
+	file := &uploadFile{r: part, max: maxUploadFileSize}
+	hasher := sha256.New()
+	encryptedReader, err := crypto.EncryptStreamParallel(encryptCtx, io.TeeReader(file, hasher), key, h.workers)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to encrypt file")
+		respondError(w, r, http.StatusInternalServerError, "Failed to encrypt file")
 		return
 	}
 
 	// Determine content type
-	contentType := header.Header.Get("Content-Type")
+	contentType := part.Header.Get("Content-Type")
 	if contentType == "" {
 		contentType = "application/octet-stream"
 	}
 
-	// MinIO path
-	minioPath := fmt.Sprintf("%s/%s", userID, fileID)
+	// MinIO path (in the user's own bucket with bucket isolation)
+	minioPath, err := h.minioStorage.ObjectPath(r.Context(), userID, fileID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to prepare storage for user %s: %v", userID, err)
+		if !respondStorageUnavailable(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, "Failed to upload file")
+		}
+		return
+	}
+
+	// Upload to MinIO; the size is only known once the part has been read
+	err = h.minioStorage.SaveFile(r.Context(), minioPath, encryptedReader, -1, "application/octet-stream", storage.ObjectLabels{Owner: userID, Tier: storage.TierFile})
+	if err != nil {
+		cancelEncrypt()
+		var maxErr *http.MaxBytesError
+		switch readErr := file.Err(); {
+		case errors.Is(readErr, errFileTooLarge):
+			respondErrorCode(w, r, http.StatusRequestEntityTooLarge, apierror.CodeFileTooLarge, fmt.Sprintf("File too large. Max size: %d MB", maxUploadFileSize/(1<<20)))
+		case errors.As(readErr, &maxErr):
+			respondErrorCode(w, r, http.StatusRequestEntityTooLarge, apierror.CodeFileTooLarge, fmt.Sprintf("Upload too large. Max size: %d MB", maxErr.Limit/(1<<20)))
+		case readErr != nil:
+			respondError(w, r, http.StatusBadRequest, "Failed to read uploaded file")
+		case respondStorageUnavailable(w, r, err):
+		case storage.IsIntegrityError(err):
+			log.Printf("[ERROR] Upload by user %s was not stored intact: %v", userID, err)
+			respondError(w, r, http.StatusBadGateway, "The storage did not keep the upload intact, try again")
+		default:
+			respondError(w, r, http.StatusInternalServerError, "Failed to upload file")
+		}
+		return
+	}
+	size := file.n
+	encryptedSize := size + 16 // 16 bytes for IV
+
+	discard := func() {
+		if err := h.minioStorage.DeleteFile(r.Context(), minioPath); err != nil {
+			log.Printf("[ERROR] Failed to delete rejected upload %s: %v", minioPath, err)
+		}
+	}
+
+	// Fields sent after the file
+	for {
+		if _, err := nextFilePart(mr, fields); err != nil {
+			if err != io.EOF {
+				discard()
+				respondFormError(w, r, err)
+				return
+			}
+			break
+		}
+	}
+
+	// Get optional parameters
+	expireAfterStr := fields.Get("expire_after") // in hours
+	tagsStr := fields.Get("tags")                // comma-separated
+	description := fields.Get("description")     // file description
+
+	checksum := strings.ToLower(fields.Get("sha256")) // client's SHA-256, verified below
 
-	// Upload to MinIO (encrypted size is original size + IV size)
-	encryptedSize := header.Size + 16 // 16 bytes for IV
-	err = h.minioStorage.SaveFile(r.Context(), minioPath, encryptedReader, encryptedSize, "application/octet-stream")
+	folder, err := normalizeFolder(fields.Get("folder"))
+	if err != nil {
+		discard()
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	modifiedAt, err := parseModifiedAt(fields.Get("modified_at"))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to upload file")
+		discard()
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if checksum != "" {
+		if b, err := hex.DecodeString(checksum); err != nil || len(b) != sha256.Size {
+			discard()
+			respondError(w, r, http.StatusBadRequest, "Invalid sha256: expected 64 hex characters")
+			return
+		}
+	}
+
+	// Parse tags
+	var tags []string
+	if tagsStr != "" {
+		tags = strings.Split(tagsStr, ",")
+		for i := range tags {
+			tags[i] = strings.TrimSpace(tags[i])
+		}
+	}
+
+	// Parse expiration; without one the user's default expiry applies
+	var hours int
+	if expireAfterStr != "" {
+		hours, _ = strconv.Atoi(expireAfterStr)
+	} else {
+		hours = userPreferences(r.Context(), h.pgStore, userID).DefaultExpiryHours
+	}
+	var expiresAt *time.Time
+	if hours > 0 {
+		expiry := time.Now().Add(time.Duration(hours) * time.Hour)
+		expiresAt = &expiry
+	}
+
+	// Reject content that was corrupted on the way
+	sum := hex.EncodeToString(hasher.Sum(nil))
+	if checksum != "" && checksum != sum {
+		log.Printf("[WARN] Checksum mismatch on upload by user %s: client %s, received %s", userID, checksum, sum)
+		discard()
+		apierror.Write(w, r, apierror.New(http.StatusBadRequest, apierror.CodeChecksumMismatch, "Checksum mismatch: the file was corrupted during upload").
+			With("expected", checksum).
+			With("received", sum))
 		return
 	}
 
 	// Encode encryption key for storage
 	encodedKey := base64.StdEncoding.EncodeToString(key)
 
-	// Create metadata
+	// Create metadata. Timestamps are truncated to PostgreSQL's precision so the
+	// cached copy matches what a later read from the database returns.
+	now := time.Now().Truncate(time.Microsecond)
 	metadata := &storage.FileMetadata{
 		FileID:        fileID,
 		UserID:        userID,
-		FileName:      header.Filename,
+		FileName:      fileName,
 		Description:   description,
 		MimeType:      contentType,
-		Size:          header.Size,
+		Size:          size,
 		EncryptedSize: encryptedSize,
 		MinIOPath:     minioPath,
 		EncryptionKey: encodedKey,
-		CreatedAt:     time.Now(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
 		ExpiresAt:     expiresAt,
 		Tags:          tags,
 		DownloadCount: 0,
+		SHA256:        sum,
+		Folder:        folder,
+		ModifiedAt:    modifiedAt,
 	}
 
+	h.quarantine.CheckUpload(r.Context(), metadata)
+
 	// Save metadata to PostgreSQL
 	log.Printf("[DEBUG] Saving file metadata: FileID=%s, UserID=%s, FileName=%s",
-		fileID, userID, header.Filename)
+		fileID, userID, fileName)
 	if err := h.pgStore.SaveFileMetadata(r.Context(), metadata); err != nil {
 		log.Printf("[ERROR] Failed to save file metadata to PostgreSQL: %v", err)
-		respondError(w, http.StatusInternalServerError, "Failed to save file metadata")
+		respondError(w, r, http.StatusInternalServerError, "Failed to save file metadata")
 		return
 	}
+	if err := h.redisCache.CacheFileMetadata(r.Context(), metadata); err != nil {
+		log.Printf("[WARN] Failed to cache metadata for file %s: %v", fileID, err)
+	}
+	// The expiry may only have arrived after the file was stored
+	if expiresAt != nil {
+		labelFileObject(r.Context(), h.minioStorage, metadata)
+	}
+	if err := h.pipeline.Process(r.Context(), fileID); err != nil {
+		log.Printf("[WARN] Failed to queue processing of file %s: %v", fileID, err)
+	}
 	log.Printf("[INFO] File uploaded successfully: FileID=%s, UserID=%s", fileID, userID)
 
 	// Return response
+	w.Header().Set("Location", fileLocation(r, fileID))
 	respondJSON(w, http.StatusCreated, UploadResponse{
 		FileID:        fileID,
-		FileName:      header.Filename,
-		Size:          header.Size,
+		FileName:      fileName,
+		Size:          size,
 		MimeType:      contentType,
 		CreatedAt:     metadata.CreatedAt,
 		ExpiresAt:     expiresAt,
 		DownloadCount: 0,
+		SHA256:        sum,
+		Folder:        folder,
+		ModifiedAt:    modifiedAt,
+		Quarantined:   metadata.QuarantinedAt != nil,
+	})
+}
+
+// HandlePrecheck creates a file without a transfer when the user already stores the
+// same content (matched by SHA-256 and size): the encrypted object is copied inside
+// MinIO under the existing data key. Responds 201 with the new file when it did,
+// and 200 {"exists": false} when the client has to upload the file itself.
+func (h *UploadHandler) HandlePrecheck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+
+	var req PrecheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	checksum := strings.ToLower(req.SHA256)
+	if b, err := hex.DecodeString(checksum); err != nil || len(b) != sha256.Size {
+		respondError(w, r, http.StatusBadRequest, "Invalid sha256: expected 64 hex characters")
+		return
+	}
+	if req.Size < 0 {
+		respondError(w, r, http.StatusBadRequest, "Invalid size")
+		return
+	}
+
+	fileName, err := h.namePolicy.Apply(req.FileName)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "Invalid filename: "+err.Error())
+		return
+	}
+	folder, err := normalizeFolder(req.Folder)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	var modifiedAt *time.Time
+	if req.ModifiedAt != nil {
+		t := req.ModifiedAt.UTC()
+		modifiedAt = &t
+	}
+
+	existing, err := h.pgStore.FindFileByChecksum(r.Context(), userID, checksum, req.Size)
+	if errors.Is(err, storage.ErrFileNotFound) {
+		respondJSON(w, http.StatusOK, map[string]bool{"exists": false})
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR] Upload precheck failed for user %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to check for existing content")
+		return
+	}
+
+	var tags []string
+	for _, tag := range req.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	var hours int
+	if req.ExpireAfter != nil {
+		hours = *req.ExpireAfter
+	} else {
+		hours = userPreferences(r.Context(), h.pgStore, userID).DefaultExpiryHours
+	}
+	var expiresAt *time.Time
+	if hours > 0 {
+		expiry := time.Now().Add(time.Duration(hours) * time.Hour)
+		expiresAt = &expiry
+	}
+
+	metadata, err := h.copyContent(r.Context(), userID, existing, func(m *storage.FileMetadata) {
+		m.FileName = fileName
+		m.Description = req.Description
+		m.ExpiresAt = expiresAt
+		m.Tags = tags
+		m.Folder = folder
+		m.ModifiedAt = modifiedAt
+	})
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "Failed to upload file")
+		return
+	}
+	fileID := metadata.FileID
+	log.Printf("[INFO] Instant upload: FileID=%s, UserID=%s, content of %s", fileID, userID, existing.FileID)
+
+	w.Header().Set("Location", fileLocation(r, fileID))
+	respondJSON(w, http.StatusCreated, UploadResponse{
+		FileID:      fileID,
+		FileName:    fileName,
+		Size:        metadata.Size,
+		MimeType:    metadata.MimeType,
+		CreatedAt:   metadata.CreatedAt,
+		ExpiresAt:   expiresAt,
+		SHA256:      metadata.SHA256,
+		Folder:      metadata.Folder,
+		ModifiedAt:  metadata.ModifiedAt,
+		Instant:     true,
+		Quarantined: metadata.QuarantinedAt != nil,
+	})
+}
+
+// CopyFileRequest places the copy of a file. Omitted fields keep the original's.
+type CopyFileRequest struct {
+	FileName *string `json:"file_name"`
+	Folder   *string `json:"folder"`
+}
+
+// HandleCopyFile copies a file without a transfer: the encrypted object is copied
+// inside MinIO under the original's data key, as for an instant upload. The copy
+// keeps the description, tags, expiry, password and modification time.
+func (h *UploadHandler) HandleCopyFile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(constants.UserIDKey).(string)
+	if !ok {
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
+		return
+	}
+	fileID := chi.URLParam(r, "fileID")
+
+	var req CopyFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	original, err := h.pgStore.GetFileMetadata(r.Context(), fileID)
+	if err != nil || original.UserID != userID {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeFileNotFound, "File not found")
+		return
+	}
+
+	fileName := original.FileName
+	if req.FileName != nil {
+		if fileName, err = h.namePolicy.Apply(*req.FileName); err != nil {
+			respondError(w, r, http.StatusBadRequest, "Invalid filename: "+err.Error())
+			return
+		}
+	}
+	folder := original.Folder
+	if req.Folder != nil {
+		if folder, err = normalizeFolder(*req.Folder); err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	metadata, err := h.copyContent(r.Context(), userID, original, func(m *storage.FileMetadata) {
+		m.FileName = fileName
+		m.Description = original.Description
+		m.ExpiresAt = original.ExpiresAt
+		m.Tags = original.Tags
+		m.PasswordHash = original.PasswordHash
+		m.Folder = folder
+		m.ModifiedAt = original.ModifiedAt
 	})
+	if err != nil {
+		if !respondStorageUnavailable(w, r, err) {
+			respondError(w, r, http.StatusInternalServerError, "Failed to copy file")
+		}
+		return
+	}
+	log.Printf("[INFO] File copied: FileID=%s, UserID=%s, copy of %s", metadata.FileID, userID, original.FileID)
+
+	w.Header().Set("Location", fileLocation(r, metadata.FileID))
+	respondJSON(w, http.StatusCreated, newFileInfo(metadata))
+}
+
+// copyContent creates a file for userID holding the content of src: the encrypted
+// object is copied inside MinIO under the same data key. With copy_keys set to
+// regenerate, a job then re-encrypts the copy with a key of its own. set fills in
+// the metadata that is not about the content (name, folder, tags, ...) before it
+// is saved. Failures are logged; nothing is left behind.
+func (h *UploadHandler) copyContent(ctx context.Context, userID string, src *storage.FileMetadata, set func(*storage.FileMetadata)) (*storage.FileMetadata, error) {
+	key, err := h.pgStore.DataKey(ctx, src)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get data key of file %s: %v", src.FileID, err)
+		return nil, err
+	}
+
+	fileID := uuid.New().String()
+	minioPath, err := h.minioStorage.ObjectPath(ctx, userID, fileID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to prepare storage for user %s: %v", userID, err)
+		return nil, err
+	}
+	if err := h.minioStorage.CopyFile(ctx, src.MinIOPath, minioPath, storage.ObjectLabels{Owner: userID, Tier: storage.TierFile}); err != nil {
+		log.Printf("[ERROR] Failed to copy %s to %s: %v", src.MinIOPath, minioPath, err)
+		return nil, err
+	}
+
+	now := time.Now().Truncate(time.Microsecond)
+	metadata := &storage.FileMetadata{
+		FileID:        fileID,
+		UserID:        userID,
+		MimeType:      src.MimeType,
+		Size:          src.Size,
+		EncryptedSize: src.EncryptedSize,
+		MinIOPath:     minioPath,
+		EncryptionKey: base64.StdEncoding.EncodeToString(key),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		SHA256:        src.SHA256,
+
+		// A copy of content held back for review is held back too
+		QuarantinedAt:    src.QuarantinedAt,
+		QuarantineReason: src.QuarantineReason,
+	}
+	set(metadata)
+	h.quarantine.CheckUpload(ctx, metadata)
+
+	if err := h.pgStore.SaveFileMetadata(ctx, metadata); err != nil {
+		log.Printf("[ERROR] Failed to save file metadata to PostgreSQL: %v", err)
+		if err := h.minioStorage.DeleteFile(ctx, minioPath); err != nil {
+			log.Printf("[ERROR] Failed to delete copied object %s: %v", minioPath, err)
+		}
+		return nil, err
+	}
+	if err := h.redisCache.CacheFileMetadata(ctx, metadata); err != nil {
+		log.Printf("[WARN] Failed to cache metadata for file %s: %v", fileID, err)
+	}
+	if metadata.ExpiresAt != nil {
+		labelFileObject(ctx, h.minioStorage, metadata)
+	}
+	if err := h.pipeline.Process(ctx, fileID); err != nil {
+		log.Printf("[WARN] Failed to queue processing of file %s: %v", fileID, err)
+	}
+	if h.regenerateCopyKeys {
+		if _, err := h.jobQueue.Enqueue(ctx, jobs.TypeFileReencrypt, jobs.FilePayload{FileID: fileID}); err != nil {
+			log.Printf("[WARN] Failed to queue re-encryption of copy %s: %v", fileID, err)
+		}
+	}
+	return metadata, nil
+}
+
+// parseModifiedAt parses a client-set modification time (RFC 3339); "" is none
+func parseModifiedAt(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, errors.New("modified_at must be an RFC 3339 timestamp")
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// nextFilePart reads form fields into fields until it reaches a file part, which
+// it returns unread. It returns io.EOF at the end of the form.
+func nextFilePart(mr *multipart.Reader, fields url.Values) (*multipart.Part, error) {
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FileName() != "" {
+			if part.FormName() == "file" {
+				return part, nil
+			}
+			continue // other files are skipped
+		}
+
+		room := int64(maxUploadFieldsSize)
+		for _, values := range fields {
+			for _, v := range values {
+				room -= int64(len(v))
+			}
+		}
+		value, err := io.ReadAll(io.LimitReader(part, room+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(value)) > room {
+			return nil, errors.New("form fields too large")
+		}
+		fields.Add(part.FormName(), string(value))
+	}
+}
+
+// respondFormError reports an error from reading the upload form
+func respondFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxErr *http.MaxBytesError
+	switch {
+	case err == io.EOF:
+		respondError(w, r, http.StatusBadRequest, "No file provided")
+	case errors.As(err, &maxErr):
+		respondErrorCode(w, r, http.StatusRequestEntityTooLarge, apierror.CodeFileTooLarge, fmt.Sprintf("Upload too large. Max size: %d MB", maxErr.Limit/(1<<20)))
+	default:
+		respondError(w, r, http.StatusBadRequest, "Failed to parse form")
+	}
+}
+
+// uploadFile counts the bytes of the uploaded file and fails once they pass max.
+// It keeps the first read error, which MinIO only reports as a failed upload.
+type uploadFile struct {
+	r   io.Reader
+	max int64
+	n   int64 // valid once the file has been read to the end
+
+	mu  sync.Mutex
+	err error
+}
+
+func (f *uploadFile) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	f.n += int64(n)
+	if f.n > f.max && (err == nil || err == io.EOF) {
+		err = errFileTooLarge
+	}
+	if err != nil && err != io.EOF {
+		f.mu.Lock()
+		if f.err == nil {
+			f.err = err
+		}
+		f.mu.Unlock()
+	}
+	return n, err
+}
+
+// Err returns the first error reading the file, other than io.EOF
+func (f *uploadFile) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
 }
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/internal/usage"
+)
+
+// UsageRecordsResponse is the usage of every user in a month
+type UsageRecordsResponse struct {
+	Month    string                `json:"month"`    // YYYY-MM
+	Finished bool                  `json:"finished"` // false while days of the month are still to be added
+	Records  []storage.UsageRecord `json:"records"`
+}
+
+// usageMonth returns the month of ?month=, the last finished one by default
+func usageMonth(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	month, err := usage.ParseMonth(r.URL.Query().Get("month"), time.Now())
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "month must be YYYY-MM")
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// HandleGetUsageRecords returns the usage records of a month (?month=YYYY-MM,
+// the last finished month by default): storage-days and download bandwidth per
+// user, for chargeback
+func (h *AdminHandler) HandleGetUsageRecords(w http.ResponseWriter, r *http.Request) {
+	month, ok := usageMonth(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := h.pg.ListUsageRecords(r.Context(), month)
+	if err != nil {
+		log.Printf("[admin] Failed to list usage records: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get usage records")
+		return
+	}
+	respondJSON(w, http.StatusOK, UsageRecordsResponse{
+		Month:    month.Format("2006-01"),
+		Finished: usage.Finished(month, time.Now()),
+		Records:  records,
+	})
+}
+
+// HandleExportUsageRecords returns the usage records of a month as CSV, for
+// billing systems that import files
+func (h *AdminHandler) HandleExportUsageRecords(w http.ResponseWriter, r *http.Request) {
+	month, ok := usageMonth(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := h.pg.ListUsageRecords(r.Context(), month)
+	if err != nil {
+		log.Printf("[admin] Failed to list usage records: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get usage records")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", contentDisposition("attachment", "usage-"+month.Format("2006-01")+".csv"))
+	if err := usage.WriteCSV(w, records); err != nil {
+		log.Printf("[admin] Failed to write usage records: %v", err)
+	}
+}
+
+// HandleSendUsageRecords queues the delivery of a finished month's usage records
+// to the billing webhook, whether or not they were posted on schedule already
+func (h *AdminHandler) HandleSendUsageRecords(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	month, ok := usageMonth(w, r)
+	if !ok {
+		return
+	}
+	if !h.usage.Webhook() {
+		respondError(w, r, http.StatusBadRequest, "No usage webhook configured (features.usage.webhook.url)")
+		return
+	}
+	if !usage.Finished(month, time.Now()) {
+		respondError(w, r, http.StatusBadRequest, "Only the records of a finished month can be sent")
+		return
+	}
+
+	job, err := h.usage.Queue(r.Context(), month)
+	if err != nil {
+		log.Printf("[admin] Failed to queue usage delivery: %v", err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to queue usage records")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "USAGE_RECORDS_SENT", "job", job.ID, map[string]interface{}{
+		"month": month.Format("2006-01"),
+	}, GetClientIP(r))
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "Usage records queued",
+		"job_id":  job.ID,
+	})
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/reports"
+)
+
+// usageReportPeriod returns the bounds of the latest finished period named in the URL
+func usageReportPeriod(w http.ResponseWriter, r *http.Request) (period string, from, to time.Time, ok bool) {
+	period = chi.URLParam(r, "period")
+	from, to, err := reports.LastPeriod(period, time.Now())
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "period must be weekly or monthly")
+		return "", time.Time{}, time.Time{}, false
+	}
+	return period, from, to, true
+}
+
+// HandleGetUsageReport returns the usage report of the last finished week or
+// month, as the scheduled report would send it (nothing is sent)
+func (h *AdminHandler) HandleGetUsageReport(w http.ResponseWriter, r *http.Request) {
+	period, from, to, ok := usageReportPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.reporter.Generate(r.Context(), period, from, to)
+	if err != nil {
+		log.Printf("[admin] Failed to generate %s report: %v", period, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to generate report")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"report": report,
+		"title":  report.Title(),
+		"text":   report.Text(),
+	})
+}
+
+// HandleSendUsageReport queues the usage report of the last finished week or month
+// for delivery to the configured recipients and webhook, whether or not it was
+// sent on schedule already
+func (h *AdminHandler) HandleSendUsageReport(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value(constants.UserIDKey).(string)
+	period, from, to, ok := usageReportPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.reporter.Queue(r.Context(), period, from, to)
+	if err != nil {
+		log.Printf("[admin] Failed to queue %s report: %v", period, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to queue report")
+		return
+	}
+
+	_ = h.auditLogger.LogAdminAction(r.Context(), adminID, "USAGE_REPORT_SENT", "job", job.ID, map[string]interface{}{
+		"period": period,
+		"from":   from,
+	}, GetClientIP(r))
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "Report queued",
+		"job_id":  job.ID,
+	})
+}
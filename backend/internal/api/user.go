@@ -5,18 +5,24 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/mail"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserHandler struct {
-	pgStore *storage.PostgresStore
+	pgStore    *storage.PostgresStore
+	minioStore *storage.MinIOStorage // avatars
+	mailer     mail.Sender           // email change verification
 }
 
-func NewUserHandler(pgStore *storage.PostgresStore) *UserHandler {
+func NewUserHandler(pgStore *storage.PostgresStore, minioStore *storage.MinIOStorage, mailer mail.Sender) *UserHandler {
 	return &UserHandler{
-		pgStore: pgStore,
+		pgStore:    pgStore,
+		minioStore: minioStore,
+		mailer:     mailer,
 	}
 }
 
@@ -36,32 +42,32 @@ func (h *UserHandler) HandleChangePassword(w http.ResponseWriter, r *http.Reques
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value(constants.UserIDKey).(string)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "User not authenticated")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 		return
 	}
 
 	// Parse request
 	var req ChangePasswordRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, r, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate input
 	if req.CurrentPassword == "" {
-		respondError(w, http.StatusBadRequest, "Current password is required")
+		respondError(w, r, http.StatusBadRequest, "Current password is required")
 		return
 	}
 	if req.NewPassword == "" {
-		respondError(w, http.StatusBadRequest, "New password is required")
+		respondError(w, r, http.StatusBadRequest, "New password is required")
 		return
 	}
 	if len(req.NewPassword) < 6 {
-		respondError(w, http.StatusBadRequest, "New password must be at least 6 characters")
+		respondError(w, r, http.StatusBadRequest, "New password must be at least 6 characters")
 		return
 	}
 	if req.CurrentPassword == req.NewPassword {
-		respondError(w, http.StatusBadRequest, "New password must be different from current password")
+		respondError(w, r, http.StatusBadRequest, "New password must be different from current password")
 		return
 	}
 
@@ -71,14 +77,14 @@ func (h *UserHandler) HandleChangePassword(w http.ResponseWriter, r *http.Reques
 	user, err := h.pgStore.GetUserByID(r.Context(), userID)
 	if err != nil {
 		log.Printf("[ERROR] Failed to get user: %v", err)
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve user")
+		respondError(w, r, http.StatusInternalServerError, "Failed to retrieve user")
 		return
 	}
 
 	// Verify current password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
 		log.Printf("[DEBUG] Current password verification failed for user: %s", userID)
-		respondError(w, http.StatusUnauthorized, "Current password is incorrect")
+		respondErrorCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidCredentials, "Current password is incorrect")
 		return
 	}
 
@@ -86,14 +92,14 @@ func (h *UserHandler) HandleChangePassword(w http.ResponseWriter, r *http.Reques
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
 		log.Printf("[ERROR] Failed to hash new password: %v", err)
-		respondError(w, http.StatusInternalServerError, "Failed to update password")
+		respondError(w, r, http.StatusInternalServerError, "Failed to update password")
 		return
 	}
 
 	// Update password in database
 	if err := h.pgStore.UpdateUserPassword(r.Context(), userID, string(hashedPassword)); err != nil {
 		log.Printf("[ERROR] Failed to update password in database: %v", err)
-		respondError(w, http.StatusInternalServerError, "Failed to update password")
+		respondError(w, r, http.StatusInternalServerError, "Failed to update password")
 		return
 	}
 
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const (
+	largestFilesLimit   = 20
+	defaultGrowthMonths = 12
+	maxGrowthMonths     = 120
+)
+
+// StoredFile is a file in a storage report, which also counts files in the trash
+type StoredFile struct {
+	FileInfo
+	Trashed bool `json:"trashed,omitempty"`
+}
+
+// UserStorageReport is where a user's storage goes: totals, growth per month,
+// the largest files and the breakdown by MIME type
+type UserStorageReport struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	storage.UserStorage
+	QuotaBytes   int64                      `json:"quota_bytes,omitempty"` // per-user quota; 0 = none
+	Growth       []storage.StorageGrowth    `json:"growth"`
+	LargestFiles []StoredFile               `json:"largest_files"`
+	ContentTypes []storage.ContentTypeUsage `json:"content_types"`
+}
+
+// HandleGetUserStorage reports the storage of a user, for quota questions.
+// ?months= sets how many months of growth to return (1-120, default 12).
+func (h *AdminHandler) HandleGetUserStorage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+
+	months := defaultGrowthMonths
+	if v := r.URL.Query().Get("months"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxGrowthMonths {
+			respondError(w, r, http.StatusBadRequest, "months must be between 1 and 120")
+			return
+		}
+		months = n
+	}
+
+	user, err := h.pg.GetUserByID(ctx, userID)
+	if err != nil {
+		respondErrorCode(w, r, http.StatusNotFound, apierror.CodeUserNotFound, "User not found")
+		return
+	}
+
+	report := UserStorageReport{UserID: user.ID, Username: user.Username}
+	if report.UserStorage, err = h.pg.GetUserStorage(ctx, userID); err != nil {
+		log.Printf("[admin] Failed to get storage of user %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get storage report")
+		return
+	}
+	if report.QuotaBytes, err = h.pg.GetStorageQuota(ctx); err != nil {
+		// The report is still useful without the quota
+		log.Printf("[admin] Failed to get storage quota: %v", err)
+	}
+	if report.Growth, err = h.pg.UserStorageGrowth(ctx, userID, months, time.Now()); err != nil {
+		log.Printf("[admin] Failed to get storage growth of user %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get storage report")
+		return
+	}
+	if report.ContentTypes, err = h.pg.UserContentTypes(ctx, userID); err != nil {
+		log.Printf("[admin] Failed to get content types of user %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get storage report")
+		return
+	}
+
+	largest, err := h.pg.LargestUserFiles(ctx, userID, largestFilesLimit)
+	if err != nil {
+		log.Printf("[admin] Failed to list largest files of user %s: %v", userID, err)
+		respondError(w, r, http.StatusInternalServerError, "Failed to get storage report")
+		return
+	}
+	report.LargestFiles = make([]StoredFile, len(largest))
+	for i, metadata := range largest {
+		report.LargestFiles[i] = StoredFile{FileInfo: newFileInfo(metadata), Trashed: metadata.DeletedAt != nil}
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 )
 
 func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
@@ -11,6 +13,12 @@ func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	_ = json.NewEncoder(w).Encode(payload)
 }
 
-func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, map[string]string{"error": message})
+// respondError sends an apierror.APIError with the generic code of status
+func respondError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	apierror.Respond(w, r, status, message)
+}
+
+// respondErrorCode sends an apierror.APIError with a specific code
+func respondErrorCode(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	apierror.RespondCode(w, r, status, code, message)
 }
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"runtime"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// APIVersion is the newest major version of the HTTP API (see APIVersions for all
+// served ones). It changes only with incompatible changes; clients compare it with
+// the version they were built for.
+const APIVersion = 2
+
+// BuildInfo identifies the server build (set with -ldflags at build time)
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildDate string `json:"build_date"`
+}
+
+// VersionHandler serves GET /version: build, API and schema versions and the
+// features enabled on this server, so clients can detect what they can use
+type VersionHandler struct {
+	pg       *storage.PostgresStore
+	build    BuildInfo
+	features map[string]bool
+}
+
+func NewVersionHandler(pg *storage.PostgresStore, build BuildInfo, features map[string]bool) *VersionHandler {
+	return &VersionHandler{pg: pg, build: build, features: features}
+}
+
+func (h *VersionHandler) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	response := map[string]interface{}{
+		"version":                 h.build.Version,
+		"git_commit":              h.build.GitCommit,
+		"build_date":              h.build.BuildDate,
+		"go_version":              runtime.Version(),
+		"api_version":             APIVersion,
+		"api_versions":            APIVersions,
+		"deprecated_api_versions": DeprecatedAPIVersions,
+		"features":                h.features,
+	}
+
+	// The schema version is informational; the endpoint still answers without it
+	version, dirty, err := h.pg.SchemaVersion(r.Context())
+	if err != nil {
+		log.Printf("[WARN] Failed to get schema version: %v", err)
+	} else {
+		response["schema_version"] = version
+		response["schema_dirty"] = dirty
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+)
+
+// Both API versions are served by the same handlers: /api/v2 wraps their JSON
+// responses in an envelope ({data, error, meta}), /api/v1 returns them as they are
+// and is deprecated.
+const (
+	APIV1Prefix = "/api/v1"
+	APIV2Prefix = "/api/v2"
+)
+
+// APIVersions are the API versions served; DeprecatedAPIVersions are still served
+// but will be removed
+var (
+	APIVersions           = []int{1, 2}
+	DeprecatedAPIVersions = []int{1}
+)
+
+// canonicalPath maps an /api/v2 path onto its /api/v1 equivalent, so settings keyed
+// by path prefix (body limits, route timeouts, the maintenance allowlist) apply to
+// both versions
+func canonicalPath(path string) string {
+	if path == APIV2Prefix || strings.HasPrefix(path, APIV2Prefix+"/") {
+		return APIV1Prefix + strings.TrimPrefix(path, APIV2Prefix)
+	}
+	return path
+}
+
+// apiBase is the API prefix the request came in on, for URLs handed back to the client
+func apiBase(r *http.Request) string {
+	if canonicalPath(r.URL.Path) != r.URL.Path {
+		return APIV2Prefix
+	}
+	return APIV1Prefix
+}
+
+// Deprecated marks responses as deprecated (Deprecation header) and links the same
+// path under /api/v2 as successor. A non-zero sunset is announced in the Sunset
+// header.
+func Deprecated(sunset time.Time) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			successor := APIV2Prefix + strings.TrimPrefix(r.URL.Path, APIV1Prefix)
+			w.Header().Set("Deprecation", "true")
+			w.Header().Add("Link", "<"+successor+`>; rel="successor-version"`)
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Envelope is the body of every JSON response under /api/v2. Exactly one of Data and
+// Error is set.
+type Envelope struct {
+	Data  interface{}    `json:"data"`
+	Error *EnvelopeError `json:"error"`
+	Meta  EnvelopeMeta   `json:"meta"`
+}
+
+// EnvelopeError describes a failed request. Code is one of the apierror codes;
+// Details carries extra fields of the error (e.g. the maintenance message).
+type EnvelopeError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+type EnvelopeMeta struct {
+	APIVersion int    `json:"api_version"`
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+// errorCode is the code of an error body, derived from the status for bodies
+// that carry none (e.g. an empty 504 from a timeout)
+func errorCode(status int, body map[string]interface{}) string {
+	if code, ok := body["code"].(string); ok && code != "" {
+		return code
+	}
+	return apierror.CodeForStatus(status)
+}
+
+// maxEnvelopeBody is the largest response buffered to be wrapped. Larger ones are
+// sent as they are rather than held in memory.
+const maxEnvelopeBody = 8 << 20
+
+// EnvelopeV2 wraps the JSON responses of the shared handlers in an Envelope. Error
+// responses are wrapped whatever their content type.
+// Other responses, such as file downloads and streams, pass through unchanged,
+// even for files that are JSON themselves: anything with a Content-Disposition or
+// Content-Range is file content.
+func EnvelopeV2(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew := &envelopeWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r)
+		ew.finish(middleware.GetReqID(r.Context()))
+	})
+}
+
+// envelopeWriter buffers a response that is to be wrapped; the decision is made
+// when the status is written
+type envelopeWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	buffered    bool
+	buf         bytes.Buffer
+}
+
+func (w *envelopeWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	w.buffered = status >= 400 || (strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") && !fileContent(w.Header()))
+	if !w.buffered {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+// fileContent reports whether response headers are those of a file being served
+// (a download, a stream or a range of one) rather than of a handler's JSON
+func fileContent(h http.Header) bool {
+	return h.Get("Content-Disposition") != "" || h.Get("Content-Range") != ""
+}
+
+func (w *envelopeWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffered && w.buf.Len()+len(p) > maxEnvelopeBody {
+		log.Printf("[api] %d response over %d bytes, sent without the v2 envelope", w.status, maxEnvelopeBody)
+		w.buffered = false
+		w.ResponseWriter.WriteHeader(w.status)
+		if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+			return 0, err
+		}
+		w.buf = bytes.Buffer{}
+	}
+	if w.buffered {
+		return w.buf.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush only reaches the client for responses that are not wrapped
+func (w *envelopeWriter) Flush() {
+	if !w.buffered {
+		_ = http.NewResponseController(w.ResponseWriter).Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController (used for transfer deadlines) reach the connection
+func (w *envelopeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *envelopeWriter) finish(requestID string) {
+	if !w.buffered {
+		return
+	}
+	body := bytes.TrimSpace(w.buf.Bytes())
+	env := Envelope{Meta: EnvelopeMeta{APIVersion: 2, RequestID: requestID}}
+
+	if w.status < 400 {
+		// Bodiless responses (204, 304) stay bodiless
+		if len(body) == 0 || !json.Valid(body) {
+			w.ResponseWriter.WriteHeader(w.status)
+			_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+			return
+		}
+		env.Data = json.RawMessage(body)
+	} else {
+		env.Error = envelopeError(w.status, body)
+	}
+
+	w.Header().Del("Content-Length")
+	w.Header().Set("Content-Type", "application/json")
+	w.ResponseWriter.WriteHeader(w.status)
+	_ = json.NewEncoder(w.ResponseWriter).Encode(env)
+}
+
+// envelopeError turns an error body (an apierror.APIError, or plain text) into an
+// EnvelopeError. The request ID moves to the envelope's meta.
+func envelopeError(status int, body []byte) *EnvelopeError {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		message := string(body)
+		if message == "" {
+			message = http.StatusText(status)
+		}
+		return &EnvelopeError{Code: errorCode(status, nil), Message: message}
+	}
+
+	e := &EnvelopeError{Code: errorCode(status, fields)}
+	e.Message, _ = fields["error"].(string)
+	if e.Message == "" {
+		e.Message = http.StatusText(status)
+	}
+	for key, value := range fields {
+		if key == "error" || key == "code" || key == "request_id" {
+			continue
+		}
+		if e.Details == nil {
+			e.Details = make(map[string]interface{})
+		}
+		e.Details[key] = value
+	}
+	return e
+}
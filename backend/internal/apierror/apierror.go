@@ -0,0 +1,197 @@
+// Package apierror is the error response of the HTTP API, shared by the handlers
+// and the auth middleware:
+//
+//	{"error": "File has expired", "code": "FILE_EXPIRED", "request_id": "host/abc-000042"}
+//
+// error is meant for people, code for programs, and request_id ties the response to
+// the server logs. For clients asking for another language in Accept-Language,
+// error is the translation of the code (see package i18n) when there is one.
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sachinthra/file-locker/backend/internal/i18n"
+)
+
+// Error codes. The generic ones follow the HTTP status; the others name a specific
+// condition clients may want to handle.
+const (
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	CodeConflict           = "CONFLICT"
+	CodeGone               = "GONE"
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+	CodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMedia   = "UNSUPPORTED_MEDIA_TYPE"
+	CodeRangeNotSatisfied  = "RANGE_NOT_SATISFIABLE"
+	CodeRateLimited        = "RATE_LIMITED"
+	CodeInternal           = "INTERNAL_ERROR"
+	CodeUnavailable        = "SERVICE_UNAVAILABLE"
+	CodeTimeout            = "TIMEOUT"
+
+	// Authentication and accounts
+	CodeAuthRequired       = "AUTH_REQUIRED"
+	CodeInvalidToken       = "INVALID_TOKEN"
+	CodeInvalidCredentials = "INVALID_CREDENTIALS"
+	CodeAccountPending     = "ACCOUNT_PENDING"
+	CodeAccountRejected    = "ACCOUNT_REJECTED"
+	CodeAccountSuspended   = "ACCOUNT_SUSPENDED"
+	CodeAdminRequired      = "ADMIN_REQUIRED"
+	CodeInsufficientScope  = "INSUFFICIENT_SCOPE"
+	CodeUserNotFound       = "USER_NOT_FOUND"
+	CodeUsernameTaken      = "USERNAME_TAKEN"
+	CodeEmailTaken         = "EMAIL_TAKEN"
+
+	// Wrong, expired or used email verification code
+	CodeVerificationInvalid = "VERIFICATION_INVALID"
+
+	// Files
+	CodeFileNotFound         = "FILE_NOT_FOUND"
+	CodeFileExpired          = "FILE_EXPIRED"
+	CodeFileTooLarge         = "FILE_TOO_LARGE"
+	CodeFilePasswordRequired = "FILE_PASSWORD_REQUIRED"
+	CodeFilePasswordInvalid  = "FILE_PASSWORD_INVALID"
+	CodeChecksumMismatch     = "CHECKSUM_MISMATCH"
+	// Not enough temp or object storage space left for the upload
+	CodeInsufficientStorage = "INSUFFICIENT_STORAGE"
+	// The user stayed over the storage quota past the grace period
+	CodeQuotaExceeded = "QUOTA_EXCEEDED"
+	// The owner's files were served up to the monthly transfer cap
+	CodeTransferCapExceeded = "TRANSFER_CAP_EXCEEDED"
+
+	// Share links and signed download URLs
+	CodeShareNotFound = "SHARE_NOT_FOUND"
+	CodeShareExpired  = "SHARE_EXPIRED"
+	CodeShareDisabled = "SHARE_DISABLED"
+	CodeLinkExpired   = "LINK_EXPIRED"
+	CodeLinkInvalid   = "LINK_INVALID"
+
+	// Too many invalid link tokens from one address (see auth.TokenGuard)
+	CodeTooManyAttempts = "TOO_MANY_ATTEMPTS"
+
+	// Missing or rejected captcha token on an anonymous route
+	CodeCaptchaRequired = "CAPTCHA_REQUIRED"
+
+	// Client address or country refused by the IP access rules
+	CodeIPBlocked = "IP_BLOCKED"
+
+	// Idempotency-Key
+	CodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+	CodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+
+	// Server state
+	CodeMaintenance = "MAINTENANCE"
+	CodeReadOnly    = "READ_ONLY"
+)
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:                   CodeBadRequest,
+	http.StatusUnauthorized:                 CodeUnauthorized,
+	http.StatusForbidden:                    CodeForbidden,
+	http.StatusNotFound:                     CodeNotFound,
+	http.StatusMethodNotAllowed:             CodeMethodNotAllowed,
+	http.StatusConflict:                     CodeConflict,
+	http.StatusGone:                         CodeGone,
+	http.StatusPreconditionFailed:           CodePreconditionFailed,
+	http.StatusRequestEntityTooLarge:        CodePayloadTooLarge,
+	http.StatusUnsupportedMediaType:         CodeUnsupportedMedia,
+	http.StatusRequestedRangeNotSatisfiable: CodeRangeNotSatisfied,
+	http.StatusTooManyRequests:              CodeRateLimited,
+	http.StatusServiceUnavailable:           CodeUnavailable,
+	http.StatusGatewayTimeout:               CodeTimeout,
+	http.StatusInsufficientStorage:          CodeInsufficientStorage,
+}
+
+// CodeForStatus is the generic code of an HTTP status
+func CodeForStatus(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// APIError is an error response. Details are extra top-level fields of the body
+// (e.g. retry_after), kept for clients that read them.
+type APIError struct {
+	Status    int
+	Code      string
+	Message   string
+	RequestID string
+	Details   map[string]interface{}
+}
+
+// New creates an error response; an empty code is derived from the status
+func New(status int, code, message string) *APIError {
+	if code == "" {
+		code = CodeForStatus(status)
+	}
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+func (e *APIError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// With adds a detail field to the body
+func (e *APIError) With(key string, value interface{}) *APIError {
+	if e.Details == nil {
+		e.Details = make(map[string]interface{})
+	}
+	e.Details[key] = value
+	return e
+}
+
+func (e *APIError) MarshalJSON() ([]byte, error) {
+	body := make(map[string]interface{}, len(e.Details)+3)
+	for key, value := range e.Details {
+		body[key] = value
+	}
+	body["error"] = e.Message
+	body["code"] = e.Code
+	if e.RequestID != "" {
+		body["request_id"] = e.RequestID
+	}
+	return json.Marshal(body)
+}
+
+// Write sends e as the response to r, tagged with the request's ID. English
+// keeps the handler's message, which is more specific than the catalog's.
+func Write(w http.ResponseWriter, r *http.Request, e *APIError) {
+	if e.RequestID == "" {
+		e.RequestID = middleware.GetReqID(r.Context())
+	}
+	lang := i18n.English
+	if accept := r.Header.Get("Accept-Language"); accept != "" {
+		catalog := i18n.Default()
+		if negotiated := catalog.Negotiate(accept); negotiated != i18n.English {
+			if message, ok := catalog.Lookup(negotiated, "error."+e.Code); ok {
+				e.Message = message
+				lang = negotiated
+			}
+		}
+	}
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(e.Status)
+	_ = json.NewEncoder(w).Encode(e)
+}
+
+// Respond sends an error response with the generic code of status
+func Respond(w http.ResponseWriter, r *http.Request, status int, message string) {
+	Write(w, r, New(status, "", message))
+}
+
+// RespondCode sends an error response with a specific code
+func RespondCode(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	Write(w, r, New(status, code, message))
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+)
+
+// accountStatusTTL is how long an account status is served from Redis. Admin
+// changes invalidate it at once; the TTL only bounds changes made elsewhere.
+const accountStatusTTL = 30 * time.Second
+
+// accountStatus returns the effective status of a user (see storage.User.Status),
+// cached in Redis for all replicas. It fails when the user does not exist.
+func (a *AuthMiddleware) accountStatus(ctx context.Context, userID string) (string, error) {
+	status, err := a.redisCache.CachedAccountStatus(ctx, userID)
+	if err != nil {
+		log.Printf("[auth] %v", err)
+	}
+	if status != "" {
+		return status, nil
+	}
+
+	user, err := a.pg.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	status = user.Status()
+	if err := a.redisCache.CacheAccountStatus(ctx, userID, status, accountStatusTTL); err != nil {
+		log.Printf("[auth] Failed to cache account status: %v", err)
+	}
+	return status, nil
+}
+
+// allowAccount answers the request with 403 and returns false unless the user's
+// account is active, so suspended, pending and rejected accounts are refused on
+// their next request whatever credential they hold
+func (a *AuthMiddleware) allowAccount(w http.ResponseWriter, r *http.Request, userID string) bool {
+	status, err := a.accountStatus(r.Context(), userID)
+	if err != nil {
+		log.Printf("[auth] Failed to get user for account status check: %v", err)
+		apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "User not found")
+		return false
+	}
+
+	switch status {
+	case "active":
+		return true
+	case "pending":
+		apierror.RespondCode(w, r, http.StatusForbidden, apierror.CodeAccountPending, "Account awaiting admin approval")
+	case "rejected":
+		apierror.RespondCode(w, r, http.StatusForbidden, apierror.CodeAccountRejected, "Account has been rejected by administrator")
+	default:
+		apierror.RespondCode(w, r, http.StatusForbidden, apierror.CodeAccountSuspended, "Account suspended. Contact administrator.")
+	}
+	log.Printf("[auth] Blocked request from %s user %s", status, userID)
+	return false
+}
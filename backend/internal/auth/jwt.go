@@ -1,36 +1,306 @@
 package auth
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
 )
 
+// DefaultKeyID names security.jwt_secret when no key ring is configured
+const DefaultKeyID = "default"
+
+// DefaultAccessTokenTTL is the lifetime of access tokens when
+// security.access_token_ttl is unset; clients renew them with their refresh token
+const DefaultAccessTokenTTL = 15 * time.Minute
+
+// signingKeyRefresh is how long a replica uses its cached key ring state before
+// reading the setting again (rotations on other replicas apply after this)
+const signingKeyRefresh = 5 * time.Second
+
+// ErrKeyRingExhausted means every key of the ring has been active already; add a
+// new one to security.jwt_keys before rotating again
+var ErrKeyRingExhausted = errors.New("no unused signing key left in the key ring")
+
+// SigningKey is one HMAC key of the JWT key ring
+type SigningKey struct {
+	ID     string
+	Secret []byte
+}
+
+// Key statuses in the ring (see Keys)
+const (
+	KeyActive   = "active"   // signs new tokens
+	KeyRetiring = "retiring" // still verifies the tokens it signed, until they expire
+	KeyRetired  = "retired"  // refused
+	KeySpare    = "spare"    // next in line
+)
+
+// KeyStatus describes a key of the ring, without its secret
+type KeyStatus struct {
+	ID     string     `json:"id"`
+	Status string     `json:"status"`
+	Since  *time.Time `json:"since,omitempty"` // active since
+	Until  *time.Time `json:"until,omitempty"` // retiring until
+}
+
+// JWTService signs short-lived access tokens with the active key of a key ring;
+// tokens carry the key ID in their kid header. The ring is used in order: each rotation makes
+// the next key active. A scheduled or manual rotation keeps honouring the previous
+// key until every token it signed has expired, so nobody is logged out; revoking
+// (after a leak) refuses all earlier keys at once. The state is a setting shared
+// by all replicas.
 type JWTService struct {
-	secret []byte
+	keys   []SigningKey
 	expiry time.Duration
+	pg     *storage.PostgresStore
+
+	mu        sync.Mutex
+	state     storage.SigningKeyState
+	active    int // index into keys
+	checkedAt time.Time
 }
 
+// Claims of an access token. SessionID (sid) names the session the token was
+// issued for and ID (jti) the token itself, so either can be revoked.
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-// NewJWTService creates a new JWT service
-func NewJWTService(secret string, expirySeconds int) *JWTService {
+// NewJWTService creates a JWT service signing with the ring keys (at least one);
+// tokens expire after accessTTL (DefaultAccessTokenTTL when zero)
+func NewJWTService(keys []SigningKey, accessTTL time.Duration, pg *storage.PostgresStore) *JWTService {
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
 	return &JWTService{
-		secret: []byte(secret),
-		expiry: time.Duration(expirySeconds) * time.Second,
+		keys:   keys,
+		expiry: accessTTL,
+		pg:     pg,
+	}
+}
+
+// AccessTTL is the lifetime of access tokens
+func (j *JWTService) AccessTTL() time.Duration {
+	return j.expiry
+}
+
+// current returns the key ring state and the active key index, reading the
+// setting at most every signingKeyRefresh. When it cannot be read the last known
+// state is kept.
+func (j *JWTService) current(ctx context.Context) (storage.SigningKeyState, int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if time.Since(j.checkedAt) >= signingKeyRefresh {
+		j.refreshLocked(ctx)
 	}
+	return j.state, j.active
 }
 
-// GenerateToken generates a JWT token for a user
-func (j *JWTService) GenerateToken(userID string) (string, error) {
+func (j *JWTService) refreshLocked(ctx context.Context) {
+	j.checkedAt = time.Now()
+	if j.pg == nil {
+		return
+	}
+	state, err := j.pg.GetSigningKeyState(ctx)
+	if err != nil {
+		log.Printf("[auth] Failed to read the signing key state, keeping %s: %v", j.keys[j.active].ID, err)
+		return
+	}
+	j.setStateLocked(state)
+}
+
+func (j *JWTService) setStateLocked(state storage.SigningKeyState) {
+	j.state = state
+	j.active = j.index(state.Active)
+}
+
+// index is the position of the active key id in the ring: the first key when none
+// was rotated to, the newest when id was removed from the ring
+func (j *JWTService) index(id string) int {
+	if id == "" {
+		return 0
+	}
+	for i, key := range j.keys {
+		if key.ID == id {
+			return i
+		}
+	}
+	log.Printf("[auth] Active signing key %q is not in security.jwt_keys; using %s", id, j.keys[len(j.keys)-1].ID)
+	return len(j.keys) - 1
+}
+
+func (j *JWTService) keyByID(id string) (SigningKey, bool) {
+	for _, key := range j.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return SigningKey{}, false
+}
+
+// ActiveKeyID is the ID of the key new tokens are signed with
+func (j *JWTService) ActiveKeyID(ctx context.Context) string {
+	_, active := j.current(ctx)
+	return j.keys[active].ID
+}
+
+// Keys lists the ring with the status of each key
+func (j *JWTService) Keys(ctx context.Context) []KeyStatus {
+	state, active := j.current(ctx)
+	now := time.Now()
+
+	keys := make([]KeyStatus, len(j.keys))
+	for i, key := range j.keys {
+		keys[i] = KeyStatus{ID: key.ID}
+		switch {
+		case i == active:
+			keys[i].Status = KeyActive
+			if !state.ActiveSince.IsZero() {
+				since := state.ActiveSince
+				keys[i].Since = &since
+			}
+		case i > active:
+			keys[i].Status = KeySpare
+		default:
+			keys[i].Status = KeyRetired
+			if until, ok := state.Retiring[key.ID]; ok && now.Before(until) {
+				keys[i].Status = KeyRetiring
+				keys[i].Until = &until
+			}
+		}
+	}
+	return keys
+}
+
+// Rotate makes the next key of the ring the active one and returns its ID, or
+// ErrKeyRingExhausted. The previous key keeps verifying its tokens until they
+// expire; with revoke, every earlier key is refused at once.
+func (j *JWTService) Rotate(ctx context.Context, adminID string, revoke bool) (string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	state, err := j.pg.UpdateSigningKeyState(ctx, adminID, func(state *storage.SigningKeyState) error {
+		active := j.index(state.Active)
+		if active+1 >= len(j.keys) {
+			return ErrKeyRingExhausted
+		}
+		now := time.Now()
+
+		retiring := make(map[string]time.Time)
+		if !revoke {
+			for id, until := range state.Retiring {
+				if now.Before(until) {
+					retiring[id] = until
+				}
+			}
+			retiring[j.keys[active].ID] = now.Add(j.expiry)
+		}
+		state.Active = j.keys[active+1].ID
+		state.ActiveSince = now
+		state.Retiring = retiring
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	j.setStateLocked(state)
+	j.checkedAt = time.Now()
+	if spare := len(j.keys) - 1 - j.active; spare == 0 {
+		log.Printf("[auth] Signing key %s is the last of the ring; add a new key to security.jwt_keys before the next rotation", state.Active)
+	}
+	return state.Active, nil
+}
+
+// RotateEvery returns a job that rotates the signing key once it has been active
+// for interval (run it on one replica, e.g. with worker.RunAsLeader)
+func (j *JWTService) RotateEvery(interval time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		check := interval / 24
+		if check > time.Hour {
+			check = time.Hour
+		}
+		ticker := time.NewTicker(check)
+		defer ticker.Stop()
+
+		for {
+			j.rotateIfDue(ctx, interval)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+func (j *JWTService) rotateIfDue(ctx context.Context, interval time.Duration) {
+	state, err := j.pg.GetSigningKeyState(ctx)
+	if err != nil {
+		log.Printf("[auth] Failed to read the signing key state: %v", err)
+		return
+	}
+	if state.ActiveSince.IsZero() {
+		// Not rotated yet: start the clock for the key signing today
+		_, err := j.pg.UpdateSigningKeyState(ctx, "", func(s *storage.SigningKeyState) error {
+			if s.ActiveSince.IsZero() {
+				s.Active = j.keys[j.index(s.Active)].ID
+				s.ActiveSince = time.Now()
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[auth] Failed to record the signing key start: %v", err)
+		}
+		return
+	}
+	if time.Since(state.ActiveSince) < interval {
+		return
+	}
+
+	previous := j.keys[j.index(state.Active)].ID
+	keyID, err := j.Rotate(ctx, "", false)
+	if err != nil {
+		log.Printf("[auth] Scheduled signing key rotation failed: %v", err)
+		return
+	}
+	log.Printf("[auth] Rotated the signing key to %s on schedule (%s honoured for %s)", keyID, previous, j.expiry)
+
+	metadata, _ := json.Marshal(map[string]interface{}{"signing_key": keyID, "previous_key": previous, "trigger": "schedule"})
+	if err := j.pg.LogAudit(ctx, storage.AuditEntry{
+		Action:     "SIGNING_KEY_ROTATED",
+		TargetType: "system",
+		Metadata:   metadata,
+	}); err != nil {
+		log.Printf("[auth] Failed to audit the signing key rotation: %v", err)
+	}
+}
+
+// GenerateToken generates an access token for a user's session
+func (j *JWTService) GenerateToken(ctx context.Context, userID, sessionID string) (string, error) {
+	jti, err := GenerateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	_, active := j.current(ctx)
+	key := j.keys[active]
 	now := time.Now()
 	claims := &Claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.expiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -38,7 +308,8 @@ func (j *JWTService) GenerateToken(userID string) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secret)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.Secret)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -46,8 +317,11 @@ func (j *JWTService) GenerateToken(userID string) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken validates and parses a JWT token
-func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
+// ValidateToken validates and parses a JWT token signed with the active key or a
+// retiring one. Tokens without a kid (issued before the key ring) count as signed
+// with the first key.
+func (j *JWTService) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	state, active := j.current(ctx)
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -55,7 +329,19 @@ func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return j.secret, nil
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			kid = j.keys[0].ID
+		}
+		if kid == j.keys[active].ID {
+			return j.keys[active].Secret, nil
+		}
+		if until, ok := state.Retiring[kid]; ok && time.Now().Before(until) {
+			if key, ok := j.keyByID(kid); ok {
+				return key.Secret, nil
+			}
+		}
+		return nil, fmt.Errorf("token signed with retired key %q", kid)
 	})
 
 	if err != nil {
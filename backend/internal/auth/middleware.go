@@ -2,7 +2,6 @@ package auth
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"net/http"
 	"strings"
@@ -10,6 +9,7 @@ import (
 
 	"log"
 
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
 	"github.com/sachinthra/file-locker/backend/internal/constants"
 	"github.com/sachinthra/file-locker/backend/internal/storage"
 )
@@ -18,14 +18,16 @@ type AuthMiddleware struct {
 	jwtService *JWTService
 	redisCache *storage.RedisCache
 	pg         *storage.PostgresStore
+	guard      *TokenGuard // ticket and stream grant guessing
 }
 
 // NewAuthMiddleware creates auth middleware
-func NewAuthMiddleware(jwtService *JWTService, redisCache *storage.RedisCache, pg *storage.PostgresStore) *AuthMiddleware {
+func NewAuthMiddleware(jwtService *JWTService, redisCache *storage.RedisCache, pg *storage.PostgresStore, guard *TokenGuard) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtService: jwtService,
 		redisCache: redisCache,
 		pg:         pg,
+		guard:      guard,
 	}
 }
 
@@ -44,118 +46,71 @@ func (a *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			}
 		}
 
-		// 2. If not in header, try query parameter (for download/stream links)
+		// 2. No token, return error. Tokens are never accepted in the query string
+		// (they would end up in access logs and referrers); links use tickets instead.
 		if tokenString == "" {
-			tokenString = r.URL.Query().Get("token")
-		}
-
-		// 3. If still no token, return error
-		if tokenString == "" {
-			http.Error(w, `{"error":"Authorization header required"}`, http.StatusUnauthorized)
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "Authorization header required")
 			return
 		}
 
-		// If token looks like PAT (starts with fl_), verify against DB
-		if strings.HasPrefix(tokenString, "fl_") {
-			// delegate verification to PostgresStore helper
-			if a.pg == nil {
-				log.Printf("[auth] PAT lookup requested but PostgresStore not available from %s", r.RemoteAddr)
-				http.Error(w, `{"error":"token lookup not available"}`, http.StatusInternalServerError)
-				return
-			}
-			tokenID, userID, err := a.pg.VerifyPersonalAccessToken(context.Background(), tokenString)
-			if err != nil {
-				if err == sql.ErrNoRows {
-					log.Printf("[auth] PAT verify failed: not found from %s", r.RemoteAddr)
-					http.Error(w, `{"error":"Invalid token"}`, http.StatusUnauthorized)
-					return
-				}
-				log.Printf("[auth] PAT verify error from %s: %v", r.RemoteAddr, err)
-				http.Error(w, `{"error":"token lookup failed"}`, http.StatusInternalServerError)
-				return
-			}
-			// token verified; set userID in context
-			log.Printf("[auth] PAT accepted id=%s user=%s from=%s", tokenID, userID, r.RemoteAddr)
-			ctx := context.WithValue(r.Context(), constants.UserIDKey, userID)
-			// optionally attach token ID
-			ctx = context.WithValue(ctx, constants.PatIDKey, tokenID)
-			next.ServeHTTP(w, r.WithContext(ctx))
+		// Service account keys (fls_) carry their own scope and source restrictions
+		if strings.HasPrefix(tokenString, ServiceKeyPrefix) {
+			a.authenticateServiceKey(w, r, next, tokenString)
 			return
 		}
 
-		// 4. Validate token with jwtService
-		claims, err := a.jwtService.ValidateToken(tokenString)
-		if err != nil {
-			http.Error(w, `{"error":"Invalid or expired token"}`, http.StatusUnauthorized)
+		// Personal access tokens (fl_) act as their owner, limited to their scopes
+		if strings.HasPrefix(tokenString, PATPrefix) {
+			a.authenticatePAT(w, r, next, tokenString)
 			return
 		}
 
-		// 5. Check if session exists in Redis (using token as key)
-		ctx := context.Background()
-		sessionUserID, err := a.redisCache.GetSession(ctx, tokenString)
+		// 3. Validate token with jwtService
+		claims, err := a.jwtService.ValidateToken(r.Context(), tokenString)
 		if err != nil {
-			http.Error(w, `{"error":"Session not found or expired"}`, http.StatusUnauthorized)
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid or expired token")
 			return
 		}
 
-		// 6. Verify session userID matches token claims
-		if sessionUserID != claims.UserID {
-			http.Error(w, `{"error":"Session mismatch"}`, http.StatusUnauthorized)
+		// Tokens issued before sessions had IDs cannot be revoked; refuse them
+		if claims.SessionID == "" || claims.ID == "" {
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Session expired, please log in again")
 			return
 		}
 
-		// 7. Check if user account is active
-		user, err := a.pg.GetUserByID(ctx, claims.UserID)
+		// 4. Check the token was not revoked (logout, POST /auth/revoke)
+		ctx := context.Background()
+		revoked, err := a.redisCache.AccessTokenRevoked(ctx, claims.ID)
 		if err != nil {
-			log.Printf("[auth] Failed to get user for account status check: %v", err)
-			http.Error(w, `{"error":"User not found"}`, http.StatusUnauthorized)
-			return
+			log.Printf("[auth] %v", err)
 		}
-
-		// Check if account is suspended
-		if !user.IsActive {
-			log.Printf("[auth] Blocked request from suspended user: %s (%s)", user.Username, user.ID)
-			http.Error(w, `{"error":"Account suspended. Contact administrator."}`, http.StatusForbidden)
+		if err != nil || revoked {
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Token revoked")
 			return
 		}
 
-		// 8. Set userID in context
-		ctx = context.WithValue(r.Context(), constants.UserIDKey, claims.UserID)
-
-		// 9. Call next handler with updated context
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
-}
-
-// RequireAdmin middleware ensures the user is authenticated AND has admin role
-func (a *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// 1. Get userID from context (set by RequireAuth)
-		userID := r.Context().Value(constants.UserIDKey)
-		if userID == nil {
-			http.Error(w, `{"error":"User not authenticated"}`, http.StatusUnauthorized)
+		// 5. Check its session exists in Redis and belongs to the token's user
+		session, err := a.redisCache.GetSession(ctx, claims.SessionID)
+		if err != nil {
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Session not found or expired")
 			return
 		}
-
-		// 2. Fetch user from database to check role
-		ctx := context.Background()
-		user, err := a.pg.GetUserByID(ctx, userID.(string))
-		if err != nil {
-			log.Printf("[auth] Failed to get user %s for admin check: %v", userID, err)
-			http.Error(w, `{"error":"User not found"}`, http.StatusUnauthorized)
+		if session.UserID != claims.UserID {
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Session mismatch")
 			return
 		}
 
-		// 3. Check if user has admin role
-		if user.Role != "admin" {
-			log.Printf("[auth] Access denied: user %s (role=%s) attempted to access admin endpoint", user.Username, user.Role)
-			http.Error(w, `{"error":"Admin access required"}`, http.StatusForbidden)
+		// 6. Check the user account is active (suspended users are refused at once)
+		if !a.allowAccount(w, r, claims.UserID) {
 			return
 		}
 
-		// 4. User is admin, proceed
-		log.Printf("[auth] Admin access granted to user %s", user.Username)
-		next.ServeHTTP(w, r)
+		// 7. Set userID and the token claims in context
+		ctx = context.WithValue(r.Context(), constants.UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, constants.SessionClaimsKey, claims)
+
+		// 8. Call next handler with updated context
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
@@ -166,7 +121,7 @@ func (a *AuthMiddleware) RateLimitMiddleware(requests int, window time.Duration)
 			// 1. Get userID from context (set by RequireAuth)
 			userID := r.Context().Value(constants.UserIDKey)
 			if userID == nil {
-				http.Error(w, `{"error":"User not authenticated"}`, http.StatusUnauthorized)
+				apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "User not authenticated")
 				return
 			}
 
@@ -178,7 +133,7 @@ func (a *AuthMiddleware) RateLimitMiddleware(requests int, window time.Duration)
 			// 3. Increment counter with INCR
 			count, err := a.redisCache.IncrRateLimit(ctx, userID.(string), currentWindow)
 			if err != nil {
-				http.Error(w, `{"error":"Rate limit check failed"}`, http.StatusInternalServerError)
+				apierror.Respond(w, r, http.StatusInternalServerError, "Rate limit check failed")
 				return
 			}
 
@@ -193,9 +148,8 @@ func (a *AuthMiddleware) RateLimitMiddleware(requests int, window time.Duration)
 
 			// 5. If count > limit, return 429 Too Many Requests
 			if count > int64(requests) {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusTooManyRequests)
-				_, _ = fmt.Fprintf(w, `{"error":"Rate limit exceeded","retry_after":%d}`, int(window.Seconds()))
+				apierror.Write(w, r, apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, "Rate limit exceeded").
+					With("retry_after", int(window.Seconds())))
 				return
 			}
 
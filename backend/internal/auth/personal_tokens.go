@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// PATPrefix marks personal access tokens: fl_<lookup prefix>_<secret>
+const PATPrefix = "fl_"
+
+// ScopeAdmin lets a personal access token of an admin use the admin routes
+const ScopeAdmin = "admin"
+
+// ValidPATScopes lists the scopes a personal access token may be granted
+var ValidPATScopes = map[string]bool{
+	ScopeFilesRead:   true,
+	ScopeFilesWrite:  true,
+	ScopeFilesDelete: true,
+	ScopeAdmin:       true,
+}
+
+// DefaultPATScopes are granted to tokens created without explicit scopes
+var DefaultPATScopes = []string{ScopeFilesRead, ScopeFilesWrite, ScopeFilesDelete}
+
+// GeneratePAT returns a new raw personal access token and its public lookup prefix
+func GeneratePAT() (rawToken, lookupPrefix string, err error) {
+	p := make([]byte, 6)
+	if _, err := rand.Read(p); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	lookupPrefix = hex.EncodeToString(p)
+	return PATPrefix + lookupPrefix + "_" + base64.RawURLEncoding.EncodeToString(secret), lookupPrefix, nil
+}
+
+// CheckTokenGrant returns the error code and message refusing c a new personal
+// access token with scopes, or "" if c may create it. Only admins may grant the
+// admin scope, and a token may only grant scopes it has itself, so it cannot mint
+// a more powerful one; sessions are not limited by scopes.
+func CheckTokenGrant(c Caller, scopes []string) (code, message string) {
+	for _, s := range scopes {
+		if s == ScopeAdmin && c.Role != RoleAdmin {
+			return apierror.CodeAdminRequired, "Only admins can create tokens with the admin scope"
+		}
+		if c.Scopes != nil && !slices.Contains(c.Scopes, s) {
+			return apierror.CodeInsufficientScope, "A token can only create tokens with its own scopes; missing: " + s
+		}
+	}
+	return "", ""
+}
+
+// patUsageAuditInterval limits PAT_USED audit entries to one per token per
+// interval, so a busy script does not flood the audit log
+const patUsageAuditInterval = time.Hour
+
+// authenticatePAT verifies a personal access token and serves the request as the
+// token's owner (Authorize checks its scopes)
+func (a *AuthMiddleware) authenticatePAT(w http.ResponseWriter, r *http.Request, next http.Handler, rawToken string) {
+	if a.pg == nil {
+		log.Printf("[auth] PAT lookup requested but PostgresStore not available from %s", r.RemoteAddr)
+		apierror.Respond(w, r, http.StatusInternalServerError, "token lookup not available")
+		return
+	}
+
+	lookupPrefix, _, ok := strings.Cut(strings.TrimPrefix(rawToken, PATPrefix), "_")
+	if !ok || lookupPrefix == "" {
+		apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid token")
+		return
+	}
+
+	ctx := r.Context()
+	token, err := a.pg.VerifyPersonalAccessToken(ctx, lookupPrefix, rawToken)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("[auth] PAT verify failed: prefix=%s from %s", lookupPrefix, r.RemoteAddr)
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid token")
+			return
+		}
+		log.Printf("[auth] PAT verify error from %s: %v", r.RemoteAddr, err)
+		apierror.Respond(w, r, http.StatusInternalServerError, "token lookup failed")
+		return
+	}
+
+	if !token.UserActive {
+		log.Printf("[auth] Blocked PAT %s of suspended user %s", token.ID, token.UserID)
+		apierror.RespondCode(w, r, http.StatusForbidden, apierror.CodeAccountSuspended, "Account suspended. Contact administrator.")
+		return
+	}
+	if !a.allowAccount(w, r, token.UserID) {
+		return
+	}
+
+	if token.LastUsedAt == nil || time.Since(*token.LastUsedAt) >= patUsageAuditInterval {
+		a.auditPAT(ctx, r, token.UserID, token.ID, "PAT_USED", "")
+	}
+
+	ctx = context.WithValue(ctx, constants.UserIDKey, token.UserID)
+	ctx = context.WithValue(ctx, constants.PatIDKey, token.ID)
+	ctx = context.WithValue(ctx, constants.TokenScopesKey, token.Scopes)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// auditPAT records a use of a personal access token in the audit log; scope is the
+// one a refused request lacked
+func (a *AuthMiddleware) auditPAT(ctx context.Context, r *http.Request, userID, tokenID, action, scope string) {
+	fields := map[string]interface{}{"method": r.Method, "path": r.URL.Path}
+	if scope != "" {
+		fields["scope"] = scope
+	}
+	metadata, _ := json.Marshal(fields)
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	err = a.pg.LogAudit(ctx, storage.AuditEntry{
+		ActorID:    userID,
+		Action:     action,
+		TargetType: "token",
+		TargetID:   tokenID,
+		Metadata:   metadata,
+		IPAddress:  ip,
+	})
+	if err != nil {
+		log.Printf("[auth] Failed to audit %s for PAT %s: %v", action, tokenID, err)
+	}
+}
@@ -0,0 +1,317 @@
+package auth
+
+import (
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+)
+
+// Roles a route may require
+const (
+	RoleUser  = "user"  // any authenticated account, service accounts included
+	RoleAdmin = "admin" // admins only
+)
+
+// Rule is what a caller needs to use a route
+type Rule struct {
+	Public    bool   `json:"public,omitempty"`     // no authentication at all
+	Role      string `json:"role,omitempty"`       // RoleUser or RoleAdmin
+	Scope     string `json:"scope,omitempty"`      // needed by tokens (PATs, service keys); "" = any token
+	HumanOnly bool   `json:"human_only,omitempty"` // service account keys are refused
+}
+
+func public() Rule                { return Rule{Public: true} }
+func user(scope string) Rule      { return Rule{Role: RoleUser, Scope: scope} }
+func human(scope string) Rule     { return Rule{Role: RoleUser, Scope: scope, HumanOnly: true} }
+func admin() Rule                 { return Rule{Role: RoleAdmin, Scope: ScopeAdmin, HumanOnly: true} }
+func routeKey(m, p string) string { return m + " " + p }
+
+// Policy maps every API route ("METHOD /path", chi pattern without the /api/vN
+// prefix) to its rule. Authorize enforces it, GET /permissions publishes it, and
+// CheckPolicy refuses to start the server while a route is missing from it.
+var Policy = map[string]Rule{
+	// Public
+	routeKey(http.MethodGet, "/version"):           public(),
+	routeKey(http.MethodGet, "/s/{token}"):         public(),
+	routeKey(http.MethodGet, "/dl/{token}"):        public(),
+	routeKey(http.MethodPost, "/s/{token}/report"): public(),
+	routeKey(http.MethodPost, "/auth/login"):       public(),
+	routeKey(http.MethodPost, "/auth/register"):    public(),
+	routeKey(http.MethodPost, "/auth/refresh"):     public(),
+	routeKey(http.MethodPost, "/auth/revoke"):      public(),
+	routeKey(http.MethodGet, "/docs/openapi.yaml"): public(),
+
+	// Transfers (also reachable with a ticket)
+	routeKey(http.MethodGet, "/download/{id}"): user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/stream/{id}"):   user(ScopeFilesRead),
+	routeKey(http.MethodPost, "/upload"):       user(ScopeFilesWrite),
+	routeKey(http.MethodGet, "/files/export"):  user(ScopeFilesRead),
+
+	// Files
+	routeKey(http.MethodPost, "/upload/precheck"):                      user(ScopeFilesWrite),
+	routeKey(http.MethodGet, "/files"):                                 user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/files/search"):                          user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/files/expiring"):                        user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/files/starred"):                         user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/files/recent"):                          user(ScopeFilesRead),
+	routeKey(http.MethodDelete, "/files"):                              user(ScopeFilesDelete),
+	routeKey(http.MethodGet, "/files/trash"):                           user(ScopeFilesRead),
+	routeKey(http.MethodDelete, "/files/trash"):                        user(ScopeFilesDelete),
+	routeKey(http.MethodPost, "/files/batch/update"):                   user(ScopeFilesWrite),
+	routeKey(http.MethodGet, "/files/{fileID}"):                        user(ScopeFilesRead),
+	routeKey(http.MethodHead, "/files/{fileID}"):                       user(ScopeFilesRead),
+	routeKey(http.MethodPatch, "/files/{fileID}"):                      user(ScopeFilesWrite),
+	routeKey(http.MethodPost, "/files/{fileID}/copy"):                  user(ScopeFilesWrite),
+	routeKey(http.MethodPost, "/files/{fileID}/move"):                  user(ScopeFilesWrite),
+	routeKey(http.MethodPatch, "/files/{fileID}/expiry"):               user(ScopeFilesWrite),
+	routeKey(http.MethodPut, "/files/{fileID}/password"):               user(ScopeFilesWrite),
+	routeKey(http.MethodDelete, "/files/{fileID}/password"):            user(ScopeFilesWrite),
+	routeKey(http.MethodPost, "/files/{fileID}/star"):                  user(ScopeFilesWrite),
+	routeKey(http.MethodDelete, "/files/{fileID}/star"):                user(ScopeFilesWrite),
+	routeKey(http.MethodPost, "/files/{fileID}/download-url"):          user(ScopeFilesRead),
+	routeKey(http.MethodPost, "/files/{fileID}/ticket"):                user(ScopeFilesRead),
+	routeKey(http.MethodPost, "/files/{fileID}/shares"):                user(ScopeFilesWrite),
+	routeKey(http.MethodGet, "/files/{fileID}/shares"):                 user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/files/{fileID}/shares/{shareID}/stats"): user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/files/{fileID}/processing"):             user(ScopeFilesRead),
+	routeKey(http.MethodGet, "/files/{fileID}/thumbnail"):              user(ScopeFilesRead),
+	routeKey(http.MethodPost, "/files/{fileID}/restore"):               user(ScopeFilesWrite),
+	routeKey(http.MethodPatch, "/shares/{id}"):                         user(ScopeFilesWrite),
+	routeKey(http.MethodDelete, "/shares/{id}"):                        user(ScopeFilesWrite),
+
+	// Account
+	routeKey(http.MethodGet, "/auth/me"):                     user(""),
+	routeKey(http.MethodGet, "/permissions"):                 user(""),
+	routeKey(http.MethodGet, "/announcements"):               user(""),
+	routeKey(http.MethodPost, "/announcements/{id}/dismiss"): user(""),
+	routeKey(http.MethodPatch, "/user/password"):             human(ScopeFilesWrite),
+	routeKey(http.MethodPatch, "/user/profile"):              human(ScopeFilesWrite),
+	routeKey(http.MethodPost, "/user/profile/verify-email"):  human(ScopeFilesWrite),
+	routeKey(http.MethodGet, "/user/preferences"):            user(""),
+	routeKey(http.MethodGet, "/user/transfer"):               user(""),
+	routeKey(http.MethodPatch, "/user/preferences"):          human(ScopeFilesWrite),
+	routeKey(http.MethodPut, "/user/avatar"):                 human(ScopeFilesWrite),
+	routeKey(http.MethodDelete, "/user/avatar"):              human(ScopeFilesWrite),
+	routeKey(http.MethodGet, "/users/{id}/avatar"):           user(ScopeFilesRead),
+	routeKey(http.MethodPost, "/auth/logout"):                human(""),
+	routeKey(http.MethodPost, "/auth/tokens"):                human(ScopeFilesWrite),
+	routeKey(http.MethodGet, "/auth/tokens"):                 human(ScopeFilesRead),
+	routeKey(http.MethodDelete, "/auth/tokens/{id}"):         human(ScopeFilesWrite),
+
+	// Administration
+	routeKey(http.MethodGet, "/admin/stats"):                                 admin(),
+	routeKey(http.MethodGet, "/admin/stats/content-types"):                   admin(),
+	routeKey(http.MethodGet, "/admin/users"):                                 admin(),
+	routeKey(http.MethodGet, "/admin/users/pending"):                         admin(),
+	routeKey(http.MethodGet, "/admin/users/{id}/files"):                      admin(),
+	routeKey(http.MethodGet, "/admin/users/{id}/storage"):                    admin(),
+	routeKey(http.MethodPost, "/admin/users/{id}/approve"):                   admin(),
+	routeKey(http.MethodPost, "/admin/users/{id}/reject"):                    admin(),
+	routeKey(http.MethodDelete, "/admin/users/{id}"):                         admin(),
+	routeKey(http.MethodPatch, "/admin/users/{id}/status"):                   admin(),
+	routeKey(http.MethodPatch, "/admin/users/{id}/role"):                     admin(),
+	routeKey(http.MethodPatch, "/admin/users/{id}/profile"):                  admin(),
+	routeKey(http.MethodPost, "/admin/users/{id}/reset-password"):            admin(),
+	routeKey(http.MethodPost, "/admin/users/{id}/logout"):                    admin(),
+	routeKey(http.MethodPut, "/admin/users/{id}/read-only"):                  admin(),
+	routeKey(http.MethodPost, "/admin/users/{id}/transfer-files"):            admin(),
+	routeKey(http.MethodGet, "/admin/service-accounts"):                      admin(),
+	routeKey(http.MethodPost, "/admin/service-accounts"):                     admin(),
+	routeKey(http.MethodDelete, "/admin/service-accounts/{id}"):              admin(),
+	routeKey(http.MethodGet, "/admin/service-accounts/{id}/keys"):            admin(),
+	routeKey(http.MethodPost, "/admin/service-accounts/{id}/keys"):           admin(),
+	routeKey(http.MethodDelete, "/admin/service-accounts/{id}/keys/{keyID}"): admin(),
+	routeKey(http.MethodGet, "/admin/settings"):                              admin(),
+	routeKey(http.MethodPatch, "/admin/settings"):                            admin(),
+	routeKey(http.MethodGet, "/admin/maintenance"):                           admin(),
+	routeKey(http.MethodPut, "/admin/maintenance"):                           admin(),
+	routeKey(http.MethodGet, "/admin/read-only"):                             admin(),
+	routeKey(http.MethodPut, "/admin/read-only"):                             admin(),
+	routeKey(http.MethodGet, "/admin/sessions"):                              admin(),
+	routeKey(http.MethodPost, "/admin/sessions/revoke-all"):                  admin(),
+	routeKey(http.MethodGet, "/admin/signing-keys"):                          admin(),
+	routeKey(http.MethodPost, "/admin/signing-keys/rotate"):                  admin(),
+	routeKey(http.MethodGet, "/admin/ip-access"):                             admin(),
+	routeKey(http.MethodPut, "/admin/ip-access"):                             admin(),
+	routeKey(http.MethodGet, "/admin/announcements"):                         admin(),
+	routeKey(http.MethodPost, "/admin/announcements"):                        admin(),
+	routeKey(http.MethodDelete, "/admin/announcements/{id}"):                 admin(),
+	routeKey(http.MethodGet, "/admin/files"):                                 admin(),
+	routeKey(http.MethodDelete, "/admin/files/{id}"):                         admin(),
+	routeKey(http.MethodGet, "/admin/storage/analyze"):                       admin(),
+	routeKey(http.MethodPost, "/admin/storage/cleanup"):                      admin(),
+	routeKey(http.MethodGet, "/admin/storage/replica"):                       admin(),
+	routeKey(http.MethodPost, "/admin/storage/replica/reconcile"):            admin(),
+	routeKey(http.MethodGet, "/admin/snapshots"):                             admin(),
+	routeKey(http.MethodPost, "/admin/snapshots"):                            admin(),
+	routeKey(http.MethodGet, "/admin/snapshots/{id}/restorable"):             admin(),
+	routeKey(http.MethodPost, "/admin/snapshots/{id}/restore"):               admin(),
+	routeKey(http.MethodGet, "/admin/quarantine"):                            admin(),
+	routeKey(http.MethodPost, "/admin/quarantine/{id}/approve"):              admin(),
+	routeKey(http.MethodPost, "/admin/quarantine/{id}/reject"):               admin(),
+	routeKey(http.MethodGet, "/admin/reports"):                               admin(),
+	routeKey(http.MethodPost, "/admin/reports/{id}/resolve"):                 admin(),
+	routeKey(http.MethodGet, "/admin/usage-reports/{period}"):                admin(),
+	routeKey(http.MethodPost, "/admin/usage-reports/{period}/send"):          admin(),
+	routeKey(http.MethodGet, "/admin/usage"):                                 admin(),
+	routeKey(http.MethodGet, "/admin/usage/export"):                          admin(),
+	routeKey(http.MethodPost, "/admin/usage/send"):                           admin(),
+	routeKey(http.MethodGet, "/admin/notifications"):                         admin(),
+	routeKey(http.MethodPost, "/admin/notifications/{channel}/test"):         admin(),
+	routeKey(http.MethodPost, "/admin/keys/rotate"):                          admin(),
+	routeKey(http.MethodGet, "/admin/keys/rotation"):                         admin(),
+	routeKey(http.MethodGet, "/admin/jobs"):                                  admin(),
+	routeKey(http.MethodPost, "/admin/jobs/{id}/retry"):                      admin(),
+	routeKey(http.MethodPost, "/admin/files/{id}/reencrypt"):                 admin(),
+	routeKey(http.MethodGet, "/admin/replication/remotes"):                   admin(),
+	routeKey(http.MethodPost, "/admin/replication/remotes"):                  admin(),
+	routeKey(http.MethodDelete, "/admin/replication/remotes/{id}"):           admin(),
+	routeKey(http.MethodPost, "/admin/replication/remotes/{id}/sync"):        admin(),
+	routeKey(http.MethodGet, "/admin/logs"):                                  admin(),
+}
+
+// Caller is who a request runs as, as far as the policy is concerned
+type Caller struct {
+	Role           string   // users.role; only needed for admin routes
+	Scopes         []string // token scopes; nil for sessions and tickets (unrestricted)
+	ServiceAccount bool
+}
+
+// CallerFromRequest describes the authenticated caller of r; role is the caller's
+// users.role, looked up by the caller
+func CallerFromRequest(r *http.Request, role string) Caller {
+	scopes, _ := r.Context().Value(constants.TokenScopesKey).([]string)
+	return Caller{
+		Role:           role,
+		Scopes:         scopes,
+		ServiceAccount: r.Context().Value(constants.ServiceKeyIDKey) != nil,
+	}
+}
+
+// Check returns the error code and message refusing c, or "" if the rule allows c
+func (rule Rule) Check(c Caller) (code, message string) {
+	switch {
+	case rule.Public:
+		return "", ""
+	case rule.HumanOnly && c.ServiceAccount:
+		return apierror.CodeForbidden, "Not available to service accounts"
+	case rule.Role == RoleAdmin && c.Role != RoleAdmin:
+		return apierror.CodeAdminRequired, "Admin access required"
+	case rule.Scope != "" && c.Scopes != nil && !slices.Contains(c.Scopes, rule.Scope):
+		return apierror.CodeInsufficientScope, "Token lacks required scope: " + rule.Scope
+	}
+	return "", ""
+}
+
+// RuleFor returns the rule of the route r was routed to
+func RuleFor(r *http.Request) (Rule, bool) {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return Rule{}, false
+	}
+	rule, ok := Policy[routeKey(r.Method, trimAPIPrefix(rctx.RoutePattern()))]
+	return rule, ok
+}
+
+// trimAPIPrefix drops the /api/vN prefix of a route pattern
+func trimAPIPrefix(pattern string) string {
+	if rest, ok := strings.CutPrefix(pattern, "/api/v"); ok {
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			return rest[i:]
+		}
+	}
+	return pattern
+}
+
+// Authorize enforces Policy on an authenticated request (mount it after RequireAuth
+// or RequireAuthOrTicket). Routes missing from the policy are refused.
+func (a *AuthMiddleware) Authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rule, ok := RuleFor(r)
+		if !ok {
+			log.Printf("[auth] No authorization policy for %s %s; refusing", r.Method, r.URL.Path)
+			apierror.Respond(w, r, http.StatusForbidden, "Access denied")
+			return
+		}
+
+		userID, _ := r.Context().Value(constants.UserIDKey).(string)
+		role := ""
+		if rule.Role == RoleAdmin {
+			user, err := a.pg.GetUserByID(r.Context(), userID)
+			if err != nil {
+				log.Printf("[auth] Failed to get user %s for admin check: %v", userID, err)
+				apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "User not found")
+				return
+			}
+			role = user.Role
+		}
+
+		if code, message := rule.Check(CallerFromRequest(r, role)); code != "" {
+			log.Printf("[auth] Access denied: user %s on %s %s: %s", userID, r.Method, r.URL.Path, message)
+			if patID, ok := r.Context().Value(constants.PatIDKey).(string); ok && code == apierror.CodeInsufficientScope {
+				a.auditPAT(r.Context(), r, userID, patID, "PAT_SCOPE_DENIED", rule.Scope)
+			}
+			apierror.RespondCode(w, r, http.StatusForbidden, code, message)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Permission is one route of the policy and whether a caller may use it
+type Permission struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Rule
+	Allowed bool `json:"allowed"`
+}
+
+// Permissions lists the policy for c, sorted by path and method
+func Permissions(c Caller) []Permission {
+	out := make([]Permission, 0, len(Policy))
+	for key, rule := range Policy {
+		method, path, _ := strings.Cut(key, " ")
+		code, _ := rule.Check(c)
+		out = append(out, Permission{Method: method, Path: path, Rule: rule, Allowed: code == ""})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+// CheckPolicy compares the routes of router under prefix with Policy. It returns the
+// routes without a rule (which Authorize would refuse) and the rules without a route.
+func CheckPolicy(router chi.Routes, prefix string) (missing, stale []string, err error) {
+	seen := make(map[string]bool)
+	err = chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		path, ok := strings.CutPrefix(route, prefix)
+		if !ok {
+			return nil
+		}
+		key := routeKey(method, path)
+		seen[key] = true
+		if _, ok := Policy[key]; !ok {
+			missing = append(missing, key)
+		}
+		return nil
+	})
+	for key := range Policy {
+		if !seen[key] {
+			stale = append(stale, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+	return missing, stale, err
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+)
+
+var (
+	session    = Caller{Role: RoleUser}
+	adminUser  = Caller{Role: RoleAdmin}
+	readPAT    = Caller{Role: RoleUser, Scopes: []string{ScopeFilesRead}}
+	allPAT     = Caller{Role: RoleUser, Scopes: DefaultPATScopes}
+	adminPAT   = Caller{Role: RoleAdmin, Scopes: []string{ScopeFilesRead, ScopeAdmin}}
+	readKey    = Caller{Role: RoleUser, Scopes: []string{ScopeFilesRead}, ServiceAccount: true}
+	adminKey   = Caller{Role: RoleAdmin, Scopes: []string{ScopeFilesRead, ScopeAdmin}, ServiceAccount: true}
+	demotedPAT = Caller{Role: RoleUser, Scopes: []string{ScopeFilesRead, ScopeAdmin}}
+)
+
+func TestPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		route  string
+		caller Caller
+		code   string
+	}{
+		{"public route, no caller", "POST /auth/login", Caller{}, ""},
+		{"public route, service key", "GET /version", readKey, ""},
+
+		{"session reads files", "GET /files", session, ""},
+		{"session deletes files", "DELETE /files", session, ""},
+		{"read PAT reads files", "GET /files", readPAT, ""},
+		{"read PAT uploads", "POST /upload", readPAT, apierror.CodeInsufficientScope},
+		{"read PAT deletes files", "DELETE /files", readPAT, apierror.CodeInsufficientScope},
+		{"default PAT deletes files", "DELETE /files/trash", allPAT, ""},
+		{"read key reads files", "GET /files", readKey, ""},
+		{"read key uploads", "POST /upload", readKey, apierror.CodeInsufficientScope},
+		{"any scope route, read PAT", "GET /permissions", readPAT, ""},
+
+		{"human-only route, session", "POST /auth/tokens", session, ""},
+		{"human-only route, PAT", "GET /auth/tokens", readPAT, ""},
+		{"human-only route, PAT without scope", "POST /auth/tokens", readPAT, apierror.CodeInsufficientScope},
+		{"human-only route, service key", "GET /auth/tokens", readKey, apierror.CodeForbidden},
+		{"human-only profile, service key", "PATCH /user/profile", readKey, apierror.CodeForbidden},
+
+		{"admin route, user session", "GET /admin/users", session, apierror.CodeAdminRequired},
+		{"admin route, admin session", "GET /admin/users", adminUser, ""},
+		{"admin route, admin PAT without admin scope", "GET /admin/users", Caller{Role: RoleAdmin, Scopes: DefaultPATScopes}, apierror.CodeInsufficientScope},
+		{"admin route, admin PAT", "GET /admin/users", adminPAT, ""},
+		{"admin route, PAT of a demoted admin", "GET /admin/users", demotedPAT, apierror.CodeAdminRequired},
+		{"admin route, admin service key", "GET /admin/service-accounts", adminKey, apierror.CodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := Policy[tt.route]
+			if !ok {
+				t.Fatalf("no rule for %s", tt.route)
+			}
+			if code, message := rule.Check(tt.caller); code != tt.code {
+				t.Errorf("Check = %q (%s), want %q", code, message, tt.code)
+			}
+		})
+	}
+}
+
+func TestPolicyAdminRoutes(t *testing.T) {
+	for key, rule := range Policy {
+		_, path, _ := strings.Cut(key, " ")
+		if !strings.HasPrefix(path, "/admin/") {
+			continue
+		}
+		if rule.Role != RoleAdmin || rule.Scope != ScopeAdmin || !rule.HumanOnly {
+			t.Errorf("%s: rule %+v, want admin()", key, rule)
+		}
+	}
+}
+
+func TestCheckTokenGrant(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller Caller
+		scopes []string
+		code   string
+	}{
+		{"session, default scopes", session, DefaultPATScopes, ""},
+		{"session, admin scope", session, []string{ScopeAdmin}, apierror.CodeAdminRequired},
+		{"admin session, admin scope", adminUser, []string{ScopeFilesRead, ScopeAdmin}, ""},
+		{"PAT, own scopes", allPAT, []string{ScopeFilesRead, ScopeFilesDelete}, ""},
+		{"read PAT, same scope", readPAT, []string{ScopeFilesRead}, ""},
+		{"read PAT, broader scopes", readPAT, []string{ScopeFilesRead, ScopeFilesWrite}, apierror.CodeInsufficientScope},
+		{"read PAT, admin scope", readPAT, []string{ScopeAdmin}, apierror.CodeAdminRequired},
+		{"default PAT of an admin, admin scope", Caller{Role: RoleAdmin, Scopes: DefaultPATScopes}, []string{ScopeAdmin}, apierror.CodeInsufficientScope},
+		{"admin PAT, admin scope", adminPAT, []string{ScopeAdmin}, ""},
+		{"admin PAT, write scope", adminPAT, []string{ScopeFilesWrite}, apierror.CodeInsufficientScope},
+		{"PAT of a demoted admin, admin scope", demotedPAT, []string{ScopeAdmin}, apierror.CodeAdminRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, message := CheckTokenGrant(tt.caller, tt.scopes); code != tt.code {
+				t.Errorf("CheckTokenGrant = %q (%s), want %q", code, message, tt.code)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		scopes []string
+		key    bool
+		status int
+	}{
+		{"session lists files", http.MethodGet, "/api/v1/files", nil, false, http.StatusOK},
+		{"read PAT lists files", http.MethodGet, "/api/v1/files", []string{ScopeFilesRead}, false, http.StatusOK},
+		{"read key uploads", http.MethodPost, "/api/v1/upload", []string{ScopeFilesRead}, true, http.StatusForbidden},
+		{"write key creates a token", http.MethodPost, "/api/v1/auth/tokens", []string{ScopeFilesWrite}, true, http.StatusForbidden},
+		{"session on a route without a rule", http.MethodGet, "/api/v1/unlisted", nil, false, http.StatusForbidden},
+	}
+
+	a := &AuthMiddleware{}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	r := chi.NewRouter()
+	r.Route("/api/v1", func(r chi.Router) {
+		// As in the server: group middleware runs once the route pattern is known
+		r.Group(func(r chi.Router) {
+			r.Use(a.Authorize)
+			r.Get("/files", ok)
+			r.Post("/upload", ok)
+			r.Post("/auth/tokens", ok)
+			r.Get("/unlisted", ok)
+		})
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), constants.UserIDKey, "user-1")
+			if tt.scopes != nil {
+				ctx = context.WithValue(ctx, constants.TokenScopesKey, tt.scopes)
+			}
+			if tt.key {
+				ctx = context.WithValue(ctx, constants.ServiceKeyIDKey, "key-1")
+			}
+			req := httptest.NewRequest(tt.method, tt.path, nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// NewRefreshToken returns a refresh token for a session, <sessionID>.<secret>, and
+// the hash the session stores in its place
+func NewRefreshToken(sessionID string) (token, hash string, err error) {
+	secret, err := GenerateToken()
+	if err != nil {
+		return "", "", err
+	}
+	token = sessionID + "." + secret
+	return token, HashRefreshToken(token), nil
+}
+
+// SplitRefreshToken returns the session ID of a refresh token; ok is false when
+// token cannot have come from NewRefreshToken
+func SplitRefreshToken(token string) (sessionID string, ok bool) {
+	sessionID, secret, found := strings.Cut(token, ".")
+	if !found || !WellFormedToken(sessionID) || !WellFormedToken(secret) {
+		return "", false
+	}
+	return sessionID, true
+}
+
+// HashRefreshToken is the hash of a refresh token kept by its session
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
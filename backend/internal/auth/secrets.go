@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// TokenBytes is the entropy of bearer secrets handed out in links and cookies (share
+// links, access tickets, stream grants): 256 bits, far beyond guessing range
+const TokenBytes = 32
+
+// errMalformedToken rejects a token that GenerateToken cannot have produced
+var errMalformedToken = errors.New("malformed token")
+
+// tokenLength is the length of a token from GenerateToken
+var tokenLength = base64.RawURLEncoding.EncodedLen(TokenBytes)
+
+// GenerateToken returns a random, URL-safe bearer token of TokenBytes
+func GenerateToken() (string, error) {
+	b := make([]byte, TokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// WellFormedToken reports whether s could have come from GenerateToken; malformed
+// guesses are refused without a database or Redis lookup
+func WellFormedToken(s string) bool {
+	if len(s) != tokenLength {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// TokenEqual compares two secrets in constant time
+func TokenEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// Token guard scopes: failures in one do not lock a client out of the others
+const (
+	GuardShare     = "share"
+	GuardTicket    = "ticket"
+	GuardSignedURL = "signed-url"
+	// GuardSharePassword counts wrong file passwords on share links, per address
+	// and per link
+	GuardSharePassword = "share-password"
+)
+
+// TokenGuard locks a client address out of a kind of token after too many invalid
+// ones, so link tokens cannot be guessed by brute force. Counters live in Redis and
+// are shared by all replicas.
+type TokenGuard struct {
+	redisCache  *storage.RedisCache
+	maxFailures int
+	window      time.Duration
+	lockout     time.Duration
+}
+
+// NewTokenGuard creates a guard allowing maxFailures invalid tokens per address
+// within window before a lockout; zero values get defaults (20, 10m, 15m)
+func NewTokenGuard(redisCache *storage.RedisCache, maxFailures int, window, lockout time.Duration) *TokenGuard {
+	if maxFailures <= 0 {
+		maxFailures = 20
+	}
+	if window <= 0 {
+		window = 10 * time.Minute
+	}
+	if lockout <= 0 {
+		lockout = 15 * time.Minute
+	}
+	return &TokenGuard{redisCache: redisCache, maxFailures: maxFailures, window: window, lockout: lockout}
+}
+
+// Allow answers the request with 429 and returns false while its address is locked
+// out of scope. Redis errors fail open: a guard outage must not break every link.
+func (g *TokenGuard) Allow(w http.ResponseWriter, r *http.Request, scope string) bool {
+	return g.allow(w, r, scope, guardIP(r), "Too many invalid links from this address; try again later")
+}
+
+// Fail records an invalid token presented by the request's address
+func (g *TokenGuard) Fail(ctx context.Context, r *http.Request, scope string) {
+	g.fail(ctx, scope, guardIP(r))
+}
+
+// AllowPassword answers the request with 429 and returns false while its address,
+// or the target the password protects (e.g. a share link), is locked out of scope.
+// The target lockout stops guesses spread over many addresses.
+func (g *TokenGuard) AllowPassword(w http.ResponseWriter, r *http.Request, scope, target string) bool {
+	const message = "Too many wrong passwords; try again later"
+	return g.allow(w, r, scope, guardIP(r), message) && g.allow(w, r, scope, "target:"+target, message)
+}
+
+// FailPassword records a wrong password for target from the request's address
+func (g *TokenGuard) FailPassword(ctx context.Context, r *http.Request, scope, target string) {
+	g.fail(ctx, scope, guardIP(r))
+	g.fail(ctx, scope, "target:"+target)
+}
+
+// allow answers the request with 429 while subject is locked out of scope
+func (g *TokenGuard) allow(w http.ResponseWriter, r *http.Request, scope, subject, message string) bool {
+	remaining, err := g.redisCache.TokenLockout(r.Context(), scope, subject)
+	if err != nil {
+		log.Printf("[auth] %v", err)
+		return true
+	}
+	if remaining <= 0 {
+		return true
+	}
+
+	retryAfter := int(remaining.Round(time.Second) / time.Second)
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	apierror.Write(w, r, apierror.New(http.StatusTooManyRequests, apierror.CodeTooManyAttempts, message).
+		With("retry_after", retryAfter))
+	return false
+}
+
+// fail counts a failure of subject (an address or a target) in scope
+func (g *TokenGuard) fail(ctx context.Context, scope, subject string) {
+	count, err := g.redisCache.RecordTokenFailure(ctx, scope, subject, g.maxFailures, g.window, g.lockout)
+	if err != nil {
+		log.Printf("[auth] %v", err)
+		return
+	}
+	if count >= int64(g.maxFailures) {
+		log.Printf("[auth] Locked %s out of %s for %s after %d invalid attempts", subject, scope, g.lockout, count)
+	}
+}
+
+// guardIP is the client address (RealIP has already resolved proxies into RemoteAddr)
+func guardIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
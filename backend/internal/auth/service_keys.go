@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// ServiceKeyPrefix marks service account API keys: fls_<lookup prefix>_<secret>
+const ServiceKeyPrefix = "fls_"
+
+// Token scopes (service account keys and personal access tokens); Policy says which
+// routes need which
+const (
+	ScopeFilesRead   = "files:read"   // listing, reading and downloading files
+	ScopeFilesWrite  = "files:write"  // uploads and metadata, star, share and password changes
+	ScopeFilesDelete = "files:delete" // deleting files and emptying the trash
+)
+
+// ValidScopes lists the scopes a service account key may be granted
+var ValidScopes = map[string]bool{
+	ScopeFilesRead:   true,
+	ScopeFilesWrite:  true,
+	ScopeFilesDelete: true,
+}
+
+// GenerateServiceKey returns a new raw service key and its public lookup prefix
+func GenerateServiceKey() (rawKey, lookupPrefix string, err error) {
+	p := make([]byte, 6)
+	if _, err := rand.Read(p); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	lookupPrefix = hex.EncodeToString(p)
+	return ServiceKeyPrefix + lookupPrefix + "_" + base64.RawURLEncoding.EncodeToString(secret), lookupPrefix, nil
+}
+
+// ipInCIDRs reports whether ip falls within any of the allowed CIDRs
+func ipInCIDRs(ip string, cidrs []string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err == nil && n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticateServiceKey verifies a service account key and its source address, and
+// serves the request as the service account (Authorize checks its scopes)
+func (a *AuthMiddleware) authenticateServiceKey(w http.ResponseWriter, r *http.Request, next http.Handler, rawKey string) {
+	lookupPrefix, _, ok := strings.Cut(strings.TrimPrefix(rawKey, ServiceKeyPrefix), "_")
+	if !ok || lookupPrefix == "" {
+		apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid token")
+		return
+	}
+
+	ctx := r.Context()
+	key, err := a.pg.VerifyServiceAccountKey(ctx, lookupPrefix, rawKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("[auth] Service key verify failed: prefix=%s from %s", lookupPrefix, r.RemoteAddr)
+			apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid token")
+			return
+		}
+		log.Printf("[auth] Service key verify error from %s: %v", r.RemoteAddr, err)
+		apierror.Respond(w, r, http.StatusInternalServerError, "token lookup failed")
+		return
+	}
+
+	// RealIP middleware has already resolved the client address into RemoteAddr
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !ipInCIDRs(ip, key.AllowedCIDRs) {
+		log.Printf("[auth] Service key %s rejected: source %s not in allowed CIDRs", key.ID, ip)
+		apierror.Respond(w, r, http.StatusForbidden, "Source address not allowed for this key")
+		return
+	}
+
+	// Keys of a suspended service account stop working with it
+	if !a.allowAccount(w, r, key.ServiceAccountID) {
+		return
+	}
+
+	if err := a.pg.TouchServiceAccountKey(ctx, key.ID, ip); err != nil {
+		log.Printf("[auth] %v", err)
+	}
+
+	ctx = context.WithValue(ctx, constants.UserIDKey, key.ServiceAccountID)
+	ctx = context.WithValue(ctx, constants.ServiceKeyIDKey, key.ID)
+	ctx = context.WithValue(ctx, constants.TokenScopesKey, key.Scopes)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// IsServiceAccount reports whether a user row is a service account
+func IsServiceAccount(user *storage.User) bool {
+	return user != nil && user.Role == storage.RoleService
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sachinthra/file-locker/backend/internal/apierror"
+	"github.com/sachinthra/file-locker/backend/internal/constants"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Ticket purposes
+const (
+	TicketPurposeDownload = "download"
+	TicketPurposeStream   = "stream"
+)
+
+const (
+	// TicketTTL is how long a minted ticket may wait for its (single) use
+	TicketTTL = 60 * time.Second
+	// StreamGrantTTL bounds one playback session started from a stream ticket
+	StreamGrantTTL = 4 * time.Hour
+	// StreamGrantCookie carries the grant for the player's follow-up range requests
+	StreamGrantCookie = "fl_stream"
+)
+
+// RequireAuthOrTicket authenticates download/stream routes ({id} = file ID). Requests
+// with an Authorization header go through RequireAuth. Otherwise a one-time ?ticket=
+// minted for this file and purpose is accepted; session tokens are never read from
+// the query string. A redeemed stream ticket sets a cookie scoped to the stream URL
+// so the player's range requests keep working.
+func (a *AuthMiddleware) RequireAuthOrTicket(purpose string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withHeader := a.RequireAuth(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" {
+				withHeader.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			fileID := chi.URLParam(r, "id")
+
+			var ticket *storage.AccessTicket
+			if raw := r.URL.Query().Get("ticket"); raw != "" {
+				if !a.guard.Allow(w, r, GuardTicket) {
+					return
+				}
+				t, err := a.consumeTicket(ctx, raw)
+				if err != nil {
+					a.guard.Fail(ctx, r, GuardTicket)
+					apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid or expired ticket")
+					return
+				}
+				ticket = t
+			} else if cookie, err := r.Cookie(StreamGrantCookie); err == nil && purpose == TicketPurposeStream {
+				if !a.guard.Allow(w, r, GuardTicket) {
+					return
+				}
+				t, err := a.streamGrant(ctx, cookie.Value)
+				if err != nil {
+					a.guard.Fail(ctx, r, GuardTicket)
+					apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeInvalidToken, "Stream session expired")
+					return
+				}
+				ticket = t
+			} else {
+				apierror.RespondCode(w, r, http.StatusUnauthorized, apierror.CodeAuthRequired, "Authorization header required")
+				return
+			}
+
+			if ticket.FileID != fileID || ticket.Purpose != purpose {
+				log.Printf("[auth] Ticket for %s/%s used on %s from %s", ticket.Purpose, ticket.FileID, r.URL.Path, r.RemoteAddr)
+				apierror.Respond(w, r, http.StatusForbidden, "Ticket not valid for this file")
+				return
+			}
+
+			if !a.allowAccount(w, r, ticket.UserID) {
+				return
+			}
+
+			// First use of a stream ticket: hand out the grant for the rest of the playback
+			if purpose == TicketPurposeStream && r.URL.Query().Get("ticket") != "" {
+				if !a.issueStreamGrant(w, r, *ticket) {
+					return
+				}
+			}
+
+			ctx = context.WithValue(ctx, constants.UserIDKey, ticket.UserID)
+			ctx = context.WithValue(ctx, constants.AccessTicketKey, *ticket)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// consumeTicket redeems a one-time ticket; tokens not in the generated format are
+// refused without a lookup
+func (a *AuthMiddleware) consumeTicket(ctx context.Context, raw string) (*storage.AccessTicket, error) {
+	if !WellFormedToken(raw) {
+		return nil, errMalformedToken
+	}
+	return a.redisCache.ConsumeAccessTicket(ctx, raw)
+}
+
+// streamGrant returns the grant behind a stream cookie
+func (a *AuthMiddleware) streamGrant(ctx context.Context, raw string) (*storage.AccessTicket, error) {
+	if !WellFormedToken(raw) {
+		return nil, errMalformedToken
+	}
+	return a.redisCache.GetStreamGrant(ctx, raw)
+}
+
+func (a *AuthMiddleware) issueStreamGrant(w http.ResponseWriter, r *http.Request, ticket storage.AccessTicket) bool {
+	grant, err := GenerateToken()
+	if err == nil {
+		err = a.redisCache.SaveStreamGrant(r.Context(), grant, ticket, StreamGrantTTL)
+	}
+	if err != nil {
+		log.Printf("[auth] Failed to create stream grant: %v", err)
+		apierror.Respond(w, r, http.StatusInternalServerError, "Failed to start stream")
+		return false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     StreamGrantCookie,
+		Value:    grant,
+		Path:     r.URL.Path,
+		MaxAge:   int(StreamGrantTTL / time.Second),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return true
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// visitorHashSize is the number of HMAC bytes kept per visitor; plenty to tell
+// the visitors of one link apart
+const visitorHashSize = 16
+
+// VisitorHasher turns client addresses into keyed hashes, so share statistics
+// can count unique visitors without storing their addresses. Without the key the
+// hashes cannot be reversed by trying every IPv4 address.
+type VisitorHasher struct {
+	key []byte
+}
+
+// NewVisitorHasher derives the hashing key from the server secret, keeping it
+// distinct from the keys used for JWTs and signed URLs
+func NewVisitorHasher(secret string) *VisitorHasher {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("file-locker share visitors v1"))
+	return &VisitorHasher{key: mac.Sum(nil)}
+}
+
+// Hash returns the hash of a visitor's address for a share link. The same
+// address hashes differently for every link, so visitors cannot be followed
+// from one link to another.
+func (v *VisitorHasher) Hash(shareID, ip string) string {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(shareID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil)[:visitorHashSize])
+}
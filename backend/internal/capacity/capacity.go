@@ -0,0 +1,149 @@
+// Package capacity estimates the space left for uploads: the free bytes of the
+// filesystem holding the temp directory, and the configured storage capacity minus
+// the bytes of the stored files. An upload that would leave less than the reserve
+// is refused up front (507 Insufficient Storage) instead of failing halfway, when
+// MinIO or the disk runs out. The estimates are part of GET /admin/stats.
+package capacity
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Resources
+const (
+	Temp    = "temp"    // filesystem of the temp directory
+	Storage = "storage" // object storage, against the configured capacity
+)
+
+// storedTTL is how long the sum of the stored bytes is reused; it is a query over
+// every file, too slow to run for each upload
+const storedTTL = 30 * time.Second
+
+// Config selects what is checked
+type Config struct {
+	TempDir         string // "" = the system temp directory
+	MinTempFree     int64  // bytes left free on the temp filesystem; 0 = not checked
+	StorageCapacity int64  // bytes the bucket can hold; 0 = not checked
+	MinStorageFree  int64  // bytes left free in the bucket
+}
+
+// Space is the estimated room of a resource
+type Space struct {
+	Dir           string `json:"dir,omitempty"`
+	FreeBytes     int64  `json:"free_bytes"`
+	TotalBytes    int64  `json:"total_bytes"`
+	ReservedBytes int64  `json:"reserved_bytes"` // kept free; uploads that would use it are refused
+	Low           bool   `json:"low"`            // new uploads are refused
+}
+
+// Status is the estimated room of the checked resources
+type Status struct {
+	Temp    *Space `json:"temp,omitempty"`
+	Storage *Space `json:"storage,omitempty"`
+}
+
+// InsufficientError refuses an upload that does not fit
+type InsufficientError struct {
+	Resource  string
+	FreeBytes int64 // above the reserve
+	Needed    int64
+}
+
+func (e *InsufficientError) Error() string {
+	return fmt.Sprintf("not enough %s space: %d bytes free, %d needed", e.Resource, e.FreeBytes, e.Needed)
+}
+
+// Guard checks that uploads fit
+type Guard struct {
+	pgStore *storage.PostgresStore
+	cfg     Config
+
+	mu       sync.Mutex
+	stored   int64
+	storedAt time.Time
+}
+
+// New creates a guard; without a reserve or capacity it allows every upload
+func New(pgStore *storage.PostgresStore, cfg Config) *Guard {
+	if cfg.TempDir == "" {
+		cfg.TempDir = os.TempDir()
+	}
+	return &Guard{pgStore: pgStore, cfg: cfg}
+}
+
+// Enabled reports whether anything is checked
+func (g *Guard) Enabled() bool {
+	return g.cfg.MinTempFree > 0 || g.cfg.StorageCapacity > 0
+}
+
+// Check returns an *InsufficientError if an upload of size bytes (0 when unknown)
+// does not fit in one of the resources
+func (g *Guard) Check(ctx context.Context, size int64) error {
+	if size < 0 {
+		size = 0
+	}
+	status, err := g.Status(ctx)
+	if err != nil || status == nil {
+		return err
+	}
+	for _, r := range []struct {
+		name  string
+		space *Space
+	}{{Temp, status.Temp}, {Storage, status.Storage}} {
+		if r.space == nil {
+			continue
+		}
+		if free := r.space.FreeBytes - r.space.ReservedBytes; free <= size {
+			return &InsufficientError{Resource: r.name, FreeBytes: max(free, 0), Needed: size}
+		}
+	}
+	return nil
+}
+
+// Status estimates the room left; nil if nothing is checked
+func (g *Guard) Status(ctx context.Context) (*Status, error) {
+	if !g.Enabled() {
+		return nil, nil
+	}
+	var status Status
+	if g.cfg.MinTempFree > 0 {
+		free, total, err := diskSpace(g.cfg.TempDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get free space of %s: %w", g.cfg.TempDir, err)
+		}
+		status.Temp = space(free, total, g.cfg.MinTempFree)
+		status.Temp.Dir = g.cfg.TempDir
+	}
+	if g.cfg.StorageCapacity > 0 {
+		stored, err := g.storedBytes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		status.Storage = space(g.cfg.StorageCapacity-stored, g.cfg.StorageCapacity, g.cfg.MinStorageFree)
+	}
+	return &status, nil
+}
+
+func space(free, total, reserved int64) *Space {
+	return &Space{FreeBytes: max(free, 0), TotalBytes: total, ReservedBytes: reserved, Low: free <= reserved}
+}
+
+func (g *Guard) storedBytes(ctx context.Context) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if time.Since(g.storedAt) < storedTTL {
+		return g.stored, nil
+	}
+	stored, err := g.pgStore.GetStoredBytes(ctx)
+	if err != nil {
+		return 0, err
+	}
+	g.stored, g.storedAt = stored, time.Now()
+	return stored, nil
+}
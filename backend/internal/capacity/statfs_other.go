@@ -0,0 +1,10 @@
+//go:build !unix
+
+package capacity
+
+import "errors"
+
+// diskSpace is not available on this platform; the temp directory is not checked
+func diskSpace(dir string) (free, total int64, err error) {
+	return 0, 0, errors.New("free disk space is not available on this platform")
+}
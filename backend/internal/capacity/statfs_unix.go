@@ -0,0 +1,15 @@
+//go:build unix
+
+package capacity
+
+import "syscall"
+
+// diskSpace returns the free (for unprivileged users) and total bytes of the
+// filesystem holding dir
+func diskSpace(dir string) (free, total int64, err error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return 0, 0, err
+	}
+	return int64(st.Bavail) * int64(st.Bsize), int64(st.Blocks) * int64(st.Bsize), nil
+}
@@ -16,6 +16,24 @@ type Config struct {
 	Storage  StorageConfig  `mapstructure:"storage" validate:"required"`
 	Features FeaturesConfig `mapstructure:"features" validate:"required"`
 	Logging  LoggingConfig  `mapstructure:"logging" validate:"required"`
+	Mail     MailConfig     `mapstructure:"mail"`
+	I18n     I18nConfig     `mapstructure:"i18n"`
+}
+
+// I18nConfig points to translations of server messages (API errors, emails,
+// announcements): one <language>.json per language, e.g. de.json, fr-CA.json
+type I18nConfig struct {
+	Dir string `mapstructure:"dir"` // "" = English only
+}
+
+// MailConfig is the SMTP relay for email verification codes; without a host,
+// messages are written to the server log
+type MailConfig struct {
+	SMTPHost string `mapstructure:"smtp_host"`
+	SMTPPort int    `mapstructure:"smtp_port" validate:"min=0,max=65535"` // 0 = 587
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from" validate:"required_with=SMTPHost"`
 }
 
 type ServerConfig struct {
@@ -25,6 +43,48 @@ type ServerConfig struct {
 	ReadTimeout    time.Duration `mapstructure:"read_timeout" validate:"required"`
 	WriteTimeout   time.Duration `mapstructure:"write_timeout" validate:"required"`
 	MaxHeaderBytes int           `mapstructure:"max_header_bytes" validate:"required,min=1"`
+
+	// "development" accepts the sample secrets; anything else (default "production") refuses them
+	Mode string `mapstructure:"mode" validate:"omitempty,oneof=development production"`
+
+	// Slow-client protection
+	ReadHeaderTimeout       time.Duration `mapstructure:"read_header_timeout"`                             // 0 = fall back to read_timeout
+	MaxBodyBytes            int64         `mapstructure:"max_body_bytes" validate:"min=0"`                 // global cap, 0 = unlimited
+	MaxUploadBytes          int64         `mapstructure:"max_upload_bytes" validate:"min=0"`               // cap for /upload, 0 = unlimited
+	MaxConcurrentRequestsIP int           `mapstructure:"max_concurrent_requests_per_ip" validate:"min=0"` // 0 = unlimited
+
+	// Keep-alive and per-request timeouts
+	IdleTimeout    time.Duration            `mapstructure:"idle_timeout"`    // keep-alive connections; 0 = fall back to read_timeout
+	RequestTimeout time.Duration            `mapstructure:"request_timeout"` // handler timeout; 0 = 60s
+	RouteTimeouts  map[string]time.Duration `mapstructure:"route_timeouts"`  // path prefix -> timeout (0 = none)
+
+	// Uploads/downloads have no overall timeout; they fail after this long without progress (0 = 60s)
+	TransferStallTimeout time.Duration `mapstructure:"transfer_stall_timeout"`
+
+	// Reverse proxies (CIDRs or addresses) whose X-Forwarded-For, X-Real-IP,
+	// True-Client-IP and country headers are believed; empty = none, the peer
+	// address is the client
+	TrustedProxies []string `mapstructure:"trusted_proxies" validate:"dive,cidr|ip"`
+
+	// Date /api/v1 goes away, announced in its Sunset header (YYYY-MM-DD; empty = not scheduled)
+	APIV1Sunset string `mapstructure:"api_v1_sunset" validate:"omitempty,datetime=2006-01-02"`
+
+	Compression CompressionConfig `mapstructure:"compression"`
+	Startup     StartupConfig     `mapstructure:"startup"`
+}
+
+// StartupConfig controls how long the server waits for Postgres, MinIO and Redis
+// to come up (overridden by the --wait-for-deps flag)
+type StartupConfig struct {
+	WaitForDeps    time.Duration `mapstructure:"wait_for_deps" validate:"min=0"`   // 0 = fail on the first error
+	InitialBackoff time.Duration `mapstructure:"initial_backoff" validate:"min=0"` // doubled after each attempt; 0 = 1s
+	MaxBackoff     time.Duration `mapstructure:"max_backoff" validate:"min=0"`     // 0 = 30s
+}
+
+type CompressionConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	MinSize int  `mapstructure:"min_size" validate:"min=0"`    // bytes; smaller responses are sent uncompressed
+	Level   int  `mapstructure:"level" validate:"min=0,max=9"` // 1 (fast) - 9 (small), 0 = default
 }
 
 type SecurityConfig struct {
@@ -33,6 +93,84 @@ type SecurityConfig struct {
 	DefaultAdmin   DefaultAdmin    `mapstructure:"default_admin" validate:"required"`
 	TLS            TLSConfig       `mapstructure:"tls" validate:"required"`
 	RateLimit      RateLimitConfig `mapstructure:"rate_limiting" validate:"required"`
+
+	// AccessTokenTTL is the lifetime of access tokens (0 = 15m); clients renew them
+	// with their refresh token. SessionTimeout is the lifetime of a session (and its
+	// refresh token), extended on every refresh.
+	AccessTokenTTL time.Duration `mapstructure:"access_token_ttl" validate:"min=0"`
+
+	// JWTKeys is the session token key ring; unset = jwt_secret alone (key ID
+	// "default"). Tokens are signed with the first key until a rotation moves to the
+	// next one: append new keys at the end and keep a spare after the active one.
+	JWTKeys []JWTKeyConfig `mapstructure:"jwt_keys" validate:"unique=ID,dive"`
+	// Rotate to the next key after this long (0 = only on request); the previous
+	// key keeps verifying its tokens until they expire
+	JWTRotationInterval time.Duration `mapstructure:"jwt_rotation_interval" validate:"min=0"`
+
+	// KEK is the base64-encoded 32-byte key-encryption key (set it through
+	// FILELOCKER_SECURITY_KEK rather than the config file). EncryptMetadata stores
+	// file names, descriptions and tags encrypted under it (or under a KMS-wrapped
+	// key when only KMS is set).
+	KEK             string `mapstructure:"kek"`
+	EncryptMetadata bool   `mapstructure:"encrypt_metadata"`
+
+	// Retired KEKs, still accepted for reading until a key rotation
+	// (fl admin rotate-keys) has moved every file to KEK
+	PreviousKEKs []string `mapstructure:"previous_keks"`
+
+	// KMS keeps the master key in an external key management service; when set,
+	// data keys are wrapped by it instead of KEK
+	KMS KMSConfig `mapstructure:"kms"`
+
+	// TokenGuard locks a client address out of share links, tickets and signed
+	// download URLs after too many invalid tokens
+	TokenGuard TokenGuardConfig `mapstructure:"token_guard"`
+
+	// IPAccess configures the admin-managed IP allow/deny lists (the lists
+	// themselves are settings, changed with PUT /admin/ip-access)
+	IPAccess IPAccessConfig `mapstructure:"ip_access"`
+}
+
+type IPAccessConfig struct {
+	// Request header carrying the client's ISO 3166 country, set by the reverse
+	// proxy or CDN (e.g. CF-IPCountry); unset = no country blocking. Only read from
+	// server.trusted_proxies, which must overwrite or strip a client's copy.
+	CountryHeader string `mapstructure:"country_header"`
+}
+
+type JWTKeyConfig struct {
+	ID     string `mapstructure:"id" validate:"required"`
+	Secret string `mapstructure:"secret" validate:"required,min=16"`
+}
+
+type TokenGuardConfig struct {
+	MaxFailures int           `mapstructure:"max_failures" validate:"min=0"` // 0 = 20
+	Window      time.Duration `mapstructure:"window" validate:"min=0"`       // failures are counted over this window (0 = 10m)
+	Lockout     time.Duration `mapstructure:"lockout" validate:"min=0"`      // 0 = 15m
+}
+
+type KMSConfig struct {
+	Provider string         `mapstructure:"provider" validate:"omitempty,oneof=vault awskms"`
+	Vault    VaultKMSConfig `mapstructure:"vault"`
+	AWS      AWSKMSConfig   `mapstructure:"aws"`
+	// How long unwrapped data keys stay in memory (0 = not cached)
+	CacheTTL time.Duration `mapstructure:"cache_ttl" validate:"min=0"`
+}
+
+type VaultKMSConfig struct {
+	Address string `mapstructure:"address"` // default VAULT_ADDR
+	Token   string `mapstructure:"token"`   // default VAULT_TOKEN
+	Mount   string `mapstructure:"mount"`   // transit engine mount, default "transit"
+	KeyName string `mapstructure:"key_name" validate:"required_if=Provider vault"`
+}
+
+type AWSKMSConfig struct {
+	Region          string `mapstructure:"region"` // default AWS_REGION
+	KeyID           string `mapstructure:"key_id" validate:"required_if=Provider awskms"`
+	AccessKeyID     string `mapstructure:"access_key_id"` // default AWS_ACCESS_KEY_ID
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	SessionToken    string `mapstructure:"session_token"`
+	Endpoint        string `mapstructure:"endpoint"` // override, e.g. for LocalStack
 }
 
 type DefaultAdmin struct {
@@ -43,8 +181,8 @@ type DefaultAdmin struct {
 
 type TLSConfig struct {
 	Enabled  bool   `mapstructure:"enabled"`
-	CertFile string `mapstructure:"cert_file"`
-	KeyFile  string `mapstructure:"key_file"`
+	CertFile string `mapstructure:"cert_file" validate:"required_if=Enabled true"`
+	KeyFile  string `mapstructure:"key_file" validate:"required_if=Enabled true"`
 }
 
 type RateLimitConfig struct {
@@ -68,7 +206,12 @@ type DatabaseConfig struct {
 	SSLMode         string `mapstructure:"sslmode" validate:"required,oneof=disable require verify-ca verify-full"`
 	MaxOpenConns    int    `mapstructure:"max_open_conns" validate:"required,min=1"`
 	MaxIdleConns    int    `mapstructure:"max_idle_conns" validate:"required,min=1"`
-	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime" validate:"required,min=1"`
+	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime" validate:"required,min=1"` // seconds
+	// Queries taking at least this long are logged (parameters redacted); 0 = off
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold" validate:"min=0"`
+	// Do not migrate at startup (the schema is managed with fl-server migrate); the
+	// server only warns when migrations are pending
+	SkipMigrations bool `mapstructure:"skip_migrations"`
 }
 
 type MinIOConfig struct {
@@ -80,6 +223,59 @@ type MinIOConfig struct {
 	Bucket      string `mapstructure:"bucket" validate:"required"`
 	UseSSL      bool   `mapstructure:"use_ssl"`
 	Region      string `mapstructure:"region" validate:"required"`
+	// "shared" (default) keeps all objects in Bucket under "<user id>/"; "bucket" gives
+	// each user a bucket "<bucket>-<user id>" for per-tenant lifecycle rules and policies
+	Isolation string `mapstructure:"isolation" validate:"omitempty,oneof=shared bucket"`
+	// Standby endpoint every object write and delete is mirrored to
+	Replica MinIOReplicaConfig `mapstructure:"replica"`
+	// Fail fast with 503 while MinIO is down
+	Breaker MinIOBreakerConfig `mapstructure:"breaker"`
+	// Repeat operations that failed with network errors, timeouts or 5xx
+	Retry MinIORetryConfig `mapstructure:"retry"`
+	// Check the ETag and size of every stored object against the bytes sent and
+	// remove it on a mismatch; leave off with SSE-KMS or SSE-C (the ETag is not an MD5)
+	VerifyUploads bool `mapstructure:"verify_uploads"`
+	// Part size and concurrency of multipart writes
+	Upload MinIOUploadConfig `mapstructure:"upload"`
+	// Write owner, tier and expiry of every object as user metadata and tags,
+	// for S3 lifecycle rules and tooling working on the bucket directly
+	LabelObjects bool `mapstructure:"label_objects"`
+}
+
+// MinIOUploadConfig tunes multipart writes: a streamed upload buffers
+// part_size * concurrency bytes in memory and can be at most part_size * 10000
+type MinIOUploadConfig struct {
+	PartSize     int64 `mapstructure:"part_size" validate:"omitempty,min=5242880,max=5368709120"` // bytes; 0 = 16 MiB for streams
+	Concurrency  int   `mapstructure:"concurrency" validate:"min=0,max=64"`                       // parts sent at once; 0 = 4 for known sizes, 1 for streams
+	SinglePutMax int64 `mapstructure:"single_put_max" validate:"min=0,max=5368709120"`            // bytes sent without multipart; 0 = up to a part
+}
+
+// MinIORetryConfig retries reads, stats, copies, deletes and rewindable writes
+// with exponential backoff and full jitter; streamed uploads are not repeated
+type MinIORetryConfig struct {
+	Attempts  int           `mapstructure:"attempts" validate:"min=0,max=10"` // the first included; 0/1 = no retries
+	BaseDelay time.Duration `mapstructure:"base_delay" validate:"min=0"`      // 0 = 200ms
+	MaxDelay  time.Duration `mapstructure:"max_delay" validate:"min=0"`       // 0 = 5s
+}
+
+// MinIOBreakerConfig opens a circuit breaker after Failures consecutive network
+// errors, timeouts or 5xx responses from MinIO; requests then get 503 with
+// Retry-After until Cooldown has passed and a request succeeds again
+type MinIOBreakerConfig struct {
+	Failures int           `mapstructure:"failures" validate:"min=0"` // 0 = no breaker
+	Cooldown time.Duration `mapstructure:"cooldown" validate:"min=0"` // 0 = 30s
+}
+
+// MinIOReplicaConfig is a second S3/MinIO endpoint kept as a cold standby. Objects
+// go to buckets named as on the primary, so failing over means pointing the
+// primary settings at it.
+type MinIOReplicaConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	Endpoint  string `mapstructure:"endpoint" validate:"required_if=Enabled true"`
+	AccessKey string `mapstructure:"access_key" validate:"required_if=Enabled true"`
+	SecretKey string `mapstructure:"secret_key" validate:"required_if=Enabled true"`
+	UseSSL    bool   `mapstructure:"use_ssl"`
+	Region    string `mapstructure:"region"` // "" for the primary's
 }
 
 type RedisConfig struct {
@@ -87,22 +283,193 @@ type RedisConfig struct {
 	Port     int    `mapstructure:"port" validate:"required,min=1,max=65535"` // For Docker Port Mapping
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db" validate:"min=0"`
+
+	// Connection pool; 0 = go-redis defaults
+	PoolSize     int           `mapstructure:"pool_size" validate:"min=0"`      // 0 = 10 per CPU
+	MinIdleConns int           `mapstructure:"min_idle_conns" validate:"min=0"` // kept open while idle
+	PoolTimeout  time.Duration `mapstructure:"pool_timeout" validate:"min=0"`   // wait for a free connection
 }
 
 type FeaturesConfig struct {
 	AutoDelete     AutoDeleteConfig     `mapstructure:"auto_delete" validate:"required"`
 	VideoStreaming VideoStreamingConfig `mapstructure:"video_streaming" validate:"required"`
 	BatchUploads   BatchUploadsConfig   `mapstructure:"batch_uploads" validate:"required"`
+	Uploads        UploadsConfig        `mapstructure:"uploads"`
+	Shares         SharesConfig         `mapstructure:"shares"`
+	DownloadURLs   DownloadURLsConfig   `mapstructure:"download_urls"`
+	FileNames      FileNamesConfig      `mapstructure:"file_names"`
+	Jobs           JobsConfig           `mapstructure:"jobs"`
+	Pipeline       PipelineConfig       `mapstructure:"pipeline"`
+	Trash          TrashConfig          `mapstructure:"trash"`
+	Maintenance    MaintenanceConfig    `mapstructure:"maintenance"`
+	Idempotency    IdempotencyConfig    `mapstructure:"idempotency"`
+	Captcha        CaptchaConfig        `mapstructure:"captcha"`
+	Snapshots      SnapshotsConfig      `mapstructure:"snapshots"`
+	Quarantine     QuarantineConfig     `mapstructure:"quarantine"`
+	Reports        ReportsConfig        `mapstructure:"reports"`
+	Notifications  NotificationsConfig  `mapstructure:"notifications"`
+	StorageAlerts  StorageAlertsConfig  `mapstructure:"storage_alerts"`
+	Usage          UsageConfig          `mapstructure:"usage"`
+}
+
+// UsageConfig posts the monthly usage records (GET /admin/usage) of every
+// finished month to a billing system; they are recorded either way
+type UsageConfig struct {
+	Webhook UsageWebhookConfig `mapstructure:"webhook"`
+}
+
+// UsageWebhookConfig receives a usage.monthly event on the 1st of each month
+type UsageWebhookConfig struct {
+	URL    string `mapstructure:"url" validate:"omitempty,url"` // "" = not posted
+	Secret string `mapstructure:"secret"`                       // signs the body (X-FileLocker-Signature)
+}
+
+// StorageAlertsConfig alerts when storage use crosses a threshold; the current
+// usage is part of GET /admin/stats. Unset thresholds are not checked.
+type StorageAlertsConfig struct {
+	Interval time.Duration `mapstructure:"interval" validate:"min=0"` // 0 = 15m
+	// Users whose files reach this share of storage_quota_per_user_bytes get an
+	// announcement (and email); 0 = off
+	UserQuotaPercent int                    `mapstructure:"user_quota_percent" validate:"min=0,max=100"`
+	Bucket           StorageThresholdConfig `mapstructure:"bucket"`   // all stored files
+	Database         StorageThresholdConfig `mapstructure:"database"` // pg_database_size
+	EmailAdmins      bool                   `mapstructure:"email_admins"`
+	EmailUsers       bool                   `mapstructure:"email_users"` // unless they turned quota emails off
+}
+
+// StorageThresholdConfig alerts admins when usage reaches percent of capacity
+type StorageThresholdConfig struct {
+	Capacity int64 `mapstructure:"capacity" validate:"min=0"`        // bytes; 0 = not checked
+	Percent  int   `mapstructure:"percent" validate:"min=0,max=100"` // 0 = 90
+}
+
+// NotificationsConfig posts events to chat channels (GET /admin/notifications)
+type NotificationsConfig struct {
+	Channels []NotificationChannelConfig `mapstructure:"channels" validate:"unique=Name,dive"`
+}
+
+// NotificationChannelConfig is a Slack or Discord incoming webhook or a Matrix room
+type NotificationChannelConfig struct {
+	Name     string `mapstructure:"name" validate:"required"`
+	Provider string `mapstructure:"provider" validate:"required,oneof=slack discord matrix"`
+	URL      string `mapstructure:"url" validate:"required_unless=Provider matrix,omitempty,url"` // Slack/Discord webhook
+	// Matrix: the room is posted to as the user of access_token, who must have joined it
+	Homeserver  string `mapstructure:"homeserver" validate:"required_if=Provider matrix,omitempty,url"`
+	RoomID      string `mapstructure:"room_id" validate:"required_if=Provider matrix"`
+	AccessToken string `mapstructure:"access_token" validate:"required_if=Provider matrix"`
+	// Events posted to the channel; unset = all
+	Events []string `mapstructure:"events" validate:"dive,oneof=user.pending share.reported file.quarantined cleanup.finished storage.threshold"`
+}
+
+// ReportsConfig sends admins a usage report for every past week and/or month by
+// email and to a chat webhook
+type ReportsConfig struct {
+	Weekly       bool                 `mapstructure:"weekly"`  // on Mondays, for the past week (UTC)
+	Monthly      bool                 `mapstructure:"monthly"` // on the 1st, for the past month
+	EmailAdmins  bool                 `mapstructure:"email_admins"`
+	Recipients   []string             `mapstructure:"recipients" validate:"dive,email"` // mailed in addition to the admins
+	Webhook      ReportsWebhookConfig `mapstructure:"webhook"`
+	TopUploaders int                  `mapstructure:"top_uploaders" validate:"min=0"` // 0 = 5
+}
+
+// ReportsWebhookConfig posts reports to a Slack or Discord incoming webhook
+type ReportsWebhookConfig struct {
+	URL    string `mapstructure:"url" validate:"omitempty,url"`
+	Format string `mapstructure:"format" validate:"omitempty,oneof=slack discord"` // "" = slack
+}
+
+// QuarantineConfig holds back new uploads that match a rule until an admin approves
+// them (/admin/quarantine); without rules nothing is quarantined
+type QuarantineConfig struct {
+	// Accounts younger than this have the trust level "new", older ones "member"
+	TrustedAfter time.Duration          `mapstructure:"trusted_after" validate:"min=0"`
+	Rules        []QuarantineRuleConfig `mapstructure:"rules" validate:"unique=Name,dive"`
+}
+
+// QuarantineRuleConfig matches the uploads that meet all of its conditions
+type QuarantineRuleConfig struct {
+	Name        string   `mapstructure:"name" validate:"required"` // recorded as the reason
+	MinSize     int64    `mapstructure:"min_size" validate:"min=0"`
+	MimeTypes   []string `mapstructure:"mime_types"` // "video/*" matches a whole type
+	Extensions  []string `mapstructure:"extensions"` // ".exe"
+	ScanVerdict string   `mapstructure:"scan_verdict" validate:"omitempty,oneof=clean infected error"`
+	TrustLevels []string `mapstructure:"trust_levels" validate:"dive,oneof=new member admin"`
+}
+
+// SnapshotsConfig schedules metadata snapshots (the files table and an inventory of
+// the stored objects, kept in MinIO) that deleted files can be restored from
+type SnapshotsConfig struct {
+	Interval time.Duration `mapstructure:"interval" validate:"min=0"` // 0 = only on demand
+	Keep     int           `mapstructure:"keep" validate:"min=0"`     // newest snapshots kept; 0 = all
+}
+
+// CaptchaConfig selects the captcha anonymous requests (share reports) must solve (unset provider = none)
+type CaptchaConfig struct {
+	Provider  string `mapstructure:"provider" validate:"omitempty,oneof=hcaptcha turnstile"`
+	SiteKey   string `mapstructure:"site_key" validate:"required_with=Provider"`   // public, rendered by the client
+	SecretKey string `mapstructure:"secret_key" validate:"required_with=Provider"` // sent to the provider's siteverify
+}
+
+type IdempotencyConfig struct {
+	// Responses to requests with an Idempotency-Key are replayed to retries this long (0 = 24h)
+	TTL time.Duration `mapstructure:"ttl" validate:"min=0"`
+}
+
+type MaintenanceConfig struct {
+	// Path prefixes served to everyone while maintenance mode is on (admins are always
+	// served); unset = login, logout and /auth/me
+	Allowlist []string `mapstructure:"allowlist"`
+}
+
+type TrashConfig struct {
+	// Deleted files stay restorable this long, then the auto-delete worker purges
+	// them; 0 deletes files immediately
+	Retention time.Duration `mapstructure:"retention" validate:"min=0"`
+}
+
+type JobsConfig struct {
+	Workers      int           `mapstructure:"workers" validate:"min=0"` // per replica; 0 = do not run jobs here
+	PollInterval time.Duration `mapstructure:"poll_interval" validate:"min=0"`
+	Retention    time.Duration `mapstructure:"retention" validate:"min=0"` // keep succeeded jobs this long (0 = forever)
+}
+
+// PipelineConfig selects the stages run on every uploaded file (as background jobs)
+type PipelineConfig struct {
+	Checksum   bool                  `mapstructure:"checksum"`
+	VirusScan  VirusScanConfig       `mapstructure:"virus_scan"`
+	Thumbnails ThumbnailsConfig      `mapstructure:"thumbnails"`
+	Webhook    PipelineWebhookConfig `mapstructure:"webhook"`
+}
+
+type VirusScanConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	ClamdAddress string `mapstructure:"clamd_address" validate:"required_if=Enabled true"` // host:port of clamd
+}
+
+type ThumbnailsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	Size    int  `mapstructure:"size" validate:"min=0,max=2048"` // longer side in pixels (0 = 256)
+}
+
+type PipelineWebhookConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	URL     string `mapstructure:"url" validate:"required_if=Enabled true,omitempty,url"`
+	Secret  string `mapstructure:"secret"` // signs the body (X-FileLocker-Signature)
 }
 
 type AutoDeleteConfig struct {
-	Enabled       bool `mapstructure:"enabled"`
-	CheckInterval int  `mapstructure:"check_interval" validate:"min=1"`
+	Enabled         bool `mapstructure:"enabled"`
+	CheckInterval   int  `mapstructure:"check_interval" validate:"min=1"`
+	WarnBeforeHours int  `mapstructure:"warn_before_hours" validate:"min=0"` // 0 disables expiry warnings
 }
 
 type VideoStreamingConfig struct {
 	Enabled   bool `mapstructure:"enabled"`
 	ChunkSize int  `mapstructure:"chunk_size" validate:"min=1"`
+	// Memory (bytes) for decrypted blocks reused across range requests; 0 = no cache
+	BlockCacheSize int64 `mapstructure:"block_cache_size" validate:"min=0"`
+	// 64 KB blocks of a range read from MinIO ahead of the client; 0 = no prefetching
+	PrefetchBlocks int `mapstructure:"prefetch_blocks" validate:"min=0"`
 }
 
 type BatchUploadsConfig struct {
@@ -110,6 +477,51 @@ type BatchUploadsConfig struct {
 	MaxConcurrent int  `mapstructure:"max_concurrent" validate:"min=1"`
 }
 
+type UploadsConfig struct {
+	// Cores encrypting each upload in parallel 1 MB segments; 0 or 1 = one stream
+	EncryptionWorkers int `mapstructure:"encryption_workers" validate:"min=0"`
+	// New uploads get 503 while those in progress have received this many bytes; 0 = off
+	InFlightWatermark int64 `mapstructure:"inflight_watermark" validate:"min=0"`
+	// Server-side copies and instant uploads "share" the original's data key (default)
+	// or get their own, re-encrypted in the background ("regenerate")
+	CopyKeys string `mapstructure:"copy_keys" validate:"omitempty,oneof=share regenerate"`
+	// Uploads get 507 when they would eat into these reserves
+	Space UploadSpaceConfig `mapstructure:"space"`
+}
+
+// UploadSpaceConfig sets the reserves kept free on the temp filesystem and in the
+// object storage; the estimates are part of GET /admin/stats
+type UploadSpaceConfig struct {
+	TempDir     string `mapstructure:"temp_dir"`                       // "" = the system temp directory
+	MinTempFree int64  `mapstructure:"min_temp_free" validate:"min=0"` // bytes; 0 = not checked
+	// Bytes the bucket can hold; 0 = features.storage_alerts.bucket.capacity, and
+	// without it the storage is not checked
+	StorageCapacity int64 `mapstructure:"storage_capacity" validate:"min=0"`
+	MinStorageFree  int64 `mapstructure:"min_storage_free" validate:"min=0"`
+}
+
+type SharesConfig struct {
+	// Public share links are disabled automatically (and the owner notified)
+	// when they receive more than this many requests in a minute. 0 = off.
+	SpikeRequestsPerMinute int `mapstructure:"spike_requests_per_minute" validate:"min=0"`
+	// Abuse reports (POST /s/{token}/report) one address may file per hour (0 = 5)
+	ReportsPerHour int `mapstructure:"reports_per_hour" validate:"min=0"`
+}
+
+type DownloadURLsConfig struct {
+	// Lifetime of signed download URLs when the client does not ask for one,
+	// and the longest lifetime a client may request. 0 = built-in defaults (1h / 7 days).
+	DefaultTTL time.Duration `mapstructure:"default_ttl" validate:"min=0"`
+	MaxTTL     time.Duration `mapstructure:"max_ttl" validate:"min=0"`
+}
+
+type FileNamesConfig struct {
+	// How unsafe filenames (control characters, paths, overlong names) in uploads, copies,
+	// renames and moves are handled: "sanitize" (default) repairs them, "reject" refuses the request.
+	Policy    string `mapstructure:"policy" validate:"omitempty,oneof=sanitize reject"`
+	MaxLength int    `mapstructure:"max_length" validate:"min=0,max=255"` // bytes; 0 = 255
+}
+
 type LoggingConfig struct {
 	Level      string `mapstructure:"level" validate:"required,oneof=debug info warn error"`
 	Path       string `mapstructure:"path" validate:"required"`
@@ -155,6 +567,11 @@ func LoadConfig() (*Config, error) {
 
 	viper.AutomaticEnv()
 
+	// Secrets from files (Docker secrets) take precedence over the config file and environment
+	if err := loadSecretFiles(); err != nil {
+		return nil, err
+	}
+
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
@@ -179,6 +596,10 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
+	if err := checkDefaultSecrets(&config); err != nil {
+		return nil, fmt.Errorf("refusing to start with default secrets:\n%w", err)
+	}
+
 	fmt.Println("✅ Configuration validation passed")
 	return &config, nil
 }
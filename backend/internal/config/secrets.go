@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ModeDevelopment allows the sample secrets shipped in configs/config.yaml
+const ModeDevelopment = "development"
+
+// secretKeys can be read from a file named by FILELOCKER_<KEY>_FILE (e.g. a Docker
+// secret at /run/secrets/...), so the value never appears in the environment
+var secretKeys = []string{
+	"security.jwt_secret",
+	"security.default_admin.password",
+	"security.kek",
+	"security.kms.vault.token",
+	"security.kms.aws.secret_access_key",
+	"features.pipeline.webhook.secret",
+	"features.captcha.secret_key",
+	"features.reports.webhook.url",
+	"features.usage.webhook.secret",
+	"mail.password",
+	"storage.database.password",
+	"storage.minio.access_key",
+	"storage.minio.secret_key",
+	"storage.redis.password",
+}
+
+// defaultSecrets are the placeholder values from the sample configs
+var defaultSecrets = map[string][]string{
+	"security.jwt_secret":             {"change-me-in-production", "your-secret-key-change-this"},
+	"security.default_admin.password": {"password123"},
+}
+
+// loadSecretFiles overrides secretKeys with the contents of their *_FILE files.
+// A trailing newline is dropped; a set but unreadable path is an error.
+func loadSecretFiles() error {
+	for _, key := range secretKeys {
+		env := "FILELOCKER_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_")) + "_FILE"
+		path := os.Getenv(env)
+		if path == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", env, err)
+		}
+		viper.Set(key, strings.TrimRight(string(data), "\r\n"))
+	}
+	return nil
+}
+
+// checkDefaultSecrets refuses the sample secrets unless the server runs in
+// development mode
+func checkDefaultSecrets(cfg *Config) error {
+	if cfg.Server.Mode == ModeDevelopment {
+		return nil
+	}
+
+	values := []struct{ key, value string }{
+		{"security.jwt_secret", cfg.Security.JWTSecret},
+		{"security.default_admin.password", cfg.Security.DefaultAdmin.Password},
+	}
+
+	var errs []error
+	for _, v := range values {
+		for _, def := range defaultSecrets[v.key] {
+			if v.value == def {
+				errs = append(errs, fmt.Errorf("❌ %s is still the default value; set a real secret (or server.mode: development)", v.key))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
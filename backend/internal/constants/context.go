@@ -5,4 +5,18 @@ type ContextKey string
 const (
 	UserIDKey ContextKey = "userID"
 	PatIDKey  ContextKey = "patID"
+
+	// SessionClaimsKey holds the *auth.Claims of the access token a session request
+	// authenticated with; unset for tokens and tickets
+	SessionClaimsKey ContextKey = "sessionClaims"
+
+	// TokenScopesKey holds the scopes ([]string) of the personal access token or
+	// service account key a request authenticated with; unset for sessions
+	TokenScopesKey ContextKey = "tokenScopes"
+
+	// ServiceKeyIDKey is set when the request authenticated with a service account key
+	ServiceKeyIDKey ContextKey = "serviceKeyID"
+
+	// AccessTicketKey holds the storage.AccessTicket a download/stream request was authorized with
+	AccessTicketKey ContextKey = "accessTicket"
 )
@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"sync"
 )
 
 // GenerateKey generates a random 256-bit key
@@ -18,7 +19,82 @@ func GenerateKey() ([]byte, error) {
 	return key, nil
 }
 
-// EncryptStream creates a streaming encryptor for large files
+// streamBufferSize is the chunk size streams are encrypted and decrypted in when
+// copied with io.Copy
+const streamBufferSize = 256 << 10
+
+// streamBuffers recycles copy buffers between requests
+var streamBuffers = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, streamBufferSize)
+		return &buf
+	},
+}
+
+// ctrReader applies an AES-CTR keystream to everything read from r. It works in
+// place in the caller's buffer, without a goroutine or pipe in between, and
+// implements io.WriterTo so io.Copy moves data in large pooled chunks.
+type ctrReader struct {
+	r      io.Reader
+	stream cipher.Stream
+	header []byte // still to be emitted before the stream (the IV, when encrypting)
+	source string // what r carries, for error messages
+}
+
+func (c *ctrReader) Read(p []byte) (int, error) {
+	if len(c.header) > 0 {
+		n := copy(p, c.header)
+		c.header = c.header[n:]
+		return n, nil
+	}
+
+	n, err := c.r.Read(p)
+	c.stream.XORKeyStream(p[:n], p[:n])
+	if err != nil && err != io.EOF {
+		err = fmt.Errorf("failed to read %s: %w", c.source, err)
+	}
+	return n, err
+}
+
+func (c *ctrReader) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	if len(c.header) > 0 {
+		n, err := w.Write(c.header)
+		total += int64(n)
+		c.header = c.header[n:]
+		if err != nil {
+			return total, err
+		}
+	}
+
+	bufPtr := streamBuffers.Get().(*[]byte)
+	defer streamBuffers.Put(bufPtr)
+	buf := *bufPtr
+
+	for {
+		n, err := c.r.Read(buf)
+		if n > 0 {
+			c.stream.XORKeyStream(buf[:n], buf[:n])
+			written, writeErr := w.Write(buf[:n])
+			total += int64(written)
+			if writeErr != nil {
+				return total, writeErr
+			}
+			if written != n {
+				return total, io.ErrShortWrite
+			}
+		}
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, fmt.Errorf("failed to read %s: %w", c.source, err)
+		}
+	}
+}
+
+// EncryptStream creates a streaming encryptor for large files. The returned reader
+// yields the IV followed by the ciphertext; plaintext is read as it is consumed.
 func EncryptStream(plaintext io.Reader, key []byte) (io.Reader, error) {
 	// Validate key length before creating cipher
 	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
@@ -35,42 +111,16 @@ func EncryptStream(plaintext io.Reader, key []byte) (io.Reader, error) {
 		return nil, fmt.Errorf("failed to generate IV: %w", err)
 	}
 
-	stream := cipher.NewCTR(block, iv)
-	pr, pw := io.Pipe()
-
-	go func() {
-		defer func() { _ = pw.Close() }()
-
-		// Write IV first
-		if _, err := pw.Write(iv); err != nil {
-			pw.CloseWithError(fmt.Errorf("failed to write IV: %w", err))
-			return
-		}
-
-		buf := make([]byte, 4096)
-		for {
-			n, err := plaintext.Read(buf)
-			if n > 0 {
-				stream.XORKeyStream(buf[:n], buf[:n]) // Reuse buffer
-				if _, writeErr := pw.Write(buf[:n]); writeErr != nil {
-					pw.CloseWithError(fmt.Errorf("failed to write encrypted data: %w", writeErr))
-					return
-				}
-			}
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				pw.CloseWithError(fmt.Errorf("failed to read plaintext: %w", err))
-				return
-			}
-		}
-	}()
-
-	return pr, nil
+	return &ctrReader{
+		r:      plaintext,
+		stream: cipher.NewCTR(block, iv),
+		header: iv,
+		source: "plaintext",
+	}, nil
 }
 
-// DecryptStream creates a streaming decryptor
+// DecryptStream creates a streaming decryptor. It reads the IV right away;
+// ciphertext is read as the plaintext is consumed.
 func DecryptStream(ciphertext io.Reader, key []byte) (io.Reader, error) {
 	// Validate key length before creating cipher
 	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
@@ -87,33 +137,11 @@ func DecryptStream(ciphertext io.Reader, key []byte) (io.Reader, error) {
 		return nil, fmt.Errorf("failed to read IV: %w", err)
 	}
 
-	stream := cipher.NewCTR(block, iv)
-	pr, pw := io.Pipe()
-
-	go func() {
-		defer func() { _ = pw.Close() }()
-
-		buf := make([]byte, 4096)
-		for {
-			n, err := ciphertext.Read(buf)
-			if n > 0 {
-				stream.XORKeyStream(buf[:n], buf[:n]) // Reuse buffer
-				if _, writeErr := pw.Write(buf[:n]); writeErr != nil {
-					pw.CloseWithError(fmt.Errorf("failed to write decrypted data: %w", writeErr))
-					return
-				}
-			}
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				pw.CloseWithError(fmt.Errorf("failed to read ciphertext: %w", err))
-				return
-			}
-		}
-	}()
-
-	return pr, nil
+	return &ctrReader{
+		r:      ciphertext,
+		stream: cipher.NewCTR(block, iv),
+		source: "ciphertext",
+	}, nil
 }
 
 // EncryptBytes encrypts small data (for keys, metadata, etc.)
@@ -0,0 +1,99 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxBlindPrefix is the longest prefix (in characters) that gets its own index entry.
+// Longer search terms are matched on this prefix and verified after decryption.
+const MaxBlindPrefix = 32
+
+// blindIndexSize is the number of HMAC bytes kept per entry. Truncation makes
+// collisions possible, so matches are always re-checked against the plaintext.
+const blindIndexSize = 12
+
+// BlindIndexer computes keyed hashes of metadata values, allowing exact and prefix
+// lookups on encrypted columns without storing plaintext. Values are lower-cased
+// first, so lookups are case-insensitive.
+type BlindIndexer struct {
+	key []byte
+}
+
+// NewBlindIndexer derives the index key from the KEK, so the KEK itself never
+// keys a deterministic function
+func NewBlindIndexer(kek []byte) (*BlindIndexer, error) {
+	if len(kek) != 32 {
+		return nil, fmt.Errorf("invalid key-encryption key length: got %d bytes, need 32", len(kek))
+	}
+	mac := hmac.New(sha256.New, kek)
+	mac.Write([]byte("filelocker blind index v1"))
+	return &BlindIndexer{key: mac.Sum(nil)}, nil
+}
+
+// Index returns the blind index of value in the given domain ("name", "prefix", "tag").
+// Domains keep equal strings in different columns from producing equal entries.
+func (b *BlindIndexer) Index(domain, value string) string {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(domain))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strings.ToLower(value)))
+	return base64.RawStdEncoding.EncodeToString(mac.Sum(nil)[:blindIndexSize])
+}
+
+// PrefixIndexes returns the "prefix" entries for name: every prefix of the whole
+// name and of each word in it, up to MaxBlindPrefix characters, without duplicates
+func (b *BlindIndexer) PrefixIndexes(name string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	add := func(s string) {
+		runes := []rune(strings.ToLower(s))
+		for n := 1; n <= len(runes) && n <= MaxBlindPrefix; n++ {
+			p := string(runes[:n])
+			if !seen[p] {
+				seen[p] = true
+				out = append(out, b.Index("prefix", p))
+			}
+		}
+	}
+
+	add(name)
+	for _, word := range nameWords(name) {
+		add(word)
+	}
+	return out
+}
+
+// PrefixQuery returns the "prefix" entry to look up for a search term
+func (b *BlindIndexer) PrefixQuery(term string) string {
+	runes := []rune(strings.ToLower(term))
+	if len(runes) > MaxBlindPrefix {
+		runes = runes[:MaxBlindPrefix]
+	}
+	return b.Index("prefix", string(runes))
+}
+
+// PrefixMatch reports whether term is a case-insensitive prefix of name or of one
+// of its words, i.e. whether an index hit for term on name is genuine
+func PrefixMatch(name, term string) bool {
+	term = strings.ToLower(term)
+	if strings.HasPrefix(strings.ToLower(name), term) {
+		return true
+	}
+	for _, word := range nameWords(name) {
+		if strings.HasPrefix(strings.ToLower(word), term) {
+			return true
+		}
+	}
+	return false
+}
+
+func nameWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
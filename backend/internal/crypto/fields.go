@@ -0,0 +1,128 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// encryptedFieldPrefix marks column values produced by FieldCipher.Encrypt.
+// Values without it are legacy plaintext and are returned unchanged.
+const encryptedFieldPrefix = "enc:v1:"
+
+// ErrFieldKeyMissing is returned when an encrypted value is read without a key
+var ErrFieldKeyMissing = errors.New("value is encrypted but no key-encryption key is configured")
+
+// FieldCipher encrypts short text values (filenames, descriptions, tags, wrapped
+// data keys) with the key-encryption key (KEK) using AES-256-GCM. Every value is
+// bound to a context string (e.g. "<file id>|file_name") so ciphertexts cannot be
+// moved between rows or columns.
+type FieldCipher struct {
+	aeads []cipher.AEAD // [0] is the current KEK, the rest are retired KEKs still accepted for reading
+}
+
+// NewFieldCipher creates a FieldCipher that encrypts with the 32-byte kek and
+// decrypts with it or any of the previous KEKs (during key rotation)
+func NewFieldCipher(kek []byte, previous ...[]byte) (*FieldCipher, error) {
+	c := &FieldCipher{}
+	for _, key := range append([][]byte{kek}, previous...) {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("invalid key-encryption key length: got %d bytes, need 32", len(key))
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCM: %w", err)
+		}
+		c.aeads = append(c.aeads, aead)
+	}
+
+	return c, nil
+}
+
+// KEKID returns a short, non-secret identifier of a KEK, recorded next to the data
+// it protects so key rotation can tell which rows still use an old key
+func KEKID(kek []byte) string {
+	sum := sha256.Sum256(append([]byte("filelocker kek id v1\x00"), kek...))
+	return hex.EncodeToString(sum[:4])
+}
+
+// ParseKEK decodes a base64 (standard or URL alphabet) 32-byte KEK
+func ParseKEK(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := enc.DecodeString(encoded); err == nil {
+			if len(key) != 32 {
+				return nil, fmt.Errorf("invalid key-encryption key length: got %d bytes, need 32", len(key))
+			}
+			return key, nil
+		}
+	}
+	return nil, errors.New("key-encryption key must be base64 encoded")
+}
+
+// IsEncryptedField reports whether value was produced by FieldCipher.Encrypt
+func IsEncryptedField(value string) bool {
+	return strings.HasPrefix(value, encryptedFieldPrefix)
+}
+
+// Encrypt returns the encrypted, printable form of plaintext. Empty strings stay
+// empty so optional columns keep their meaning.
+func (c *FieldCipher) Encrypt(plaintext, context string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := c.aeads[0]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(context))
+	return encryptedFieldPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt. Values without the encryption prefix are returned as is.
+// A nil FieldCipher can read plaintext values only.
+func (c *FieldCipher) Decrypt(value, context string) (string, error) {
+	if !IsEncryptedField(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", ErrFieldKeyMissing
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, encryptedFieldPrefix))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted value: %w", err)
+	}
+
+	nonceSize := c.aeads[0].NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+
+	var lastErr error
+	for _, aead := range c.aeads {
+		plaintext, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(context))
+		if err == nil {
+			return string(plaintext), nil
+		}
+		lastErr = err
+	}
+
+	return "", fmt.Errorf("failed to decrypt value: %w", lastErr)
+}
@@ -0,0 +1,175 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// parallelSegmentSize is how much plaintext a worker encrypts at a time. It is a
+// multiple of aes.BlockSize, so every segment starts on a counter boundary.
+const parallelSegmentSize = 1 << 20
+
+// segmentBuffers recycles segment buffers between uploads
+var segmentBuffers = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, parallelSegmentSize)
+		return &buf
+	},
+}
+
+// segment is one slice of the stream, encrypted by a worker and emitted in order
+type segment struct {
+	buf     *[]byte
+	data    []byte
+	counter uint64        // AES blocks from the start of the stream
+	err     error         // read error that ended the stream after data
+	last    bool          // nothing follows this segment
+	done    chan struct{} // closed once data is encrypted
+}
+
+// EncryptStreamParallel is EncryptStream spread over several cores: the plaintext
+// is read in 1 MB segments, a pool of workers encrypts each from its own counter
+// offset, and the segments are emitted in order. The output has the same format as
+// EncryptStream, so DecryptStream reads it. The goroutines stop at the end of the
+// plaintext, on a read error, or when ctx is done. With fewer than two workers this
+// is EncryptStream.
+func EncryptStreamParallel(ctx context.Context, plaintext io.Reader, key []byte, workers int) (io.Reader, error) {
+	if workers < 2 {
+		return EncryptStream(plaintext, key)
+	}
+
+	// Validate key length before creating cipher
+	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+		return nil, fmt.Errorf("invalid AES key length: got %d bytes, need 16, 24, or 32", len(key))
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, fmt.Errorf("failed to generate IV: %w", err)
+	}
+
+	jobs := make(chan *segment, workers)
+	ordered := make(chan *segment, 2*workers)
+
+	for i := 0; i < workers; i++ {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			close(jobs)
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		go func() {
+			for seg := range jobs {
+				cipher.NewCTR(block, addCounter(iv, seg.counter)).XORKeyStream(seg.data, seg.data)
+				close(seg.done)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		defer close(ordered)
+
+		for counter := uint64(0); ; counter += parallelSegmentSize / aes.BlockSize {
+			buf := segmentBuffers.Get().(*[]byte)
+			n, err := io.ReadFull(plaintext, *buf)
+			seg := &segment{buf: buf, data: (*buf)[:n], counter: counter, done: make(chan struct{})}
+
+			seg.last = err != nil
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				seg.err = fmt.Errorf("failed to read plaintext: %w", err)
+			}
+
+			select {
+			case ordered <- seg:
+			case <-ctx.Done():
+				return
+			}
+			if n == 0 {
+				close(seg.done)
+			} else {
+				select {
+				case jobs <- seg:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if seg.last {
+				return
+			}
+		}
+	}()
+
+	return &parallelReader{ctx: ctx, header: iv, ordered: ordered}, nil
+}
+
+// parallelReader emits the IV, then the encrypted segments in stream order
+type parallelReader struct {
+	ctx     context.Context
+	header  []byte
+	ordered <-chan *segment
+	cur     *segment
+	off     int
+}
+
+func (p *parallelReader) Read(b []byte) (int, error) {
+	if len(p.header) > 0 {
+		n := copy(b, p.header)
+		p.header = p.header[n:]
+		return n, nil
+	}
+
+	if p.ordered == nil {
+		return 0, io.EOF
+	}
+
+	for {
+		if p.cur == nil {
+			seg, ok := <-p.ordered
+			if !ok {
+				// Closed before the last segment: ctx was cancelled
+				return 0, p.ctx.Err()
+			}
+			select {
+			case <-seg.done:
+			case <-p.ctx.Done():
+				return 0, p.ctx.Err()
+			}
+			p.cur, p.off = seg, 0
+		}
+
+		if p.off < len(p.cur.data) {
+			n := copy(b, p.cur.data[p.off:])
+			p.off += n
+			return n, nil
+		}
+
+		seg := p.cur
+		segmentBuffers.Put(seg.buf)
+		p.cur = nil
+		if seg.err != nil {
+			return 0, seg.err
+		}
+		if seg.last {
+			p.ordered = nil
+			return 0, io.EOF
+		}
+	}
+}
+
+// addCounter returns iv advanced by delta AES blocks (big-endian addition)
+func addCounter(iv []byte, delta uint64) []byte {
+	ctr := make([]byte, len(iv))
+	copy(ctr, iv)
+	for i := len(ctr) - 1; i >= 0 && delta > 0; i-- {
+		sum := uint64(ctr[i]) + delta&0xff
+		ctr[i] = byte(sum)
+		delta = delta>>8 + sum>>8
+	}
+	return ctr
+}
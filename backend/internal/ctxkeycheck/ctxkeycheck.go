@@ -0,0 +1,57 @@
+// Package ctxkeycheck is a vet analyzer that keeps request context keys typed.
+//
+// Middleware stores the authenticated user and friends under the constants.ContextKey
+// keys. A lookup with a plain string, such as ctx.Value("userID"), compiles but never
+// finds them (a string key and a ContextKey key are different keys), so the handler
+// silently sees an anonymous request. The analyzer reports every context.WithValue and
+// Context.Value call whose key has a built-in type.
+package ctxkeycheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "ctxkey",
+	Doc:      "report context keys of built-in types; use the typed keys in internal/constants",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok {
+			return
+		}
+
+		var key ast.Expr
+		switch fn.FullName() {
+		case "context.WithValue":
+			if len(call.Args) == 3 {
+				key = call.Args[1]
+			}
+		case "(context.Context).Value":
+			if len(call.Args) == 1 {
+				key = call.Args[0]
+			}
+		}
+		if key == nil {
+			return
+		}
+
+		if basic, ok := pass.TypesInfo.TypeOf(key).(*types.Basic); ok {
+			pass.Reportf(key.Pos(), "context key of built-in type %s; use a typed key such as constants.UserIDKey", basic.Name())
+		}
+	})
+	return nil, nil
+}
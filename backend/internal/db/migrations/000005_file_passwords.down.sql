@@ -0,0 +1,4 @@
+-- Migration: 000005_file_passwords.down.sql
+-- Description: Rollback per-file download passwords
+
+ALTER TABLE files DROP COLUMN IF EXISTS password_hash;
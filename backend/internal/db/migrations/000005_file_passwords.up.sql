@@ -0,0 +1,6 @@
+-- Migration: 000005_file_passwords.up.sql
+-- Description: Per-file download passwords (argon2id hash, independent of account auth)
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS password_hash TEXT;
+
+COMMENT ON COLUMN files.password_hash IS 'argon2id hash of the per-file download password (NULL = no password)';
@@ -0,0 +1,8 @@
+-- Migration: 000006_expiry_warnings.down.sql
+-- Description: Rollback expiry warning tracking
+
+DELETE FROM announcements WHERE created_by IS NULL;
+ALTER TABLE announcements ALTER COLUMN created_by SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_files_expiry_pending_warning;
+ALTER TABLE files DROP COLUMN IF EXISTS expiry_warned_at;
@@ -0,0 +1,11 @@
+-- Migration: 000006_expiry_warnings.up.sql
+-- Description: Track expiry warnings sent before auto-deletion; allow system-generated announcements
+
+-- When the owner was last warned that the file is about to expire (NULL = not warned yet)
+ALTER TABLE files ADD COLUMN IF NOT EXISTS expiry_warned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_files_expiry_pending_warning ON files(expires_at)
+    WHERE expires_at IS NOT NULL AND expiry_warned_at IS NULL;
+
+-- System announcements (e.g. expiry warnings) have no human author
+ALTER TABLE announcements ALTER COLUMN created_by DROP NOT NULL;
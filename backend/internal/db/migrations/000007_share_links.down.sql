@@ -0,0 +1,6 @@
+-- Migration: 000007_share_links.down.sql
+-- Description: Rollback public share links
+
+DROP INDEX IF EXISTS idx_share_links_created_by;
+DROP INDEX IF EXISTS idx_share_links_file_id;
+DROP TABLE IF EXISTS share_links;
@@ -0,0 +1,35 @@
+-- Migration: 000007_share_links.up.sql
+-- Description: Public share links with abuse controls (request/bandwidth caps, referer/IP allow lists)
+
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+
+    -- Abuse controls (NULL / empty = unlimited / allow all)
+    max_requests INTEGER,
+    max_bytes BIGINT,
+    allowed_referers TEXT[] DEFAULT '{}',
+    allowed_ips TEXT[] DEFAULT '{}',
+
+    -- Usage counters
+    request_count INTEGER DEFAULT 0 NOT NULL,
+    bytes_served BIGINT DEFAULT 0 NOT NULL,
+    last_accessed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Set when the owner revokes the link or a threshold trips
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    disabled_reason VARCHAR(255),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT share_links_max_requests_positive CHECK (max_requests IS NULL OR max_requests > 0),
+    CONSTRAINT share_links_max_bytes_positive CHECK (max_bytes IS NULL OR max_bytes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_file_id ON share_links(file_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_created_by ON share_links(created_by);
+
+COMMENT ON TABLE share_links IS 'Public (unauthenticated) download links for files';
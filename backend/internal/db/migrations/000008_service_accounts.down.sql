@@ -0,0 +1,11 @@
+-- Migration: 000008_service_accounts.down.sql
+-- Description: Rollback service accounts
+
+DROP INDEX IF EXISTS idx_service_account_keys_account;
+DROP TABLE IF EXISTS service_account_keys;
+
+-- Service accounts cannot exist without the role
+DELETE FROM users WHERE role = 'service';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'admin'));
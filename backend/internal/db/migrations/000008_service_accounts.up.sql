@@ -0,0 +1,27 @@
+-- Migration: 000008_service_accounts.up.sql
+-- Description: Service accounts (non-human users) authenticated with scoped, CIDR-restricted API keys
+
+-- Service accounts live in users so files and audit logs keep their foreign keys,
+-- but use a dedicated role and never have a usable password
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'admin', 'service'));
+
+CREATE TABLE IF NOT EXISTS service_account_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_account_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) UNIQUE NOT NULL, -- public lookup part of the key
+    key_hash TEXT NOT NULL,                 -- bcrypt hash of the full key
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    allowed_cidrs TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_account_keys_account ON service_account_keys(service_account_id);
+
+COMMENT ON TABLE service_account_keys IS 'Long-lived API keys for service accounts (users with role = service)';
@@ -0,0 +1,5 @@
+-- Migration: 000009_file_updated_at.down.sql
+-- Description: Rollback file updated_at tracking
+
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+ALTER TABLE files DROP COLUMN IF EXISTS updated_at;
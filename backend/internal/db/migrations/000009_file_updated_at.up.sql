@@ -0,0 +1,14 @@
+-- Migration: 000009_file_updated_at.up.sql
+-- Description: Track file metadata changes for ETag / Last-Modified (conditional GET)
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+UPDATE files SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE files ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE files ALTER COLUMN updated_at SET NOT NULL;
+
+-- Only user-visible changes bump updated_at; download counters and
+-- bookkeeping columns must not invalidate cached downloads
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+CREATE TRIGGER update_files_updated_at
+    BEFORE UPDATE OF file_name, description, mime_type, tags, expires_at, password_hash ON files
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- Migration: 000010_file_folders.down.sql
+-- Description: Rollback virtual folders
+
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+CREATE TRIGGER update_files_updated_at
+    BEFORE UPDATE OF file_name, description, mime_type, tags, expires_at, password_hash ON files
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP INDEX IF EXISTS idx_files_user_folder;
+ALTER TABLE files DROP COLUMN IF EXISTS folder;
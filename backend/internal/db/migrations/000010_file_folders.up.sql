@@ -0,0 +1,12 @@
+-- Migration: 000010_file_folders.up.sql
+-- Description: Virtual folders for organising files (e.g. "/work/reports"); "/" is the root
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS folder TEXT NOT NULL DEFAULT '/';
+
+CREATE INDEX IF NOT EXISTS idx_files_user_folder ON files(user_id, folder);
+
+-- Moving a file is a user-visible change
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+CREATE TRIGGER update_files_updated_at
+    BEFORE UPDATE OF file_name, description, mime_type, tags, expires_at, password_hash, folder ON files
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Migration: 000011_starred_recent.down.sql
+-- Description: Rollback starred files and last-download tracking
+
+DROP INDEX IF EXISTS idx_files_user_recent;
+DROP INDEX IF EXISTS idx_files_user_starred;
+ALTER TABLE files DROP COLUMN IF EXISTS last_downloaded_at;
+ALTER TABLE files DROP COLUMN IF EXISTS starred_at;
@@ -0,0 +1,11 @@
+-- Migration: 000011_starred_recent.up.sql
+-- Description: Starred files and last-download tracking for home screen listings
+
+-- When the owner starred the file (NULL = not starred)
+ALTER TABLE files ADD COLUMN IF NOT EXISTS starred_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS last_downloaded_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_files_user_starred ON files(user_id, starred_at DESC)
+    WHERE starred_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_files_user_recent
+    ON files(user_id, GREATEST(created_at, COALESCE(last_downloaded_at, created_at)) DESC);
@@ -0,0 +1,4 @@
+-- Migration: 000012_file_downloads.down.sql
+-- Description: Rollback per-download records
+
+DROP TABLE IF EXISTS file_downloads;
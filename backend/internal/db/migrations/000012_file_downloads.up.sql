@@ -0,0 +1,15 @@
+-- Migration: 000012_file_downloads.up.sql
+-- Description: One row per completed download, for download statistics
+
+CREATE TABLE IF NOT EXISTS file_downloads (
+    id BIGSERIAL PRIMARY KEY,
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,          -- NULL for anonymous share downloads
+    share_id UUID REFERENCES share_links(id) ON DELETE SET NULL,   -- set when served through a share link
+    ip_address VARCHAR(45),
+    bytes BIGINT NOT NULL,
+    downloaded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_file_downloads_file ON file_downloads(file_id, downloaded_at DESC);
+CREATE INDEX IF NOT EXISTS idx_file_downloads_time ON file_downloads(downloaded_at);
@@ -0,0 +1,9 @@
+-- Migration: 000013_blind_indexes.down.sql
+-- Description: Rollback blind indexes
+
+DROP INDEX IF EXISTS idx_files_tag_index;
+DROP INDEX IF EXISTS idx_files_name_prefix_index;
+DROP INDEX IF EXISTS idx_files_user_name_index;
+ALTER TABLE files DROP COLUMN IF EXISTS tag_index;
+ALTER TABLE files DROP COLUMN IF EXISTS name_prefix_index;
+ALTER TABLE files DROP COLUMN IF EXISTS name_index;
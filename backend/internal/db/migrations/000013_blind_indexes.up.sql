@@ -0,0 +1,12 @@
+-- Migration: 000013_blind_indexes.up.sql
+-- Description: HMAC blind indexes so encrypted file names and tags stay searchable
+
+-- Keyed hashes of the lower-cased file name, of its (and its words') prefixes and of
+-- each tag. NULL while metadata encryption is off.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS name_index TEXT;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS name_prefix_index TEXT[];
+ALTER TABLE files ADD COLUMN IF NOT EXISTS tag_index TEXT[];
+
+CREATE INDEX IF NOT EXISTS idx_files_user_name_index ON files(user_id, name_index);
+CREATE INDEX IF NOT EXISTS idx_files_name_prefix_index ON files USING GIN (name_prefix_index);
+CREATE INDEX IF NOT EXISTS idx_files_tag_index ON files USING GIN (tag_index);
@@ -0,0 +1,14 @@
+-- Migration: 000014_key_rotation.down.sql
+-- Description: Rollback key rotation tracking
+
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TABLE IF EXISTS key_rotations;
+DROP INDEX IF EXISTS idx_files_kek_id;
+ALTER TABLE files DROP COLUMN IF EXISTS kek_id;
@@ -0,0 +1,40 @@
+-- Migration: 000014_key_rotation.up.sql
+-- Description: Track which KEK wraps each file's data key, and key rotation jobs
+
+-- ID of the key-encryption key protecting encryption_key (and encrypted metadata);
+-- NULL = data key stored unwrapped
+ALTER TABLE files ADD COLUMN IF NOT EXISTS kek_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_files_kek_id ON files(kek_id, id);
+
+CREATE TABLE IF NOT EXISTS key_rotations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kek_id TEXT NOT NULL,                      -- KEK the job rotates to
+    reencrypt_objects BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'running',    -- running, completed, failed
+    total_files INTEGER NOT NULL DEFAULT 0,
+    processed_files INTEGER NOT NULL DEFAULT 0,
+    failed_files INTEGER NOT NULL DEFAULT 0,
+    last_file_id UUID,                         -- resume point (files are processed in id order)
+    last_error TEXT,
+    started_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- At most one rotation runs at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_key_rotations_running ON key_rotations(status)
+    WHERE status = 'running';
+
+-- Re-encryption (key rotation, metadata encryption) is not a user-visible change:
+-- such transactions set filelocker.preserve_updated_at so issued links stay valid
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('filelocker.preserve_updated_at', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
@@ -0,0 +1,4 @@
+-- Migration: 000015_wrapped_keys.down.sql
+-- Description: Rollback KMS-wrapped server keys
+
+DROP TABLE IF EXISTS wrapped_keys;
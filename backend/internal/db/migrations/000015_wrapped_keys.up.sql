@@ -0,0 +1,11 @@
+-- Migration: 000015_wrapped_keys.up.sql
+-- Description: Server keys stored only in KMS-wrapped form
+
+-- e.g. the metadata encryption key when the master key lives in an external KMS;
+-- the plaintext key is never written to disk
+CREATE TABLE IF NOT EXISTS wrapped_keys (
+    purpose TEXT PRIMARY KEY,
+    key_id TEXT NOT NULL,                      -- KMS master key that wraps it
+    wrapped_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
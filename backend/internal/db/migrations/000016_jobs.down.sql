@@ -0,0 +1,4 @@
+-- Migration: 000016_jobs.down.sql
+-- Description: Rollback background job queue
+
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,5 @@
+-- Migration: 000017_file_processing.down.sql
+-- Description: Rollback upload processing pipeline tables
+
+DROP TABLE IF EXISTS file_thumbnails;
+DROP TABLE IF EXISTS file_processing;
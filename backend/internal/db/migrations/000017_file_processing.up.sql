@@ -0,0 +1,19 @@
+-- Migration: 000017_file_processing.up.sql
+-- Description: Per-stage status of the upload processing pipeline, and thumbnails
+
+CREATE TABLE IF NOT EXISTS file_processing (
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    stage TEXT NOT NULL,                       -- checksum, virus_scan, thumbnail, webhook
+    status TEXT NOT NULL DEFAULT 'pending',    -- pending, running, succeeded, failed, skipped
+    detail TEXT,                               -- result (e.g. sha256:...) or last error
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (file_id, stage)
+);
+
+-- Encrypted with the file's data key, like the file itself
+CREATE TABLE IF NOT EXISTS file_thumbnails (
+    file_id UUID PRIMARY KEY REFERENCES files(id) ON DELETE CASCADE,
+    data BYTEA NOT NULL,
+    mime_type TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
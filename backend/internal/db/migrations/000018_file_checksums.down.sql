@@ -0,0 +1,4 @@
+-- Migration: 000018_file_checksums.down.sql
+-- Description: Rollback file checksums
+
+ALTER TABLE files DROP COLUMN IF EXISTS sha256;
@@ -0,0 +1,4 @@
+-- Migration: 000018_file_checksums.up.sql
+-- Description: SHA-256 of each file's plaintext, computed on upload
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS sha256 TEXT; -- hex; NULL for files uploaded before this
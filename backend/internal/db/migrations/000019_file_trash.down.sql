@@ -0,0 +1,5 @@
+-- Migration: 000019_file_trash.down.sql
+-- Description: Rollback soft delete (files in the trash become visible again)
+
+DROP INDEX IF EXISTS idx_files_trash;
+ALTER TABLE files DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,7 @@
+-- Migration: 000019_file_trash.up.sql
+-- Description: Soft delete. Deleted files stay in the owner's trash until restored,
+-- deleted permanently, or purged after the configured retention.
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_files_trash ON files(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
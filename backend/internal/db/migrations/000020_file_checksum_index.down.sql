@@ -0,0 +1,4 @@
+-- Migration: 000020_file_checksum_index.down.sql
+-- Description: Rollback checksum lookup index
+
+DROP INDEX IF EXISTS idx_files_user_sha256;
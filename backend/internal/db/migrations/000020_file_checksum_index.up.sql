@@ -0,0 +1,4 @@
+-- Migration: 000020_file_checksum_index.up.sql
+-- Description: Look up a user's files by content checksum (upload precheck)
+
+CREATE INDEX IF NOT EXISTS idx_files_user_sha256 ON files(user_id, sha256) WHERE sha256 IS NOT NULL;
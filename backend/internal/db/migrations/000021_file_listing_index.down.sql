@@ -0,0 +1,4 @@
+-- Migration: 000021_file_listing_index.down.sql
+-- Description: Rollback file listing index
+
+DROP INDEX IF EXISTS idx_files_user_created;
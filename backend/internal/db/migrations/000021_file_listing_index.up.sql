@@ -0,0 +1,5 @@
+-- Migration: 000021_file_listing_index.up.sql
+-- Description: Serve file listings (newest first) straight from an index, without
+-- sorting all of a user's files
+
+CREATE INDEX IF NOT EXISTS idx_files_user_created ON files(user_id, created_at DESC) WHERE deleted_at IS NULL;
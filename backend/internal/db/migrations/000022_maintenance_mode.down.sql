@@ -0,0 +1,4 @@
+-- Migration: 000022_maintenance_mode.down.sql
+-- Description: Rollback maintenance mode settings
+
+DELETE FROM settings WHERE key IN ('maintenance_mode', 'maintenance_message');
@@ -0,0 +1,9 @@
+-- Migration: 000022_maintenance_mode.up.sql
+-- Description: Maintenance mode settings; while enabled the API answers non-admin
+-- requests with 503 and the message below
+
+INSERT INTO settings (key, value, description)
+VALUES
+    ('maintenance_mode', 'false', 'Reject non-admin API requests with 503 (e.g. during migrations)'),
+    ('maintenance_message', 'File Locker is down for maintenance. Please try again shortly.', 'Message shown to users during maintenance')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,5 @@
+-- Migration: 000023_read_only_mode.down.sql
+-- Description: Rollback read-only switch
+
+ALTER TABLE users DROP COLUMN IF EXISTS read_only;
+DELETE FROM settings WHERE key = 'read_only_mode';
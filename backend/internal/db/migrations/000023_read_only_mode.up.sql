@@ -0,0 +1,9 @@
+-- Migration: 000023_read_only_mode.up.sql
+-- Description: Read-only switch, globally (settings) and per user; blocks uploads,
+-- deletes and metadata changes while downloads keep working
+
+INSERT INTO settings (key, value, description)
+VALUES ('read_only_mode', 'false', 'Block uploads, deletes and metadata changes for all users (e.g. during storage migrations)')
+ON CONFLICT (key) DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS read_only BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+-- Migration: 000024_pat_scopes.down.sql
+-- Description: Rollback personal access token scopes
+
+ALTER TABLE personal_access_tokens DROP COLUMN IF EXISTS scopes;
@@ -0,0 +1,11 @@
+-- Migration: 000024_pat_scopes.up.sql
+-- Description: Scopes for personal access tokens. Existing tokens keep the access
+-- they had: all file scopes, plus admin for tokens of admins.
+
+ALTER TABLE personal_access_tokens
+  ADD COLUMN IF NOT EXISTS scopes TEXT[] NOT NULL DEFAULT ARRAY['files:read', 'files:write', 'files:delete'];
+
+UPDATE personal_access_tokens t
+SET scopes = array_append(t.scopes, 'admin')
+FROM users u
+WHERE u.id = t.user_id AND u.role = 'admin' AND NOT ('admin' = ANY(t.scopes));
@@ -0,0 +1,7 @@
+-- Migration: 000025_ip_access.down.sql
+-- Description: Rollback IP access settings
+
+DELETE FROM audit_logs WHERE actor_id IS NULL;
+ALTER TABLE audit_logs ALTER COLUMN actor_id SET NOT NULL;
+
+DELETE FROM settings WHERE key IN ('ip_allowlist', 'ip_denylist', 'blocked_countries');
@@ -0,0 +1,12 @@
+-- Migration: 000025_ip_access.up.sql
+-- Description: IP allow/deny lists and blocked countries (JSON arrays), checked
+-- before authentication. Requests refused by them are audited without an actor.
+
+INSERT INTO settings (key, value, description)
+VALUES
+    ('ip_allowlist', '[]', 'CIDRs allowed to use the API (empty = all)'),
+    ('ip_denylist', '[]', 'CIDRs refused by the API'),
+    ('blocked_countries', '[]', 'ISO 3166 country codes refused by the API')
+ON CONFLICT (key) DO NOTHING;
+
+ALTER TABLE audit_logs ALTER COLUMN actor_id DROP NOT NULL;
@@ -0,0 +1,10 @@
+-- Migration: 000026_user_profile.down.sql
+-- Description: Rollback display names and pending email changes
+
+DROP INDEX IF EXISTS idx_users_email_lower;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verification_expires_at,
+    DROP COLUMN IF EXISTS email_verification_hash,
+    DROP COLUMN IF EXISTS pending_email,
+    DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,12 @@
+-- Migration: 000026_user_profile.up.sql
+-- Description: Display names and email changes. A new email waits in pending_email
+-- until the code mailed to it is confirmed (only its SHA-256 is stored).
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS pending_email VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS email_verification_hash VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS email_verification_expires_at TIMESTAMP;
+
+-- Email uniqueness is checked case-insensitively on change
+CREATE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
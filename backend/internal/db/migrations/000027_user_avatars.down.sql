@@ -0,0 +1,8 @@
+-- Migration: 000027_user_avatars.down.sql
+-- Description: Rollback user avatars (the MinIO objects are left behind)
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS avatar_updated_at,
+    DROP COLUMN IF EXISTS avatar_kek_id,
+    DROP COLUMN IF EXISTS avatar_key,
+    DROP COLUMN IF EXISTS avatar_path;
@@ -0,0 +1,9 @@
+-- Migration: 000027_user_avatars.up.sql
+-- Description: User avatars. The image is stored encrypted in MinIO under the
+-- system prefix; avatar_key is its data key, wrapped like the file keys.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS avatar_path TEXT,
+    ADD COLUMN IF NOT EXISTS avatar_key TEXT,
+    ADD COLUMN IF NOT EXISTS avatar_kek_id TEXT,
+    ADD COLUMN IF NOT EXISTS avatar_updated_at TIMESTAMP;
@@ -0,0 +1,4 @@
+-- Migration: 000028_user_preferences.down.sql
+-- Description: Rollback per-user preferences
+
+DROP TABLE IF EXISTS user_preferences;
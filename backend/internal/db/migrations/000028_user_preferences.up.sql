@@ -0,0 +1,12 @@
+-- Migration: 000028_user_preferences.up.sql
+-- Description: Per-user preferences. Users without a row get the defaults below.
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    locale VARCHAR(35) NOT NULL DEFAULT 'en',
+    default_expiry_hours INTEGER NOT NULL DEFAULT 0,  -- uploads without expire_after; 0 = never expire
+    page_size INTEGER NOT NULL DEFAULT 0,             -- file listings without a limit; 0 = everything
+    notifications JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
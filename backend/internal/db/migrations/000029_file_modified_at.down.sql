@@ -0,0 +1,9 @@
+-- Migration: 000029_file_modified_at.down.sql
+-- Description: Rollback client-set file modification times
+
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+CREATE TRIGGER update_files_updated_at
+    BEFORE UPDATE OF file_name, description, mime_type, tags, expires_at, password_hash, folder ON files
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE files DROP COLUMN IF EXISTS modified_at;
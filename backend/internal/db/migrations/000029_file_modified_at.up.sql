@@ -0,0 +1,11 @@
+-- Migration: 000029_file_modified_at.up.sql
+-- Description: Modification time of a file as set by the client (e.g. the mtime of
+-- the local file, so sync tools can compare it); NULL when none was given
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS modified_at TIMESTAMP WITH TIME ZONE;
+
+-- Setting it is a user-visible change (moves the ETag)
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+CREATE TRIGGER update_files_updated_at
+    BEFORE UPDATE OF file_name, description, mime_type, tags, expires_at, password_hash, folder, modified_at ON files
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Migration: 000030_replication.down.sql
+-- Description: Rollback replication to remote instances
+
+DROP TABLE IF EXISTS replicated_files;
+DROP TABLE IF EXISTS replication_remotes;
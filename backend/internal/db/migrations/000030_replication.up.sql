@@ -0,0 +1,22 @@
+-- Migration: 000030_replication.up.sql
+-- Description: Remote File Locker instances that files are replicated to for off-site
+-- backup, and which files each remote already has
+
+CREATE TABLE IF NOT EXISTS replication_remotes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) UNIQUE NOT NULL,
+    url TEXT NOT NULL,                      -- API root of the remote, e.g. https://backup.example.com/api/v1
+    token TEXT NOT NULL,                    -- personal access token on the remote; encrypted when a KEK is configured
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS replicated_files (
+    remote_id UUID NOT NULL REFERENCES replication_remotes(id) ON DELETE CASCADE,
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    remote_file_id TEXT NOT NULL,
+    replicated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (remote_id, file_id)
+);
+
+COMMENT ON TABLE replicated_files IS 'Files copied to a remote; content is immutable, so each is sent once';
@@ -0,0 +1,11 @@
+-- Migration: 000031_file_quarantine.down.sql
+-- Description: Rollback upload quarantine
+
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+CREATE TRIGGER update_files_updated_at
+    BEFORE UPDATE OF file_name, description, mime_type, tags, expires_at, password_hash, folder, modified_at ON files
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP INDEX IF EXISTS idx_files_quarantined;
+ALTER TABLE files DROP COLUMN IF EXISTS quarantine_reason;
+ALTER TABLE files DROP COLUMN IF EXISTS quarantined_at;
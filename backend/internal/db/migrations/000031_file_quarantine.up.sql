@@ -0,0 +1,14 @@
+-- Migration: 000031_file_quarantine.up.sql
+-- Description: Uploads held back by moderation rules until an admin approves them;
+-- quarantined files cannot be downloaded through share links
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS quarantined_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS quarantine_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_files_quarantined ON files(quarantined_at) WHERE quarantined_at IS NOT NULL;
+
+-- Quarantining and releasing a file is a user-visible change (moves the ETag)
+DROP TRIGGER IF EXISTS update_files_updated_at ON files;
+CREATE TRIGGER update_files_updated_at
+    BEFORE UPDATE OF file_name, description, mime_type, tags, expires_at, password_hash, folder, modified_at, quarantined_at ON files
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Migration: 000032_share_reports.down.sql
+-- Description: Rollback share link abuse reports
+
+DROP TABLE IF EXISTS share_reports;
@@ -0,0 +1,26 @@
+-- Migration: 000032_share_reports.up.sql
+-- Description: Abuse and copyright (DMCA) reports about public share links, filed
+-- anonymously and reviewed by admins
+
+CREATE TABLE IF NOT EXISTS share_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    share_id UUID NOT NULL REFERENCES share_links(id) ON DELETE CASCADE,
+    category VARCHAR(32) NOT NULL,
+    message TEXT,
+    reporter_email VARCHAR(255),
+    ip_address VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    -- Review: 'dismissed', or 'actioned' when the share was disabled
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolution_note TEXT,
+
+    CONSTRAINT share_reports_status_check CHECK (status IN ('open', 'dismissed', 'actioned'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_reports_share_id ON share_reports(share_id);
+CREATE INDEX IF NOT EXISTS idx_share_reports_status ON share_reports(status, created_at);
+
+COMMENT ON TABLE share_reports IS 'Abuse reports about public share links';
@@ -0,0 +1,5 @@
+-- Migration: 000033_scheduled_reports.down.sql
+-- Description: Drop the tables of the scheduled usage reports
+
+DROP TABLE IF EXISTS report_runs;
+DROP TABLE IF EXISTS cleanup_runs;
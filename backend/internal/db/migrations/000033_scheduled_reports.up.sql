@@ -0,0 +1,27 @@
+-- Migration: 000033_scheduled_reports.up.sql
+-- Description: Weekly/monthly usage reports for admins: what the auto-delete
+-- worker removed, and which report periods were sent
+
+-- One row per cleanup run that deleted something
+CREATE TABLE IF NOT EXISTS cleanup_runs (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(16) NOT NULL,  -- 'expired' or 'trash'
+    files_deleted INTEGER NOT NULL,
+    bytes_freed BIGINT NOT NULL,
+    ran_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cleanup_runs_ran_at ON cleanup_runs(ran_at);
+
+-- One row per scheduled report, claimed before it is sent so replicas send it once
+CREATE TABLE IF NOT EXISTS report_runs (
+    period VARCHAR(16) NOT NULL,  -- 'weekly' or 'monthly'
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    storage_bytes BIGINT,         -- stored bytes when sent; the next report's growth is measured from it
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (period, period_start)
+);
+
+COMMENT ON TABLE report_runs IS 'Scheduled usage reports, one per period';
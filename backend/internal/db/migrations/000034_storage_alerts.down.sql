@@ -0,0 +1,4 @@
+-- Migration: 000034_storage_alerts.down.sql
+-- Description: Drop the raised storage usage alerts
+
+DROP TABLE IF EXISTS storage_alerts;
@@ -0,0 +1,15 @@
+-- Migration: 000034_storage_alerts.up.sql
+-- Description: Storage usage alerts that are currently raised, so each crossing of
+-- a threshold is reported once
+
+CREATE TABLE IF NOT EXISTS storage_alerts (
+    kind VARCHAR(16) NOT NULL,            -- 'bucket', 'database' or 'user_quota'
+    subject VARCHAR(64) NOT NULL DEFAULT '', -- user ID for 'user_quota', '' otherwise
+    used_bytes BIGINT NOT NULL,
+    limit_bytes BIGINT NOT NULL,          -- the threshold that was crossed
+    raised_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, subject)
+);
+
+COMMENT ON TABLE storage_alerts IS 'Raised storage usage alerts; a row is removed once usage drops below its threshold';
@@ -0,0 +1,5 @@
+-- Migration: 000035_storage_quota_grace.down.sql
+-- Description: Rollback soft storage quota
+
+ALTER TABLE users DROP COLUMN IF EXISTS over_quota_since;
+DELETE FROM settings WHERE key = 'storage_quota_grace_days';
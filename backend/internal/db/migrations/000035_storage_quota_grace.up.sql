@@ -0,0 +1,9 @@
+-- Migration: 000035_storage_quota_grace.up.sql
+-- Description: Soft storage quota. Users over storage_quota_per_user_bytes get a
+-- grace period before their uploads are refused; it starts at over_quota_since.
+
+INSERT INTO settings (key, value, description)
+VALUES ('storage_quota_grace_days', 'off', 'Days a user may stay over the storage quota before uploads are refused (off = never refused, 0 = at once)')
+ON CONFLICT (key) DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS over_quota_since TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,5 @@
+-- Migration: 000036_usage_records.down.sql
+-- Description: Drop the monthly usage records
+
+DROP TABLE IF EXISTS usage_deliveries;
+DROP TABLE IF EXISTS usage_records;
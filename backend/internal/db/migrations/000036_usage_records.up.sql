@@ -0,0 +1,29 @@
+-- Migration: 000036_usage_records.up.sql
+-- Description: Monthly usage of each user for chargeback (storage-days and download
+-- bandwidth), and which finished months were posted to the billing webhook
+
+-- One row per user and month, added to once a day
+CREATE TABLE IF NOT EXISTS usage_records (
+    month DATE NOT NULL,                -- first day of the month, UTC
+    user_id UUID NOT NULL,              -- no foreign key: records outlive the account
+    username VARCHAR(255) NOT NULL,     -- as of the last day recorded
+    email VARCHAR(255) NOT NULL,
+    storage_byte_days BIGINT NOT NULL,  -- sum of the daily storage samples
+    peak_storage_bytes BIGINT NOT NULL,
+    download_bytes BIGINT NOT NULL,     -- served from the user's files, share links included
+    downloads INTEGER NOT NULL,
+    last_day DATE NOT NULL,             -- the last day added; each day is added once
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (month, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_records_last_day ON usage_records(last_day);
+
+-- One row per finished month, claimed before its records are posted so replicas post it once
+CREATE TABLE IF NOT EXISTS usage_deliveries (
+    month DATE PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE usage_records IS 'Monthly usage per user for chargeback, see GET /admin/usage';
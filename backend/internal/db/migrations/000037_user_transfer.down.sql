@@ -0,0 +1,6 @@
+-- Migration: 000037_user_transfer.down.sql
+-- Description: Drop the transfer accounting
+
+DELETE FROM settings WHERE key = 'transfer_cap_per_user_bytes';
+
+DROP TABLE IF EXISTS user_transfer;
@@ -0,0 +1,18 @@
+-- Migration: 000037_user_transfer.up.sql
+-- Description: Bytes served from each user's files per day (downloads, streams and
+-- exports), and an optional monthly transfer cap per user
+
+CREATE TABLE IF NOT EXISTS user_transfer (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,  -- owner of the files served
+    day DATE NOT NULL,                                            -- UTC
+    download_bytes BIGINT NOT NULL DEFAULT 0,  -- downloads, share links and signed URLs included
+    stream_bytes BIGINT NOT NULL DEFAULT 0,
+    export_bytes BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_transfer_day ON user_transfer(day);
+
+INSERT INTO settings (key, value, description)
+VALUES ('transfer_cap_per_user_bytes', '0', 'Bytes served from a user''s files per calendar month (UTC) before downloads are refused (0 = no cap)')
+ON CONFLICT (key) DO NOTHING;
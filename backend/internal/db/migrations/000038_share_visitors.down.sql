@@ -0,0 +1,4 @@
+-- Migration: 000038_share_visitors.down.sql
+-- Description: Drop the share link statistics
+
+DROP TABLE IF EXISTS share_visitors;
@@ -0,0 +1,14 @@
+-- Migration: 000038_share_visitors.up.sql
+-- Description: Views and downloads of each share link per visitor, identified by a
+-- keyed hash of their IP address, for the owner's share statistics
+
+CREATE TABLE IF NOT EXISTS share_visitors (
+    share_id UUID NOT NULL REFERENCES share_links(id) ON DELETE CASCADE,
+    ip_hash VARCHAR(64) NOT NULL,  -- HMAC of the share ID and address; never the address itself
+    views INTEGER NOT NULL DEFAULT 0,      -- every request with the link's token, refused ones included
+    downloads INTEGER NOT NULL DEFAULT 0,  -- requests that were served the file
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_download_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (share_id, ip_hash)
+);
@@ -0,0 +1,5 @@
+-- Migration: 000039_pat_lookup_prefix.down.sql
+-- Description: Drop the lookup prefix of personal access tokens (tokens expired by the
+-- up migration stay expired)
+
+ALTER TABLE personal_access_tokens DROP COLUMN IF EXISTS token_prefix;
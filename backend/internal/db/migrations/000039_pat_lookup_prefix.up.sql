@@ -0,0 +1,11 @@
+-- Migration: 000039_pat_lookup_prefix.up.sql
+-- Description: Personal access tokens carry a public lookup prefix (fl_<prefix>_<secret>),
+-- so a presented token is compared against one row instead of every token's bcrypt hash.
+-- Tokens from before cannot be looked up; they are expired and have to be created again.
+
+ALTER TABLE personal_access_tokens
+  ADD COLUMN IF NOT EXISTS token_prefix VARCHAR(16) UNIQUE;
+
+UPDATE personal_access_tokens
+SET expires_at = NOW()
+WHERE token_prefix IS NULL AND (expires_at IS NULL OR expires_at > NOW());
@@ -14,7 +14,7 @@ func CreateDefaultAdmin(dbURL string, username, email, password string, logger *
 	logger.Info("Checking default admin user")
 
 	// Open database connection
-	db, err := sql.Open("postgres", dbURL)
+	db, err := sql.Open("pgx", dbURL)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
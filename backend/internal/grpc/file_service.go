@@ -2,23 +2,31 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/sachinthra/file-locker/backend/internal/storage"
 	pb "github.com/sachinthra/file-locker/backend/pkg/proto"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 type FileServiceServer struct {
 	pb.UnimplementedFileServiceServer
-	pgStore *storage.PostgresStore
+	pgStore      *storage.PostgresStore
+	redisCache   *storage.RedisCache
+	minioStorage *storage.MinIOStorage
 }
 
-func NewFileServiceServer(pgStore *storage.PostgresStore) *FileServiceServer {
+func NewFileServiceServer(pgStore *storage.PostgresStore, redisCache *storage.RedisCache, minioStorage *storage.MinIOStorage) *FileServiceServer {
 	return &FileServiceServer{
-		pgStore: pgStore,
+		pgStore:      pgStore,
+		redisCache:   redisCache,
+		minioStorage: minioStorage,
 	}
 }
 
@@ -68,22 +76,39 @@ func (s *FileServiceServer) ListFiles(ctx context.Context, req *pb.ListRequest)
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
-	// Get user's files from PostgreSQL
-	metadataList, err := s.pgStore.ListUserFiles(ctx, req.UserId)
+	// Pagination: page_token (keyset) takes precedence over page (offset)
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 100 // Default limit, unless the user chose a page size
+		if prefs, err := s.pgStore.GetUserPreferences(ctx, req.UserId); err == nil && prefs.PageSize > 0 {
+			limit = prefs.PageSize
+		}
+	}
+	var after *storage.Cursor
+	offset := 0
+	if req.PageToken != "" {
+		cursor, err := storage.ParseCursor(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		after = cursor
+	} else if req.Page > 1 {
+		offset = (int(req.Page) - 1) * limit
+	}
+
+	// Get the page of the user's unexpired files from PostgreSQL
+	metadataList, next, err := s.pgStore.ListActiveFilesPage(ctx, req.UserId, after, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to retrieve files")
+	}
+	total, err := s.pgStore.CountActiveFiles(ctx, req.UserId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to retrieve files")
 	}
 
 	// Convert to protobuf messages
-	files := make([]*pb.FileMetadata, 0)
-	now := time.Now()
-
+	files := make([]*pb.FileMetadata, 0, len(metadataList))
 	for _, metadata := range metadataList {
-		// Filter out expired files
-		if metadata.ExpiresAt != nil && metadata.ExpiresAt.Before(now) {
-			continue
-		}
-
 		pbMetadata := &pb.FileMetadata{
 			FileId:        metadata.FileID,
 			UserId:        metadata.UserID,
@@ -103,39 +128,14 @@ func (s *FileServiceServer) ListFiles(ctx context.Context, req *pb.ListRequest)
 		files = append(files, pbMetadata)
 	}
 
-	// Apply pagination if requested
-	page := int(req.Page)
-	limit := int(req.Limit)
-
-	if limit == 0 {
-		limit = 100 // Default limit
-	}
-	if page == 0 {
-		page = 1 // Default to first page
-	}
-
-	start := (page - 1) * limit
-	end := start + limit
-
-	total := len(files)
-
-	if start >= total {
-		return &pb.FileList{
-			Files: []*pb.FileMetadata{},
-			Total: int32(total),
-		}, nil
+	list := &pb.FileList{
+		Files: files,
+		Total: int32(total),
 	}
-
-	if end > total {
-		end = total
+	if next != nil {
+		list.NextPageToken = next.Encode()
 	}
-
-	pagedFiles := files[start:end]
-
-	return &pb.FileList{
-		Files: pagedFiles,
-		Total: int32(total),
-	}, nil
+	return list, nil
 }
 
 func (s *FileServiceServer) UpdateTags(ctx context.Context, req *pb.UpdateTagsRequest) (*pb.FileMetadata, error) {
@@ -158,13 +158,38 @@ func (s *FileServiceServer) UpdateTags(ctx context.Context, req *pb.UpdateTagsRe
 		return nil, status.Error(codes.PermissionDenied, "access denied")
 	}
 
-	// Update tags
-	metadata.Tags = req.Tags
+	// Metadata changes are blocked while the user's files are read-only
+	if err := s.pgStore.CheckWritable(ctx, req.UserId); errors.Is(err, storage.ErrReadOnly) {
+		return nil, status.Error(codes.FailedPrecondition, "files are read-only")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check read-only mode")
+	}
 
-	// Save updated metadata
-	if err := s.pgStore.SaveFileMetadata(ctx, metadata); err != nil {
+	// Update tags. An "if-match" metadata entry (the file's ETag, as returned by the
+	// HTTP API or in the "etag" header of an earlier call) makes the update
+	// conditional: it is aborted if the file changed since.
+	ifMatch := ""
+	if md, ok := grpcmetadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("if-match"); len(values) > 0 {
+			ifMatch = values[0]
+		}
+	}
+	updated, err := s.pgStore.ModifyFile(ctx, req.FileId, func(m *storage.FileMetadata) error {
+		if ifMatch != "" && ifMatch != "*" && ifMatch != m.ETag() {
+			return storage.ErrPreconditionFailed
+		}
+		m.Tags = req.Tags
+		return nil
+	})
+	if errors.Is(err, storage.ErrPreconditionFailed) {
+		return nil, status.Error(codes.Aborted, "file was modified since it was read")
+	}
+	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to update tags")
 	}
+	metadata = updated
+	_ = s.redisCache.InvalidateFileMetadata(ctx, metadata.FileID)
+	_ = grpc.SetHeader(ctx, grpcmetadata.Pairs("etag", metadata.ETag()))
 
 	// Return updated metadata
 	pbMetadata := &pb.FileMetadata{
@@ -206,6 +231,13 @@ func (s *FileServiceServer) SetExpiration(ctx context.Context, req *pb.Expiratio
 		return nil, status.Error(codes.PermissionDenied, "access denied")
 	}
 
+	// Metadata changes are blocked while the user's files are read-only
+	if err := s.pgStore.CheckWritable(ctx, req.UserId); errors.Is(err, storage.ErrReadOnly) {
+		return nil, status.Error(codes.FailedPrecondition, "files are read-only")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check read-only mode")
+	}
+
 	// Parse expiration time
 	if req.ExpiresAt != "" {
 		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
@@ -217,10 +249,14 @@ func (s *FileServiceServer) SetExpiration(ctx context.Context, req *pb.Expiratio
 		metadata.ExpiresAt = nil // Remove expiration
 	}
 
-	// Save updated metadata to PostgreSQL
-	if err := s.pgStore.SaveFileMetadata(ctx, metadata); err != nil {
+	// Save updated expiry to PostgreSQL
+	if err := s.pgStore.UpdateFileExpiry(ctx, metadata.FileID, metadata.ExpiresAt); err != nil {
 		return nil, status.Error(codes.Internal, "failed to update expiration")
 	}
+	_ = s.redisCache.InvalidateFileMetadata(ctx, metadata.FileID)
+	if err := s.minioStorage.LabelObject(ctx, metadata.MinIOPath, metadata.ObjectLabels()); err != nil {
+		log.Printf("[WARN] Failed to tag the object of file %s: %v", metadata.FileID, err)
+	}
 
 	// Return updated metadata
 	pbMetadata := &pb.FileMetadata{
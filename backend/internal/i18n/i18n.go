@@ -0,0 +1,165 @@
+// Package i18n translates the messages the server writes for people: API error
+// messages, emails and announcements. Messages are looked up by key in a catalog
+// per language; English is built in (en.json, which lists every key) and further
+// languages are JSON files in the configured directory, named after their
+// language tag:
+//
+//	{"error.FILE_EXPIRED": "Die Datei ist abgelaufen", ...}
+//
+// {name} in a message is replaced by the value passed for name. Keys missing from
+// a translation fall back to English.
+package i18n
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+// English is the built-in language, used when nothing better matches
+const English = "en"
+
+//go:embed en.json
+var englishCatalog []byte
+
+// Catalog holds the messages of every loaded language
+type Catalog struct {
+	messages map[string]map[string]string // language -> key -> message
+	langs    []string                     // langs[i] is the language of matcher entry i
+	matcher  language.Matcher
+}
+
+// Load reads the built-in English messages and the <language>.json files in dir
+// ("" for English only). A file named en.json replaces built-in messages.
+func Load(dir string) (*Catalog, error) {
+	english := make(map[string]string)
+	if err := json.Unmarshal(englishCatalog, &english); err != nil {
+		return nil, fmt.Errorf("invalid built-in catalog: %w", err)
+	}
+	messages := map[string]map[string]string{English: english}
+
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list translations: %w", err)
+		}
+		for _, file := range files {
+			tag, err := language.Parse(strings.TrimSuffix(filepath.Base(file), ".json"))
+			if err != nil {
+				return nil, fmt.Errorf("translation %s is not named after a language tag: %w", file, err)
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read translation: %w", err)
+			}
+			translated := make(map[string]string)
+			if err := json.Unmarshal(data, &translated); err != nil {
+				return nil, fmt.Errorf("invalid translation %s: %w", file, err)
+			}
+
+			lang := tag.String()
+			if messages[lang] == nil {
+				messages[lang] = make(map[string]string, len(translated))
+			}
+			for key, message := range translated {
+				messages[lang][key] = message
+			}
+		}
+	}
+
+	// English first: it is what the matcher falls back to
+	c := &Catalog{messages: messages, langs: []string{English}}
+	tags := []language.Tag{language.English}
+	for lang := range messages {
+		if lang != English {
+			c.langs = append(c.langs, lang)
+			tags = append(tags, language.Make(lang))
+		}
+	}
+	c.matcher = language.NewMatcher(tags)
+	return c, nil
+}
+
+// Languages lists the loaded languages, English first
+func (c *Catalog) Languages() []string {
+	return c.langs
+}
+
+// Negotiate picks the loaded language that best matches an Accept-Language header
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return English
+	}
+	return c.match(tags...)
+}
+
+// ForLocale picks the loaded language that best matches a user's locale preference
+func (c *Catalog) ForLocale(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return English
+	}
+	return c.match(tag)
+}
+
+func (c *Catalog) match(tags ...language.Tag) string {
+	_, index, confidence := c.matcher.Match(tags...)
+	if confidence == language.No {
+		return English
+	}
+	return c.langs[index]
+}
+
+// Lookup returns the message for key in lang itself, without falling back to English
+func (c *Catalog) Lookup(lang, key string) (string, bool) {
+	message, ok := c.messages[lang][key]
+	return message, ok
+}
+
+// Text returns the message for key in lang (English when it is not translated),
+// with {name} placeholders replaced from name/value pairs. Unknown keys return
+// the key itself, so a typo shows up instead of an empty message.
+func (c *Catalog) Text(lang, key string, pairs ...string) string {
+	message, ok := c.Lookup(lang, key)
+	if !ok {
+		if message, ok = c.Lookup(English, key); !ok {
+			return key
+		}
+	}
+	if len(pairs) == 0 {
+		return message
+	}
+
+	oldnew := make([]string, 0, len(pairs))
+	for i := 0; i+1 < len(pairs); i += 2 {
+		oldnew = append(oldnew, "{"+pairs[i]+"}", pairs[i+1])
+	}
+	return strings.NewReplacer(oldnew...).Replace(message)
+}
+
+var current atomic.Pointer[Catalog]
+
+func init() {
+	c, err := Load("")
+	if err != nil {
+		panic(err)
+	}
+	current.Store(c)
+}
+
+// Default is the catalog used by the server; English only until SetDefault
+func Default() *Catalog {
+	return current.Load()
+}
+
+// SetDefault makes c the catalog used by the server
+func SetDefault(c *Catalog) {
+	current.Store(c)
+}
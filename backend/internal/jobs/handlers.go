@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/internal/worker"
+)
+
+// Job types
+const (
+	// TypeFileReencrypt re-encrypts one file's object with a new data key
+	TypeFileReencrypt = "file.reencrypt"
+	// TypeFileRelocate moves one file's object to its owner's location after the
+	// file changed hands
+	TypeFileRelocate = "file.relocate"
+)
+
+// FilePayload identifies the file a job works on
+type FilePayload struct {
+	FileID string `json:"file_id"`
+}
+
+func decodeFilePayload(job *storage.Job) (string, error) {
+	var p FilePayload
+	if err := DecodePayload(job, &p); err != nil {
+		return "", err
+	}
+	if p.FileID == "" {
+		return "", Permanent(fmt.Errorf("invalid payload: %s", job.Payload))
+	}
+	return p.FileID, nil
+}
+
+// ReencryptFileHandler handles TypeFileReencrypt
+func ReencryptFileHandler(pgStore *storage.PostgresStore, redisCache *storage.RedisCache, rotator *worker.KeyRotator) Handler {
+	return func(ctx context.Context, job *storage.Job) error {
+		fileID, err := decodeFilePayload(job)
+		if err != nil {
+			return err
+		}
+
+		metadata, err := pgStore.GetFileMetadata(ctx, fileID)
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return Permanent(fmt.Errorf("file %s no longer exists", fileID))
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := rotator.ReencryptFile(ctx, metadata); err != nil {
+			return err
+		}
+		_ = redisCache.InvalidateFileMetadata(ctx, fileID)
+		return nil
+	}
+}
+
+// RelocateFileHandler handles TypeFileRelocate. The object is copied to the
+// owner's location, the row switched over, and only then the old object removed,
+// so the file stays readable throughout.
+func RelocateFileHandler(pgStore *storage.PostgresStore, redisCache *storage.RedisCache, minioStorage *storage.MinIOStorage) Handler {
+	return func(ctx context.Context, job *storage.Job) error {
+		fileID, err := decodeFilePayload(job)
+		if err != nil {
+			return err
+		}
+
+		metadata, err := pgStore.GetFileMetadata(ctx, fileID)
+		if errors.Is(err, storage.ErrFileNotFound) {
+			metadata, err = pgStore.GetTrashedFile(ctx, fileID)
+		}
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return Permanent(fmt.Errorf("file %s no longer exists", fileID))
+		}
+		if err != nil {
+			return err
+		}
+
+		newPath, err := minioStorage.ObjectPath(ctx, metadata.UserID, fileID)
+		if err != nil {
+			return err
+		}
+		if newPath == metadata.MinIOPath {
+			return nil
+		}
+		if err := minioStorage.CopyFile(ctx, metadata.MinIOPath, newPath, metadata.ObjectLabels()); err != nil {
+			return fmt.Errorf("failed to copy object: %w", err)
+		}
+		switched, err := pgStore.RelocateFile(ctx, fileID, metadata.MinIOPath, newPath)
+		if err != nil || !switched {
+			// Changed meanwhile (e.g. re-encrypted); the copy is not used
+			_ = minioStorage.DeleteFile(context.WithoutCancel(ctx), newPath)
+			return err
+		}
+		_ = redisCache.InvalidateFileMetadata(ctx, fileID)
+		if err := minioStorage.DeleteFile(ctx, metadata.MinIOPath); err != nil {
+			return Permanent(fmt.Errorf("relocated, but failed to delete the old object %s: %w", metadata.MinIOPath, err))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// TypeObjectReplicate mirrors one object to the standby storage
+// (storage.minio.replica)
+const TypeObjectReplicate = "object.replicate"
+
+// ObjectPayload identifies the object a job works on by its stored path
+type ObjectPayload struct {
+	Path string `json:"path"`
+}
+
+// ReplicateObjectHandler handles TypeObjectReplicate. The job does not say what
+// happened to the object: the standby is made to match the primary as it is now,
+// so jobs may run in any order and a write followed by a delete ends up deleted.
+func ReplicateObjectHandler(primary, replica *storage.MinIOStorage) Handler {
+	return func(ctx context.Context, job *storage.Job) error {
+		var p ObjectPayload
+		if err := DecodePayload(job, &p); err != nil {
+			return err
+		}
+		if p.Path == "" {
+			return Permanent(fmt.Errorf("invalid payload: %s", job.Payload))
+		}
+
+		return primary.ReplicateObject(ctx, replica, p.Path)
+	}
+}
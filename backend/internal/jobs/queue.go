@@ -0,0 +1,262 @@
+// Package jobs runs asynchronous work (re-encryption, upload processing, ...) from
+// a queue in Postgres. Any number of replicas can run workers: jobs are leased with
+// row locks, failed attempts are retried with backoff, and jobs that run out of
+// attempts stay in the queue as dead letters until an admin retries them.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/internal/worker"
+)
+
+const (
+	// defaultMaxAttempts is how often a job is tried before it becomes a dead letter
+	defaultMaxAttempts = 5
+
+	// lease is how long a claimed job is reserved for its worker; it is renewed
+	// while the handler runs and picked up by another worker once it expires
+	lease = 2 * time.Minute
+
+	maxBackoff = time.Hour
+)
+
+// Handler processes one job. Returning an error schedules a retry unless the job
+// is out of attempts or the error is Permanent.
+type Handler func(ctx context.Context, job *storage.Job) error
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error that retrying cannot fix (e.g. the file is gone);
+// the job becomes a dead letter right away
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+// IsPermanent reports whether err was marked with Permanent
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+// DecodePayload unmarshals a job's payload into v; a malformed payload is permanent
+func DecodePayload(job *storage.Job, v interface{}) error {
+	if err := json.Unmarshal(job.Payload, v); err != nil {
+		return Permanent(fmt.Errorf("invalid payload: %w", err))
+	}
+	return nil
+}
+
+// Queue enqueues jobs and runs the registered handlers
+type Queue struct {
+	pgStore      *storage.PostgresStore
+	workers      int
+	pollInterval time.Duration
+	retention    time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	wake     chan struct{}
+}
+
+// NewQueue creates a queue run by the given number of workers, which look for due
+// jobs every pollInterval (and right after a local Enqueue). Succeeded jobs are
+// deleted after retention.
+func NewQueue(pgStore *storage.PostgresStore, workers int, pollInterval, retention time.Duration) *Queue {
+	return &Queue{
+		pgStore:      pgStore,
+		workers:      workers,
+		pollInterval: pollInterval,
+		retention:    retention,
+		handlers:     make(map[string]Handler),
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Register sets the handler for a job type. Call before Start.
+func (q *Queue) Register(jobType string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = h
+}
+
+// Enqueue adds a job whose payload is marshalled to JSON
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) (*storage.Job, error) {
+	return q.EnqueueAt(ctx, jobType, payload, time.Now())
+}
+
+// EnqueueAt adds a job that runs no earlier than runAt
+func (q *Queue) EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) (*storage.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	job, err := q.pgStore.EnqueueJob(ctx, jobType, data, defaultMaxAttempts, runAt)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Start runs the workers until ctx is cancelled. Jobs interrupted by shutdown go
+// back to the queue (or are picked up once their lease expires after a crash).
+func (q *Queue) Start(ctx context.Context) {
+	q.mu.RLock()
+	types := make([]string, 0, len(q.handlers))
+	for t := range q.handlers {
+		types = append(types, t)
+	}
+	q.mu.RUnlock()
+
+	if len(types) == 0 || q.workers <= 0 {
+		return
+	}
+
+	for i := 0; i < q.workers; i++ {
+		go q.work(ctx, types)
+	}
+	go q.prune(ctx)
+}
+
+func (q *Queue) work(ctx context.Context, types []string) {
+	for {
+		job, err := q.pgStore.ClaimJob(ctx, worker.InstanceID(), types, lease)
+		if err == nil {
+			q.run(ctx, job)
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) && ctx.Err() == nil {
+			log.Printf("[jobs] Failed to claim job: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-time.After(q.pollInterval):
+		}
+	}
+}
+
+func (q *Queue) run(ctx context.Context, job *storage.Job) {
+	q.mu.RLock()
+	handler := q.handlers[job.Type]
+	q.mu.RUnlock()
+
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		// Its worker died on the last attempt
+		err = Permanent(errors.New("out of attempts"))
+	} else {
+		err = q.call(ctx, handler, job)
+	}
+
+	// Record the outcome even when shutting down mid-job
+	recordCtx := context.WithoutCancel(ctx)
+	if err == nil {
+		if err := q.pgStore.CompleteJob(recordCtx, job.ID, worker.InstanceID()); err != nil {
+			log.Printf("[jobs] %v", err)
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	switch {
+	case ctx.Err() != nil:
+		// Interrupted by shutdown rather than failed; the next replica up takes it over
+		t := time.Now()
+		retryAt = &t
+	case !IsPermanent(err) && job.Attempts < job.MaxAttempts:
+		t := time.Now().Add(backoff(job.Attempts))
+		retryAt = &t
+	}
+	if retryAt == nil {
+		log.Printf("[jobs] Job %s (%s) failed for good after %d attempts: %v", job.ID, job.Type, job.Attempts, err)
+	} else {
+		log.Printf("[jobs] Job %s (%s) failed (attempt %d/%d), retrying at %s: %v",
+			job.ID, job.Type, job.Attempts, job.MaxAttempts, retryAt.Format(time.RFC3339), err)
+	}
+	if err := q.pgStore.FailJob(recordCtx, job.ID, worker.InstanceID(), err.Error(), retryAt); err != nil {
+		log.Printf("[jobs] %v", err)
+	}
+}
+
+// call runs the handler, renewing the job's lease while it works
+func (q *Queue) call(ctx context.Context, handler Handler, job *storage.Job) (err error) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				if err := q.pgStore.ExtendJobLease(jobCtx, job.ID, worker.InstanceID(), lease); err != nil && jobCtx.Err() == nil {
+					log.Printf("[jobs] Failed to extend lease of job %s: %v", job.ID, err)
+				}
+			}
+		}
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[jobs] Job %s (%s) panicked: %v\n%s", job.ID, job.Type, r, debug.Stack())
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return handler(jobCtx, job)
+}
+
+// backoff returns the delay before the next attempt: 30s, 1m, 2m, ... up to maxBackoff
+func backoff(attempts int) time.Duration {
+	d := 30 * time.Second
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+// prune deletes old succeeded jobs once an hour
+func (q *Queue) prune(ctx context.Context) {
+	if q.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		if n, err := q.pgStore.DeleteFinishedJobs(ctx, time.Now().Add(-q.retention)); err != nil {
+			log.Printf("[jobs] %v", err)
+		} else if n > 0 {
+			log.Printf("[jobs] Deleted %d finished jobs", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+	"github.com/sachinthra/file-locker/backend/pkg/client"
+)
+
+// TypeFileReplicate copies one file to a replication remote
+const TypeFileReplicate = "file.replicate"
+
+// ReplicatePayload names the file to copy and the remote to copy it to
+type ReplicatePayload struct {
+	RemoteID string `json:"remote_id"`
+	FileID   string `json:"file_id"`
+}
+
+// ReplicateFileHandler handles TypeFileReplicate. The file is uploaded through the
+// remote's API with the remote's token, into /<owner's username>/<folder> so the
+// files of several users do not mix. Content the remote account already has is
+// not sent again, and the upload's idempotency key makes retries safe.
+func ReplicateFileHandler(pgStore *storage.PostgresStore, minioStorage *storage.MinIOStorage) Handler {
+	return func(ctx context.Context, job *storage.Job) error {
+		var p ReplicatePayload
+		if err := DecodePayload(job, &p); err != nil {
+			return err
+		}
+		if p.RemoteID == "" || p.FileID == "" {
+			return Permanent(fmt.Errorf("invalid payload: %s", job.Payload))
+		}
+
+		done, err := pgStore.IsReplicated(ctx, p.RemoteID, p.FileID)
+		if err != nil || done {
+			return err
+		}
+		remote, err := pgStore.GetReplicationRemote(ctx, p.RemoteID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Permanent(fmt.Errorf("remote %s no longer exists", p.RemoteID))
+		}
+		if err != nil {
+			return err
+		}
+		metadata, err := pgStore.GetFileMetadata(ctx, p.FileID)
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return Permanent(fmt.Errorf("file %s no longer exists", p.FileID))
+		}
+		if err != nil {
+			return err
+		}
+		owner, err := pgStore.GetUserByID(ctx, metadata.UserID)
+		if err != nil {
+			return err
+		}
+
+		api := client.New(remote.URL, remote.Token)
+		folder := path.Join("/", owner.Username, metadata.Folder)
+		never := 0
+
+		var result *client.UploadResult
+		if metadata.SHA256 != "" {
+			result, err = api.Precheck(ctx, client.PrecheckRequest{
+				SHA256:      metadata.SHA256,
+				Size:        metadata.Size,
+				FileName:    metadata.FileName,
+				Description: metadata.Description,
+				Tags:        metadata.Tags,
+				ExpireAfter: &never,
+				Folder:      folder,
+				ModifiedAt:  metadata.ModifiedAt,
+			})
+			if err != nil && !client.IsStatus(err, http.StatusNotFound) { // 404: remote without prechecks
+				return remoteError(remote, err)
+			}
+		}
+
+		if result == nil {
+			key, err := pgStore.DataKey(ctx, metadata)
+			if err != nil {
+				return err
+			}
+			encrypted, err := minioStorage.GetFile(ctx, metadata.MinIOPath)
+			if err != nil {
+				return fmt.Errorf("failed to read object: %w", err)
+			}
+			defer func() { _ = encrypted.Close() }()
+			plain, err := crypto.DecryptStream(encrypted, key)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt file: %w", err)
+			}
+
+			result, err = api.Upload(ctx, client.UploadRequest{
+				FileName:       metadata.FileName,
+				Body:           plain,
+				Size:           metadata.Size,
+				Description:    metadata.Description,
+				Tags:           metadata.Tags,
+				ExpireAfter:    &never,
+				Folder:         folder,
+				ModifiedAt:     metadata.ModifiedAt,
+				IdempotencyKey: "replicate-" + remote.ID + "-" + metadata.FileID,
+			})
+			if err != nil {
+				return remoteError(remote, err)
+			}
+		}
+
+		return pgStore.MarkReplicated(ctx, remote.ID, metadata.FileID, result.FileID)
+	}
+}
+
+// remoteError wraps an error of a remote's API; rejected requests (e.g. a revoked
+// token or a full remote) are not retried
+func remoteError(remote *storage.ReplicationRemote, err error) error {
+	err = fmt.Errorf("remote %s: %w", remote.Name, err)
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) && !client.Retryable(err) && apiErr.Status < 500 {
+		return Permanent(err)
+	}
+	return err
+}
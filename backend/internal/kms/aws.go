@@ -0,0 +1,178 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// awsKMS calls the AWS KMS JSON API (Encrypt/Decrypt) with SigV4-signed requests
+type awsKMS struct {
+	region       string
+	keyID        string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	endpoint     string
+	client       *http.Client
+}
+
+func newAWSKMS(cfg Config, client *http.Client) (*awsKMS, error) {
+	a := &awsKMS{
+		region:       cfg.AWSRegion,
+		keyID:        cfg.AWSKeyID,
+		accessKey:    cfg.AWSAccessKeyID,
+		secretKey:    cfg.AWSSecretAccessKey,
+		sessionToken: cfg.AWSSessionToken,
+		endpoint:     strings.TrimRight(cfg.AWSEndpoint, "/"),
+		client:       client,
+	}
+	if a.region == "" {
+		a.region = os.Getenv("AWS_REGION")
+	}
+	if a.accessKey == "" {
+		a.accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+		a.secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		a.sessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if a.endpoint == "" && a.region != "" {
+		a.endpoint = "https://kms." + a.region + ".amazonaws.com"
+	}
+
+	switch {
+	case a.region == "":
+		return nil, errors.New("awskms: region is required")
+	case a.keyID == "":
+		return nil, errors.New("awskms: key_id is required")
+	case a.accessKey == "" || a.secretKey == "":
+		return nil, errors.New("awskms: access key credentials are required")
+	}
+	return a, nil
+}
+
+func (a *awsKMS) KeyID() string {
+	return "awskms:" + a.keyID
+}
+
+func (a *awsKMS) Encrypt(ctx context.Context, plaintext []byte, aad map[string]string) (string, error) {
+	var out struct {
+		CiphertextBlob string `json:"CiphertextBlob"`
+	}
+	err := a.call(ctx, "Encrypt", map[string]interface{}{
+		"KeyId":             a.keyID,
+		"Plaintext":         base64.StdEncoding.EncodeToString(plaintext),
+		"EncryptionContext": aad,
+	}, &out)
+	if err != nil {
+		return "", err
+	}
+	return out.CiphertextBlob, nil
+}
+
+func (a *awsKMS) Decrypt(ctx context.Context, ciphertext string, aad map[string]string) ([]byte, error) {
+	var out struct {
+		Plaintext string `json:"Plaintext"`
+	}
+	err := a.call(ctx, "Decrypt", map[string]interface{}{
+		"KeyId":             a.keyID,
+		"CiphertextBlob":    ciphertext,
+		"EncryptionContext": aad,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(out.Plaintext)
+}
+
+func (a *awsKMS) call(ctx context.Context, action string, body map[string]interface{}, out interface{}) error {
+	payload, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint+"/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	a.sign(req, payload, time.Now().UTC())
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("awskms %s: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("awskms %s: status %d: %s", action, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// sign adds AWS Signature Version 4 headers for the "kms" service
+func (a *awsKMS) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if a.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", a.sessionToken)
+	}
+
+	// Canonical headers, sorted by name; these are all the headers we send
+	headers := [][2]string{
+		{"content-type", req.Header.Get("Content-Type")},
+		{"host", req.URL.Host},
+		{"x-amz-date", amzDate},
+	}
+	if a.sessionToken != "" {
+		headers = append(headers, [2]string{"x-amz-security-token", a.sessionToken})
+	}
+	headers = append(headers, [2]string{"x-amz-target", req.Header.Get("X-Amz-Target")})
+
+	var canonicalHeaders strings.Builder
+	names := make([]string, 0, len(headers))
+	for _, h := range headers {
+		canonicalHeaders.WriteString(h[0] + ":" + h[1] + "\n")
+		names = append(names, h[0])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, "/", "", canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+
+	scope := day + "/" + a.region + "/kms/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+a.secretKey), day)
+	key = hmacSHA256(key, a.region)
+	key = hmacSHA256(key, "kms")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		a.accessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,52 @@
+// Package kms wraps and unwraps small secrets (per-file data keys) with a master
+// key held by an external key management service, so the key-encryption key never
+// has to be stored on the server.
+package kms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Service encrypts and decrypts small secrets under a master key kept in a KMS.
+// aad binds a ciphertext to its purpose (e.g. the file it belongs to); providers
+// that cannot bind additional data ignore it.
+type Service interface {
+	Encrypt(ctx context.Context, plaintext []byte, aad map[string]string) (string, error)
+	Decrypt(ctx context.Context, ciphertext string, aad map[string]string) ([]byte, error)
+	// KeyID identifies the master key, recorded with every wrapped data key
+	KeyID() string
+}
+
+// Config selects and configures a provider
+type Config struct {
+	Provider string // "vault" or "awskms"
+
+	VaultAddress string
+	VaultToken   string
+	VaultMount   string // transit secrets engine mount, default "transit"
+	VaultKey     string
+
+	AWSRegion          string
+	AWSKeyID           string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+	AWSEndpoint        string // override, e.g. for LocalStack
+}
+
+// New creates the configured provider
+func New(cfg Config) (Service, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	switch cfg.Provider {
+	case "vault":
+		return newVaultTransit(cfg, client)
+	case "awskms":
+		return newAWSKMS(cfg, client)
+	default:
+		return nil, fmt.Errorf("unknown KMS provider %q (want vault or awskms)", cfg.Provider)
+	}
+}
@@ -0,0 +1,110 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// vaultTransit uses HashiCorp Vault's transit secrets engine
+type vaultTransit struct {
+	address string
+	token   string
+	mount   string
+	key     string
+	client  *http.Client
+}
+
+func newVaultTransit(cfg Config, client *http.Client) (*vaultTransit, error) {
+	v := &vaultTransit{
+		address: strings.TrimRight(cfg.VaultAddress, "/"),
+		token:   cfg.VaultToken,
+		mount:   strings.Trim(cfg.VaultMount, "/"),
+		key:     cfg.VaultKey,
+		client:  client,
+	}
+	if v.address == "" {
+		v.address = strings.TrimRight(os.Getenv("VAULT_ADDR"), "/")
+	}
+	if v.token == "" {
+		v.token = os.Getenv("VAULT_TOKEN")
+	}
+	if v.mount == "" {
+		v.mount = "transit"
+	}
+
+	switch {
+	case v.address == "":
+		return nil, errors.New("vault: address is required")
+	case v.token == "":
+		return nil, errors.New("vault: token is required")
+	case v.key == "":
+		return nil, errors.New("vault: key name is required")
+	}
+	return v, nil
+}
+
+func (v *vaultTransit) KeyID() string {
+	return "vault:" + v.mount + "/" + v.key
+}
+
+func (v *vaultTransit) Encrypt(ctx context.Context, plaintext []byte, _ map[string]string) (string, error) {
+	var out struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	err := v.call(ctx, "encrypt", map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+	}, &out)
+	if err != nil {
+		return "", err
+	}
+	return out.Ciphertext, nil
+}
+
+func (v *vaultTransit) Decrypt(ctx context.Context, ciphertext string, _ map[string]string) ([]byte, error) {
+	var out struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := v.call(ctx, "decrypt", map[string]string{"ciphertext": ciphertext}, &out); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(out.Plaintext)
+}
+
+func (v *vaultTransit) call(ctx context.Context, op string, body map[string]string, out interface{}) error {
+	payload, _ := json.Marshal(body)
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", v.address, v.mount, op, v.key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault %s: %w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("vault %s: status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("vault %s: invalid response: %w", op, err)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
@@ -0,0 +1,268 @@
+// Package notify posts events (accounts awaiting approval, abuse reports,
+// quarantined uploads, cleanup runs, storage alerts) to chat channels: Slack and
+// Discord incoming webhooks and Matrix rooms. Every message is a job, so a failed
+// post is retried like other jobs; a formatter per provider turns the message
+// into its payload.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// JobType is the job that posts one message to one channel
+const JobType = "notify.chat"
+
+// Providers
+const (
+	Slack   = "slack"   // incoming webhook, {"text": ...}
+	Discord = "discord" // incoming webhook, {"content": ...}
+	Matrix  = "matrix"  // m.notice sent to a room through the client-server API
+)
+
+// Events channels can subscribe to
+const (
+	EventUserPending      = "user.pending"      // a new account awaits approval
+	EventShareReported    = "share.reported"    // a public share link was reported
+	EventFileQuarantined  = "file.quarantined"  // an upload was held back by a rule
+	EventCleanup          = "cleanup.finished"  // auto-delete removed files
+	EventStorageThreshold = "storage.threshold" // stored files or the database crossed an alert threshold
+	EventTest             = "test"              // sent on request to one channel
+)
+
+// Events are the events a channel can subscribe to
+var Events = []string{EventUserPending, EventShareReported, EventFileQuarantined, EventCleanup, EventStorageThreshold}
+
+const discordMaxContent = 2000
+
+// Channel is where messages are posted
+type Channel struct {
+	Name     string
+	Provider string
+	URL      string // Slack/Discord incoming webhook
+
+	// Matrix room the messages are sent to, as the user of AccessToken
+	Homeserver  string // https://matrix.example.org
+	RoomID      string // !abc:example.org
+	AccessToken string
+
+	Events []string // nil = all
+}
+
+// Subscribed reports whether the channel gets an event
+func (c Channel) Subscribed(event string) bool {
+	return event == EventTest || len(c.Events) == 0 || slices.Contains(c.Events, event)
+}
+
+// Message is a notification, rendered by the formatter of each provider
+type Message struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+	// Text is shown as is in a monospaced block (e.g. a report with aligned columns)
+	Preformatted bool `json:"preformatted,omitempty"`
+}
+
+// Payload is the payload of a JobType job
+type Payload struct {
+	Channel string  `json:"channel"`
+	Event   string  `json:"event"`
+	Message Message `json:"message"`
+}
+
+// ChannelInfo describes a channel without its secrets
+type ChannelInfo struct {
+	Name     string   `json:"name"`
+	Provider string   `json:"provider"`
+	Events   []string `json:"events"`
+}
+
+// Notifier queues messages to the channels subscribed to their event
+type Notifier struct {
+	jobQueue *jobs.Queue
+	channels []Channel
+	client   *http.Client
+}
+
+// New creates a notifier; without channels Notify does nothing
+func New(jobQueue *jobs.Queue, channels []Channel) *Notifier {
+	return &Notifier{
+		jobQueue: jobQueue,
+		channels: channels,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Channels lists the configured channels
+func (n *Notifier) Channels() []ChannelInfo {
+	infos := make([]ChannelInfo, 0, len(n.channels))
+	for _, c := range n.channels {
+		events := c.Events
+		if len(events) == 0 {
+			events = Events
+		}
+		infos = append(infos, ChannelInfo{Name: c.Name, Provider: c.Provider, Events: events})
+	}
+	return infos
+}
+
+func (n *Notifier) channel(name string) (Channel, bool) {
+	for _, c := range n.channels {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Channel{}, false
+}
+
+// Notify queues msg for every channel subscribed to event. It never fails the
+// caller: a message that cannot be queued is logged and dropped.
+func (n *Notifier) Notify(ctx context.Context, event string, msg Message) {
+	if n == nil {
+		return
+	}
+	for _, c := range n.channels {
+		if !c.Subscribed(event) {
+			continue
+		}
+		if _, err := n.Send(context.WithoutCancel(ctx), c.Name, event, msg); err != nil {
+			log.Printf("[notify] Failed to queue %s for %s: %v", event, c.Name, err)
+		}
+	}
+}
+
+// Send queues msg for one channel, whatever events it subscribed to
+func (n *Notifier) Send(ctx context.Context, channel, event string, msg Message) (*storage.Job, error) {
+	if _, ok := n.channel(channel); !ok {
+		return nil, fmt.Errorf("unknown notification channel %q", channel)
+	}
+	return n.jobQueue.Enqueue(ctx, JobType, Payload{Channel: channel, Event: event, Message: msg})
+}
+
+// HandleJob is the job handler for JobType. The job ID is the Matrix transaction
+// ID, so a retried post does not show up twice in the room.
+func (n *Notifier) HandleJob(ctx context.Context, job *storage.Job) error {
+	var p Payload
+	if err := jobs.DecodePayload(job, &p); err != nil {
+		return err
+	}
+	c, ok := n.channel(p.Channel)
+	if !ok {
+		// Removed from the configuration since the message was queued
+		return jobs.Permanent(fmt.Errorf("unknown notification channel %q", p.Channel))
+	}
+	return Post(ctx, n.client, c, p.Message, job.ID)
+}
+
+// Post sends msg to a channel. txnID makes Matrix sends idempotent; it should be
+// unique per message and stay the same when a post is retried.
+func Post(ctx context.Context, client *http.Client, c Channel, msg Message, txnID string) error {
+	method, target := http.MethodPost, c.URL
+	var payload interface{}
+	switch c.Provider {
+	case Slack:
+		payload = map[string]string{"text": formatSlack(msg)}
+	case Discord:
+		payload = map[string]string{"content": formatDiscord(msg)}
+	case Matrix:
+		method = http.MethodPut
+		target = strings.TrimRight(c.Homeserver, "/") + "/_matrix/client/v3/rooms/" +
+			url.PathEscape(c.RoomID) + "/send/m.room.message/" + url.PathEscape(txnID)
+		payload = formatMatrix(msg)
+	default:
+		return jobs.Permanent(fmt.Errorf("unknown notification provider %q", c.Provider))
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Provider == Matrix {
+		req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", c.Provider, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		// Wrong URL, token or room; sending it again will not help
+		return jobs.Permanent(fmt.Errorf("%s rejected the message: HTTP %d", c.Provider, resp.StatusCode))
+	default:
+		return fmt.Errorf("%s post failed: HTTP %d", c.Provider, resp.StatusCode)
+	}
+}
+
+// slackEscaper escapes the characters Slack reserves for links and mentions
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func formatSlack(msg Message) string {
+	text := slackEscaper.Replace(msg.Text)
+	if msg.Preformatted {
+		text = "```" + text + "```"
+	}
+	return "*" + slackEscaper.Replace(msg.Title) + "*\n" + text
+}
+
+func formatDiscord(msg Message) string {
+	content := "**" + msg.Title + "**\n"
+	if !msg.Preformatted {
+		return truncate(content+msg.Text, discordMaxContent)
+	}
+	content += "```\n" + msg.Text + "```"
+	if len(content) > discordMaxContent {
+		content = truncate(content, discordMaxContent-3) + "```"
+	}
+	return content
+}
+
+func formatMatrix(msg Message) map[string]string {
+	text := html.EscapeString(msg.Text)
+	if msg.Preformatted {
+		text = "<pre><code>" + text + "</code></pre>"
+	} else {
+		text = strings.ReplaceAll(text, "\n", "<br>")
+	}
+	return map[string]string{
+		"msgtype":        "m.notice",
+		"body":           msg.Title + "\n\n" + msg.Text,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": "<strong>" + html.EscapeString(msg.Title) + "</strong><br>" + text,
+	}
+}
+
+// truncate cuts s to at most max bytes without splitting a character
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
@@ -0,0 +1,39 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Checksum records the SHA-256 of the stored file's content ("sha256:<hex>") and
+// fails if it differs from the checksum taken on upload, i.e. the object was
+// damaged or altered in storage
+type Checksum struct{}
+
+func (Checksum) Name() string { return "checksum" }
+
+func (Checksum) Applies(*storage.FileMetadata) bool { return true }
+
+func (Checksum) Run(ctx context.Context, file *File) (string, error) {
+	r, err := file.Open(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = r.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	if file.SHA256 != "" && file.SHA256 != sum {
+		return "", jobs.Permanent(fmt.Errorf("stored content does not match upload checksum (sha256:%s)", sum))
+	}
+	return "sha256:" + sum, nil
+}
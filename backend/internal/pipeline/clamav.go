@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// clamdChunkSize is the size of the chunks streamed to clamd (its default
+// StreamMaxLength applies to the whole file)
+const clamdChunkSize = 64 * 1024
+
+// VirusScan scans files with a ClamAV daemon (clamd) over TCP using INSTREAM.
+// An infected file fails the stage with the signature name.
+type VirusScan struct {
+	Address string // clamd host:port
+}
+
+func (VirusScan) Name() string { return "virus_scan" }
+
+func (VirusScan) Applies(*storage.FileMetadata) bool { return true }
+
+func (v VirusScan) Run(ctx context.Context, file *File) (string, error) {
+	r, err := file.Open(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = r.Close() }()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", v.Address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	// Unblock reads and writes when the job is cancelled
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("failed to start scan: %w", err)
+	}
+
+	buf := make([]byte, clamdChunkSize)
+	var size [4]byte
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size[:], uint32(n))
+			if _, err := conn.Write(size[:]); err != nil {
+				return "", fmt.Errorf("failed to send file to clamd: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return "", fmt.Errorf("failed to send file to clamd: %w", err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	if _, err := conn.Write(size[:]); err != nil {
+		return "", fmt.Errorf("failed to finish scan: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return "", fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	reply = strings.TrimSpace(strings.TrimPrefix(strings.TrimRight(reply, "\x00"), "stream:"))
+
+	switch {
+	case reply == "OK":
+		return "clean", nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return "", jobs.Permanent(fmt.Errorf("infected: %s", strings.TrimSuffix(reply, " FOUND")))
+	default:
+		// e.g. "INSTREAM size limit exceeded. ERROR"
+		return "", fmt.Errorf("clamd: %s", reply)
+	}
+}
@@ -0,0 +1,181 @@
+// Package pipeline runs processing stages (checksum, virus scan, thumbnail,
+// webhook, ...) on every uploaded file after it has been stored. Each stage runs
+// as its own background job, so a slow or failing stage does not hold up the
+// upload or the other stages, and its status is recorded per file.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Stage is one step of upload processing
+type Stage interface {
+	// Name identifies the stage in statuses and job types ("checksum", ...)
+	Name() string
+	// Applies reports whether the stage handles this kind of file; others are skipped
+	Applies(file *storage.FileMetadata) bool
+	// Run processes the file and returns a short result for its status (e.g. the
+	// checksum). Errors are retried; wrap them with jobs.Permanent when retrying
+	// cannot help (e.g. an infected file).
+	Run(ctx context.Context, file *File) (string, error)
+}
+
+// File is the input of a stage
+type File struct {
+	*storage.FileMetadata
+
+	// Key is the file's data key, for stages that store derived data encrypted
+	Key []byte
+
+	minioStorage *storage.MinIOStorage
+}
+
+// Open returns the decrypted content of the file
+func (f *File) Open(ctx context.Context) (io.ReadCloser, error) {
+	obj, err := f.minioStorage.GetFile(ctx, f.MinIOPath)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := crypto.DecryptStream(obj, f.Key)
+	if err != nil {
+		_ = obj.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{plain, obj}, nil
+}
+
+// Pipeline enqueues and runs the configured stages
+type Pipeline struct {
+	pgStore      *storage.PostgresStore
+	minioStorage *storage.MinIOStorage
+	queue        *jobs.Queue
+	stages       []Stage
+	onDone       StageHook
+}
+
+// StageHook is called when a stage is done with a file for good: with
+// storage.StageSucceeded, StageSkipped, or StageFailed once no attempts are left
+type StageHook func(ctx context.Context, file *storage.FileMetadata, stage, status, detail string)
+
+// New creates a pipeline and registers a job type ("pipeline.<stage>") per stage
+func New(pgStore *storage.PostgresStore, minioStorage *storage.MinIOStorage, queue *jobs.Queue, stages ...Stage) *Pipeline {
+	p := &Pipeline{
+		pgStore:      pgStore,
+		minioStorage: minioStorage,
+		queue:        queue,
+		stages:       stages,
+	}
+	for _, s := range stages {
+		queue.Register(jobType(s), p.handler(s))
+	}
+	return p
+}
+
+// SetStageHook sets the function called whenever a stage is done with a file
+func (p *Pipeline) SetStageHook(hook StageHook) {
+	p.onDone = hook
+}
+
+func (p *Pipeline) done(ctx context.Context, file *storage.FileMetadata, stage, status, detail string) {
+	if p.onDone != nil {
+		p.onDone(ctx, file, stage, status, detail)
+	}
+}
+
+func jobType(s Stage) string {
+	return "pipeline." + s.Name()
+}
+
+// Stages returns the names of the configured stages
+func (p *Pipeline) Stages() []string {
+	names := make([]string, len(p.stages))
+	for i, s := range p.stages {
+		names[i] = s.Name()
+	}
+	return names
+}
+
+// Process records every stage as pending for a newly stored file and queues them
+func (p *Pipeline) Process(ctx context.Context, fileID string) error {
+	if p == nil || len(p.stages) == 0 {
+		return nil
+	}
+
+	if err := p.pgStore.CreateFileStages(ctx, fileID, p.Stages()); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, s := range p.stages {
+		if _, err := p.queue.Enqueue(ctx, jobType(s), jobs.FilePayload{FileID: fileID}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Pipeline) handler(s Stage) jobs.Handler {
+	return func(ctx context.Context, job *storage.Job) error {
+		var payload jobs.FilePayload
+		if err := jobs.DecodePayload(job, &payload); err != nil {
+			return err
+		}
+
+		metadata, err := p.pgStore.GetFileMetadata(ctx, payload.FileID)
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return nil // deleted before processing finished
+		}
+		if err != nil {
+			return err
+		}
+
+		if !s.Applies(metadata) {
+			if err := p.pgStore.UpdateFileStage(ctx, metadata.FileID, s.Name(), storage.StageSkipped, ""); err != nil {
+				return err
+			}
+			p.done(ctx, metadata, s.Name(), storage.StageSkipped, "")
+			return nil
+		}
+
+		key, err := p.pgStore.DataKey(ctx, metadata)
+		if err != nil {
+			return err
+		}
+
+		_ = p.pgStore.UpdateFileStage(ctx, metadata.FileID, s.Name(), storage.StageRunning, "")
+		detail, err := s.Run(ctx, &File{FileMetadata: metadata, Key: key, minioStorage: p.minioStorage})
+		if err == nil {
+			if err := p.pgStore.UpdateFileStage(ctx, metadata.FileID, s.Name(), storage.StageSucceeded, detail); err != nil {
+				return err
+			}
+			p.done(ctx, metadata, s.Name(), storage.StageSucceeded, detail)
+			return nil
+		}
+
+		// Failed for good when retrying cannot help or this was the last attempt
+		status := storage.StagePending
+		if jobs.IsPermanent(err) || job.Attempts >= job.MaxAttempts {
+			status = storage.StageFailed
+			log.Printf("[pipeline] Stage %s failed for file %s: %v", s.Name(), metadata.FileID, err)
+		}
+		if err := p.pgStore.UpdateFileStage(context.WithoutCancel(ctx), metadata.FileID, s.Name(), status, err.Error()); err != nil {
+			log.Printf("[pipeline] %v", err)
+		}
+		if status == storage.StageFailed {
+			p.done(context.WithoutCancel(ctx), metadata, s.Name(), status, err.Error())
+		}
+		return err
+	}
+}
@@ -0,0 +1,148 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register decoders
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+const (
+	// maxThumbnailSource bounds the images decoded for thumbnails
+	maxThumbnailSource = 50 << 20     // bytes
+	maxThumbnailPixels = 50 * 1000000 // guards against decompression bombs
+)
+
+// Thumbnail stores a JPEG preview of images, at most Size pixels on the longer
+// side, encrypted with the file's data key. Served by GET /files/{id}/thumbnail.
+type Thumbnail struct {
+	pgStore *storage.PostgresStore
+	Size    int
+}
+
+// NewThumbnail creates a thumbnail stage; size 0 means 256 pixels
+func NewThumbnail(pgStore *storage.PostgresStore, size int) *Thumbnail {
+	if size <= 0 {
+		size = 256
+	}
+	return &Thumbnail{pgStore: pgStore, Size: size}
+}
+
+func (*Thumbnail) Name() string { return "thumbnail" }
+
+func (*Thumbnail) Applies(file *storage.FileMetadata) bool {
+	switch file.MimeType {
+	case "image/jpeg", "image/png", "image/gif":
+		return file.Size <= maxThumbnailSource
+	}
+	return false
+}
+
+func (t *Thumbnail) Run(ctx context.Context, file *File) (string, error) {
+	r, err := file.Open(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = r.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(r, maxThumbnailSource+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", jobs.Permanent(fmt.Errorf("not a supported image: %w", err))
+	}
+	if cfg.Width*cfg.Height > maxThumbnailPixels {
+		return "", jobs.Permanent(fmt.Errorf("image too large (%dx%d)", cfg.Width, cfg.Height))
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", jobs.Permanent(fmt.Errorf("failed to decode image: %w", err))
+	}
+	thumb := ScaleDown(src, t.Size)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80}); err != nil {
+		return "", fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	encrypted, err := crypto.EncryptBytes(buf.Bytes(), file.Key)
+	if err != nil {
+		return "", err
+	}
+	if err := t.pgStore.SaveThumbnail(ctx, file.FileID, encrypted, "image/jpeg"); err != nil {
+		return "", err
+	}
+
+	b := thumb.Bounds()
+	return fmt.Sprintf("%dx%d", b.Dx(), b.Dy()), nil
+}
+
+// CropSquare returns the centered square of img, as large as its shorter side
+func CropSquare(img image.Image) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0, y0 := b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2
+	square := image.Rect(x0, y0, x0+side, y0+side)
+
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(square)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), img, square.Min, draw.Src)
+	return dst
+}
+
+// ScaleDown shrinks img to fit in size x size by averaging the source pixels that
+// cover each target pixel, on a white background (JPEG has no transparency).
+// Smaller images are kept at their size.
+func ScaleDown(img image.Image, size int) image.Image {
+	sb := img.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	dw, dh := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			dw, dh = size, max(1, sh*size/sw)
+		} else {
+			dw, dh = max(1, sw*size/sh), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := sb.Min.Y+y*sh/dh, sb.Min.Y+max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := sb.Min.X+x*sw/dw, sb.Min.X+max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			// Colors are premultiplied by alpha; fill the transparent share with white
+			white := 0xffff - a/n
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r/n + white) >> 8), G: uint8((g/n + white) >> 8), B: uint8((b/n + white) >> 8), A: 0xff,
+			})
+		}
+	}
+	return dst
+}
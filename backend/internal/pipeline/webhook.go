@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Webhook notifies an external service of every upload with a JSON POST. When a
+// secret is set the body is signed: X-FileLocker-Signature: sha256=<hex HMAC>.
+type Webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+// NewWebhook creates a webhook stage with a 10 second timeout
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{URL: url, Secret: secret, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (*Webhook) Name() string { return "webhook" }
+
+func (*Webhook) Applies(*storage.FileMetadata) bool { return true }
+
+// webhookEvent is the body sent for an upload
+type webhookEvent struct {
+	Event     string    `json:"event"`
+	FileID    string    `json:"file_id"`
+	UserID    string    `json:"user_id"`
+	FileName  string    `json:"file_name"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (w *Webhook) Run(ctx context.Context, file *File) (string, error) {
+	body, err := json.Marshal(webhookEvent{
+		Event:     "file.uploaded",
+		FileID:    file.FileID,
+		UserID:    file.UserID,
+		FileName:  file.FileName,
+		MimeType:  file.MimeType,
+		Size:      file.Size,
+		Tags:      file.Tags,
+		CreatedAt: file.CreatedAt,
+	})
+	if err != nil {
+		return "", jobs.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", jobs.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-FileLocker-Event", "file.uploaded")
+	if w.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.Secret))
+		mac.Write(body)
+		req.Header.Set("X-FileLocker-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	status := fmt.Sprintf("HTTP %d", resp.StatusCode)
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return status, nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		// The receiver rejected the event; sending it again will not help
+		return "", jobs.Permanent(fmt.Errorf("webhook rejected: %s", status))
+	default:
+		return "", fmt.Errorf("webhook failed: %s", status)
+	}
+}
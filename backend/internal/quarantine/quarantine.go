@@ -0,0 +1,190 @@
+// Package quarantine holds back uploads that match moderation rules (size, type,
+// virus scan verdict, trust level of the uploader) until an admin approves or
+// rejects them. Quarantined files stay with their owner but cannot be downloaded
+// through share links.
+package quarantine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/pipeline"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Trust levels of uploaders
+const (
+	TrustNew    = "new"    // account younger than the trusted age
+	TrustMember = "member" // any other user
+	TrustAdmin  = "admin"
+)
+
+// Verdicts of the virus scan stage
+const (
+	VerdictClean    = "clean"
+	VerdictInfected = "infected"
+	VerdictError    = "error" // the scan failed for good
+)
+
+// Rule quarantines the files that meet all of its conditions; unset conditions
+// match every file
+type Rule struct {
+	Name        string
+	MinSize     int64    // bytes
+	MimeTypes   []string // "application/x-msdownload", or "video/*" for a whole type
+	Extensions  []string // ".exe"
+	ScanVerdict string   // checked once the virus scan finished
+	TrustLevels []string // of the uploader
+}
+
+// Quarantine applies the rules to new uploads
+type Quarantine struct {
+	pgStore      *storage.PostgresStore
+	redisCache   *storage.RedisCache
+	rules        []Rule
+	trustedAfter time.Duration
+	notifier     *notify.Notifier
+}
+
+// New creates the quarantine; accounts become TrustMember once they are
+// trustedAfter old
+func New(pgStore *storage.PostgresStore, redisCache *storage.RedisCache, rules []Rule, trustedAfter time.Duration, notifier *notify.Notifier) *Quarantine {
+	return &Quarantine{
+		pgStore:      pgStore,
+		redisCache:   redisCache,
+		rules:        rules,
+		trustedAfter: trustedAfter,
+		notifier:     notifier,
+	}
+}
+
+// CheckUpload applies the rules that do not depend on a scan verdict to a new
+// file before it is saved, and marks it quarantined if one matches
+func (q *Quarantine) CheckUpload(ctx context.Context, file *storage.FileMetadata) {
+	if q == nil || file.QuarantinedAt != nil {
+		return
+	}
+	if rule := q.match(ctx, file, ""); rule != nil {
+		now := time.Now().Truncate(time.Microsecond)
+		file.QuarantinedAt = &now
+		file.QuarantineReason = rule.Name
+		log.Printf("[quarantine] File %s of user %s held back by rule %s", file.FileID, file.UserID, rule.Name)
+		q.notify(ctx, file, rule.Name)
+	}
+}
+
+// StageDone is the pipeline's stage hook: once the virus scan of a file is done,
+// the rules on scan verdicts are applied
+func (q *Quarantine) StageDone(ctx context.Context, file *storage.FileMetadata, stage, status, detail string) {
+	if q == nil || stage != (pipeline.VirusScan{}).Name() || file.QuarantinedAt != nil {
+		return
+	}
+
+	var verdict string
+	switch {
+	case status == storage.StageSucceeded:
+		verdict = VerdictClean
+	case status == storage.StageFailed && strings.HasPrefix(detail, "infected:"):
+		verdict = VerdictInfected
+	case status == storage.StageFailed:
+		verdict = VerdictError
+	default:
+		return
+	}
+
+	rule := q.match(ctx, file, verdict)
+	if rule == nil {
+		return
+	}
+	if err := q.pgStore.QuarantineFile(ctx, file.FileID, rule.Name); err != nil {
+		log.Printf("[quarantine] Failed to quarantine file %s: %v", file.FileID, err)
+		return
+	}
+	if err := q.redisCache.InvalidateFileMetadata(ctx, file.FileID); err != nil {
+		log.Printf("[quarantine] Failed to invalidate cached metadata for %s: %v", file.FileID, err)
+	}
+	log.Printf("[quarantine] File %s of user %s held back by rule %s (scan: %s)", file.FileID, file.UserID, rule.Name, verdict)
+	q.notify(ctx, file, rule.Name+", scan: "+verdict)
+}
+
+// notify tells the admins' chat channels that a file waits for review
+func (q *Quarantine) notify(ctx context.Context, file *storage.FileMetadata, reason string) {
+	q.notifier.Notify(ctx, notify.EventFileQuarantined, notify.Message{
+		Title: "Upload quarantined",
+		Text: fmt.Sprintf("File %s of user %s is held back (%s) until an admin approves it: fl admin quarantine approve %s",
+			file.FileID, file.UserID, reason, file.FileID),
+	})
+}
+
+// match returns the first rule the file meets. Without a verdict (at upload),
+// rules on scan verdicts are left for later; with one, only those are checked.
+func (q *Quarantine) match(ctx context.Context, file *storage.FileMetadata, verdict string) *Rule {
+	trust := ""
+	for i := range q.rules {
+		rule := &q.rules[i]
+		if rule.ScanVerdict != verdict {
+			continue
+		}
+		if rule.MinSize > 0 && file.Size < rule.MinSize {
+			continue
+		}
+		if len(rule.MimeTypes) > 0 && !mimeMatches(file.MimeType, rule.MimeTypes) {
+			continue
+		}
+		if len(rule.Extensions) > 0 && !extensionMatches(file.FileName, rule.Extensions) {
+			continue
+		}
+		if len(rule.TrustLevels) > 0 {
+			if trust == "" {
+				trust = q.trustLevel(ctx, file.UserID)
+			}
+			if !slices.Contains(rule.TrustLevels, trust) {
+				continue
+			}
+		}
+		return rule
+	}
+	return nil
+}
+
+// trustLevel returns the trust level of a user; unknown users count as new
+func (q *Quarantine) trustLevel(ctx context.Context, userID string) string {
+	user, err := q.pgStore.GetUserByID(ctx, userID)
+	switch {
+	case err != nil:
+		return TrustNew
+	case user.Role == "admin":
+		return TrustAdmin
+	case time.Since(user.CreatedAt) < q.trustedAfter:
+		return TrustNew
+	default:
+		return TrustMember
+	}
+}
+
+func mimeMatches(mimeType string, patterns []string) bool {
+	mimeType = strings.ToLower(mimeType)
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		if p == mimeType || (strings.HasSuffix(p, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(p, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+func extensionMatches(name string, extensions []string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	for _, e := range extensions {
+		if strings.ToLower(e) == ext {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,297 @@
+// Package reports sends admins a summary of the past week or month: new users,
+// storage growth, top uploaders, the bytes served, failed logins and what the
+// cleanup worker deleted. A scheduler on the elected replica claims each finished period once
+// and queues a job that generates the report and mails it to the admins and/or
+// posts it to a Slack or Discord webhook.
+package reports
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/mail"
+	"github.com/sachinthra/file-locker/backend/internal/notify"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Report periods
+const (
+	Weekly  = "weekly"  // Monday to Monday, UTC
+	Monthly = "monthly" // first to first of the month, UTC
+)
+
+// JobType is the job that generates and delivers a report
+const JobType = "report.send"
+
+// Webhook payload formats
+const (
+	FormatSlack   = notify.Slack   // {"text": ...}
+	FormatDiscord = notify.Discord // {"content": ...}
+)
+
+const (
+	defaultTopUploaders = 5
+	checkInterval       = time.Hour
+)
+
+// Payload is the payload of a report job
+type Payload struct {
+	Period string    `json:"period"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+// Report is the activity of a period [From, To)
+type Report struct {
+	Period string    `json:"period"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	*storage.ReportStats
+	// Change of the stored bytes since the previous report of the period; nil
+	// before the first one was sent
+	StorageGrowth *int64 `json:"storage_growth,omitempty"`
+}
+
+// Config selects the scheduled reports and where they go
+type Config struct {
+	Weekly        bool
+	Monthly       bool
+	EmailAdmins   bool     // mail every active admin
+	Recipients    []string // addresses mailed in addition
+	WebhookURL    string
+	WebhookFormat string // FormatSlack (default) or FormatDiscord
+	TopUploaders  int    // 0 = 5
+}
+
+// Reporter generates reports and delivers them
+type Reporter struct {
+	pgStore  *storage.PostgresStore
+	jobQueue *jobs.Queue
+	mailer   mail.Sender
+	cfg      Config
+	client   *http.Client
+}
+
+// New creates a reporter; Start schedules the configured reports
+func New(pgStore *storage.PostgresStore, jobQueue *jobs.Queue, mailer mail.Sender, cfg Config) *Reporter {
+	if cfg.TopUploaders <= 0 {
+		cfg.TopUploaders = defaultTopUploaders
+	}
+	if cfg.WebhookFormat == "" {
+		cfg.WebhookFormat = FormatSlack
+	}
+	return &Reporter{
+		pgStore:  pgStore,
+		jobQueue: jobQueue,
+		mailer:   mailer,
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Scheduled reports whether any report is sent on a schedule
+func (r *Reporter) Scheduled() bool {
+	return r.cfg.Weekly || r.cfg.Monthly
+}
+
+// LastPeriod returns the bounds of the latest finished period before now
+func LastPeriod(period string, now time.Time) (from, to time.Time, err error) {
+	now = now.UTC()
+	switch period {
+	case Weekly:
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		to = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7)) // back to Monday
+		return to.AddDate(0, 0, -7), to, nil
+	case Monthly:
+		to = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return to.AddDate(0, -1, 0), to, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown report period %q", period)
+}
+
+// Start queues the report of every period that has finished and was not sent
+// yet, checking hourly until ctx is cancelled; run it on one replica
+func (r *Reporter) Start(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		r.queueDue(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Reporter) queueDue(ctx context.Context) {
+	var periods []string
+	if r.cfg.Weekly {
+		periods = append(periods, Weekly)
+	}
+	if r.cfg.Monthly {
+		periods = append(periods, Monthly)
+	}
+
+	for _, period := range periods {
+		from, to, _ := LastPeriod(period, time.Now())
+		claimed, err := r.pgStore.ClaimReportRun(ctx, period, from, to)
+		if err != nil {
+			log.Printf("[reports] %v", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if _, err := r.Queue(ctx, period, from, to); err != nil {
+			log.Printf("[reports] Failed to queue %s report: %v", period, err)
+		}
+	}
+}
+
+// Queue queues a job that sends the report of a period
+func (r *Reporter) Queue(ctx context.Context, period string, from, to time.Time) (*storage.Job, error) {
+	return r.jobQueue.Enqueue(ctx, JobType, Payload{Period: period, From: from, To: to})
+}
+
+// Generate gathers the report of a period
+func (r *Reporter) Generate(ctx context.Context, period string, from, to time.Time) (*Report, error) {
+	stats, err := r.pgStore.GetReportStats(ctx, from, to, r.cfg.TopUploaders)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Period: period, From: from, To: to, ReportStats: stats}
+
+	previous, ok, err := r.pgStore.PreviousReportStorage(ctx, period, from)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		growth := stats.TotalBytes - previous
+		report.StorageGrowth = &growth
+	}
+	return report, nil
+}
+
+// HandleJob is the job handler for JobType: it generates the report and delivers
+// it. A failed delivery is retried, and then repeated to every destination.
+func (r *Reporter) HandleJob(ctx context.Context, job *storage.Job) error {
+	var p Payload
+	if err := jobs.DecodePayload(job, &p); err != nil {
+		return err
+	}
+
+	report, err := r.Generate(ctx, p.Period, p.From, p.To)
+	if err != nil {
+		return err
+	}
+	if err := r.deliver(ctx, report, job.ID); err != nil {
+		return err
+	}
+	return r.pgStore.FinishReportRun(ctx, p.Period, p.From, report.TotalBytes)
+}
+
+func (r *Reporter) deliver(ctx context.Context, report *Report, jobID string) error {
+	recipients := append([]string{}, r.cfg.Recipients...)
+	if r.cfg.EmailAdmins {
+		admins, err := r.pgStore.ListAdminEmails(ctx)
+		if err != nil {
+			return err
+		}
+		recipients = append(recipients, admins...)
+	}
+
+	subject := report.Title()
+	body := report.Text()
+	sent := map[string]bool{}
+	for _, to := range recipients {
+		if sent[strings.ToLower(to)] {
+			continue
+		}
+		sent[strings.ToLower(to)] = true
+		if err := r.mailer.Send(ctx, to, subject, body); err != nil {
+			return err
+		}
+	}
+
+	if r.cfg.WebhookURL != "" {
+		if err := r.post(ctx, report, jobID); err != nil {
+			return err
+		}
+	}
+	log.Printf("[reports] Sent %s report for %s to %d address(es)", report.Period, report.From.Format("2006-01-02"), len(sent))
+	return nil
+}
+
+// post sends the report to the chat webhook
+func (r *Reporter) post(ctx context.Context, report *Report, txnID string) error {
+	channel := notify.Channel{Name: "reports", Provider: r.cfg.WebhookFormat, URL: r.cfg.WebhookURL}
+	msg := notify.Message{Title: report.Title(), Text: report.Text(), Preformatted: true}
+	if err := notify.Post(ctx, r.client, channel, msg, txnID); err != nil {
+		return fmt.Errorf("report webhook: %w", err)
+	}
+	return nil
+}
+
+// Title is the subject of the report
+func (r *Report) Title() string {
+	last := r.To.AddDate(0, 0, -1)
+	if r.Period == Monthly {
+		return "File Locker monthly report: " + r.From.Format("January 2006")
+	}
+	return fmt.Sprintf("File Locker weekly report: %s - %s", r.From.Format("Jan 2"), last.Format("Jan 2, 2006"))
+}
+
+// Text renders the report as plain text
+func (r *Report) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Period: %s to %s (UTC)\n\n", r.From.Format("2006-01-02"), r.To.AddDate(0, 0, -1).Format("2006-01-02"))
+
+	fmt.Fprintf(&b, "Users\n")
+	fmt.Fprintf(&b, "  New:               %d\n", r.NewUsers)
+	fmt.Fprintf(&b, "  Pending approval:  %d\n", r.PendingUsers)
+	fmt.Fprintf(&b, "  Total:             %d\n\n", r.TotalUsers)
+
+	fmt.Fprintf(&b, "Storage\n")
+	fmt.Fprintf(&b, "  Stored:            %s in %d files\n", humanize.Bytes(uint64(r.TotalBytes)), r.TotalFiles)
+	if r.StorageGrowth != nil {
+		sign := "+"
+		growth := *r.StorageGrowth
+		if growth < 0 {
+			sign, growth = "-", -growth
+		}
+		fmt.Fprintf(&b, "  Growth:            %s%s\n", sign, humanize.Bytes(uint64(growth)))
+	}
+	fmt.Fprintf(&b, "  Uploaded:          %s in %d files\n\n", humanize.Bytes(uint64(r.UploadedBytes)), r.UploadedFiles)
+
+	fmt.Fprintf(&b, "Top uploaders\n")
+	if len(r.TopUploaders) == 0 {
+		fmt.Fprintf(&b, "  (no uploads)\n")
+	}
+	for i, u := range r.TopUploaders {
+		fmt.Fprintf(&b, "  %d. %-16s %s in %d files\n", i+1, u.Username, humanize.Bytes(uint64(u.Bytes)), u.Files)
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "Transfer (downloads, streams, exports)\n")
+	fmt.Fprintf(&b, "  Served:            %s\n", humanize.Bytes(uint64(r.TransferBytes)))
+	for i, u := range r.TopTransfer {
+		fmt.Fprintf(&b, "  %d. %-16s %s\n", i+1, u.Username, humanize.Bytes(uint64(u.Bytes)))
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "Security\n")
+	fmt.Fprintf(&b, "  Failed logins:     %d from %d address(es)\n\n", r.FailedLogins, r.FailedLoginAddresses)
+
+	fmt.Fprintf(&b, "Cleanup\n")
+	fmt.Fprintf(&b, "  Expired files:     %d (%s)\n", r.ExpiredFiles, humanize.Bytes(uint64(r.ExpiredBytes)))
+	fmt.Fprintf(&b, "  Purged from trash: %d (%s)\n", r.PurgedFiles, humanize.Bytes(uint64(r.PurgedBytes)))
+	return b.String()
+}
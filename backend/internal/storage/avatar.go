@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// Avatar is where a user's avatar is stored and its unwrapped data key
+type Avatar struct {
+	Path      string
+	Key       []byte
+	UpdatedAt time.Time
+}
+
+// avatarKeyID names a user's avatar in the data key context, apart from file IDs
+func avatarKeyID(userID string) string {
+	return "avatar:" + userID
+}
+
+// SetAvatar records the encrypted avatar stored at path with its data key, and
+// returns the path of the avatar it replaces ("" if none) for the caller to delete.
+// Returns sql.ErrNoRows for an unknown user.
+func (p *PostgresStore) SetAvatar(ctx context.Context, userID, path string, key []byte) (string, error) {
+	wrapped, kekID, err := p.wrapDataKey(ctx, avatarKeyID(userID), base64.StdEncoding.EncodeToString(key))
+	if err != nil {
+		return "", err
+	}
+
+	var previous sql.NullString
+	err = p.db.QueryRowContext(ctx, `
+		UPDATE users u
+		SET avatar_path = $1, avatar_key = $2, avatar_kek_id = $3, avatar_updated_at = NOW()
+		FROM (SELECT id, avatar_path FROM users WHERE id = $4 FOR UPDATE) old
+		WHERE u.id = old.id
+		RETURNING old.avatar_path`, path, wrapped, kekID, userID).Scan(&previous)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to set avatar: %w", err)
+	}
+	return previous.String, nil
+}
+
+// GetAvatar returns a user's avatar, or sql.ErrNoRows when they have none
+func (p *PostgresStore) GetAvatar(ctx context.Context, userID string) (*Avatar, error) {
+	var path, stored sql.NullString
+	var updatedAt sql.NullTime
+	err := p.db.QueryRowContext(ctx,
+		`SELECT avatar_path, avatar_key, avatar_updated_at FROM users WHERE id = $1`, userID).Scan(&path, &stored, &updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get avatar: %w", err)
+	}
+	if !path.Valid || !stored.Valid {
+		return nil, sql.ErrNoRows
+	}
+
+	encoded, err := p.unwrapDataKey(ctx, avatarKeyID(userID), stored.String)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode avatar key: %w", err)
+	}
+	return &Avatar{Path: path.String, Key: key, UpdatedAt: updatedAt.Time}, nil
+}
+
+// RemoveAvatar clears a user's avatar and returns the path of its object ("" if
+// they had none) for the caller to delete
+func (p *PostgresStore) RemoveAvatar(ctx context.Context, userID string) (string, error) {
+	var previous sql.NullString
+	err := p.db.QueryRowContext(ctx, `
+		UPDATE users u
+		SET avatar_path = NULL, avatar_key = NULL, avatar_kek_id = NULL, avatar_updated_at = NULL
+		FROM (SELECT id, avatar_path FROM users WHERE id = $1 FOR UPDATE) old
+		WHERE u.id = old.id
+		RETURNING old.avatar_path`, userID).Scan(&previous)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to remove avatar: %w", err)
+	}
+	return previous.String, nil
+}
+
+// AvatarPaths lists the stored avatar objects, for storage analysis
+func (p *PostgresStore) AvatarPaths(ctx context.Context) ([]string, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT avatar_path FROM users WHERE avatar_path IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list avatars: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan avatar: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	batchMaxRows      = 100              // rows written by one statement
+	batchWriteTimeout = 10 * time.Second // per statement
+)
+
+// errWriterClosed is returned for rows added after the store was closed
+var errWriterClosed = errors.New("database store is closed")
+
+// batchWriter writes rows with one multi-row statement per batch instead of one
+// round trip each (group commit): while a batch is being written, rows arriving
+// from other requests queue up and go out together in the next one. An idle
+// writer writes a row right away, so batching adds no latency. Callers wait for
+// their row to be written and get its own error; a batch that fails is retried
+// row by row, so one bad row (e.g. for a file deleted in the meantime) does not
+// fail the others.
+type batchWriter[T any] struct {
+	name  string
+	write func(ctx context.Context, rows []T) error
+	queue chan batchItem[T]
+	done  chan struct{}
+
+	// mu guards closed; add holds it shared while queueing, so close cannot
+	// close the queue under a send
+	mu     sync.RWMutex
+	closed bool
+}
+
+type batchItem[T any] struct {
+	row    T
+	result chan error
+}
+
+func newBatchWriter[T any](name string, write func(ctx context.Context, rows []T) error) *batchWriter[T] {
+	b := &batchWriter[T]{
+		name:  name,
+		write: write,
+		queue: make(chan batchItem[T], batchMaxRows),
+		done:  make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// add writes row with the next batch and returns once it has been written
+func (b *batchWriter[T]) add(ctx context.Context, row T) error {
+	item := batchItem[T]{row: row, result: make(chan error, 1)}
+	if err := b.enqueue(ctx, item); err != nil {
+		return fmt.Errorf("failed to queue %s: %w", b.name, err)
+	}
+	select {
+	case err := <-item.result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("failed to write %s: %w", b.name, ctx.Err())
+	}
+}
+
+func (b *batchWriter[T]) enqueue(ctx context.Context, item batchItem[T]) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return errWriterClosed
+	}
+	select {
+	case b.queue <- item:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close writes the queued rows and stops the writer. Rows added afterwards fail
+// with errWriterClosed.
+func (b *batchWriter[T]) close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.mu.Unlock()
+	<-b.done
+}
+
+func (b *batchWriter[T]) run() {
+	defer close(b.done)
+
+	batch := make([]batchItem[T], 0, batchMaxRows)
+	for item := range b.queue {
+		batch = append(batch[:0], item)
+	collect:
+		for len(batch) < batchMaxRows {
+			select {
+			case item, ok := <-b.queue:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, item)
+			default:
+				break collect
+			}
+		}
+		b.flush(batch)
+	}
+}
+
+func (b *batchWriter[T]) flush(batch []batchItem[T]) {
+	ctx, cancel := context.WithTimeout(context.Background(), batchWriteTimeout)
+	defer cancel()
+
+	rows := make([]T, len(batch))
+	for i, item := range batch {
+		rows[i] = item.row
+	}
+
+	err := b.write(ctx, rows)
+	if err == nil || len(batch) == 1 {
+		for _, item := range batch {
+			item.result <- err
+		}
+		return
+	}
+
+	for i, item := range batch {
+		item.result <- b.write(ctx, rows[i:i+1])
+	}
+}
+
+// valuesList returns "($1, $2), ($3, $4)" for rows rows of the given columns. A
+// column other than "$" wraps its placeholder, e.g. "$::uuid".
+func valuesList(rows int, columns ...string) string {
+	var sb strings.Builder
+	n := 1
+	for r := 0; r < rows; r++ {
+		if r > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('(')
+		for c, column := range columns {
+			if c > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(strings.Replace(column, "$", fmt.Sprintf("$%d", n), 1))
+			n++
+		}
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
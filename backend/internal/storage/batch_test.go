@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestBatchWriterClose(t *testing.T) {
+	var mu sync.Mutex
+	written := 0
+	b := newBatchWriter("rows", func(ctx context.Context, rows []int) error {
+		mu.Lock()
+		written += len(rows)
+		mu.Unlock()
+		return nil
+	})
+
+	// Rows added while the writer closes are either written or refused, never lost
+	// and never sent on the closed queue
+	var wg sync.WaitGroup
+	var added, refused int
+	var countMu sync.Mutex
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := b.add(context.Background(), i)
+			countMu.Lock()
+			defer countMu.Unlock()
+			switch {
+			case err == nil:
+				added++
+			case errors.Is(err, errWriterClosed):
+				refused++
+			default:
+				t.Errorf("add: %v", err)
+			}
+		}()
+	}
+	b.close()
+	wg.Wait()
+
+	if added+refused != 200 || written != added {
+		t.Errorf("added %d, refused %d, written %d", added, refused, written)
+	}
+	if err := b.add(context.Background(), 1); !errors.Is(err, errWriterClosed) {
+		t.Errorf("add after close = %v, want errWriterClosed", err)
+	}
+	b.close() // closing twice is harmless
+}
+
+func TestValuesList(t *testing.T) {
+	if got, want := valuesList(2, "$", "$::uuid"), "($1, $2::uuid), ($3, $4::uuid)"; got != want {
+		t.Errorf("valuesList = %q, want %q", got, want)
+	}
+}
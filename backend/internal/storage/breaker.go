@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"    // requests go to MinIO
+	BreakerOpen     = "open"      // requests fail right away with *UnavailableError
+	BreakerHalfOpen = "half_open" // the cooldown is over; the next result closes or reopens it
+)
+
+// UnavailableError is returned without contacting MinIO while the circuit breaker
+// is open
+type UnavailableError struct {
+	RetryAfter time.Duration // until the breaker lets requests through again
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("object storage unavailable, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// IsUnavailable reports whether err comes from an open circuit breaker
+func IsUnavailable(err error) (*UnavailableError, bool) {
+	var unavailable *UnavailableError
+	return unavailable, errors.As(err, &unavailable)
+}
+
+// BreakerStats is the state of a circuit breaker
+type BreakerStats struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Threshold           int        `json:"threshold"`
+	Trips               int64      `json:"trips"` // times it opened since startup
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAfterSeconds   int        `json:"retry_after_seconds,omitempty"`
+}
+
+// Breaker stops sending requests to MinIO after threshold consecutive failures
+// (network errors, timeouts, 5xx), so that requests fail fast with 503 instead of
+// each waiting for its own timeout. After cooldown requests are let through again:
+// the first success closes the breaker, the first failure opens it for another
+// cooldown. A nil *Breaker lets everything through.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	trips    int64
+	openedAt time.Time
+}
+
+// NewBreaker creates a breaker; a threshold of 0 disables it (returns nil)
+func NewBreaker(name string, threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, state: BreakerClosed}
+}
+
+// allow returns an *UnavailableError while the breaker is open
+func (b *Breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != BreakerOpen {
+		return nil
+	}
+	if wait := b.cooldown - time.Since(b.openedAt); wait > 0 {
+		return &UnavailableError{RetryAfter: wait}
+	}
+	b.state = BreakerHalfOpen
+	log.Printf("[storage] %s circuit half-open, trying again", b.name)
+	return nil
+}
+
+// record counts the result of a request that allow let through
+func (b *Breaker) record(err error) {
+	if b == nil {
+		return
+	}
+	outage := isOutage(err)
+	if err != nil && !outage {
+		// Canceled by the caller or answered by MinIO (not found, denied...):
+		// says nothing about its health
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !outage {
+		if b.state != BreakerClosed {
+			log.Printf("[storage] %s circuit closed, storage is back", b.name)
+		}
+		b.state, b.failures = BreakerClosed, 0
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.threshold) {
+		b.state, b.openedAt = BreakerOpen, time.Now()
+		b.trips++
+		log.Printf("[storage] %s circuit open after %d consecutive failures, last: %v", b.name, b.failures, err)
+	}
+}
+
+// Stats returns the current state
+func (b *Breaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := BreakerStats{State: b.state, ConsecutiveFailures: b.failures, Threshold: b.threshold, Trips: b.trips}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		stats.OpenedAt = &openedAt
+	}
+	if b.state == BreakerOpen {
+		// Past the cooldown the next request is let through
+		if wait := b.cooldown - time.Since(b.openedAt); wait > 0 {
+			stats.RetryAfterSeconds = int(math.Ceil(wait.Seconds()))
+		} else {
+			stats.State = BreakerHalfOpen
+		}
+	}
+	return stats
+}
+
+// isOutage reports whether err means MinIO could not be reached or failed itself
+func isOutage(err error) bool {
+	var source *sourceError
+	if err == nil || errors.Is(err, context.Canceled) || errors.As(err, &source) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var resp minio.ErrorResponse
+	if errors.As(err, &resp) && resp.StatusCode != 0 {
+		return resp.StatusCode >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// breakerReader records the outcome of reading an object (GetObject only contacts
+// MinIO on the first read) and failures in the middle of it
+type breakerReader struct {
+	io.ReadCloser
+	breaker  *Breaker
+	recorded bool
+}
+
+func (r *breakerReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	switch {
+	case err != nil && err != io.EOF:
+		r.breaker.record(err)
+	case !r.recorded:
+		r.breaker.record(nil)
+	}
+	r.recorded = true
+	return n, err
+}
+
+// sourceReader keeps the error of the reader an upload is sent from, so that a
+// client going away is not taken for a storage failure
+type sourceReader struct {
+	io.Reader
+	err error
+}
+
+func (r *sourceReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+// SetBreaker puts the requests of m behind a circuit breaker
+func (m *MinIOStorage) SetBreaker(b *Breaker) {
+	m.breaker = b
+}
+
+// BreakerStats returns the state of the circuit breaker; nil without one
+func (m *MinIOStorage) BreakerStats() *BreakerStats {
+	if m.breaker == nil {
+		return nil
+	}
+	stats := m.breaker.Stats()
+	return &stats
+}
+
+// guard runs a request to MinIO through the circuit breaker
+func (m *MinIOStorage) guard(request func() error) error {
+	if err := m.breaker.allow(); err != nil {
+		return err
+	}
+	err := request()
+	m.breaker.record(err)
+	return err
+}
+
+// track records the outcome of reading obj in the circuit breaker
+func (m *MinIOStorage) track(obj io.ReadCloser) io.ReadCloser {
+	if m.breaker == nil {
+		return obj
+	}
+	return &breakerReader{ReadCloser: obj, breaker: m.breaker}
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxExtensionLen is the longest suffix taken for an extension; anything longer
+// ("report.final-draft-v2") is part of the name
+const maxExtensionLen = 10
+
+// ExtensionUsage is the space the files with one extension take up
+type ExtensionUsage struct {
+	Extension string `json:"extension"` // lower case with the dot, "" for none
+	Files     int    `json:"files"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// ContentStats breaks the storage of the whole instance down by MIME type and
+// by file extension, largest first. Files in the trash are counted.
+type ContentStats struct {
+	Files       int                `json:"files"`
+	Bytes       int64              `json:"bytes"`
+	MimeTypes   []ContentTypeUsage `json:"mime_types"`
+	Extensions  []ExtensionUsage   `json:"extensions"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// fileExtension returns the lower-case extension of a file name, "" when it has
+// none worth the name
+func fileExtension(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	if len(ext) < 2 || len(ext) > maxExtensionLen || strings.ContainsAny(ext, " \t") {
+		return ""
+	}
+	return ext
+}
+
+// GetContentStats sums up every stored file by MIME type and extension. File
+// names may be encrypted, so extensions are found by reading and decrypting
+// the name of each file: this is slow on large instances and meant to be cached.
+func (p *PostgresStore) GetContentStats(ctx context.Context) (*ContentStats, error) {
+	stats := &ContentStats{MimeTypes: []ContentTypeUsage{}, Extensions: []ExtensionUsage{}, GeneratedAt: time.Now().UTC()}
+
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT mime_type, COUNT(*), COALESCE(SUM(size), 0)
+		FROM files
+		GROUP BY mime_type
+		ORDER BY SUM(size) DESC, mime_type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content types: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var t ContentTypeUsage
+		if err := rows.Scan(&t.MimeType, &t.Files, &t.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan content type: %w", err)
+		}
+		stats.MimeTypes = append(stats.MimeTypes, t)
+		stats.Files += t.Files
+		stats.Bytes += t.Bytes
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating content types: %w", err)
+	}
+
+	names, err := p.db.QueryContext(ctx, `SELECT id, file_name, size FROM files`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file names: %w", err)
+	}
+	defer func() { _ = names.Close() }()
+
+	byExtension := make(map[string]*ExtensionUsage)
+	undecryptable := 0
+	for names.Next() {
+		var id, name string
+		var size int64
+		if err := names.Scan(&id, &name, &size); err != nil {
+			return nil, fmt.Errorf("failed to scan file name: %w", err)
+		}
+		ext := ""
+		if plain, err := p.DecryptFileName(id, name); err == nil {
+			ext = fileExtension(plain)
+		} else {
+			undecryptable++
+		}
+		usage := byExtension[ext]
+		if usage == nil {
+			usage = &ExtensionUsage{Extension: ext}
+			byExtension[ext] = usage
+		}
+		usage.Files++
+		usage.Bytes += size
+	}
+	if err := names.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating file names: %w", err)
+	}
+	if undecryptable > 0 {
+		log.Printf("[storage] Content stats: %d file names could not be decrypted, counted without extension", undecryptable)
+	}
+
+	for _, usage := range byExtension {
+		stats.Extensions = append(stats.Extensions, *usage)
+	}
+	sort.Slice(stats.Extensions, func(i, j int) bool {
+		a, b := stats.Extensions[i], stats.Extensions[j]
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		return a.Extension < b.Extension
+	})
+	return stats, nil
+}
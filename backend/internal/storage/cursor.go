@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned for page cursors that were not issued by
+// Cursor.Encode
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// Cursor marks a position in a newest-first listing (files, search results, audit
+// logs, jobs): the page after it starts with the rows created before CreatedAt,
+// ties broken by ID. Unlike an offset it stays valid while rows are added or
+// deleted.
+//
+// Clients get it as an opaque token (next_cursor) and send it back as ?cursor=,
+// so paging works the same in every listing and SDK.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + "." + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a token returned by Encode
+func ParseCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	micros, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{CreatedAt: time.UnixMicro(us), ID: id}, nil
+}
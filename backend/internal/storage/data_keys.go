@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/kms"
+)
+
+// kmsKeyPrefix marks data keys wrapped by the external KMS
+const kmsKeyPrefix = "kms:v1:"
+
+// SetKeyService wraps new per-file data keys with an external KMS instead of a local
+// KEK. Unwrapped keys are kept in memory for cacheTTL (0 disables caching); the
+// master key itself never leaves the KMS. Call before serving requests.
+func (p *PostgresStore) SetKeyService(svc kms.Service, cacheTTL time.Duration) {
+	p.kms = svc
+	p.kekID = svc.KeyID()
+	p.dataKeys = newDataKeyCache(cacheTTL)
+}
+
+// DataKey returns the raw data key of a file, unwrapping it with the KMS or the
+// local KEK as needed
+func (p *PostgresStore) DataKey(ctx context.Context, m *FileMetadata) ([]byte, error) {
+	encoded, err := p.unwrapDataKey(ctx, m.FileID, m.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data key: %w", err)
+	}
+	return key, nil
+}
+
+// unwrapDataKey returns the base64 data key for its stored form, whichever key wraps it
+func (p *PostgresStore) unwrapDataKey(ctx context.Context, fileID, stored string) (string, error) {
+	if !strings.HasPrefix(stored, kmsKeyPrefix) {
+		key, err := p.fieldCipher.Decrypt(stored, fieldContext(fileID, "encryption_key"))
+		if err != nil {
+			return "", fmt.Errorf("failed to unwrap data key: %w", err)
+		}
+		return key, nil
+	}
+
+	if p.kms == nil {
+		return "", errors.New("data key is wrapped by a KMS but none is configured")
+	}
+	if key, ok := p.dataKeys.get(stored); ok {
+		return key, nil
+	}
+
+	raw, err := p.kms.Decrypt(ctx, strings.TrimPrefix(stored, kmsKeyPrefix), map[string]string{"file_id": fileID})
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	key := string(raw)
+	p.dataKeys.put(stored, key)
+	return key, nil
+}
+
+// wrapDataKey returns the stored form of a file's base64 data key and the ID of the
+// key that wraps it (NULL when data keys are stored unwrapped)
+func (p *PostgresStore) wrapDataKey(ctx context.Context, fileID, key string) (string, sql.NullString, error) {
+	switch {
+	case p.kms != nil:
+		wrapped, err := p.kms.Encrypt(ctx, []byte(key), map[string]string{"file_id": fileID})
+		if err != nil {
+			return "", sql.NullString{}, fmt.Errorf("failed to wrap data key: %w", err)
+		}
+		stored := kmsKeyPrefix + wrapped
+		p.dataKeys.put(stored, key)
+		return stored, sql.NullString{String: p.kekID, Valid: true}, nil
+
+	case p.fieldCipher != nil:
+		wrapped, err := p.fieldCipher.Encrypt(key, fieldContext(fileID, "encryption_key"))
+		if err != nil {
+			return "", sql.NullString{}, fmt.Errorf("failed to wrap data key: %w", err)
+		}
+		return wrapped, sql.NullString{String: p.kekID, Valid: true}, nil
+
+	default:
+		return key, sql.NullString{}, nil
+	}
+}
+
+// newKey returns the replacement data key, or "" for a nil change
+func (c *FileKeyChange) newKey() string {
+	if c == nil {
+		return ""
+	}
+	return c.NewKey
+}
+
+// LoadOrCreateWrappedKey returns a 32-byte key that is stored in the database only in
+// KMS-wrapped form, generating it on first use. Used for the metadata key when no
+// local KEK is configured.
+func (p *PostgresStore) LoadOrCreateWrappedKey(ctx context.Context, purpose string, svc kms.Service) ([]byte, error) {
+	aad := map[string]string{"purpose": purpose}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := svc.Encrypt(ctx, key, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap %s key: %w", purpose, err)
+	}
+
+	// A concurrent first start may have won; the stored key is authoritative either way
+	_, err = p.db.ExecContext(ctx, `
+		INSERT INTO wrapped_keys (purpose, key_id, wrapped_key)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (purpose) DO NOTHING
+	`, purpose, svc.KeyID(), wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store %s key: %w", purpose, err)
+	}
+
+	var stored string
+	if err := p.db.QueryRowContext(ctx,
+		`SELECT wrapped_key FROM wrapped_keys WHERE purpose = $1`, purpose).Scan(&stored); err != nil {
+		return nil, fmt.Errorf("failed to load %s key: %w", purpose, err)
+	}
+
+	key, err = svc.Decrypt(ctx, stored, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap %s key: %w", purpose, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid %s key length: %d bytes", purpose, len(key))
+	}
+	return key, nil
+}
+
+// dataKeyCache holds recently unwrapped data keys so repeated downloads of a file do
+// not each cost a KMS round trip. Entries live only in process memory.
+type dataKeyCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedDataKey
+}
+
+type cachedDataKey struct {
+	key     string
+	expires time.Time
+}
+
+// dataKeyCacheMax bounds memory use; the cache is emptied when it fills up
+const dataKeyCacheMax = 10000
+
+func newDataKeyCache(ttl time.Duration) *dataKeyCache {
+	return &dataKeyCache{ttl: ttl, entries: make(map[string]cachedDataKey)}
+}
+
+func (c *dataKeyCache) get(wrapped string) (string, bool) {
+	if c == nil || c.ttl <= 0 {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[wrapped]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(e.expires) {
+		delete(c.entries, wrapped)
+		return "", false
+	}
+	return e.key, true
+}
+
+func (c *dataKeyCache) put(wrapped, key string) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= dataKeyCacheMax {
+		now := time.Now()
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= dataKeyCacheMax {
+			c.entries = make(map[string]cachedDataKey)
+		}
+	}
+	c.entries[wrapped] = cachedDataKey{key: key, expires: time.Now().Add(c.ttl)}
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DownloadStats sums up the recorded downloads of a file
+type DownloadStats struct {
+	Downloads      int        `json:"downloads"`
+	Bytes          int64      `json:"bytes"`
+	ViaShares      int        `json:"via_shares"`  // through a share link
+	Downloaders    int        `json:"downloaders"` // distinct users, and IPs for anonymous downloads
+	LastDownloaded *time.Time `json:"last_downloaded,omitempty"`
+}
+
+// AccessEvent is one recorded download of a file
+type AccessEvent struct {
+	At        time.Time `json:"at"`
+	UserID    string    `json:"user_id,omitempty"`  // empty for anonymous share downloads
+	ShareID   string    `json:"share_id,omitempty"` // share link it was served through
+	IPAddress string    `json:"ip_address,omitempty"`
+	Bytes     int64     `json:"bytes"`
+}
+
+// FileDownloadStats sums up the downloads recorded for a file
+func (p *PostgresStore) FileDownloadStats(ctx context.Context, fileID string) (DownloadStats, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(bytes), 0), COUNT(share_id),
+		       COUNT(DISTINCT COALESCE(user_id::text, ip_address)), MAX(downloaded_at)
+		FROM file_downloads
+		WHERE file_id = $1
+	`
+
+	var stats DownloadStats
+	var last sql.NullTime
+	err := p.db.QueryRowContext(ctx, query, fileID).Scan(&stats.Downloads, &stats.Bytes, &stats.ViaShares, &stats.Downloaders, &last)
+	if err != nil {
+		return stats, fmt.Errorf("failed to get download stats: %w", err)
+	}
+	if last.Valid {
+		stats.LastDownloaded = &last.Time
+	}
+	return stats, nil
+}
+
+// RecentDownloads returns the latest limit downloads of a file, newest first
+func (p *PostgresStore) RecentDownloads(ctx context.Context, fileID string, limit int) ([]AccessEvent, error) {
+	query := `
+		SELECT downloaded_at, COALESCE(user_id::text, ''), COALESCE(share_id::text, ''),
+		       COALESCE(ip_address, ''), bytes
+		FROM file_downloads
+		WHERE file_id = $1
+		ORDER BY downloaded_at DESC
+		LIMIT $2
+	`
+
+	rows, err := p.db.QueryContext(ctx, query, fileID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list downloads: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := []AccessEvent{}
+	for rows.Next() {
+		var e AccessEvent
+		if err := rows.Scan(&e.At, &e.UserID, &e.ShareID, &e.IPAddress, &e.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan download: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating downloads: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+)
+
+// SetEncryption configures local keys. c holds the current local key and any retired
+// ones; unless a KMS is configured (SetKeyService), new per-file data keys are wrapped
+// with it and kekID identifies it (see crypto.KEKID). A non-nil idx also turns on
+// encryption of file_name, description and tags with c, with blind indexes for
+// searching names and tags. Existing rows stay readable and are converted by
+// EncryptPlaintextMetadata and RotateFileKey. Call before serving requests.
+func (p *PostgresStore) SetEncryption(c *crypto.FieldCipher, kekID string, idx *crypto.BlindIndexer) {
+	p.fieldCipher = c
+	p.blindIndex = idx
+	if p.kms == nil {
+		p.kekID = kekID
+	}
+}
+
+// MetadataEncrypted reports whether file metadata is encrypted at rest, in which
+// case SQL cannot match on file_name, description or tags
+func (p *PostgresStore) MetadataEncrypted() bool {
+	return p.blindIndex != nil
+}
+
+// CurrentKEKID returns the ID of the key that wraps new data keys, or "" when they
+// are stored unwrapped
+func (p *PostgresStore) CurrentKEKID() string {
+	return p.kekID
+}
+
+func fieldContext(fileID, column string) string {
+	return fileID + "|" + column
+}
+
+// sealedFields holds the searchable metadata columns of a file as they are stored.
+// The blind index columns are NULL (nil) while encryption is off.
+type sealedFields struct {
+	name, description string
+	tags              []string
+	nameIndex         sql.NullString
+	namePrefixIndex   []string
+	tagIndex          []string
+}
+
+// sealFileFields encrypts file_name, description and tags and computes their blind indexes
+func (p *PostgresStore) sealFileFields(m *FileMetadata) (*sealedFields, error) {
+	if p.blindIndex == nil {
+		return &sealedFields{name: m.FileName, description: m.Description, tags: m.Tags}, nil
+	}
+
+	var s sealedFields
+	var err error
+	if s.name, err = p.fieldCipher.Encrypt(m.FileName, fieldContext(m.FileID, "file_name")); err != nil {
+		return nil, err
+	}
+	if s.description, err = p.fieldCipher.Encrypt(m.Description, fieldContext(m.FileID, "description")); err != nil {
+		return nil, err
+	}
+
+	s.tagIndex = []string{}
+	if m.Tags != nil {
+		s.tags = make([]string, len(m.Tags))
+		for i, tag := range m.Tags {
+			if s.tags[i], err = p.fieldCipher.Encrypt(tag, fieldContext(m.FileID, "tags")); err != nil {
+				return nil, err
+			}
+			s.tagIndex = append(s.tagIndex, p.blindIndex.Index("tag", tag))
+		}
+	}
+
+	s.nameIndex = sql.NullString{String: p.blindIndex.Index("name", m.FileName), Valid: true}
+	s.namePrefixIndex = p.blindIndex.PrefixIndexes(m.FileName)
+	return &s, nil
+}
+
+// openFileFields decrypts the encrypted metadata of a scanned row in place. The data
+// key stays wrapped; DataKey unwraps it when the file's content is needed.
+func (p *PostgresStore) openFileFields(m *FileMetadata) error {
+	var err error
+	if m.FileName, err = p.fieldCipher.Decrypt(m.FileName, fieldContext(m.FileID, "file_name")); err != nil {
+		return fmt.Errorf("file %s: file_name: %w", m.FileID, err)
+	}
+	if m.Description, err = p.fieldCipher.Decrypt(m.Description, fieldContext(m.FileID, "description")); err != nil {
+		return fmt.Errorf("file %s: description: %w", m.FileID, err)
+	}
+	for i, tag := range m.Tags {
+		if m.Tags[i], err = p.fieldCipher.Decrypt(tag, fieldContext(m.FileID, "tags")); err != nil {
+			return fmt.Errorf("file %s: tags: %w", m.FileID, err)
+		}
+	}
+	return nil
+}
+
+// scanFile scans a row selected with fileColumns and decrypts its metadata
+func (p *PostgresStore) scanFile(row rowScanner) (*FileMetadata, error) {
+	metadata, err := scanFileMetadata(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.openFileFields(metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// DecryptFileName returns the plaintext of a file_name value read with custom SQL
+func (p *PostgresStore) DecryptFileName(fileID, stored string) (string, error) {
+	return p.fieldCipher.Decrypt(stored, fieldContext(fileID, "file_name"))
+}
+
+// searchEncryptedFiles finds a user's files through the blind indexes: the query is
+// matched against the whole file name, prefixes of the name and of its words, and
+// tags (all case-insensitive). Descriptions and infix matches are not searchable
+// while metadata is encrypted. Index hits are re-checked on the decrypted values.
+// Results start after the cursor, if any.
+func (p *PostgresStore) searchEncryptedFiles(ctx context.Context, userID, query string, after *Cursor) ([]*FileMetadata, error) {
+	sqlQuery := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1 AND deleted_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		  AND (
+			name_index = $2 OR
+			name_prefix_index @> ARRAY[$3] OR
+			tag_index @> ARRAY[$4]
+		  )`
+	args := []interface{}{userID,
+		p.blindIndex.Index("name", query), p.blindIndex.PrefixQuery(query), p.blindIndex.Index("tag", query)}
+	if after != nil {
+		sqlQuery += ` AND (created_at, id) < ($5, $6)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	sqlQuery += `
+		ORDER BY created_at DESC, id DESC`
+
+	candidates, err := p.queryFiles(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*FileMetadata
+	for _, f := range candidates {
+		if matchesBlindSearch(f, query) {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+func matchesBlindSearch(m *FileMetadata, query string) bool {
+	if crypto.PrefixMatch(m.FileName, query) {
+		return true
+	}
+	for _, tag := range m.Tags {
+		if strings.EqualFold(tag, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// EncryptPlaintextMetadata encrypts the metadata of files written before encryption
+// was enabled (or while it was off) and fills in their blind indexes, in batches.
+// It returns the IDs of the converted files so cached copies can be dropped.
+// A no-op when encryption is off.
+func (p *PostgresStore) EncryptPlaintextMetadata(ctx context.Context) ([]string, error) {
+	if !p.MetadataEncrypted() {
+		return nil, nil
+	}
+
+	const batchSize = 100
+	var converted []string
+	for {
+		// Rows written without encryption have no name index
+		files, err := p.queryFiles(ctx, `
+			SELECT `+fileColumns+`
+			FROM files
+			WHERE name_index IS NULL
+			ORDER BY id
+			LIMIT $1
+		`, batchSize)
+		if err != nil {
+			return converted, fmt.Errorf("failed to find plaintext metadata: %w", err)
+		}
+		if len(files) == 0 {
+			return converted, nil
+		}
+
+		for _, f := range files {
+			// Re-reads the row under lock and leaves updated_at (and so issued links) as is
+			err := p.RotateFileKey(ctx, f.FileID, nil)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue // deleted meanwhile
+			}
+			if err != nil {
+				return converted, fmt.Errorf("failed to encrypt metadata of file %s: %w", f.FileID, err)
+			}
+			converted = append(converted, f.FileID)
+		}
+		log.Printf("[INFO] Encrypted metadata of %d files", len(converted))
+	}
+}
+
+// withinTx runs fn in a transaction, committing when it returns nil
+func (p *PostgresStore) withinTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// preserveUpdatedAt stops the files updated_at trigger for the rest of the transaction,
+// for re-encryption that does not change what the user sees
+func preserveUpdatedAt(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `SELECT set_config('filelocker.preserve_updated_at', 'on', true)`)
+	return err
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListActiveFilesPage returns a page of a user's files that are neither trashed
+// nor expired, newest first. The page starts after the cursor, or at offset when
+// after is nil; a limit of 0 returns all remaining files. The returned cursor
+// points at the next page and is nil on the last one.
+func (p *PostgresStore) ListActiveFilesPage(ctx context.Context, userID string, after *Cursor, offset, limit int) ([]*FileMetadata, *Cursor, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1 AND deleted_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())`
+	args := []interface{}{userID}
+
+	if after != nil {
+		query += ` AND (created_at, id) < ($2, $3)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += `
+		ORDER BY created_at DESC, id DESC`
+	if limit > 0 {
+		// One more row than asked for tells whether there is a next page
+		query += fmt.Sprintf(` LIMIT %d`, limit+1)
+	}
+	if after == nil && offset > 0 {
+		query += fmt.Sprintf(` OFFSET %d`, offset)
+	}
+
+	files, err := p.queryFiles(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	files, next := filePage(files, limit)
+	return files, next, nil
+}
+
+// filePage cuts a newest-first result down to limit files (0 for no limit) and
+// returns the cursor of the next page, nil when there is none. Queries fetch one
+// more row than the limit to tell.
+func filePage(files []*FileMetadata, limit int) ([]*FileMetadata, *Cursor) {
+	if limit <= 0 || len(files) <= limit {
+		return files, nil
+	}
+	files = files[:limit]
+	last := files[limit-1]
+	return files, &Cursor{CreatedAt: last.CreatedAt, ID: last.FileID}
+}
+
+// CountActiveFiles counts a user's files that are neither trashed nor expired
+func (p *PostgresStore) CountActiveFiles(ctx context.Context, userID string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM files
+		WHERE user_id = $1 AND deleted_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	var count int
+	if err := p.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count files: %w", err)
+	}
+
+	return count, nil
+}
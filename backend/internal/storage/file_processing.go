@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Processing stage statuses
+const (
+	StagePending   = "pending"
+	StageRunning   = "running"
+	StageSucceeded = "succeeded"
+	StageFailed    = "failed"
+	StageSkipped   = "skipped" // does not apply to this file (e.g. thumbnail of a PDF)
+)
+
+// FileStage is the status of one upload processing stage of a file
+type FileStage struct {
+	Stage     string    `json:"stage"`
+	Status    string    `json:"status"`
+	Detail    string    `json:"detail,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateFileStages records the given stages as pending for a new file
+func (p *PostgresStore) CreateFileStages(ctx context.Context, fileID string, stages []string) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO file_processing (file_id, stage)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT (file_id, stage) DO UPDATE
+		SET status = 'pending', detail = NULL, updated_at = CURRENT_TIMESTAMP
+	`, fileID, stages)
+	if err != nil {
+		return fmt.Errorf("failed to create processing stages: %w", err)
+	}
+	return nil
+}
+
+// UpdateFileStage sets the status and detail of a file's processing stage
+func (p *PostgresStore) UpdateFileStage(ctx context.Context, fileID, stage, status, detail string) error {
+	_, err := p.db.ExecContext(ctx, `
+		UPDATE file_processing
+		SET status = $1, detail = NULLIF($2, ''), updated_at = CURRENT_TIMESTAMP
+		WHERE file_id = $3 AND stage = $4
+	`, status, detail, fileID, stage)
+	if err != nil {
+		return fmt.Errorf("failed to update processing stage: %w", err)
+	}
+	return nil
+}
+
+// GetFileStages returns the processing stages of a file in stage order
+func (p *PostgresStore) GetFileStages(ctx context.Context, fileID string) ([]FileStage, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT stage, status, detail, updated_at
+		FROM file_processing
+		WHERE file_id = $1
+		ORDER BY stage
+	`, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get processing stages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stages := []FileStage{}
+	for rows.Next() {
+		var s FileStage
+		var detail sql.NullString
+		if err := rows.Scan(&s.Stage, &s.Status, &detail, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan processing stage: %w", err)
+		}
+		s.Detail = detail.String
+		stages = append(stages, s)
+	}
+	return stages, rows.Err()
+}
+
+// SaveThumbnail stores a file's thumbnail, already encrypted with the file's data key
+func (p *PostgresStore) SaveThumbnail(ctx context.Context, fileID string, data []byte, mimeType string) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO file_thumbnails (file_id, data, mime_type)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (file_id) DO UPDATE
+		SET data = EXCLUDED.data, mime_type = EXCLUDED.mime_type, created_at = CURRENT_TIMESTAMP
+	`, fileID, data, mimeType)
+	if err != nil {
+		return fmt.Errorf("failed to save thumbnail: %w", err)
+	}
+	return nil
+}
+
+// GetThumbnail returns a file's encrypted thumbnail and its MIME type, or sql.ErrNoRows
+func (p *PostgresStore) GetThumbnail(ctx context.Context, fileID string) ([]byte, string, error) {
+	var data []byte
+	var mimeType string
+	err := p.db.QueryRowContext(ctx,
+		`SELECT data, mime_type FROM file_thumbnails WHERE file_id = $1`, fileID).Scan(&data, &mimeType)
+	return data, mimeType, err
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// TransferFiles makes toUserID the owner of files of fromUserID: those in fileIDs,
+// or all of them (the trash included) when fileIDs is empty. Share links of the
+// files move along and stars are cleared. Nothing changes, and ErrFileNotFound is
+// returned, when one of fileIDs is not a file of fromUserID. The objects stay where
+// they are; RelocateFile moves them to the new owner's location.
+func (p *PostgresStore) TransferFiles(ctx context.Context, fromUserID, toUserID string, fileIDs []string) ([]*FileMetadata, error) {
+	query := `UPDATE files SET user_id = $2, starred_at = NULL WHERE user_id = $1`
+	args := []interface{}{fromUserID, toUserID}
+	if len(fileIDs) > 0 {
+		query += ` AND id = ANY($3::uuid[])`
+		args = append(args, fileIDs)
+	}
+	query += ` RETURNING ` + fileColumns
+
+	var files []*FileMetadata
+	err := p.withinTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to transfer files: %w", err)
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			metadata, err := p.scanFile(rows)
+			if err != nil {
+				return fmt.Errorf("failed to scan file: %w", err)
+			}
+			files = append(files, metadata)
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating files: %w", err)
+		}
+		if len(fileIDs) > 0 {
+			wanted := make(map[string]bool, len(fileIDs))
+			for _, id := range fileIDs {
+				wanted[id] = true
+			}
+			if len(files) != len(wanted) {
+				return ErrFileNotFound
+			}
+		}
+
+		ids := make([]string, len(files))
+		for i, f := range files {
+			ids[i] = f.FileID
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE share_links SET created_by = $1 WHERE file_id = ANY($2::uuid[])`, toUserID, ids); err != nil {
+			return fmt.Errorf("failed to transfer share links: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// RelocateFile points a file at newPath if its object is still at oldPath, and
+// reports whether it did
+func (p *PostgresStore) RelocateFile(ctx context.Context, fileID, oldPath, newPath string) (bool, error) {
+	result, err := p.db.ExecContext(ctx,
+		`UPDATE files SET minio_path = $3 WHERE id = $1 AND minio_path = $2`, fileID, oldPath, newPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to relocate file: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Job statuses
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobDead      = "dead" // out of attempts; listed for inspection and manual retry
+)
+
+// Job is a queued unit of asynchronous work
+type Job struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedBy    string          `json:"locked_by,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+const jobColumns = `id, type, payload, status, attempts, max_attempts, run_at,
+		       locked_by, last_error, created_at, updated_at, finished_at`
+
+func scanJob(row rowScanner) (*Job, error) {
+	var j Job
+	var payload []byte
+	var lockedBy, lastError sql.NullString
+	var finishedAt sql.NullTime
+
+	err := row.Scan(
+		&j.ID,
+		&j.Type,
+		&payload,
+		&j.Status,
+		&j.Attempts,
+		&j.MaxAttempts,
+		&j.RunAt,
+		&lockedBy,
+		&lastError,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	j.Payload = payload
+	j.LockedBy = lockedBy.String
+	j.LastError = lastError.String
+	if finishedAt.Valid {
+		j.FinishedAt = &finishedAt.Time
+	}
+
+	return &j, nil
+}
+
+// EnqueueJob adds a pending job that runs no earlier than runAt
+func (p *PostgresStore) EnqueueJob(ctx context.Context, jobType string, payload []byte, maxAttempts int, runAt time.Time) (*Job, error) {
+	query := `
+		INSERT INTO jobs (type, payload, max_attempts, run_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(p.db.QueryRowContext(ctx, query, jobType, payload, maxAttempts, runAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	return job, nil
+}
+
+// ClaimJob leases the next due job of one of the given types to worker for lease,
+// counting an attempt. Jobs whose lease expired (their worker died) are claimed
+// again. Returns sql.ErrNoRows when nothing is due.
+func (p *PostgresStore) ClaimJob(ctx context.Context, worker string, types []string, lease time.Duration) (*Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1, locked_by = $1,
+		    locked_until = CURRENT_TIMESTAMP + make_interval(secs => $2),
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE type = ANY($3)
+			  AND ((status = 'pending' AND run_at <= CURRENT_TIMESTAMP)
+			    OR (status = 'running' AND locked_until < CURRENT_TIMESTAMP))
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	return scanJob(p.db.QueryRowContext(ctx, query, worker, lease.Seconds(), types))
+}
+
+// ExtendJobLease keeps a long-running job leased to worker
+func (p *PostgresStore) ExtendJobLease(ctx context.Context, jobID, worker string, lease time.Duration) error {
+	_, err := p.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET locked_until = CURRENT_TIMESTAMP + make_interval(secs => $1)
+		WHERE id = $2 AND status = 'running' AND locked_by = $3
+	`, lease.Seconds(), jobID, worker)
+	return err
+}
+
+// CompleteJob marks a job leased to worker as succeeded
+func (p *PostgresStore) CompleteJob(ctx context.Context, jobID, worker string) error {
+	_, err := p.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'succeeded', locked_by = NULL, locked_until = NULL, last_error = NULL,
+		    updated_at = CURRENT_TIMESTAMP, finished_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND locked_by = $2
+	`, jobID, worker)
+	if err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// FailJob records a failed attempt of a job leased to worker: it is retried at
+// retryAt, or moved to the dead letters when retryAt is nil
+func (p *PostgresStore) FailJob(ctx context.Context, jobID, worker, lastError string, retryAt *time.Time) error {
+	query := `
+		UPDATE jobs
+		SET status = 'pending', run_at = $1, locked_by = NULL, locked_until = NULL,
+		    last_error = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND locked_by = $4
+	`
+	args := []interface{}{retryAt, lastError, jobID, worker}
+	if retryAt == nil {
+		query = `
+			UPDATE jobs
+			SET status = 'dead', locked_by = NULL, locked_until = NULL,
+			    last_error = $1, updated_at = CURRENT_TIMESTAMP, finished_at = CURRENT_TIMESTAMP
+			WHERE id = $2 AND locked_by = $3
+		`
+		args = []interface{}{lastError, jobID, worker}
+	}
+
+	if _, err := p.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record job failure: %w", err)
+	}
+	return nil
+}
+
+// RetryJob puts a dead job back in the queue with a fresh set of attempts.
+// Returns sql.ErrNoRows if the job does not exist or is not dead.
+func (p *PostgresStore) RetryJob(ctx context.Context, jobID string) (*Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'pending', attempts = 0, run_at = CURRENT_TIMESTAMP,
+		    updated_at = CURRENT_TIMESTAMP, finished_at = NULL
+		WHERE id = $1 AND status = 'dead'
+		RETURNING ` + jobColumns
+
+	return scanJob(p.db.QueryRowContext(ctx, query, jobID))
+}
+
+// ListJobs returns a page of jobs newest first, optionally filtered by status and
+// type. The page starts after the cursor, or at offset when after is nil; the
+// returned cursor points at the next page and is nil on the last one.
+func (p *PostgresStore) ListJobs(ctx context.Context, status, jobType string, after *Cursor, limit, offset int) ([]*Job, *Cursor, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR type = $2)`
+	args := []interface{}{status, jobType}
+	if after != nil {
+		query += ` AND (created_at, id) < ($3, $4)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	// One more row than asked for tells whether there is a next page
+	query += fmt.Sprintf(`
+		ORDER BY created_at DESC, id DESC
+		LIMIT %d`, limit+1)
+	if after == nil && offset > 0 {
+		query += fmt.Sprintf(` OFFSET %d`, offset)
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	jobs := []*Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	if len(jobs) <= limit {
+		return jobs, nil, nil
+	}
+	jobs = jobs[:limit]
+	last := jobs[limit-1]
+	return jobs, &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+}
+
+// CountJobsByStatus returns the number of jobs per status
+func (p *PostgresStore) CountJobsByStatus(ctx context.Context) (map[string]int, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM jobs GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := map[string]int{JobPending: 0, JobRunning: 0, JobSucceeded: 0, JobDead: 0}
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, err
+		}
+		counts[status] = n
+	}
+	return counts, rows.Err()
+}
+
+// JobBacklog summarises the jobs of one type, e.g. how far a replication lags
+type JobBacklog struct {
+	Pending         int        `json:"pending"` // pending or running
+	Dead            int        `json:"dead"`
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty"`
+	LastFinishedAt  *time.Time `json:"last_finished_at,omitempty"` // of the latest success still kept
+}
+
+// GetJobBacklog returns the backlog of the jobs of jobType
+func (p *PostgresStore) GetJobBacklog(ctx context.Context, jobType string) (*JobBacklog, error) {
+	var b JobBacklog
+	var oldest, last sql.NullTime
+	err := p.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE status IN ('pending', 'running')),
+		       COUNT(*) FILTER (WHERE status = 'dead'),
+		       MIN(created_at) FILTER (WHERE status IN ('pending', 'running')),
+		       MAX(finished_at) FILTER (WHERE status = 'succeeded')
+		FROM jobs WHERE type = $1
+	`, jobType).Scan(&b.Pending, &b.Dead, &oldest, &last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job backlog: %w", err)
+	}
+	if oldest.Valid {
+		b.OldestPendingAt = &oldest.Time
+	}
+	if last.Valid {
+		b.LastFinishedAt = &last.Time
+	}
+	return &b, nil
+}
+
+// DeleteFinishedJobs removes succeeded jobs finished before cutoff
+func (p *PostgresStore) DeleteFinishedJobs(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := p.db.ExecContext(ctx,
+		`DELETE FROM jobs WHERE status = 'succeeded' AND finished_at < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+	return result.RowsAffected()
+}
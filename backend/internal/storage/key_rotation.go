@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Key rotation job statuses
+const (
+	KeyRotationRunning   = "running"
+	KeyRotationCompleted = "completed"
+	KeyRotationFailed    = "failed"
+)
+
+// ErrKeyRotationRunning is returned when a rotation is started while another runs
+var ErrKeyRotationRunning = errors.New("a key rotation is already running")
+
+// KeyRotation is the persisted progress of a key rotation job
+type KeyRotation struct {
+	ID               string     `json:"id"`
+	KEKID            string     `json:"kek_id"`
+	ReencryptObjects bool       `json:"reencrypt_objects"`
+	Status           string     `json:"status"`
+	TotalFiles       int        `json:"total_files"`
+	ProcessedFiles   int        `json:"processed_files"`
+	FailedFiles      int        `json:"failed_files"`
+	LastFileID       string     `json:"last_file_id,omitempty"`
+	LastError        string     `json:"last_error,omitempty"`
+	StartedBy        string     `json:"started_by,omitempty"`
+	StartedAt        time.Time  `json:"started_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+}
+
+const keyRotationColumns = `id, kek_id, reencrypt_objects, status, total_files,
+		       processed_files, failed_files, last_file_id, last_error, started_by,
+		       started_at, updated_at, finished_at`
+
+func scanKeyRotation(row rowScanner) (*KeyRotation, error) {
+	var k KeyRotation
+	var lastFileID, lastError, startedBy sql.NullString
+	var finishedAt sql.NullTime
+
+	err := row.Scan(
+		&k.ID,
+		&k.KEKID,
+		&k.ReencryptObjects,
+		&k.Status,
+		&k.TotalFiles,
+		&k.ProcessedFiles,
+		&k.FailedFiles,
+		&lastFileID,
+		&lastError,
+		&startedBy,
+		&k.StartedAt,
+		&k.UpdatedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	k.LastFileID = lastFileID.String
+	k.LastError = lastError.String
+	k.StartedBy = startedBy.String
+	if finishedAt.Valid {
+		k.FinishedAt = &finishedAt.Time
+	}
+
+	return &k, nil
+}
+
+// CreateKeyRotation records a new running rotation to the current KEK, covering
+// every file whose data key is not wrapped under it yet.
+// Returns ErrKeyRotationRunning if another rotation is in progress.
+func (p *PostgresStore) CreateKeyRotation(ctx context.Context, reencryptObjects bool, startedBy string) (*KeyRotation, error) {
+	if p.kekID == "" {
+		return nil, errors.New("no key-encryption key configured")
+	}
+
+	query := `
+		INSERT INTO key_rotations (kek_id, reencrypt_objects, started_by, total_files)
+		VALUES ($1, $2, NULLIF($3, '')::uuid,
+		        (SELECT COUNT(*) FROM files WHERE kek_id IS DISTINCT FROM $1 OR $2))
+		RETURNING ` + keyRotationColumns
+
+	job, err := scanKeyRotation(p.db.QueryRowContext(ctx, query, p.kekID, reencryptObjects, startedBy))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrKeyRotationRunning
+		}
+		return nil, fmt.Errorf("failed to create key rotation: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetRunningKeyRotation returns the rotation in progress, or sql.ErrNoRows
+func (p *PostgresStore) GetRunningKeyRotation(ctx context.Context) (*KeyRotation, error) {
+	query := `SELECT ` + keyRotationColumns + ` FROM key_rotations WHERE status = 'running'`
+	return scanKeyRotation(p.db.QueryRowContext(ctx, query))
+}
+
+// GetLatestKeyRotation returns the most recently started rotation, or sql.ErrNoRows
+func (p *PostgresStore) GetLatestKeyRotation(ctx context.Context) (*KeyRotation, error) {
+	query := `SELECT ` + keyRotationColumns + ` FROM key_rotations ORDER BY started_at DESC LIMIT 1`
+	return scanKeyRotation(p.db.QueryRowContext(ctx, query))
+}
+
+// UpdateKeyRotationProgress stores the job's counters and resume point
+func (p *PostgresStore) UpdateKeyRotationProgress(ctx context.Context, job *KeyRotation) error {
+	query := `
+		UPDATE key_rotations
+		SET processed_files = $1, failed_files = $2, last_file_id = NULLIF($3, '')::uuid,
+		    last_error = NULLIF($4, ''), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5
+	`
+
+	if _, err := p.db.ExecContext(ctx, query,
+		job.ProcessedFiles, job.FailedFiles, job.LastFileID, job.LastError, job.ID); err != nil {
+		return fmt.Errorf("failed to update key rotation: %w", err)
+	}
+
+	return nil
+}
+
+// FinishKeyRotation marks the job completed or failed
+func (p *PostgresStore) FinishKeyRotation(ctx context.Context, jobID, status, lastError string) error {
+	query := `
+		UPDATE key_rotations
+		SET status = $1, last_error = COALESCE(NULLIF($2, ''), last_error),
+		    updated_at = CURRENT_TIMESTAMP, finished_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`
+
+	if _, err := p.db.ExecContext(ctx, query, status, lastError, jobID); err != nil {
+		return fmt.Errorf("failed to finish key rotation: %w", err)
+	}
+
+	return nil
+}
+
+// ListFilesForRotation returns the next batch of files after afterID (in id order)
+// that a rotation still has to process: those not under the current KEK, or all
+// files when objects are re-encrypted
+func (p *PostgresStore) ListFilesForRotation(ctx context.Context, afterID string, allFiles bool, limit int) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE (kek_id IS DISTINCT FROM $1 OR $2)
+		  AND ($3 = '' OR id > NULLIF($3, '')::uuid)
+		ORDER BY id
+		LIMIT $4
+	`
+
+	files, err := p.queryFiles(ctx, query, p.kekID, allFiles, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files for rotation: %w", err)
+	}
+
+	return files, nil
+}
+
+// FileKeyChange replaces a file's data key after its object was re-encrypted.
+// OldKey (the stored, wrapped form) must still be the file's key, guarding against
+// concurrent changes. NewKey is the new base64 data key. Thumbnail, if set, is the
+// file's thumbnail re-encrypted with it.
+type FileKeyChange struct {
+	OldKey    string
+	NewKey    string
+	MinIOPath string
+	Thumbnail []byte
+}
+
+// RotateFileKey re-wraps a file's data key under the current KEK and re-encrypts its
+// metadata, optionally installing a new data key and object path first (which needs
+// no KEK). The file's updated_at is left unchanged so issued download links keep working.
+func (p *PostgresStore) RotateFileKey(ctx context.Context, fileID string, change *FileKeyChange) error {
+	if p.kekID == "" && change == nil {
+		return errors.New("no key-encryption key configured")
+	}
+
+	return p.withinTx(ctx, func(tx *sql.Tx) error {
+		metadata, err := p.scanFile(tx.QueryRowContext(ctx,
+			`SELECT `+fileColumns+` FROM files WHERE id = $1 FOR UPDATE`, fileID))
+		if err != nil {
+			return err
+		}
+
+		dataKey := change.newKey()
+		if change != nil {
+			if metadata.EncryptionKey != change.OldKey {
+				return errors.New("data key changed during rotation")
+			}
+			metadata.MinIOPath = change.MinIOPath
+		} else if dataKey, err = p.unwrapDataKey(ctx, fileID, metadata.EncryptionKey); err != nil {
+			return err
+		}
+
+		sealed, err := p.sealFileFields(metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt file metadata: %w", err)
+		}
+		encryptionKey, kekID, err := p.wrapDataKey(ctx, fileID, dataKey)
+		if err != nil {
+			return err
+		}
+
+		if err := preserveUpdatedAt(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE files
+			SET encryption_key = $1, kek_id = $2, minio_path = $3,
+			    file_name = $4, description = $5, tags = $6,
+			    name_index = $7, name_prefix_index = $8, tag_index = $9
+			WHERE id = $10
+		`, encryptionKey, kekID, metadata.MinIOPath,
+			sealed.name, sealed.description, sealed.tags,
+			sealed.nameIndex, sealed.namePrefixIndex, sealed.tagIndex, fileID)
+		if err != nil {
+			return fmt.Errorf("failed to rotate file key: %w", err)
+		}
+
+		if change != nil && change.Thumbnail != nil {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE file_thumbnails SET data = $1 WHERE file_id = $2`, change.Thumbnail, fileID); err != nil {
+				return fmt.Errorf("failed to update thumbnail: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -5,19 +5,44 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strings"
+	"sync"
 
+	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
 // Docs: https://github.com/minio/minio-go/blob/master/examples/s3/makebucket.go
 
+// Object isolation modes
+const (
+	// IsolationShared keeps every object in the main bucket under "<user id>/"
+	IsolationShared = "shared"
+	// IsolationBucket gives each user a bucket of their own ("<bucket>-<user id>"),
+	// so lifecycle rules, quotas and policies can be set per tenant
+	IsolationBucket = "bucket"
+)
+
 type MinIOStorage struct {
-	client *minio.Client
-	bucket string
+	client    *minio.Client
+	bucket    string
+	region    string
+	isolation string
+
+	mu      sync.Mutex
+	buckets map[string]bool // tenant buckets known to exist
+
+	onChange func(ctx context.Context, path string) // see SetChangeHook
+	breaker  *Breaker                               // see SetBreaker; nil = none
+
+	retryPolicy   RetryPolicy  // see SetRetryPolicy
+	verifyUploads bool         // see SetVerifyUploads
+	uploadTuning  UploadTuning // see SetUploadTuning
+	labelObjects  bool         // see SetLabelObjects
 }
 
-func NewMinIOStorage(endpoint, accessKey, secretKey, bucket string, useSSL bool, region string) (*MinIOStorage, error) {
+func NewMinIOStorage(endpoint, accessKey, secretKey, bucket string, useSSL bool, region, isolation string) (*MinIOStorage, error) {
 	ctx := context.Background()
 
 	minioClient, err := minio.New(endpoint, &minio.Options{
@@ -43,48 +68,252 @@ func NewMinIOStorage(endpoint, accessKey, secretKey, bucket string, useSSL bool,
 		log.Printf("Bucket %s already exists\n", bucket)
 	}
 
-	return &MinIOStorage{client: minioClient, bucket: bucket}, nil
+	if isolation == "" {
+		isolation = IsolationShared
+	}
+
+	return &MinIOStorage{
+		client:    minioClient,
+		bucket:    bucket,
+		region:    region,
+		isolation: isolation,
+		buckets:   make(map[string]bool),
+	}, nil
+}
+
+// ObjectPath returns where a user's object called name is stored, as recorded in
+// files.minio_path. With bucket isolation the user's bucket is created on first use
+// and the path has the form "<bucket>:<name>"; paths without a bucket refer to the
+// main bucket, so objects written before isolation was enabled stay readable.
+func (m *MinIOStorage) ObjectPath(ctx context.Context, userID, name string) (string, error) {
+	if m.isolation != IsolationBucket {
+		return userID + "/" + name, nil
+	}
+
+	bucket := m.tenantBucket(userID)
+	if err := m.retry(ctx, "create bucket", bucket, func() error { return m.ensureBucket(ctx, bucket) }); err != nil {
+		return "", err
+	}
+	return bucket + ":" + name, nil
 }
 
-func (m *MinIOStorage) SaveFile(ctx context.Context, objectName string, reader io.Reader, size int64, contentType string) error {
-	info, err := m.client.PutObject(ctx, m.bucket, objectName, reader, size, minio.PutObjectOptions{ContentType: contentType})
+// SystemPrefix holds objects the server keeps for itself (such as avatars) in the
+// main bucket, apart from user files whatever the isolation mode
+const SystemPrefix = "_system/"
+
+// SnapshotPrefix holds the metadata snapshots (see worker.Snapshotter), one
+// "directory" per snapshot
+const SnapshotPrefix = SystemPrefix + "snapshots/"
+
+// SystemObjectPath returns where a system object called name is stored
+func (m *MinIOStorage) SystemObjectPath(name string) string {
+	return SystemPrefix + name
+}
+
+// tenantBucket names a user's bucket; user IDs are lowercase UUIDs, which keeps
+// the name valid (at most 63 characters for a main bucket name of up to 26)
+func (m *MinIOStorage) tenantBucket(userID string) string {
+	return m.bucket + "-" + strings.ToLower(userID)
+}
+
+func (m *MinIOStorage) ensureBucket(ctx context.Context, bucket string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.buckets[bucket] {
+		return nil
+	}
+
+	exists, err := m.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket existence: %w", err)
+	}
+	if !exists {
+		if err := m.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: m.region}); err != nil {
+			// Another replica may have created it meanwhile
+			if exists, _ := m.client.BucketExists(ctx, bucket); !exists {
+				return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+			}
+		}
+		log.Printf("Created tenant bucket %s\n", bucket)
+	}
+
+	m.buckets[bucket] = true
+	return nil
+}
+
+// locate splits a stored object path into bucket and object name
+func (m *MinIOStorage) locate(path string) (string, string) {
+	if bucket, name, ok := strings.Cut(path, ":"); ok {
+		return bucket, name
+	}
+	return m.bucket, path
+}
+
+// SaveFile stores reader under objectName. A size of -1 means the size is not
+// known in advance; the object is then sent in parts (see SetUploadTuning). The upload
+// is retried only if reader is an io.Seeker it can be rewound with. With
+// SetVerifyUploads an object that does not match what was sent is removed and
+// an *IntegrityError returned. labels are written with the object when
+// SetLabelObjects is on.
+func (m *MinIOStorage) SaveFile(ctx context.Context, objectName string, reader io.Reader, size int64, contentType string, labels ObjectLabels) error {
+	bucket, name := m.locate(objectName)
+	opts := m.putOptions(size, contentType)
+	if m.labelObjects {
+		opts.UserMetadata, opts.UserTags = labels.userMetadata(), labels.tags()
+	}
+
+	put := func() (minio.UploadInfo, error) {
+		source := &sourceReader{Reader: reader}
+		var sent *uploadChecksum
+		if m.verifyUploads {
+			var err error
+			if sent, err = newUploadChecksum(size, opts); err != nil {
+				return minio.UploadInfo{}, err
+			}
+			source.Reader = &hashingReader{r: reader, sum: sent}
+		}
+		info, err := m.client.PutObject(ctx, bucket, name, source, size, opts)
+		if source.err != nil {
+			return info, &sourceError{err: source.err}
+		}
+		if err == nil && sent != nil {
+			err = m.verify(ctx, objectName, info, sent)
+		}
+		return info, err
+	}
+	var info minio.UploadInfo
+	var err error
+	seeker, rewindable := reader.(io.Seeker)
+	var start int64
+	if rewindable {
+		start, err = seeker.Seek(0, io.SeekCurrent)
+		rewindable = err == nil
+	}
+	if rewindable {
+		attempt := 0
+		err = m.retry(ctx, "upload", objectName, func() error {
+			if attempt++; attempt > 1 {
+				if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+					return &sourceError{err: err}
+				}
+			}
+			info, err = put()
+			return err
+		})
+	} else {
+		err = m.guard(func() (err error) {
+			info, err = put()
+			return err
+		})
+	}
 	if err != nil {
 		return fmt.Errorf("failed to upload file: %w", err)
 	}
 	log.Printf("Successfully uploaded %s of size %d\n", objectName, info.Size)
+	m.changed(ctx, objectName)
 	return nil
 }
 
 func (m *MinIOStorage) GetFile(ctx context.Context, objectName string) (io.ReadCloser, error) {
-	obj, err := m.client.GetObject(ctx, m.bucket, objectName, minio.GetObjectOptions{})
+	obj, err := m.openObject(ctx, objectName, 0, -1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file: %w", err)
 	}
 	return obj, nil
 }
 
+// GetFileRange reads bytes start to end (inclusive) of an object
 func (m *MinIOStorage) GetFileRange(ctx context.Context, objectName string, start, end int64) (io.ReadCloser, error) {
-	opts := minio.GetObjectOptions{}
-	if err := opts.SetRange(start, end); err != nil {
-		return nil, fmt.Errorf("failed to set range: %w", err)
-	}
-
-	obj, err := m.client.GetObject(ctx, m.bucket, objectName, opts)
+	obj, err := m.openObject(ctx, objectName, start, end)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file range: %w", err)
 	}
 	return obj, nil
 }
 
+// openObject reads bytes start to end (inclusive; -1 = to the end) of an object.
+// A read that breaks off is resumed where it stopped (see RetryPolicy).
+func (m *MinIOStorage) openObject(ctx context.Context, objectName string, start, end int64) (io.ReadCloser, error) {
+	bucket, name := m.locate(objectName)
+	open := func(offset int64) (io.ReadCloser, error) {
+		opts := minio.GetObjectOptions{}
+		switch {
+		case end >= 0:
+			if err := opts.SetRange(start+offset, end); err != nil {
+				return nil, fmt.Errorf("failed to set range: %w", err)
+			}
+		case start+offset > 0:
+			if err := opts.SetRange(start+offset, 0); err != nil {
+				return nil, fmt.Errorf("failed to set range: %w", err)
+			}
+		}
+		if err := m.breaker.allow(); err != nil {
+			return nil, err
+		}
+		// Nothing is sent before the first read
+		obj, err := m.client.GetObject(ctx, bucket, name, opts)
+		if err != nil {
+			return nil, err
+		}
+		return m.track(obj), nil
+	}
+
+	obj, err := open(0)
+	if err != nil {
+		return nil, err
+	}
+	if m.retryPolicy.Attempts <= 1 {
+		return obj, nil
+	}
+	return &resumingReader{m: m, ctx: ctx, path: objectName, open: open, rc: obj}, nil
+}
+
+// CopyFile duplicates an object on the server side, without streaming it through
+// this process. With SetLabelObjects the copy gets labels instead of the labels
+// of the source, and is stored as the encrypted octet-stream file content is.
+func (m *MinIOStorage) CopyFile(ctx context.Context, srcObject, dstObject string, labels ObjectLabels) error {
+	srcBucket, srcName := m.locate(srcObject)
+	dstBucket, dstName := m.locate(dstObject)
+	dst := minio.CopyDestOptions{Bucket: dstBucket, Object: dstName}
+	if m.labelObjects {
+		dst.ContentType = "application/octet-stream"
+		dst.UserMetadata, dst.ReplaceMetadata = labels.userMetadata(), true
+		dst.UserTags, dst.ReplaceTags = labels.tags(), true
+	}
+	err := m.retry(ctx, "copy", srcObject, func() error {
+		_, err := m.client.CopyObject(ctx,
+			dst,
+			minio.CopySrcOptions{Bucket: srcBucket, Object: srcName},
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	m.changed(ctx, dstObject)
+	return nil
+}
+
 func (m *MinIOStorage) DeleteFile(ctx context.Context, objectName string) error {
-	if err := m.client.RemoveObject(ctx, m.bucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+	bucket, name := m.locate(objectName)
+	err := m.retry(ctx, "delete", objectName, func() error {
+		return m.client.RemoveObject(ctx, bucket, name, minio.RemoveObjectOptions{})
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
+	m.changed(ctx, objectName)
 	return nil
 }
 
 func (m *MinIOStorage) GetFileInfo(ctx context.Context, objectName string) (minio.ObjectInfo, error) {
-	info, err := m.client.StatObject(ctx, m.bucket, objectName, minio.StatObjectOptions{})
+	bucket, name := m.locate(objectName)
+	var info minio.ObjectInfo
+	err := m.retry(ctx, "stat", objectName, func() (err error) {
+		info, err = m.client.StatObject(ctx, bucket, name, minio.StatObjectOptions{})
+		return err
+	})
 	if err != nil {
 		return minio.ObjectInfo{}, fmt.Errorf("failed to get file info: %w", err)
 	}
@@ -97,12 +326,49 @@ type MinIOObject struct {
 	Size int64
 }
 
-// ListAllObjects lists all objects in the bucket for storage analysis
+// ListAllObjects lists all objects in the main bucket and the tenant buckets for
+// storage analysis, keyed by their stored path (see ObjectPath)
 func (m *MinIOStorage) ListAllObjects(ctx context.Context) ([]MinIOObject, error) {
+	objects, err := m.listObjects(ctx, m.bucket, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Tenant buckets may exist even if isolation was switched off again
+	buckets, err := m.client.ListBuckets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+	for _, b := range buckets {
+		if !m.isTenantBucket(b.Name) {
+			continue
+		}
+		tenantObjects, err := m.listObjects(ctx, b.Name, b.Name+":")
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, tenantObjects...)
+	}
+
+	return objects, nil
+}
+
+// isTenantBucket reports whether bucket was named by tenantBucket, leaving alone
+// unrelated buckets that merely share the prefix
+func (m *MinIOStorage) isTenantBucket(bucket string) bool {
+	userID, ok := strings.CutPrefix(bucket, m.bucket+"-")
+	if !ok {
+		return false
+	}
+	_, err := uuid.Parse(userID)
+	return err == nil
+}
+
+func (m *MinIOStorage) listObjects(ctx context.Context, bucket, pathPrefix string) ([]MinIOObject, error) {
 	var objects []MinIOObject
 
 	// Create a channel to receive objects
-	objectCh := m.client.ListObjects(ctx, m.bucket, minio.ListObjectsOptions{
+	objectCh := m.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
 		Recursive: true,
 	})
 
@@ -112,10 +378,22 @@ func (m *MinIOStorage) ListAllObjects(ctx context.Context) ([]MinIOObject, error
 		}
 
 		objects = append(objects, MinIOObject{
-			Key:  object.Key,
+			Key:  pathPrefix + object.Key,
 			Size: object.Size,
 		})
 	}
 
 	return objects, nil
 }
+
+// ListPrefix lists the objects of the main bucket whose path starts with prefix
+func (m *MinIOStorage) ListPrefix(ctx context.Context, prefix string) ([]MinIOObject, error) {
+	var objects []MinIOObject
+	for object := range m.client.ListObjects(ctx, m.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", object.Err)
+		}
+		objects = append(objects, MinIOObject{Key: object.Key, Size: object.Size})
+	}
+	return objects, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"cmp"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// streamPartSize is the default multipart part size for uploads of unknown size;
+// each such upload buffers one part in memory (Concurrency of them when parts are
+// sent in parallel)
+const streamPartSize = 16 << 20
+
+// maxParts is the most parts S3 accepts for one object
+const maxParts = 10000
+
+// UploadTuning sets how PutObject splits object writes, trading memory for
+// throughput. Uploads of unknown size (the file uploads) can be at most
+// PartSize * 10000 bytes.
+type UploadTuning struct {
+	PartSize     int64 // bytes per part, 5 MiB to 5 GiB; 0 = 16 MiB for streams, the client's choice otherwise
+	Concurrency  int   // parts sent at once; 0 = the client's default (4) for known sizes, one at a time for streams
+	SinglePutMax int64 // objects of known size up to this many bytes are sent in one request; 0 = up to a part
+}
+
+// SetUploadTuning sets the part size and concurrency of the writes of m
+func (m *MinIOStorage) SetUploadTuning(t UploadTuning) {
+	m.uploadTuning = t
+}
+
+// putOptions returns the options an object of size bytes (-1 = unknown) is
+// written with
+func (m *MinIOStorage) putOptions(size int64, contentType string) minio.PutObjectOptions {
+	t := m.uploadTuning
+	opts := minio.PutObjectOptions{ContentType: contentType}
+	switch {
+	case size < 0:
+		opts.PartSize = uint64(cmp.Or(t.PartSize, streamPartSize))
+		if t.Concurrency > 1 {
+			// Fills Concurrency buffers of a part each and sends them in parallel
+			opts.NumThreads = uint(t.Concurrency)
+			opts.ConcurrentStreamParts = true
+		}
+	case t.SinglePutMax > 0 && size <= t.SinglePutMax:
+		opts.DisableMultipart = true
+	default:
+		// A part size that would need more than maxParts parts is left to the client
+		if t.PartSize > 0 && size <= t.PartSize*maxParts {
+			opts.PartSize = uint64(t.PartSize)
+		}
+		if t.Concurrency > 0 {
+			opts.NumThreads = uint(t.Concurrency)
+		}
+	}
+	return opts
+}
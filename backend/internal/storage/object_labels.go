@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// Object tiers, so lifecycle rules can treat kinds of objects differently
+const (
+	TierFile     = "file"     // content of a user's file
+	TierAvatar   = "avatar"   // profile picture
+	TierSnapshot = "snapshot" // part of a backup snapshot
+)
+
+// ObjectLabels describe a stored object to tools that only see the bucket: S3
+// lifecycle rules (which filter on tags) and inventory or audit scripts. Owner
+// and tier are written as user metadata (x-amz-meta-owner, x-amz-meta-tier) and
+// as tags; the expiry, which changes over the life of a file, only as a tag.
+type ObjectLabels struct {
+	Owner     string     // user ID; "" for system objects
+	Tier      string     // Tier*
+	ExpiresAt *time.Time // when file-locker deletes the object; nil = never
+}
+
+// ObjectLabels returns the labels of the object holding the content of f
+func (f *FileMetadata) ObjectLabels() ObjectLabels {
+	return ObjectLabels{Owner: f.UserID, Tier: TierFile, ExpiresAt: f.ExpiresAt}
+}
+
+func (l ObjectLabels) userMetadata() map[string]string {
+	meta := map[string]string{"tier": l.Tier}
+	if l.Owner != "" {
+		meta["owner"] = l.Owner
+	}
+	return meta
+}
+
+func (l ObjectLabels) tags() map[string]string {
+	t := l.userMetadata()
+	if l.ExpiresAt != nil {
+		t["expires"] = l.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return t
+}
+
+// SetLabelObjects makes m write ObjectLabels with every object. Off, the labels
+// passed to SaveFile, CopyFile and LabelObject are ignored.
+func (m *MinIOStorage) SetLabelObjects(label bool) {
+	m.labelObjects = label
+}
+
+// LabelObject replaces the tags of a stored object, e.g. after its expiry changed.
+// User metadata cannot change without rewriting the object and is left as written.
+func (m *MinIOStorage) LabelObject(ctx context.Context, objectName string, labels ObjectLabels) error {
+	if !m.labelObjects {
+		return nil
+	}
+	objectTags, err := tags.NewTags(labels.tags(), true)
+	if err != nil {
+		return fmt.Errorf("invalid tags for %s: %w", objectName, err)
+	}
+	bucket, name := m.locate(objectName)
+	err = m.retry(ctx, "tag", objectName, func() error {
+		return m.client.PutObjectTagging(ctx, bucket, name, objectTags, minio.PutObjectTaggingOptions{})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag object: %w", err)
+	}
+	return nil
+}
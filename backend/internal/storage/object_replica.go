@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// NewMinIOReplica connects to a standby S3/MinIO endpoint that keeps a copy of
+// every object of primary, in buckets of the same names, so that failing over is
+// a matter of pointing storage.minio at it. Buckets are created on first use;
+// nothing is sent before that, so an unreachable standby does not hold up startup.
+func NewMinIOReplica(primary *MinIOStorage, endpoint, accessKey, secretKey string, useSSL bool, region string) (*MinIOStorage, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MinIO client for the replica: %w", err)
+	}
+	if region == "" {
+		region = primary.region
+	}
+
+	return &MinIOStorage{
+		client:    client,
+		bucket:    primary.bucket,
+		region:    region,
+		isolation: primary.isolation,
+		buckets:   make(map[string]bool),
+	}, nil
+}
+
+// Endpoint returns the host (and port) the storage talks to
+func (m *MinIOStorage) Endpoint() string {
+	return m.client.EndpointURL().Host
+}
+
+// SetChangeHook sets a function that is called with the path of every object
+// written or deleted through m, after the change succeeded
+func (m *MinIOStorage) SetChangeHook(hook func(ctx context.Context, path string)) {
+	m.onChange = hook
+}
+
+func (m *MinIOStorage) changed(ctx context.Context, path string) {
+	if m.onChange != nil {
+		m.onChange(ctx, path)
+	}
+}
+
+// ReplicateObject makes the object at path on replica the same as on m: it is
+// copied when m has it and deleted when m does not
+func (m *MinIOStorage) ReplicateObject(ctx context.Context, replica *MinIOStorage, path string) error {
+	bucket, name := m.locate(path)
+	info, err := m.client.StatObject(ctx, bucket, name, minio.StatObjectOptions{})
+	if isNotFound(err) {
+		replicaBucket, replicaName := replica.locate(path)
+		err := replica.client.RemoveObject(ctx, replicaBucket, replicaName, minio.RemoveObjectOptions{})
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete replica of %s: %w", path, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	obj, err := m.client.GetObject(ctx, bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer func() { _ = obj.Close() }()
+
+	replicaBucket, replicaName := replica.locate(path)
+	if err := replica.ensureBucket(ctx, replicaBucket); err != nil {
+		return err
+	}
+	// Labels go along as they are now; later tag changes are not mirrored
+	opts := m.putOptions(info.Size, info.ContentType)
+	opts.UserMetadata = info.UserMetadata
+	if m.labelObjects {
+		objectTags, err := m.client.GetObjectTagging(ctx, bucket, name, minio.GetObjectTaggingOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to read tags of %s: %w", path, err)
+		}
+		opts.UserTags = objectTags.ToMap()
+	}
+	_, err = replica.client.PutObject(ctx, replicaBucket, replicaName, obj, info.Size, opts)
+	if err != nil {
+		return fmt.Errorf("failed to write replica of %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReplicaDiff lists the objects that differ between the primary storage and its
+// replica. Objects are compared by path and size: ETags depend on how an object
+// was uploaded, so they are not comparable across endpoints.
+type ReplicaDiff struct {
+	PrimaryObjects int      `json:"primary_objects"`
+	ReplicaObjects int      `json:"replica_objects"`
+	Missing        []string `json:"missing"`   // on the primary only
+	Different      []string `json:"different"` // size differs
+	Extra          []string `json:"extra"`     // on the replica only
+}
+
+// DiffReplica compares all objects of m with those of replica
+func (m *MinIOStorage) DiffReplica(ctx context.Context, replica *MinIOStorage) (*ReplicaDiff, error) {
+	primaryObjects, err := m.ListAllObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := replica.ensureBucket(ctx, replica.bucket); err != nil {
+		return nil, err
+	}
+	replicaObjects, err := replica.ListAllObjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("replica: %w", err)
+	}
+
+	diff := &ReplicaDiff{
+		PrimaryObjects: len(primaryObjects),
+		ReplicaObjects: len(replicaObjects),
+		Missing:        []string{},
+		Different:      []string{},
+		Extra:          []string{},
+	}
+	sizes := make(map[string]int64, len(replicaObjects))
+	for _, o := range replicaObjects {
+		sizes[o.Key] = o.Size
+	}
+	for _, o := range primaryObjects {
+		size, ok := sizes[o.Key]
+		switch {
+		case !ok:
+			diff.Missing = append(diff.Missing, o.Key)
+		case size != o.Size:
+			diff.Different = append(diff.Different, o.Key)
+		}
+		delete(sizes, o.Key)
+	}
+	for key := range sizes {
+		diff.Extra = append(diff.Extra, key)
+	}
+	return diff, nil
+}
+
+func isNotFound(err error) bool {
+	var resp minio.ErrorResponse
+	return errors.As(err, &resp) && (resp.StatusCode == http.StatusNotFound || resp.Code == "NoSuchKey")
+}
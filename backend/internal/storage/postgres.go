@@ -3,99 +3,221 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
-	"github.com/lib/pq"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/kms"
 )
 
+// ErrFileNotFound is returned by GetFileMetadata for unknown file IDs
+var ErrFileNotFound = errors.New("file not found")
+
+// ErrPreconditionFailed is returned from a ModifyFile callback when the file changed
+// since the client read it (its ETag no longer matches)
+var ErrPreconditionFailed = errors.New("file was modified concurrently")
+
 type PostgresStore struct {
-	db *sql.DB
+	db          *sql.DB
+	fieldCipher *crypto.FieldCipher  // local KEK(s); nil = none configured
+	kms         kms.Service          // external master key; wraps data keys when set
+	kekID       string               // ID of the key new data keys are wrapped with; "" = unwrapped
+	blindIndex  *crypto.BlindIndexer // nil = file metadata stored in plaintext
+	dataKeys    *dataKeyCache        // briefly cached KMS-unwrapped data keys
+	metrics     *queryMetrics        // counts and times every query
+	downloads   *batchWriter[DownloadRecord]
+	transfers   *batchWriter[TransferRecord]
+	shareVisits *batchWriter[ShareVisit]
+	auditLogs   *batchWriter[AuditEntry]
 }
 
 type User struct {
-	ID            string    `json:"id"`
-	Username      string    `json:"username"`
-	Email         string    `json:"email"`
-	PasswordHash  string    `json:"password_hash"`
-	Role          string    `json:"role"`
-	IsActive      bool      `json:"is_active"`
-	AccountStatus string    `json:"account_status"` // 'pending', 'active', 'rejected', 'suspended'
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-}
-
-// NewPostgresStore creates a new PostgreSQL connection with connection pooling
-func NewPostgresStore(host, port, user, password, dbname string) (*PostgresStore, error) {
+	ID              string     `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	PasswordHash    string     `json:"password_hash"`
+	Role            string     `json:"role"`
+	IsActive        bool       `json:"is_active"`
+	AccountStatus   string     `json:"account_status"` // 'pending', 'active', 'rejected', 'suspended'
+	DisplayName     string     `json:"display_name,omitempty"`
+	PendingEmail    string     `json:"pending_email,omitempty"`     // awaiting verification
+	AvatarUpdatedAt *time.Time `json:"avatar_updated_at,omitempty"` // nil without an avatar
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Status is the effective account status: a deactivated account counts as
+// suspended whatever its account_status
+func (u *User) Status() string {
+	if !u.IsActive && (u.AccountStatus == "active" || u.AccountStatus == "") {
+		return "suspended"
+	}
+	return u.AccountStatus
+}
+
+// PostgresPool sizes the database connection pool
+type PostgresPool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// DBPoolStats is a snapshot of the database connection pool
+type DBPoolStats struct {
+	MaxOpenConns      int     `json:"max_open_conns"`
+	OpenConns         int     `json:"open_conns"`
+	InUse             int     `json:"in_use"`
+	Idle              int     `json:"idle"`
+	WaitCount         int64   `json:"wait_count"`
+	WaitDurationMs    float64 `json:"wait_duration_ms"`
+	MaxIdleClosed     int64   `json:"max_idle_closed"`
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`
+}
+
+// NewPostgresStore creates a new PostgreSQL connection pool sized by pool.
+// Queries slower than slowQueryThreshold are logged (0 = never).
+func NewPostgresStore(host, port, user, password, dbname string, pool PostgresPool, slowQueryThreshold time.Duration) (*PostgresStore, error) {
 	connStr := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		host, port, user, password, dbname,
 	)
 
-	db, err := sql.Open("postgres", connStr)
+	// pgx keeps statements prepared per connection (see statement for which), so
+	// hot queries are parsed and planned once instead of on every call
+	config, err := pgx.ParseConfig(connStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	connector := stdlib.GetConnector(*config)
+	metrics := &queryMetrics{
+		slowThreshold: slowQueryThreshold,
+		stats:         QueryStats{ByKind: make(map[string]QueryKindStats)},
+	}
+	db := sql.OpenDB(&instrumentedConnector{Connector: connector, metrics: metrics})
 
 	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &PostgresStore{db: db}, nil
+	p := &PostgresStore{db: db, metrics: metrics}
+	p.downloads = newBatchWriter("download events", p.writeDownloads)
+	p.transfers = newBatchWriter("transfer", p.writeTransfers)
+	p.shareVisits = newBatchWriter("share visits", p.writeShareVisits)
+	p.auditLogs = newBatchWriter("audit log entries", p.writeAuditLogs)
+	return p, nil
 }
 
-// Close closes the database connection
+// Close writes the pending download events, transfer, share visits and audit log
+// entries and closes the database connection
 func (p *PostgresStore) Close() error {
+	p.downloads.close()
+	p.transfers.close()
+	p.shareVisits.close()
+	p.auditLogs.close()
 	return p.db.Close()
 }
 
+// QueryStats returns the query counts and durations since startup
+func (p *PostgresStore) QueryStats() QueryStats {
+	return p.metrics.snapshot()
+}
+
+// PoolStats returns the current state of the connection pool
+func (p *PostgresStore) PoolStats() DBPoolStats {
+	s := p.db.Stats()
+	return DBPoolStats{
+		MaxOpenConns:      s.MaxOpenConnections,
+		OpenConns:         s.OpenConnections,
+		InUse:             s.InUse,
+		Idle:              s.Idle,
+		WaitCount:         s.WaitCount,
+		WaitDurationMs:    float64(s.WaitDuration) / float64(time.Millisecond),
+		MaxIdleClosed:     s.MaxIdleClosed,
+		MaxLifetimeClosed: s.MaxLifetimeClosed,
+	}
+}
+
+// SchemaVersion returns the applied migration version (from golang-migrate's
+// schema_migrations table) and whether it failed halfway
+func (p *PostgresStore) SchemaVersion(ctx context.Context) (version int64, dirty bool, err error) {
+	err = p.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// StringArray scans a Postgres array column into dst (nil for NULL)
+func StringArray(dst *[]string) sql.Scanner {
+	// A Map caches plans and is not safe for concurrent use; a new one is cheap
+	return pgtype.NewMap().SQLScanner(dst)
+}
+
 // DB returns the underlying *sql.DB for advanced queries when necessary
 func (p *PostgresStore) DB() *sql.DB {
 	return p.db
 }
 
-// VerifyPersonalAccessToken verifies a raw personal access token against stored bcrypt hashes.
-// Returns tokenID and userID on success, or sql.ErrNoRows if not found.
-func (p *PostgresStore) VerifyPersonalAccessToken(ctx context.Context, rawToken string) (string, string, error) {
-	rows, err := p.db.QueryContext(ctx, `SELECT id, user_id, token_hash FROM personal_access_tokens WHERE expires_at IS NULL OR expires_at > NOW()`)
+// PersonalAccessToken is a verified personal access token and the state of its owner
+type PersonalAccessToken struct {
+	ID         string
+	UserID     string
+	Scopes     []string
+	LastUsedAt *time.Time // before this use
+	UserActive bool
+}
+
+// VerifyPersonalAccessToken verifies a raw personal access token against the bcrypt
+// hash of the unexpired token with its lookup prefix, and records its use. Returns
+// sql.ErrNoRows if no token matches.
+func (p *PostgresStore) VerifyPersonalAccessToken(ctx context.Context, prefix, rawToken string) (*PersonalAccessToken, error) {
+	var t PersonalAccessToken
+	var thash string
+	var lastUsed sql.NullTime
+	err := p.db.QueryRowContext(ctx, `
+		SELECT t.id, t.user_id, t.token_hash, t.scopes, t.last_used_at, u.is_active
+		FROM personal_access_tokens t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.token_prefix = $1
+		  AND (t.expires_at IS NULL OR t.expires_at > NOW())`, prefix).
+		Scan(&t.ID, &t.UserID, &thash, StringArray(&t.Scopes), &lastUsed, &t.UserActive)
 	if err != nil {
-		log.Printf("[store] VerifyPAT query error: %v", err)
-		return "", "", err
+		return nil, err
 	}
-	defer func() { _ = rows.Close() }()
-	count := 0
-	for rows.Next() {
-		count++
-		var id string
-		var uid string
-		var thash string
-		if err := rows.Scan(&id, &uid, &thash); err != nil {
-			log.Printf("[store] VerifyPAT scan error: %v", err)
-			continue
-		}
-		if bcrypt.CompareHashAndPassword([]byte(thash), []byte(rawToken)) == nil {
-			// update last_used_at (best-effort)
-			if _, err := p.db.ExecContext(ctx, `UPDATE personal_access_tokens SET last_used_at = $1 WHERE id = $2`, time.Now().UTC(), id); err != nil {
-				log.Printf("[store] failed to update last_used_at for id=%s: %v", id, err)
-			}
-			log.Printf("[store] VerifyPAT matched id=%s user=%s (scanned=%d)", id, uid, count)
-			return id, uid, nil
-		}
+
+	if bcrypt.CompareHashAndPassword([]byte(thash), []byte(rawToken)) != nil {
+		return nil, sql.ErrNoRows
+	}
+	if lastUsed.Valid {
+		t.LastUsedAt = &lastUsed.Time
 	}
-	log.Printf("[store] VerifyPAT no match (scanned=%d)", count)
-	return "", "", sql.ErrNoRows
+	// update last_used_at (best-effort)
+	if _, err := p.db.ExecContext(ctx, `UPDATE personal_access_tokens SET last_used_at = $1 WHERE id = $2`, time.Now().UTC(), t.ID); err != nil {
+		log.Printf("[store] failed to update last_used_at for id=%s: %v", t.ID, err)
+	}
+	return &t, nil
 }
 
 // =====================================================
@@ -150,7 +272,8 @@ func (p *PostgresStore) CreateUser(ctx context.Context, username, email, passwor
 // GetUserByUsername retrieves a user by username
 func (p *PostgresStore) GetUserByUsername(ctx context.Context, username string) (*User, error) {
 	query := `
-		SELECT id, username, email, password_hash, role, is_active, account_status, created_at, updated_at
+		SELECT id, username, email, password_hash, role, is_active, account_status,
+			COALESCE(display_name, ''), COALESCE(pending_email, ''), avatar_updated_at, created_at, updated_at
 		FROM users
 		WHERE username = $1
 	`
@@ -164,6 +287,9 @@ func (p *PostgresStore) GetUserByUsername(ctx context.Context, username string)
 		&user.Role,
 		&user.IsActive,
 		&user.AccountStatus,
+		&user.DisplayName,
+		&user.PendingEmail,
+		&user.AvatarUpdatedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -180,13 +306,14 @@ func (p *PostgresStore) GetUserByUsername(ctx context.Context, username string)
 // GetUserByID retrieves a user by ID
 func (p *PostgresStore) GetUserByID(ctx context.Context, userID string) (*User, error) {
 	query := `
-		SELECT id, username, email, password_hash, role, is_active, account_status, created_at, updated_at
+		SELECT id, username, email, password_hash, role, is_active, account_status,
+			COALESCE(display_name, ''), COALESCE(pending_email, ''), avatar_updated_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 
 	var user User
-	err := p.db.QueryRowContext(ctx, query, userID).Scan(
+	err := p.db.QueryRowContext(hotPath(ctx), query, userID).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
@@ -194,12 +321,15 @@ func (p *PostgresStore) GetUserByID(ctx context.Context, userID string) (*User,
 		&user.Role,
 		&user.IsActive,
 		&user.AccountStatus,
+		&user.DisplayName,
+		&user.PendingEmail,
+		&user.AvatarUpdatedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("user not found: %s", userID)
+		return nil, fmt.Errorf("user not found: %s: %w", userID, sql.ErrNoRows)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
@@ -250,6 +380,112 @@ func (p *PostgresStore) UpdateUserPassword(ctx context.Context, userID, newPassw
 // FILE OPERATIONS
 // =====================================================
 
+// fileColumns is the column list shared by every query that returns FileMetadata.
+// Keep it in sync with scanFileMetadata.
+const fileColumns = `id, user_id, file_name, description, mime_type,
+		       size, encrypted_size, minio_path, encryption_key,
+		       created_at, expires_at, download_count, tags, password_hash, updated_at, folder,
+		       starred_at, last_downloaded_at, sha256, deleted_at, modified_at,
+		       quarantined_at, quarantine_reason`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanFileMetadata scans a single files row selected with fileColumns
+func scanFileMetadata(row rowScanner) (*FileMetadata, error) {
+	var metadata FileMetadata
+	var description sql.NullString
+	var expiresAt sql.NullTime
+	var passwordHash sql.NullString
+	var starredAt, lastDownloadedAt, deletedAt, modifiedAt, quarantinedAt sql.NullTime
+	var sha, quarantineReason sql.NullString
+
+	err := row.Scan(
+		&metadata.FileID,
+		&metadata.UserID,
+		&metadata.FileName,
+		&description,
+		&metadata.MimeType,
+		&metadata.Size,
+		&metadata.EncryptedSize,
+		&metadata.MinIOPath,
+		&metadata.EncryptionKey,
+		&metadata.CreatedAt,
+		&expiresAt,
+		&metadata.DownloadCount,
+		StringArray(&metadata.Tags),
+		&passwordHash,
+		&metadata.UpdatedAt,
+		&metadata.Folder,
+		&starredAt,
+		&lastDownloadedAt,
+		&sha,
+		&deletedAt,
+		&modifiedAt,
+		&quarantinedAt,
+		&quarantineReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle nullable fields
+	if description.Valid {
+		metadata.Description = description.String
+	}
+	if expiresAt.Valid {
+		metadata.ExpiresAt = &expiresAt.Time
+	}
+	if passwordHash.Valid {
+		metadata.PasswordHash = passwordHash.String
+	}
+	if starredAt.Valid {
+		metadata.StarredAt = &starredAt.Time
+	}
+	if lastDownloadedAt.Valid {
+		metadata.LastDownloadedAt = &lastDownloadedAt.Time
+	}
+	metadata.SHA256 = sha.String
+	if deletedAt.Valid {
+		metadata.DeletedAt = &deletedAt.Time
+	}
+	if modifiedAt.Valid {
+		metadata.ModifiedAt = &modifiedAt.Time
+	}
+	if quarantinedAt.Valid {
+		metadata.QuarantinedAt = &quarantinedAt.Time
+	}
+	metadata.QuarantineReason = quarantineReason.String
+
+	return &metadata, nil
+}
+
+// queryFiles runs a query selecting fileColumns and scans every row
+func (p *PostgresStore) queryFiles(ctx context.Context, query string, args ...interface{}) ([]*FileMetadata, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var files []*FileMetadata
+	for rows.Next() {
+		metadata, err := p.scanFile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan file: %w", err)
+		}
+		files = append(files, metadata)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating files: %w", err)
+	}
+
+	return files, nil
+}
+
 // SaveFileMetadata saves file metadata to the database
 func (p *PostgresStore) SaveFileMetadata(ctx context.Context, metadata *FileMetadata) error {
 	log.Printf("[DEBUG] SaveFileMetadata: FileID=%s, UserID=%s, FileName=%s, Tags=%v",
@@ -259,24 +495,46 @@ func (p *PostgresStore) SaveFileMetadata(ctx context.Context, metadata *FileMeta
 		INSERT INTO files (
 			id, user_id, file_name, description, mime_type, 
 			size, encrypted_size, minio_path, encryption_key, 
-			created_at, expires_at, download_count, tags
-		) VALUES ($1::uuid, $2::uuid, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			created_at, expires_at, download_count, tags, password_hash, updated_at, folder,
+			name_index, name_prefix_index, tag_index, kek_id, sha256, modified_at,
+			quarantined_at, quarantine_reason
+		) VALUES ($1::uuid, $2::uuid, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, ''), $10, COALESCE(NULLIF($15, ''), '/'),
+			$16, $17, $18, $19, NULLIF($20, ''), $21, $22, NULLIF($23, ''))
 	`
 
-	_, err := p.db.ExecContext(ctx, query,
+	sealed, err := p.sealFileFields(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt file metadata: %w", err)
+	}
+	encryptionKey, kekID, err := p.wrapDataKey(ctx, metadata.FileID, metadata.EncryptionKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.db.ExecContext(ctx, query,
 		metadata.FileID,
 		metadata.UserID,
-		metadata.FileName,
-		metadata.Description,
+		sealed.name,
+		sealed.description,
 		metadata.MimeType,
 		metadata.Size,
 		metadata.EncryptedSize,
 		metadata.MinIOPath,
-		metadata.EncryptionKey,
+		encryptionKey,
 		metadata.CreatedAt,
 		metadata.ExpiresAt,
 		metadata.DownloadCount,
-		pq.Array(metadata.Tags),
+		sealed.tags,
+		metadata.PasswordHash,
+		metadata.Folder,
+		sealed.nameIndex,
+		sealed.namePrefixIndex,
+		sealed.tagIndex,
+		kekID,
+		metadata.SHA256,
+		metadata.ModifiedAt,
+		metadata.QuarantinedAt,
+		metadata.QuarantineReason,
 	)
 
 	if err != nil {
@@ -289,63 +547,57 @@ func (p *PostgresStore) SaveFileMetadata(ctx context.Context, metadata *FileMeta
 	return nil
 }
 
-// GetFileMetadata retrieves file metadata by file ID
+// GetFileMetadata retrieves file metadata by file ID. Files in the trash are not found.
 func (p *PostgresStore) GetFileMetadata(ctx context.Context, fileID string) (*FileMetadata, error) {
-	query := `
-		SELECT id, user_id, file_name, description, mime_type,
-		       size, encrypted_size, minio_path, encryption_key,
-		       created_at, expires_at, download_count, tags
-		FROM files
-		WHERE id = $1
-	`
-
-	var metadata FileMetadata
-	var description sql.NullString
-	var expiresAt sql.NullTime
-
-	err := p.db.QueryRowContext(ctx, query, fileID).Scan(
-		&metadata.FileID,
-		&metadata.UserID,
-		&metadata.FileName,
-		&description,
-		&metadata.MimeType,
-		&metadata.Size,
-		&metadata.EncryptedSize,
-		&metadata.MinIOPath,
-		&metadata.EncryptionKey,
-		&metadata.CreatedAt,
-		&expiresAt,
-		&metadata.DownloadCount,
-		pq.Array(&metadata.Tags),
-	)
+	query := `SELECT ` + fileColumns + ` FROM files WHERE id = $1 AND deleted_at IS NULL`
 
+	metadata, err := p.scanFile(p.db.QueryRowContext(hotPath(ctx), query, fileID))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("file not found: %s", fileID)
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, fileID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file metadata: %w", err)
 	}
 
-	// Handle nullable fields
-	if description.Valid {
-		metadata.Description = description.String
+	return metadata, nil
+}
+
+// FindFileByChecksum returns the newest live (not trashed or expired) file of a
+// user with the given plaintext SHA-256 and size. Returns ErrFileNotFound when
+// the user has no such content.
+func (p *PostgresStore) FindFileByChecksum(ctx context.Context, userID, sha256 string, size int64) (*FileMetadata, error) {
+	query := `SELECT ` + fileColumns + ` FROM files
+		WHERE user_id = $1 AND sha256 = $2 AND size = $3
+		  AND deleted_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	metadata, err := p.scanFile(p.db.QueryRowContext(ctx, query, userID, sha256, size))
+	if err == sql.ErrNoRows {
+		return nil, ErrFileNotFound
 	}
-	if expiresAt.Valid {
-		metadata.ExpiresAt = &expiresAt.Time
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file by checksum: %w", err)
 	}
 
-	return &metadata, nil
+	return metadata, nil
 }
 
 // UpdateFileMetadata updates file metadata (for description/tags changes)
 func (p *PostgresStore) UpdateFileMetadata(ctx context.Context, fileID, description string, tags []string) error {
 	query := `
 		UPDATE files
-		SET description = $1, tags = $2
-		WHERE id = $3
+		SET description = $1, tags = $2, tag_index = $3
+		WHERE id = $4
 	`
 
-	result, err := p.db.ExecContext(ctx, query, description, pq.Array(tags), fileID)
+	sealed, err := p.sealFileFields(&FileMetadata{FileID: fileID, Description: description, Tags: tags})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt file metadata: %w", err)
+	}
+
+	result, err := p.db.ExecContext(ctx, query, sealed.description, sealed.tags, sealed.tagIndex, fileID)
 	if err != nil {
 		return fmt.Errorf("failed to update file metadata: %w", err)
 	}
@@ -362,129 +614,111 @@ func (p *PostgresStore) UpdateFileMetadata(ctx context.Context, fileID, descript
 	return nil
 }
 
-// ListUserFiles retrieves all files for a user
-func (p *PostgresStore) ListUserFiles(ctx context.Context, userID string) ([]*FileMetadata, error) {
+// SetFilePassword stores the download password hash for a file.
+// An empty hash removes the password.
+func (p *PostgresStore) SetFilePassword(ctx context.Context, fileID, passwordHash string) error {
 	query := `
-		SELECT id, user_id, file_name, description, mime_type,
-		       size, encrypted_size, minio_path, encryption_key,
-		       created_at, expires_at, download_count, tags
-		FROM files
-		WHERE user_id = $1
-		ORDER BY created_at DESC
+		UPDATE files
+		SET password_hash = NULLIF($1, '')
+		WHERE id = $2
 	`
 
-	rows, err := p.db.QueryContext(ctx, query, userID)
+	result, err := p.db.ExecContext(ctx, query, passwordHash, fileID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list files: %w", err)
+		return fmt.Errorf("failed to update file password: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
 
-	var files []*FileMetadata
-	for rows.Next() {
-		var metadata FileMetadata
-		var description sql.NullString
-		var expiresAt sql.NullTime
-
-		err := rows.Scan(
-			&metadata.FileID,
-			&metadata.UserID,
-			&metadata.FileName,
-			&description,
-			&metadata.MimeType,
-			&metadata.Size,
-			&metadata.EncryptedSize,
-			&metadata.MinIOPath,
-			&metadata.EncryptionKey,
-			&metadata.CreatedAt,
-			&expiresAt,
-			&metadata.DownloadCount,
-			pq.Array(&metadata.Tags),
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan file: %w", err)
-		}
-
-		// Handle nullable fields
-		if description.Valid {
-			metadata.Description = description.String
-		}
-		if expiresAt.Valid {
-			metadata.ExpiresAt = &expiresAt.Time
-		}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
 
-		files = append(files, &metadata)
+	if rows == 0 {
+		return fmt.Errorf("file not found: %s", fileID)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating files: %w", err)
+	return nil
+}
+
+// ListUserFiles retrieves the files of a user that are not in the trash
+func (p *PostgresStore) ListUserFiles(ctx context.Context, userID string) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	files, err := p.queryFiles(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
 	}
 
 	return files, nil
 }
 
-// SearchFiles searches files by filename or tags
-func (p *PostgresStore) SearchFiles(ctx context.Context, userID, query string) ([]*FileMetadata, error) {
-	sqlQuery := `
-		SELECT id, user_id, file_name, description, mime_type,
-		       size, encrypted_size, minio_path, encryption_key,
-		       created_at, expires_at, download_count, tags
+// ListUserFilesIncludingTrash retrieves all files of a user, those in the trash
+// included, for cleanups that must reach every stored object (e.g. deleting the user)
+func (p *PostgresStore) ListUserFilesIncludingTrash(ctx context.Context, userID string) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
 		FROM files
-		WHERE user_id = $1 AND (
-			file_name ILIKE $2 OR
-			description ILIKE $2 OR
-			$3 = ANY(tags)
-		)
+		WHERE user_id = $1
 		ORDER BY created_at DESC
 	`
 
-	searchPattern := "%" + query + "%"
-	rows, err := p.db.QueryContext(ctx, sqlQuery, userID, searchPattern, query)
+	files, err := p.queryFiles(ctx, query, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search files: %w", err)
+		return nil, fmt.Errorf("failed to list files: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
 
-	var files []*FileMetadata
-	for rows.Next() {
-		var metadata FileMetadata
-		var description sql.NullString
-		var expiresAt sql.NullTime
-
-		err := rows.Scan(
-			&metadata.FileID,
-			&metadata.UserID,
-			&metadata.FileName,
-			&description,
-			&metadata.MimeType,
-			&metadata.Size,
-			&metadata.EncryptedSize,
-			&metadata.MinIOPath,
-			&metadata.EncryptionKey,
-			&metadata.CreatedAt,
-			&expiresAt,
-			&metadata.DownloadCount,
-			pq.Array(&metadata.Tags),
-		)
+	return files, nil
+}
+
+// SearchFiles searches a user's unexpired files by filename, description or tag,
+// newest first. Like ListActiveFilesPage it returns the page after the cursor (all
+// matches for a limit of 0) and the cursor of the next page, nil on the last one.
+func (p *PostgresStore) SearchFiles(ctx context.Context, userID, query string, after *Cursor, limit int) ([]*FileMetadata, *Cursor, error) {
+	// Encrypted columns cannot be matched in SQL; use the blind indexes instead
+	if p.MetadataEncrypted() {
+		files, err := p.searchEncryptedFiles(ctx, userID, query, after)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan file: %w", err)
+			return nil, nil, fmt.Errorf("failed to search files: %w", err)
 		}
+		files, next := filePage(files, limit)
+		return files, next, nil
+	}
 
-		// Handle nullable fields
-		if description.Valid {
-			metadata.Description = description.String
-		}
-		if expiresAt.Valid {
-			metadata.ExpiresAt = &expiresAt.Time
-		}
+	sqlQuery := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1 AND deleted_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		  AND (
+			file_name ILIKE $2 OR
+			description ILIKE $2 OR
+			tags @> ARRAY[$3]
+		  )`
+	searchPattern := "%" + query + "%"
+	args := []interface{}{userID, searchPattern, query}
 
-		files = append(files, &metadata)
+	if after != nil {
+		sqlQuery += ` AND (created_at, id) < ($4, $5)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	sqlQuery += `
+		ORDER BY created_at DESC, id DESC`
+	if limit > 0 {
+		sqlQuery += fmt.Sprintf(` LIMIT %d`, limit+1)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating files: %w", err)
+	files, err := p.queryFiles(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search files: %w", err)
 	}
 
-	return files, nil
+	files, next := filePage(files, limit)
+	return files, next, nil
 }
 
 // DeleteFileMetadata deletes file metadata
@@ -508,78 +742,326 @@ func (p *PostgresStore) DeleteFileMetadata(ctx context.Context, fileID string) e
 	return nil
 }
 
-// IncrementDownloadCount increments the download counter for a file
-func (p *PostgresStore) IncrementDownloadCount(ctx context.Context, fileID string) error {
+// DownloadRecord describes one completed download
+type DownloadRecord struct {
+	FileID    string
+	UserID    string // downloading user; empty for anonymous share downloads
+	ShareID   string // share link the file was served through, if any
+	IPAddress string
+	Bytes     int64
+}
+
+// RecordDownload stores a download row and bumps the file's download counter.
+// Concurrent downloads are recorded in batches, each in a single statement that
+// also bumps the counters, so the counters always match the recorded rows.
+func (p *PostgresStore) RecordDownload(ctx context.Context, rec DownloadRecord) error {
+	return p.downloads.add(ctx, rec)
+}
+
+func (p *PostgresStore) writeDownloads(ctx context.Context, recs []DownloadRecord) error {
 	query := `
+		WITH recorded AS (
+			INSERT INTO file_downloads (file_id, user_id, share_id, ip_address, bytes)
+			VALUES ` + valuesList(len(recs), "$", "NULLIF($, '')::uuid", "NULLIF($, '')::uuid", "NULLIF($, '')", "$::bigint") + `
+			RETURNING file_id
+		)
 		UPDATE files
-		SET download_count = download_count + 1
-		WHERE id = $1
+		SET download_count = download_count + counts.n, last_downloaded_at = CURRENT_TIMESTAMP
+		FROM (SELECT file_id, COUNT(*) AS n FROM recorded GROUP BY file_id) counts
+		WHERE files.id = counts.file_id
 	`
 
-	_, err := p.db.ExecContext(ctx, query, fileID)
+	args := make([]interface{}, 0, 5*len(recs))
+	for _, rec := range recs {
+		args = append(args, rec.FileID, rec.UserID, rec.ShareID, rec.IPAddress, rec.Bytes)
+	}
+	if _, err := p.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record downloads: %w", err)
+	}
+
+	return nil
+}
+
+// AuditEntry is one row of the audit log
+type AuditEntry struct {
+	ActorID    string // empty for anonymous requests (e.g. IP_BLOCKED)
+	Action     string
+	TargetType string
+	TargetID   string // IDs that are not UUIDs (e.g. snapshots) are kept in Metadata["target_id"]
+	Metadata   []byte // JSON
+	IPAddress  string
+}
+
+// LogAudit stores an audit log entry; concurrent entries are written in batches
+func (p *PostgresStore) LogAudit(ctx context.Context, entry AuditEntry) error {
+	return p.auditLogs.add(ctx, entry)
+}
+
+func (p *PostgresStore) writeAuditLogs(ctx context.Context, entries []AuditEntry) error {
+	query := `
+		INSERT INTO audit_logs (actor_id, action, target_type, target_id, metadata, ip_address)
+		VALUES ` + valuesList(len(entries), "NULLIF($, '')::uuid", "$", "$", "NULLIF($, '')::uuid", "$::jsonb", "$")
+
+	args := make([]interface{}, 0, 6*len(entries))
+	for _, e := range entries {
+		if e.TargetID != "" && uuid.Validate(e.TargetID) != nil {
+			// target_id is a UUID column; one bad ID must not fail the whole batch
+			e.Metadata = withTargetID(e.Metadata, e.TargetID)
+			e.TargetID = ""
+		}
+		args = append(args, e.ActorID, e.Action, e.TargetType, e.TargetID, e.Metadata, e.IPAddress)
+	}
+	if _, err := p.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// withTargetID adds target_id to the JSON object metadata
+func withTargetID(metadata []byte, targetID string) []byte {
+	fields := map[string]json.RawMessage{}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &fields); err != nil {
+			log.Printf("[store] Audit metadata is not a JSON object, replacing it: %v", err)
+			fields = map[string]json.RawMessage{}
+		}
+	}
+	id, _ := json.Marshal(targetID)
+	fields["target_id"] = id
+	out, _ := json.Marshal(fields)
+	return out
+}
+
+// SetFileStarred stars or unstars a file owned by userID.
+// Returns sql.ErrNoRows when the user has no such file.
+func (p *PostgresStore) SetFileStarred(ctx context.Context, fileID, userID string, starred bool) error {
+	query := `
+		UPDATE files
+		SET starred_at = CASE WHEN $1 THEN COALESCE(starred_at, CURRENT_TIMESTAMP) END
+		WHERE id = $2 AND user_id = $3
+	`
+
+	result, err := p.db.ExecContext(ctx, query, starred, fileID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to increment download count: %w", err)
+		return fmt.Errorf("failed to update star: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
 	}
 
 	return nil
 }
 
+// ListStarredFiles retrieves a user's unexpired starred files, most recently starred first
+func (p *PostgresStore) ListStarredFiles(ctx context.Context, userID string) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1
+		  AND deleted_at IS NULL
+		  AND starred_at IS NOT NULL
+		  AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		ORDER BY starred_at DESC
+	`
+
+	files, err := p.queryFiles(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list starred files: %w", err)
+	}
+
+	return files, nil
+}
+
+// ListRecentFiles retrieves a user's unexpired files ordered by their latest
+// upload or download, newest first
+func (p *PostgresStore) ListRecentFiles(ctx context.Context, userID string, limit int) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1
+		  AND deleted_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		ORDER BY GREATEST(created_at, COALESCE(last_downloaded_at, created_at)) DESC
+		LIMIT $2
+	`
+
+	files, err := p.queryFiles(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent files: %w", err)
+	}
+
+	return files, nil
+}
+
 // GetExpiredFiles retrieves all files that have expired
 func (p *PostgresStore) GetExpiredFiles(ctx context.Context) ([]*FileMetadata, error) {
 	query := `
-		SELECT id, user_id, file_name, description, mime_type,
-		       size, encrypted_size, minio_path, encryption_key,
-		       created_at, expires_at, download_count, tags
+		SELECT ` + fileColumns + `
 		FROM files
 		WHERE expires_at IS NOT NULL AND expires_at < CURRENT_TIMESTAMP
 		ORDER BY expires_at ASC
 	`
 
-	rows, err := p.db.QueryContext(ctx, query)
+	files, err := p.queryFiles(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get expired files: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
 
-	var files []*FileMetadata
-	for rows.Next() {
-		var metadata FileMetadata
-		var description sql.NullString
-		var expiresAt sql.NullTime
-
-		err := rows.Scan(
-			&metadata.FileID,
-			&metadata.UserID,
-			&metadata.FileName,
-			&description,
-			&metadata.MimeType,
-			&metadata.Size,
-			&metadata.EncryptedSize,
-			&metadata.MinIOPath,
-			&metadata.EncryptionKey,
-			&metadata.CreatedAt,
-			&expiresAt,
-			&metadata.DownloadCount,
-			pq.Array(&metadata.Tags),
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan file: %w", err)
-		}
+	return files, nil
+}
 
-		// Handle nullable fields
-		if description.Valid {
-			metadata.Description = description.String
-		}
-		if expiresAt.Valid {
-			metadata.ExpiresAt = &expiresAt.Time
-		}
+// UpdateFileExpiry sets or clears (nil) the expiration of a file.
+// The expiry warning flag is reset so the owner is warned again for the new date.
+func (p *PostgresStore) UpdateFileExpiry(ctx context.Context, fileID string, expiresAt *time.Time) error {
+	query := `
+		UPDATE files
+		SET expires_at = $1, expiry_warned_at = NULL
+		WHERE id = $2
+	`
 
-		files = append(files, &metadata)
+	result, err := p.db.ExecContext(ctx, query, expiresAt, fileID)
+	if err != nil {
+		return fmt.Errorf("failed to update file expiry: %w", err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating files: %w", err)
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("file not found: %s", fileID)
+	}
+
+	return nil
+}
+
+// ModifyFile applies a read-modify-write change to a file atomically. The row is
+// locked, passed to modify, and the editable fields (file_name, description, tags,
+// expires_at, folder, modified_at) are written back; an error from modify aborts without changes.
+// Returns the updated metadata, or sql.ErrNoRows when the file does not exist (or is in the trash).
+func (p *PostgresStore) ModifyFile(ctx context.Context, fileID string, modify func(*FileMetadata) error) (*FileMetadata, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	metadata, err := p.scanFile(tx.QueryRowContext(ctx,
+		`SELECT `+fileColumns+` FROM files WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, fileID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := modify(metadata); err != nil {
+		return nil, err
+	}
+
+	// A changed expiry gets a fresh expiry warning
+	query := `
+		UPDATE files
+		SET file_name = $1, description = $2, tags = $3, folder = $5,
+		    name_index = $7, name_prefix_index = $8, tag_index = $9,
+		    expiry_warned_at = CASE WHEN expires_at IS DISTINCT FROM $4 THEN NULL ELSE expiry_warned_at END,
+		    expires_at = $4, modified_at = $10
+		WHERE id = $6
+		RETURNING ` + fileColumns
+
+	sealed, err := p.sealFileFields(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt file metadata: %w", err)
+	}
+
+	updated, err := p.scanFile(tx.QueryRowContext(ctx, query,
+		sealed.name, sealed.description, sealed.tags,
+		metadata.ExpiresAt, metadata.Folder, fileID,
+		sealed.nameIndex, sealed.namePrefixIndex, sealed.tagIndex, metadata.ModifiedAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update file: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit file update: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ListExpiringFiles retrieves a user's files that have not expired yet but will before the cutoff
+func (p *PostgresStore) ListExpiringFiles(ctx context.Context, userID string, cutoff time.Time) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1
+		  AND deleted_at IS NULL
+		  AND expires_at IS NOT NULL
+		  AND expires_at > CURRENT_TIMESTAMP
+		  AND expires_at <= $2
+		ORDER BY expires_at ASC
+	`
+
+	files, err := p.queryFiles(ctx, query, userID, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiring files: %w", err)
 	}
 
 	return files, nil
 }
+
+// GetFilesPendingExpiryWarning retrieves files expiring before the cutoff whose owners have not been warned yet
+func (p *PostgresStore) GetFilesPendingExpiryWarning(ctx context.Context, cutoff time.Time) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE expires_at IS NOT NULL
+		  AND expires_at > CURRENT_TIMESTAMP
+		  AND expires_at <= $1
+		  AND expiry_warned_at IS NULL
+		  AND deleted_at IS NULL
+		ORDER BY user_id, expires_at ASC
+	`
+
+	files, err := p.queryFiles(ctx, query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files pending expiry warning: %w", err)
+	}
+
+	return files, nil
+}
+
+// MarkExpiryWarned records that the owners of the given files have been warned
+func (p *PostgresStore) MarkExpiryWarned(ctx context.Context, fileIDs []string) error {
+	query := `UPDATE files SET expiry_warned_at = CURRENT_TIMESTAMP WHERE id = ANY($1::uuid[])`
+
+	if _, err := p.db.ExecContext(ctx, query, fileIDs); err != nil {
+		return fmt.Errorf("failed to mark expiry warned: %w", err)
+	}
+
+	return nil
+}
+
+// =====================================================
+// ANNOUNCEMENT OPERATIONS
+// =====================================================
+
+// CreateSystemAnnouncement creates an announcement with no human author, targeted at specific users
+func (p *PostgresStore) CreateSystemAnnouncement(ctx context.Context, title, message, annType string, userIDs []string, expiresAt *time.Time) (string, error) {
+	query := `
+		INSERT INTO announcements (title, message, type, target_type, target_user_ids, expires_at, created_by)
+		VALUES ($1, $2, $3, 'specific_users', $4, $5, NULL)
+		RETURNING id
+	`
+
+	var id string
+	if err := p.db.QueryRowContext(ctx, query, title, message, annType, userIDs, expiresAt).Scan(&id); err != nil {
+		return "", fmt.Errorf("failed to create system announcement: %w", err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// NotificationPreferences choose which notices a user gets
+type NotificationPreferences struct {
+	ShareDisabled bool `json:"share_disabled"` // announcement when a share link is disabled automatically
+	StorageQuota  bool `json:"storage_quota"`  // email when nearing the storage quota (the announcement is always made)
+}
+
+// UserPreferences are a user's settings; clients use timezone and locale, the
+// server applies the defaults for uploads and file listings
+type UserPreferences struct {
+	Timezone           string                  `json:"timezone"`             // IANA name
+	Locale             string                  `json:"locale"`               // BCP 47 tag
+	DefaultExpiryHours int                     `json:"default_expiry_hours"` // uploads without expire_after; 0 = never
+	PageSize           int                     `json:"page_size"`            // file listings without a limit; 0 = everything
+	Notifications      NotificationPreferences `json:"notifications"`
+}
+
+// DefaultUserPreferences are the preferences of users who never set any
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{
+		Timezone:      "UTC",
+		Locale:        "en",
+		Notifications: NotificationPreferences{ShareDisabled: true, StorageQuota: true},
+	}
+}
+
+// GetUserPreferences returns a user's preferences, or the defaults when they have
+// not set any. Notification settings missing from the stored row keep their default.
+func (p *PostgresStore) GetUserPreferences(ctx context.Context, userID string) (UserPreferences, error) {
+	prefs := DefaultUserPreferences()
+	var notifications []byte
+	err := p.db.QueryRowContext(hotPath(ctx), `
+		SELECT timezone, locale, default_expiry_hours, page_size, notifications
+		FROM user_preferences WHERE user_id = $1`, userID).Scan(
+		&prefs.Timezone, &prefs.Locale, &prefs.DefaultExpiryHours, &prefs.PageSize, &notifications)
+	if err == sql.ErrNoRows {
+		return prefs, nil
+	}
+	if err != nil {
+		return prefs, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	if err := json.Unmarshal(notifications, &prefs.Notifications); err != nil {
+		return prefs, fmt.Errorf("failed to decode notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// SaveUserPreferences stores a user's preferences, replacing earlier ones
+func (p *PostgresStore) SaveUserPreferences(ctx context.Context, userID string, prefs UserPreferences) error {
+	notifications, err := json.Marshal(prefs.Notifications)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification preferences: %w", err)
+	}
+
+	_, err = p.db.ExecContext(ctx, `
+		INSERT INTO user_preferences (user_id, timezone, locale, default_expiry_hours, page_size, notifications, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone, locale = EXCLUDED.locale,
+		    default_expiry_hours = EXCLUDED.default_expiry_hours, page_size = EXCLUDED.page_size,
+		    notifications = EXCLUDED.notifications, updated_at = NOW()`,
+		userID, prefs.Timezone, prefs.Locale, prefs.DefaultExpiryHours, prefs.PageSize, notifications)
+	if err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
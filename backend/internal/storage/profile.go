@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrUsernameTaken means another user has the username
+	ErrUsernameTaken = errors.New("username already exists")
+	// ErrEmailTaken means another user has the email address (compared case-insensitively)
+	ErrEmailTaken = errors.New("email address already in use")
+	// ErrVerificationInvalid means the email verification code is wrong, expired or
+	// was already used
+	ErrVerificationInvalid = errors.New("invalid or expired verification code")
+)
+
+// ProfileUpdate lists the profile fields to change; nil fields are kept
+type ProfileUpdate struct {
+	Username    *string
+	Email       *string
+	DisplayName *string
+}
+
+// queryRower is a *sql.DB or *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// emailInUse reports whether a user other than userID has email
+func emailInUse(ctx context.Context, q queryRower, email, userID string) (bool, error) {
+	var exists bool
+	err := q.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = LOWER($1) AND id <> $2)`, email, userID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check email: %w", err)
+	}
+	return exists, nil
+}
+
+// UpdateUserProfile changes profile fields without verification (display names,
+// and admin overrides). Setting the email drops a pending email change. Returns
+// ErrUsernameTaken, ErrEmailTaken, or sql.ErrNoRows for an unknown user.
+func (p *PostgresStore) UpdateUserProfile(ctx context.Context, userID string, update ProfileUpdate) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var sets []string
+	var args []interface{}
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Username != nil {
+		var exists bool
+		err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS(SELECT 1 FROM users WHERE username = $1 AND id <> $2)`, *update.Username, userID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check username: %w", err)
+		}
+		if exists {
+			return ErrUsernameTaken
+		}
+		set("username", *update.Username)
+	}
+	if update.Email != nil {
+		taken, err := emailInUse(ctx, tx, *update.Email, userID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrEmailTaken
+		}
+		set("email", *update.Email)
+		sets = append(sets, "pending_email = NULL", "email_verification_hash = NULL", "email_verification_expires_at = NULL")
+	}
+	if update.DisplayName != nil {
+		set("display_name", sql.NullString{String: *update.DisplayName, Valid: *update.DisplayName != ""})
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	args = append(args, userID)
+	query := fmt.Sprintf(`UPDATE users SET %s, updated_at = NOW() WHERE id = $%d`, strings.Join(sets, ", "), len(args))
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrUsernameTaken // a concurrent rename won the unique index
+		}
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit profile: %w", err)
+	}
+	return nil
+}
+
+// RequestEmailChange records email as the user's pending email until it is
+// confirmed with the code whose hash is codeHash, or expiresAt passes. A new
+// request replaces an earlier one. Returns ErrEmailTaken when another user has it.
+func (p *PostgresStore) RequestEmailChange(ctx context.Context, userID, email, codeHash string, expiresAt time.Time) error {
+	taken, err := emailInUse(ctx, p.db, email, userID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrEmailTaken
+	}
+
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE users
+		SET pending_email = $1, email_verification_hash = $2, email_verification_expires_at = $3, updated_at = NOW()
+		WHERE id = $4`, email, codeHash, expiresAt.UTC(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to record email change: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ConfirmEmailChange makes the pending email the user's email if codeHash matches
+// an unexpired code, and returns it. Returns ErrVerificationInvalid or
+// ErrEmailTaken (claimed by another user since the request).
+func (p *PostgresStore) ConfirmEmailChange(ctx context.Context, userID, codeHash string) (string, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var pendingEmail, storedHash sql.NullString
+	var expiresAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT pending_email, email_verification_hash, email_verification_expires_at
+		FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&pendingEmail, &storedHash, &expiresAt)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pending email: %w", err)
+	}
+	if !pendingEmail.Valid || !storedHash.Valid || !expiresAt.Valid || time.Now().After(expiresAt.Time) ||
+		subtle.ConstantTimeCompare([]byte(storedHash.String), []byte(codeHash)) != 1 {
+		return "", ErrVerificationInvalid
+	}
+
+	taken, err := emailInUse(ctx, tx, pendingEmail.String, userID)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return "", ErrEmailTaken
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users
+		SET email = pending_email, pending_email = NULL, email_verification_hash = NULL,
+		    email_verification_expires_at = NULL, updated_at = NOW()
+		WHERE id = $1`, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to change email: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit email change: %w", err)
+	}
+	return pendingEmail.String, nil
+}
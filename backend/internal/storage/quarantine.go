@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// QuarantineFile holds a file back for review with the reason (the rule that
+// matched). A file already quarantined keeps its original time and reason.
+func (p *PostgresStore) QuarantineFile(ctx context.Context, fileID, reason string) error {
+	_, err := p.db.ExecContext(ctx, `
+		UPDATE files
+		SET quarantined_at = CURRENT_TIMESTAMP, quarantine_reason = $2
+		WHERE id = $1 AND quarantined_at IS NULL
+	`, fileID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to quarantine file: %w", err)
+	}
+	return nil
+}
+
+// ReleaseFile takes a file out of quarantine. Returns ErrFileNotFound when no
+// such file is quarantined.
+func (p *PostgresStore) ReleaseFile(ctx context.Context, fileID string) (*FileMetadata, error) {
+	metadata, err := p.scanFile(p.db.QueryRowContext(ctx, `
+		UPDATE files
+		SET quarantined_at = NULL, quarantine_reason = NULL
+		WHERE id = $1 AND quarantined_at IS NOT NULL AND deleted_at IS NULL
+		RETURNING `+fileColumns, fileID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, fileID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to release file: %w", err)
+	}
+	return metadata, nil
+}
+
+// GetQuarantinedFile retrieves a quarantined file by file ID
+func (p *PostgresStore) GetQuarantinedFile(ctx context.Context, fileID string) (*FileMetadata, error) {
+	query := `SELECT ` + fileColumns + ` FROM files WHERE id = $1 AND quarantined_at IS NOT NULL AND deleted_at IS NULL`
+
+	metadata, err := p.scanFile(p.db.QueryRowContext(ctx, query, fileID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, fileID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// ListQuarantinedFiles returns the files awaiting review, oldest first
+func (p *PostgresStore) ListQuarantinedFiles(ctx context.Context) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE quarantined_at IS NOT NULL AND deleted_at IS NULL
+		ORDER BY quarantined_at ASC
+	`
+
+	files, err := p.queryFiles(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined files: %w", err)
+	}
+	return files, nil
+}
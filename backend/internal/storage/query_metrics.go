@@ -0,0 +1,273 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryStats summarises the database queries run since startup, by statement kind
+// ("select", "insert", "update", "delete", "other")
+type QueryStats struct {
+	Queries     int64                     `json:"queries"`
+	Errors      int64                     `json:"errors"`
+	SlowQueries int64                     `json:"slow_queries"`
+	ByKind      map[string]QueryKindStats `json:"by_kind"`
+}
+
+// QueryKindStats counts and times the queries of one statement kind. For reads the
+// duration runs until the first rows arrive, not until they have all been scanned.
+type QueryKindStats struct {
+	Count           int64   `json:"count"`
+	Errors          int64   `json:"errors"`
+	TotalDurationMs float64 `json:"total_duration_ms"`
+	MaxDurationMs   float64 `json:"max_duration_ms"`
+}
+
+// queryMetrics times every query sent through an instrumented connection and logs
+// the slow ones
+type queryMetrics struct {
+	slowThreshold time.Duration // 0 = no slow-query log
+
+	mu    sync.Mutex
+	stats QueryStats
+}
+
+func (m *queryMetrics) observe(ctx context.Context, query string, args int, took time.Duration, err error) {
+	kind := statementKind(query)
+	slow := m.slowThreshold > 0 && took >= m.slowThreshold
+
+	m.mu.Lock()
+	m.stats.Queries++
+	k := m.stats.ByKind[kind]
+	k.Count++
+	if err != nil {
+		m.stats.Errors++
+		k.Errors++
+	}
+	if slow {
+		m.stats.SlowQueries++
+	}
+	ms := float64(took) / float64(time.Millisecond)
+	k.TotalDurationMs += ms
+	if ms > k.MaxDurationMs {
+		k.MaxDurationMs = ms
+	}
+	m.stats.ByKind[kind] = k
+	m.mu.Unlock()
+
+	if slow {
+		// Queries use placeholders, so only the argument count is logged, never values
+		requestID := middleware.GetReqID(ctx)
+		if requestID == "" {
+			requestID = "-"
+		}
+		log.Printf("[WARN] Slow query (%s, request %s, %d args redacted): %s",
+			took.Round(time.Millisecond), requestID, args, strings.Join(strings.Fields(query), " "))
+	}
+}
+
+func (m *queryMetrics) snapshot() QueryStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.stats
+	s.ByKind = make(map[string]QueryKindStats, len(m.stats.ByKind))
+	for kind, k := range m.stats.ByKind {
+		s.ByKind[kind] = k
+	}
+	return s
+}
+
+// statementKind returns the lower-cased leading keyword of a query
+func statementKind(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "other"
+	}
+	switch kind := strings.ToLower(fields[0]); kind {
+	case "select", "insert", "update", "delete":
+		return kind
+	case "with":
+		return "select"
+	default:
+		return "other"
+	}
+}
+
+// tagQuery appends the request ID of ctx as an SQL comment, so the query can be
+// matched to its request in pg_stat_activity and the Postgres logs. Characters that
+// could end the comment are dropped, since request IDs may come from clients.
+func tagQuery(ctx context.Context, query string) string {
+	requestID := middleware.GetReqID(ctx)
+	if requestID == "" {
+		return query
+	}
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune("-_./:", r):
+			return r
+		default:
+			return -1
+		}
+	}, requestID)
+	return query + " /* request_id=" + safe + " */"
+}
+
+type hotPathKey struct{}
+
+// hotPath marks the queries run with ctx as hot paths (file and user lookups on
+// every request): they are sent without the request ID tag, so their text is the
+// same for every request and pgx keeps them prepared on each connection
+func hotPath(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hotPathKey{}, true)
+}
+
+// statement returns the text and arguments to send for query. A query tagged with
+// a request ID is unique to its request, so it is run without being added to the
+// per-connection statement cache (as a one-off prepared statement); others are
+// prepared once per connection and reused.
+func statement(ctx context.Context, query string, args []driver.NamedValue) (string, []driver.NamedValue) {
+	if ctx.Value(hotPathKey{}) != nil {
+		return query, args
+	}
+	tagged := tagQuery(ctx, query)
+	if tagged == query || len(args) == 0 {
+		return tagged, args
+	}
+	return tagged, append([]driver.NamedValue{{Value: pgx.QueryExecModeDescribeExec}}, args...)
+}
+
+// instrumentedConnector hands out connections whose queries are timed and tagged
+type instrumentedConnector struct {
+	driver.Connector
+	metrics *queryMetrics
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn, metrics: c.metrics}, nil
+}
+
+// instrumentedConn wraps a pgx connection. database/sql runs queries, including
+// those in transactions, through QueryContext and ExecContext.
+type instrumentedConn struct {
+	driver.Conn
+	metrics *queryMetrics
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	sent, sentArgs := statement(ctx, query, args)
+	rows, err := queryer.QueryContext(ctx, sent, sentArgs)
+	if err != driver.ErrSkip {
+		c.metrics.observe(ctx, query, len(args), time.Since(start), err)
+	}
+	return rows, err
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	sent, sentArgs := statement(ctx, query, args)
+	result, err := execer.ExecContext(ctx, sent, sentArgs)
+	if err != driver.ErrSkip {
+		c.metrics.observe(ctx, query, len(args), time.Since(start), err)
+	}
+	return result, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedStmt{Stmt: stmt, query: query, metrics: c.metrics}, nil
+}
+
+// CheckNamedValue lets arguments through as pgx takes them (e.g. slices as arrays)
+func (c *instrumentedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// instrumentedStmt times the executions of a prepared statement. Its text is fixed
+// when it is prepared, so executions are not tagged with request IDs.
+type instrumentedStmt struct {
+	driver.Stmt
+	query   string
+	metrics *queryMetrics
+}
+
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, args)
+	s.metrics.observe(ctx, s.query, len(args), time.Since(start), err)
+	return rows, err
+}
+
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, args)
+	s.metrics.observe(ctx, s.query, len(args), time.Since(start), err)
+	return result, err
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestStatement(t *testing.T) {
+	const query = "SELECT 1 WHERE $1"
+	args := []driver.NamedValue{{Ordinal: 1, Value: true}}
+
+	request := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		args   []driver.NamedValue
+		sent   string
+		oneOff bool // run without the statement cache
+	}{
+		{"no request", context.Background(), args, query, false},
+		{"request", request, args, query + " /* request_id=req-1 */", true},
+		{"request, no arguments", request, nil, query + " /* request_id=req-1 */", false},
+		{"hot path in a request", hotPath(request), args, query, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent, sentArgs := statement(tt.ctx, query, tt.args)
+			if sent != tt.sent {
+				t.Errorf("query = %q, want %q", sent, tt.sent)
+			}
+			oneOff := len(sentArgs) > 0 && sentArgs[0].Value == pgx.QueryExecModeDescribeExec
+			if oneOff != tt.oneOff {
+				t.Errorf("one-off = %v, want %v (args %v)", oneOff, tt.oneOff, sentArgs)
+			}
+			if n := len(sentArgs); oneOff && n != len(tt.args)+1 || !oneOff && n != len(tt.args) {
+				t.Errorf("sent %d args for %d", n, len(tt.args))
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SettingQuotaGraceDays is how many days a user may stay over the storage quota
+// before their uploads are refused: "off" (the default) never refuses them, 0
+// refuses them as soon as the quota is exceeded
+const SettingQuotaGraceDays = "storage_quota_grace_days"
+
+// Quota enforcement states
+const (
+	QuotaOK      = "ok"      // within the quota
+	QuotaGrace   = "grace"   // over the quota; uploads still work until the grace period ends
+	QuotaBlocked = "blocked" // over the quota past the grace period: uploads are refused
+)
+
+// QuotaPolicy is the per-user storage quota and how it is enforced
+type QuotaPolicy struct {
+	QuotaBytes int64 // 0 = no quota
+	Enforced   bool  // uploads are refused once the grace period is over
+	Grace      time.Duration
+}
+
+// QuotaStatus is a user's storage against the quota
+type QuotaStatus struct {
+	State       string     `json:"state"`
+	QuotaBytes  int64      `json:"quota_bytes"`
+	UsedBytes   int64      `json:"used_bytes"` // trash included
+	OverSince   *time.Time `json:"over_since,omitempty"`
+	GraceEndsAt *time.Time `json:"grace_ends_at,omitempty"` // unset while uploads are never refused
+}
+
+// Status judges used bytes against the policy for a user over the quota since
+// overSince (nil if not recorded yet: from now). Nil without a quota.
+func (p QuotaPolicy) Status(used int64, overSince *time.Time, now time.Time) *QuotaStatus {
+	if p.QuotaBytes <= 0 {
+		return nil
+	}
+	status := &QuotaStatus{State: QuotaOK, QuotaBytes: p.QuotaBytes, UsedBytes: used}
+	if used <= p.QuotaBytes {
+		return status
+	}
+
+	since := now
+	if overSince != nil {
+		since = *overSince
+	}
+	status.State, status.OverSince = QuotaGrace, &since
+	if p.Enforced {
+		ends := since.Add(p.Grace)
+		status.GraceEndsAt = &ends
+		if !now.Before(ends) {
+			status.State = QuotaBlocked
+		}
+	}
+	return status
+}
+
+// GetQuotaPolicy reads the quota and grace period settings
+func (p *PostgresStore) GetQuotaPolicy(ctx context.Context) (QuotaPolicy, error) {
+	quota, err := p.GetStorageQuota(ctx)
+	if err != nil {
+		return QuotaPolicy{}, err
+	}
+	policy := QuotaPolicy{QuotaBytes: quota}
+
+	var value string
+	err = p.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = $1`, SettingQuotaGraceDays).Scan(&value)
+	if err == sql.ErrNoRows || value == "" || value == "off" {
+		return policy, nil
+	}
+	if err != nil {
+		return QuotaPolicy{}, fmt.Errorf("failed to get quota grace period: %w", err)
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return QuotaPolicy{}, fmt.Errorf("invalid %s setting %q", SettingQuotaGraceDays, value)
+	}
+	policy.Enforced, policy.Grace = true, time.Duration(days)*24*time.Hour
+	return policy, nil
+}
+
+// UpdateQuotaStatus measures a user's storage against the policy and records
+// when they went over the quota, or clears it once they are back under. entered
+// reports whether this call found them over it first, so that they are warned
+// once. The status is nil without a quota.
+func (p *PostgresStore) UpdateQuotaStatus(ctx context.Context, userID string, policy QuotaPolicy) (status *QuotaStatus, entered bool, err error) {
+	var used int64
+	var overSince sql.NullTime
+	err = p.db.QueryRowContext(ctx, `
+		SELECT COALESCE((SELECT SUM(size) FROM files WHERE user_id = u.id), 0), u.over_quota_since
+		FROM users u
+		WHERE u.id = $1
+	`, userID).Scan(&used, &overSince)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+
+	now := time.Now()
+	over := policy.QuotaBytes > 0 && used > policy.QuotaBytes
+	switch {
+	case over && !overSince.Valid:
+		// The first replica to see it starts the grace period
+		result, err := p.db.ExecContext(ctx, `
+			UPDATE users SET over_quota_since = $2 WHERE id = $1 AND over_quota_since IS NULL
+		`, userID, now)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to record quota overrun: %w", err)
+		}
+		n, _ := result.RowsAffected()
+		entered = n == 1
+		overSince = sql.NullTime{Time: now, Valid: true}
+	case !over && overSince.Valid:
+		if _, err := p.db.ExecContext(ctx, `UPDATE users SET over_quota_since = NULL WHERE id = $1`, userID); err != nil {
+			return nil, false, fmt.Errorf("failed to clear quota overrun: %w", err)
+		}
+		overSince = sql.NullTime{}
+	}
+
+	var since *time.Time
+	if overSince.Valid {
+		since = &overSince.Time
+	}
+	return policy.Status(used, since, now), entered, nil
+}
+
+// SyncQuotaOverruns records the users who went over quota bytes since the last
+// call and returns them; users back under it (all of them when quota is 0) are
+// cleared
+func (p *PostgresStore) SyncQuotaOverruns(ctx context.Context, quota int64) ([]UserUsage, error) {
+	if _, err := p.db.ExecContext(ctx, `
+		UPDATE users u SET over_quota_since = NULL
+		WHERE u.over_quota_since IS NOT NULL
+		  AND ($1::bigint = 0 OR COALESCE((SELECT SUM(size) FROM files WHERE user_id = u.id), 0) <= $1::bigint)
+	`, quota); err != nil {
+		return nil, fmt.Errorf("failed to clear quota overruns: %w", err)
+	}
+	if quota <= 0 {
+		return nil, nil
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+		WITH usage AS (
+			SELECT user_id, SUM(size) AS bytes FROM files GROUP BY user_id HAVING SUM(size) > $1
+		)
+		UPDATE users u SET over_quota_since = NOW()
+		FROM usage
+		WHERE u.id = usage.user_id AND u.over_quota_since IS NULL AND u.role <> 'service'
+		RETURNING u.id, u.username, u.email, usage.bytes
+	`, quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record quota overruns: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var users []UserUsage
+	for rows.Next() {
+		var u UserUsage
+		if err := rows.Scan(&u.UserID, &u.Username, &u.Email, &u.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan quota overrun: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
@@ -2,7 +2,11 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -30,24 +34,82 @@ type FileMetadata struct {
 	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
 	Tags          []string   `json:"tags,omitempty"`
 	DownloadCount int        `json:"download_count"`
+	PasswordHash  string     `json:"-"` // argon2id hash; empty when the file has no download password
+	UpdatedAt     time.Time  `json:"updated_at"`
+	Folder        string     `json:"folder"` // virtual folder path, "/" for the root
+
+	StarredAt        *time.Time `json:"starred_at,omitempty"`
+	LastDownloadedAt *time.Time `json:"last_downloaded_at,omitempty"`
+	SHA256           string     `json:"sha256,omitempty"`      // hex digest of the plaintext; empty for older files
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`  // set while the file is in the trash
+	ModifiedAt       *time.Time `json:"modified_at,omitempty"` // set by the client, e.g. the local mtime; nil if not given
+
+	QuarantinedAt    *time.Time `json:"quarantined_at,omitempty"` // set while held back for review by an admin
+	QuarantineReason string     `json:"quarantine_reason,omitempty"`
+}
+
+// ETag identifies a file's content and its metadata. Stored content is immutable
+// and every metadata change moves updated_at, so the ID plus updated_at is enough.
+func (m *FileMetadata) ETag() string {
+	return fmt.Sprintf(`"%s-%x"`, m.FileID, m.UpdatedAt.UnixNano())
 }
 
-func NewRedisCache(addr, password string, db int) (*RedisCache, error) {
+// RedisPool sizes the Redis connection pool; zero fields keep go-redis defaults
+// (10 connections per CPU, no idle minimum, pool timeout of read timeout + 1s)
+type RedisPool struct {
+	PoolSize     int
+	MinIdleConns int
+	PoolTimeout  time.Duration
+}
+
+// RedisPoolStats is a snapshot of the Redis connection pool
+type RedisPoolStats struct {
+	TotalConns uint32 `json:"total_conns"`
+	IdleConns  uint32 `json:"idle_conns"`
+	StaleConns uint32 `json:"stale_conns"`
+	Hits       uint32 `json:"hits"`     // a free connection was found
+	Misses     uint32 `json:"misses"`   // a new connection had to be opened
+	Timeouts   uint32 `json:"timeouts"` // waiting for a connection timed out
+}
+
+func NewRedisCache(addr, password string, db int, pool RedisPool) (*RedisCache, error) {
 	rdb := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: password,
-		DB:       db,
+		Addr:         addr,
+		Password:     password,
+		DB:           db,
+		PoolSize:     pool.PoolSize,
+		MinIdleConns: pool.MinIdleConns,
+		PoolTimeout:  pool.PoolTimeout,
 	})
 
 	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		_ = rdb.Close()
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
 	return &RedisCache{client: rdb}, nil
 }
 
+// PoolStats returns the current state of the connection pool
+func (r *RedisCache) PoolStats() RedisPoolStats {
+	s := r.client.PoolStats()
+	return RedisPoolStats{
+		TotalConns: s.TotalConns,
+		IdleConns:  s.IdleConns,
+		StaleConns: s.StaleConns,
+		Hits:       s.Hits,
+		Misses:     s.Misses,
+		Timeouts:   s.Timeouts,
+	}
+}
+
 // Basic key-value operations
 
+// Ping checks that Redis answers
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 func (r *RedisCache) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
@@ -64,6 +126,78 @@ func (r *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
 	return result > 0, nil
 }
 
+// =====================================================
+// FILE METADATA CACHE (POSTGRES IS THE SOURCE OF TRUTH)
+// =====================================================
+
+// fileMetadataTTL bounds how long a missed invalidation can serve stale metadata
+const fileMetadataTTL = 10 * time.Minute
+
+func fileMetadataKey(fileID string) string {
+	return "filemeta:" + fileID
+}
+
+// cachedFileMetadata carries the fields FileMetadata hides from JSON
+type cachedFileMetadata struct {
+	*FileMetadata
+	PasswordHash string `json:"password_hash,omitempty"`
+}
+
+// CacheFileMetadata stores a copy of a file's metadata. Callers must call
+// InvalidateFileMetadata whenever the row changes or is deleted.
+func (r *RedisCache) CacheFileMetadata(ctx context.Context, metadata *FileMetadata) error {
+	data, err := json.Marshal(cachedFileMetadata{FileMetadata: metadata, PasswordHash: metadata.PasswordHash})
+	if err != nil {
+		return fmt.Errorf("failed to encode file metadata: %w", err)
+	}
+	return r.client.Set(ctx, fileMetadataKey(metadata.FileID), data, fileMetadataTTL).Err()
+}
+
+// GetCachedFileMetadata returns cached metadata, or redis.Nil on a cache miss
+func (r *RedisCache) GetCachedFileMetadata(ctx context.Context, fileID string) (*FileMetadata, error) {
+	data, err := r.client.Get(ctx, fileMetadataKey(fileID)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	cached := cachedFileMetadata{FileMetadata: &FileMetadata{}}
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to decode cached file metadata: %w", err)
+	}
+	cached.FileMetadata.PasswordHash = cached.PasswordHash
+	return cached.FileMetadata, nil
+}
+
+// InvalidateFileMetadata drops cached metadata for the given files
+func (r *RedisCache) InvalidateFileMetadata(ctx context.Context, fileIDs ...string) error {
+	if len(fileIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, len(fileIDs))
+	for i, id := range fileIDs {
+		keys[i] = fileMetadataKey(id)
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// LoadFileMetadata reads file metadata through the cache, falling back to PostgreSQL
+// and populating the cache on a miss. A Redis outage degrades to direct reads.
+func LoadFileMetadata(ctx context.Context, cache *RedisCache, pg *PostgresStore, fileID string) (*FileMetadata, error) {
+	if metadata, err := cache.GetCachedFileMetadata(ctx, fileID); err == nil {
+		return metadata, nil
+	}
+
+	metadata, err := pg.GetFileMetadata(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cache.CacheFileMetadata(ctx, metadata); err != nil {
+		log.Printf("[WARN] Failed to cache metadata for file %s: %v", fileID, err)
+	}
+	return metadata, nil
+}
+
 // =====================================================
 // RATE LIMITING (EPHEMERAL - STAYS IN REDIS)
 // =====================================================
@@ -83,60 +217,511 @@ func (r *RedisCache) SetRateLimit(ctx context.Context, userID string, currentWin
 	return r.client.Set(ctx, rateLimitKey, value, expiration).Err()
 }
 
+// IncrShareHits counts requests to a share link in the current window and returns the count.
+// Used to detect download spikes on public links.
+func (r *RedisCache) IncrShareHits(ctx context.Context, shareID string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf("sharehits:%s:%d", shareID, time.Now().Unix()/int64(window.Seconds()))
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to increment share hits: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+// IncrShareReports counts the share reports filed from ip in the current window
+// and returns the count
+func (r *RedisCache) IncrShareReports(ctx context.Context, ip string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf("sharereports:%s:%d", ip, time.Now().Unix()/int64(window.Seconds()))
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to count share reports: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+// RecordTokenFailure counts an invalid token presented by ip for scope (share links,
+// tickets, ...) within window. Once max failures are reached ip is locked out of scope
+// for lockout. Returns the failure count.
+func (r *RedisCache) RecordTokenFailure(ctx context.Context, scope, ip string, max int, window, lockout time.Duration) (int64, error) {
+	key := "tokenfail:" + scope + ":" + ip
+
+	count, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count token failure: %w", err)
+	}
+	if count == 1 {
+		// The window starts with the first failure
+		if err := r.client.Expire(ctx, key, window).Err(); err != nil {
+			return 0, fmt.Errorf("failed to count token failure: %w", err)
+		}
+	}
+
+	if count >= int64(max) {
+		pipe := r.client.TxPipeline()
+		pipe.Set(ctx, "tokenlock:"+scope+":"+ip, "1", lockout)
+		pipe.Del(ctx, key)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return 0, fmt.Errorf("failed to lock out %s: %w", ip, err)
+		}
+	}
+	return count, nil
+}
+
+// TokenLockout returns how much longer ip is locked out of scope (0 if it is not)
+func (r *RedisCache) TokenLockout(ctx context.Context, scope, ip string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, "tokenlock:"+scope+":"+ip).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check token lockout: %w", err)
+	}
+	if ttl < 0 {
+		// -2: no lockout; -1 cannot happen (always set with a TTL)
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// ipAccessKey caches the IP access settings for all replicas
+const ipAccessKey = "settings:ip_access"
+
+// CachedIPAccessRules returns the cached IP access rules, or nil if none are cached
+func (r *RedisCache) CachedIPAccessRules(ctx context.Context) (*IPAccessRules, error) {
+	data, err := r.client.Get(ctx, ipAccessKey).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached IP access rules: %w", err)
+	}
+	var rules IPAccessRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to decode cached IP access rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// CacheIPAccessRules caches the IP access rules for ttl
+func (r *RedisCache) CacheIPAccessRules(ctx context.Context, rules IPAccessRules, ttl time.Duration) error {
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("failed to encode IP access rules: %w", err)
+	}
+	return r.client.Set(ctx, ipAccessKey, data, ttl).Err()
+}
+
+// InvalidateIPAccessRules drops the cached IP access rules after they changed
+func (r *RedisCache) InvalidateIPAccessRules(ctx context.Context) error {
+	return r.client.Del(ctx, ipAccessKey).Err()
+}
+
+// contentStatsKey caches the instance-wide content statistics for all replicas
+const contentStatsKey = "stats:content_types"
+
+// CachedContentStats returns the cached content statistics, or nil if none are cached
+func (r *RedisCache) CachedContentStats(ctx context.Context) (*ContentStats, error) {
+	data, err := r.client.Get(ctx, contentStatsKey).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached content stats: %w", err)
+	}
+	var stats ContentStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode cached content stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// CacheContentStats caches the content statistics for ttl
+func (r *RedisCache) CacheContentStats(ctx context.Context, stats *ContentStats, ttl time.Duration) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to encode content stats: %w", err)
+	}
+	return r.client.Set(ctx, contentStatsKey, data, ttl).Err()
+}
+
+// FirstIPBlock reports whether ip was not blocked within interval, so a blocked
+// client is audited once per interval instead of on every request
+func (r *RedisCache) FirstIPBlock(ctx context.Context, ip string, interval time.Duration) (bool, error) {
+	first, err := r.client.SetNX(ctx, "ipblocked:"+ip, "1", interval).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to record blocked address: %w", err)
+	}
+	return first, nil
+}
+
+// =====================================================
+// ACCOUNT STATUS CACHE
+// =====================================================
+
+func accountStatusKey(userID string) string {
+	return "account_status:" + userID
+}
+
+// CachedAccountStatus returns the cached status of a user, or "" if none is cached
+func (r *RedisCache) CachedAccountStatus(ctx context.Context, userID string) (string, error) {
+	status, err := r.client.Get(ctx, accountStatusKey(userID)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get cached account status: %w", err)
+	}
+	return status, nil
+}
+
+// CacheAccountStatus caches the status of a user for ttl
+func (r *RedisCache) CacheAccountStatus(ctx context.Context, userID, status string, ttl time.Duration) error {
+	return r.client.Set(ctx, accountStatusKey(userID), status, ttl).Err()
+}
+
+// InvalidateAccountStatus drops the cached status of a user after it changed
+func (r *RedisCache) InvalidateAccountStatus(ctx context.Context, userID string) error {
+	return r.client.Del(ctx, accountStatusKey(userID)).Err()
+}
+
+// =====================================================
+// ACCESS TICKETS (EPHEMERAL - STAYS IN REDIS)
+// =====================================================
+
+// AccessTicket authorizes download or streaming of one file without the session token
+type AccessTicket struct {
+	FileID  string `json:"file_id"`
+	UserID  string `json:"user_id"`
+	Purpose string `json:"purpose"` // "download" or "stream"
+	Version int64  `json:"version"` // file updated_at (UnixNano) when issued
+}
+
+func (r *RedisCache) saveTicket(ctx context.Context, key string, ticket AccessTicket, ttl time.Duration) error {
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return fmt.Errorf("failed to encode ticket: %w", err)
+	}
+	return r.client.Set(ctx, key, data, ttl).Err()
+}
+
+func decodeTicket(data []byte) (*AccessTicket, error) {
+	var ticket AccessTicket
+	if err := json.Unmarshal(data, &ticket); err != nil {
+		return nil, fmt.Errorf("failed to decode ticket: %w", err)
+	}
+	return &ticket, nil
+}
+
+// SaveAccessTicket stores a one-time ticket
+func (r *RedisCache) SaveAccessTicket(ctx context.Context, token string, ticket AccessTicket, ttl time.Duration) error {
+	return r.saveTicket(ctx, "ticket:"+token, ticket, ttl)
+}
+
+// ConsumeAccessTicket returns and deletes a ticket in one step, so it can be used
+// only once. Returns redis.Nil when the ticket does not exist (or was already used).
+func (r *RedisCache) ConsumeAccessTicket(ctx context.Context, token string) (*AccessTicket, error) {
+	data, err := r.client.GetDel(ctx, "ticket:"+token).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return decodeTicket(data)
+}
+
+// SaveStreamGrant stores the grant a redeemed stream ticket turns into. Media players
+// issue many range requests for one playback; they present the grant as a cookie.
+func (r *RedisCache) SaveStreamGrant(ctx context.Context, token string, ticket AccessTicket, ttl time.Duration) error {
+	return r.saveTicket(ctx, "streamgrant:"+token, ticket, ttl)
+}
+
+// GetStreamGrant returns a stream grant, or redis.Nil when it does not exist
+func (r *RedisCache) GetStreamGrant(ctx context.Context, token string) (*AccessTicket, error) {
+	data, err := r.client.Get(ctx, "streamgrant:"+token).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return decodeTicket(data)
+}
+
 // =====================================================
 // SESSION MANAGEMENT (EPHEMERAL - STAYS IN REDIS)
 // =====================================================
 
-// SaveSession stores a JWT session token
-func (r *RedisCache) SaveSession(ctx context.Context, token, userID string, expiration time.Duration) error {
-	return r.client.Set(ctx, "session:"+token, userID, expiration).Err()
+// Session is a login. Its access tokens name it (sid claim) and are refused once it
+// is gone; its refresh token is stored hashed and replaced on every refresh.
+type Session struct {
+	ID          string
+	UserID      string
+	RefreshHash string
+	CreatedAt   time.Time
 }
 
-// GetSession retrieves the userID for a given session token
-func (r *RedisCache) GetSession(ctx context.Context, token string) (string, error) {
-	return r.client.Get(ctx, "session:"+token).Result()
+// ErrSessionNotFound means a session expired, was logged out or was revoked
+var ErrSessionNotFound = errors.New("session not found")
+
+func sessionKey(id string) string {
+	return "session:" + id
 }
 
-// DeleteSession removes a session token
-func (r *RedisCache) DeleteSession(ctx context.Context, token string) error {
-	return r.client.Del(ctx, "session:"+token).Err()
+// userSessionsKey is the set of a user's session IDs, so a user's sessions are
+// found without scanning every session. Members may outlive their session (it
+// expired); readers skip and prune them. The set expires with the user's last
+// session.
+func userSessionsKey(userID string) string {
+	return "user_sessions:" + userID
 }
 
-// DeleteUserSessions removes all sessions for a specific user
-func (r *RedisCache) DeleteUserSessions(ctx context.Context, userID string) (int, error) {
-	// Scan for all session keys
+// usedRefreshHashes is how many rotated-out refresh token hashes a session keeps
+// to recognise a reused token; older ones are forgotten
+const usedRefreshHashes = 16
+
+// rotateRefreshScript swaps the refresh token hash of a session when the presented
+// one is current, so each refresh token works once, and prepends the old hash to
+// the space-separated used_hashes (cut to ARGV[4] bytes, whole hashes only). It
+// extends the session and its user's session set (KEYS[2]). It returns 1 after a
+// rotation, 2 when the presented hash is a used one and 0 otherwise.
+var rotateRefreshScript = redis.NewScript(`
+local used = redis.call("HGET", KEYS[1], "used_hashes") or ""
+if redis.call("HGET", KEYS[1], "refresh_hash") == ARGV[1] then
+	if used ~= "" then used = " " .. used end
+	used = string.sub(ARGV[1] .. used, 1, tonumber(ARGV[4]))
+	redis.call("HSET", KEYS[1], "refresh_hash", ARGV[2], "used_hashes", used)
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+	redis.call("PEXPIRE", KEYS[2], ARGV[3])
+	return 1
+end
+if ARGV[1] ~= "" and string.find(" " .. used .. " ", " " .. ARGV[1] .. " ", 1, true) then
+	return 2
+end
+return 0`)
+
+// SaveSession stores a session for expiration
+func (r *RedisCache) SaveSession(ctx context.Context, s Session, expiration time.Duration) error {
+	key := sessionKey(s.ID)
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, "user_id", s.UserID, "refresh_hash", s.RefreshHash, "created_at", s.CreatedAt.UTC().Format(time.RFC3339))
+	pipe.PExpire(ctx, key, expiration)
+	// Sessions only ever end sooner than the newest one, so the set lives as long
+	pipe.SAdd(ctx, userSessionsKey(s.UserID), s.ID)
+	pipe.PExpire(ctx, userSessionsKey(s.UserID), expiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetSession retrieves a session, or ErrSessionNotFound once it expired or was
+// revoked
+func (r *RedisCache) GetSession(ctx context.Context, id string) (*Session, error) {
+	fields, err := r.client.HGetAll(ctx, sessionKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return sessionFromHash(id, fields)
+}
+
+func sessionFromHash(id string, fields map[string]string) (*Session, error) {
+	if fields["user_id"] == "" {
+		return nil, ErrSessionNotFound
+	}
+	createdAt, _ := time.Parse(time.RFC3339, fields["created_at"])
+	return &Session{ID: id, UserID: fields["user_id"], RefreshHash: fields["refresh_hash"], CreatedAt: createdAt}, nil
+}
+
+// RotateRefreshToken replaces the refresh token hash of a session and extends it
+// by expiration. When oldHash is not the current hash it returns rotated false,
+// and reused true if oldHash belongs to a refresh token of the session that was
+// rotated out already (the token was used twice).
+func (r *RedisCache) RotateRefreshToken(ctx context.Context, s *Session, oldHash, newHash string, expiration time.Duration) (rotated, reused bool, err error) {
+	keys := []string{sessionKey(s.ID), userSessionsKey(s.UserID)}
+	maxUsed := usedRefreshHashes*(len(oldHash)+1) - 1
+	n, err := rotateRefreshScript.Run(ctx, r.client, keys, oldHash, newHash, expiration.Milliseconds(), maxUsed).Int()
+	if err != nil {
+		return false, false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	return n == 1, n == 2, nil
+}
+
+// DeleteSession removes a session, revoking its refresh token and access tokens
+func (r *RedisCache) DeleteSession(ctx context.Context, id string) error {
+	userID, err := r.client.HGet(ctx, sessionKey(id), "user_id").Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, sessionKey(id))
+	pipe.SRem(ctx, userSessionsKey(userID), id)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeAccessToken denylists an access token by its jti until it would have
+// expired anyway
+func (r *RedisCache) RevokeAccessToken(ctx context.Context, jti string, expiration time.Duration) error {
+	if expiration <= 0 {
+		return nil
+	}
+	return r.client.Set(ctx, "revoked:"+jti, "1", expiration).Err()
+}
+
+// AccessTokenRevoked reports whether the access token jti is denylisted
+func (r *RedisCache) AccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	n, err := r.client.Exists(ctx, "revoked:"+jti).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return n > 0, nil
+}
+
+// SessionInfo describes a session without its refresh token
+type SessionInfo struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ListSessions returns every live session
+func (r *RedisCache) ListSessions(ctx context.Context) ([]SessionInfo, error) {
+	var sessions []SessionInfo
 	var cursor uint64
-	var keys []string
-	pattern := "session:*"
+	now := time.Now()
 
 	for {
-		var scannedKeys []string
-		var err error
-		scannedKeys, cursor, err = r.client.Scan(ctx, cursor, pattern, 100).Result()
+		keys, next, err := r.client.Scan(ctx, cursor, "session:*", 100).Result()
 		if err != nil {
-			return 0, err
+			return nil, fmt.Errorf("failed to scan sessions: %w", err)
 		}
 
-		// Check each key's value to see if it matches the userID
-		for _, key := range scannedKeys {
-			val, err := r.client.Get(ctx, key).Result()
-			if err == nil && val == userID {
-				keys = append(keys, key)
+		if len(keys) > 0 {
+			pipe := r.client.Pipeline()
+			gets := make([]*redis.MapStringStringCmd, len(keys))
+			ttls := make([]*redis.DurationCmd, len(keys))
+			for i, key := range keys {
+				gets[i] = pipe.HGetAll(ctx, key)
+				ttls[i] = pipe.PTTL(ctx, key)
+			}
+			if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+				return nil, fmt.Errorf("failed to read sessions: %w", err)
+			}
+			for i, key := range keys {
+				s, err := sessionFromHash(strings.TrimPrefix(key, "session:"), gets[i].Val())
+				if err != nil {
+					continue // expired since the scan
+				}
+				sessions = append(sessions, SessionInfo{
+					ID:        s.ID,
+					UserID:    s.UserID,
+					CreatedAt: s.CreatedAt,
+					ExpiresAt: now.Add(ttls[i].Val()),
+				})
 			}
 		}
 
+		cursor = next
 		if cursor == 0 {
 			break
 		}
 	}
+	return sessions, nil
+}
 
-	// Delete all matching keys
-	if len(keys) > 0 {
-		err := r.client.Del(ctx, keys...).Err()
-		if err != nil {
-			return 0, err
+// DeleteAllSessions removes every session, logging everyone out
+func (r *RedisCache) DeleteAllSessions(ctx context.Context) (int, error) {
+	count := 0
+	for _, pattern := range []string{"session:*", "user_sessions:*"} {
+		var cursor uint64
+		for {
+			keys, next, err := r.client.Scan(ctx, cursor, pattern, 100).Result()
+			if err != nil {
+				return count, fmt.Errorf("failed to scan sessions: %w", err)
+			}
+			if len(keys) > 0 {
+				deleted, err := r.client.Del(ctx, keys...).Result()
+				if err != nil {
+					return count, fmt.Errorf("failed to delete sessions: %w", err)
+				}
+				if pattern == "session:*" {
+					count += int(deleted)
+				}
+			}
+			cursor = next
+			if cursor == 0 {
+				break
+			}
 		}
 	}
+	return count, nil
+}
 
-	return len(keys), nil
+// DeleteUserSessions removes all sessions for a specific user
+func (r *RedisCache) DeleteUserSessions(ctx context.Context, userID string) (int, error) {
+	ids, err := r.client.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, sessionKey(id))
+	}
+	keys = append(keys, userSessionsKey(userID))
+
+	// Members whose session already expired are not counted
+	deleted, err := r.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 {
+		deleted-- // the set itself
+	}
+	return int(deleted), nil
+}
+
+// SessionCounts returns the number of live sessions of each user, pruning set
+// members whose session expired
+func (r *RedisCache) SessionCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	pipe := r.client.Pipeline()
+	members := make([]*redis.StringSliceCmd, len(userIDs))
+	for i, userID := range userIDs {
+		members[i] = pipe.SMembers(ctx, userSessionsKey(userID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read user sessions: %w", err)
+	}
+
+	pipe = r.client.Pipeline()
+	exists := make([][]*redis.IntCmd, len(userIDs))
+	for i := range userIDs {
+		for _, id := range members[i].Val() {
+			exists[i] = append(exists[i], pipe.Exists(ctx, sessionKey(id)))
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to check user sessions: %w", err)
+	}
+
+	counts := make(map[string]int, len(userIDs))
+	prune := r.client.Pipeline()
+	for i, userID := range userIDs {
+		for j, id := range members[i].Val() {
+			if exists[i][j].Val() > 0 {
+				counts[userID]++
+			} else {
+				prune.SRem(ctx, userSessionsKey(userID), id)
+			}
+		}
+	}
+	if prune.Len() > 0 {
+		if _, err := prune.Exec(ctx); err != nil {
+			log.Printf("[WARN] Failed to prune expired sessions: %v", err)
+		}
+	}
+	return counts, nil
 }
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// IdempotencyRecord is what the server remembers about a request sent with an
+// Idempotency-Key: the request it was first used for and, once that completed,
+// the response to replay
+type IdempotencyRecord struct {
+	Method      string    `json:"method"`
+	URI         string    `json:"uri"`
+	Done        bool      `json:"done"`
+	Status      int       `json:"status,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Location    string    `json:"location,omitempty"` // of a created resource
+	Body        []byte    `json:"body,omitempty"`
+	BodyOmitted bool      `json:"body_omitted,omitempty"` // the response was too large to keep
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func idempotencyKey(userID, key string) string {
+	return "idempotency:" + userID + ":" + key
+}
+
+// BeginIdempotent claims key for a request of userID. It returns nil if the claim
+// succeeded (the request should run), or the record of the earlier request with
+// the same key. The claim expires after ttl unless completed.
+func (r *RedisCache) BeginIdempotent(ctx context.Context, userID, key string, record IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode idempotency record: %w", err)
+	}
+	ok, err := r.client.SetNX(ctx, idempotencyKey(userID, key), data, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	if ok {
+		return nil, nil
+	}
+
+	existing, err := r.client.Get(ctx, idempotencyKey(userID, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// Expired between the two calls; try once more
+		return r.BeginIdempotent(ctx, userID, key, record, ttl)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+	var earlier IdempotencyRecord
+	if err := json.Unmarshal(existing, &earlier); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotency record: %w", err)
+	}
+	return &earlier, nil
+}
+
+// CompleteIdempotent stores the response of the request that claimed key, to be
+// replayed to retries for ttl
+func (r *RedisCache) CompleteIdempotent(ctx context.Context, userID, key string, record IdempotencyRecord, ttl time.Duration) error {
+	record.Done = true
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode idempotency record: %w", err)
+	}
+	return r.client.Set(ctx, idempotencyKey(userID, key), data, ttl).Err()
+}
+
+// ReleaseIdempotent drops a claim whose request failed, so a retry runs it again
+func (r *RedisCache) ReleaseIdempotent(ctx context.Context, userID, key string) error {
+	return r.client.Del(ctx, idempotencyKey(userID, key)).Err()
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func lockKey(name string) string {
+	return "lock:" + name
+}
+
+// Only the owner may extend or release a lock; a holder that stalled past the TTL
+// must not clobber the lock a successor took over
+var (
+	renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// AcquireLock takes the named lock for owner unless someone else holds it.
+// The lock expires after ttl unless renewed.
+func (r *RedisCache) AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, lockKey(name), owner, ttl).Result()
+}
+
+// RenewLock extends a lock still held by owner, reporting false if it was lost
+func (r *RedisCache) RenewLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	n, err := renewLockScript.Run(ctx, r.client, []string{lockKey(name)}, owner, ttl.Milliseconds()).Int()
+	return n == 1, err
+}
+
+// ReleaseLock drops a lock held by owner
+func (r *RedisCache) ReleaseLock(ctx context.Context, name, owner string) error {
+	return releaseLockScript.Run(ctx, r.client, []string{lockKey(name)}, owner).Err()
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrRemoteNameTaken is returned when creating a remote with the name of another
+var ErrRemoteNameTaken = errors.New("a remote with this name already exists")
+
+// ReplicationRemote is another File Locker instance that files are replicated to
+type ReplicationRemote struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"` // API root, e.g. https://backup.example.com/api/v1
+	Token     string    `json:"-"`   // personal access token on the remote
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ReplicatedFiles  int        `json:"replicated_files"`
+	LastReplicatedAt *time.Time `json:"last_replicated_at,omitempty"`
+}
+
+const remoteColumns = `r.id, r.name, r.url, r.token, r.created_by, r.created_at,
+		       (SELECT COUNT(*) FROM replicated_files f WHERE f.remote_id = r.id),
+		       (SELECT MAX(f.replicated_at) FROM replicated_files f WHERE f.remote_id = r.id)`
+
+func (p *PostgresStore) scanRemote(row rowScanner) (*ReplicationRemote, error) {
+	var r ReplicationRemote
+	var createdBy sql.NullString
+	var last sql.NullTime
+	if err := row.Scan(&r.ID, &r.Name, &r.URL, &r.Token, &createdBy, &r.CreatedAt, &r.ReplicatedFiles, &last); err != nil {
+		return nil, err
+	}
+	r.CreatedBy = createdBy.String
+	if last.Valid {
+		r.LastReplicatedAt = &last.Time
+	}
+
+	token, err := p.fieldCipher.Decrypt(r.Token, fieldContext(r.ID, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("remote %s: token: %w", r.Name, err)
+	}
+	r.Token = token
+	return &r, nil
+}
+
+// CreateReplicationRemote stores a remote, setting its ID and creation time. The
+// token is encrypted like file metadata when a local KEK is configured.
+func (p *PostgresStore) CreateReplicationRemote(ctx context.Context, remote *ReplicationRemote) error {
+	remote.ID = uuid.New().String()
+	token := remote.Token
+	if p.fieldCipher != nil {
+		var err error
+		if token, err = p.fieldCipher.Encrypt(remote.Token, fieldContext(remote.ID, "token")); err != nil {
+			return fmt.Errorf("failed to encrypt token: %w", err)
+		}
+	}
+
+	err := p.db.QueryRowContext(ctx, `
+		INSERT INTO replication_remotes (id, name, url, token, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid)
+		RETURNING created_at
+	`, remote.ID, remote.Name, remote.URL, token, remote.CreatedBy).Scan(&remote.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrRemoteNameTaken
+		}
+		return fmt.Errorf("failed to create remote: %w", err)
+	}
+	return nil
+}
+
+// ListReplicationRemotes returns all remotes by name
+func (p *PostgresStore) ListReplicationRemotes(ctx context.Context) ([]*ReplicationRemote, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT `+remoteColumns+` FROM replication_remotes r ORDER BY r.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	remotes := []*ReplicationRemote{}
+	for rows.Next() {
+		remote, err := p.scanRemote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan remote: %w", err)
+		}
+		remotes = append(remotes, remote)
+	}
+	return remotes, rows.Err()
+}
+
+// GetReplicationRemote returns a remote by ID, or sql.ErrNoRows
+func (p *PostgresStore) GetReplicationRemote(ctx context.Context, id string) (*ReplicationRemote, error) {
+	return p.scanRemote(p.db.QueryRowContext(ctx, `SELECT `+remoteColumns+` FROM replication_remotes r WHERE r.id = $1`, id))
+}
+
+// DeleteReplicationRemote deletes a remote and what is known about its files (the
+// files on the remote stay). Returns sql.ErrNoRows when there is no such remote.
+func (p *PostgresStore) DeleteReplicationRemote(ctx context.Context, id string) error {
+	result, err := p.db.ExecContext(ctx, `DELETE FROM replication_remotes WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete remote: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UnreplicatedFiles returns the IDs of the files the remote does not have yet,
+// out of those in fileIDs, or of all files of userID when fileIDs is empty (one of
+// the two is required). Files in the trash are skipped.
+func (p *PostgresStore) UnreplicatedFiles(ctx context.Context, remoteID, userID string, fileIDs []string) ([]string, error) {
+	query := `
+		SELECT f.id FROM files f
+		WHERE f.deleted_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM replicated_files r WHERE r.remote_id = $1 AND r.file_id = f.id)`
+	args := []interface{}{remoteID}
+	if len(fileIDs) > 0 {
+		query += ` AND f.id = ANY($2::uuid[])`
+		args = append(args, fileIDs)
+	} else {
+		query += ` AND f.user_id = $2`
+		args = append(args, userID)
+	}
+	query += ` ORDER BY f.created_at`
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files to replicate: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// IsReplicated reports whether the remote already has the file
+func (p *PostgresStore) IsReplicated(ctx context.Context, remoteID, fileID string) (bool, error) {
+	var exists bool
+	err := p.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM replicated_files WHERE remote_id = $1 AND file_id = $2)`, remoteID, fileID).Scan(&exists)
+	return exists, err
+}
+
+// MarkReplicated records that the remote has the file, as remoteFileID
+func (p *PostgresStore) MarkReplicated(ctx context.Context, remoteID, fileID, remoteFileID string) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO replicated_files (remote_id, file_id, remote_file_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (remote_id, file_id) DO UPDATE SET remote_file_id = $3, replicated_at = NOW()
+	`, remoteID, fileID, remoteFileID)
+	if err != nil {
+		return fmt.Errorf("failed to record replication: %w", err)
+	}
+	return nil
+}
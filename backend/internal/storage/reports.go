@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Kinds of cleanup runs
+const (
+	CleanupExpired = "expired" // auto-deleted after expires_at
+	CleanupTrash   = "trash"   // purged from the trash
+)
+
+// ReportStats is the activity of a report period [From, To)
+type ReportStats struct {
+	NewUsers     int `json:"new_users"`
+	PendingUsers int `json:"pending_users"` // awaiting approval now
+	TotalUsers   int `json:"total_users"`
+
+	TotalFiles    int   `json:"total_files"`
+	TotalBytes    int64 `json:"total_bytes"`
+	UploadedFiles int   `json:"uploaded_files"` // uploaded in the period and still stored
+	UploadedBytes int64 `json:"uploaded_bytes"`
+
+	TopUploaders []Uploader `json:"top_uploaders"`
+
+	// Served from users' files: downloads, streams and exports
+	TransferBytes int64          `json:"transfer_bytes"`
+	TopTransfer   []UserTransfer `json:"top_transfer"`
+
+	FailedLogins         int `json:"failed_logins"`
+	FailedLoginAddresses int `json:"failed_login_addresses"` // distinct client addresses
+
+	ExpiredFiles int   `json:"expired_files"` // deleted by the auto-delete worker
+	ExpiredBytes int64 `json:"expired_bytes"`
+	PurgedFiles  int   `json:"purged_files"` // purged from the trash
+	PurgedBytes  int64 `json:"purged_bytes"`
+}
+
+// Uploader is a user's uploads in a report period
+type Uploader struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Files    int    `json:"files"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// RecordCleanupRun records what a cleanup run deleted
+func (p *PostgresStore) RecordCleanupRun(ctx context.Context, kind string, files int, bytes int64) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO cleanup_runs (kind, files_deleted, bytes_freed) VALUES ($1, $2, $3)
+	`, kind, files, bytes)
+	if err != nil {
+		return fmt.Errorf("failed to record cleanup run: %w", err)
+	}
+	return nil
+}
+
+// GetReportStats gathers the activity between from and to, with the topN users who
+// uploaded the most bytes
+func (p *PostgresStore) GetReportStats(ctx context.Context, from, to time.Time, topN int) (*ReportStats, error) {
+	var s ReportStats
+
+	err := p.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE created_at >= $1 AND created_at < $2),
+		       COUNT(*) FILTER (WHERE account_status = 'pending'),
+		       COUNT(*)
+		FROM users WHERE role <> 'service'
+	`, from, to).Scan(&s.NewUsers, &s.PendingUsers, &s.TotalUsers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(size), 0),
+		       COUNT(*) FILTER (WHERE created_at >= $1 AND created_at < $2),
+		       COALESCE(SUM(size) FILTER (WHERE created_at >= $1 AND created_at < $2), 0)
+		FROM files
+	`, from, to).Scan(&s.TotalFiles, &s.TotalBytes, &s.UploadedFiles, &s.UploadedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum files: %w", err)
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT f.user_id, u.username, COUNT(*), SUM(f.size)
+		FROM files f
+		JOIN users u ON u.id = f.user_id
+		WHERE f.created_at >= $1 AND f.created_at < $2
+		GROUP BY f.user_id, u.username
+		ORDER BY SUM(f.size) DESC, COUNT(*) DESC
+		LIMIT $3
+	`, from, to, topN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top uploaders: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	s.TopUploaders = []Uploader{}
+	for rows.Next() {
+		var u Uploader
+		if err := rows.Scan(&u.UserID, &u.Username, &u.Files, &u.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan uploader: %w", err)
+		}
+		s.TopUploaders = append(s.TopUploaders, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get top uploaders: %w", err)
+	}
+
+	// Transfer is kept per day: the periods start and end at midnight UTC
+	fromDay, toDay := from.UTC().Format("2006-01-02"), to.UTC().Format("2006-01-02")
+	err = p.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(download_bytes + stream_bytes + export_bytes), 0)
+		FROM user_transfer
+		WHERE day >= $1::date AND day < $2::date
+	`, fromDay, toDay).Scan(&s.TransferBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum transfer: %w", err)
+	}
+
+	rows, err = p.db.QueryContext(ctx, `
+		SELECT t.user_id, u.username, SUM(t.download_bytes + t.stream_bytes + t.export_bytes)
+		FROM user_transfer t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.day >= $1::date AND t.day < $2::date
+		GROUP BY t.user_id, u.username
+		ORDER BY 3 DESC
+		LIMIT $3
+	`, fromDay, toDay, topN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top transfer: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	s.TopTransfer = []UserTransfer{}
+	for rows.Next() {
+		var u UserTransfer
+		if err := rows.Scan(&u.UserID, &u.Username, &u.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan transfer: %w", err)
+		}
+		s.TopTransfer = append(s.TopTransfer, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get top transfer: %w", err)
+	}
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COUNT(DISTINCT ip_address)
+		FROM audit_logs
+		WHERE action = 'LOGIN_FAILED' AND created_at >= $1 AND created_at < $2
+	`, from, to).Scan(&s.FailedLogins, &s.FailedLoginAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failed logins: %w", err)
+	}
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(files_deleted) FILTER (WHERE kind = 'expired'), 0),
+		       COALESCE(SUM(bytes_freed) FILTER (WHERE kind = 'expired'), 0),
+		       COALESCE(SUM(files_deleted) FILTER (WHERE kind = 'trash'), 0),
+		       COALESCE(SUM(bytes_freed) FILTER (WHERE kind = 'trash'), 0)
+		FROM cleanup_runs
+		WHERE ran_at >= $1 AND ran_at < $2
+	`, from, to).Scan(&s.ExpiredFiles, &s.ExpiredBytes, &s.PurgedFiles, &s.PurgedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum cleanup runs: %w", err)
+	}
+
+	return &s, nil
+}
+
+// ClaimReportRun records that the report of a period is being sent; false if it
+// already was (by this or another replica)
+func (p *PostgresStore) ClaimReportRun(ctx context.Context, period string, from, to time.Time) (bool, error) {
+	result, err := p.db.ExecContext(ctx, `
+		INSERT INTO report_runs (period, period_start, period_end) VALUES ($1, $2, $3)
+		ON CONFLICT (period, period_start) DO NOTHING
+	`, period, from, to)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim report run: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// FinishReportRun marks the report of a period as sent, with the bytes stored then
+func (p *PostgresStore) FinishReportRun(ctx context.Context, period string, from time.Time, storageBytes int64) error {
+	_, err := p.db.ExecContext(ctx, `
+		UPDATE report_runs SET sent_at = NOW(), storage_bytes = $3
+		WHERE period = $1 AND period_start = $2
+	`, period, from, storageBytes)
+	if err != nil {
+		return fmt.Errorf("failed to finish report run: %w", err)
+	}
+	return nil
+}
+
+// PreviousReportStorage returns the bytes stored when the last report of a period
+// before from was sent; false if there was none
+func (p *PostgresStore) PreviousReportStorage(ctx context.Context, period string, from time.Time) (int64, bool, error) {
+	var bytes int64
+	err := p.db.QueryRowContext(ctx, `
+		SELECT storage_bytes FROM report_runs
+		WHERE period = $1 AND period_start < $2 AND storage_bytes IS NOT NULL
+		ORDER BY period_start DESC
+		LIMIT 1
+	`, period, from).Scan(&bytes)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get previous report: %w", err)
+	}
+	return bytes, true, nil
+}
+
+// ListAdminEmails returns the email addresses of the active admins
+func (p *PostgresStore) ListAdminEmails(ctx context.Context) ([]string, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT email FROM users
+		WHERE role = 'admin' AND is_active AND account_status = 'active' AND email <> ''
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list admin emails: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan admin email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	return emails, rows.Err()
+}
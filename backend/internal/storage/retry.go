@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy repeats MinIO operations that failed with a network error, a
+// timeout or a 5xx response. The MinIO client already retries single HTTP
+// requests for a few seconds; this covers longer blips and reads that break off
+// in the middle of an object. Only operations that are safe to repeat are
+// retried: reads, stats, copies and deletes, and writes whose source can be
+// rewound. Streamed uploads are not: their parts are retried by the client alone.
+type RetryPolicy struct {
+	Attempts  int           // tries, the first included; 0 or 1 = no retries
+	BaseDelay time.Duration // before the first retry, doubling with each one; 0 = 200ms
+	MaxDelay  time.Duration // cap of the delay; 0 = 5s
+}
+
+// RetryError is returned when an operation failed on each of its attempts
+type RetryError struct {
+	Op       string // "upload", "download", "copy", "delete", "stat", "tag"
+	Path     string
+	Attempts int
+	Err      error // of the last attempt
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s of %s failed after %d attempts: %v", e.Op, e.Path, e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryExhausted reports whether err comes from an operation that ran out of
+// attempts
+func IsRetryExhausted(err error) (*RetryError, bool) {
+	var retryErr *RetryError
+	return retryErr, errors.As(err, &retryErr)
+}
+
+// SetRetryPolicy sets how failed operations of m are retried
+func (m *MinIOStorage) SetRetryPolicy(p RetryPolicy) {
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 200 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 5 * time.Second
+	}
+	m.retryPolicy = p
+}
+
+// delay returns how long to wait before retry number n (1 for the first), with
+// full jitter so that requests failing together do not retry together
+func (p RetryPolicy) delay(n int) time.Duration {
+	backoff := p.BaseDelay << min(n-1, 20)
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	return rand.N(backoff) + 1
+}
+
+// retryable reports whether a failed attempt may be repeated
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if _, open := IsUnavailable(err); open {
+		return false
+	}
+	return isOutage(err)
+}
+
+// wait sleeps before retry number n; false if ctx ended first
+func (p RetryPolicy) wait(ctx context.Context, n int) bool {
+	timer := time.NewTimer(p.delay(n))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// retry runs request through the circuit breaker until it succeeds, fails for a
+// reason retrying cannot help, or has used up the attempts of the policy
+func (m *MinIOStorage) retry(ctx context.Context, op, path string, request func() error) error {
+	attempts := max(m.retryPolicy.Attempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := m.guard(request)
+		if err == nil || !retryable(ctx, err) {
+			return err
+		}
+		if attempt >= attempts {
+			if attempts == 1 {
+				return err
+			}
+			return &RetryError{Op: op, Path: path, Attempts: attempt, Err: err}
+		}
+		log.Printf("[storage] %s of %s failed (attempt %d of %d), retrying: %v", op, path, attempt, attempts, err)
+		if !m.retryPolicy.wait(ctx, attempt) {
+			return err
+		}
+	}
+}
+
+// sourceError is the error of the reader an upload is sent from; it is not a
+// storage failure and is neither retried nor counted by the circuit breaker
+type sourceError struct {
+	err error
+}
+
+func (e *sourceError) Error() string { return e.err.Error() }
+func (e *sourceError) Unwrap() error { return e.err }
+
+// resumingReader reads an object and, when the connection fails in the middle,
+// opens it again at the offset reached. Stored objects never change, so the rest
+// of the bytes is the same.
+type resumingReader struct {
+	m      *MinIOStorage
+	ctx    context.Context
+	path   string
+	open   func(offset int64) (io.ReadCloser, error)
+	rc     io.ReadCloser
+	offset int64
+	failed int // attempts failed in a row
+}
+
+func (r *resumingReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.rc.Read(p)
+		r.offset += int64(n)
+		if err == nil || err == io.EOF || !retryable(r.ctx, err) {
+			if n > 0 {
+				r.failed = 0
+			}
+			return n, err
+		}
+
+		attempts := max(r.m.retryPolicy.Attempts, 1)
+		if r.failed++; r.failed >= attempts {
+			if attempts == 1 {
+				return n, err
+			}
+			return n, &RetryError{Op: "download", Path: r.path, Attempts: r.failed, Err: err}
+		}
+		log.Printf("[storage] Reading %s failed at byte %d (attempt %d of %d), resuming: %v", r.path, r.offset, r.failed, attempts, err)
+		if !r.m.retryPolicy.wait(r.ctx, r.failed) {
+			return n, err
+		}
+		_ = r.rc.Close()
+		rc, openErr := r.open(r.offset)
+		if openErr != nil {
+			return n, openErr
+		}
+		r.rc = rc
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (r *resumingReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,272 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RoleService marks a user row as a service account (non-human, API key only)
+const RoleService = "service"
+
+// ServiceAccount is a non-human account used by automation
+type ServiceAccount struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	IsActive   bool      `json:"is_active"`
+	ActiveKeys int       `json:"active_keys"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ServiceAccountKey is a long-lived API key belonging to a service account
+type ServiceAccountKey struct {
+	ID               string     `json:"id"`
+	ServiceAccountID string     `json:"service_account_id"`
+	Name             string     `json:"name"`
+	KeyPrefix        string     `json:"key_prefix"`
+	Scopes           []string   `json:"scopes"`
+	AllowedCIDRs     []string   `json:"allowed_cidrs"`
+	CreatedBy        string     `json:"created_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP       string     `json:"last_used_ip,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+}
+
+const serviceKeyColumns = `k.id, k.service_account_id, k.name, k.key_prefix, k.scopes, k.allowed_cidrs,
+		       k.created_by, k.created_at, k.expires_at, k.last_used_at, k.last_used_ip, k.revoked_at`
+
+func scanServiceAccountKey(row rowScanner, extra ...interface{}) (*ServiceAccountKey, error) {
+	var k ServiceAccountKey
+	var createdBy, lastUsedIP sql.NullString
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+
+	dest := []interface{}{
+		&k.ID,
+		&k.ServiceAccountID,
+		&k.Name,
+		&k.KeyPrefix,
+		StringArray(&k.Scopes),
+		StringArray(&k.AllowedCIDRs),
+		&createdBy,
+		&k.CreatedAt,
+		&expiresAt,
+		&lastUsedAt,
+		&lastUsedIP,
+		&revokedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	// Handle nullable fields
+	k.CreatedBy = createdBy.String
+	k.LastUsedIP = lastUsedIP.String
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		k.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+
+	return &k, nil
+}
+
+// CreateServiceAccount creates a service account. It has no usable password
+// ("!" never matches a bcrypt hash) and is active immediately.
+func (p *PostgresStore) CreateServiceAccount(ctx context.Context, name string) (*ServiceAccount, error) {
+	query := `
+		INSERT INTO users (username, email, password_hash, role, account_status)
+		VALUES ($1, '', '!', $2, 'active'::account_status)
+		RETURNING id, username, is_active, created_at
+	`
+
+	var sa ServiceAccount
+	err := p.db.QueryRowContext(ctx, query, name, RoleService).Scan(&sa.ID, &sa.Name, &sa.IsActive, &sa.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return &sa, nil
+}
+
+// GetServiceAccount retrieves a service account by ID
+func (p *PostgresStore) GetServiceAccount(ctx context.Context, id string) (*ServiceAccount, error) {
+	query := `
+		SELECT u.id, u.username, u.is_active, u.created_at,
+		       (SELECT COUNT(*) FROM service_account_keys k
+		        WHERE k.service_account_id = u.id AND k.revoked_at IS NULL
+		          AND (k.expires_at IS NULL OR k.expires_at > NOW()))
+		FROM users u
+		WHERE u.id = $1 AND u.role = $2
+	`
+
+	var sa ServiceAccount
+	err := p.db.QueryRowContext(ctx, query, id, RoleService).Scan(&sa.ID, &sa.Name, &sa.IsActive, &sa.CreatedAt, &sa.ActiveKeys)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("service account not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return &sa, nil
+}
+
+// ListServiceAccounts retrieves all service accounts with their active key counts
+func (p *PostgresStore) ListServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
+	query := `
+		SELECT u.id, u.username, u.is_active, u.created_at,
+		       COUNT(k.id) FILTER (WHERE k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > NOW()))
+		FROM users u
+		LEFT JOIN service_account_keys k ON k.service_account_id = u.id
+		WHERE u.role = $1
+		GROUP BY u.id, u.username, u.is_active, u.created_at
+		ORDER BY u.created_at DESC
+	`
+
+	rows, err := p.db.QueryContext(ctx, query, RoleService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var accounts []*ServiceAccount
+	for rows.Next() {
+		var sa ServiceAccount
+		if err := rows.Scan(&sa.ID, &sa.Name, &sa.IsActive, &sa.CreatedAt, &sa.ActiveKeys); err != nil {
+			return nil, fmt.Errorf("failed to scan service account: %w", err)
+		}
+		accounts = append(accounts, &sa)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// CreateServiceAccountKey stores a new key (hashed with bcrypt) and fills in its generated fields
+func (p *PostgresStore) CreateServiceAccountKey(ctx context.Context, k *ServiceAccountKey, rawKey string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(rawKey), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash key: %w", err)
+	}
+
+	query := `
+		INSERT INTO service_account_keys (
+			service_account_id, name, key_prefix, key_hash, scopes, allowed_cidrs, created_by, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8)
+		RETURNING id, created_at
+	`
+
+	err = p.db.QueryRowContext(ctx, query,
+		k.ServiceAccountID,
+		k.Name,
+		k.KeyPrefix,
+		string(hash),
+		k.Scopes,
+		k.AllowedCIDRs,
+		k.CreatedBy,
+		k.ExpiresAt,
+	).Scan(&k.ID, &k.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create service account key: %w", err)
+	}
+
+	return nil
+}
+
+// ListServiceAccountKeys retrieves all keys (including revoked) of a service account
+func (p *PostgresStore) ListServiceAccountKeys(ctx context.Context, accountID string) ([]*ServiceAccountKey, error) {
+	query := `SELECT ` + serviceKeyColumns + ` FROM service_account_keys k WHERE k.service_account_id = $1 ORDER BY k.created_at DESC`
+
+	rows, err := p.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service account keys: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var keys []*ServiceAccountKey
+	for rows.Next() {
+		k, err := scanServiceAccountKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service account key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating service account keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeServiceAccountKey revokes a key of the given service account
+func (p *PostgresStore) RevokeServiceAccountKey(ctx context.Context, accountID, keyID string) error {
+	query := `
+		UPDATE service_account_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND service_account_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := p.db.ExecContext(ctx, query, keyID, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke service account key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("service account key not found: %s", keyID)
+	}
+
+	return nil
+}
+
+// VerifyServiceAccountKey looks up a raw key by its prefix and checks it against the stored hash.
+// Only unrevoked, unexpired keys of active service accounts match; otherwise sql.ErrNoRows is returned.
+func (p *PostgresStore) VerifyServiceAccountKey(ctx context.Context, prefix, rawKey string) (*ServiceAccountKey, error) {
+	query := `
+		SELECT ` + serviceKeyColumns + `, k.key_hash
+		FROM service_account_keys k
+		JOIN users u ON u.id = k.service_account_id
+		WHERE k.key_prefix = $1
+		  AND k.revoked_at IS NULL
+		  AND (k.expires_at IS NULL OR k.expires_at > NOW())
+		  AND u.role = $2 AND u.is_active
+	`
+
+	var hash string
+	k, err := scanServiceAccountKey(p.db.QueryRowContext(ctx, query, prefix, RoleService), &hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(rawKey)) != nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return k, nil
+}
+
+// TouchServiceAccountKey records the last use of a key (best-effort)
+func (p *PostgresStore) TouchServiceAccountKey(ctx context.Context, keyID, ip string) error {
+	_, err := p.db.ExecContext(ctx, `UPDATE service_account_keys SET last_used_at = NOW(), last_used_ip = $2 WHERE id = $1`, keyID, ip)
+	if err != nil {
+		return fmt.Errorf("failed to update service account key usage: %w", err)
+	}
+	return nil
+}
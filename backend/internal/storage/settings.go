@@ -0,0 +1,358 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Settings keys of maintenance mode (see migration 000022), read-only mode (000023),
+// IP access rules (000025) and the active JWT signing key (unset = first of the ring)
+const (
+	SettingMaintenanceMode    = "maintenance_mode"
+	SettingMaintenanceMessage = "maintenance_message"
+	SettingReadOnlyMode       = "read_only_mode"
+	SettingIPAllowlist        = "ip_allowlist"
+	SettingIPDenylist         = "ip_denylist"
+	SettingBlockedCountries   = "blocked_countries"
+	SettingJWTSigningKey      = "jwt_signing_key"
+)
+
+// ErrReadOnly is returned for writes while the global or the user's read-only switch is on
+var ErrReadOnly = errors.New("files are read-only")
+
+// MaintenanceState is the maintenance mode switch and the message shown to users
+type MaintenanceState struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// GetMaintenanceMode reads the maintenance mode settings
+func (p *PostgresStore) GetMaintenanceMode(ctx context.Context) (MaintenanceState, error) {
+	query := `SELECT key, value, updated_at FROM settings WHERE key IN ($1, $2)`
+	rows, err := p.db.QueryContext(ctx, query, SettingMaintenanceMode, SettingMaintenanceMessage)
+	if err != nil {
+		return MaintenanceState{}, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var state MaintenanceState
+	for rows.Next() {
+		var key, value string
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&key, &value, &updatedAt); err != nil {
+			return MaintenanceState{}, fmt.Errorf("failed to scan setting: %w", err)
+		}
+		switch key {
+		case SettingMaintenanceMode:
+			state.Enabled = value == "true"
+			if updatedAt.Valid {
+				state.UpdatedAt = &updatedAt.Time
+			}
+		case SettingMaintenanceMessage:
+			state.Message = value
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return MaintenanceState{}, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+	return state, nil
+}
+
+// SetMaintenanceMode turns maintenance mode on or off. An empty message keeps the
+// current one.
+func (p *PostgresStore) SetMaintenanceMode(ctx context.Context, enabled bool, message, adminID string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := setSetting(ctx, tx, SettingMaintenanceMode, fmt.Sprintf("%t", enabled),
+		"Reject non-admin API requests with 503 (e.g. during migrations)", adminID); err != nil {
+		return fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+	if message != "" {
+		if err := setSetting(ctx, tx, SettingMaintenanceMessage, message,
+			"Message shown to users during maintenance", adminID); err != nil {
+			return fmt.Errorf("failed to set maintenance message: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit maintenance mode: %w", err)
+	}
+	return nil
+}
+
+// setSetting stores a setting, creating it (with description) if it does not exist.
+// adminID is empty for changes made by the server itself.
+func setSetting(ctx context.Context, tx *sql.Tx, key, value, description, adminID string) error {
+	query := `
+		INSERT INTO settings (key, value, description, updated_at, updated_by)
+		VALUES ($1, $2, $3, NOW(), NULLIF($4, '')::uuid)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW(), updated_by = EXCLUDED.updated_by
+	`
+	_, err := tx.ExecContext(ctx, query, key, value, description, adminID)
+	return err
+}
+
+// ReadOnlyState tells whether writes are blocked for everyone (Global) and whether
+// they are blocked for one user (User)
+type ReadOnlyState struct {
+	Global bool `json:"global"`
+	User   bool `json:"user"`
+}
+
+// Blocked reports whether writes are blocked
+func (s ReadOnlyState) Blocked() bool {
+	return s.Global || s.User
+}
+
+// GetReadOnly reads the global read-only switch and the one of userID (which may be
+// empty)
+func (p *PostgresStore) GetReadOnly(ctx context.Context, userID string) (ReadOnlyState, error) {
+	query := `
+		SELECT
+			COALESCE((SELECT value = 'true' FROM settings WHERE key = $1), false),
+			COALESCE((SELECT read_only FROM users WHERE id::text = $2), false)
+	`
+	var state ReadOnlyState
+	if err := p.db.QueryRowContext(ctx, query, SettingReadOnlyMode, userID).Scan(&state.Global, &state.User); err != nil {
+		return ReadOnlyState{}, fmt.Errorf("failed to get read-only mode: %w", err)
+	}
+	return state, nil
+}
+
+// CheckWritable returns ErrReadOnly while writes are blocked for userID
+func (p *PostgresStore) CheckWritable(ctx context.Context, userID string) error {
+	state, err := p.GetReadOnly(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if state.Blocked() {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// SetGlobalReadOnly turns the read-only switch for all users on or off
+func (p *PostgresStore) SetGlobalReadOnly(ctx context.Context, enabled bool, adminID string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := setSetting(ctx, tx, SettingReadOnlyMode, fmt.Sprintf("%t", enabled),
+		"Block uploads, deletes and metadata changes for all users (e.g. during storage migrations)", adminID); err != nil {
+		return fmt.Errorf("failed to set read-only mode: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit read-only mode: %w", err)
+	}
+	return nil
+}
+
+// SetUserReadOnly turns the read-only switch of one user on or off
+func (p *PostgresStore) SetUserReadOnly(ctx context.Context, userID string, readOnly bool) error {
+	result, err := p.db.ExecContext(ctx, `UPDATE users SET read_only = $1, updated_at = NOW() WHERE id = $2`, readOnly, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set user read-only: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ReadOnlyUser is a user whose own read-only switch is on
+type ReadOnlyUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// ListReadOnlyUsers returns the users whose own read-only switch is on
+func (p *PostgresStore) ListReadOnlyUsers(ctx context.Context) ([]ReadOnlyUser, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT id, username FROM users WHERE read_only ORDER BY username`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list read-only users: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	users := []ReadOnlyUser{}
+	for rows.Next() {
+		var u ReadOnlyUser
+		if err := rows.Scan(&u.ID, &u.Username); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+// IPAccessRules decide which client addresses may use the API: Deny (CIDRs or
+// addresses) always wins, a non-empty Allow admits only its entries, and
+// BlockedCountries lists ISO 3166 country codes
+type IPAccessRules struct {
+	Allow            []string   `json:"allow"`
+	Deny             []string   `json:"deny"`
+	BlockedCountries []string   `json:"blocked_countries"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// GetIPAccessRules reads the IP access settings (stored as JSON arrays)
+func (p *PostgresStore) GetIPAccessRules(ctx context.Context) (IPAccessRules, error) {
+	query := `SELECT key, value, updated_at FROM settings WHERE key IN ($1, $2, $3)`
+	rows, err := p.db.QueryContext(ctx, query, SettingIPAllowlist, SettingIPDenylist, SettingBlockedCountries)
+	if err != nil {
+		return IPAccessRules{}, fmt.Errorf("failed to get IP access rules: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	rules := IPAccessRules{Allow: []string{}, Deny: []string{}, BlockedCountries: []string{}}
+	for rows.Next() {
+		var key, value string
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&key, &value, &updatedAt); err != nil {
+			return IPAccessRules{}, fmt.Errorf("failed to scan setting: %w", err)
+		}
+		var list *[]string
+		switch key {
+		case SettingIPAllowlist:
+			list = &rules.Allow
+		case SettingIPDenylist:
+			list = &rules.Deny
+		case SettingBlockedCountries:
+			list = &rules.BlockedCountries
+		}
+		if err := json.Unmarshal([]byte(value), list); err != nil {
+			return IPAccessRules{}, fmt.Errorf("invalid %s setting: %w", key, err)
+		}
+		if updatedAt.Valid && (rules.UpdatedAt == nil || updatedAt.Time.After(*rules.UpdatedAt)) {
+			rules.UpdatedAt = &updatedAt.Time
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return IPAccessRules{}, fmt.Errorf("failed to get IP access rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SetIPAccessRules replaces the IP access settings
+func (p *PostgresStore) SetIPAccessRules(ctx context.Context, rules IPAccessRules, adminID string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	settings := []struct {
+		key, description string
+		list             []string
+	}{
+		{SettingIPAllowlist, "CIDRs allowed to use the API (empty = all)", rules.Allow},
+		{SettingIPDenylist, "CIDRs refused by the API", rules.Deny},
+		{SettingBlockedCountries, "ISO 3166 country codes refused by the API", rules.BlockedCountries},
+	}
+	for _, s := range settings {
+		if s.list == nil {
+			s.list = []string{}
+		}
+		value, err := json.Marshal(s.list)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", s.key, err)
+		}
+		if err := setSetting(ctx, tx, s.key, string(value), s.description, adminID); err != nil {
+			return fmt.Errorf("failed to set %s: %w", s.key, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit IP access rules: %w", err)
+	}
+	return nil
+}
+
+// SigningKeyState is the JWT key ring state shared by all replicas: the key new
+// tokens are signed with (and since when), and the earlier keys still honoured
+// until the given time, when the last token they signed has expired
+type SigningKeyState struct {
+	Active      string               `json:"active"`
+	ActiveSince time.Time            `json:"active_since"`
+	Retiring    map[string]time.Time `json:"retiring,omitempty"`
+}
+
+func parseSigningKeyState(value string) (SigningKeyState, error) {
+	var state SigningKeyState
+	if !strings.HasPrefix(value, "{") {
+		// A bare key ID, written before the state had retiring keys
+		state.Active = value
+		return state, nil
+	}
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return SigningKeyState{}, fmt.Errorf("invalid %s setting: %w", SettingJWTSigningKey, err)
+	}
+	return state, nil
+}
+
+// GetSigningKeyState returns the JWT key ring state; the zero state (first key of
+// the ring, nothing retiring) when no key was rotated to yet
+func (p *PostgresStore) GetSigningKeyState(ctx context.Context) (SigningKeyState, error) {
+	var value string
+	err := p.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = $1`, SettingJWTSigningKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SigningKeyState{}, nil
+	}
+	if err != nil {
+		return SigningKeyState{}, fmt.Errorf("failed to get signing key: %w", err)
+	}
+	return parseSigningKeyState(value)
+}
+
+// UpdateSigningKeyState changes the JWT key ring state with fn, holding a row lock
+// so concurrent rotations (an admin and the schedule) apply one after the other.
+// adminID is empty for scheduled rotations.
+func (p *PostgresStore) UpdateSigningKeyState(ctx context.Context, adminID string, fn func(*SigningKeyState) error) (SigningKeyState, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return SigningKeyState{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO settings (key, value, description) VALUES ($1, '{}', $2) ON CONFLICT (key) DO NOTHING`,
+		SettingJWTSigningKey, signingKeyDescription); err != nil {
+		return SigningKeyState{}, fmt.Errorf("failed to create signing key setting: %w", err)
+	}
+	var value string
+	if err := tx.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = $1 FOR UPDATE`, SettingJWTSigningKey).Scan(&value); err != nil {
+		return SigningKeyState{}, fmt.Errorf("failed to lock signing key setting: %w", err)
+	}
+	state, err := parseSigningKeyState(value)
+	if err != nil {
+		return SigningKeyState{}, err
+	}
+
+	if err := fn(&state); err != nil {
+		return SigningKeyState{}, err
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return SigningKeyState{}, fmt.Errorf("failed to encode signing key state: %w", err)
+	}
+	if err := setSetting(ctx, tx, SettingJWTSigningKey, string(data), signingKeyDescription, adminID); err != nil {
+		return SigningKeyState{}, fmt.Errorf("failed to set signing key: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return SigningKeyState{}, fmt.Errorf("failed to commit signing key: %w", err)
+	}
+	return state, nil
+}
+
+const signingKeyDescription = "security.jwt_keys entry session tokens are signed with, and the retiring ones"
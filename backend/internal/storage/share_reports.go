@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Statuses of a share report
+const (
+	ReportOpen      = "open"
+	ReportDismissed = "dismissed"
+	ReportActioned  = "actioned" // the share was disabled
+)
+
+// ShareReport is an abuse report about a public share link
+type ShareReport struct {
+	ID             string     `json:"id"`
+	ShareID        string     `json:"share_id"`
+	FileID         string     `json:"file_id"`
+	OwnerID        string     `json:"owner_id"` // who created the share
+	ShareDisabled  bool       `json:"share_disabled"`
+	Category       string     `json:"category"`
+	Message        string     `json:"message,omitempty"`
+	ReporterEmail  string     `json:"reporter_email,omitempty"`
+	IPAddress      string     `json:"ip_address,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Status         string     `json:"status"`
+	ResolvedBy     string     `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ResolutionNote string     `json:"resolution_note,omitempty"`
+}
+
+const shareReportColumns = `r.id, r.share_id, s.file_id, s.created_by, s.disabled_at IS NOT NULL,
+		       r.category, r.message, r.reporter_email, r.ip_address, r.created_at,
+		       r.status, r.resolved_by, r.resolved_at, r.resolution_note`
+
+func scanShareReport(row rowScanner) (*ShareReport, error) {
+	var r ShareReport
+	var message, email, ip, resolvedBy, note sql.NullString
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(&r.ID, &r.ShareID, &r.FileID, &r.OwnerID, &r.ShareDisabled,
+		&r.Category, &message, &email, &ip, &r.CreatedAt,
+		&r.Status, &resolvedBy, &resolvedAt, &note)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Message = message.String
+	r.ReporterEmail = email.String
+	r.IPAddress = ip.String
+	r.ResolvedBy = resolvedBy.String
+	r.ResolutionNote = note.String
+	if resolvedAt.Valid {
+		r.ResolvedAt = &resolvedAt.Time
+	}
+	return &r, nil
+}
+
+// CreateShareReport records a report; ID and CreatedAt are filled in
+func (p *PostgresStore) CreateShareReport(ctx context.Context, r *ShareReport) error {
+	err := p.db.QueryRowContext(ctx, `
+		INSERT INTO share_reports (share_id, category, message, reporter_email, ip_address)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''))
+		RETURNING id, created_at, status
+	`, r.ShareID, r.Category, r.Message, r.ReporterEmail, r.IPAddress).Scan(&r.ID, &r.CreatedAt, &r.Status)
+	if err != nil {
+		return fmt.Errorf("failed to create share report: %w", err)
+	}
+	return nil
+}
+
+// GetShareReport retrieves a report by ID; sql.ErrNoRows if there is none
+func (p *PostgresStore) GetShareReport(ctx context.Context, id string) (*ShareReport, error) {
+	report, err := scanShareReport(p.db.QueryRowContext(ctx, `
+		SELECT `+shareReportColumns+`
+		FROM share_reports r
+		JOIN share_links s ON s.id = r.share_id
+		WHERE r.id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share report: %w", err)
+	}
+	return report, nil
+}
+
+// ListShareReports returns the reports with a status ("" = all), oldest first
+func (p *PostgresStore) ListShareReports(ctx context.Context, status string) ([]*ShareReport, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+shareReportColumns+`
+		FROM share_reports r
+		JOIN share_links s ON s.id = r.share_id
+		WHERE $1 = '' OR r.status = $1
+		ORDER BY r.created_at ASC
+	`, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share reports: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	reports := []*ShareReport{}
+	for rows.Next() {
+		report, err := scanShareReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// ResolveShareReport closes an open report with status (ReportDismissed or
+// ReportActioned). Acting on a share closes the other open reports about it too.
+// Returns the number of reports closed.
+func (p *PostgresStore) ResolveShareReport(ctx context.Context, id, status, resolvedBy, note string) (int64, error) {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE share_reports
+		SET status = $2, resolved_by = $3, resolved_at = NOW(), resolution_note = NULLIF($4, '')
+		WHERE status = 'open'
+		  AND (id = $1 OR ($2 = 'actioned' AND share_id = (SELECT share_id FROM share_reports WHERE id = $1)))
+	`, id, status, resolvedBy, note)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve share report: %w", err)
+	}
+	return result.RowsAffected()
+}
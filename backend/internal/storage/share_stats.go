@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ShareVisit is one request to a share link
+type ShareVisit struct {
+	ShareID    string
+	IPHash     string // keyed hash of the client address (auth.VisitorHasher)
+	Downloaded bool   // the file was served
+	At         time.Time
+}
+
+// ShareStats is how a share link has been used since it was created. Unlike the
+// link's request and byte counters it is never reset.
+type ShareStats struct {
+	ShareID           string     `json:"share_id"`
+	FileID            string     `json:"file_id"`
+	Views             int64      `json:"views"`               // requests with the link's token, refused ones included
+	Downloads         int64      `json:"downloads"`           // requests that were served the file
+	UniqueIPs         int64      `json:"unique_ips"`          // addresses that opened the link
+	UniqueDownloadIPs int64      `json:"unique_download_ips"` // addresses that downloaded the file
+	FirstAccessedAt   *time.Time `json:"first_accessed_at,omitempty"`
+	LastAccessedAt    *time.Time `json:"last_accessed_at,omitempty"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+}
+
+// RecordShareVisit adds a request to the statistics of its share link.
+// Concurrent requests are added in batches like download events.
+func (p *PostgresStore) RecordShareVisit(ctx context.Context, visit ShareVisit) error {
+	if visit.At.IsZero() {
+		visit.At = time.Now()
+	}
+	return p.shareVisits.add(ctx, visit)
+}
+
+func (p *PostgresStore) writeShareVisits(ctx context.Context, visits []ShareVisit) error {
+	// One statement may not update a row twice, so sum the batch per visitor first
+	type key struct{ shareID, ipHash string }
+	type sum struct {
+		views, downloads int
+		first, last      time.Time
+		lastDownload     *time.Time
+	}
+	sums := make(map[key]*sum)
+	var keys []key
+	for _, v := range visits {
+		k := key{v.ShareID, v.IPHash}
+		s := sums[k]
+		if s == nil {
+			s = &sum{first: v.At, last: v.At}
+			sums[k] = s
+			keys = append(keys, k)
+		}
+		s.views++
+		if v.At.Before(s.first) {
+			s.first = v.At
+		}
+		if v.At.After(s.last) {
+			s.last = v.At
+		}
+		if v.Downloaded {
+			s.downloads++
+			if at := v.At; s.lastDownload == nil || at.After(*s.lastDownload) {
+				s.lastDownload = &at
+			}
+		}
+	}
+
+	query := `
+		INSERT INTO share_visitors (share_id, ip_hash, views, downloads, first_seen_at, last_seen_at, last_download_at)
+		VALUES ` + valuesList(len(keys), "$::uuid", "$", "$::integer", "$::integer", "$::timestamptz", "$::timestamptz", "$::timestamptz") + `
+		ON CONFLICT (share_id, ip_hash) DO UPDATE SET
+			views = share_visitors.views + EXCLUDED.views,
+			downloads = share_visitors.downloads + EXCLUDED.downloads,
+			last_seen_at = GREATEST(share_visitors.last_seen_at, EXCLUDED.last_seen_at),
+			last_download_at = GREATEST(share_visitors.last_download_at, EXCLUDED.last_download_at)
+	`
+
+	args := make([]interface{}, 0, 7*len(keys))
+	for _, k := range keys {
+		s := sums[k]
+		args = append(args, k.shareID, k.ipHash, s.views, s.downloads, s.first, s.last, s.lastDownload)
+	}
+	if _, err := p.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record share visits: %w", err)
+	}
+	return nil
+}
+
+// GetShareStats returns the statistics of a share link
+func (p *PostgresStore) GetShareStats(ctx context.Context, link *ShareLink) (*ShareStats, error) {
+	stats := ShareStats{ShareID: link.ID, FileID: link.FileID}
+	var first, last, lastDownload sql.NullTime
+	err := p.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(views), 0), COALESCE(SUM(downloads), 0),
+		       COUNT(*), COUNT(*) FILTER (WHERE downloads > 0),
+		       MIN(first_seen_at), MAX(last_seen_at), MAX(last_download_at)
+		FROM share_visitors
+		WHERE share_id = $1
+	`, link.ID).Scan(&stats.Views, &stats.Downloads, &stats.UniqueIPs, &stats.UniqueDownloadIPs, &first, &last, &lastDownload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share stats: %w", err)
+	}
+
+	if first.Valid {
+		stats.FirstAccessedAt = &first.Time
+	}
+	if last.Valid {
+		stats.LastAccessedAt = &last.Time
+	}
+	if lastDownload.Valid {
+		stats.LastDownloadedAt = &lastDownload.Time
+	}
+	return &stats, nil
+}
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ShareLink is a public download link for a single file
+type ShareLink struct {
+	ID              string     `json:"id"`
+	FileID          string     `json:"file_id"`
+	CreatedBy       string     `json:"created_by"`
+	Token           string     `json:"token"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	MaxRequests     *int       `json:"max_requests,omitempty"`
+	MaxBytes        *int64     `json:"max_bytes,omitempty"`
+	AllowedReferers []string   `json:"allowed_referers"`
+	AllowedIPs      []string   `json:"allowed_ips"`
+	RequestCount    int        `json:"request_count"`
+	BytesServed     int64      `json:"bytes_served"`
+	LastAccessedAt  *time.Time `json:"last_accessed_at,omitempty"`
+	DisabledAt      *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason  string     `json:"disabled_reason,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+const shareColumns = `id, file_id, created_by, token, expires_at,
+		       max_requests, max_bytes, allowed_referers, allowed_ips,
+		       request_count, bytes_served, last_accessed_at,
+		       disabled_at, disabled_reason, created_at`
+
+func scanShareLink(row rowScanner) (*ShareLink, error) {
+	var s ShareLink
+	var expiresAt, lastAccessedAt, disabledAt sql.NullTime
+	var maxRequests sql.NullInt32
+	var maxBytes sql.NullInt64
+	var disabledReason sql.NullString
+
+	err := row.Scan(
+		&s.ID,
+		&s.FileID,
+		&s.CreatedBy,
+		&s.Token,
+		&expiresAt,
+		&maxRequests,
+		&maxBytes,
+		StringArray(&s.AllowedReferers),
+		StringArray(&s.AllowedIPs),
+		&s.RequestCount,
+		&s.BytesServed,
+		&lastAccessedAt,
+		&disabledAt,
+		&disabledReason,
+		&s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle nullable fields
+	if expiresAt.Valid {
+		s.ExpiresAt = &expiresAt.Time
+	}
+	if maxRequests.Valid {
+		v := int(maxRequests.Int32)
+		s.MaxRequests = &v
+	}
+	if maxBytes.Valid {
+		s.MaxBytes = &maxBytes.Int64
+	}
+	if lastAccessedAt.Valid {
+		s.LastAccessedAt = &lastAccessedAt.Time
+	}
+	if disabledAt.Valid {
+		s.DisabledAt = &disabledAt.Time
+	}
+	if disabledReason.Valid {
+		s.DisabledReason = disabledReason.String
+	}
+
+	return &s, nil
+}
+
+// CreateShareLink inserts a new share link and fills in its generated fields
+func (p *PostgresStore) CreateShareLink(ctx context.Context, s *ShareLink) error {
+	query := `
+		INSERT INTO share_links (
+			file_id, created_by, token, expires_at,
+			max_requests, max_bytes, allowed_referers, allowed_ips
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+
+	err := p.db.QueryRowContext(ctx, query,
+		s.FileID,
+		s.CreatedBy,
+		s.Token,
+		s.ExpiresAt,
+		s.MaxRequests,
+		s.MaxBytes,
+		s.AllowedReferers,
+		s.AllowedIPs,
+	).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return nil
+}
+
+// GetShareLinkByToken retrieves a share link by its public token
+func (p *PostgresStore) GetShareLinkByToken(ctx context.Context, token string) (*ShareLink, error) {
+	query := `SELECT ` + shareColumns + ` FROM share_links WHERE token = $1`
+
+	s, err := scanShareLink(p.db.QueryRowContext(ctx, query, token))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("share link not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return s, nil
+}
+
+// GetShareLink retrieves a share link by ID
+func (p *PostgresStore) GetShareLink(ctx context.Context, id string) (*ShareLink, error) {
+	query := `SELECT ` + shareColumns + ` FROM share_links WHERE id = $1`
+
+	s, err := scanShareLink(p.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("share link not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return s, nil
+}
+
+// ListShareLinks retrieves all share links of a file, newest first
+func (p *PostgresStore) ListShareLinks(ctx context.Context, fileID string) ([]*ShareLink, error) {
+	query := `SELECT ` + shareColumns + ` FROM share_links WHERE file_id = $1 ORDER BY created_at DESC`
+
+	rows, err := p.db.QueryContext(ctx, query, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var links []*ShareLink
+	for rows.Next() {
+		s, err := scanShareLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share link: %w", err)
+		}
+		links = append(links, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating share links: %w", err)
+	}
+
+	return links, nil
+}
+
+// ReserveShareDownload atomically counts a download of size bytes against the link's caps.
+// It returns false (and changes nothing) when the link is disabled or a cap would be exceeded.
+func (p *PostgresStore) ReserveShareDownload(ctx context.Context, id string, size int64) (bool, error) {
+	query := `
+		UPDATE share_links
+		SET request_count = request_count + 1,
+		    bytes_served = bytes_served + $2,
+		    last_accessed_at = NOW()
+		WHERE id = $1
+		  AND disabled_at IS NULL
+		  AND (max_requests IS NULL OR request_count < max_requests)
+		  AND (max_bytes IS NULL OR bytes_served + $2 <= max_bytes)
+	`
+
+	result, err := p.db.ExecContext(ctx, query, id, size)
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve share download: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
+// DisableShareLink disables a share link with a reason.
+// It returns true only for the call that actually disabled it, so callers can notify once.
+func (p *PostgresStore) DisableShareLink(ctx context.Context, id, reason string) (bool, error) {
+	query := `
+		UPDATE share_links
+		SET disabled_at = NOW(), disabled_reason = $2
+		WHERE id = $1 AND disabled_at IS NULL
+	`
+
+	result, err := p.db.ExecContext(ctx, query, id, reason)
+	if err != nil {
+		return false, fmt.Errorf("failed to disable share link: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
+// ResetShareLinkCounters resets the request and byte counters of a share link
+// without changing whether it is enabled
+func (p *PostgresStore) ResetShareLinkCounters(ctx context.Context, id string) error {
+	query := `
+		UPDATE share_links
+		SET request_count = 0, bytes_served = 0
+		WHERE id = $1
+	`
+
+	result, err := p.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to reset share link counters: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("share link not found: %s", id)
+	}
+
+	return nil
+}
+
+// EnableShareLink re-enables a disabled share link and optionally resets its counters
+func (p *PostgresStore) EnableShareLink(ctx context.Context, id string, resetCounters bool) error {
+	query := `
+		UPDATE share_links
+		SET disabled_at = NULL, disabled_reason = NULL,
+		    request_count = CASE WHEN $2 THEN 0 ELSE request_count END,
+		    bytes_served = CASE WHEN $2 THEN 0 ELSE bytes_served END
+		WHERE id = $1
+	`
+
+	result, err := p.db.ExecContext(ctx, query, id, resetCounters)
+	if err != nil {
+		return fmt.Errorf("failed to enable share link: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("share link not found: %s", id)
+	}
+
+	return nil
+}
+
+// DeleteShareLink permanently removes a share link
+func (p *PostgresStore) DeleteShareLink(ctx context.Context, id string) error {
+	result, err := p.db.ExecContext(ctx, `DELETE FROM share_links WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete share link: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("share link not found: %s", id)
+	}
+
+	return nil
+}
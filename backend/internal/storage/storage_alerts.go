@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SettingStorageQuota is the storage quota per user in bytes (0 = none)
+const SettingStorageQuota = "storage_quota_per_user_bytes"
+
+// Kinds of storage alerts
+const (
+	AlertBucket    = "bucket"     // bytes of all stored files
+	AlertDatabase  = "database"   // size of the Postgres database
+	AlertUserQuota = "user_quota" // a user's files against the per-user quota
+)
+
+// StorageAlert is a storage threshold that is currently exceeded
+type StorageAlert struct {
+	Kind       string    `json:"kind"`
+	Subject    string    `json:"subject,omitempty"` // user ID of AlertUserQuota
+	UsedBytes  int64     `json:"used_bytes"`
+	LimitBytes int64     `json:"limit_bytes"` // the threshold
+	RaisedAt   time.Time `json:"raised_at"`
+	UpdatedAt  time.Time `json:"updated_at"` // last check that found it exceeded
+}
+
+// UserUsage is the bytes a user's files take up
+type UserUsage struct {
+	UserID   string
+	Username string
+	Email    string
+	Bytes    int64
+}
+
+// GetStorageQuota returns the storage quota per user in bytes; 0 if none is set
+func (p *PostgresStore) GetStorageQuota(ctx context.Context) (int64, error) {
+	var value string
+	err := p.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = $1`, SettingStorageQuota).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get storage quota: %w", err)
+	}
+	quota, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || quota < 0 {
+		return 0, fmt.Errorf("invalid %s setting %q", SettingStorageQuota, value)
+	}
+	return quota, nil
+}
+
+// GetStoredBytes returns the size of all stored files, including those in the trash
+func (p *PostgresStore) GetStoredBytes(ctx context.Context) (int64, error) {
+	var bytes int64
+	if err := p.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(size), 0) FROM files`).Scan(&bytes); err != nil {
+		return 0, fmt.Errorf("failed to sum stored bytes: %w", err)
+	}
+	return bytes, nil
+}
+
+// GetDatabaseSize returns the disk space the Postgres database takes up
+func (p *PostgresStore) GetDatabaseSize(ctx context.Context) (int64, error) {
+	var bytes int64
+	if err := p.db.QueryRowContext(ctx, `SELECT pg_database_size(current_database())`).Scan(&bytes); err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+	return bytes, nil
+}
+
+// ListUsersOverStorage returns the users whose files take up at least limit bytes,
+// largest first
+func (p *PostgresStore) ListUsersOverStorage(ctx context.Context, limit int64) ([]UserUsage, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT u.id, u.username, u.email, SUM(f.size)
+		FROM files f
+		JOIN users u ON u.id = f.user_id
+		WHERE u.role <> 'service'
+		GROUP BY u.id, u.username, u.email
+		HAVING SUM(f.size) >= $1
+		ORDER BY SUM(f.size) DESC
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users over storage: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var users []UserUsage
+	for rows.Next() {
+		var u UserUsage
+		if err := rows.Scan(&u.UserID, &u.Username, &u.Email, &u.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan user usage: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+// RaiseStorageAlert records that a threshold is exceeded; true if it was not
+// before, i.e. the alert is new and should be sent
+func (p *PostgresStore) RaiseStorageAlert(ctx context.Context, kind, subject string, used, limit int64) (bool, error) {
+	var inserted bool
+	err := p.db.QueryRowContext(ctx, `
+		INSERT INTO storage_alerts (kind, subject, used_bytes, limit_bytes) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (kind, subject) DO UPDATE
+		SET used_bytes = EXCLUDED.used_bytes, limit_bytes = EXCLUDED.limit_bytes, updated_at = NOW()
+		RETURNING xmax = 0
+	`, kind, subject, used, limit).Scan(&inserted)
+	if err != nil {
+		return false, fmt.Errorf("failed to raise storage alert: %w", err)
+	}
+	return inserted, nil
+}
+
+// ClearStorageAlerts removes the alerts of a kind whose subject is not in keep
+// (their usage dropped below the threshold) and returns the subjects removed
+func (p *PostgresStore) ClearStorageAlerts(ctx context.Context, kind string, keep []string) ([]string, error) {
+	if keep == nil {
+		keep = []string{}
+	}
+	rows, err := p.db.QueryContext(ctx, `
+		DELETE FROM storage_alerts WHERE kind = $1 AND NOT subject = ANY($2)
+		RETURNING subject
+	`, kind, keep)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear storage alerts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var cleared []string
+	for rows.Next() {
+		var subject string
+		if err := rows.Scan(&subject); err != nil {
+			return nil, fmt.Errorf("failed to scan storage alert: %w", err)
+		}
+		cleared = append(cleared, subject)
+	}
+	return cleared, rows.Err()
+}
+
+// ListStorageAlerts returns the raised alerts, oldest first
+func (p *PostgresStore) ListStorageAlerts(ctx context.Context) ([]StorageAlert, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT kind, subject, used_bytes, limit_bytes, raised_at, updated_at
+		FROM storage_alerts
+		ORDER BY raised_at, kind, subject
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage alerts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	alerts := []StorageAlert{}
+	for rows.Next() {
+		var a StorageAlert
+		if err := rows.Scan(&a.Kind, &a.Subject, &a.UsedBytes, &a.LimitBytes, &a.RaisedAt, &a.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan storage alert: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
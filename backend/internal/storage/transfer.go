@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SettingTransferCap is the bytes that may be served from a user's files per
+// calendar month (UTC) before downloads are refused (0 = no cap)
+const SettingTransferCap = "transfer_cap_per_user_bytes"
+
+// Kinds of transfer
+const (
+	TransferDownload = "download" // downloads, share links and signed URLs included
+	TransferStream   = "stream"
+	TransferExport   = "export"
+)
+
+// TransferRecord is the bytes of one response served from a user's files
+type TransferRecord struct {
+	UserID string // owner of the files
+	Kind   string
+	Bytes  int64
+	At     time.Time
+}
+
+// TransferDay is the bytes served from a user's files in a day (UTC)
+type TransferDay struct {
+	Day           string `json:"day"` // YYYY-MM-DD
+	DownloadBytes int64  `json:"download_bytes"`
+	StreamBytes   int64  `json:"stream_bytes"`
+	ExportBytes   int64  `json:"export_bytes"`
+	Bytes         int64  `json:"bytes"` // all three
+}
+
+// UserTransfer is the bytes served from a user's files in a report period
+type UserTransfer struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// RecordTransfer adds the bytes of a response to its owner's day. Concurrent
+// responses are added in batches like download events.
+func (p *PostgresStore) RecordTransfer(ctx context.Context, rec TransferRecord) error {
+	if rec.At.IsZero() {
+		rec.At = time.Now()
+	}
+	return p.transfers.add(ctx, rec)
+}
+
+func (p *PostgresStore) writeTransfers(ctx context.Context, recs []TransferRecord) error {
+	// One statement may not update a row twice, so sum the batch per user and day first
+	type key struct{ userID, day string }
+	sums := make(map[key]*[3]int64)
+	var keys []key
+	for _, rec := range recs {
+		k := key{rec.UserID, rec.At.UTC().Format("2006-01-02")}
+		sum := sums[k]
+		if sum == nil {
+			sum = new([3]int64)
+			sums[k] = sum
+			keys = append(keys, k)
+		}
+		switch rec.Kind {
+		case TransferStream:
+			sum[1] += rec.Bytes
+		case TransferExport:
+			sum[2] += rec.Bytes
+		default:
+			sum[0] += rec.Bytes
+		}
+	}
+
+	query := `
+		INSERT INTO user_transfer (user_id, day, download_bytes, stream_bytes, export_bytes)
+		VALUES ` + valuesList(len(keys), "$::uuid", "$::date", "$::bigint", "$::bigint", "$::bigint") + `
+		ON CONFLICT (user_id, day) DO UPDATE SET
+			download_bytes = user_transfer.download_bytes + EXCLUDED.download_bytes,
+			stream_bytes = user_transfer.stream_bytes + EXCLUDED.stream_bytes,
+			export_bytes = user_transfer.export_bytes + EXCLUDED.export_bytes
+	`
+
+	args := make([]interface{}, 0, 5*len(keys))
+	for _, k := range keys {
+		sum := sums[k]
+		args = append(args, k.userID, k.day, sum[0], sum[1], sum[2])
+	}
+	if _, err := p.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record transfer: %w", err)
+	}
+	return nil
+}
+
+// GetTransferCap returns the monthly transfer cap per user in bytes; 0 if none is set
+func (p *PostgresStore) GetTransferCap(ctx context.Context) (int64, error) {
+	var value string
+	err := p.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = $1`, SettingTransferCap).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transfer cap: %w", err)
+	}
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid %s setting %q", SettingTransferCap, value)
+	}
+	return limit, nil
+}
+
+// MonthTransferBytes returns the bytes served from a user's files in the
+// calendar month (UTC) of now
+func (p *PostgresStore) MonthTransferBytes(ctx context.Context, userID string, now time.Time) (int64, error) {
+	now = now.UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var bytes int64
+	err := p.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(download_bytes + stream_bytes + export_bytes), 0)
+		FROM user_transfer
+		WHERE user_id = $1 AND day >= $2::date
+	`, userID, month.Format("2006-01-02")).Scan(&bytes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transfer of the month: %w", err)
+	}
+	return bytes, nil
+}
+
+// UserTransferDays returns the bytes served from a user's files on each day
+// from from to to (UTC, both included) that had any, oldest first
+func (p *PostgresStore) UserTransferDays(ctx context.Context, userID string, from, to time.Time) ([]TransferDay, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT day::text, download_bytes, stream_bytes, export_bytes
+		FROM user_transfer
+		WHERE user_id = $1 AND day >= $2::date AND day <= $3::date
+		ORDER BY day
+	`, userID, from.UTC().Format("2006-01-02"), to.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	days := []TransferDay{}
+	for rows.Next() {
+		var d TransferDay
+		if err := rows.Scan(&d.Day, &d.DownloadBytes, &d.StreamBytes, &d.ExportBytes); err != nil {
+			return nil, fmt.Errorf("failed to scan transfer: %w", err)
+		}
+		d.Bytes = d.DownloadBytes + d.StreamBytes + d.ExportBytes
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transfer: %w", err)
+	}
+	return days, nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// TrashFile moves a file owned by userID to the trash. Returns sql.ErrNoRows when
+// the user has no such file outside the trash.
+func (p *PostgresStore) TrashFile(ctx context.Context, fileID, userID string) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE files
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`, fileID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to move file to trash: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// RestoreFile takes a file owned by userID out of the trash. Returns sql.ErrNoRows
+// when the user has no such file in the trash.
+func (p *PostgresStore) RestoreFile(ctx context.Context, fileID, userID string) (*FileMetadata, error) {
+	metadata, err := p.scanFile(p.db.QueryRowContext(ctx, `
+		UPDATE files
+		SET deleted_at = NULL
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+		RETURNING `+fileColumns, fileID, userID))
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore file: %w", err)
+	}
+	return metadata, nil
+}
+
+// GetTrashedFile retrieves a file in the trash by file ID
+func (p *PostgresStore) GetTrashedFile(ctx context.Context, fileID string) (*FileMetadata, error) {
+	query := `SELECT ` + fileColumns + ` FROM files WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	metadata, err := p.scanFile(p.db.QueryRowContext(ctx, query, fileID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, fileID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// ListTrashedFiles retrieves a user's files in the trash, most recently deleted first
+func (p *PostgresStore) ListTrashedFiles(ctx context.Context, userID string) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+
+	files, err := p.queryFiles(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	return files, nil
+}
+
+// GetPurgeableFiles retrieves files that were moved to the trash before the cutoff
+func (p *PostgresStore) GetPurgeableFiles(ctx context.Context, cutoff time.Time) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		ORDER BY deleted_at ASC
+	`
+
+	files, err := p.queryFiles(ctx, query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purgeable files: %w", err)
+	}
+	return files, nil
+}
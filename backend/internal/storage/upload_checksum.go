@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// IntegrityError is returned when the object MinIO stored does not match what
+// was sent: its ETag (the MD5 of the bytes, or of the MD5s of the parts for a
+// multipart upload) or its size differs. The object has been removed.
+type IntegrityError struct {
+	Path         string
+	ExpectedETag string
+	ETag         string
+	ExpectedSize int64
+	Size         int64
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("stored object %s does not match the upload: etag %s, expected %s; %d bytes, expected %d",
+		e.Path, e.ETag, e.ExpectedETag, e.Size, e.ExpectedSize)
+}
+
+// IsIntegrityError reports whether err is a rolled back upload that MinIO did
+// not store intact
+func IsIntegrityError(err error) bool {
+	var integrityErr *IntegrityError
+	return errors.As(err, &integrityErr)
+}
+
+// SetVerifyUploads makes every upload check the ETag and size MinIO returns
+// against those of the bytes sent. Leave it off when the server encrypts objects
+// itself (SSE-KMS, SSE-C): their ETag is not an MD5.
+func (m *MinIOStorage) SetVerifyUploads(verify bool) {
+	m.verifyUploads = verify
+}
+
+// uploadChecksum hashes an upload as it is read, both whole and in the parts
+// the MinIO client sends it in, to predict the ETag of the stored object
+type uploadChecksum struct {
+	partSize int64 // 0 = a single PUT
+
+	whole    hash.Hash
+	part     hash.Hash
+	partLen  int64
+	partSums []byte
+	n        int64
+}
+
+// newUploadChecksum splits the upload like PutObject does: objects up to a part
+// in size (or with multipart disabled) are sent in one request, larger ones and
+// those of unknown size in parts of the size OptimalPartInfo picks
+func newUploadChecksum(size int64, opts minio.PutObjectOptions) (*uploadChecksum, error) {
+	c := &uploadChecksum{whole: md5.New(), part: md5.New()}
+	configured := opts.PartSize
+	if configured == 0 {
+		configured = 16 << 20 // the client's default
+	}
+	if opts.DisableMultipart || (size >= 0 && size <= int64(configured)) {
+		return c, nil
+	}
+	_, partSize, _, err := minio.OptimalPartInfo(size, opts.PartSize)
+	if err != nil {
+		return nil, err
+	}
+	c.partSize = partSize
+	return c, nil
+}
+
+func (c *uploadChecksum) Write(p []byte) (int, error) {
+	c.whole.Write(p)
+	c.n += int64(len(p))
+	for rest := p; c.partSize > 0 && len(rest) > 0; {
+		chunk := min(int64(len(rest)), c.partSize-c.partLen)
+		c.part.Write(rest[:chunk])
+		c.partLen += chunk
+		rest = rest[chunk:]
+		if c.partLen == c.partSize {
+			c.endPart()
+		}
+	}
+	return len(p), nil
+}
+
+func (c *uploadChecksum) endPart() {
+	c.partSums = c.part.Sum(c.partSums)
+	c.part.Reset()
+	c.partLen = 0
+}
+
+// etag is the ETag S3 gives the object: the hex MD5 of its bytes, or for a
+// multipart upload the MD5 of the parts' MD5s followed by "-<parts>"
+func (c *uploadChecksum) etag() string {
+	if c.partSize == 0 {
+		return hex.EncodeToString(c.whole.Sum(nil))
+	}
+	// An empty stream is still sent as one (empty) part
+	if c.partLen > 0 || len(c.partSums) == 0 {
+		c.endPart()
+	}
+	sum := md5.Sum(c.partSums)
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), len(c.partSums)/md5.Size)
+}
+
+// verify compares what MinIO reports having stored with what was sent, and
+// removes the object if they differ
+func (m *MinIOStorage) verify(ctx context.Context, objectName string, info minio.UploadInfo, sent *uploadChecksum) error {
+	expected := sent.etag()
+	etag := strings.Trim(info.ETag, `"`)
+	if strings.EqualFold(etag, expected) && info.Size == sent.n {
+		return nil
+	}
+
+	integrityErr := &IntegrityError{Path: objectName, ExpectedETag: expected, ETag: etag, ExpectedSize: sent.n, Size: info.Size}
+	log.Printf("[storage] %v; removing it", integrityErr)
+	bucket, name := m.locate(objectName)
+	if err := m.client.RemoveObject(context.WithoutCancel(ctx), bucket, name, minio.RemoveObjectOptions{}); err != nil {
+		log.Printf("[storage] Failed to remove corrupted object %s: %v", objectName, err)
+	}
+	return integrityErr
+}
+
+// hashingReader feeds what is read to a checksum
+type hashingReader struct {
+	r   io.Reader
+	sum *uploadChecksum
+}
+
+func (h *hashingReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	_, _ = h.sum.Write(p[:n])
+	return n, err
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UsageRecord is what a user used in a calendar month (UTC), for chargeback.
+// Storage is sampled once a day, so 1 GB kept for the whole of a 30-day month
+// is 30 GB-days.
+type UsageRecord struct {
+	Month           string `json:"month"` // YYYY-MM
+	UserID          string `json:"user_id"`
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	StorageByteDays int64  `json:"storage_byte_days"`
+	PeakBytes       int64  `json:"peak_storage_bytes"`
+	DownloadBytes   int64  `json:"download_bytes"` // served from the user's files: downloads (share links included), streams and exports
+	Downloads       int    `json:"downloads"`
+}
+
+// RecordUsageDay adds a day (UTC) to the usage records of its month: the bytes
+// each user stores now, and the bytes served and downloads counted from their
+// files that day. Users without any get no record. A day is added to a record
+// once, so calling it again for the same day changes nothing. It returns the
+// records updated.
+func (p *PostgresStore) RecordUsageDay(ctx context.Context, day time.Time) (int64, error) {
+	day = day.UTC()
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	result, err := p.db.ExecContext(ctx, `
+		INSERT INTO usage_records (month, user_id, username, email, storage_byte_days, peak_storage_bytes, download_bytes, downloads, last_day)
+		SELECT date_trunc('month', $3::date)::date, u.id, u.username, u.email,
+		       COALESCE(s.bytes, 0), COALESCE(s.bytes, 0), COALESCE(d.bytes, 0), COALESCE(d.downloads, 0), $3::date
+		FROM users u
+		LEFT JOIN (
+			SELECT user_id, SUM(size) AS bytes FROM files GROUP BY user_id
+		) s ON s.user_id = u.id
+		LEFT JOIN (
+			SELECT COALESCE(t.user_id, n.user_id) AS user_id, COALESCE(t.bytes, 0) AS bytes, COALESCE(n.downloads, 0) AS downloads
+			FROM (
+				SELECT user_id, download_bytes + stream_bytes + export_bytes AS bytes
+				FROM user_transfer WHERE day = $3::date
+			) t
+			FULL JOIN (
+				SELECT f.user_id, COUNT(*) AS downloads
+				FROM file_downloads fd
+				JOIN files f ON f.id = fd.file_id
+				WHERE fd.downloaded_at >= $1 AND fd.downloaded_at < $2
+				GROUP BY f.user_id
+			) n ON n.user_id = t.user_id
+		) d ON d.user_id = u.id
+		WHERE s.bytes > 0 OR d.bytes > 0 OR d.downloads > 0
+		ON CONFLICT (month, user_id) DO UPDATE SET
+			username = EXCLUDED.username,
+			email = EXCLUDED.email,
+			storage_byte_days = usage_records.storage_byte_days + EXCLUDED.storage_byte_days,
+			peak_storage_bytes = GREATEST(usage_records.peak_storage_bytes, EXCLUDED.peak_storage_bytes),
+			download_bytes = usage_records.download_bytes + EXCLUDED.download_bytes,
+			downloads = usage_records.downloads + EXCLUDED.downloads,
+			last_day = EXCLUDED.last_day,
+			updated_at = NOW()
+		WHERE usage_records.last_day < EXCLUDED.last_day
+	`, from, from.AddDate(0, 0, 1), from.Format("2006-01-02"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to record usage of %s: %w", from.Format("2006-01-02"), err)
+	}
+	return result.RowsAffected()
+}
+
+// LastUsageDay returns the latest day added to the usage records; false if none was
+func (p *PostgresStore) LastUsageDay(ctx context.Context) (time.Time, bool, error) {
+	var day sql.NullString
+	if err := p.db.QueryRowContext(ctx, `SELECT MAX(last_day)::text FROM usage_records`).Scan(&day); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get last usage day: %w", err)
+	}
+	if !day.Valid {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse("2006-01-02", day.String)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid usage day %q: %w", day.String, err)
+	}
+	return t, true, nil
+}
+
+// ListUsageRecords returns the usage records of the month starting at month
+// (UTC), by username
+func (p *PostgresStore) ListUsageRecords(ctx context.Context, month time.Time) ([]UsageRecord, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT user_id, username, email, storage_byte_days, peak_storage_bytes, download_bytes, downloads
+		FROM usage_records
+		WHERE month = $1::date
+		ORDER BY username, user_id
+	`, month.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usage records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	records := []UsageRecord{}
+	for rows.Next() {
+		r := UsageRecord{Month: month.UTC().Format("2006-01")}
+		if err := rows.Scan(&r.UserID, &r.Username, &r.Email, &r.StorageByteDays, &r.PeakBytes, &r.DownloadBytes, &r.Downloads); err != nil {
+			return nil, fmt.Errorf("failed to scan usage record: %w", err)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating usage records: %w", err)
+	}
+	return records, nil
+}
+
+// ClaimUsageDelivery records that the usage records of a month are being posted;
+// false if they already were (by this or another replica)
+func (p *PostgresStore) ClaimUsageDelivery(ctx context.Context, month time.Time) (bool, error) {
+	result, err := p.db.ExecContext(ctx, `
+		INSERT INTO usage_deliveries (month) VALUES ($1::date)
+		ON CONFLICT (month) DO NOTHING
+	`, month.UTC().Format("2006-01-02"))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim usage delivery: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// FinishUsageDelivery marks the usage records of a month as posted
+func (p *PostgresStore) FinishUsageDelivery(ctx context.Context, month time.Time) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO usage_deliveries (month, delivered_at) VALUES ($1::date, NOW())
+		ON CONFLICT (month) DO UPDATE SET delivered_at = NOW()
+	`, month.UTC().Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to finish usage delivery: %w", err)
+	}
+	return nil
+}
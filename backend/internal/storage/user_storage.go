@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UserStorage is the space a user's files take up. Files in the trash are
+// counted, as they are still stored; the trashed fields tell them apart.
+type UserStorage struct {
+	Files        int   `json:"files"`
+	Bytes        int64 `json:"bytes"`
+	TrashedFiles int   `json:"trashed_files"`
+	TrashedBytes int64 `json:"trashed_bytes"`
+}
+
+// StorageGrowth is a month of a user's storage. Only files still stored are
+// known, so deleted files count in no month.
+type StorageGrowth struct {
+	Month      string `json:"month"` // YYYY-MM, in UTC
+	AddedFiles int    `json:"added_files"`
+	AddedBytes int64  `json:"added_bytes"`
+	TotalBytes int64  `json:"total_bytes"` // at the end of the month
+}
+
+// ContentTypeUsage is the space the files of one MIME type take up
+type ContentTypeUsage struct {
+	MimeType string `json:"mime_type"`
+	Files    int    `json:"files"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// GetUserStorage sums up the files of a user
+func (p *PostgresStore) GetUserStorage(ctx context.Context, userID string) (UserStorage, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(size), 0),
+		       COUNT(*) FILTER (WHERE deleted_at IS NOT NULL),
+		       COALESCE(SUM(size) FILTER (WHERE deleted_at IS NOT NULL), 0)
+		FROM files
+		WHERE user_id = $1
+	`
+
+	var usage UserStorage
+	err := p.db.QueryRowContext(ctx, query, userID).Scan(&usage.Files, &usage.Bytes, &usage.TrashedFiles, &usage.TrashedBytes)
+	if err != nil {
+		return usage, fmt.Errorf("failed to get user storage: %w", err)
+	}
+	return usage, nil
+}
+
+// UserStorageGrowth returns a user's storage for each of the last months
+// calendar months (the current one included), oldest first
+func (p *PostgresStore) UserStorageGrowth(ctx context.Context, userID string, months int, now time.Time) ([]StorageGrowth, error) {
+	now = now.UTC()
+	last := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	first := last.AddDate(0, 1-months, 0)
+
+	query := `
+		SELECT m.month,
+		       COUNT(f.id) FILTER (WHERE f.created_at >= m.month),
+		       COALESCE(SUM(f.size) FILTER (WHERE f.created_at >= m.month), 0),
+		       COALESCE(SUM(f.size), 0)
+		FROM generate_series($2::timestamptz, $3::timestamptz, INTERVAL '1 month') AS m(month)
+		LEFT JOIN files f ON f.user_id = $1 AND f.created_at < m.month + INTERVAL '1 month'
+		GROUP BY m.month
+		ORDER BY m.month
+	`
+
+	rows, err := p.db.QueryContext(ctx, query, userID, first, last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage growth: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	growth := make([]StorageGrowth, 0, months)
+	for rows.Next() {
+		var g StorageGrowth
+		var month time.Time
+		if err := rows.Scan(&month, &g.AddedFiles, &g.AddedBytes, &g.TotalBytes); err != nil {
+			return nil, fmt.Errorf("failed to scan storage growth: %w", err)
+		}
+		g.Month = month.UTC().Format("2006-01")
+		growth = append(growth, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating storage growth: %w", err)
+	}
+	return growth, nil
+}
+
+// LargestUserFiles returns the limit largest files of a user, those in the
+// trash included, largest first
+func (p *PostgresStore) LargestUserFiles(ctx context.Context, userID string, limit int) ([]*FileMetadata, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files
+		WHERE user_id = $1
+		ORDER BY size DESC, id
+		LIMIT $2
+	`
+
+	files, err := p.queryFiles(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list largest files: %w", err)
+	}
+	return files, nil
+}
+
+// UserContentTypes breaks the storage of a user down by MIME type, largest first
+func (p *PostgresStore) UserContentTypes(ctx context.Context, userID string) ([]ContentTypeUsage, error) {
+	query := `
+		SELECT mime_type, COUNT(*), COALESCE(SUM(size), 0)
+		FROM files
+		WHERE user_id = $1
+		GROUP BY mime_type
+		ORDER BY SUM(size) DESC, mime_type
+	`
+
+	rows, err := p.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content types: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	types := []ContentTypeUsage{}
+	for rows.Next() {
+		var t ContentTypeUsage
+		if err := rows.Scan(&t.MimeType, &t.Files, &t.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan content type: %w", err)
+		}
+		types = append(types, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating content types: %w", err)
+	}
+	return types, nil
+}
@@ -0,0 +1,261 @@
+// Package usage keeps monthly usage records per user for chargeback: the bytes
+// they store, sampled once a day into storage-days, and the bytes served from
+// their files. The elected replica adds every finished day to the records
+// of its month. Once a month is over, a job posts its records to the billing
+// webhook as JSON, signed like the upload webhook.
+package usage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/jobs"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// JobType is the job that posts the records of a month to the webhook
+const JobType = "usage.deliver"
+
+// Event is the X-FileLocker-Event of the webhook posts
+const Event = "usage.monthly"
+
+const (
+	checkInterval = time.Hour
+	// maxCatchUpDays is how far back days missed while no replica ran are
+	// added, with the storage of the day they are added on
+	maxCatchUpDays = 31
+)
+
+// Payload is the payload of a delivery job
+type Payload struct {
+	Month time.Time `json:"month"`
+}
+
+// Config is where the records of finished months are posted
+type Config struct {
+	WebhookURL    string // "" = only recorded
+	WebhookSecret string // signs the body (X-FileLocker-Signature)
+}
+
+// Recorder records usage and delivers it
+type Recorder struct {
+	pgStore  *storage.PostgresStore
+	jobQueue *jobs.Queue
+	cfg      Config
+	client   *http.Client
+}
+
+// New creates a recorder; Start records usage
+func New(pgStore *storage.PostgresStore, jobQueue *jobs.Queue, cfg Config) *Recorder {
+	return &Recorder{
+		pgStore:  pgStore,
+		jobQueue: jobQueue,
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Webhook reports whether the records of finished months are posted
+func (r *Recorder) Webhook() bool {
+	return r.cfg.WebhookURL != ""
+}
+
+// ParseMonth parses a YYYY-MM month into its first day (UTC). "" is the last
+// finished month before now.
+func ParseMonth(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		now = now.UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0), nil
+	}
+	month, err := time.Parse("2006-01", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month %q, want YYYY-MM", s)
+	}
+	return month, nil
+}
+
+// Finished reports whether every day of a month has passed
+func Finished(month, now time.Time) bool {
+	return !now.Before(month.AddDate(0, 1, 0))
+}
+
+// Start adds the finished days to the usage records and queues the delivery of
+// every month that is over, checking hourly until ctx is cancelled; run it on
+// one replica
+func (r *Recorder) Start(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.recordDays(ctx, time.Now()); err != nil {
+			log.Printf("[usage] %v", err)
+		} else if r.Webhook() {
+			r.queueDue(ctx, time.Now())
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// recordDays adds every day up to yesterday that was not added yet
+func (r *Recorder) recordDays(ctx context.Context, now time.Time) error {
+	now = now.UTC()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	day := yesterday
+	last, ok, err := r.pgStore.LastUsageDay(ctx)
+	if err != nil {
+		return err
+	}
+	if ok {
+		day = last.AddDate(0, 0, 1)
+		if earliest := yesterday.AddDate(0, 0, 1-maxCatchUpDays); day.Before(earliest) {
+			day = earliest
+		}
+	}
+
+	for ; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		n, err := r.pgStore.RecordUsageDay(ctx, day)
+		if err != nil {
+			return err
+		}
+		log.Printf("[usage] Recorded %s for %d user(s)", day.Format("2006-01-02"), n)
+	}
+	return nil
+}
+
+// queueDue queues the delivery of the last finished month unless it was queued
+// already
+func (r *Recorder) queueDue(ctx context.Context, now time.Time) {
+	month, _ := ParseMonth("", now)
+	claimed, err := r.pgStore.ClaimUsageDelivery(ctx, month)
+	if err != nil {
+		log.Printf("[usage] %v", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+	if _, err := r.Queue(ctx, month); err != nil {
+		log.Printf("[usage] Failed to queue delivery of %s: %v", month.Format("2006-01"), err)
+	}
+}
+
+// Queue queues a job that posts the records of a month to the webhook
+func (r *Recorder) Queue(ctx context.Context, month time.Time) (*storage.Job, error) {
+	return r.jobQueue.Enqueue(ctx, JobType, Payload{Month: month})
+}
+
+// HandleJob is the job handler for JobType: it posts the records of the month
+// to the webhook. A failed post is retried; a month without records is not posted.
+func (r *Recorder) HandleJob(ctx context.Context, job *storage.Job) error {
+	var p Payload
+	if err := jobs.DecodePayload(job, &p); err != nil {
+		return err
+	}
+	if !r.Webhook() {
+		return jobs.Permanent(fmt.Errorf("no usage webhook configured"))
+	}
+
+	records, err := r.pgStore.ListUsageRecords(ctx, p.Month)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		log.Printf("[usage] No usage records for %s, nothing posted", p.Month.Format("2006-01"))
+		return nil
+	}
+	if err := r.post(ctx, p.Month, records, job.ID); err != nil {
+		return err
+	}
+	log.Printf("[usage] Posted %d usage record(s) for %s", len(records), p.Month.Format("2006-01"))
+	return r.pgStore.FinishUsageDelivery(ctx, p.Month)
+}
+
+// webhookEvent is the body posted for a month
+type webhookEvent struct {
+	Event   string                `json:"event"`
+	Month   string                `json:"month"`
+	Records []storage.UsageRecord `json:"records"`
+}
+
+// post sends the records of a month to the webhook. X-FileLocker-Delivery stays
+// the same when a post is retried, so the receiver can drop duplicates.
+func (r *Recorder) post(ctx context.Context, month time.Time, records []storage.UsageRecord, deliveryID string) error {
+	body, err := json.Marshal(webhookEvent{Event: Event, Month: month.Format("2006-01"), Records: records})
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-FileLocker-Event", Event)
+	req.Header.Set("X-FileLocker-Delivery", deliveryID)
+	if r.cfg.WebhookSecret != "" {
+		mac := hmac.New(sha256.New, []byte(r.cfg.WebhookSecret))
+		mac.Write(body)
+		req.Header.Set("X-FileLocker-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("usage webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		// The receiver rejected the records; sending them again will not help
+		return jobs.Permanent(fmt.Errorf("usage webhook rejected: HTTP %d", resp.StatusCode))
+	default:
+		return fmt.Errorf("usage webhook failed: HTTP %d", resp.StatusCode)
+	}
+}
+
+// csvHeader names the columns of WriteCSV
+var csvHeader = []string{"month", "user_id", "username", "email", "storage_byte_days", "peak_storage_bytes", "download_bytes", "downloads"}
+
+// WriteCSV writes usage records as CSV with a header row
+func WriteCSV(w io.Writer, records []storage.UsageRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := cw.Write([]string{
+			r.Month,
+			r.UserID,
+			r.Username,
+			r.Email,
+			strconv.FormatInt(r.StorageByteDays, 10),
+			strconv.FormatInt(r.PeakBytes, 10),
+			strconv.FormatInt(r.DownloadBytes, 10),
+			strconv.Itoa(r.Downloads),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -11,14 +12,24 @@ import (
 type CleanupWorker struct {
 	minioStorage *storage.MinIOStorage
 	pgStore      *storage.PostgresStore
+	redisCache   *storage.RedisCache
 	interval     time.Duration
+	warnBefore   time.Duration
+	trashFor     time.Duration
+	runHook      func(ctx context.Context, kind string, files int, bytes int64)
 }
 
-func NewCleanupWorker(minio *storage.MinIOStorage, pgStore *storage.PostgresStore, interval time.Duration) *CleanupWorker {
+// NewCleanupWorker creates the expiry worker. When warnBefore is positive, owners
+// get an announcement that long before their files are auto-deleted. Files that
+// have been in the trash for trashFor are purged too.
+func NewCleanupWorker(minio *storage.MinIOStorage, pgStore *storage.PostgresStore, redisCache *storage.RedisCache, interval, warnBefore, trashFor time.Duration) *CleanupWorker {
 	return &CleanupWorker{
 		minioStorage: minio,
 		pgStore:      pgStore,
+		redisCache:   redisCache,
 		interval:     interval,
+		warnBefore:   warnBefore,
+		trashFor:     trashFor,
 	}
 }
 
@@ -40,6 +51,25 @@ func (w *CleanupWorker) Start(ctx context.Context) {
 }
 
 func (w *CleanupWorker) cleanup(ctx context.Context) {
+	if w.warnBefore > 0 {
+		w.warnExpiring(ctx)
+	}
+
+	// Nothing is deleted while files are read-only for everyone (e.g. during a
+	// storage migration); expired files are picked up on the first run after
+	if state, err := w.pgStore.GetReadOnly(ctx, ""); err != nil || state.Global {
+		if err != nil {
+			log.Printf("Skipping cleanup, failed to check read-only mode: %v", err)
+		} else {
+			log.Println("Skipping cleanup: read-only mode is on")
+		}
+		return
+	}
+
+	if w.trashFor > 0 {
+		w.purgeTrash(ctx)
+	}
+
 	// Get expired files from PostgreSQL
 	expiredFiles, err := w.pgStore.GetExpiredFiles(ctx)
 	if err != nil {
@@ -52,10 +82,53 @@ func (w *CleanupWorker) cleanup(ctx context.Context) {
 		return
 	}
 
+	filesDeleted, spaceFreed := w.deleteFiles(ctx, expiredFiles)
+	log.Printf("Cleanup completed: %d files deleted, %d bytes freed", filesDeleted, spaceFreed)
+	w.recordRun(ctx, storage.CleanupExpired, filesDeleted, spaceFreed)
+}
+
+// purgeTrash deletes files that have been in the trash longer than trashFor
+func (w *CleanupWorker) purgeTrash(ctx context.Context) {
+	files, err := w.pgStore.GetPurgeableFiles(ctx, time.Now().Add(-w.trashFor))
+	if err != nil {
+		log.Printf("Failed to get purgeable files: %v", err)
+		return
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	filesDeleted, spaceFreed := w.deleteFiles(ctx, files)
+	log.Printf("Trash purged: %d files deleted, %d bytes freed", filesDeleted, spaceFreed)
+	w.recordRun(ctx, storage.CleanupTrash, filesDeleted, spaceFreed)
+}
+
+// SetRunHook sets a function called after every run that deleted files (kind is
+// storage.CleanupExpired or storage.CleanupTrash)
+func (w *CleanupWorker) SetRunHook(hook func(ctx context.Context, kind string, files int, bytes int64)) {
+	w.runHook = hook
+}
+
+// recordRun keeps what a run deleted for the usage reports
+func (w *CleanupWorker) recordRun(ctx context.Context, kind string, files int, bytes int64) {
+	if files == 0 {
+		return
+	}
+	if err := w.pgStore.RecordCleanupRun(ctx, kind, files, bytes); err != nil {
+		log.Printf("Failed to record cleanup run: %v", err)
+	}
+	if w.runHook != nil {
+		w.runHook(ctx, kind, files, bytes)
+	}
+}
+
+// deleteFiles removes files from MinIO and PostgreSQL and returns how many were
+// deleted and their total size
+func (w *CleanupWorker) deleteFiles(ctx context.Context, files []*storage.FileMetadata) (int, int64) {
 	filesDeleted := 0
 	spaceFreed := int64(0)
 
-	for _, metadata := range expiredFiles {
+	for _, metadata := range files {
 		// Delete file from MinIO
 		if err := w.minioStorage.DeleteFile(ctx, metadata.MinIOPath); err != nil {
 			log.Printf("Failed to delete file from MinIO: %s, error: %v", metadata.FileID, err)
@@ -67,10 +140,60 @@ func (w *CleanupWorker) cleanup(ctx context.Context) {
 			log.Printf("Failed to delete file metadata: %s, error: %v", metadata.FileID, err)
 			continue
 		}
+		if err := w.redisCache.InvalidateFileMetadata(ctx, metadata.FileID); err != nil {
+			log.Printf("Failed to invalidate cached metadata: %s, error: %v", metadata.FileID, err)
+		}
 
 		filesDeleted++
 		spaceFreed += metadata.Size
 	}
 
-	log.Printf("Cleanup completed: %d files deleted, %d bytes freed", filesDeleted, spaceFreed)
+	return filesDeleted, spaceFreed
+}
+
+// warnExpiring announces upcoming auto-deletion to owners, once per file and expiry date
+func (w *CleanupWorker) warnExpiring(ctx context.Context) {
+	files, err := w.pgStore.GetFilesPendingExpiryWarning(ctx, time.Now().Add(w.warnBefore))
+	if err != nil {
+		log.Printf("Failed to get files pending expiry warning: %v", err)
+		return
+	}
+
+	// Group by owner so each user gets a single announcement per run
+	byUser := make(map[string][]*storage.FileMetadata)
+	var order []string
+	for _, f := range files {
+		if _, ok := byUser[f.UserID]; !ok {
+			order = append(order, f.UserID)
+		}
+		byUser[f.UserID] = append(byUser[f.UserID], f)
+	}
+
+	for _, userID := range order {
+		userFiles := byUser[userID]
+
+		// Files are ordered by expiry; keep the announcement up until the last one is gone
+		lastExpiry := *userFiles[len(userFiles)-1].ExpiresAt
+
+		title := fmt.Sprintf("%d file(s) will be deleted soon", len(userFiles))
+		message := "The following files will be automatically deleted:\n"
+		ids := make([]string, 0, len(userFiles))
+		for _, f := range userFiles {
+			message += fmt.Sprintf("- %s (expires %s)\n", f.FileName, f.ExpiresAt.UTC().Format(time.RFC1123))
+			ids = append(ids, f.FileID)
+		}
+		message += "Extend the expiry to keep them."
+
+		if _, err := w.pgStore.CreateSystemAnnouncement(ctx, title, message, "warning", []string{userID}, &lastExpiry); err != nil {
+			log.Printf("Failed to create expiry warning for user %s: %v", userID, err)
+			continue
+		}
+
+		if err := w.pgStore.MarkExpiryWarned(ctx, ids); err != nil {
+			log.Printf("Failed to mark expiry warned for user %s: %v", userID, err)
+			continue
+		}
+
+		log.Printf("Expiry warning sent to user %s for %d file(s)", userID, len(userFiles))
+	}
 }
@@ -0,0 +1,269 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/crypto"
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// keyRotationBatch is how many files are processed between progress updates
+const keyRotationBatch = 50
+
+// keyRotationLock serializes rotation jobs across replicas
+const keyRotationLock = "key-rotation"
+
+// keyRotationStale is how long a job must have made no progress before a replica
+// with a different KEK gives it up (during a rolling restart the job belongs to
+// the replicas that already have the new key)
+const keyRotationStale = 5 * time.Minute
+
+// AuditFunc records an audit log entry (api.AuditLogger.LogAdminAction)
+type AuditFunc func(ctx context.Context, actorID, action, targetType, targetID string, metadata map[string]interface{}, ipAddress string) error
+
+// KeyRotator runs key rotation jobs: every file's data key is re-wrapped under the
+// current KEK (and its encrypted metadata re-encrypted), optionally after
+// re-encrypting the object itself with a fresh data key. Progress is stored in
+// Postgres, so a job interrupted by a restart is resumed by Start; a Redis lock
+// makes sure only one replica works on it.
+type KeyRotator struct {
+	minioStorage *storage.MinIOStorage
+	pgStore      *storage.PostgresStore
+	redisCache   *storage.RedisCache
+	audit        AuditFunc
+
+	mu  sync.Mutex
+	ctx context.Context // server lifetime; set by Start
+}
+
+func NewKeyRotator(minio *storage.MinIOStorage, pgStore *storage.PostgresStore, redisCache *storage.RedisCache, audit AuditFunc) *KeyRotator {
+	return &KeyRotator{
+		minioStorage: minio,
+		pgStore:      pgStore,
+		redisCache:   redisCache,
+		audit:        audit,
+		ctx:          context.Background(),
+	}
+}
+
+// Start watches for rotations that are not being worked on (left by a stopped
+// replica or started on a busy one) and runs them on whichever replica holds the
+// rotation lock. Jobs stop (and stay resumable) when ctx is cancelled.
+func (k *KeyRotator) Start(ctx context.Context) {
+	k.mu.Lock()
+	k.ctx = ctx
+	k.mu.Unlock()
+
+	go RunAsLeader(ctx, k.redisCache, keyRotationLock, k.resume)
+}
+
+// resume runs the pending job, if any, to completion; called under the rotation lock
+func (k *KeyRotator) resume(ctx context.Context) {
+	job, err := k.pgStore.GetRunningKeyRotation(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+	if err != nil {
+		log.Printf("[keys] Failed to check for an unfinished key rotation: %v", err)
+		return
+	}
+
+	if job.KEKID != k.pgStore.CurrentKEKID() {
+		if time.Since(job.UpdatedAt) < keyRotationStale {
+			return // a replica with the job's KEK will pick it up
+		}
+		// The KEK changed again since the job started; its target is gone
+		log.Printf("[keys] Abandoning key rotation %s to KEK %s (current KEK is %q)", job.ID, job.KEKID, k.pgStore.CurrentKEKID())
+		_ = k.pgStore.FinishKeyRotation(ctx, job.ID, storage.KeyRotationFailed, "key-encryption key changed before the rotation finished")
+		return
+	}
+
+	if job.LastFileID != "" {
+		log.Printf("[keys] Resuming key rotation %s after file %s", job.ID, job.LastFileID)
+		_ = k.audit(ctx, job.StartedBy, "KEY_ROTATION_RESUMED", "system", job.ID, map[string]interface{}{
+			"kek_id":          job.KEKID,
+			"processed_files": job.ProcessedFiles,
+			"total_files":     job.TotalFiles,
+		}, "")
+	}
+	k.run(ctx, job)
+}
+
+// Rotate starts a rotation to the current KEK in the background and returns the new job.
+// Returns storage.ErrKeyRotationRunning if one is already in progress.
+func (k *KeyRotator) Rotate(actorID string, reencryptObjects bool, ipAddress string) (*storage.KeyRotation, error) {
+	k.mu.Lock()
+	ctx := k.ctx
+	k.mu.Unlock()
+
+	job, err := k.pgStore.CreateKeyRotation(ctx, reencryptObjects, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = k.audit(ctx, actorID, "KEY_ROTATION_STARTED", "system", job.ID, map[string]interface{}{
+		"kek_id":            job.KEKID,
+		"reencrypt_objects": reencryptObjects,
+		"total_files":       job.TotalFiles,
+	}, ipAddress)
+	log.Printf("[keys] Key rotation %s started by %s: %d files", job.ID, actorID, job.TotalFiles)
+
+	lockCtx, release, ok, err := HoldLock(ctx, k.redisCache, keyRotationLock)
+	if err != nil {
+		log.Printf("[keys] Failed to take the key rotation lock: %v", err)
+	}
+	if ok {
+		go func() {
+			defer release()
+			k.run(lockCtx, job)
+		}()
+	} // otherwise the replica holding the lock picks the job up
+	return job, nil
+}
+
+func (k *KeyRotator) run(ctx context.Context, job *storage.KeyRotation) {
+	for {
+		files, err := k.pgStore.ListFilesForRotation(ctx, job.LastFileID, job.ReencryptObjects, keyRotationBatch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return // shutting down; resumed on next start
+			}
+			k.finish(ctx, job, storage.KeyRotationFailed, err.Error())
+			return
+		}
+		if len(files) == 0 {
+			k.finish(ctx, job, storage.KeyRotationCompleted, "")
+			return
+		}
+
+		var rotated []string
+		for _, f := range files {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err := k.rotateFile(ctx, job, f); err != nil {
+				log.Printf("[keys] Failed to rotate key of file %s: %v", f.FileID, err)
+				job.FailedFiles++
+				job.LastError = fmt.Sprintf("file %s: %v", f.FileID, err)
+			} else {
+				job.ProcessedFiles++
+				rotated = append(rotated, f.FileID)
+			}
+			job.LastFileID = f.FileID
+		}
+
+		if len(rotated) > 0 {
+			_ = k.redisCache.InvalidateFileMetadata(ctx, rotated...)
+		}
+		if err := k.pgStore.UpdateKeyRotationProgress(ctx, job); err != nil {
+			log.Printf("[keys] %v", err)
+		}
+		log.Printf("[keys] Key rotation %s: %d/%d files (%d failed)", job.ID, job.ProcessedFiles, job.TotalFiles, job.FailedFiles)
+	}
+}
+
+func (k *KeyRotator) rotateFile(ctx context.Context, job *storage.KeyRotation, f *storage.FileMetadata) error {
+	if !job.ReencryptObjects {
+		return k.pgStore.RotateFileKey(ctx, f.FileID, nil)
+	}
+	return k.ReencryptFile(ctx, f)
+}
+
+// ReencryptFile re-encrypts a file's object with a new data key, wrapped under the
+// current KEK (if any)
+func (k *KeyRotator) ReencryptFile(ctx context.Context, f *storage.FileMetadata) error {
+	oldKey, err := k.pgStore.DataKey(ctx, f)
+	if err != nil {
+		return err
+	}
+	newKey, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+
+	src, err := k.minioStorage.GetFile(ctx, f.MinIOPath)
+	if err != nil {
+		return fmt.Errorf("failed to read object: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	plaintext, err := crypto.DecryptStream(src, oldKey)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := crypto.EncryptStream(plaintext, newKey)
+	if err != nil {
+		return err
+	}
+
+	// Write the new object next to the old one, switch the row over, then drop the
+	// old object, so a crash at any point leaves a readable file
+	newPath, err := k.minioStorage.ObjectPath(ctx, f.UserID, fmt.Sprintf("%s.%d", f.FileID, time.Now().UnixNano()))
+	if err != nil {
+		return err
+	}
+	if err := k.minioStorage.SaveFile(ctx, newPath, ciphertext, f.EncryptedSize, "application/octet-stream", f.ObjectLabels()); err != nil {
+		return fmt.Errorf("failed to write re-encrypted object: %w", err)
+	}
+
+	// The thumbnail is encrypted with the data key too
+	var thumbnail []byte
+	if data, _, err := k.pgStore.GetThumbnail(ctx, f.FileID); err == nil {
+		plain, err := crypto.DecryptBytes(data, oldKey)
+		if err == nil {
+			thumbnail, err = crypto.EncryptBytes(plain, newKey)
+		}
+		if err != nil {
+			_ = k.minioStorage.DeleteFile(context.WithoutCancel(ctx), newPath)
+			return fmt.Errorf("failed to re-encrypt thumbnail: %w", err)
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		_ = k.minioStorage.DeleteFile(context.WithoutCancel(ctx), newPath)
+		return err
+	}
+
+	err = k.pgStore.RotateFileKey(ctx, f.FileID, &storage.FileKeyChange{
+		OldKey:    f.EncryptionKey,
+		NewKey:    base64.StdEncoding.EncodeToString(newKey),
+		MinIOPath: newPath,
+		Thumbnail: thumbnail,
+	})
+	if err != nil {
+		_ = k.minioStorage.DeleteFile(context.WithoutCancel(ctx), newPath)
+		return err
+	}
+
+	if err := k.minioStorage.DeleteFile(ctx, f.MinIOPath); err != nil {
+		log.Printf("[keys] Failed to delete old object %s (now orphaned): %v", f.MinIOPath, err)
+	}
+	return nil
+}
+
+func (k *KeyRotator) finish(ctx context.Context, job *storage.KeyRotation, status, lastError string) {
+	if err := k.pgStore.UpdateKeyRotationProgress(ctx, job); err != nil {
+		log.Printf("[keys] %v", err)
+	}
+	if err := k.pgStore.FinishKeyRotation(ctx, job.ID, status, lastError); err != nil {
+		log.Printf("[keys] %v", err)
+	}
+
+	action := "KEY_ROTATION_COMPLETED"
+	if status == storage.KeyRotationFailed {
+		action = "KEY_ROTATION_FAILED"
+	}
+	_ = k.audit(ctx, job.StartedBy, action, "system", job.ID, map[string]interface{}{
+		"kek_id":          job.KEKID,
+		"processed_files": job.ProcessedFiles,
+		"failed_files":    job.FailedFiles,
+		"error":           lastError,
+	}, "")
+	log.Printf("[keys] Key rotation %s %s: %d files rotated, %d failed", job.ID, status, job.ProcessedFiles, job.FailedFiles)
+}
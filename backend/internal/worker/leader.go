@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// lockTTL is how long a lock outlives a replica that died while holding it
+const lockTTL = 30 * time.Second
+
+// instanceID identifies this server process as a lock owner
+var instanceID = newInstanceID()
+
+// InstanceID identifies this server process, e.g. as the holder of a lock or job lease
+func InstanceID() string {
+	return instanceID
+}
+
+func newInstanceID() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b))
+}
+
+// HoldLock takes the named Redis lock and keeps renewing it until release is called.
+// The returned context is cancelled when the lock is lost (or ctx ends), so work
+// done under it stops before another replica can take over. ok is false when
+// another replica holds the lock.
+func HoldLock(ctx context.Context, redisCache *storage.RedisCache, name string) (lockCtx context.Context, release func(), ok bool, err error) {
+	ok, err = redisCache.AcquireLock(ctx, name, instanceID, lockTTL)
+	if err != nil || !ok {
+		return nil, nil, false, err
+	}
+
+	lockCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(lockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-lockCtx.Done():
+				return
+			case <-ticker.C:
+				held, err := redisCache.RenewLock(lockCtx, name, instanceID, lockTTL)
+				if err != nil && lockCtx.Err() == nil {
+					log.Printf("[lock] Failed to renew lock %s: %v", name, err)
+				}
+				if !held && lockCtx.Err() == nil {
+					log.Printf("[lock] Lost lock %s", name)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	release = func() {
+		cancel()
+		<-done
+		_ = redisCache.ReleaseLock(context.WithoutCancel(ctx), name, instanceID)
+	}
+	return lockCtx, release, true, nil
+}
+
+// RunAsLeader runs fn on exactly one replica at a time: it campaigns for the named
+// lock and, once elected, runs fn with a context that ends when leadership is lost.
+// When fn returns (or leadership is lost) it campaigns again. Returns when ctx ends.
+func RunAsLeader(ctx context.Context, redisCache *storage.RedisCache, name string, fn func(ctx context.Context)) {
+	for {
+		lockCtx, release, ok, err := HoldLock(ctx, redisCache, name)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[lock] Failed to campaign for %s: %v", name, err)
+		}
+		if ok {
+			log.Printf("[lock] Elected leader for %s (%s)", name, instanceID)
+			fn(lockCtx)
+			release()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(lockTTL / 3):
+		}
+	}
+}
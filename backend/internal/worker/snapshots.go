@@ -0,0 +1,286 @@
+package worker
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sachinthra/file-locker/backend/internal/storage"
+)
+
+// Objects of a snapshot; the manifest is written last, so snapshots without one
+// are incomplete and ignored
+const (
+	snapshotManifest  = "manifest.json"
+	snapshotFiles     = "files.jsonl.gz"   // the files table, see PostgresStore.ExportFiles
+	snapshotInventory = "objects.jsonl.gz" // {"path", "size"} of every stored object
+)
+
+// snapshotIDFormat names snapshots by when they were taken (UTC)
+const snapshotIDFormat = "20060102T150405Z"
+
+var snapshotIDPattern = regexp.MustCompile(`^\d{8}T\d{6}Z$`)
+
+// snapshotLabels mark snapshot objects for lifecycle rules; they belong to no user
+var snapshotLabels = storage.ObjectLabels{Tier: storage.TierSnapshot}
+
+// restoreBatch is how many snapshot rows are checked against the files table at once
+const restoreBatch = 500
+
+// Snapshot describes a metadata snapshot (its manifest)
+type Snapshot struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by,omitempty"` // admin who took it; "" when scheduled
+	WALLSN      string    `json:"wal_lsn"`              // database WAL position the export corresponds to
+	Files       int       `json:"files"`
+	Objects     int       `json:"objects"`
+	ObjectBytes int64     `json:"object_bytes"`
+}
+
+// InventoryObject is an entry of a snapshot's object inventory
+type InventoryObject struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// Snapshotter takes logical backups of the file metadata: an export of the files
+// table and an inventory of the stored objects, kept in MinIO next to the data.
+// They are what an admin restores deleted files rows from, as long as the objects
+// still exist.
+type Snapshotter struct {
+	minioStorage *storage.MinIOStorage
+	pgStore      *storage.PostgresStore
+	interval     time.Duration
+	keep         int
+}
+
+// NewSnapshotter creates the snapshotter; Start takes a snapshot every interval
+// and keeps the latest keep (0 = all)
+func NewSnapshotter(minio *storage.MinIOStorage, pgStore *storage.PostgresStore, interval time.Duration, keep int) *Snapshotter {
+	return &Snapshotter{
+		minioStorage: minio,
+		pgStore:      pgStore,
+		interval:     interval,
+		keep:         keep,
+	}
+}
+
+// Start takes scheduled snapshots until ctx is cancelled; run it on one replica
+func (s *Snapshotter) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.Take(ctx, ""); err != nil {
+				log.Printf("[snapshots] Scheduled snapshot failed: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Take takes a snapshot now, then deletes the ones beyond the number kept
+func (s *Snapshotter) Take(ctx context.Context, createdBy string) (*Snapshot, error) {
+	now := time.Now().UTC()
+	snap := &Snapshot{ID: now.Format(snapshotIDFormat), CreatedAt: now, CreatedBy: createdBy}
+	dir := storage.SnapshotPrefix + snap.ID + "/"
+
+	err := s.writeGzip(ctx, dir+snapshotFiles, func(w io.Writer) error {
+		var err error
+		snap.WALLSN, snap.Files, err = s.pgStore.ExportFiles(ctx, w)
+		return err
+	})
+	if err == nil {
+		err = s.writeGzip(ctx, dir+snapshotInventory, func(w io.Writer) error {
+			objects, err := s.minioStorage.ListAllObjects(ctx)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(w)
+			for _, o := range objects {
+				if strings.HasPrefix(o.Key, storage.SnapshotPrefix) {
+					continue
+				}
+				if err := enc.Encode(InventoryObject{Path: o.Key, Size: o.Size}); err != nil {
+					return err
+				}
+				snap.Objects++
+				snap.ObjectBytes += o.Size
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		var manifest []byte
+		manifest, err = json.Marshal(snap)
+		if err == nil {
+			err = s.minioStorage.SaveFile(ctx, dir+snapshotManifest, strings.NewReader(string(manifest)), int64(len(manifest)), "application/json", snapshotLabels)
+		}
+	}
+	if err != nil {
+		s.deleteSnapshot(context.WithoutCancel(ctx), snap.ID)
+		return nil, fmt.Errorf("snapshot %s: %w", snap.ID, err)
+	}
+	log.Printf("[snapshots] Snapshot %s taken: %d files, %d objects, WAL %s", snap.ID, snap.Files, snap.Objects, snap.WALLSN)
+
+	s.prune(ctx)
+	return snap, nil
+}
+
+// writeGzip stores what write writes, gzipped, as the object path
+func (s *Snapshotter) writeGzip(ctx context.Context, path string, write func(io.Writer) error) error {
+	pr, pw := io.Pipe()
+	go func() {
+		zw := gzip.NewWriter(pw)
+		err := write(zw)
+		if closeErr := zw.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+	err := s.minioStorage.SaveFile(ctx, path, pr, -1, "application/gzip", snapshotLabels)
+	_ = pr.CloseWithError(err) // unblocks the writer if the upload failed
+	return err
+}
+
+// List returns the complete snapshots, newest first
+func (s *Snapshotter) List(ctx context.Context) ([]*Snapshot, error) {
+	objects, err := s.minioStorage.ListPrefix(ctx, storage.SnapshotPrefix)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := []*Snapshot{}
+	for _, o := range objects {
+		if !strings.HasSuffix(o.Key, "/"+snapshotManifest) {
+			continue
+		}
+		snap, err := s.readManifest(ctx, o.Key)
+		if err != nil {
+			log.Printf("[snapshots] Skipping %s: %v", o.Key, err)
+			continue
+		}
+		snapshots = append(snapshots, snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID > snapshots[j].ID })
+	return snapshots, nil
+}
+
+// Get returns a snapshot, or nil if there is no complete snapshot with that ID
+func (s *Snapshotter) Get(ctx context.Context, id string) (*Snapshot, error) {
+	if !snapshotIDPattern.MatchString(id) {
+		return nil, nil
+	}
+	objects, err := s.minioStorage.ListPrefix(ctx, storage.SnapshotPrefix+id+"/"+snapshotManifest)
+	if err != nil || len(objects) == 0 {
+		return nil, err
+	}
+	return s.readManifest(ctx, objects[0].Key)
+}
+
+func (s *Snapshotter) readManifest(ctx context.Context, path string) (*Snapshot, error) {
+	r, err := s.minioStorage.GetFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	var snap Snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return &snap, nil
+}
+
+// DeletedFiles returns the files of a snapshot that no longer have a files row,
+// only those in ids unless it is empty
+func (s *Snapshotter) DeletedFiles(ctx context.Context, id string, ids map[string]bool) ([]*storage.SnapshotFile, error) {
+	r, err := s.minioStorage.GetFile(ctx, storage.SnapshotPrefix+id+"/"+snapshotFiles)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", id, err)
+	}
+
+	var deleted, batch []*storage.SnapshotFile
+	check := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		batchIDs := make([]string, len(batch))
+		for i, f := range batch {
+			batchIDs[i] = f.FileID
+		}
+		missing, err := s.pgStore.MissingFileIDs(ctx, batchIDs)
+		if err != nil {
+			return err
+		}
+		for _, f := range batch {
+			if missing[f.FileID] {
+				deleted = append(deleted, f)
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 64<<10), 16<<20)
+	for scanner.Scan() {
+		f, err := storage.ParseSnapshotFile(scanner.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 && !ids[f.FileID] {
+			continue
+		}
+		if batch = append(batch, f); len(batch) == restoreBatch {
+			if err := check(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", id, err)
+	}
+	if err := check(); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// prune deletes the oldest snapshots beyond the number kept
+func (s *Snapshotter) prune(ctx context.Context) {
+	if s.keep <= 0 {
+		return
+	}
+	snapshots, err := s.List(ctx)
+	if err != nil {
+		log.Printf("[snapshots] Failed to list snapshots for pruning: %v", err)
+		return
+	}
+	for i := s.keep; i < len(snapshots); i++ {
+		s.deleteSnapshot(ctx, snapshots[i].ID)
+	}
+}
+
+func (s *Snapshotter) deleteSnapshot(ctx context.Context, id string) {
+	for _, name := range []string{snapshotManifest, snapshotFiles, snapshotInventory} {
+		if err := s.minioStorage.DeleteFile(ctx, storage.SnapshotPrefix+id+"/"+name); err != nil {
+			log.Printf("[snapshots] Failed to delete %s of snapshot %s: %v", name, id, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Session is the result of a password login or a refresh
+type Session struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"` // works once; Refresh returns the next
+	ExpiresIn    int    `json:"expires_in"`    // seconds until Token expires
+	UserID       string `json:"user_id"`
+	Email        string `json:"email,omitempty"`
+}
+
+// Login signs in with a username and password. It does not change c.Token.
+func (c *Client) Login(ctx context.Context, username, password string) (*Session, error) {
+	var session Session
+	err := c.do(ctx, http.MethodPost, "/auth/login", map[string]string{
+		"username": username,
+		"password": password,
+	}, &session)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Refresh exchanges a refresh token for new tokens. It does not change c.Token.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*Session, error) {
+	var session Session
+	err := c.do(ctx, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": refreshToken}, &session)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Logout ends the session of c.Token
+func (c *Client) Logout(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/auth/logout", nil, nil)
+}
+
+// User is the signed-in user
+type User struct {
+	ID           string    `json:"user_id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	DisplayName  string    `json:"display_name"`
+	PendingEmail string    `json:"pending_email"` // waiting for verification
+	AvatarURL    string    `json:"avatar_url"`
+	Role         string    `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+	ReadOnly     bool      `json:"read_only"` // uploads and edits are refused
+	Quota        *Quota    `json:"quota"`     // nil when the server sets no quota
+}
+
+// Quota enforcement states
+const (
+	QuotaOK      = "ok"
+	QuotaGrace   = "grace"   // over the quota, uploads still work until GraceEndsAt
+	QuotaBlocked = "blocked" // over the quota past the grace period, uploads are refused
+)
+
+// Quota is a user's storage against the per-user quota
+type Quota struct {
+	State       string     `json:"state"`
+	QuotaBytes  int64      `json:"quota_bytes"`
+	UsedBytes   int64      `json:"used_bytes"`
+	OverSince   *time.Time `json:"over_since"`
+	GraceEndsAt *time.Time `json:"grace_ends_at"` // nil when uploads are never refused
+}
+
+// Me returns the user c.Token belongs to
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, http.MethodGet, "/auth/me", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// TransferDay is the bytes served from the user's files in a day (UTC)
+type TransferDay struct {
+	Day           string `json:"day"` // YYYY-MM-DD
+	DownloadBytes int64  `json:"download_bytes"`
+	StreamBytes   int64  `json:"stream_bytes"`
+	ExportBytes   int64  `json:"export_bytes"`
+	Bytes         int64  `json:"bytes"`
+}
+
+// MonthTransfer is the bytes served from the user's files this month
+type MonthTransfer struct {
+	Month    string        `json:"month"` // YYYY-MM, UTC
+	Bytes    int64         `json:"bytes"`
+	CapBytes int64         `json:"cap_bytes"` // 0 = no cap
+	ResetsAt time.Time     `json:"resets_at"`
+	Days     []TransferDay `json:"days"`
+}
+
+// Transfer returns the bytes served from the user's files this month (downloads,
+// streams and exports) against the monthly cap. Older servers answer with a 404
+// *Error.
+func (c *Client) Transfer(ctx context.Context) (*MonthTransfer, error) {
+	var transfer MonthTransfer
+	if err := c.do(ctx, http.MethodGet, "/user/transfer", nil, &transfer); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// ServerVersion is what the server reports about itself
+type ServerVersion struct {
+	Version     string          `json:"version"`
+	GitCommit   string          `json:"git_commit"`
+	BuildDate   string          `json:"build_date"`
+	APIVersion  int             `json:"api_version"`  // newest API version served
+	APIVersions []int           `json:"api_versions"` // all served
+	Features    map[string]bool `json:"features"`
+}
+
+// Version returns the server's version and features. Servers that predate
+// version reporting answer with a 404 *Error.
+func (c *Client) Version(ctx context.Context) (*ServerVersion, error) {
+	var version ServerVersion
+	if err := c.do(ctx, http.MethodGet, "/version", nil, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
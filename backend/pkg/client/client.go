@@ -0,0 +1,179 @@
+// Package client is a Go client for the File Locker HTTP API. It covers what
+// programs built on top of File Locker need most: signing in, uploading and
+// downloading files (with checksums checked on both ends), listing and deleting
+// files, and share links. The fl CLI uses it too.
+//
+//	c := client.New("http://localhost:9010/api/v1", "")
+//	session, err := c.Login(ctx, "alice", "secret")
+//	if err != nil { ... }
+//	c.Token = session.Token
+//	page, err := c.ListFiles(ctx, client.ListOptions{})
+//
+// Failed requests return an *Error with the status, code and request ID of the
+// server's response. The full API, one method per operation, is in the generated
+// package client/openapi.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Client talks to one File Locker server. Its fields may be changed between
+// requests (e.g. Token after Login or Refresh) but not during them.
+type Client struct {
+	// BaseURL is the API root, e.g. http://localhost:9010/api/v1
+	BaseURL string
+
+	// Token is sent as the bearer token: an access token from Login or Refresh,
+	// or a personal access token. Empty for anonymous requests.
+	Token string
+
+	// HTTPClient sends the requests; nil means http.DefaultClient. Uploads and
+	// downloads stream, so a Timeout here limits whole transfers.
+	HTTPClient *http.Client
+}
+
+// New creates a client for the API at baseURL
+func New(baseURL, token string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), Token: token}
+}
+
+// Error is an error response of the server
+type Error struct {
+	Status    int    // HTTP status
+	Code      string // e.g. FILE_NOT_FOUND; see the ErrorCode schema of the API
+	Message   string
+	RequestID string // ties the response to the server logs
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.Status)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("%s (status %d, %s)", msg, e.Status, e.Code)
+	}
+	return fmt.Sprintf("%s (status %d)", msg, e.Status)
+}
+
+// IsStatus reports whether err is an error response with the given status
+func IsStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Status == status
+}
+
+// Retryable reports whether repeating the request may succeed: the server was
+// not reached, a gateway failed, or (409) an earlier attempt with the same
+// idempotency key is still being processed
+func Retryable(err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Status {
+		case http.StatusConflict, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// newRequest creates a request for path (relative to BaseURL, with its query)
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return req, nil
+}
+
+// send sends req and turns error responses into an *Error. The caller closes
+// the body of successful responses.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer func() { _ = resp.Body.Close() }()
+		return nil, parseError(resp)
+	}
+	return resp, nil
+}
+
+// parseError reads an error response. Bodies that are not the API's JSON error
+// (e.g. from a proxy) become the message.
+func parseError(resp *http.Response) *Error {
+	apiErr := &Error{Status: resp.StatusCode, RequestID: resp.Header.Get("X-Request-ID")}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	var parsed struct {
+		Error     string `json:"error"`
+		Code      string `json:"code"`
+		RequestID string `json:"request_id"`
+	}
+	if json.Unmarshal(body, &parsed) == nil && parsed.Error != "" {
+		apiErr.Message = parsed.Error
+		apiErr.Code = parsed.Code
+		if parsed.RequestID != "" {
+			apiErr.RequestID = parsed.RequestID
+		}
+		return apiErr
+	}
+	apiErr.Message = strings.TrimSpace(string(body))
+	return apiErr
+}
+
+// do sends a JSON request (in may be nil) and decodes the response into out
+// (which may be nil)
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = strings.NewReader(string(data))
+	}
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// File is the metadata of a stored file
+type File struct {
+	FileID            string     `json:"file_id"`
+	FileName          string     `json:"file_name"`
+	Description       string     `json:"description,omitempty"`
+	MimeType          string     `json:"mime_type"`
+	Size              int64      `json:"size"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	Tags              []string   `json:"tags,omitempty"`
+	Folder            string     `json:"folder"`
+	DownloadCount     int        `json:"download_count"`
+	PasswordProtected bool       `json:"password_protected"`
+	Starred           bool       `json:"starred"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+	SHA256            string     `json:"sha256,omitempty"`      // "" for files uploaded before checksums were recorded
+	ModifiedAt        *time.Time `json:"modified_at,omitempty"` // as set on upload or with UpdateFile
+	Quarantined       bool       `json:"quarantined,omitempty"` // held back for review; share links do not serve it
+}
+
+// ListOptions selects a page of a file listing or search. Listings are newest
+// first and paged with opaque cursors: pass NextCursor of a page to get the next.
+type ListOptions struct {
+	Limit  int    // files per page; 0 for the user's page size preference
+	Cursor string // NextCursor of the previous page; "" for the first
+}
+
+// FilePage is one page of a file listing
+type FilePage struct {
+	Files      []File `json:"files"`
+	NextCursor string `json:"next_cursor"` // "" on the last page
+}
+
+// ListFiles returns a page of the user's files, newest first
+func (c *Client) ListFiles(ctx context.Context, opts ListOptions) (*FilePage, error) {
+	return c.listPage(ctx, "/files", url.Values{}, opts)
+}
+
+// AllFiles returns all of the user's files, following the pages
+func (c *Client) AllFiles(ctx context.Context) ([]File, error) {
+	return c.listAll(ctx, "/files")
+}
+
+// SearchFiles returns a page of the user's files whose name, description or tags
+// match query, newest first
+func (c *Client) SearchFiles(ctx context.Context, query string, opts ListOptions) (*FilePage, error) {
+	return c.listPage(ctx, "/files/search", url.Values{"q": {query}}, opts)
+}
+
+// Trash returns the files in the user's trash
+func (c *Client) Trash(ctx context.Context) ([]File, error) {
+	return c.listAll(ctx, "/files/trash")
+}
+
+func (c *Client) listPage(ctx context.Context, path string, query url.Values, opts ListOptions) (*FilePage, error) {
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var page FilePage
+	if err := c.do(ctx, http.MethodGet, path, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (c *Client) listAll(ctx context.Context, path string) ([]File, error) {
+	var files []File
+	opts := ListOptions{}
+	for {
+		page, err := c.listPage(ctx, path, url.Values{}, opts)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, page.Files...)
+		if page.NextCursor == "" {
+			return files, nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// FileDetails is a file with its share links and downloads
+type FileDetails struct {
+	File
+	Shares        []Share `json:"shares"`
+	DownloadStats struct {
+		Downloads      int        `json:"downloads"`
+		Bytes          int64      `json:"bytes"`
+		ViaShares      int        `json:"via_shares"`
+		Downloaders    int        `json:"downloaders"` // distinct users, and IPs for anonymous downloads
+		LastDownloaded *time.Time `json:"last_downloaded,omitempty"`
+	} `json:"download_stats"`
+	RecentDownloads []DownloadEvent `json:"recent_downloads"` // newest first
+}
+
+// DownloadEvent is one recorded download of a file
+type DownloadEvent struct {
+	At        time.Time `json:"at"`
+	UserID    string    `json:"user_id,omitempty"`  // "" for anonymous share downloads
+	ShareID   string    `json:"share_id,omitempty"` // share link it was served through
+	IPAddress string    `json:"ip_address,omitempty"`
+	Bytes     int64     `json:"bytes"`
+}
+
+// GetFile returns the metadata of one file with its share links and latest downloads
+func (c *Client) GetFile(ctx context.Context, fileID string) (*FileDetails, error) {
+	var file FileDetails
+	if err := c.do(ctx, http.MethodGet, "/files/"+url.PathEscape(fileID), nil, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// FileStat is what the server tells about a file without sending its metadata
+type FileStat struct {
+	Size   int64
+	SHA256 string // "" for files uploaded before checksums were recorded
+	ETag   string
+}
+
+// StatFile checks that a file exists with a HEAD request. A file that does not
+// exist (or is in the trash) fails with an error IsStatus(err, http.StatusNotFound)
+// matches.
+func (c *Client) StatFile(ctx context.Context, fileID string) (*FileStat, error) {
+	req, err := c.newRequest(ctx, http.MethodHead, "/files/"+url.PathEscape(fileID), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	size, _ := strconv.ParseInt(resp.Header.Get("X-File-Size"), 10, 64)
+	return &FileStat{Size: size, SHA256: resp.Header.Get("X-Checksum-SHA256"), ETag: resp.Header.Get("ETag")}, nil
+}
+
+// FileUpdate changes the metadata of a file; nil fields are left unchanged.
+// FileName and Folder together move a file anywhere.
+type FileUpdate struct {
+	FileName    *string    `json:"file_name,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Tags        *[]string  `json:"tags,omitempty"`
+	Folder      *string    `json:"folder,omitempty"`
+	ModifiedAt  *time.Time `json:"modified_at,omitempty"`
+}
+
+// UpdateFile changes the metadata of a file
+func (c *Client) UpdateFile(ctx context.Context, fileID string, update FileUpdate) error {
+	return c.do(ctx, http.MethodPatch, "/files/"+url.PathEscape(fileID), update, nil)
+}
+
+// CopyFile copies a file on the server, without downloading and uploading it.
+// An empty fileName or folder keeps the original's.
+func (c *Client) CopyFile(ctx context.Context, fileID, fileName, folder string) (*File, error) {
+	return c.placeFile(ctx, fileID, "copy", fileName, folder)
+}
+
+// MoveFile moves a file to another folder and/or renames it. An empty fileName
+// or folder leaves it unchanged.
+func (c *Client) MoveFile(ctx context.Context, fileID, fileName, folder string) (*File, error) {
+	return c.placeFile(ctx, fileID, "move", fileName, folder)
+}
+
+func (c *Client) placeFile(ctx context.Context, fileID, op, fileName, folder string) (*File, error) {
+	req := map[string]string{}
+	if fileName != "" {
+		req["file_name"] = fileName
+	}
+	if folder != "" {
+		req["folder"] = folder
+	}
+	var file File
+	if err := c.do(ctx, http.MethodPost, "/files/"+url.PathEscape(fileID)+"/"+op, req, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DeleteFile moves a file to the trash, or deletes it for good when permanent is
+// set. It reports whether the file went to the trash (servers without one, or
+// with it turned off, always delete permanently).
+func (c *Client) DeleteFile(ctx context.Context, fileID string, permanent bool) (bool, error) {
+	query := url.Values{"id": {fileID}}
+	if permanent {
+		query.Set("permanent", "true")
+	}
+	var result struct {
+		PurgeAt *time.Time `json:"purge_at"`
+	}
+	if err := c.do(ctx, http.MethodDelete, "/files?"+query.Encode(), nil, &result); err != nil {
+		return false, err
+	}
+	return result.PurgeAt != nil, nil
+}
@@ -0,0 +1,21051 @@
+// Package openapi provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for AnnouncementSeverity.
+const (
+	AnnouncementSeverityError   AnnouncementSeverity = "error"
+	AnnouncementSeverityInfo    AnnouncementSeverity = "info"
+	AnnouncementSeverityWarning AnnouncementSeverity = "warning"
+)
+
+// Defines values for ErrorCode.
+const (
+	ACCOUNTPENDING        ErrorCode = "ACCOUNT_PENDING"
+	ACCOUNTREJECTED       ErrorCode = "ACCOUNT_REJECTED"
+	ACCOUNTSUSPENDED      ErrorCode = "ACCOUNT_SUSPENDED"
+	ADMINREQUIRED         ErrorCode = "ADMIN_REQUIRED"
+	AUTHREQUIRED          ErrorCode = "AUTH_REQUIRED"
+	BADREQUEST            ErrorCode = "BAD_REQUEST"
+	CAPTCHAREQUIRED       ErrorCode = "CAPTCHA_REQUIRED"
+	CHECKSUMMISMATCH      ErrorCode = "CHECKSUM_MISMATCH"
+	CONFLICT              ErrorCode = "CONFLICT"
+	EMAILTAKEN            ErrorCode = "EMAIL_TAKEN"
+	FILEEXPIRED           ErrorCode = "FILE_EXPIRED"
+	FILENOTFOUND          ErrorCode = "FILE_NOT_FOUND"
+	FILEPASSWORDINVALID   ErrorCode = "FILE_PASSWORD_INVALID"
+	FILEPASSWORDREQUIRED  ErrorCode = "FILE_PASSWORD_REQUIRED"
+	FILETOOLARGE          ErrorCode = "FILE_TOO_LARGE"
+	FORBIDDEN             ErrorCode = "FORBIDDEN"
+	GONE                  ErrorCode = "GONE"
+	IDEMPOTENCYINPROGRESS ErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	IDEMPOTENCYKEYREUSED  ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INSUFFICIENTSCOPE     ErrorCode = "INSUFFICIENT_SCOPE"
+	INSUFFICIENTSTORAGE   ErrorCode = "INSUFFICIENT_STORAGE"
+	INTERNALERROR         ErrorCode = "INTERNAL_ERROR"
+	INVALIDCREDENTIALS    ErrorCode = "INVALID_CREDENTIALS"
+	INVALIDTOKEN          ErrorCode = "INVALID_TOKEN"
+	IPBLOCKED             ErrorCode = "IP_BLOCKED"
+	LINKEXPIRED           ErrorCode = "LINK_EXPIRED"
+	LINKINVALID           ErrorCode = "LINK_INVALID"
+	MAINTENANCE           ErrorCode = "MAINTENANCE"
+	METHODNOTALLOWED      ErrorCode = "METHOD_NOT_ALLOWED"
+	NOTFOUND              ErrorCode = "NOT_FOUND"
+	PAYLOADTOOLARGE       ErrorCode = "PAYLOAD_TOO_LARGE"
+	PRECONDITIONFAILED    ErrorCode = "PRECONDITION_FAILED"
+	QUOTAEXCEEDED         ErrorCode = "QUOTA_EXCEEDED"
+	RANGENOTSATISFIABLE   ErrorCode = "RANGE_NOT_SATISFIABLE"
+	RATELIMITED           ErrorCode = "RATE_LIMITED"
+	READONLY              ErrorCode = "READ_ONLY"
+	SERVICEUNAVAILABLE    ErrorCode = "SERVICE_UNAVAILABLE"
+	SHAREDISABLED         ErrorCode = "SHARE_DISABLED"
+	SHAREEXPIRED          ErrorCode = "SHARE_EXPIRED"
+	SHARENOTFOUND         ErrorCode = "SHARE_NOT_FOUND"
+	TIMEOUT               ErrorCode = "TIMEOUT"
+	TOOMANYATTEMPTS       ErrorCode = "TOO_MANY_ATTEMPTS"
+	TRANSFERCAPEXCEEDED   ErrorCode = "TRANSFER_CAP_EXCEEDED"
+	UNAUTHORIZED          ErrorCode = "UNAUTHORIZED"
+	UNSUPPORTEDMEDIATYPE  ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	USERNAMETAKEN         ErrorCode = "USERNAME_TAKEN"
+	USERNOTFOUND          ErrorCode = "USER_NOT_FOUND"
+	VERIFICATIONINVALID   ErrorCode = "VERIFICATION_INVALID"
+)
+
+// Defines values for FileStageStage.
+const (
+	Checksum  FileStageStage = "checksum"
+	Thumbnail FileStageStage = "thumbnail"
+	VirusScan FileStageStage = "virus_scan"
+	Webhook   FileStageStage = "webhook"
+)
+
+// Defines values for FileStageStatus.
+const (
+	FileStageStatusFailed    FileStageStatus = "failed"
+	FileStageStatusPending   FileStageStatus = "pending"
+	FileStageStatusRunning   FileStageStatus = "running"
+	FileStageStatusSkipped   FileStageStatus = "skipped"
+	FileStageStatusSucceeded FileStageStatus = "succeeded"
+)
+
+// Defines values for JobStatus.
+const (
+	JobStatusDead      JobStatus = "dead"
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+)
+
+// Defines values for KeyRotationStatus.
+const (
+	KeyRotationStatusCompleted KeyRotationStatus = "completed"
+	KeyRotationStatusFailed    KeyRotationStatus = "failed"
+	KeyRotationStatusRunning   KeyRotationStatus = "running"
+)
+
+// Defines values for NotificationChannelEvents.
+const (
+	CleanupFinished  NotificationChannelEvents = "cleanup.finished"
+	FileQuarantined  NotificationChannelEvents = "file.quarantined"
+	ShareReported    NotificationChannelEvents = "share.reported"
+	StorageThreshold NotificationChannelEvents = "storage.threshold"
+	UserPending      NotificationChannelEvents = "user.pending"
+)
+
+// Defines values for NotificationChannelProvider.
+const (
+	Discord NotificationChannelProvider = "discord"
+	Matrix  NotificationChannelProvider = "matrix"
+	Slack   NotificationChannelProvider = "slack"
+)
+
+// Defines values for ShareReportCategory.
+const (
+	ShareReportCategoryCopyright  ShareReportCategory = "copyright"
+	ShareReportCategoryHarassment ShareReportCategory = "harassment"
+	ShareReportCategoryIllegal    ShareReportCategory = "illegal"
+	ShareReportCategoryMalware    ShareReportCategory = "malware"
+	ShareReportCategoryOther      ShareReportCategory = "other"
+	ShareReportCategorySpam       ShareReportCategory = "spam"
+)
+
+// Defines values for ShareReportStatus.
+const (
+	ShareReportStatusActioned  ShareReportStatus = "actioned"
+	ShareReportStatusDismissed ShareReportStatus = "dismissed"
+	ShareReportStatusOpen      ShareReportStatus = "open"
+)
+
+// Defines values for SigningKeysKeysStatus.
+const (
+	SigningKeysKeysStatusActive   SigningKeysKeysStatus = "active"
+	SigningKeysKeysStatusRetired  SigningKeysKeysStatus = "retired"
+	SigningKeysKeysStatusRetiring SigningKeysKeysStatus = "retiring"
+	SigningKeysKeysStatusSpare    SigningKeysKeysStatus = "spare"
+)
+
+// Defines values for UsageReportPeriod.
+const (
+	UsageReportPeriodMonthly UsageReportPeriod = "monthly"
+	UsageReportPeriodWeekly  UsageReportPeriod = "weekly"
+)
+
+// Defines values for UserInfoRole.
+const (
+	UserInfoRoleAdmin UserInfoRole = "admin"
+	UserInfoRoleUser  UserInfoRole = "user"
+)
+
+// Defines values for PostAdminAnnouncementsJSONBodySeverity.
+const (
+	PostAdminAnnouncementsJSONBodySeverityError   PostAdminAnnouncementsJSONBodySeverity = "error"
+	PostAdminAnnouncementsJSONBodySeverityInfo    PostAdminAnnouncementsJSONBodySeverity = "info"
+	PostAdminAnnouncementsJSONBodySeverityWarning PostAdminAnnouncementsJSONBodySeverity = "warning"
+)
+
+// Defines values for GetAdminJobsParamsStatus.
+const (
+	GetAdminJobsParamsStatusDead      GetAdminJobsParamsStatus = "dead"
+	GetAdminJobsParamsStatusPending   GetAdminJobsParamsStatus = "pending"
+	GetAdminJobsParamsStatusRunning   GetAdminJobsParamsStatus = "running"
+	GetAdminJobsParamsStatusSucceeded GetAdminJobsParamsStatus = "succeeded"
+)
+
+// Defines values for GetAdminReportsParamsStatus.
+const (
+	GetAdminReportsParamsStatusActioned  GetAdminReportsParamsStatus = "actioned"
+	GetAdminReportsParamsStatusAll       GetAdminReportsParamsStatus = "all"
+	GetAdminReportsParamsStatusDismissed GetAdminReportsParamsStatus = "dismissed"
+	GetAdminReportsParamsStatusOpen      GetAdminReportsParamsStatus = "open"
+)
+
+// Defines values for PostAdminReportsIdResolveJSONBodyAction.
+const (
+	Disable PostAdminReportsIdResolveJSONBodyAction = "disable"
+	Dismiss PostAdminReportsIdResolveJSONBodyAction = "dismiss"
+)
+
+// Defines values for PostAdminServiceAccountsIdKeysJSONBodyScopes.
+const (
+	PostAdminServiceAccountsIdKeysJSONBodyScopesFilesDelete PostAdminServiceAccountsIdKeysJSONBodyScopes = "files:delete"
+	PostAdminServiceAccountsIdKeysJSONBodyScopesFilesRead   PostAdminServiceAccountsIdKeysJSONBodyScopes = "files:read"
+	PostAdminServiceAccountsIdKeysJSONBodyScopesFilesWrite  PostAdminServiceAccountsIdKeysJSONBodyScopes = "files:write"
+)
+
+// Defines values for GetAdminUsageReportsPeriodParamsPeriod.
+const (
+	GetAdminUsageReportsPeriodParamsPeriodMonthly GetAdminUsageReportsPeriodParamsPeriod = "monthly"
+	GetAdminUsageReportsPeriodParamsPeriodWeekly  GetAdminUsageReportsPeriodParamsPeriod = "weekly"
+)
+
+// Defines values for PostAdminUsageReportsPeriodSendParamsPeriod.
+const (
+	Monthly PostAdminUsageReportsPeriodSendParamsPeriod = "monthly"
+	Weekly  PostAdminUsageReportsPeriodSendParamsPeriod = "weekly"
+)
+
+// Defines values for GetAdminUsersParamsStatus.
+const (
+	GetAdminUsersParamsStatusActive   GetAdminUsersParamsStatus = "active"
+	GetAdminUsersParamsStatusInactive GetAdminUsersParamsStatus = "inactive"
+	GetAdminUsersParamsStatusPending  GetAdminUsersParamsStatus = "pending"
+)
+
+// Defines values for PatchAdminUsersIdRoleJSONBodyRole.
+const (
+	PatchAdminUsersIdRoleJSONBodyRoleAdmin PatchAdminUsersIdRoleJSONBodyRole = "admin"
+	PatchAdminUsersIdRoleJSONBodyRoleUser  PatchAdminUsersIdRoleJSONBodyRole = "user"
+)
+
+// Defines values for PatchAdminUsersIdStatusJSONBodyStatus.
+const (
+	Active   PatchAdminUsersIdStatusJSONBodyStatus = "active"
+	Inactive PatchAdminUsersIdStatusJSONBodyStatus = "inactive"
+)
+
+// Defines values for PostAuthTokensJSONBodyScopes.
+const (
+	PostAuthTokensJSONBodyScopesAdmin       PostAuthTokensJSONBodyScopes = "admin"
+	PostAuthTokensJSONBodyScopesFilesDelete PostAuthTokensJSONBodyScopes = "files:delete"
+	PostAuthTokensJSONBodyScopesFilesRead   PostAuthTokensJSONBodyScopes = "files:read"
+	PostAuthTokensJSONBodyScopesFilesWrite  PostAuthTokensJSONBodyScopes = "files:write"
+)
+
+// Defines values for PostFilesFileIDDownloadUrlJSONBodyDisposition.
+const (
+	Attachment PostFilesFileIDDownloadUrlJSONBodyDisposition = "attachment"
+	Inline     PostFilesFileIDDownloadUrlJSONBodyDisposition = "inline"
+)
+
+// Defines values for PostFilesFileIDTicketJSONBodyPurpose.
+const (
+	Download PostFilesFileIDTicketJSONBodyPurpose = "download"
+	Stream   PostFilesFileIDTicketJSONBodyPurpose = "stream"
+)
+
+// Defines values for PostSTokenReportJSONBodyCategory.
+const (
+	PostSTokenReportJSONBodyCategoryCopyright  PostSTokenReportJSONBodyCategory = "copyright"
+	PostSTokenReportJSONBodyCategoryHarassment PostSTokenReportJSONBodyCategory = "harassment"
+	PostSTokenReportJSONBodyCategoryIllegal    PostSTokenReportJSONBodyCategory = "illegal"
+	PostSTokenReportJSONBodyCategoryMalware    PostSTokenReportJSONBodyCategory = "malware"
+	PostSTokenReportJSONBodyCategoryOther      PostSTokenReportJSONBodyCategory = "other"
+	PostSTokenReportJSONBodyCategorySpam       PostSTokenReportJSONBodyCategory = "spam"
+)
+
+// AdminFileList defines model for AdminFileList.
+type AdminFileList struct {
+	Count *int `json:"count,omitempty"`
+	Files *[]struct {
+		ContentType *string `json:"content_type,omitempty"`
+		CreatedAt   *string `json:"created_at,omitempty"`
+		Filename    *string `json:"filename,omitempty"`
+		Id          *string `json:"id,omitempty"`
+		Size        *int64  `json:"size,omitempty"`
+		UserId      *string `json:"user_id,omitempty"`
+		Username    *string `json:"username,omitempty"`
+	} `json:"files,omitempty"`
+	HasMore    *bool   `json:"has_more,omitempty"`
+	Limit      *int    `json:"limit,omitempty"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Offset     *int    `json:"offset,omitempty"`
+
+	// Total Number of files matching the filters
+	Total *int `json:"total,omitempty"`
+}
+
+// Announcement defines model for Announcement.
+type Announcement struct {
+	// CreatedAt Creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+
+	// Dismissed Whether current user has dismissed this announcement
+	Dismissed *bool `json:"dismissed,omitempty"`
+
+	// Id Unique announcement identifier
+	Id string `json:"id"`
+
+	// Message Announcement message/body
+	Message string `json:"message"`
+
+	// Severity Severity level of the announcement
+	Severity AnnouncementSeverity `json:"severity"`
+
+	// Title Announcement title
+	Title string `json:"title"`
+}
+
+// AnnouncementSeverity Severity level of the announcement
+type AnnouncementSeverity string
+
+// AuthResponse defines model for AuthResponse.
+type AuthResponse struct {
+	// Email User's email address
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// ExpiresIn Seconds until the access token expires
+	ExpiresIn int `json:"expires_in"`
+
+	// RefreshToken Single-use token for /auth/refresh
+	RefreshToken string `json:"refresh_token"`
+
+	// Token Short-lived JWT access token
+	Token string `json:"token"`
+
+	// UserId Unique user identifier
+	UserId string `json:"user_id"`
+}
+
+// ContentStats The storage of the instance by MIME type and by extension ("" for files without
+// one), largest first. `cached` is set when it comes from the cache, computed at
+// `generated_at`.
+type ContentStats struct {
+	Bytes      *int64 `json:"bytes,omitempty"`
+	Cached     *bool  `json:"cached,omitempty"`
+	Extensions *[]struct {
+		Bytes     *int64  `json:"bytes,omitempty"`
+		Extension *string `json:"extension,omitempty"`
+		Files     *int    `json:"files,omitempty"`
+	} `json:"extensions,omitempty"`
+	Files       *int       `json:"files,omitempty"`
+	GeneratedAt *time.Time `json:"generated_at,omitempty"`
+	MimeTypes   *[]struct {
+		Bytes    *int64  `json:"bytes,omitempty"`
+		Files    *int    `json:"files,omitempty"`
+		MimeType *string `json:"mime_type,omitempty"`
+	} `json:"mime_types,omitempty"`
+}
+
+// Envelope Body of every JSON response under /api/v2; exactly one of data and error is set
+type Envelope struct {
+	// Data The v1 response body
+	Data  interface{} `json:"data"`
+	Error *struct {
+		// Code Machine-readable error code. Generic codes follow the HTTP status; specific ones
+		// name a condition clients may handle. New codes may be added.
+		Code *ErrorCode `json:"code,omitempty"`
+
+		// Details Further fields of the error (e.g. the maintenance message)
+		Details *map[string]interface{} `json:"details,omitempty"`
+		Message *string                 `json:"message,omitempty"`
+	} `json:"error"`
+	Meta *struct {
+		ApiVersion *int    `json:"api_version,omitempty"`
+		RequestId  *string `json:"request_id,omitempty"`
+	} `json:"meta,omitempty"`
+}
+
+// ErrorCode Machine-readable error code. Generic codes follow the HTTP status; specific ones
+// name a condition clients may handle. New codes may be added.
+type ErrorCode string
+
+// ErrorResponse Error body of every endpoint (under /api/v2 it becomes the envelope's `error`).
+// Some errors add fields, e.g. `retry_after` (RATE_LIMITED, SERVICE_UNAVAILABLE), `read_only` (READ_ONLY),
+// `resource` (INSUFFICIENT_STORAGE), `quota` (QUOTA_EXCEEDED) or `message` (MAINTENANCE).
+//
+// With an `Accept-Language` header naming a language the server has a translation
+// for, `error` is the translated message of the code and the `Content-Language`
+// response header names that language; otherwise it is the English message.
+type ErrorResponse struct {
+	// Code Machine-readable error code. Generic codes follow the HTTP status; specific ones
+	// name a condition clients may handle. New codes may be added.
+	Code ErrorCode `json:"code"`
+
+	// Error Error message for people, in the language of Content-Language
+	Error string `json:"error"`
+
+	// RequestId ID of the request in the server logs; quote it in bug reports
+	RequestId            *string                `json:"request_id,omitempty"`
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
+// FileDetails A file's metadata (as in FileMetadata) with its share links, a summary of its recorded
+// downloads and the latest of them. Download events carry the IP address of the client,
+// also for anonymous downloads through share links.
+type FileDetails struct {
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	DownloadCount *int       `json:"download_count,omitempty"`
+	DownloadStats *struct {
+		Bytes          *int64     `json:"bytes,omitempty"`
+		Downloaders    *int       `json:"downloaders,omitempty"`
+		Downloads      *int       `json:"downloads,omitempty"`
+		LastDownloaded *time.Time `json:"last_downloaded,omitempty"`
+		ViaShares      *int       `json:"via_shares,omitempty"`
+	} `json:"download_stats,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	FileId            *string    `json:"file_id,omitempty"`
+	FileName          *string    `json:"file_name,omitempty"`
+	Folder            *string    `json:"folder,omitempty"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+	MimeType          *string    `json:"mime_type,omitempty"`
+	ModifiedAt        *time.Time `json:"modified_at,omitempty"`
+	PasswordProtected *bool      `json:"password_protected,omitempty"`
+	Quarantined       *bool      `json:"quarantined,omitempty"`
+	RecentDownloads   *[]struct {
+		At        *time.Time `json:"at,omitempty"`
+		Bytes     *int64     `json:"bytes,omitempty"`
+		IpAddress *string    `json:"ip_address,omitempty"`
+		ShareId   *string    `json:"share_id,omitempty"`
+		UserId    *string    `json:"user_id,omitempty"`
+	} `json:"recent_downloads,omitempty"`
+	Sha256  *string      `json:"sha256,omitempty"`
+	Shares  *[]ShareLink `json:"shares,omitempty"`
+	Size    *int64       `json:"size,omitempty"`
+	Starred *bool        `json:"starred,omitempty"`
+	Tags    *[]string    `json:"tags,omitempty"`
+}
+
+// FileListResponse defines model for FileListResponse.
+type FileListResponse struct {
+	// Count Number of files in this response
+	Count int            `json:"count"`
+	Files []FileMetadata `json:"files"`
+
+	// NextCursor Cursor of the next page; only set when a limit was given and more files follow
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// FileMetadata defines model for FileMetadata.
+type FileMetadata struct {
+	// CreatedAt File upload timestamp
+	CreatedAt   time.Time `json:"created_at"`
+	Description *string   `json:"description,omitempty"`
+
+	// DownloadCount Number of times file has been downloaded
+	DownloadCount int `json:"download_count"`
+
+	// ExpiresAt Expiration timestamp (null if no expiration)
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// FileId Unique file identifier
+	FileId string `json:"file_id"`
+
+	// FileName Original filename
+	FileName string `json:"file_name"`
+
+	// Folder Virtual folder path ("/" is the root)
+	Folder *string `json:"folder,omitempty"`
+
+	// LastDownloadedAt When the file was last downloaded
+	LastDownloadedAt *time.Time `json:"last_downloaded_at"`
+
+	// MimeType MIME type of the file
+	MimeType string `json:"mime_type"`
+
+	// ModifiedAt Modification time set by the client on upload or with PATCH (missing if never set)
+	ModifiedAt *time.Time `json:"modified_at"`
+
+	// PasswordProtected Whether downloads require the X-File-Password header
+	PasswordProtected *bool `json:"password_protected,omitempty"`
+
+	// Quarantined Set while the file is held back for review by an admin (features.quarantine);
+	// its share links answer 404 until it is approved
+	Quarantined *bool `json:"quarantined,omitempty"`
+
+	// Sha256 Hex SHA-256 of the content (missing for files uploaded before checksums were recorded)
+	Sha256 *string `json:"sha256,omitempty"`
+
+	// Size File size in bytes
+	Size int64 `json:"size"`
+
+	// Starred Whether the owner starred the file
+	Starred *bool `json:"starred,omitempty"`
+
+	// Tags User-defined tags for categorization
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// FileStage defines model for FileStage.
+type FileStage struct {
+	// Detail Result (e.g. sha256:..., thumbnail size) or the last error
+	Detail    *string          `json:"detail,omitempty"`
+	Stage     *FileStageStage  `json:"stage,omitempty"`
+	Status    *FileStageStatus `json:"status,omitempty"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+}
+
+// FileStageStage defines model for FileStage.Stage.
+type FileStageStage string
+
+// FileStageStatus defines model for FileStage.Status.
+type FileStageStatus string
+
+// IPAccessRules defines model for IPAccessRules.
+type IPAccessRules struct {
+	// Allow CIDRs or addresses allowed to use the API (empty = all)
+	Allow *[]string `json:"allow,omitempty"`
+
+	// BlockedCountries ISO 3166 alpha-2 country codes refused
+	BlockedCountries *[]string `json:"blocked_countries,omitempty"`
+
+	// Deny CIDRs or addresses refused (checked first)
+	Deny      *[]string  `json:"deny,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Job defines model for Job.
+type Job struct {
+	Attempts   *int                `json:"attempts,omitempty"`
+	CreatedAt  *time.Time          `json:"created_at,omitempty"`
+	FinishedAt *time.Time          `json:"finished_at,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	LastError  *string             `json:"last_error,omitempty"`
+
+	// LockedBy Server instance running the job
+	LockedBy    *string                 `json:"locked_by,omitempty"`
+	MaxAttempts *int                    `json:"max_attempts,omitempty"`
+	Payload     *map[string]interface{} `json:"payload,omitempty"`
+
+	// RunAt Next attempt not before
+	RunAt     *time.Time `json:"run_at,omitempty"`
+	Status    *JobStatus `json:"status,omitempty"`
+	Type      *string    `json:"type,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// JobStatus defines model for Job.Status.
+type JobStatus string
+
+// KeyRotation defines model for KeyRotation.
+type KeyRotation struct {
+	FailedFiles *int                `json:"failed_files,omitempty"`
+	FinishedAt  *time.Time          `json:"finished_at,omitempty"`
+	Id          *openapi_types.UUID `json:"id,omitempty"`
+
+	// KekId KEK the job rotates to (`vault:<mount>/<key>` or `awskms:<key id>` for an external KMS)
+	KekId     *string `json:"kek_id,omitempty"`
+	LastError *string `json:"last_error,omitempty"`
+
+	// LastFileId Resume point
+	LastFileId       *string            `json:"last_file_id,omitempty"`
+	ProcessedFiles   *int               `json:"processed_files,omitempty"`
+	ReencryptObjects *bool              `json:"reencrypt_objects,omitempty"`
+	StartedAt        *time.Time         `json:"started_at,omitempty"`
+	StartedBy        *string            `json:"started_by,omitempty"`
+	Status           *KeyRotationStatus `json:"status,omitempty"`
+	TotalFiles       *int               `json:"total_files,omitempty"`
+	UpdatedAt        *time.Time         `json:"updated_at,omitempty"`
+}
+
+// KeyRotationStatus defines model for KeyRotation.Status.
+type KeyRotationStatus string
+
+// MaintenanceResponse Body of 503 responses while maintenance mode is on (sent with Retry-After)
+type MaintenanceResponse struct {
+	Error       *string `json:"error,omitempty"`
+	Maintenance *bool   `json:"maintenance,omitempty"`
+	Message     *string `json:"message,omitempty"`
+}
+
+// MaintenanceState defines model for MaintenanceState.
+type MaintenanceState struct {
+	Enabled   *bool      `json:"enabled,omitempty"`
+	Message   *string    `json:"message,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// NotificationChannel defines model for NotificationChannel.
+type NotificationChannel struct {
+	// Events Events posted to the channel
+	Events   *[]NotificationChannelEvents `json:"events,omitempty"`
+	Name     *string                      `json:"name,omitempty"`
+	Provider *NotificationChannelProvider `json:"provider,omitempty"`
+}
+
+// NotificationChannelEvents defines model for NotificationChannel.Events.
+type NotificationChannelEvents string
+
+// NotificationChannelProvider defines model for NotificationChannel.Provider.
+type NotificationChannelProvider string
+
+// NullString An optional string as the audit log encodes it; String is only meaningful when Valid
+type NullString struct {
+	String *string `json:"String,omitempty"`
+	Valid  *bool   `json:"Valid,omitempty"`
+}
+
+// Profile defines model for Profile.
+type Profile struct {
+	// AvatarUrl Versioned avatar URL; absent without an avatar
+	AvatarUrl   *string             `json:"avatar_url,omitempty"`
+	DisplayName string              `json:"display_name"`
+	Email       openapi_types.Email `json:"email"`
+
+	// PendingEmail New address awaiting its verification code
+	PendingEmail *openapi_types.Email `json:"pending_email,omitempty"`
+	UserId       string               `json:"user_id"`
+	Username     string               `json:"username"`
+}
+
+// QuarantinedFile defines model for QuarantinedFile.
+type QuarantinedFile struct {
+	CreatedAt     *time.Time          `json:"created_at,omitempty"`
+	FileId        *openapi_types.UUID `json:"file_id,omitempty"`
+	FileName      *string             `json:"file_name,omitempty"`
+	MimeType      *string             `json:"mime_type,omitempty"`
+	QuarantinedAt *time.Time          `json:"quarantined_at,omitempty"`
+
+	// Reason Name of the rule that matched
+	Reason   *string             `json:"reason,omitempty"`
+	Sha256   *string             `json:"sha256,omitempty"`
+	Size     *int64              `json:"size,omitempty"`
+	UserId   *openapi_types.UUID `json:"user_id,omitempty"`
+	Username *string             `json:"username,omitempty"`
+}
+
+// ReadOnlyResponse Body of 403 responses to file writes while files are read-only
+type ReadOnlyResponse struct {
+	Error    *string `json:"error,omitempty"`
+	ReadOnly *struct {
+		Global *bool `json:"global,omitempty"`
+		User   *bool `json:"user,omitempty"`
+	} `json:"read_only,omitempty"`
+}
+
+// ReadOnlyState defines model for ReadOnlyState.
+type ReadOnlyState struct {
+	// Enabled Read-only for all users
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Users Users whose own read-only switch is on
+	Users *[]struct {
+		Id       *string `json:"id,omitempty"`
+		Username *string `json:"username,omitempty"`
+	} `json:"users,omitempty"`
+}
+
+// ReplicationRemote Another File Locker instance files are replicated to
+type ReplicationRemote struct {
+	CreatedAt        *time.Time          `json:"created_at,omitempty"`
+	CreatedBy        *string             `json:"created_by,omitempty"`
+	Id               *openapi_types.UUID `json:"id,omitempty"`
+	LastReplicatedAt *time.Time          `json:"last_replicated_at,omitempty"`
+	Name             *string             `json:"name,omitempty"`
+
+	// ReplicatedFiles Files the remote has received
+	ReplicatedFiles *int    `json:"replicated_files,omitempty"`
+	Url             *string `json:"url,omitempty"`
+}
+
+// ServiceAccount defines model for ServiceAccount.
+type ServiceAccount struct {
+	ActiveKeys *int       `json:"active_keys,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	Id         *string    `json:"id,omitempty"`
+	IsActive   *bool      `json:"is_active,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+}
+
+// ServiceAccountKey defines model for ServiceAccountKey.
+type ServiceAccountKey struct {
+	AllowedCidrs     *[]string  `json:"allowed_cidrs,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	CreatedBy        *string    `json:"created_by,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	Id               *string    `json:"id,omitempty"`
+	KeyPrefix        *string    `json:"key_prefix,omitempty"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIp       *string    `json:"last_used_ip,omitempty"`
+	Name             *string    `json:"name,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	Scopes           *[]string  `json:"scopes,omitempty"`
+	ServiceAccountId *string    `json:"service_account_id,omitempty"`
+}
+
+// ShareLink defines model for ShareLink.
+type ShareLink struct {
+	AllowedIps      *[]string  `json:"allowed_ips,omitempty"`
+	AllowedReferers *[]string  `json:"allowed_referers,omitempty"`
+	BytesServed     *int64     `json:"bytes_served,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	CreatedBy       *string    `json:"created_by,omitempty"`
+	DisabledAt      *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason  *string    `json:"disabled_reason,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	FileId          *string    `json:"file_id,omitempty"`
+	Id              *string    `json:"id,omitempty"`
+	LastAccessedAt  *time.Time `json:"last_accessed_at,omitempty"`
+	MaxBytes        *int64     `json:"max_bytes,omitempty"`
+	MaxRequests     *int       `json:"max_requests,omitempty"`
+	RequestCount    *int       `json:"request_count,omitempty"`
+	Token           *string    `json:"token,omitempty"`
+}
+
+// ShareReport defines model for ShareReport.
+type ShareReport struct {
+	Category       *ShareReportCategory `json:"category,omitempty"`
+	CreatedAt      *time.Time           `json:"created_at,omitempty"`
+	FileId         *openapi_types.UUID  `json:"file_id,omitempty"`
+	FileName       *string              `json:"file_name,omitempty"`
+	Id             *openapi_types.UUID  `json:"id,omitempty"`
+	IpAddress      *string              `json:"ip_address,omitempty"`
+	Message        *string              `json:"message,omitempty"`
+	OwnerId        *openapi_types.UUID  `json:"owner_id,omitempty"`
+	OwnerUsername  *string              `json:"owner_username,omitempty"`
+	ReporterEmail  *string              `json:"reporter_email,omitempty"`
+	ResolutionNote *string              `json:"resolution_note,omitempty"`
+	ResolvedAt     *time.Time           `json:"resolved_at,omitempty"`
+	ResolvedBy     *openapi_types.UUID  `json:"resolved_by,omitempty"`
+	ShareDisabled  *bool                `json:"share_disabled,omitempty"`
+	ShareId        *openapi_types.UUID  `json:"share_id,omitempty"`
+	Status         *ShareReportStatus   `json:"status,omitempty"`
+}
+
+// ShareReportCategory defines model for ShareReport.Category.
+type ShareReportCategory string
+
+// ShareReportStatus defines model for ShareReport.Status.
+type ShareReportStatus string
+
+// ShareStats How a share link has been used since it was created. Unique visitors are
+// counted by a keyed hash of their IP address.
+type ShareStats struct {
+	Downloads         *int64     `json:"downloads,omitempty"`
+	FileId            *string    `json:"file_id,omitempty"`
+	FirstAccessedAt   *time.Time `json:"first_accessed_at,omitempty"`
+	LastAccessedAt    *time.Time `json:"last_accessed_at,omitempty"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+	ShareId           *string    `json:"share_id,omitempty"`
+	UniqueDownloadIps *int64     `json:"unique_download_ips,omitempty"`
+	UniqueIps         *int64     `json:"unique_ips,omitempty"`
+	Views             *int64     `json:"views,omitempty"`
+}
+
+// SigningKeys defines model for SigningKeys.
+type SigningKeys struct {
+	Keys *[]struct {
+		Id *string `json:"id,omitempty"`
+
+		// Since When the active key started signing
+		Since  *time.Time             `json:"since,omitempty"`
+		Status *SigningKeysKeysStatus `json:"status,omitempty"`
+
+		// Until When a retiring key stops verifying
+		Until *time.Time `json:"until,omitempty"`
+	} `json:"keys,omitempty"`
+}
+
+// SigningKeysKeysStatus defines model for SigningKeys.Keys.Status.
+type SigningKeysKeysStatus string
+
+// Snapshot A metadata snapshot
+type Snapshot struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// CreatedBy Admin who took it; absent for scheduled snapshots
+	CreatedBy *string `json:"created_by,omitempty"`
+	Files     *int    `json:"files,omitempty"`
+
+	// Id When it was taken (UTC)
+	Id          *string `json:"id,omitempty"`
+	ObjectBytes *int64  `json:"object_bytes,omitempty"`
+	Objects     *int    `json:"objects,omitempty"`
+
+	// WalLsn Database WAL position the export corresponds to, for lining it up with WAL archives
+	WalLsn *string `json:"wal_lsn,omitempty"`
+}
+
+// StorageAlertStatus Usage against the thresholds of features.storage_alerts, measured when requested;
+// omitted when no threshold is configured. alerts are the thresholds the monitor
+// found exceeded and has reported.
+type StorageAlertStatus struct {
+	Alerts *[]struct {
+		Kind       *string    `json:"kind,omitempty"`
+		LimitBytes *int64     `json:"limit_bytes,omitempty"`
+		RaisedAt   *time.Time `json:"raised_at,omitempty"`
+		Subject    *string    `json:"subject,omitempty"`
+		UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+		UsedBytes  *int64     `json:"used_bytes,omitempty"`
+	} `json:"alerts,omitempty"`
+	Bucket *struct {
+		CapacityBytes    *int64   `json:"capacity_bytes,omitempty"`
+		Exceeded         *bool    `json:"exceeded,omitempty"`
+		Percent          *float32 `json:"percent,omitempty"`
+		ThresholdPercent *int     `json:"threshold_percent,omitempty"`
+		UsedBytes        *int64   `json:"used_bytes,omitempty"`
+	} `json:"bucket,omitempty"`
+	Database *struct {
+		CapacityBytes    *int64   `json:"capacity_bytes,omitempty"`
+		Exceeded         *bool    `json:"exceeded,omitempty"`
+		Percent          *float32 `json:"percent,omitempty"`
+		ThresholdPercent *int     `json:"threshold_percent,omitempty"`
+		UsedBytes        *int64   `json:"used_bytes,omitempty"`
+	} `json:"database,omitempty"`
+	UserQuota *struct {
+		QuotaBytes       *int64 `json:"quota_bytes,omitempty"`
+		ThresholdPercent *int   `json:"threshold_percent,omitempty"`
+		UsersOver        *int   `json:"users_over,omitempty"`
+	} `json:"user_quota,omitempty"`
+}
+
+// StorageBreaker Circuit breaker in front of MinIO (storage.minio.breaker), omitted when it is off.
+// While `open`, transfers get 503 with Retry-After and GET /health/ready answers 503.
+type StorageBreaker struct {
+	ConsecutiveFailures *int       `json:"consecutive_failures,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAfterSeconds   *int       `json:"retry_after_seconds,omitempty"`
+	State               *string    `json:"state,omitempty"`
+	Threshold           *int       `json:"threshold,omitempty"`
+	Trips               *int64     `json:"trips,omitempty"`
+}
+
+// StorageQuota A user's storage against the per-user quota (storage_quota_per_user_bytes, trash
+// included). Over the quota, `state` is `grace` until `grace_ends_at` and `blocked`
+// after it, when uploads are refused; without `grace_ends_at` (storage_quota_grace_days
+// is off) uploads are never refused.
+type StorageQuota struct {
+	GraceEndsAt *time.Time `json:"grace_ends_at,omitempty"`
+	OverSince   *time.Time `json:"over_since,omitempty"`
+	QuotaBytes  *int64     `json:"quota_bytes,omitempty"`
+	State       *string    `json:"state,omitempty"`
+	UsedBytes   *int64     `json:"used_bytes,omitempty"`
+}
+
+// TransferStatus Bytes served from a user's files in the current month, with the days that had any.
+// `cap_bytes` is unset when there is no monthly transfer cap.
+type TransferStatus struct {
+	Bytes    *int64 `json:"bytes,omitempty"`
+	CapBytes *int64 `json:"cap_bytes,omitempty"`
+	Days     *[]struct {
+		Bytes         *int64  `json:"bytes,omitempty"`
+		Day           *string `json:"day,omitempty"`
+		DownloadBytes *int64  `json:"download_bytes,omitempty"`
+		ExportBytes   *int64  `json:"export_bytes,omitempty"`
+		StreamBytes   *int64  `json:"stream_bytes,omitempty"`
+	} `json:"days,omitempty"`
+	Month    *string    `json:"month,omitempty"`
+	ResetsAt *time.Time `json:"resets_at,omitempty"`
+}
+
+// UploadCapacity Estimated room for uploads (features.uploads.space), omitted when nothing is
+// checked: free bytes of the temp filesystem, and the storage capacity minus the
+// stored files. While `low`, uploads get 507.
+type UploadCapacity struct {
+	Storage *struct {
+		Dir           *string `json:"dir,omitempty"`
+		FreeBytes     *int64  `json:"free_bytes,omitempty"`
+		Low           *bool   `json:"low,omitempty"`
+		ReservedBytes *int64  `json:"reserved_bytes,omitempty"`
+		TotalBytes    *int64  `json:"total_bytes,omitempty"`
+	} `json:"storage,omitempty"`
+	Temp *struct {
+		Dir           *string `json:"dir,omitempty"`
+		FreeBytes     *int64  `json:"free_bytes,omitempty"`
+		Low           *bool   `json:"low,omitempty"`
+		ReservedBytes *int64  `json:"reserved_bytes,omitempty"`
+		TotalBytes    *int64  `json:"total_bytes,omitempty"`
+	} `json:"temp,omitempty"`
+}
+
+// UsageRecord A user's usage in a calendar month (UTC). Storage is sampled once a day and the
+// samples summed into `storage_byte_days` (1 GB kept for 30 days is 30 GB-days).
+// `download_bytes` counts the bytes served from the user's files (downloads, share
+// links included, streams and exports); `downloads` counts completed downloads.
+type UsageRecord struct {
+	DownloadBytes    *int64  `json:"download_bytes,omitempty"`
+	Downloads        *int    `json:"downloads,omitempty"`
+	Email            *string `json:"email,omitempty"`
+	Month            *string `json:"month,omitempty"`
+	PeakStorageBytes *int64  `json:"peak_storage_bytes,omitempty"`
+	StorageByteDays  *int64  `json:"storage_byte_days,omitempty"`
+	UserId           *string `json:"user_id,omitempty"`
+	Username         *string `json:"username,omitempty"`
+}
+
+// UsageReport defines model for UsageReport.
+type UsageReport struct {
+	ExpiredBytes *int64 `json:"expired_bytes,omitempty"`
+
+	// ExpiredFiles Deleted by the auto-delete worker
+	ExpiredFiles         *int       `json:"expired_files,omitempty"`
+	FailedLoginAddresses *int       `json:"failed_login_addresses,omitempty"`
+	FailedLogins         *int       `json:"failed_logins,omitempty"`
+	From                 *time.Time `json:"from,omitempty"`
+	NewUsers             *int       `json:"new_users,omitempty"`
+
+	// PendingUsers Accounts awaiting approval now
+	PendingUsers *int               `json:"pending_users,omitempty"`
+	Period       *UsageReportPeriod `json:"period,omitempty"`
+	PurgedBytes  *int64             `json:"purged_bytes,omitempty"`
+
+	// PurgedFiles Purged from the trash
+	PurgedFiles *int `json:"purged_files,omitempty"`
+
+	// StorageGrowth Change of the stored bytes since the previous report of the period; absent before the first one
+	StorageGrowth *int64 `json:"storage_growth,omitempty"`
+
+	// To End of the period (exclusive)
+	To          *time.Time `json:"to,omitempty"`
+	TopTransfer *[]struct {
+		Bytes    *int64              `json:"bytes,omitempty"`
+		UserId   *openapi_types.UUID `json:"user_id,omitempty"`
+		Username *string             `json:"username,omitempty"`
+	} `json:"top_transfer,omitempty"`
+	TopUploaders *[]struct {
+		Bytes    *int64              `json:"bytes,omitempty"`
+		Files    *int                `json:"files,omitempty"`
+		UserId   *openapi_types.UUID `json:"user_id,omitempty"`
+		Username *string             `json:"username,omitempty"`
+	} `json:"top_uploaders,omitempty"`
+	TotalBytes *int64 `json:"total_bytes,omitempty"`
+	TotalFiles *int   `json:"total_files,omitempty"`
+	TotalUsers *int   `json:"total_users,omitempty"`
+
+	// TransferBytes Served from users' files in the period (downloads, streams and exports)
+	TransferBytes *int64 `json:"transfer_bytes,omitempty"`
+	UploadedBytes *int64 `json:"uploaded_bytes,omitempty"`
+
+	// UploadedFiles Files uploaded in the period that are still stored
+	UploadedFiles *int `json:"uploaded_files,omitempty"`
+}
+
+// UsageReportPeriod defines model for UsageReport.Period.
+type UsageReportPeriod string
+
+// UserInfo defines model for UserInfo.
+type UserInfo struct {
+	AccountStatus *string `json:"account_status,omitempty"`
+
+	// AvatarUrl Versioned avatar URL (GET /admin/users, GET /auth/me); absent without an avatar
+	AvatarUrl *string `json:"avatar_url,omitempty"`
+
+	// CreatedAt Account creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+
+	// Email User's email address
+	Email     openapi_types.Email `json:"email"`
+	FileCount *int                `json:"file_count,omitempty"`
+
+	// Id Unique user identifier
+	Id       string `json:"id"`
+	IsActive *bool  `json:"is_active,omitempty"`
+
+	// Quota A user's storage against the per-user quota (storage_quota_per_user_bytes, trash
+	// included). Over the quota, `state` is `grace` until `grace_ends_at` and `blocked`
+	// after it, when uploads are refused; without `grace_ends_at` (storage_quota_grace_days
+	// is off) uploads are never refused.
+	Quota *StorageQuota `json:"quota,omitempty"`
+
+	// Role User role
+	Role UserInfoRole `json:"role"`
+
+	// SessionCount Live login sessions of the user (GET /admin/users)
+	SessionCount *int   `json:"session_count,omitempty"`
+	TotalStorage *int64 `json:"total_storage,omitempty"`
+
+	// Username Username
+	Username string `json:"username"`
+}
+
+// UserInfoRole User role
+type UserInfoRole string
+
+// UserPreferences defines model for UserPreferences.
+type UserPreferences struct {
+	// DefaultExpiryHours Expiry of uploads that do not set expire_after (0 = never)
+	DefaultExpiryHours *int `json:"default_expiry_hours,omitempty"`
+
+	// Locale BCP 47 language tag; also the language of emails and announcements the server sends the user
+	Locale        *string `json:"locale,omitempty"`
+	Notifications *struct {
+		// ShareDisabled Announce share links that were disabled automatically
+		ShareDisabled *bool `json:"share_disabled,omitempty"`
+
+		// StorageQuota Email when nearing the storage quota
+		StorageQuota *bool `json:"storage_quota,omitempty"`
+	} `json:"notifications,omitempty"`
+
+	// PageSize Page size of file listings that do not set a limit (0 = all files)
+	PageSize *int `json:"page_size,omitempty"`
+
+	// Timezone IANA timezone, for clients
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// UserStorageReport A user's storage: totals, growth per month, the largest files and the breakdown by
+// MIME type. Files in the trash are counted everywhere, as they are still stored.
+type UserStorageReport struct {
+	Bytes        *int64 `json:"bytes,omitempty"`
+	ContentTypes *[]struct {
+		Bytes    *int64  `json:"bytes,omitempty"`
+		Files    *int    `json:"files,omitempty"`
+		MimeType *string `json:"mime_type,omitempty"`
+	} `json:"content_types,omitempty"`
+	Files  *int `json:"files,omitempty"`
+	Growth *[]struct {
+		AddedBytes *int64  `json:"added_bytes,omitempty"`
+		AddedFiles *int    `json:"added_files,omitempty"`
+		Month      *string `json:"month,omitempty"`
+		TotalBytes *int64  `json:"total_bytes,omitempty"`
+	} `json:"growth,omitempty"`
+	LargestFiles *[]struct {
+		CreatedAt         *time.Time `json:"created_at,omitempty"`
+		Description       *string    `json:"description,omitempty"`
+		DownloadCount     *int       `json:"download_count,omitempty"`
+		ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+		FileId            *string    `json:"file_id,omitempty"`
+		FileName          *string    `json:"file_name,omitempty"`
+		Folder            *string    `json:"folder,omitempty"`
+		LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+		MimeType          *string    `json:"mime_type,omitempty"`
+		ModifiedAt        *time.Time `json:"modified_at,omitempty"`
+		PasswordProtected *bool      `json:"password_protected,omitempty"`
+		Quarantined       *bool      `json:"quarantined,omitempty"`
+		Sha256            *string    `json:"sha256,omitempty"`
+		Size              *int64     `json:"size,omitempty"`
+		Starred           *bool      `json:"starred,omitempty"`
+		Tags              *[]string  `json:"tags,omitempty"`
+		Trashed           *bool      `json:"trashed,omitempty"`
+	} `json:"largest_files,omitempty"`
+	QuotaBytes   *int64  `json:"quota_bytes,omitempty"`
+	TrashedBytes *int64  `json:"trashed_bytes,omitempty"`
+	TrashedFiles *int    `json:"trashed_files,omitempty"`
+	UserId       *string `json:"user_id,omitempty"`
+	Username     *string `json:"username,omitempty"`
+}
+
+// VersionInfo defines model for VersionInfo.
+type VersionInfo struct {
+	// ApiVersion Newest major version of the HTTP API; changes only with incompatible changes
+	ApiVersion *int `json:"api_version,omitempty"`
+
+	// ApiVersions All API versions served (each under /api/v{n})
+	ApiVersions *[]int  `json:"api_versions,omitempty"`
+	BuildDate   *string `json:"build_date,omitempty"`
+
+	// DeprecatedApiVersions Served versions that will be removed
+	DeprecatedApiVersions *[]int `json:"deprecated_api_versions,omitempty"`
+
+	// Features Feature name to whether it is enabled (e.g. streaming_upload, trash, virus_scan)
+	Features    *map[string]bool `json:"features,omitempty"`
+	GitCommit   *string          `json:"git_commit,omitempty"`
+	GoVersion   *string          `json:"go_version,omitempty"`
+	SchemaDirty *bool            `json:"schema_dirty,omitempty"`
+
+	// SchemaVersion Applied database migration (absent when it could not be read)
+	SchemaVersion *int    `json:"schema_version,omitempty"`
+	Version       *string `json:"version,omitempty"`
+}
+
+// AccessTicket defines model for AccessTicket.
+type AccessTicket = string
+
+// AdminFilesCursor defines model for AdminFilesCursor.
+type AdminFilesCursor = string
+
+// AdminFilesLimit defines model for AdminFilesLimit.
+type AdminFilesLimit = int
+
+// AdminFilesMaxSize defines model for AdminFilesMaxSize.
+type AdminFilesMaxSize = int64
+
+// AdminFilesMimeType defines model for AdminFilesMimeType.
+type AdminFilesMimeType = string
+
+// AdminFilesMinSize defines model for AdminFilesMinSize.
+type AdminFilesMinSize = int64
+
+// AdminFilesOffset defines model for AdminFilesOffset.
+type AdminFilesOffset = int
+
+// AdminFilesUploadedAfter defines model for AdminFilesUploadedAfter.
+type AdminFilesUploadedAfter = string
+
+// AdminFilesUploadedBefore defines model for AdminFilesUploadedBefore.
+type AdminFilesUploadedBefore = string
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// FilePassword defines model for FilePassword.
+type FilePassword = string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// UsageMonth defines model for UsageMonth.
+type UsageMonth = string
+
+// PostAdminAnnouncementsJSONBody defines parameters for PostAdminAnnouncements.
+type PostAdminAnnouncementsJSONBody struct {
+	Message  string                                 `json:"message"`
+	Severity PostAdminAnnouncementsJSONBodySeverity `json:"severity"`
+	Title    string                                 `json:"title"`
+}
+
+// PostAdminAnnouncementsJSONBodySeverity defines parameters for PostAdminAnnouncements.
+type PostAdminAnnouncementsJSONBodySeverity string
+
+// GetAdminFilesParams defines parameters for GetAdminFiles.
+type GetAdminFilesParams struct {
+	UserId *string           `form:"user_id,omitempty" json:"user_id,omitempty"`
+	Limit  *AdminFilesLimit  `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *AdminFilesOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor from the previous page (see the Cursor parameter); cannot be combined with offset
+	Cursor *AdminFilesCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// MinSize Minimum size in bytes
+	MinSize *AdminFilesMinSize `form:"min_size,omitempty" json:"min_size,omitempty"`
+
+	// MaxSize Maximum size in bytes
+	MaxSize *AdminFilesMaxSize `form:"max_size,omitempty" json:"max_size,omitempty"`
+
+	// MimeType Exact MIME type, or a prefix ending in * (e.g. image/*)
+	MimeType *AdminFilesMimeType `form:"mime_type,omitempty" json:"mime_type,omitempty"`
+
+	// UploadedAfter RFC 3339 timestamp or YYYY-MM-DD (inclusive)
+	UploadedAfter *AdminFilesUploadedAfter `form:"uploaded_after,omitempty" json:"uploaded_after,omitempty"`
+
+	// UploadedBefore RFC 3339 timestamp or YYYY-MM-DD (exclusive)
+	UploadedBefore *AdminFilesUploadedBefore `form:"uploaded_before,omitempty" json:"uploaded_before,omitempty"`
+}
+
+// GetAdminJobsParams defines parameters for GetAdminJobs.
+type GetAdminJobsParams struct {
+	// Status Only jobs in this status (dead = dead-letter list)
+	Status *GetAdminJobsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Type   *string                   `form:"type,omitempty" json:"type,omitempty"`
+	Limit  *int                      `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Jobs to skip; cannot be combined with cursor
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page. Cursors are opaque tokens: pass them back
+	// unchanged. They point at the last item seen rather than a position, so pages
+	// do not skip or repeat items when items are added or removed in between.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetAdminJobsParamsStatus defines parameters for GetAdminJobs.
+type GetAdminJobsParamsStatus string
+
+// PostAdminKeysRotateJSONBody defines parameters for PostAdminKeysRotate.
+type PostAdminKeysRotateJSONBody struct {
+	ReencryptObjects *bool `json:"reencrypt_objects,omitempty"`
+}
+
+// GetAdminLogsParams defines parameters for GetAdminLogs.
+type GetAdminLogsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page. Cursors are opaque tokens: pass them back
+	// unchanged. They point at the last item seen rather than a position, so pages
+	// do not skip or repeat items when items are added or removed in between.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// PutAdminMaintenanceJSONBody defines parameters for PutAdminMaintenance.
+type PutAdminMaintenanceJSONBody struct {
+	Enabled bool `json:"enabled"`
+
+	// Message Message shown to users; omitted = keep the current one
+	Message *string `json:"message,omitempty"`
+}
+
+// PutAdminReadOnlyJSONBody defines parameters for PutAdminReadOnly.
+type PutAdminReadOnlyJSONBody struct {
+	Enabled bool `json:"enabled"`
+}
+
+// PostAdminReplicationRemotesJSONBody defines parameters for PostAdminReplicationRemotes.
+type PostAdminReplicationRemotesJSONBody struct {
+	Name string `json:"name"`
+
+	// Token Personal access token on the remote
+	Token string `json:"token"`
+	Url   string `json:"url"`
+}
+
+// PostAdminReplicationRemotesIdSyncJSONBody defines parameters for PostAdminReplicationRemotesIdSync.
+type PostAdminReplicationRemotesIdSyncJSONBody struct {
+	FileIds *[]openapi_types.UUID `json:"file_ids,omitempty"`
+
+	// UserId Replicate all files of this user
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// GetAdminReportsParams defines parameters for GetAdminReports.
+type GetAdminReportsParams struct {
+	Status *GetAdminReportsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetAdminReportsParamsStatus defines parameters for GetAdminReports.
+type GetAdminReportsParamsStatus string
+
+// PostAdminReportsIdResolveJSONBody defines parameters for PostAdminReportsIdResolve.
+type PostAdminReportsIdResolveJSONBody struct {
+	Action PostAdminReportsIdResolveJSONBodyAction `json:"action"`
+
+	// Note Kept with the report
+	Note *string `json:"note,omitempty"`
+}
+
+// PostAdminReportsIdResolveJSONBodyAction defines parameters for PostAdminReportsIdResolve.
+type PostAdminReportsIdResolveJSONBodyAction string
+
+// PostAdminServiceAccountsJSONBody defines parameters for PostAdminServiceAccounts.
+type PostAdminServiceAccountsJSONBody struct {
+	Name string `json:"name"`
+}
+
+// PostAdminServiceAccountsIdKeysJSONBody defines parameters for PostAdminServiceAccountsIdKeys.
+type PostAdminServiceAccountsIdKeysJSONBody struct {
+	AllowedCidrs  []string                                       `json:"allowed_cidrs"`
+	ExpiresInDays *int                                           `json:"expires_in_days,omitempty"`
+	Name          string                                         `json:"name"`
+	Scopes        []PostAdminServiceAccountsIdKeysJSONBodyScopes `json:"scopes"`
+}
+
+// PostAdminServiceAccountsIdKeysJSONBodyScopes defines parameters for PostAdminServiceAccountsIdKeys.
+type PostAdminServiceAccountsIdKeysJSONBodyScopes string
+
+// PatchAdminSettingsJSONBody defines parameters for PatchAdminSettings.
+type PatchAdminSettingsJSONBody struct {
+	Key   *string `json:"key,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// PostAdminSnapshotsIdRestoreJSONBody defines parameters for PostAdminSnapshotsIdRestore.
+type PostAdminSnapshotsIdRestoreJSONBody struct {
+	FileIds []openapi_types.UUID `json:"file_ids"`
+}
+
+// GetAdminStatsContentTypesParams defines parameters for GetAdminStatsContentTypes.
+type GetAdminStatsContentTypesParams struct {
+	// Refresh Skip the cache and compute the statistics again
+	Refresh *bool `form:"refresh,omitempty" json:"refresh,omitempty"`
+}
+
+// PostAdminStorageReplicaReconcileJSONBody defines parameters for PostAdminStorageReplicaReconcile.
+type PostAdminStorageReplicaReconcileJSONBody struct {
+	DryRun *bool `json:"dry_run,omitempty"`
+}
+
+// GetAdminUsageParams defines parameters for GetAdminUsage.
+type GetAdminUsageParams struct {
+	// Month YYYY-MM (UTC); the last finished month when unset
+	Month *UsageMonth `form:"month,omitempty" json:"month,omitempty"`
+}
+
+// GetAdminUsageReportsPeriodParamsPeriod defines parameters for GetAdminUsageReportsPeriod.
+type GetAdminUsageReportsPeriodParamsPeriod string
+
+// PostAdminUsageReportsPeriodSendParamsPeriod defines parameters for PostAdminUsageReportsPeriodSend.
+type PostAdminUsageReportsPeriodSendParamsPeriod string
+
+// GetAdminUsageExportParams defines parameters for GetAdminUsageExport.
+type GetAdminUsageExportParams struct {
+	// Month YYYY-MM (UTC); the last finished month when unset
+	Month *UsageMonth `form:"month,omitempty" json:"month,omitempty"`
+}
+
+// PostAdminUsageSendParams defines parameters for PostAdminUsageSend.
+type PostAdminUsageSendParams struct {
+	// Month YYYY-MM (UTC); the last finished month when unset
+	Month *UsageMonth `form:"month,omitempty" json:"month,omitempty"`
+}
+
+// GetAdminUsersParams defines parameters for GetAdminUsers.
+type GetAdminUsersParams struct {
+	Page   *int                       `form:"page,omitempty" json:"page,omitempty"`
+	Limit  *int                       `form:"limit,omitempty" json:"limit,omitempty"`
+	Status *GetAdminUsersParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetAdminUsersParamsStatus defines parameters for GetAdminUsers.
+type GetAdminUsersParamsStatus string
+
+// GetAdminUsersIdFilesParams defines parameters for GetAdminUsersIdFiles.
+type GetAdminUsersIdFilesParams struct {
+	Limit  *AdminFilesLimit  `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *AdminFilesOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor from the previous page (see the Cursor parameter); cannot be combined with offset
+	Cursor *AdminFilesCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// MinSize Minimum size in bytes
+	MinSize *AdminFilesMinSize `form:"min_size,omitempty" json:"min_size,omitempty"`
+
+	// MaxSize Maximum size in bytes
+	MaxSize *AdminFilesMaxSize `form:"max_size,omitempty" json:"max_size,omitempty"`
+
+	// MimeType Exact MIME type, or a prefix ending in * (e.g. image/*)
+	MimeType *AdminFilesMimeType `form:"mime_type,omitempty" json:"mime_type,omitempty"`
+
+	// UploadedAfter RFC 3339 timestamp or YYYY-MM-DD (inclusive)
+	UploadedAfter *AdminFilesUploadedAfter `form:"uploaded_after,omitempty" json:"uploaded_after,omitempty"`
+
+	// UploadedBefore RFC 3339 timestamp or YYYY-MM-DD (exclusive)
+	UploadedBefore *AdminFilesUploadedBefore `form:"uploaded_before,omitempty" json:"uploaded_before,omitempty"`
+}
+
+// PatchAdminUsersIdProfileJSONBody defines parameters for PatchAdminUsersIdProfile.
+type PatchAdminUsersIdProfileJSONBody struct {
+	DisplayName *string              `json:"display_name,omitempty"`
+	Email       *openapi_types.Email `json:"email,omitempty"`
+	Username    *string              `json:"username,omitempty"`
+}
+
+// PutAdminUsersIdReadOnlyJSONBody defines parameters for PutAdminUsersIdReadOnly.
+type PutAdminUsersIdReadOnlyJSONBody struct {
+	ReadOnly bool `json:"read_only"`
+}
+
+// PatchAdminUsersIdRoleJSONBody defines parameters for PatchAdminUsersIdRole.
+type PatchAdminUsersIdRoleJSONBody struct {
+	Role PatchAdminUsersIdRoleJSONBodyRole `json:"role"`
+}
+
+// PatchAdminUsersIdRoleJSONBodyRole defines parameters for PatchAdminUsersIdRole.
+type PatchAdminUsersIdRoleJSONBodyRole string
+
+// PatchAdminUsersIdStatusJSONBody defines parameters for PatchAdminUsersIdStatus.
+type PatchAdminUsersIdStatusJSONBody struct {
+	Status PatchAdminUsersIdStatusJSONBodyStatus `json:"status"`
+}
+
+// PatchAdminUsersIdStatusJSONBodyStatus defines parameters for PatchAdminUsersIdStatus.
+type PatchAdminUsersIdStatusJSONBodyStatus string
+
+// GetAdminUsersIdStorageParams defines parameters for GetAdminUsersIdStorage.
+type GetAdminUsersIdStorageParams struct {
+	// Months Months of growth to return, the current one included
+	Months *int `form:"months,omitempty" json:"months,omitempty"`
+}
+
+// PostAdminUsersIdTransferFilesJSONBody defines parameters for PostAdminUsersIdTransferFiles.
+type PostAdminUsersIdTransferFilesJSONBody struct {
+	// FileIds Files to transfer; omit for all. All of them must belong to the user, or nothing is transferred.
+	FileIds *[]string `json:"file_ids,omitempty"`
+
+	// ToUserId New owner (or to_username)
+	ToUserId   *string `json:"to_user_id,omitempty"`
+	ToUsername *string `json:"to_username,omitempty"`
+}
+
+// PostAuthLoginJSONBody defines parameters for PostAuthLogin.
+type PostAuthLoginJSONBody struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// PostAuthRefreshJSONBody defines parameters for PostAuthRefresh.
+type PostAuthRefreshJSONBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// PostAuthRegisterJSONBody defines parameters for PostAuthRegister.
+type PostAuthRegisterJSONBody struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+	Username string              `json:"username"`
+}
+
+// PostAuthRevokeJSONBody defines parameters for PostAuthRevoke.
+type PostAuthRevokeJSONBody struct {
+	// Token Access token or refresh token
+	Token string `json:"token"`
+}
+
+// PostAuthTokensJSONBody defines parameters for PostAuthTokens.
+type PostAuthTokensJSONBody struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+	Name      string     `json:"name"`
+
+	// Scopes What the token may do: files:read (listing and downloading), files:write
+	// (uploads and changes), files:delete (deleting files); admin additionally allows
+	// the admin routes and is only granted to admins. A personal access token can
+	// only grant scopes it has itself. GET /permissions lists the scope of every
+	// route. Defaults to the three files scopes.
+	Scopes *[]PostAuthTokensJSONBodyScopes `json:"scopes,omitempty"`
+}
+
+// PostAuthTokensJSONBodyScopes defines parameters for PostAuthTokens.
+type PostAuthTokensJSONBodyScopes string
+
+// GetDownloadIdParams defines parameters for GetDownloadId.
+type GetDownloadIdParams struct {
+	// Ticket One-time ticket from POST /files/{fileID}/ticket (valid 60 seconds), used instead
+	// of the Authorization header. Session tokens are not accepted in the query string.
+	Ticket *AccessTicket `form:"ticket,omitempty" json:"ticket,omitempty"`
+
+	// XFilePassword Per-file download password (only required for password-protected files)
+	XFilePassword *FilePassword `json:"X-File-Password,omitempty"`
+
+	// IfNoneMatch ETag from a previous response
+	IfNoneMatch     *IfNoneMatch `json:"If-None-Match,omitempty"`
+	IfModifiedSince *string      `json:"If-Modified-Since,omitempty"`
+}
+
+// DeleteFilesParams defines parameters for DeleteFiles.
+type DeleteFilesParams struct {
+	// Id File ID to delete
+	Id string `form:"id" json:"id"`
+
+	// Permanent Skip the trash; also deletes files that are already in it
+	Permanent *bool `form:"permanent,omitempty" json:"permanent,omitempty"`
+
+	// IdempotencyKey Client-chosen unique key (e.g. a random UUID) that makes retries safe. A successful
+	// response is stored for 24 hours (features.idempotency.ttl) and replayed, with an
+	// `Idempotent-Replayed: true` header, to later requests of the same user with the same
+	// key instead of running them again (of a response over 64 KB the status, Location
+	// and top-level fields such as file_id are replayed; nested lists and objects are dropped). While the first request is still running, retries
+	// get 409 IDEMPOTENCY_IN_PROGRESS; reusing a key for a different request gets 422
+	// IDEMPOTENCY_KEY_REUSED. Failed requests do not keep the key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetFilesParams defines parameters for GetFiles.
+type GetFilesParams struct {
+	// Limit Page size; when omitted, the user's page_size preference (all files by default)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page. Cursors are opaque tokens: pass them back
+	// unchanged. They point at the last item seen rather than a position, so pages
+	// do not skip or repeat items when items are added or removed in between.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// IfNoneMatch ETag from a previous response
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PostFilesBatchUpdateJSONBody defines parameters for PostFilesBatchUpdate.
+type PostFilesBatchUpdateJSONBody struct {
+	AddTags     *[]string  `json:"add_tags,omitempty"`
+	ClearExpiry *bool      `json:"clear_expiry,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	FileIds     []string   `json:"file_ids"`
+
+	// Folder Folder to move the files to
+	Folder     *string   `json:"folder,omitempty"`
+	RemoveTags *[]string `json:"remove_tags,omitempty"`
+}
+
+// PostFilesBatchUpdateParams defines parameters for PostFilesBatchUpdate.
+type PostFilesBatchUpdateParams struct {
+	// IdempotencyKey Client-chosen unique key (e.g. a random UUID) that makes retries safe. A successful
+	// response is stored for 24 hours (features.idempotency.ttl) and replayed, with an
+	// `Idempotent-Replayed: true` header, to later requests of the same user with the same
+	// key instead of running them again (of a response over 64 KB the status, Location
+	// and top-level fields such as file_id are replayed; nested lists and objects are dropped). While the first request is still running, retries
+	// get 409 IDEMPOTENCY_IN_PROGRESS; reusing a key for a different request gets 422
+	// IDEMPOTENCY_KEY_REUSED. Failed requests do not keep the key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetFilesExpiringParams defines parameters for GetFilesExpiring.
+type GetFilesExpiringParams struct {
+	// Within Go duration string (e.g. "24h", "72h"), max one year
+	Within *string `form:"within,omitempty" json:"within,omitempty"`
+}
+
+// GetFilesRecentParams defines parameters for GetFilesRecent.
+type GetFilesRecentParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IfNoneMatch ETag from a previous response
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetFilesSearchParams defines parameters for GetFilesSearch.
+type GetFilesSearchParams struct {
+	// Q Search query (matches filename and tags)
+	Q string `form:"q" json:"q"`
+
+	// Limit Page size; when omitted, the user's page_size preference (all results by default)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page. Cursors are opaque tokens: pass them back
+	// unchanged. They point at the last item seen rather than a position, so pages
+	// do not skip or repeat items when items are added or removed in between.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetFilesStarredParams defines parameters for GetFilesStarred.
+type GetFilesStarredParams struct {
+	// IfNoneMatch ETag from a previous response
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// DeleteFilesTrashParams defines parameters for DeleteFilesTrash.
+type DeleteFilesTrashParams struct {
+	// IdempotencyKey Client-chosen unique key (e.g. a random UUID) that makes retries safe. A successful
+	// response is stored for 24 hours (features.idempotency.ttl) and replayed, with an
+	// `Idempotent-Replayed: true` header, to later requests of the same user with the same
+	// key instead of running them again (of a response over 64 KB the status, Location
+	// and top-level fields such as file_id are replayed; nested lists and objects are dropped). While the first request is still running, retries
+	// get 409 IDEMPOTENCY_IN_PROGRESS; reusing a key for a different request gets 422
+	// IDEMPOTENCY_KEY_REUSED. Failed requests do not keep the key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// HeadFilesFileIDParams defines parameters for HeadFilesFileID.
+type HeadFilesFileIDParams struct {
+	// IfNoneMatch ETag from a previous response
+	IfNoneMatch     *IfNoneMatch `json:"If-None-Match,omitempty"`
+	IfModifiedSince *string      `json:"If-Modified-Since,omitempty"`
+}
+
+// PatchFilesFileIDJSONBody defines parameters for PatchFilesFileID.
+type PatchFilesFileIDJSONBody struct {
+	Description *string `json:"description,omitempty"`
+
+	// FileName New filename (same filename policy as /upload)
+	FileName *string `json:"file_name,omitempty"`
+
+	// Folder Virtual folder to move the file to
+	Folder *string `json:"folder,omitempty"`
+
+	// ModifiedAt New modification time (as for /upload)
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+	Tags       *[]string  `json:"tags,omitempty"`
+}
+
+// PatchFilesFileIDParams defines parameters for PatchFilesFileID.
+type PatchFilesFileIDParams struct {
+	// IfMatch ETag of the file as last read (from a download or an earlier PATCH). The update only
+	// applies if the file has not changed since; `*` matches any version.
+	IfMatch *string `json:"If-Match,omitempty"`
+
+	// IfUnmodifiedSince HTTP date; ignored when If-Match is sent. The update only applies if updated_at is not later.
+	IfUnmodifiedSince *string `json:"If-Unmodified-Since,omitempty"`
+}
+
+// PostFilesFileIDCopyJSONBody defines parameters for PostFilesFileIDCopy.
+type PostFilesFileIDCopyJSONBody struct {
+	// FileName Name of the copy (same filename policy as /upload); default the original's
+	FileName *string `json:"file_name,omitempty"`
+
+	// Folder Folder of the copy; default the original's
+	Folder *string `json:"folder,omitempty"`
+}
+
+// PostFilesFileIDCopyParams defines parameters for PostFilesFileIDCopy.
+type PostFilesFileIDCopyParams struct {
+	// IdempotencyKey Client-chosen unique key (e.g. a random UUID) that makes retries safe. A successful
+	// response is stored for 24 hours (features.idempotency.ttl) and replayed, with an
+	// `Idempotent-Replayed: true` header, to later requests of the same user with the same
+	// key instead of running them again (of a response over 64 KB the status, Location
+	// and top-level fields such as file_id are replayed; nested lists and objects are dropped). While the first request is still running, retries
+	// get 409 IDEMPOTENCY_IN_PROGRESS; reusing a key for a different request gets 422
+	// IDEMPOTENCY_KEY_REUSED. Failed requests do not keep the key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostFilesFileIDDownloadUrlJSONBody defines parameters for PostFilesFileIDDownloadUrl.
+type PostFilesFileIDDownloadUrlJSONBody struct {
+	Disposition *PostFilesFileIDDownloadUrlJSONBodyDisposition `json:"disposition,omitempty"`
+
+	// ExpiresIn Lifetime in seconds (default and maximum are configurable; 1h / 7 days by default)
+	ExpiresIn *int `json:"expires_in,omitempty"`
+}
+
+// PostFilesFileIDDownloadUrlParams defines parameters for PostFilesFileIDDownloadUrl.
+type PostFilesFileIDDownloadUrlParams struct {
+	// XFilePassword Per-file download password (only required for password-protected files)
+	XFilePassword *FilePassword `json:"X-File-Password,omitempty"`
+}
+
+// PostFilesFileIDDownloadUrlJSONBodyDisposition defines parameters for PostFilesFileIDDownloadUrl.
+type PostFilesFileIDDownloadUrlJSONBodyDisposition string
+
+// PatchFilesFileIDExpiryJSONBody defines parameters for PatchFilesFileIDExpiry.
+type PatchFilesFileIDExpiryJSONBody struct {
+	Clear       *bool      `json:"clear,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ExtendHours *int       `json:"extend_hours,omitempty"`
+}
+
+// PostFilesFileIDMoveJSONBody defines parameters for PostFilesFileIDMove.
+type PostFilesFileIDMoveJSONBody struct {
+	// FileName New filename (same filename policy as /upload)
+	FileName *string `json:"file_name,omitempty"`
+
+	// Folder Folder to move the file to
+	Folder *string `json:"folder,omitempty"`
+}
+
+// PostFilesFileIDMoveParams defines parameters for PostFilesFileIDMove.
+type PostFilesFileIDMoveParams struct {
+	// IfMatch ETag of the file as last read; the move only applies if the file has not changed since
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// DeleteFilesFileIDPasswordParams defines parameters for DeleteFilesFileIDPassword.
+type DeleteFilesFileIDPasswordParams struct {
+	// XFilePassword Per-file download password (only required for password-protected files)
+	XFilePassword *FilePassword `json:"X-File-Password,omitempty"`
+}
+
+// PutFilesFileIDPasswordJSONBody defines parameters for PutFilesFileIDPassword.
+type PutFilesFileIDPasswordJSONBody struct {
+	// CurrentPassword Required when the file already has a password
+	CurrentPassword *string `json:"current_password,omitempty"`
+	Password        string  `json:"password"`
+}
+
+// PostFilesFileIDSharesJSONBody defines parameters for PostFilesFileIDShares.
+type PostFilesFileIDSharesJSONBody struct {
+	AllowedIps      *[]string `json:"allowed_ips,omitempty"`
+	AllowedReferers *[]string `json:"allowed_referers,omitempty"`
+	ExpiresInHours  *int      `json:"expires_in_hours,omitempty"`
+	MaxBytes        *int64    `json:"max_bytes,omitempty"`
+	MaxRequests     *int      `json:"max_requests,omitempty"`
+}
+
+// GetFilesFileIDThumbnailParams defines parameters for GetFilesFileIDThumbnail.
+type GetFilesFileIDThumbnailParams struct {
+	// Ticket One-time ticket from POST /files/{fileID}/ticket (valid 60 seconds), used instead
+	// of the Authorization header. Session tokens are not accepted in the query string.
+	Ticket *AccessTicket `form:"ticket,omitempty" json:"ticket,omitempty"`
+
+	// XFilePassword Per-file download password (only required for password-protected files)
+	XFilePassword *FilePassword `json:"X-File-Password,omitempty"`
+}
+
+// PostFilesFileIDTicketJSONBody defines parameters for PostFilesFileIDTicket.
+type PostFilesFileIDTicketJSONBody struct {
+	Purpose *PostFilesFileIDTicketJSONBodyPurpose `json:"purpose,omitempty"`
+}
+
+// PostFilesFileIDTicketParams defines parameters for PostFilesFileIDTicket.
+type PostFilesFileIDTicketParams struct {
+	// XFilePassword Per-file download password (only required for password-protected files)
+	XFilePassword *FilePassword `json:"X-File-Password,omitempty"`
+}
+
+// PostFilesFileIDTicketJSONBodyPurpose defines parameters for PostFilesFileIDTicket.
+type PostFilesFileIDTicketJSONBodyPurpose string
+
+// GetSTokenParams defines parameters for GetSToken.
+type GetSTokenParams struct {
+	// XFilePassword Per-file download password (only required for password-protected files)
+	XFilePassword *FilePassword `json:"X-File-Password,omitempty"`
+}
+
+// PostSTokenReportJSONBody defines parameters for PostSTokenReport.
+type PostSTokenReportJSONBody struct {
+	Category PostSTokenReportJSONBodyCategory `json:"category"`
+
+	// Email Address to contact the reporter at, e.g. for a DMCA notice
+	Email   *openapi_types.Email `json:"email,omitempty"`
+	Message *string              `json:"message,omitempty"`
+}
+
+// PostSTokenReportParams defines parameters for PostSTokenReport.
+type PostSTokenReportParams struct {
+	XCaptchaToken *string `json:"X-Captcha-Token,omitempty"`
+}
+
+// PostSTokenReportJSONBodyCategory defines parameters for PostSTokenReport.
+type PostSTokenReportJSONBodyCategory string
+
+// PatchSharesIdJSONBody defines parameters for PatchSharesId.
+type PatchSharesIdJSONBody struct {
+	// Enabled Enable or disable the link; leave out to keep its current state
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ResetCounters Reset request and byte counters to zero
+	ResetCounters *bool `json:"reset_counters,omitempty"`
+}
+
+// GetStreamIdParams defines parameters for GetStreamId.
+type GetStreamIdParams struct {
+	// Ticket One-time ticket from POST /files/{fileID}/ticket (valid 60 seconds), used instead
+	// of the Authorization header. Session tokens are not accepted in the query string.
+	Ticket *AccessTicket `form:"ticket,omitempty" json:"ticket,omitempty"`
+
+	// XFilePassword Per-file download password (only required for password-protected files)
+	XFilePassword *FilePassword `json:"X-File-Password,omitempty"`
+
+	// Range HTTP Range header for seeking (e.g., "bytes=0-1023")
+	Range *string `json:"Range,omitempty"`
+}
+
+// PostUploadMultipartBody defines parameters for PostUpload.
+type PostUploadMultipartBody struct {
+	// ExpireAfter Hours until file expires and is auto-deleted (0 = never; omitted = the user's default_expiry_hours preference)
+	ExpireAfter *int `json:"expire_after,omitempty"`
+
+	// File File to upload (max 500MB). The filename is NFC-normalized; control
+	// characters and directory components are stripped and overlong names
+	// shortened, or the upload is rejected if the server's filename policy is "reject".
+	File openapi_types.File `json:"file"`
+
+	// Folder Virtual folder to put the file in (default "/")
+	Folder *string `json:"folder,omitempty"`
+
+	// ModifiedAt Modification time of the file as the client knows it (e.g. the mtime of the
+	// local file), returned as modified_at in listings so sync tools can compare it
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+
+	// Sha256 Hex SHA-256 of the file as sent by the client. The upload is rejected
+	// (and nothing stored) if the server received different content.
+	// May come after the file part.
+	Sha256 *string `json:"sha256,omitempty"`
+
+	// Tags Comma-separated tags (e.g., "work,document,2025")
+	Tags *string `json:"tags,omitempty"`
+}
+
+// PostUploadParams defines parameters for PostUpload.
+type PostUploadParams struct {
+	// IdempotencyKey Client-chosen unique key (e.g. a random UUID) that makes retries safe. A successful
+	// response is stored for 24 hours (features.idempotency.ttl) and replayed, with an
+	// `Idempotent-Replayed: true` header, to later requests of the same user with the same
+	// key instead of running them again (of a response over 64 KB the status, Location
+	// and top-level fields such as file_id are replayed; nested lists and objects are dropped). While the first request is still running, retries
+	// get 409 IDEMPOTENCY_IN_PROGRESS; reusing a key for a different request gets 422
+	// IDEMPOTENCY_KEY_REUSED. Failed requests do not keep the key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostUploadPrecheckJSONBody defines parameters for PostUploadPrecheck.
+type PostUploadPrecheckJSONBody struct {
+	Description *string `json:"description,omitempty"`
+
+	// ExpireAfter Hours until file expires and is auto-deleted (0 = never; omitted = the user's default_expiry_hours preference)
+	ExpireAfter *int `json:"expire_after,omitempty"`
+
+	// FileName Name of the new file (same filename policy as /upload)
+	FileName string `json:"file_name"`
+
+	// Folder Virtual folder to put the file in (default "/")
+	Folder *string `json:"folder,omitempty"`
+
+	// ModifiedAt Modification time of the file as the client knows it (as for /upload)
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+
+	// Sha256 Hex SHA-256 of the file
+	Sha256 string    `json:"sha256"`
+	Size   int64     `json:"size"`
+	Tags   *[]string `json:"tags,omitempty"`
+}
+
+// PostUploadPrecheckParams defines parameters for PostUploadPrecheck.
+type PostUploadPrecheckParams struct {
+	// IdempotencyKey Client-chosen unique key (e.g. a random UUID) that makes retries safe. A successful
+	// response is stored for 24 hours (features.idempotency.ttl) and replayed, with an
+	// `Idempotent-Replayed: true` header, to later requests of the same user with the same
+	// key instead of running them again (of a response over 64 KB the status, Location
+	// and top-level fields such as file_id are replayed; nested lists and objects are dropped). While the first request is still running, retries
+	// get 409 IDEMPOTENCY_IN_PROGRESS; reusing a key for a different request gets 422
+	// IDEMPOTENCY_KEY_REUSED. Failed requests do not keep the key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PatchUserPasswordJSONBody defines parameters for PatchUserPassword.
+type PatchUserPasswordJSONBody struct {
+	NewPassword string `json:"new_password"`
+	OldPassword string `json:"old_password"`
+}
+
+// PatchUserProfileJSONBody defines parameters for PatchUserProfile.
+type PatchUserProfileJSONBody struct {
+	// CurrentPassword Required when email changes
+	CurrentPassword *string              `json:"current_password,omitempty"`
+	DisplayName     *string              `json:"display_name,omitempty"`
+	Email           *openapi_types.Email `json:"email,omitempty"`
+}
+
+// PostUserProfileVerifyEmailJSONBody defines parameters for PostUserProfileVerifyEmail.
+type PostUserProfileVerifyEmailJSONBody struct {
+	Code string `json:"code"`
+}
+
+// GetUsersIdAvatarParams defines parameters for GetUsersIdAvatar.
+type GetUsersIdAvatarParams struct {
+	// V Avatar version, as in avatar_url
+	V *string `form:"v,omitempty" json:"v,omitempty"`
+}
+
+// PostAdminAnnouncementsJSONRequestBody defines body for PostAdminAnnouncements for application/json ContentType.
+type PostAdminAnnouncementsJSONRequestBody PostAdminAnnouncementsJSONBody
+
+// PutAdminIpAccessJSONRequestBody defines body for PutAdminIpAccess for application/json ContentType.
+type PutAdminIpAccessJSONRequestBody = IPAccessRules
+
+// PostAdminKeysRotateJSONRequestBody defines body for PostAdminKeysRotate for application/json ContentType.
+type PostAdminKeysRotateJSONRequestBody PostAdminKeysRotateJSONBody
+
+// PutAdminMaintenanceJSONRequestBody defines body for PutAdminMaintenance for application/json ContentType.
+type PutAdminMaintenanceJSONRequestBody PutAdminMaintenanceJSONBody
+
+// PutAdminReadOnlyJSONRequestBody defines body for PutAdminReadOnly for application/json ContentType.
+type PutAdminReadOnlyJSONRequestBody PutAdminReadOnlyJSONBody
+
+// PostAdminReplicationRemotesJSONRequestBody defines body for PostAdminReplicationRemotes for application/json ContentType.
+type PostAdminReplicationRemotesJSONRequestBody PostAdminReplicationRemotesJSONBody
+
+// PostAdminReplicationRemotesIdSyncJSONRequestBody defines body for PostAdminReplicationRemotesIdSync for application/json ContentType.
+type PostAdminReplicationRemotesIdSyncJSONRequestBody PostAdminReplicationRemotesIdSyncJSONBody
+
+// PostAdminReportsIdResolveJSONRequestBody defines body for PostAdminReportsIdResolve for application/json ContentType.
+type PostAdminReportsIdResolveJSONRequestBody PostAdminReportsIdResolveJSONBody
+
+// PostAdminServiceAccountsJSONRequestBody defines body for PostAdminServiceAccounts for application/json ContentType.
+type PostAdminServiceAccountsJSONRequestBody PostAdminServiceAccountsJSONBody
+
+// PostAdminServiceAccountsIdKeysJSONRequestBody defines body for PostAdminServiceAccountsIdKeys for application/json ContentType.
+type PostAdminServiceAccountsIdKeysJSONRequestBody PostAdminServiceAccountsIdKeysJSONBody
+
+// PatchAdminSettingsJSONRequestBody defines body for PatchAdminSettings for application/json ContentType.
+type PatchAdminSettingsJSONRequestBody PatchAdminSettingsJSONBody
+
+// PostAdminSnapshotsIdRestoreJSONRequestBody defines body for PostAdminSnapshotsIdRestore for application/json ContentType.
+type PostAdminSnapshotsIdRestoreJSONRequestBody PostAdminSnapshotsIdRestoreJSONBody
+
+// PostAdminStorageReplicaReconcileJSONRequestBody defines body for PostAdminStorageReplicaReconcile for application/json ContentType.
+type PostAdminStorageReplicaReconcileJSONRequestBody PostAdminStorageReplicaReconcileJSONBody
+
+// PatchAdminUsersIdProfileJSONRequestBody defines body for PatchAdminUsersIdProfile for application/json ContentType.
+type PatchAdminUsersIdProfileJSONRequestBody PatchAdminUsersIdProfileJSONBody
+
+// PutAdminUsersIdReadOnlyJSONRequestBody defines body for PutAdminUsersIdReadOnly for application/json ContentType.
+type PutAdminUsersIdReadOnlyJSONRequestBody PutAdminUsersIdReadOnlyJSONBody
+
+// PatchAdminUsersIdRoleJSONRequestBody defines body for PatchAdminUsersIdRole for application/json ContentType.
+type PatchAdminUsersIdRoleJSONRequestBody PatchAdminUsersIdRoleJSONBody
+
+// PatchAdminUsersIdStatusJSONRequestBody defines body for PatchAdminUsersIdStatus for application/json ContentType.
+type PatchAdminUsersIdStatusJSONRequestBody PatchAdminUsersIdStatusJSONBody
+
+// PostAdminUsersIdTransferFilesJSONRequestBody defines body for PostAdminUsersIdTransferFiles for application/json ContentType.
+type PostAdminUsersIdTransferFilesJSONRequestBody PostAdminUsersIdTransferFilesJSONBody
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody PostAuthLoginJSONBody
+
+// PostAuthRefreshJSONRequestBody defines body for PostAuthRefresh for application/json ContentType.
+type PostAuthRefreshJSONRequestBody PostAuthRefreshJSONBody
+
+// PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
+type PostAuthRegisterJSONRequestBody PostAuthRegisterJSONBody
+
+// PostAuthRevokeJSONRequestBody defines body for PostAuthRevoke for application/json ContentType.
+type PostAuthRevokeJSONRequestBody PostAuthRevokeJSONBody
+
+// PostAuthTokensJSONRequestBody defines body for PostAuthTokens for application/json ContentType.
+type PostAuthTokensJSONRequestBody PostAuthTokensJSONBody
+
+// PostFilesBatchUpdateJSONRequestBody defines body for PostFilesBatchUpdate for application/json ContentType.
+type PostFilesBatchUpdateJSONRequestBody PostFilesBatchUpdateJSONBody
+
+// PatchFilesFileIDJSONRequestBody defines body for PatchFilesFileID for application/json ContentType.
+type PatchFilesFileIDJSONRequestBody PatchFilesFileIDJSONBody
+
+// PostFilesFileIDCopyJSONRequestBody defines body for PostFilesFileIDCopy for application/json ContentType.
+type PostFilesFileIDCopyJSONRequestBody PostFilesFileIDCopyJSONBody
+
+// PostFilesFileIDDownloadUrlJSONRequestBody defines body for PostFilesFileIDDownloadUrl for application/json ContentType.
+type PostFilesFileIDDownloadUrlJSONRequestBody PostFilesFileIDDownloadUrlJSONBody
+
+// PatchFilesFileIDExpiryJSONRequestBody defines body for PatchFilesFileIDExpiry for application/json ContentType.
+type PatchFilesFileIDExpiryJSONRequestBody PatchFilesFileIDExpiryJSONBody
+
+// PostFilesFileIDMoveJSONRequestBody defines body for PostFilesFileIDMove for application/json ContentType.
+type PostFilesFileIDMoveJSONRequestBody PostFilesFileIDMoveJSONBody
+
+// PutFilesFileIDPasswordJSONRequestBody defines body for PutFilesFileIDPassword for application/json ContentType.
+type PutFilesFileIDPasswordJSONRequestBody PutFilesFileIDPasswordJSONBody
+
+// PostFilesFileIDSharesJSONRequestBody defines body for PostFilesFileIDShares for application/json ContentType.
+type PostFilesFileIDSharesJSONRequestBody PostFilesFileIDSharesJSONBody
+
+// PostFilesFileIDTicketJSONRequestBody defines body for PostFilesFileIDTicket for application/json ContentType.
+type PostFilesFileIDTicketJSONRequestBody PostFilesFileIDTicketJSONBody
+
+// PostSTokenReportJSONRequestBody defines body for PostSTokenReport for application/json ContentType.
+type PostSTokenReportJSONRequestBody PostSTokenReportJSONBody
+
+// PatchSharesIdJSONRequestBody defines body for PatchSharesId for application/json ContentType.
+type PatchSharesIdJSONRequestBody PatchSharesIdJSONBody
+
+// PostUploadMultipartRequestBody defines body for PostUpload for multipart/form-data ContentType.
+type PostUploadMultipartRequestBody PostUploadMultipartBody
+
+// PostUploadPrecheckJSONRequestBody defines body for PostUploadPrecheck for application/json ContentType.
+type PostUploadPrecheckJSONRequestBody PostUploadPrecheckJSONBody
+
+// PatchUserPasswordJSONRequestBody defines body for PatchUserPassword for application/json ContentType.
+type PatchUserPasswordJSONRequestBody PatchUserPasswordJSONBody
+
+// PatchUserPreferencesJSONRequestBody defines body for PatchUserPreferences for application/json ContentType.
+type PatchUserPreferencesJSONRequestBody = UserPreferences
+
+// PatchUserProfileJSONRequestBody defines body for PatchUserProfile for application/json ContentType.
+type PatchUserProfileJSONRequestBody PatchUserProfileJSONBody
+
+// PostUserProfileVerifyEmailJSONRequestBody defines body for PostUserProfileVerifyEmail for application/json ContentType.
+type PostUserProfileVerifyEmailJSONRequestBody PostUserProfileVerifyEmailJSONBody
+
+// Getter for additional properties for ErrorResponse. Returns the specified
+// element and whether it was found
+func (a ErrorResponse) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for ErrorResponse
+func (a *ErrorResponse) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for ErrorResponse to handle AdditionalProperties
+func (a *ErrorResponse) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["code"]; found {
+		err = json.Unmarshal(raw, &a.Code)
+		if err != nil {
+			return fmt.Errorf("error reading 'code': %w", err)
+		}
+		delete(object, "code")
+	}
+
+	if raw, found := object["error"]; found {
+		err = json.Unmarshal(raw, &a.Error)
+		if err != nil {
+			return fmt.Errorf("error reading 'error': %w", err)
+		}
+		delete(object, "error")
+	}
+
+	if raw, found := object["request_id"]; found {
+		err = json.Unmarshal(raw, &a.RequestId)
+		if err != nil {
+			return fmt.Errorf("error reading 'request_id': %w", err)
+		}
+		delete(object, "request_id")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for ErrorResponse to handle AdditionalProperties
+func (a ErrorResponse) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	object["code"], err = json.Marshal(a.Code)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'code': %w", err)
+	}
+
+	object["error"], err = json.Marshal(a.Error)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'error': %w", err)
+	}
+
+	if a.RequestId != nil {
+		object["request_id"], err = json.Marshal(a.RequestId)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'request_id': %w", err)
+		}
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAdminAnnouncements request
+	GetAdminAnnouncements(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminAnnouncementsWithBody request with any body
+	PostAdminAnnouncementsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminAnnouncements(ctx context.Context, body PostAdminAnnouncementsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAdminAnnouncementsId request
+	DeleteAdminAnnouncementsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminFiles request
+	GetAdminFiles(ctx context.Context, params *GetAdminFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAdminFilesId request
+	DeleteAdminFilesId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminFilesIdReencrypt request
+	PostAdminFilesIdReencrypt(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminIpAccess request
+	GetAdminIpAccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminIpAccessWithBody request with any body
+	PutAdminIpAccessWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminIpAccess(ctx context.Context, body PutAdminIpAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminJobs request
+	GetAdminJobs(ctx context.Context, params *GetAdminJobsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminJobsIdRetry request
+	PostAdminJobsIdRetry(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminKeysRotateWithBody request with any body
+	PostAdminKeysRotateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminKeysRotate(ctx context.Context, body PostAdminKeysRotateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminKeysRotation request
+	GetAdminKeysRotation(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminLogs request
+	GetAdminLogs(ctx context.Context, params *GetAdminLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminMaintenance request
+	GetAdminMaintenance(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminMaintenanceWithBody request with any body
+	PutAdminMaintenanceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminMaintenance(ctx context.Context, body PutAdminMaintenanceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminNotifications request
+	GetAdminNotifications(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminNotificationsChannelTest request
+	PostAdminNotificationsChannelTest(ctx context.Context, channel string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminQuarantine request
+	GetAdminQuarantine(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminQuarantineIdApprove request
+	PostAdminQuarantineIdApprove(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminQuarantineIdReject request
+	PostAdminQuarantineIdReject(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminReadOnly request
+	GetAdminReadOnly(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminReadOnlyWithBody request with any body
+	PutAdminReadOnlyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminReadOnly(ctx context.Context, body PutAdminReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminReplicationRemotes request
+	GetAdminReplicationRemotes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminReplicationRemotesWithBody request with any body
+	PostAdminReplicationRemotesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminReplicationRemotes(ctx context.Context, body PostAdminReplicationRemotesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAdminReplicationRemotesId request
+	DeleteAdminReplicationRemotesId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminReplicationRemotesIdSyncWithBody request with any body
+	PostAdminReplicationRemotesIdSyncWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminReplicationRemotesIdSync(ctx context.Context, id openapi_types.UUID, body PostAdminReplicationRemotesIdSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminReports request
+	GetAdminReports(ctx context.Context, params *GetAdminReportsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminReportsIdResolveWithBody request with any body
+	PostAdminReportsIdResolveWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminReportsIdResolve(ctx context.Context, id openapi_types.UUID, body PostAdminReportsIdResolveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminServiceAccounts request
+	GetAdminServiceAccounts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminServiceAccountsWithBody request with any body
+	PostAdminServiceAccountsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminServiceAccounts(ctx context.Context, body PostAdminServiceAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAdminServiceAccountsId request
+	DeleteAdminServiceAccountsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminServiceAccountsIdKeys request
+	GetAdminServiceAccountsIdKeys(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminServiceAccountsIdKeysWithBody request with any body
+	PostAdminServiceAccountsIdKeysWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminServiceAccountsIdKeys(ctx context.Context, id string, body PostAdminServiceAccountsIdKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAdminServiceAccountsIdKeysKeyID request
+	DeleteAdminServiceAccountsIdKeysKeyID(ctx context.Context, id string, keyID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminSessions request
+	GetAdminSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminSessionsRevokeAll request
+	PostAdminSessionsRevokeAll(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminSettings request
+	GetAdminSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchAdminSettingsWithBody request with any body
+	PatchAdminSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchAdminSettings(ctx context.Context, body PatchAdminSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminSigningKeys request
+	GetAdminSigningKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminSigningKeysRotate request
+	PostAdminSigningKeysRotate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminSnapshots request
+	GetAdminSnapshots(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminSnapshots request
+	PostAdminSnapshots(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminSnapshotsIdRestorable request
+	GetAdminSnapshotsIdRestorable(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminSnapshotsIdRestoreWithBody request with any body
+	PostAdminSnapshotsIdRestoreWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminSnapshotsIdRestore(ctx context.Context, id string, body PostAdminSnapshotsIdRestoreJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminStats request
+	GetAdminStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminStatsContentTypes request
+	GetAdminStatsContentTypes(ctx context.Context, params *GetAdminStatsContentTypesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminStorageAnalyze request
+	GetAdminStorageAnalyze(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminStorageCleanup request
+	PostAdminStorageCleanup(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminStorageReplica request
+	GetAdminStorageReplica(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminStorageReplicaReconcileWithBody request with any body
+	PostAdminStorageReplicaReconcileWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminStorageReplicaReconcile(ctx context.Context, body PostAdminStorageReplicaReconcileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsage request
+	GetAdminUsage(ctx context.Context, params *GetAdminUsageParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsageReportsPeriod request
+	GetAdminUsageReportsPeriod(ctx context.Context, period GetAdminUsageReportsPeriodParamsPeriod, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminUsageReportsPeriodSend request
+	PostAdminUsageReportsPeriodSend(ctx context.Context, period PostAdminUsageReportsPeriodSendParamsPeriod, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsageExport request
+	GetAdminUsageExport(ctx context.Context, params *GetAdminUsageExportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminUsageSend request
+	PostAdminUsageSend(ctx context.Context, params *PostAdminUsageSendParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsers request
+	GetAdminUsers(ctx context.Context, params *GetAdminUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsersPending request
+	GetAdminUsersPending(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAdminUsersId request
+	DeleteAdminUsersId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminUsersIdApprove request
+	PostAdminUsersIdApprove(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsersIdFiles request
+	GetAdminUsersIdFiles(ctx context.Context, id string, params *GetAdminUsersIdFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminUsersIdLogout request
+	PostAdminUsersIdLogout(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchAdminUsersIdProfileWithBody request with any body
+	PatchAdminUsersIdProfileWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchAdminUsersIdProfile(ctx context.Context, id string, body PatchAdminUsersIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminUsersIdReadOnlyWithBody request with any body
+	PutAdminUsersIdReadOnlyWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminUsersIdReadOnly(ctx context.Context, id string, body PutAdminUsersIdReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminUsersIdReject request
+	PostAdminUsersIdReject(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminUsersIdResetPassword request
+	PostAdminUsersIdResetPassword(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchAdminUsersIdRoleWithBody request with any body
+	PatchAdminUsersIdRoleWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchAdminUsersIdRole(ctx context.Context, id string, body PatchAdminUsersIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchAdminUsersIdStatusWithBody request with any body
+	PatchAdminUsersIdStatusWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchAdminUsersIdStatus(ctx context.Context, id string, body PatchAdminUsersIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsersIdStorage request
+	GetAdminUsersIdStorage(ctx context.Context, id string, params *GetAdminUsersIdStorageParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminUsersIdTransferFilesWithBody request with any body
+	PostAdminUsersIdTransferFilesWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminUsersIdTransferFiles(ctx context.Context, id string, body PostAdminUsersIdTransferFilesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAnnouncements request
+	GetAnnouncements(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAnnouncementsIdDismiss request
+	PostAnnouncementsIdDismiss(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthLoginWithBody request with any body
+	PostAuthLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthLogin(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthLogout request
+	PostAuthLogout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAuthMe request
+	GetAuthMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthRefreshWithBody request with any body
+	PostAuthRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthRefresh(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthRegisterWithBody request with any body
+	PostAuthRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthRegister(ctx context.Context, body PostAuthRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthRevokeWithBody request with any body
+	PostAuthRevokeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthRevoke(ctx context.Context, body PostAuthRevokeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAuthTokens request
+	GetAuthTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthTokensWithBody request with any body
+	PostAuthTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthTokens(ctx context.Context, body PostAuthTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAuthTokensId request
+	DeleteAuthTokensId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDlToken request
+	GetDlToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDocsOpenapiYaml request
+	GetDocsOpenapiYaml(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDownloadId request
+	GetDownloadId(ctx context.Context, id string, params *GetDownloadIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFiles request
+	DeleteFiles(ctx context.Context, params *DeleteFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFiles request
+	GetFiles(ctx context.Context, params *GetFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesBatchUpdateWithBody request with any body
+	PostFilesBatchUpdateWithBody(ctx context.Context, params *PostFilesBatchUpdateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostFilesBatchUpdate(ctx context.Context, params *PostFilesBatchUpdateParams, body PostFilesBatchUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesExpiring request
+	GetFilesExpiring(ctx context.Context, params *GetFilesExpiringParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesExport request
+	GetFilesExport(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesRecent request
+	GetFilesRecent(ctx context.Context, params *GetFilesRecentParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesSearch request
+	GetFilesSearch(ctx context.Context, params *GetFilesSearchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesStarred request
+	GetFilesStarred(ctx context.Context, params *GetFilesStarredParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFilesTrash request
+	DeleteFilesTrash(ctx context.Context, params *DeleteFilesTrashParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesTrash request
+	GetFilesTrash(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesFileID request
+	GetFilesFileID(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// HeadFilesFileID request
+	HeadFilesFileID(ctx context.Context, fileID string, params *HeadFilesFileIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchFilesFileIDWithBody request with any body
+	PatchFilesFileIDWithBody(ctx context.Context, fileID string, params *PatchFilesFileIDParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchFilesFileID(ctx context.Context, fileID string, params *PatchFilesFileIDParams, body PatchFilesFileIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesFileIDCopyWithBody request with any body
+	PostFilesFileIDCopyWithBody(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostFilesFileIDCopy(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, body PostFilesFileIDCopyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesFileIDDownloadUrlWithBody request with any body
+	PostFilesFileIDDownloadUrlWithBody(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostFilesFileIDDownloadUrl(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, body PostFilesFileIDDownloadUrlJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchFilesFileIDExpiryWithBody request with any body
+	PatchFilesFileIDExpiryWithBody(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchFilesFileIDExpiry(ctx context.Context, fileID string, body PatchFilesFileIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesFileIDMoveWithBody request with any body
+	PostFilesFileIDMoveWithBody(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostFilesFileIDMove(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, body PostFilesFileIDMoveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFilesFileIDPassword request
+	DeleteFilesFileIDPassword(ctx context.Context, fileID string, params *DeleteFilesFileIDPasswordParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutFilesFileIDPasswordWithBody request with any body
+	PutFilesFileIDPasswordWithBody(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutFilesFileIDPassword(ctx context.Context, fileID string, body PutFilesFileIDPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesFileIDProcessing request
+	GetFilesFileIDProcessing(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesFileIDRestore request
+	PostFilesFileIDRestore(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesFileIDShares request
+	GetFilesFileIDShares(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesFileIDSharesWithBody request with any body
+	PostFilesFileIDSharesWithBody(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostFilesFileIDShares(ctx context.Context, fileID string, body PostFilesFileIDSharesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesFileIDSharesShareIDStats request
+	GetFilesFileIDSharesShareIDStats(ctx context.Context, fileID string, shareID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFilesFileIDStar request
+	DeleteFilesFileIDStar(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesFileIDStar request
+	PostFilesFileIDStar(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilesFileIDThumbnail request
+	GetFilesFileIDThumbnail(ctx context.Context, fileID string, params *GetFilesFileIDThumbnailParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostFilesFileIDTicketWithBody request with any body
+	PostFilesFileIDTicketWithBody(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostFilesFileIDTicket(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, body PostFilesFileIDTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPermissions request
+	GetPermissions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSToken request
+	GetSToken(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSTokenReportWithBody request with any body
+	PostSTokenReportWithBody(ctx context.Context, token string, params *PostSTokenReportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSTokenReport(ctx context.Context, token string, params *PostSTokenReportParams, body PostSTokenReportJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSharesId request
+	DeleteSharesId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchSharesIdWithBody request with any body
+	PatchSharesIdWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchSharesId(ctx context.Context, id string, body PatchSharesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStreamId request
+	GetStreamId(ctx context.Context, id string, params *GetStreamIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUploadWithBody request with any body
+	PostUploadWithBody(ctx context.Context, params *PostUploadParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUploadPrecheckWithBody request with any body
+	PostUploadPrecheckWithBody(ctx context.Context, params *PostUploadPrecheckParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUploadPrecheck(ctx context.Context, params *PostUploadPrecheckParams, body PostUploadPrecheckJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteUserAvatar request
+	DeleteUserAvatar(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutUserAvatarWithBody request with any body
+	PutUserAvatarWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchUserPasswordWithBody request with any body
+	PatchUserPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchUserPassword(ctx context.Context, body PatchUserPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUserPreferences request
+	GetUserPreferences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchUserPreferencesWithBody request with any body
+	PatchUserPreferencesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchUserPreferences(ctx context.Context, body PatchUserPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchUserProfileWithBody request with any body
+	PatchUserProfileWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchUserProfile(ctx context.Context, body PatchUserProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUserProfileVerifyEmailWithBody request with any body
+	PostUserProfileVerifyEmailWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUserProfileVerifyEmail(ctx context.Context, body PostUserProfileVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUserTransfer request
+	GetUserTransfer(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersIdAvatar request
+	GetUsersIdAvatar(ctx context.Context, id string, params *GetUsersIdAvatarParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetVersion request
+	GetVersion(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAdminAnnouncements(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminAnnouncementsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminAnnouncementsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminAnnouncementsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminAnnouncements(ctx context.Context, body PostAdminAnnouncementsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminAnnouncementsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAdminAnnouncementsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminAnnouncementsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminFiles(ctx context.Context, params *GetAdminFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminFilesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAdminFilesId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminFilesIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminFilesIdReencrypt(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminFilesIdReencryptRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminIpAccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminIpAccessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminIpAccessWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminIpAccessRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminIpAccess(ctx context.Context, body PutAdminIpAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminIpAccessRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminJobs(ctx context.Context, params *GetAdminJobsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminJobsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminJobsIdRetry(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminJobsIdRetryRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminKeysRotateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminKeysRotateRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminKeysRotate(ctx context.Context, body PostAdminKeysRotateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminKeysRotateRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminKeysRotation(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminKeysRotationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminLogs(ctx context.Context, params *GetAdminLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminLogsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminMaintenance(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminMaintenanceRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminMaintenanceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminMaintenanceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminMaintenance(ctx context.Context, body PutAdminMaintenanceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminMaintenanceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminNotifications(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminNotificationsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminNotificationsChannelTest(ctx context.Context, channel string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminNotificationsChannelTestRequest(c.Server, channel)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminQuarantine(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminQuarantineRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminQuarantineIdApprove(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminQuarantineIdApproveRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminQuarantineIdReject(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminQuarantineIdRejectRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminReadOnly(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminReadOnlyRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminReadOnlyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminReadOnlyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminReadOnly(ctx context.Context, body PutAdminReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminReadOnlyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminReplicationRemotes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminReplicationRemotesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminReplicationRemotesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminReplicationRemotesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminReplicationRemotes(ctx context.Context, body PostAdminReplicationRemotesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminReplicationRemotesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAdminReplicationRemotesId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminReplicationRemotesIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminReplicationRemotesIdSyncWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminReplicationRemotesIdSyncRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminReplicationRemotesIdSync(ctx context.Context, id openapi_types.UUID, body PostAdminReplicationRemotesIdSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminReplicationRemotesIdSyncRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminReports(ctx context.Context, params *GetAdminReportsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminReportsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminReportsIdResolveWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminReportsIdResolveRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminReportsIdResolve(ctx context.Context, id openapi_types.UUID, body PostAdminReportsIdResolveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminReportsIdResolveRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminServiceAccounts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminServiceAccountsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminServiceAccountsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminServiceAccountsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminServiceAccounts(ctx context.Context, body PostAdminServiceAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminServiceAccountsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAdminServiceAccountsId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminServiceAccountsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminServiceAccountsIdKeys(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminServiceAccountsIdKeysRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminServiceAccountsIdKeysWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminServiceAccountsIdKeysRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminServiceAccountsIdKeys(ctx context.Context, id string, body PostAdminServiceAccountsIdKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminServiceAccountsIdKeysRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAdminServiceAccountsIdKeysKeyID(ctx context.Context, id string, keyID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminServiceAccountsIdKeysKeyIDRequest(c.Server, id, keyID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminSessionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminSessionsRevokeAll(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminSessionsRevokeAllRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminSettingsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminSettingsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminSettings(ctx context.Context, body PatchAdminSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminSettingsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminSigningKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminSigningKeysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminSigningKeysRotate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminSigningKeysRotateRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminSnapshots(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminSnapshotsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminSnapshots(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminSnapshotsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminSnapshotsIdRestorable(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminSnapshotsIdRestorableRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminSnapshotsIdRestoreWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminSnapshotsIdRestoreRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminSnapshotsIdRestore(ctx context.Context, id string, body PostAdminSnapshotsIdRestoreJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminSnapshotsIdRestoreRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminStatsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminStatsContentTypes(ctx context.Context, params *GetAdminStatsContentTypesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminStatsContentTypesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminStorageAnalyze(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminStorageAnalyzeRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminStorageCleanup(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminStorageCleanupRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminStorageReplica(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminStorageReplicaRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminStorageReplicaReconcileWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminStorageReplicaReconcileRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminStorageReplicaReconcile(ctx context.Context, body PostAdminStorageReplicaReconcileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminStorageReplicaReconcileRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminUsage(ctx context.Context, params *GetAdminUsageParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsageRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminUsageReportsPeriod(ctx context.Context, period GetAdminUsageReportsPeriodParamsPeriod, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsageReportsPeriodRequest(c.Server, period)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsageReportsPeriodSend(ctx context.Context, period PostAdminUsageReportsPeriodSendParamsPeriod, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsageReportsPeriodSendRequest(c.Server, period)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminUsageExport(ctx context.Context, params *GetAdminUsageExportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsageExportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsageSend(ctx context.Context, params *PostAdminUsageSendParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsageSendRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminUsers(ctx context.Context, params *GetAdminUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminUsersPending(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsersPendingRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAdminUsersId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminUsersIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsersIdApprove(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsersIdApproveRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminUsersIdFiles(ctx context.Context, id string, params *GetAdminUsersIdFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsersIdFilesRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsersIdLogout(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsersIdLogoutRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminUsersIdProfileWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminUsersIdProfileRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminUsersIdProfile(ctx context.Context, id string, body PatchAdminUsersIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminUsersIdProfileRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminUsersIdReadOnlyWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminUsersIdReadOnlyRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminUsersIdReadOnly(ctx context.Context, id string, body PutAdminUsersIdReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminUsersIdReadOnlyRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsersIdReject(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsersIdRejectRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsersIdResetPassword(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsersIdResetPasswordRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminUsersIdRoleWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminUsersIdRoleRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminUsersIdRole(ctx context.Context, id string, body PatchAdminUsersIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminUsersIdRoleRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminUsersIdStatusWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminUsersIdStatusRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchAdminUsersIdStatus(ctx context.Context, id string, body PatchAdminUsersIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchAdminUsersIdStatusRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminUsersIdStorage(ctx context.Context, id string, params *GetAdminUsersIdStorageParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsersIdStorageRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsersIdTransferFilesWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsersIdTransferFilesRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminUsersIdTransferFiles(ctx context.Context, id string, body PostAdminUsersIdTransferFilesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminUsersIdTransferFilesRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAnnouncements(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAnnouncementsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAnnouncementsIdDismiss(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAnnouncementsIdDismissRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthLogin(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthLogout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLogoutRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAuthMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuthMeRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRefreshRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRefresh(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRefreshRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRegisterRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRegister(ctx context.Context, body PostAuthRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRegisterRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRevokeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRevokeRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRevoke(ctx context.Context, body PostAuthRevokeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRevokeRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAuthTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuthTokensRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTokensRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthTokens(ctx context.Context, body PostAuthTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTokensRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAuthTokensId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAuthTokensIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDlToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDlTokenRequest(c.Server, token)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDocsOpenapiYaml(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDocsOpenapiYamlRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDownloadId(ctx context.Context, id string, params *GetDownloadIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDownloadIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteFiles(ctx context.Context, params *DeleteFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFilesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFiles(ctx context.Context, params *GetFilesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesBatchUpdateWithBody(ctx context.Context, params *PostFilesBatchUpdateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesBatchUpdateRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesBatchUpdate(ctx context.Context, params *PostFilesBatchUpdateParams, body PostFilesBatchUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesBatchUpdateRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesExpiring(ctx context.Context, params *GetFilesExpiringParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesExpiringRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesExport(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesExportRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesRecent(ctx context.Context, params *GetFilesRecentParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesRecentRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesSearch(ctx context.Context, params *GetFilesSearchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesSearchRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesStarred(ctx context.Context, params *GetFilesStarredParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesStarredRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteFilesTrash(ctx context.Context, params *DeleteFilesTrashParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFilesTrashRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesTrash(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesTrashRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesFileID(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesFileIDRequest(c.Server, fileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) HeadFilesFileID(ctx context.Context, fileID string, params *HeadFilesFileIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHeadFilesFileIDRequest(c.Server, fileID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchFilesFileIDWithBody(ctx context.Context, fileID string, params *PatchFilesFileIDParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchFilesFileIDRequestWithBody(c.Server, fileID, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchFilesFileID(ctx context.Context, fileID string, params *PatchFilesFileIDParams, body PatchFilesFileIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchFilesFileIDRequest(c.Server, fileID, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDCopyWithBody(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDCopyRequestWithBody(c.Server, fileID, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDCopy(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, body PostFilesFileIDCopyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDCopyRequest(c.Server, fileID, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDDownloadUrlWithBody(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDDownloadUrlRequestWithBody(c.Server, fileID, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDDownloadUrl(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, body PostFilesFileIDDownloadUrlJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDDownloadUrlRequest(c.Server, fileID, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchFilesFileIDExpiryWithBody(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchFilesFileIDExpiryRequestWithBody(c.Server, fileID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchFilesFileIDExpiry(ctx context.Context, fileID string, body PatchFilesFileIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchFilesFileIDExpiryRequest(c.Server, fileID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDMoveWithBody(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDMoveRequestWithBody(c.Server, fileID, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDMove(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, body PostFilesFileIDMoveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDMoveRequest(c.Server, fileID, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteFilesFileIDPassword(ctx context.Context, fileID string, params *DeleteFilesFileIDPasswordParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFilesFileIDPasswordRequest(c.Server, fileID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutFilesFileIDPasswordWithBody(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFilesFileIDPasswordRequestWithBody(c.Server, fileID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutFilesFileIDPassword(ctx context.Context, fileID string, body PutFilesFileIDPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutFilesFileIDPasswordRequest(c.Server, fileID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesFileIDProcessing(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesFileIDProcessingRequest(c.Server, fileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDRestore(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDRestoreRequest(c.Server, fileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesFileIDShares(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesFileIDSharesRequest(c.Server, fileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDSharesWithBody(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDSharesRequestWithBody(c.Server, fileID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDShares(ctx context.Context, fileID string, body PostFilesFileIDSharesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDSharesRequest(c.Server, fileID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesFileIDSharesShareIDStats(ctx context.Context, fileID string, shareID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesFileIDSharesShareIDStatsRequest(c.Server, fileID, shareID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteFilesFileIDStar(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFilesFileIDStarRequest(c.Server, fileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDStar(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDStarRequest(c.Server, fileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFilesFileIDThumbnail(ctx context.Context, fileID string, params *GetFilesFileIDThumbnailParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilesFileIDThumbnailRequest(c.Server, fileID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDTicketWithBody(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDTicketRequestWithBody(c.Server, fileID, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostFilesFileIDTicket(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, body PostFilesFileIDTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostFilesFileIDTicketRequest(c.Server, fileID, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPermissions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPermissionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSToken(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSTokenRequest(c.Server, token, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSTokenReportWithBody(ctx context.Context, token string, params *PostSTokenReportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSTokenReportRequestWithBody(c.Server, token, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSTokenReport(ctx context.Context, token string, params *PostSTokenReportParams, body PostSTokenReportJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSTokenReportRequest(c.Server, token, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSharesId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSharesIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchSharesIdWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchSharesIdRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchSharesId(ctx context.Context, id string, body PatchSharesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchSharesIdRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStreamId(ctx context.Context, id string, params *GetStreamIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStreamIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUploadWithBody(ctx context.Context, params *PostUploadParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUploadRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUploadPrecheckWithBody(ctx context.Context, params *PostUploadPrecheckParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUploadPrecheckRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUploadPrecheck(ctx context.Context, params *PostUploadPrecheckParams, body PostUploadPrecheckJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUploadPrecheckRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteUserAvatar(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteUserAvatarRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutUserAvatarWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutUserAvatarRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUserPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUserPasswordRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUserPassword(ctx context.Context, body PatchUserPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUserPasswordRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUserPreferences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUserPreferencesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUserPreferencesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUserPreferencesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUserPreferences(ctx context.Context, body PatchUserPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUserPreferencesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUserProfileWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUserProfileRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUserProfile(ctx context.Context, body PatchUserProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUserProfileRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUserProfileVerifyEmailWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUserProfileVerifyEmailRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUserProfileVerifyEmail(ctx context.Context, body PostUserProfileVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUserProfileVerifyEmailRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUserTransfer(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUserTransferRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersIdAvatar(ctx context.Context, id string, params *GetUsersIdAvatarParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersIdAvatarRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetVersion(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVersionRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAdminAnnouncementsRequest generates requests for GetAdminAnnouncements
+func NewGetAdminAnnouncementsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/announcements")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminAnnouncementsRequest calls the generic PostAdminAnnouncements builder with application/json body
+func NewPostAdminAnnouncementsRequest(server string, body PostAdminAnnouncementsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminAnnouncementsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAdminAnnouncementsRequestWithBody generates requests for PostAdminAnnouncements with any type of body
+func NewPostAdminAnnouncementsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/announcements")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAdminAnnouncementsIdRequest generates requests for DeleteAdminAnnouncementsId
+func NewDeleteAdminAnnouncementsIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/announcements/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminFilesRequest generates requests for GetAdminFiles
+func NewGetAdminFilesRequest(server string, params *GetAdminFilesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/files")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.UserId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, *params.UserId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MinSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "min_size", runtime.ParamLocationQuery, *params.MinSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MaxSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_size", runtime.ParamLocationQuery, *params.MaxSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MimeType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mime_type", runtime.ParamLocationQuery, *params.MimeType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UploadedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "uploaded_after", runtime.ParamLocationQuery, *params.UploadedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UploadedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "uploaded_before", runtime.ParamLocationQuery, *params.UploadedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteAdminFilesIdRequest generates requests for DeleteAdminFilesId
+func NewDeleteAdminFilesIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/files/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminFilesIdReencryptRequest generates requests for PostAdminFilesIdReencrypt
+func NewPostAdminFilesIdReencryptRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/files/%s/reencrypt", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminIpAccessRequest generates requests for GetAdminIpAccess
+func NewGetAdminIpAccessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/ip-access")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutAdminIpAccessRequest calls the generic PutAdminIpAccess builder with application/json body
+func NewPutAdminIpAccessRequest(server string, body PutAdminIpAccessJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminIpAccessRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutAdminIpAccessRequestWithBody generates requests for PutAdminIpAccess with any type of body
+func NewPutAdminIpAccessRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/ip-access")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminJobsRequest generates requests for GetAdminJobs
+func NewGetAdminJobsRequest(server string, params *GetAdminJobsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/jobs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminJobsIdRetryRequest generates requests for PostAdminJobsIdRetry
+func NewPostAdminJobsIdRetryRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/jobs/%s/retry", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminKeysRotateRequest calls the generic PostAdminKeysRotate builder with application/json body
+func NewPostAdminKeysRotateRequest(server string, body PostAdminKeysRotateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminKeysRotateRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAdminKeysRotateRequestWithBody generates requests for PostAdminKeysRotate with any type of body
+func NewPostAdminKeysRotateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys/rotate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminKeysRotationRequest generates requests for GetAdminKeysRotation
+func NewGetAdminKeysRotationRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys/rotation")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminLogsRequest generates requests for GetAdminLogs
+func NewGetAdminLogsRequest(server string, params *GetAdminLogsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/logs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminMaintenanceRequest generates requests for GetAdminMaintenance
+func NewGetAdminMaintenanceRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/maintenance")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutAdminMaintenanceRequest calls the generic PutAdminMaintenance builder with application/json body
+func NewPutAdminMaintenanceRequest(server string, body PutAdminMaintenanceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminMaintenanceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutAdminMaintenanceRequestWithBody generates requests for PutAdminMaintenance with any type of body
+func NewPutAdminMaintenanceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/maintenance")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminNotificationsRequest generates requests for GetAdminNotifications
+func NewGetAdminNotificationsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/notifications")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminNotificationsChannelTestRequest generates requests for PostAdminNotificationsChannelTest
+func NewPostAdminNotificationsChannelTestRequest(server string, channel string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "channel", runtime.ParamLocationPath, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/notifications/%s/test", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminQuarantineRequest generates requests for GetAdminQuarantine
+func NewGetAdminQuarantineRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/quarantine")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminQuarantineIdApproveRequest generates requests for PostAdminQuarantineIdApprove
+func NewPostAdminQuarantineIdApproveRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/quarantine/%s/approve", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminQuarantineIdRejectRequest generates requests for PostAdminQuarantineIdReject
+func NewPostAdminQuarantineIdRejectRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/quarantine/%s/reject", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminReadOnlyRequest generates requests for GetAdminReadOnly
+func NewGetAdminReadOnlyRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/read-only")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutAdminReadOnlyRequest calls the generic PutAdminReadOnly builder with application/json body
+func NewPutAdminReadOnlyRequest(server string, body PutAdminReadOnlyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminReadOnlyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutAdminReadOnlyRequestWithBody generates requests for PutAdminReadOnly with any type of body
+func NewPutAdminReadOnlyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/read-only")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminReplicationRemotesRequest generates requests for GetAdminReplicationRemotes
+func NewGetAdminReplicationRemotesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/replication/remotes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminReplicationRemotesRequest calls the generic PostAdminReplicationRemotes builder with application/json body
+func NewPostAdminReplicationRemotesRequest(server string, body PostAdminReplicationRemotesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminReplicationRemotesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAdminReplicationRemotesRequestWithBody generates requests for PostAdminReplicationRemotes with any type of body
+func NewPostAdminReplicationRemotesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/replication/remotes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAdminReplicationRemotesIdRequest generates requests for DeleteAdminReplicationRemotesId
+func NewDeleteAdminReplicationRemotesIdRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/replication/remotes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminReplicationRemotesIdSyncRequest calls the generic PostAdminReplicationRemotesIdSync builder with application/json body
+func NewPostAdminReplicationRemotesIdSyncRequest(server string, id openapi_types.UUID, body PostAdminReplicationRemotesIdSyncJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminReplicationRemotesIdSyncRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostAdminReplicationRemotesIdSyncRequestWithBody generates requests for PostAdminReplicationRemotesIdSync with any type of body
+func NewPostAdminReplicationRemotesIdSyncRequestWithBody(server string, id openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/replication/remotes/%s/sync", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminReportsRequest generates requests for GetAdminReports
+func NewGetAdminReportsRequest(server string, params *GetAdminReportsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/reports")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminReportsIdResolveRequest calls the generic PostAdminReportsIdResolve builder with application/json body
+func NewPostAdminReportsIdResolveRequest(server string, id openapi_types.UUID, body PostAdminReportsIdResolveJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminReportsIdResolveRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostAdminReportsIdResolveRequestWithBody generates requests for PostAdminReportsIdResolve with any type of body
+func NewPostAdminReportsIdResolveRequestWithBody(server string, id openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/reports/%s/resolve", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminServiceAccountsRequest generates requests for GetAdminServiceAccounts
+func NewGetAdminServiceAccountsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/service-accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminServiceAccountsRequest calls the generic PostAdminServiceAccounts builder with application/json body
+func NewPostAdminServiceAccountsRequest(server string, body PostAdminServiceAccountsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminServiceAccountsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAdminServiceAccountsRequestWithBody generates requests for PostAdminServiceAccounts with any type of body
+func NewPostAdminServiceAccountsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/service-accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAdminServiceAccountsIdRequest generates requests for DeleteAdminServiceAccountsId
+func NewDeleteAdminServiceAccountsIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/service-accounts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminServiceAccountsIdKeysRequest generates requests for GetAdminServiceAccountsIdKeys
+func NewGetAdminServiceAccountsIdKeysRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/service-accounts/%s/keys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminServiceAccountsIdKeysRequest calls the generic PostAdminServiceAccountsIdKeys builder with application/json body
+func NewPostAdminServiceAccountsIdKeysRequest(server string, id string, body PostAdminServiceAccountsIdKeysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminServiceAccountsIdKeysRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostAdminServiceAccountsIdKeysRequestWithBody generates requests for PostAdminServiceAccountsIdKeys with any type of body
+func NewPostAdminServiceAccountsIdKeysRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/service-accounts/%s/keys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAdminServiceAccountsIdKeysKeyIDRequest generates requests for DeleteAdminServiceAccountsIdKeysKeyID
+func NewDeleteAdminServiceAccountsIdKeysKeyIDRequest(server string, id string, keyID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "keyID", runtime.ParamLocationPath, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/service-accounts/%s/keys/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminSessionsRequest generates requests for GetAdminSessions
+func NewGetAdminSessionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminSessionsRevokeAllRequest generates requests for PostAdminSessionsRevokeAll
+func NewPostAdminSessionsRevokeAllRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/sessions/revoke-all")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminSettingsRequest generates requests for GetAdminSettings
+func NewGetAdminSettingsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/settings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchAdminSettingsRequest calls the generic PatchAdminSettings builder with application/json body
+func NewPatchAdminSettingsRequest(server string, body PatchAdminSettingsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchAdminSettingsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPatchAdminSettingsRequestWithBody generates requests for PatchAdminSettings with any type of body
+func NewPatchAdminSettingsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/settings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminSigningKeysRequest generates requests for GetAdminSigningKeys
+func NewGetAdminSigningKeysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/signing-keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminSigningKeysRotateRequest generates requests for PostAdminSigningKeysRotate
+func NewPostAdminSigningKeysRotateRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/signing-keys/rotate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminSnapshotsRequest generates requests for GetAdminSnapshots
+func NewGetAdminSnapshotsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/snapshots")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminSnapshotsRequest generates requests for PostAdminSnapshots
+func NewPostAdminSnapshotsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/snapshots")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminSnapshotsIdRestorableRequest generates requests for GetAdminSnapshotsIdRestorable
+func NewGetAdminSnapshotsIdRestorableRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/snapshots/%s/restorable", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminSnapshotsIdRestoreRequest calls the generic PostAdminSnapshotsIdRestore builder with application/json body
+func NewPostAdminSnapshotsIdRestoreRequest(server string, id string, body PostAdminSnapshotsIdRestoreJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminSnapshotsIdRestoreRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostAdminSnapshotsIdRestoreRequestWithBody generates requests for PostAdminSnapshotsIdRestore with any type of body
+func NewPostAdminSnapshotsIdRestoreRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/snapshots/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminStatsRequest generates requests for GetAdminStats
+func NewGetAdminStatsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/stats")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminStatsContentTypesRequest generates requests for GetAdminStatsContentTypes
+func NewGetAdminStatsContentTypesRequest(server string, params *GetAdminStatsContentTypesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/stats/content-types")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Refresh != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "refresh", runtime.ParamLocationQuery, *params.Refresh); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminStorageAnalyzeRequest generates requests for GetAdminStorageAnalyze
+func NewGetAdminStorageAnalyzeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/storage/analyze")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminStorageCleanupRequest generates requests for PostAdminStorageCleanup
+func NewPostAdminStorageCleanupRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/storage/cleanup")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminStorageReplicaRequest generates requests for GetAdminStorageReplica
+func NewGetAdminStorageReplicaRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/storage/replica")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminStorageReplicaReconcileRequest calls the generic PostAdminStorageReplicaReconcile builder with application/json body
+func NewPostAdminStorageReplicaReconcileRequest(server string, body PostAdminStorageReplicaReconcileJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminStorageReplicaReconcileRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAdminStorageReplicaReconcileRequestWithBody generates requests for PostAdminStorageReplicaReconcile with any type of body
+func NewPostAdminStorageReplicaReconcileRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/storage/replica/reconcile")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminUsageRequest generates requests for GetAdminUsage
+func NewGetAdminUsageRequest(server string, params *GetAdminUsageParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/usage")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Month != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "month", runtime.ParamLocationQuery, *params.Month); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminUsageReportsPeriodRequest generates requests for GetAdminUsageReportsPeriod
+func NewGetAdminUsageReportsPeriodRequest(server string, period GetAdminUsageReportsPeriodParamsPeriod) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "period", runtime.ParamLocationPath, period)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/usage-reports/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminUsageReportsPeriodSendRequest generates requests for PostAdminUsageReportsPeriodSend
+func NewPostAdminUsageReportsPeriodSendRequest(server string, period PostAdminUsageReportsPeriodSendParamsPeriod) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "period", runtime.ParamLocationPath, period)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/usage-reports/%s/send", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminUsageExportRequest generates requests for GetAdminUsageExport
+func NewGetAdminUsageExportRequest(server string, params *GetAdminUsageExportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/usage/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Month != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "month", runtime.ParamLocationQuery, *params.Month); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminUsageSendRequest generates requests for PostAdminUsageSend
+func NewPostAdminUsageSendRequest(server string, params *PostAdminUsageSendParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/usage/send")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Month != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "month", runtime.ParamLocationQuery, *params.Month); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminUsersRequest generates requests for GetAdminUsers
+func NewGetAdminUsersRequest(server string, params *GetAdminUsersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminUsersPendingRequest generates requests for GetAdminUsersPending
+func NewGetAdminUsersPendingRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/pending")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteAdminUsersIdRequest generates requests for DeleteAdminUsersId
+func NewDeleteAdminUsersIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminUsersIdApproveRequest generates requests for PostAdminUsersIdApprove
+func NewPostAdminUsersIdApproveRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/approve", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminUsersIdFilesRequest generates requests for GetAdminUsersIdFiles
+func NewGetAdminUsersIdFilesRequest(server string, id string, params *GetAdminUsersIdFilesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/files", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MinSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "min_size", runtime.ParamLocationQuery, *params.MinSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MaxSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_size", runtime.ParamLocationQuery, *params.MaxSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MimeType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mime_type", runtime.ParamLocationQuery, *params.MimeType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UploadedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "uploaded_after", runtime.ParamLocationQuery, *params.UploadedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UploadedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "uploaded_before", runtime.ParamLocationQuery, *params.UploadedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminUsersIdLogoutRequest generates requests for PostAdminUsersIdLogout
+func NewPostAdminUsersIdLogoutRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/logout", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchAdminUsersIdProfileRequest calls the generic PatchAdminUsersIdProfile builder with application/json body
+func NewPatchAdminUsersIdProfileRequest(server string, id string, body PatchAdminUsersIdProfileJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchAdminUsersIdProfileRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPatchAdminUsersIdProfileRequestWithBody generates requests for PatchAdminUsersIdProfile with any type of body
+func NewPatchAdminUsersIdProfileRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/profile", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPutAdminUsersIdReadOnlyRequest calls the generic PutAdminUsersIdReadOnly builder with application/json body
+func NewPutAdminUsersIdReadOnlyRequest(server string, id string, body PutAdminUsersIdReadOnlyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminUsersIdReadOnlyRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutAdminUsersIdReadOnlyRequestWithBody generates requests for PutAdminUsersIdReadOnly with any type of body
+func NewPutAdminUsersIdReadOnlyRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/read-only", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAdminUsersIdRejectRequest generates requests for PostAdminUsersIdReject
+func NewPostAdminUsersIdRejectRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/reject", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminUsersIdResetPasswordRequest generates requests for PostAdminUsersIdResetPassword
+func NewPostAdminUsersIdResetPasswordRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/reset-password", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchAdminUsersIdRoleRequest calls the generic PatchAdminUsersIdRole builder with application/json body
+func NewPatchAdminUsersIdRoleRequest(server string, id string, body PatchAdminUsersIdRoleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchAdminUsersIdRoleRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPatchAdminUsersIdRoleRequestWithBody generates requests for PatchAdminUsersIdRole with any type of body
+func NewPatchAdminUsersIdRoleRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/role", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPatchAdminUsersIdStatusRequest calls the generic PatchAdminUsersIdStatus builder with application/json body
+func NewPatchAdminUsersIdStatusRequest(server string, id string, body PatchAdminUsersIdStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchAdminUsersIdStatusRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPatchAdminUsersIdStatusRequestWithBody generates requests for PatchAdminUsersIdStatus with any type of body
+func NewPatchAdminUsersIdStatusRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminUsersIdStorageRequest generates requests for GetAdminUsersIdStorage
+func NewGetAdminUsersIdStorageRequest(server string, id string, params *GetAdminUsersIdStorageParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/storage", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Months != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "months", runtime.ParamLocationQuery, *params.Months); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminUsersIdTransferFilesRequest calls the generic PostAdminUsersIdTransferFiles builder with application/json body
+func NewPostAdminUsersIdTransferFilesRequest(server string, id string, body PostAdminUsersIdTransferFilesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminUsersIdTransferFilesRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostAdminUsersIdTransferFilesRequestWithBody generates requests for PostAdminUsersIdTransferFiles with any type of body
+func NewPostAdminUsersIdTransferFilesRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/transfer-files", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAnnouncementsRequest generates requests for GetAnnouncements
+func NewGetAnnouncementsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/announcements")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAnnouncementsIdDismissRequest generates requests for PostAnnouncementsIdDismiss
+func NewPostAnnouncementsIdDismissRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/announcements/%s/dismiss", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthLoginRequest calls the generic PostAuthLogin builder with application/json body
+func NewPostAuthLoginRequest(server string, body PostAuthLoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthLoginRequestWithBody generates requests for PostAuthLogin with any type of body
+func NewPostAuthLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthLogoutRequest generates requests for PostAuthLogout
+func NewPostAuthLogoutRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/logout")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAuthMeRequest generates requests for GetAuthMe
+func NewGetAuthMeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthRefreshRequest calls the generic PostAuthRefresh builder with application/json body
+func NewPostAuthRefreshRequest(server string, body PostAuthRefreshJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthRefreshRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthRefreshRequestWithBody generates requests for PostAuthRefresh with any type of body
+func NewPostAuthRefreshRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/refresh")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthRegisterRequest calls the generic PostAuthRegister builder with application/json body
+func NewPostAuthRegisterRequest(server string, body PostAuthRegisterJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthRegisterRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthRegisterRequestWithBody generates requests for PostAuthRegister with any type of body
+func NewPostAuthRegisterRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/register")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthRevokeRequest calls the generic PostAuthRevoke builder with application/json body
+func NewPostAuthRevokeRequest(server string, body PostAuthRevokeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthRevokeRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthRevokeRequestWithBody generates requests for PostAuthRevoke with any type of body
+func NewPostAuthRevokeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/revoke")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAuthTokensRequest generates requests for GetAuthTokens
+func NewGetAuthTokensRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthTokensRequest calls the generic PostAuthTokens builder with application/json body
+func NewPostAuthTokensRequest(server string, body PostAuthTokensJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthTokensRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthTokensRequestWithBody generates requests for PostAuthTokens with any type of body
+func NewPostAuthTokensRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAuthTokensIdRequest generates requests for DeleteAuthTokensId
+func NewDeleteAuthTokensIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDlTokenRequest generates requests for GetDlToken
+func NewGetDlTokenRequest(server string, token string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/dl/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDocsOpenapiYamlRequest generates requests for GetDocsOpenapiYaml
+func NewGetDocsOpenapiYamlRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/docs/openapi.yaml")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDownloadIdRequest generates requests for GetDownloadId
+func NewGetDownloadIdRequest(server string, id string, params *GetDownloadIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/download/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Ticket != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ticket", runtime.ParamLocationQuery, *params.Ticket); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFilePassword != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-File-Password", runtime.ParamLocationHeader, *params.XFilePassword)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-File-Password", headerParam0)
+		}
+
+		if params.IfNoneMatch != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam1)
+		}
+
+		if params.IfModifiedSince != nil {
+			var headerParam2 string
+
+			headerParam2, err = runtime.StyleParamWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, *params.IfModifiedSince)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Modified-Since", headerParam2)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteFilesRequest generates requests for DeleteFiles
+func NewDeleteFilesRequest(server string, params *DeleteFilesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "id", runtime.ParamLocationQuery, params.Id); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Permanent != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "permanent", runtime.ParamLocationQuery, *params.Permanent); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetFilesRequest generates requests for GetFiles
+func NewGetFilesRequest(server string, params *GetFilesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostFilesBatchUpdateRequest calls the generic PostFilesBatchUpdate builder with application/json body
+func NewPostFilesBatchUpdateRequest(server string, params *PostFilesBatchUpdateParams, body PostFilesBatchUpdateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostFilesBatchUpdateRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostFilesBatchUpdateRequestWithBody generates requests for PostFilesBatchUpdate with any type of body
+func NewPostFilesBatchUpdateRequestWithBody(server string, params *PostFilesBatchUpdateParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/batch/update")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetFilesExpiringRequest generates requests for GetFilesExpiring
+func NewGetFilesExpiringRequest(server string, params *GetFilesExpiringParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/expiring")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Within != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "within", runtime.ParamLocationQuery, *params.Within); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFilesExportRequest generates requests for GetFilesExport
+func NewGetFilesExportRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFilesRecentRequest generates requests for GetFilesRecent
+func NewGetFilesRecentRequest(server string, params *GetFilesRecentParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/recent")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetFilesSearchRequest generates requests for GetFilesSearch
+func NewGetFilesSearchRequest(server string, params *GetFilesSearchParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/search")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, params.Q); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFilesStarredRequest generates requests for GetFilesStarred
+func NewGetFilesStarredRequest(server string, params *GetFilesStarredParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/starred")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteFilesTrashRequest generates requests for DeleteFilesTrash
+func NewDeleteFilesTrashRequest(server string, params *DeleteFilesTrashParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/trash")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetFilesTrashRequest generates requests for GetFilesTrash
+func NewGetFilesTrashRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/trash")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFilesFileIDRequest generates requests for GetFilesFileID
+func NewGetFilesFileIDRequest(server string, fileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewHeadFilesFileIDRequest generates requests for HeadFilesFileID
+func NewHeadFilesFileIDRequest(server string, fileID string, params *HeadFilesFileIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("HEAD", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+		if params.IfModifiedSince != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, *params.IfModifiedSince)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Modified-Since", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchFilesFileIDRequest calls the generic PatchFilesFileID builder with application/json body
+func NewPatchFilesFileIDRequest(server string, fileID string, params *PatchFilesFileIDParams, body PatchFilesFileIDJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchFilesFileIDRequestWithBody(server, fileID, params, "application/json", bodyReader)
+}
+
+// NewPatchFilesFileIDRequestWithBody generates requests for PatchFilesFileID with any type of body
+func NewPatchFilesFileIDRequestWithBody(server string, fileID string, params *PatchFilesFileIDParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+		if params.IfUnmodifiedSince != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "If-Unmodified-Since", runtime.ParamLocationHeader, *params.IfUnmodifiedSince)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Unmodified-Since", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostFilesFileIDCopyRequest calls the generic PostFilesFileIDCopy builder with application/json body
+func NewPostFilesFileIDCopyRequest(server string, fileID string, params *PostFilesFileIDCopyParams, body PostFilesFileIDCopyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostFilesFileIDCopyRequestWithBody(server, fileID, params, "application/json", bodyReader)
+}
+
+// NewPostFilesFileIDCopyRequestWithBody generates requests for PostFilesFileIDCopy with any type of body
+func NewPostFilesFileIDCopyRequestWithBody(server string, fileID string, params *PostFilesFileIDCopyParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/copy", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostFilesFileIDDownloadUrlRequest calls the generic PostFilesFileIDDownloadUrl builder with application/json body
+func NewPostFilesFileIDDownloadUrlRequest(server string, fileID string, params *PostFilesFileIDDownloadUrlParams, body PostFilesFileIDDownloadUrlJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostFilesFileIDDownloadUrlRequestWithBody(server, fileID, params, "application/json", bodyReader)
+}
+
+// NewPostFilesFileIDDownloadUrlRequestWithBody generates requests for PostFilesFileIDDownloadUrl with any type of body
+func NewPostFilesFileIDDownloadUrlRequestWithBody(server string, fileID string, params *PostFilesFileIDDownloadUrlParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/download-url", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XFilePassword != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-File-Password", runtime.ParamLocationHeader, *params.XFilePassword)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-File-Password", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchFilesFileIDExpiryRequest calls the generic PatchFilesFileIDExpiry builder with application/json body
+func NewPatchFilesFileIDExpiryRequest(server string, fileID string, body PatchFilesFileIDExpiryJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchFilesFileIDExpiryRequestWithBody(server, fileID, "application/json", bodyReader)
+}
+
+// NewPatchFilesFileIDExpiryRequestWithBody generates requests for PatchFilesFileIDExpiry with any type of body
+func NewPatchFilesFileIDExpiryRequestWithBody(server string, fileID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/expiry", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostFilesFileIDMoveRequest calls the generic PostFilesFileIDMove builder with application/json body
+func NewPostFilesFileIDMoveRequest(server string, fileID string, params *PostFilesFileIDMoveParams, body PostFilesFileIDMoveJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostFilesFileIDMoveRequestWithBody(server, fileID, params, "application/json", bodyReader)
+}
+
+// NewPostFilesFileIDMoveRequestWithBody generates requests for PostFilesFileIDMove with any type of body
+func NewPostFilesFileIDMoveRequestWithBody(server string, fileID string, params *PostFilesFileIDMoveParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/move", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteFilesFileIDPasswordRequest generates requests for DeleteFilesFileIDPassword
+func NewDeleteFilesFileIDPasswordRequest(server string, fileID string, params *DeleteFilesFileIDPasswordParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/password", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFilePassword != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-File-Password", runtime.ParamLocationHeader, *params.XFilePassword)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-File-Password", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPutFilesFileIDPasswordRequest calls the generic PutFilesFileIDPassword builder with application/json body
+func NewPutFilesFileIDPasswordRequest(server string, fileID string, body PutFilesFileIDPasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutFilesFileIDPasswordRequestWithBody(server, fileID, "application/json", bodyReader)
+}
+
+// NewPutFilesFileIDPasswordRequestWithBody generates requests for PutFilesFileIDPassword with any type of body
+func NewPutFilesFileIDPasswordRequestWithBody(server string, fileID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/password", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetFilesFileIDProcessingRequest generates requests for GetFilesFileIDProcessing
+func NewGetFilesFileIDProcessingRequest(server string, fileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/processing", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostFilesFileIDRestoreRequest generates requests for PostFilesFileIDRestore
+func NewPostFilesFileIDRestoreRequest(server string, fileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFilesFileIDSharesRequest generates requests for GetFilesFileIDShares
+func NewGetFilesFileIDSharesRequest(server string, fileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/shares", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostFilesFileIDSharesRequest calls the generic PostFilesFileIDShares builder with application/json body
+func NewPostFilesFileIDSharesRequest(server string, fileID string, body PostFilesFileIDSharesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostFilesFileIDSharesRequestWithBody(server, fileID, "application/json", bodyReader)
+}
+
+// NewPostFilesFileIDSharesRequestWithBody generates requests for PostFilesFileIDShares with any type of body
+func NewPostFilesFileIDSharesRequestWithBody(server string, fileID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/shares", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetFilesFileIDSharesShareIDStatsRequest generates requests for GetFilesFileIDSharesShareIDStats
+func NewGetFilesFileIDSharesShareIDStatsRequest(server string, fileID string, shareID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "shareID", runtime.ParamLocationPath, shareID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/shares/%s/stats", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteFilesFileIDStarRequest generates requests for DeleteFilesFileIDStar
+func NewDeleteFilesFileIDStarRequest(server string, fileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/star", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostFilesFileIDStarRequest generates requests for PostFilesFileIDStar
+func NewPostFilesFileIDStarRequest(server string, fileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/star", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFilesFileIDThumbnailRequest generates requests for GetFilesFileIDThumbnail
+func NewGetFilesFileIDThumbnailRequest(server string, fileID string, params *GetFilesFileIDThumbnailParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/thumbnail", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Ticket != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ticket", runtime.ParamLocationQuery, *params.Ticket); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFilePassword != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-File-Password", runtime.ParamLocationHeader, *params.XFilePassword)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-File-Password", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostFilesFileIDTicketRequest calls the generic PostFilesFileIDTicket builder with application/json body
+func NewPostFilesFileIDTicketRequest(server string, fileID string, params *PostFilesFileIDTicketParams, body PostFilesFileIDTicketJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostFilesFileIDTicketRequestWithBody(server, fileID, params, "application/json", bodyReader)
+}
+
+// NewPostFilesFileIDTicketRequestWithBody generates requests for PostFilesFileIDTicket with any type of body
+func NewPostFilesFileIDTicketRequestWithBody(server string, fileID string, params *PostFilesFileIDTicketParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fileID", runtime.ParamLocationPath, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s/ticket", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XFilePassword != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-File-Password", runtime.ParamLocationHeader, *params.XFilePassword)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-File-Password", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetPermissionsRequest generates requests for GetPermissions
+func NewGetPermissionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/permissions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSTokenRequest generates requests for GetSToken
+func NewGetSTokenRequest(server string, token string, params *GetSTokenParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/s/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFilePassword != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-File-Password", runtime.ParamLocationHeader, *params.XFilePassword)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-File-Password", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostSTokenReportRequest calls the generic PostSTokenReport builder with application/json body
+func NewPostSTokenReportRequest(server string, token string, params *PostSTokenReportParams, body PostSTokenReportJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSTokenReportRequestWithBody(server, token, params, "application/json", bodyReader)
+}
+
+// NewPostSTokenReportRequestWithBody generates requests for PostSTokenReport with any type of body
+func NewPostSTokenReportRequestWithBody(server string, token string, params *PostSTokenReportParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/s/%s/report", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XCaptchaToken != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Captcha-Token", runtime.ParamLocationHeader, *params.XCaptchaToken)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Captcha-Token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteSharesIdRequest generates requests for DeleteSharesId
+func NewDeleteSharesIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/shares/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchSharesIdRequest calls the generic PatchSharesId builder with application/json body
+func NewPatchSharesIdRequest(server string, id string, body PatchSharesIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchSharesIdRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPatchSharesIdRequestWithBody generates requests for PatchSharesId with any type of body
+func NewPatchSharesIdRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/shares/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetStreamIdRequest generates requests for GetStreamId
+func NewGetStreamIdRequest(server string, id string, params *GetStreamIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stream/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Ticket != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ticket", runtime.ParamLocationQuery, *params.Ticket); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFilePassword != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-File-Password", runtime.ParamLocationHeader, *params.XFilePassword)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-File-Password", headerParam0)
+		}
+
+		if params.Range != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "Range", runtime.ParamLocationHeader, *params.Range)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Range", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostUploadRequestWithBody generates requests for PostUpload with any type of body
+func NewPostUploadRequestWithBody(server string, params *PostUploadParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/upload")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostUploadPrecheckRequest calls the generic PostUploadPrecheck builder with application/json body
+func NewPostUploadPrecheckRequest(server string, params *PostUploadPrecheckParams, body PostUploadPrecheckJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUploadPrecheckRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostUploadPrecheckRequestWithBody generates requests for PostUploadPrecheck with any type of body
+func NewPostUploadPrecheckRequestWithBody(server string, params *PostUploadPrecheckParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/upload/precheck")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteUserAvatarRequest generates requests for DeleteUserAvatar
+func NewDeleteUserAvatarRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/avatar")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutUserAvatarRequestWithBody generates requests for PutUserAvatar with any type of body
+func NewPutUserAvatarRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/avatar")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPatchUserPasswordRequest calls the generic PatchUserPassword builder with application/json body
+func NewPatchUserPasswordRequest(server string, body PatchUserPasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchUserPasswordRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPatchUserPasswordRequestWithBody generates requests for PatchUserPassword with any type of body
+func NewPatchUserPasswordRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/password")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetUserPreferencesRequest generates requests for GetUserPreferences
+func NewGetUserPreferencesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/preferences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchUserPreferencesRequest calls the generic PatchUserPreferences builder with application/json body
+func NewPatchUserPreferencesRequest(server string, body PatchUserPreferencesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchUserPreferencesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPatchUserPreferencesRequestWithBody generates requests for PatchUserPreferences with any type of body
+func NewPatchUserPreferencesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/preferences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPatchUserProfileRequest calls the generic PatchUserProfile builder with application/json body
+func NewPatchUserProfileRequest(server string, body PatchUserProfileJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchUserProfileRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPatchUserProfileRequestWithBody generates requests for PatchUserProfile with any type of body
+func NewPatchUserProfileRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/profile")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostUserProfileVerifyEmailRequest calls the generic PostUserProfileVerifyEmail builder with application/json body
+func NewPostUserProfileVerifyEmailRequest(server string, body PostUserProfileVerifyEmailJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUserProfileVerifyEmailRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostUserProfileVerifyEmailRequestWithBody generates requests for PostUserProfileVerifyEmail with any type of body
+func NewPostUserProfileVerifyEmailRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/profile/verify-email")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetUserTransferRequest generates requests for GetUserTransfer
+func NewGetUserTransferRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/transfer")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUsersIdAvatarRequest generates requests for GetUsersIdAvatar
+func NewGetUsersIdAvatarRequest(server string, id string, params *GetUsersIdAvatarParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s/avatar", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.V != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "v", runtime.ParamLocationQuery, *params.V); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetVersionRequest generates requests for GetVersion
+func NewGetVersionRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/version")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAdminAnnouncementsWithResponse request
+	GetAdminAnnouncementsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminAnnouncementsResponse, error)
+
+	// PostAdminAnnouncementsWithBodyWithResponse request with any body
+	PostAdminAnnouncementsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminAnnouncementsResponse, error)
+
+	PostAdminAnnouncementsWithResponse(ctx context.Context, body PostAdminAnnouncementsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminAnnouncementsResponse, error)
+
+	// DeleteAdminAnnouncementsIdWithResponse request
+	DeleteAdminAnnouncementsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminAnnouncementsIdResponse, error)
+
+	// GetAdminFilesWithResponse request
+	GetAdminFilesWithResponse(ctx context.Context, params *GetAdminFilesParams, reqEditors ...RequestEditorFn) (*GetAdminFilesResponse, error)
+
+	// DeleteAdminFilesIdWithResponse request
+	DeleteAdminFilesIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminFilesIdResponse, error)
+
+	// PostAdminFilesIdReencryptWithResponse request
+	PostAdminFilesIdReencryptWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminFilesIdReencryptResponse, error)
+
+	// GetAdminIpAccessWithResponse request
+	GetAdminIpAccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminIpAccessResponse, error)
+
+	// PutAdminIpAccessWithBodyWithResponse request with any body
+	PutAdminIpAccessWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminIpAccessResponse, error)
+
+	PutAdminIpAccessWithResponse(ctx context.Context, body PutAdminIpAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminIpAccessResponse, error)
+
+	// GetAdminJobsWithResponse request
+	GetAdminJobsWithResponse(ctx context.Context, params *GetAdminJobsParams, reqEditors ...RequestEditorFn) (*GetAdminJobsResponse, error)
+
+	// PostAdminJobsIdRetryWithResponse request
+	PostAdminJobsIdRetryWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminJobsIdRetryResponse, error)
+
+	// PostAdminKeysRotateWithBodyWithResponse request with any body
+	PostAdminKeysRotateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminKeysRotateResponse, error)
+
+	PostAdminKeysRotateWithResponse(ctx context.Context, body PostAdminKeysRotateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminKeysRotateResponse, error)
+
+	// GetAdminKeysRotationWithResponse request
+	GetAdminKeysRotationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminKeysRotationResponse, error)
+
+	// GetAdminLogsWithResponse request
+	GetAdminLogsWithResponse(ctx context.Context, params *GetAdminLogsParams, reqEditors ...RequestEditorFn) (*GetAdminLogsResponse, error)
+
+	// GetAdminMaintenanceWithResponse request
+	GetAdminMaintenanceWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminMaintenanceResponse, error)
+
+	// PutAdminMaintenanceWithBodyWithResponse request with any body
+	PutAdminMaintenanceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminMaintenanceResponse, error)
+
+	PutAdminMaintenanceWithResponse(ctx context.Context, body PutAdminMaintenanceJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminMaintenanceResponse, error)
+
+	// GetAdminNotificationsWithResponse request
+	GetAdminNotificationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminNotificationsResponse, error)
+
+	// PostAdminNotificationsChannelTestWithResponse request
+	PostAdminNotificationsChannelTestWithResponse(ctx context.Context, channel string, reqEditors ...RequestEditorFn) (*PostAdminNotificationsChannelTestResponse, error)
+
+	// GetAdminQuarantineWithResponse request
+	GetAdminQuarantineWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminQuarantineResponse, error)
+
+	// PostAdminQuarantineIdApproveWithResponse request
+	PostAdminQuarantineIdApproveWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminQuarantineIdApproveResponse, error)
+
+	// PostAdminQuarantineIdRejectWithResponse request
+	PostAdminQuarantineIdRejectWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminQuarantineIdRejectResponse, error)
+
+	// GetAdminReadOnlyWithResponse request
+	GetAdminReadOnlyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminReadOnlyResponse, error)
+
+	// PutAdminReadOnlyWithBodyWithResponse request with any body
+	PutAdminReadOnlyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminReadOnlyResponse, error)
+
+	PutAdminReadOnlyWithResponse(ctx context.Context, body PutAdminReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminReadOnlyResponse, error)
+
+	// GetAdminReplicationRemotesWithResponse request
+	GetAdminReplicationRemotesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminReplicationRemotesResponse, error)
+
+	// PostAdminReplicationRemotesWithBodyWithResponse request with any body
+	PostAdminReplicationRemotesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesResponse, error)
+
+	PostAdminReplicationRemotesWithResponse(ctx context.Context, body PostAdminReplicationRemotesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesResponse, error)
+
+	// DeleteAdminReplicationRemotesIdWithResponse request
+	DeleteAdminReplicationRemotesIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteAdminReplicationRemotesIdResponse, error)
+
+	// PostAdminReplicationRemotesIdSyncWithBodyWithResponse request with any body
+	PostAdminReplicationRemotesIdSyncWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesIdSyncResponse, error)
+
+	PostAdminReplicationRemotesIdSyncWithResponse(ctx context.Context, id openapi_types.UUID, body PostAdminReplicationRemotesIdSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesIdSyncResponse, error)
+
+	// GetAdminReportsWithResponse request
+	GetAdminReportsWithResponse(ctx context.Context, params *GetAdminReportsParams, reqEditors ...RequestEditorFn) (*GetAdminReportsResponse, error)
+
+	// PostAdminReportsIdResolveWithBodyWithResponse request with any body
+	PostAdminReportsIdResolveWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminReportsIdResolveResponse, error)
+
+	PostAdminReportsIdResolveWithResponse(ctx context.Context, id openapi_types.UUID, body PostAdminReportsIdResolveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminReportsIdResolveResponse, error)
+
+	// GetAdminServiceAccountsWithResponse request
+	GetAdminServiceAccountsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminServiceAccountsResponse, error)
+
+	// PostAdminServiceAccountsWithBodyWithResponse request with any body
+	PostAdminServiceAccountsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsResponse, error)
+
+	PostAdminServiceAccountsWithResponse(ctx context.Context, body PostAdminServiceAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsResponse, error)
+
+	// DeleteAdminServiceAccountsIdWithResponse request
+	DeleteAdminServiceAccountsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminServiceAccountsIdResponse, error)
+
+	// GetAdminServiceAccountsIdKeysWithResponse request
+	GetAdminServiceAccountsIdKeysWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetAdminServiceAccountsIdKeysResponse, error)
+
+	// PostAdminServiceAccountsIdKeysWithBodyWithResponse request with any body
+	PostAdminServiceAccountsIdKeysWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsIdKeysResponse, error)
+
+	PostAdminServiceAccountsIdKeysWithResponse(ctx context.Context, id string, body PostAdminServiceAccountsIdKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsIdKeysResponse, error)
+
+	// DeleteAdminServiceAccountsIdKeysKeyIDWithResponse request
+	DeleteAdminServiceAccountsIdKeysKeyIDWithResponse(ctx context.Context, id string, keyID string, reqEditors ...RequestEditorFn) (*DeleteAdminServiceAccountsIdKeysKeyIDResponse, error)
+
+	// GetAdminSessionsWithResponse request
+	GetAdminSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSessionsResponse, error)
+
+	// PostAdminSessionsRevokeAllWithResponse request
+	PostAdminSessionsRevokeAllWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminSessionsRevokeAllResponse, error)
+
+	// GetAdminSettingsWithResponse request
+	GetAdminSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSettingsResponse, error)
+
+	// PatchAdminSettingsWithBodyWithResponse request with any body
+	PatchAdminSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminSettingsResponse, error)
+
+	PatchAdminSettingsWithResponse(ctx context.Context, body PatchAdminSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminSettingsResponse, error)
+
+	// GetAdminSigningKeysWithResponse request
+	GetAdminSigningKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSigningKeysResponse, error)
+
+	// PostAdminSigningKeysRotateWithResponse request
+	PostAdminSigningKeysRotateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminSigningKeysRotateResponse, error)
+
+	// GetAdminSnapshotsWithResponse request
+	GetAdminSnapshotsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSnapshotsResponse, error)
+
+	// PostAdminSnapshotsWithResponse request
+	PostAdminSnapshotsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminSnapshotsResponse, error)
+
+	// GetAdminSnapshotsIdRestorableWithResponse request
+	GetAdminSnapshotsIdRestorableWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetAdminSnapshotsIdRestorableResponse, error)
+
+	// PostAdminSnapshotsIdRestoreWithBodyWithResponse request with any body
+	PostAdminSnapshotsIdRestoreWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminSnapshotsIdRestoreResponse, error)
+
+	PostAdminSnapshotsIdRestoreWithResponse(ctx context.Context, id string, body PostAdminSnapshotsIdRestoreJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminSnapshotsIdRestoreResponse, error)
+
+	// GetAdminStatsWithResponse request
+	GetAdminStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStatsResponse, error)
+
+	// GetAdminStatsContentTypesWithResponse request
+	GetAdminStatsContentTypesWithResponse(ctx context.Context, params *GetAdminStatsContentTypesParams, reqEditors ...RequestEditorFn) (*GetAdminStatsContentTypesResponse, error)
+
+	// GetAdminStorageAnalyzeWithResponse request
+	GetAdminStorageAnalyzeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStorageAnalyzeResponse, error)
+
+	// PostAdminStorageCleanupWithResponse request
+	PostAdminStorageCleanupWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminStorageCleanupResponse, error)
+
+	// GetAdminStorageReplicaWithResponse request
+	GetAdminStorageReplicaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStorageReplicaResponse, error)
+
+	// PostAdminStorageReplicaReconcileWithBodyWithResponse request with any body
+	PostAdminStorageReplicaReconcileWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminStorageReplicaReconcileResponse, error)
+
+	PostAdminStorageReplicaReconcileWithResponse(ctx context.Context, body PostAdminStorageReplicaReconcileJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminStorageReplicaReconcileResponse, error)
+
+	// GetAdminUsageWithResponse request
+	GetAdminUsageWithResponse(ctx context.Context, params *GetAdminUsageParams, reqEditors ...RequestEditorFn) (*GetAdminUsageResponse, error)
+
+	// GetAdminUsageReportsPeriodWithResponse request
+	GetAdminUsageReportsPeriodWithResponse(ctx context.Context, period GetAdminUsageReportsPeriodParamsPeriod, reqEditors ...RequestEditorFn) (*GetAdminUsageReportsPeriodResponse, error)
+
+	// PostAdminUsageReportsPeriodSendWithResponse request
+	PostAdminUsageReportsPeriodSendWithResponse(ctx context.Context, period PostAdminUsageReportsPeriodSendParamsPeriod, reqEditors ...RequestEditorFn) (*PostAdminUsageReportsPeriodSendResponse, error)
+
+	// GetAdminUsageExportWithResponse request
+	GetAdminUsageExportWithResponse(ctx context.Context, params *GetAdminUsageExportParams, reqEditors ...RequestEditorFn) (*GetAdminUsageExportResponse, error)
+
+	// PostAdminUsageSendWithResponse request
+	PostAdminUsageSendWithResponse(ctx context.Context, params *PostAdminUsageSendParams, reqEditors ...RequestEditorFn) (*PostAdminUsageSendResponse, error)
+
+	// GetAdminUsersWithResponse request
+	GetAdminUsersWithResponse(ctx context.Context, params *GetAdminUsersParams, reqEditors ...RequestEditorFn) (*GetAdminUsersResponse, error)
+
+	// GetAdminUsersPendingWithResponse request
+	GetAdminUsersPendingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminUsersPendingResponse, error)
+
+	// DeleteAdminUsersIdWithResponse request
+	DeleteAdminUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminUsersIdResponse, error)
+
+	// PostAdminUsersIdApproveWithResponse request
+	PostAdminUsersIdApproveWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdApproveResponse, error)
+
+	// GetAdminUsersIdFilesWithResponse request
+	GetAdminUsersIdFilesWithResponse(ctx context.Context, id string, params *GetAdminUsersIdFilesParams, reqEditors ...RequestEditorFn) (*GetAdminUsersIdFilesResponse, error)
+
+	// PostAdminUsersIdLogoutWithResponse request
+	PostAdminUsersIdLogoutWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdLogoutResponse, error)
+
+	// PatchAdminUsersIdProfileWithBodyWithResponse request with any body
+	PatchAdminUsersIdProfileWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdProfileResponse, error)
+
+	PatchAdminUsersIdProfileWithResponse(ctx context.Context, id string, body PatchAdminUsersIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdProfileResponse, error)
+
+	// PutAdminUsersIdReadOnlyWithBodyWithResponse request with any body
+	PutAdminUsersIdReadOnlyWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminUsersIdReadOnlyResponse, error)
+
+	PutAdminUsersIdReadOnlyWithResponse(ctx context.Context, id string, body PutAdminUsersIdReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminUsersIdReadOnlyResponse, error)
+
+	// PostAdminUsersIdRejectWithResponse request
+	PostAdminUsersIdRejectWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdRejectResponse, error)
+
+	// PostAdminUsersIdResetPasswordWithResponse request
+	PostAdminUsersIdResetPasswordWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdResetPasswordResponse, error)
+
+	// PatchAdminUsersIdRoleWithBodyWithResponse request with any body
+	PatchAdminUsersIdRoleWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdRoleResponse, error)
+
+	PatchAdminUsersIdRoleWithResponse(ctx context.Context, id string, body PatchAdminUsersIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdRoleResponse, error)
+
+	// PatchAdminUsersIdStatusWithBodyWithResponse request with any body
+	PatchAdminUsersIdStatusWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdStatusResponse, error)
+
+	PatchAdminUsersIdStatusWithResponse(ctx context.Context, id string, body PatchAdminUsersIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdStatusResponse, error)
+
+	// GetAdminUsersIdStorageWithResponse request
+	GetAdminUsersIdStorageWithResponse(ctx context.Context, id string, params *GetAdminUsersIdStorageParams, reqEditors ...RequestEditorFn) (*GetAdminUsersIdStorageResponse, error)
+
+	// PostAdminUsersIdTransferFilesWithBodyWithResponse request with any body
+	PostAdminUsersIdTransferFilesWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminUsersIdTransferFilesResponse, error)
+
+	PostAdminUsersIdTransferFilesWithResponse(ctx context.Context, id string, body PostAdminUsersIdTransferFilesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminUsersIdTransferFilesResponse, error)
+
+	// GetAnnouncementsWithResponse request
+	GetAnnouncementsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAnnouncementsResponse, error)
+
+	// PostAnnouncementsIdDismissWithResponse request
+	PostAnnouncementsIdDismissWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAnnouncementsIdDismissResponse, error)
+
+	// PostAuthLoginWithBodyWithResponse request with any body
+	PostAuthLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error)
+
+	PostAuthLoginWithResponse(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error)
+
+	// PostAuthLogoutWithResponse request
+	PostAuthLogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error)
+
+	// GetAuthMeWithResponse request
+	GetAuthMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthMeResponse, error)
+
+	// PostAuthRefreshWithBodyWithResponse request with any body
+	PostAuthRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error)
+
+	PostAuthRefreshWithResponse(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error)
+
+	// PostAuthRegisterWithBodyWithResponse request with any body
+	PostAuthRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRegisterResponse, error)
+
+	PostAuthRegisterWithResponse(ctx context.Context, body PostAuthRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRegisterResponse, error)
+
+	// PostAuthRevokeWithBodyWithResponse request with any body
+	PostAuthRevokeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRevokeResponse, error)
+
+	PostAuthRevokeWithResponse(ctx context.Context, body PostAuthRevokeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRevokeResponse, error)
+
+	// GetAuthTokensWithResponse request
+	GetAuthTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthTokensResponse, error)
+
+	// PostAuthTokensWithBodyWithResponse request with any body
+	PostAuthTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTokensResponse, error)
+
+	PostAuthTokensWithResponse(ctx context.Context, body PostAuthTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTokensResponse, error)
+
+	// DeleteAuthTokensIdWithResponse request
+	DeleteAuthTokensIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAuthTokensIdResponse, error)
+
+	// GetDlTokenWithResponse request
+	GetDlTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetDlTokenResponse, error)
+
+	// GetDocsOpenapiYamlWithResponse request
+	GetDocsOpenapiYamlWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDocsOpenapiYamlResponse, error)
+
+	// GetDownloadIdWithResponse request
+	GetDownloadIdWithResponse(ctx context.Context, id string, params *GetDownloadIdParams, reqEditors ...RequestEditorFn) (*GetDownloadIdResponse, error)
+
+	// DeleteFilesWithResponse request
+	DeleteFilesWithResponse(ctx context.Context, params *DeleteFilesParams, reqEditors ...RequestEditorFn) (*DeleteFilesResponse, error)
+
+	// GetFilesWithResponse request
+	GetFilesWithResponse(ctx context.Context, params *GetFilesParams, reqEditors ...RequestEditorFn) (*GetFilesResponse, error)
+
+	// PostFilesBatchUpdateWithBodyWithResponse request with any body
+	PostFilesBatchUpdateWithBodyWithResponse(ctx context.Context, params *PostFilesBatchUpdateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesBatchUpdateResponse, error)
+
+	PostFilesBatchUpdateWithResponse(ctx context.Context, params *PostFilesBatchUpdateParams, body PostFilesBatchUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesBatchUpdateResponse, error)
+
+	// GetFilesExpiringWithResponse request
+	GetFilesExpiringWithResponse(ctx context.Context, params *GetFilesExpiringParams, reqEditors ...RequestEditorFn) (*GetFilesExpiringResponse, error)
+
+	// GetFilesExportWithResponse request
+	GetFilesExportWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFilesExportResponse, error)
+
+	// GetFilesRecentWithResponse request
+	GetFilesRecentWithResponse(ctx context.Context, params *GetFilesRecentParams, reqEditors ...RequestEditorFn) (*GetFilesRecentResponse, error)
+
+	// GetFilesSearchWithResponse request
+	GetFilesSearchWithResponse(ctx context.Context, params *GetFilesSearchParams, reqEditors ...RequestEditorFn) (*GetFilesSearchResponse, error)
+
+	// GetFilesStarredWithResponse request
+	GetFilesStarredWithResponse(ctx context.Context, params *GetFilesStarredParams, reqEditors ...RequestEditorFn) (*GetFilesStarredResponse, error)
+
+	// DeleteFilesTrashWithResponse request
+	DeleteFilesTrashWithResponse(ctx context.Context, params *DeleteFilesTrashParams, reqEditors ...RequestEditorFn) (*DeleteFilesTrashResponse, error)
+
+	// GetFilesTrashWithResponse request
+	GetFilesTrashWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFilesTrashResponse, error)
+
+	// GetFilesFileIDWithResponse request
+	GetFilesFileIDWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDResponse, error)
+
+	// HeadFilesFileIDWithResponse request
+	HeadFilesFileIDWithResponse(ctx context.Context, fileID string, params *HeadFilesFileIDParams, reqEditors ...RequestEditorFn) (*HeadFilesFileIDResponse, error)
+
+	// PatchFilesFileIDWithBodyWithResponse request with any body
+	PatchFilesFileIDWithBodyWithResponse(ctx context.Context, fileID string, params *PatchFilesFileIDParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchFilesFileIDResponse, error)
+
+	PatchFilesFileIDWithResponse(ctx context.Context, fileID string, params *PatchFilesFileIDParams, body PatchFilesFileIDJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchFilesFileIDResponse, error)
+
+	// PostFilesFileIDCopyWithBodyWithResponse request with any body
+	PostFilesFileIDCopyWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDCopyResponse, error)
+
+	PostFilesFileIDCopyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, body PostFilesFileIDCopyJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDCopyResponse, error)
+
+	// PostFilesFileIDDownloadUrlWithBodyWithResponse request with any body
+	PostFilesFileIDDownloadUrlWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDDownloadUrlResponse, error)
+
+	PostFilesFileIDDownloadUrlWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, body PostFilesFileIDDownloadUrlJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDDownloadUrlResponse, error)
+
+	// PatchFilesFileIDExpiryWithBodyWithResponse request with any body
+	PatchFilesFileIDExpiryWithBodyWithResponse(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchFilesFileIDExpiryResponse, error)
+
+	PatchFilesFileIDExpiryWithResponse(ctx context.Context, fileID string, body PatchFilesFileIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchFilesFileIDExpiryResponse, error)
+
+	// PostFilesFileIDMoveWithBodyWithResponse request with any body
+	PostFilesFileIDMoveWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDMoveResponse, error)
+
+	PostFilesFileIDMoveWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, body PostFilesFileIDMoveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDMoveResponse, error)
+
+	// DeleteFilesFileIDPasswordWithResponse request
+	DeleteFilesFileIDPasswordWithResponse(ctx context.Context, fileID string, params *DeleteFilesFileIDPasswordParams, reqEditors ...RequestEditorFn) (*DeleteFilesFileIDPasswordResponse, error)
+
+	// PutFilesFileIDPasswordWithBodyWithResponse request with any body
+	PutFilesFileIDPasswordWithBodyWithResponse(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFilesFileIDPasswordResponse, error)
+
+	PutFilesFileIDPasswordWithResponse(ctx context.Context, fileID string, body PutFilesFileIDPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFilesFileIDPasswordResponse, error)
+
+	// GetFilesFileIDProcessingWithResponse request
+	GetFilesFileIDProcessingWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDProcessingResponse, error)
+
+	// PostFilesFileIDRestoreWithResponse request
+	PostFilesFileIDRestoreWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*PostFilesFileIDRestoreResponse, error)
+
+	// GetFilesFileIDSharesWithResponse request
+	GetFilesFileIDSharesWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDSharesResponse, error)
+
+	// PostFilesFileIDSharesWithBodyWithResponse request with any body
+	PostFilesFileIDSharesWithBodyWithResponse(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDSharesResponse, error)
+
+	PostFilesFileIDSharesWithResponse(ctx context.Context, fileID string, body PostFilesFileIDSharesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDSharesResponse, error)
+
+	// GetFilesFileIDSharesShareIDStatsWithResponse request
+	GetFilesFileIDSharesShareIDStatsWithResponse(ctx context.Context, fileID string, shareID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDSharesShareIDStatsResponse, error)
+
+	// DeleteFilesFileIDStarWithResponse request
+	DeleteFilesFileIDStarWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*DeleteFilesFileIDStarResponse, error)
+
+	// PostFilesFileIDStarWithResponse request
+	PostFilesFileIDStarWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*PostFilesFileIDStarResponse, error)
+
+	// GetFilesFileIDThumbnailWithResponse request
+	GetFilesFileIDThumbnailWithResponse(ctx context.Context, fileID string, params *GetFilesFileIDThumbnailParams, reqEditors ...RequestEditorFn) (*GetFilesFileIDThumbnailResponse, error)
+
+	// PostFilesFileIDTicketWithBodyWithResponse request with any body
+	PostFilesFileIDTicketWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDTicketResponse, error)
+
+	PostFilesFileIDTicketWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, body PostFilesFileIDTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDTicketResponse, error)
+
+	// GetPermissionsWithResponse request
+	GetPermissionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPermissionsResponse, error)
+
+	// GetSTokenWithResponse request
+	GetSTokenWithResponse(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*GetSTokenResponse, error)
+
+	// PostSTokenReportWithBodyWithResponse request with any body
+	PostSTokenReportWithBodyWithResponse(ctx context.Context, token string, params *PostSTokenReportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSTokenReportResponse, error)
+
+	PostSTokenReportWithResponse(ctx context.Context, token string, params *PostSTokenReportParams, body PostSTokenReportJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSTokenReportResponse, error)
+
+	// DeleteSharesIdWithResponse request
+	DeleteSharesIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteSharesIdResponse, error)
+
+	// PatchSharesIdWithBodyWithResponse request with any body
+	PatchSharesIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchSharesIdResponse, error)
+
+	PatchSharesIdWithResponse(ctx context.Context, id string, body PatchSharesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchSharesIdResponse, error)
+
+	// GetStreamIdWithResponse request
+	GetStreamIdWithResponse(ctx context.Context, id string, params *GetStreamIdParams, reqEditors ...RequestEditorFn) (*GetStreamIdResponse, error)
+
+	// PostUploadWithBodyWithResponse request with any body
+	PostUploadWithBodyWithResponse(ctx context.Context, params *PostUploadParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUploadResponse, error)
+
+	// PostUploadPrecheckWithBodyWithResponse request with any body
+	PostUploadPrecheckWithBodyWithResponse(ctx context.Context, params *PostUploadPrecheckParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUploadPrecheckResponse, error)
+
+	PostUploadPrecheckWithResponse(ctx context.Context, params *PostUploadPrecheckParams, body PostUploadPrecheckJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUploadPrecheckResponse, error)
+
+	// DeleteUserAvatarWithResponse request
+	DeleteUserAvatarWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteUserAvatarResponse, error)
+
+	// PutUserAvatarWithBodyWithResponse request with any body
+	PutUserAvatarWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutUserAvatarResponse, error)
+
+	// PatchUserPasswordWithBodyWithResponse request with any body
+	PatchUserPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUserPasswordResponse, error)
+
+	PatchUserPasswordWithResponse(ctx context.Context, body PatchUserPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUserPasswordResponse, error)
+
+	// GetUserPreferencesWithResponse request
+	GetUserPreferencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUserPreferencesResponse, error)
+
+	// PatchUserPreferencesWithBodyWithResponse request with any body
+	PatchUserPreferencesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUserPreferencesResponse, error)
+
+	PatchUserPreferencesWithResponse(ctx context.Context, body PatchUserPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUserPreferencesResponse, error)
+
+	// PatchUserProfileWithBodyWithResponse request with any body
+	PatchUserProfileWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUserProfileResponse, error)
+
+	PatchUserProfileWithResponse(ctx context.Context, body PatchUserProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUserProfileResponse, error)
+
+	// PostUserProfileVerifyEmailWithBodyWithResponse request with any body
+	PostUserProfileVerifyEmailWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserProfileVerifyEmailResponse, error)
+
+	PostUserProfileVerifyEmailWithResponse(ctx context.Context, body PostUserProfileVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserProfileVerifyEmailResponse, error)
+
+	// GetUserTransferWithResponse request
+	GetUserTransferWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUserTransferResponse, error)
+
+	// GetUsersIdAvatarWithResponse request
+	GetUsersIdAvatarWithResponse(ctx context.Context, id string, params *GetUsersIdAvatarParams, reqEditors ...RequestEditorFn) (*GetUsersIdAvatarResponse, error)
+
+	// GetVersionWithResponse request
+	GetVersionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVersionResponse, error)
+}
+
+type GetAdminAnnouncementsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Announcement
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminAnnouncementsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminAnnouncementsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminAnnouncementsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Announcement
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminAnnouncementsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminAnnouncementsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAdminAnnouncementsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminAnnouncementsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminAnnouncementsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminFilesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminFileList
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminFilesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminFilesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAdminFilesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminFilesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminFilesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminFilesIdReencryptResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *Job
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminFilesIdReencryptResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminFilesIdReencryptResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminIpAccessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IPAccessRules
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminIpAccessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminIpAccessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminIpAccessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IPAccessRules
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminIpAccessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminIpAccessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminJobsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Counts *map[string]int `json:"counts,omitempty"`
+		Jobs   *[]Job          `json:"jobs,omitempty"`
+		Limit  *int            `json:"limit,omitempty"`
+
+		// NextCursor Cursor of the next page; only set when more jobs follow
+		NextCursor *string `json:"next_cursor,omitempty"`
+		Offset     *int    `json:"offset,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminJobsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminJobsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminJobsIdRetryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Job
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminJobsIdRetryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminJobsIdRetryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminKeysRotateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *KeyRotation
+	JSON403      *ErrorResponse
+	JSON409      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminKeysRotateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminKeysRotateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminKeysRotationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		CurrentKekId *string      `json:"current_kek_id,omitempty"`
+		Rotation     *KeyRotation `json:"rotation,omitempty"`
+	}
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminKeysRotationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminKeysRotationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count *int `json:"count,omitempty"`
+		Limit *int `json:"limit,omitempty"`
+		Logs  *[]struct {
+			Action *string `json:"action,omitempty"`
+
+			// ActorId Empty for anonymous actions
+			ActorId       *string                       `json:"actor_id,omitempty"`
+			ActorType     *GetAdminLogs200LogsActorType `json:"actor_type,omitempty"`
+			ActorUsername *string                       `json:"actor_username,omitempty"`
+			CreatedAt     *string                       `json:"created_at,omitempty"`
+			Id            *string                       `json:"id,omitempty"`
+
+			// IpAddress An optional string as the audit log encodes it; String is only meaningful when Valid
+			IpAddress *NullString `json:"ip_address,omitempty"`
+
+			// Metadata Action details, a base64-encoded JSON object
+			Metadata *[]byte `json:"metadata,omitempty"`
+
+			// TargetId An optional string as the audit log encodes it; String is only meaningful when Valid
+			TargetId *NullString `json:"target_id,omitempty"`
+
+			// TargetType An optional string as the audit log encodes it; String is only meaningful when Valid
+			TargetType *NullString `json:"target_type,omitempty"`
+		} `json:"logs,omitempty"`
+
+		// NextCursor Cursor of the next page; only set when more entries follow
+		NextCursor *string `json:"next_cursor,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+type GetAdminLogs200LogsActorType string
+
+// Status returns HTTPResponse.Status
+func (r GetAdminLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminMaintenanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MaintenanceState
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminMaintenanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminMaintenanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminMaintenanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MaintenanceState
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminMaintenanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminMaintenanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminNotificationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Channels *[]NotificationChannel `json:"channels,omitempty"`
+		Events   *[]string              `json:"events,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminNotificationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminNotificationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminNotificationsChannelTestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		JobId   *openapi_types.UUID `json:"job_id,omitempty"`
+		Message *string             `json:"message,omitempty"`
+	}
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminNotificationsChannelTestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminNotificationsChannelTestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminQuarantineResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Files *[]QuarantinedFile `json:"files,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminQuarantineResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminQuarantineResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminQuarantineIdApproveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminQuarantineIdApproveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminQuarantineIdApproveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminQuarantineIdRejectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminQuarantineIdRejectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminQuarantineIdRejectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminReadOnlyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReadOnlyState
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminReadOnlyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminReadOnlyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminReadOnlyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReadOnlyState
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminReadOnlyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminReadOnlyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminReplicationRemotesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Remotes *[]ReplicationRemote `json:"remotes,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminReplicationRemotesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminReplicationRemotesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminReplicationRemotesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ReplicationRemote
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminReplicationRemotesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminReplicationRemotesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAdminReplicationRemotesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminReplicationRemotesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminReplicationRemotesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminReplicationRemotesIdSyncResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		Message *string `json:"message,omitempty"`
+
+		// Queued Files queued; 0 when the remote has them all
+		Queued *int `json:"queued,omitempty"`
+	}
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminReplicationRemotesIdSyncResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminReplicationRemotesIdSyncResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminReportsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Reports *[]ShareReport `json:"reports,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminReportsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminReportsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminReportsIdResolveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message       *string                             `json:"message,omitempty"`
+		ReportsClosed *int                                `json:"reports_closed,omitempty"`
+		Status        *PostAdminReportsIdResolve200Status `json:"status,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON404 *ErrorResponse
+	JSON409 *ErrorResponse
+}
+type PostAdminReportsIdResolve200Status string
+
+// Status returns HTTPResponse.Status
+func (r PostAdminReportsIdResolveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminReportsIdResolveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminServiceAccountsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		ServiceAccounts *[]ServiceAccount `json:"service_accounts,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminServiceAccountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminServiceAccountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminServiceAccountsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ServiceAccount
+	JSON409      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminServiceAccountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminServiceAccountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAdminServiceAccountsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminServiceAccountsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminServiceAccountsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminServiceAccountsIdKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Keys *[]ServiceAccountKey `json:"keys,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminServiceAccountsIdKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminServiceAccountsIdKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminServiceAccountsIdKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		ApiKey *string            `json:"api_key,omitempty"`
+		Key    *ServiceAccountKey `json:"key,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminServiceAccountsIdKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminServiceAccountsIdKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAdminServiceAccountsIdKeysKeyIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminServiceAccountsIdKeysKeyIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminServiceAccountsIdKeysKeyIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		OrphanedSessions *int `json:"orphaned_sessions,omitempty"`
+
+		// SigningKey ID of the key new tokens are signed with
+		SigningKey    *string `json:"signing_key,omitempty"`
+		TotalSessions *int    `json:"total_sessions,omitempty"`
+		Users         *[]struct {
+			Orphaned *bool                           `json:"orphaned,omitempty"`
+			Reason   *GetAdminSessions200UsersReason `json:"reason,omitempty"`
+			Sessions *[]struct {
+				ExpiresAt *time.Time `json:"expires_at,omitempty"`
+				Id        *string    `json:"id,omitempty"`
+			} `json:"sessions,omitempty"`
+			UserId   *string `json:"user_id,omitempty"`
+			Username *string `json:"username,omitempty"`
+		} `json:"users,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+type GetAdminSessions200UsersReason string
+
+// Status returns HTTPResponse.Status
+func (r GetAdminSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminSessionsRevokeAllResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		KeyRotated      *bool   `json:"key_rotated,omitempty"`
+		Message         *string `json:"message,omitempty"`
+		SessionsRevoked *int    `json:"sessions_revoked,omitempty"`
+		SigningKey      *string `json:"signing_key,omitempty"`
+		Warning         *string `json:"warning,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminSessionsRevokeAllResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminSessionsRevokeAllResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		DefaultFileExpiry *int                                 `json:"default_file_expiry,omitempty"`
+		MaxUploadSize     *int                                 `json:"max_upload_size,omitempty"`
+		RegistrationMode  *GetAdminSettings200RegistrationMode `json:"registration_mode,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+type GetAdminSettings200RegistrationMode string
+
+// Status returns HTTPResponse.Status
+func (r GetAdminSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchAdminSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchAdminSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchAdminSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminSigningKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SigningKeys
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminSigningKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminSigningKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminSigningKeysRotateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SigningKeys
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON409      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminSigningKeysRotateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminSigningKeysRotateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminSnapshotsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Snapshots *[]Snapshot `json:"snapshots,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminSnapshotsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminSnapshotsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminSnapshotsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Snapshot
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminSnapshotsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminSnapshotsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminSnapshotsIdRestorableResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Files *[]struct {
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+
+			// DeletedAt Set if the file was in the trash when the snapshot was taken
+			DeletedAt    *time.Time          `json:"deleted_at,omitempty"`
+			FileId       *openapi_types.UUID `json:"file_id,omitempty"`
+			FileName     *string             `json:"file_name,omitempty"`
+			ObjectExists *bool               `json:"object_exists,omitempty"`
+			OwnerExists  *bool               `json:"owner_exists,omitempty"`
+			Size         *int64              `json:"size,omitempty"`
+			UserId       *openapi_types.UUID `json:"user_id,omitempty"`
+		} `json:"files,omitempty"`
+
+		// Snapshot A metadata snapshot
+		Snapshot *Snapshot `json:"snapshot,omitempty"`
+	}
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminSnapshotsIdRestorableResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminSnapshotsIdRestorableResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminSnapshotsIdRestoreResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Failed   *map[string]string `json:"failed,omitempty"`
+		Restored *[]string          `json:"restored,omitempty"`
+	}
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminSnapshotsIdRestoreResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminSnapshotsIdRestoreResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		ActiveSessions *int `json:"active_sessions,omitempty"`
+
+		// ActiveUsers24h Users who uploaded or downloaded in the last 24 hours
+		ActiveUsers24h *int `json:"active_users_24h,omitempty"`
+
+		// Database Database queries run by this replica since it started
+		Database *struct {
+			// ByKind Keyed by select, insert, update, delete or other
+			ByKind *map[string]struct {
+				Count           *int     `json:"count,omitempty"`
+				Errors          *int     `json:"errors,omitempty"`
+				MaxDurationMs   *float32 `json:"max_duration_ms,omitempty"`
+				TotalDurationMs *float32 `json:"total_duration_ms,omitempty"`
+			} `json:"by_kind,omitempty"`
+			Errors  *int `json:"errors,omitempty"`
+			Queries *int `json:"queries,omitempty"`
+
+			// SlowQueries Queries at or above storage.database.slow_query_threshold
+			SlowQueries *int `json:"slow_queries,omitempty"`
+		} `json:"database,omitempty"`
+
+		// DatabasePool Postgres connection pool of this replica
+		DatabasePool *struct {
+			Idle              *int `json:"idle,omitempty"`
+			InUse             *int `json:"in_use,omitempty"`
+			MaxIdleClosed     *int `json:"max_idle_closed,omitempty"`
+			MaxLifetimeClosed *int `json:"max_lifetime_closed,omitempty"`
+			MaxOpenConns      *int `json:"max_open_conns,omitempty"`
+			OpenConns         *int `json:"open_conns,omitempty"`
+
+			// WaitCount Queries that had to wait for a free connection
+			WaitCount      *int     `json:"wait_count,omitempty"`
+			WaitDurationMs *float32 `json:"wait_duration_ms,omitempty"`
+		} `json:"database_pool,omitempty"`
+		DownloadBytes24h *int64 `json:"download_bytes_24h,omitempty"`
+		Downloads24h     *int   `json:"downloads_24h,omitempty"`
+
+		// RedisPool Redis connection pool of this replica
+		RedisPool *struct {
+			Hits       *int `json:"hits,omitempty"`
+			IdleConns  *int `json:"idle_conns,omitempty"`
+			Misses     *int `json:"misses,omitempty"`
+			StaleConns *int `json:"stale_conns,omitempty"`
+			Timeouts   *int `json:"timeouts,omitempty"`
+			TotalConns *int `json:"total_conns,omitempty"`
+		} `json:"redis_pool,omitempty"`
+
+		// StorageAlerts Usage against the thresholds of features.storage_alerts, measured when requested;
+		// omitted when no threshold is configured. alerts are the thresholds the monitor
+		// found exceeded and has reported.
+		StorageAlerts *StorageAlertStatus `json:"storage_alerts,omitempty"`
+
+		// StorageBreaker Circuit breaker in front of MinIO (storage.minio.breaker), omitted when it is off.
+		// While `open`, transfers get 503 with Retry-After and GET /health/ready answers 503.
+		StorageBreaker    *StorageBreaker `json:"storage_breaker,omitempty"`
+		TotalFiles        *int            `json:"total_files,omitempty"`
+		TotalStorageBytes *int            `json:"total_storage_bytes,omitempty"`
+		TotalUsers        *int            `json:"total_users,omitempty"`
+
+		// UploadCapacity Estimated room for uploads (features.uploads.space), omitted when nothing is
+		// checked: free bytes of the temp filesystem, and the storage capacity minus the
+		// stored files. While `low`, uploads get 507.
+		UploadCapacity *UploadCapacity `json:"upload_capacity,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminStatsContentTypesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContentStats
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminStatsContentTypesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminStatsContentTypesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminStorageAnalyzeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		ExpiredFiles   *int `json:"expired_files,omitempty"`
+		OrphanedFiles  *int `json:"orphaned_files,omitempty"`
+		TotalFiles     *int `json:"total_files,omitempty"`
+		TotalSizeBytes *int `json:"total_size_bytes,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminStorageAnalyzeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminStorageAnalyzeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminStorageCleanupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		FilesDeleted    *int    `json:"files_deleted,omitempty"`
+		Message         *string `json:"message,omitempty"`
+		SpaceFreedBytes *int    `json:"space_freed_bytes,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminStorageCleanupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminStorageCleanupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminStorageReplicaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Enabled  *bool   `json:"enabled,omitempty"`
+		Endpoint *string `json:"endpoint,omitempty"`
+
+		// Failed Replications out of attempts (dead jobs); reconcile to catch up
+		Failed *int `json:"failed,omitempty"`
+
+		// LagSeconds Age of the oldest pending replication; 0 when in sync
+		LagSeconds       *float32   `json:"lag_seconds,omitempty"`
+		LastReplicatedAt *time.Time `json:"last_replicated_at,omitempty"`
+		OldestPendingAt  *time.Time `json:"oldest_pending_at,omitempty"`
+
+		// Pending Objects waiting to be replicated
+		Pending *int `json:"pending,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminStorageReplicaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminStorageReplicaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminStorageReplicaReconcileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Different Paths whose size differs
+		Different *[]string `json:"different,omitempty"`
+		DryRun    *bool     `json:"dry_run,omitempty"`
+
+		// Extra Paths only on the standby
+		Extra *[]string `json:"extra,omitempty"`
+
+		// Missing Paths only on the primary
+		Missing        *[]string `json:"missing,omitempty"`
+		PrimaryObjects *int      `json:"primary_objects,omitempty"`
+		Queued         *int      `json:"queued,omitempty"`
+		ReplicaObjects *int      `json:"replica_objects,omitempty"`
+	}
+	JSON409 *ErrorResponse
+	JSON502 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminStorageReplicaReconcileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminStorageReplicaReconcileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Finished *bool          `json:"finished,omitempty"`
+		Month    *string        `json:"month,omitempty"`
+		Records  *[]UsageRecord `json:"records,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsageReportsPeriodResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Report *UsageReport `json:"report,omitempty"`
+		Text   *string      `json:"text,omitempty"`
+		Title  *string      `json:"title,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsageReportsPeriodResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsageReportsPeriodResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminUsageReportsPeriodSendResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		JobId   *openapi_types.UUID `json:"job_id,omitempty"`
+		Message *string             `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminUsageReportsPeriodSendResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminUsageReportsPeriodSendResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsageExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsageExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsageExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminUsageSendResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		JobId   *openapi_types.UUID `json:"job_id,omitempty"`
+		Message *string             `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminUsageSendResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminUsageSendResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Limit *int        `json:"limit,omitempty"`
+		Page  *int        `json:"page,omitempty"`
+		Total *int        `json:"total,omitempty"`
+		Users *[]UserInfo `json:"users,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsersPendingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count *int `json:"count,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsersPendingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsersPendingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAdminUsersIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminUsersIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminUsersIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminUsersIdApproveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminUsersIdApproveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminUsersIdApproveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsersIdFilesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminFileList
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsersIdFilesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsersIdFilesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminUsersIdLogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminUsersIdLogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminUsersIdLogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchAdminUsersIdProfileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Profile
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchAdminUsersIdProfileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchAdminUsersIdProfileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminUsersIdReadOnlyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message  *string `json:"message,omitempty"`
+		ReadOnly *bool   `json:"read_only,omitempty"`
+		UserId   *string `json:"user_id,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminUsersIdReadOnlyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminUsersIdReadOnlyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminUsersIdRejectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminUsersIdRejectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminUsersIdRejectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminUsersIdResetPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message           *string `json:"message,omitempty"`
+		TemporaryPassword *string `json:"temporary_password,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminUsersIdResetPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminUsersIdResetPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchAdminUsersIdRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchAdminUsersIdRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchAdminUsersIdRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchAdminUsersIdStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchAdminUsersIdStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchAdminUsersIdStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsersIdStorageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserStorageReport
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsersIdStorageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsersIdStorageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminUsersIdTransferFilesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		FileIds     *[]string `json:"file_ids,omitempty"`
+		Message     *string   `json:"message,omitempty"`
+		ToUserId    *string   `json:"to_user_id,omitempty"`
+		Transferred *int      `json:"transferred,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+	JSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminUsersIdTransferFilesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminUsersIdTransferFilesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAnnouncementsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Announcement
+	JSON401      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAnnouncementsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAnnouncementsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAnnouncementsIdDismissResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAnnouncementsIdDismissResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAnnouncementsIdDismissResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthResponse
+	JSON401      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthLogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON401 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAuthMeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserInfo
+	JSON401      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuthMeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuthMeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthRefreshResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthRefreshResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthRefreshResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthRegisterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *AuthResponse
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthRegisterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthRegisterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthRevokeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthRevokeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthRevokeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAuthTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		ExpiresAt *time.Time `json:"expires_at"`
+		Id        *string    `json:"id,omitempty"`
+		LastUsed  *time.Time `json:"last_used"`
+		Name      *string    `json:"name,omitempty"`
+		Scopes    *[]string  `json:"scopes,omitempty"`
+	}
+	JSON401 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuthTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuthTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		ExpiresAt *time.Time `json:"expires_at"`
+		Name      *string    `json:"name,omitempty"`
+		Scopes    *[]string  `json:"scopes,omitempty"`
+		Token     *string    `json:"token,omitempty"`
+		TokenId   *string    `json:"token_id,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAuthTokensIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON401 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAuthTokensIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAuthTokensIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDlTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *ErrorResponse
+	JSON410      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDlTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDlTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDocsOpenapiYamlResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	YAML200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDocsOpenapiYamlResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDocsOpenapiYamlResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDownloadIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON410      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDownloadIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDownloadIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFilesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		FileId  *string `json:"file_id,omitempty"`
+		Message *string `json:"message,omitempty"`
+
+		// PurgeAt When the file will be deleted permanently (trash only)
+		PurgeAt *time.Time `json:"purge_at,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFilesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFilesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FileListResponse
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesBatchUpdateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Failed  *int `json:"failed,omitempty"`
+		Results *[]struct {
+			Error  *string       `json:"error,omitempty"`
+			File   *FileMetadata `json:"file,omitempty"`
+			FileId *string       `json:"file_id,omitempty"`
+			Ok     *bool         `json:"ok,omitempty"`
+		} `json:"results,omitempty"`
+		Updated *int `json:"updated,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesBatchUpdateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesBatchUpdateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesExpiringResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count  *int            `json:"count,omitempty"`
+		Files  *[]FileMetadata `json:"files,omitempty"`
+		Within *string         `json:"within,omitempty"`
+	}
+	JSON400 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesExpiringResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesExpiringResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesRecentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FileListResponse
+	JSON400      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesRecentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesRecentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesSearchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count *int            `json:"count,omitempty"`
+		Files *[]FileMetadata `json:"files,omitempty"`
+
+		// NextCursor Cursor of the next page; only set when more results follow
+		NextCursor *string `json:"next_cursor,omitempty"`
+		Query      *string `json:"query,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesSearchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesSearchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesStarredResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FileListResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesStarredResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesStarredResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFilesTrashResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Deleted    *int    `json:"deleted,omitempty"`
+		FreedBytes *int64  `json:"freed_bytes,omitempty"`
+		Message    *string `json:"message,omitempty"`
+	}
+	JSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFilesTrashResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFilesTrashResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesTrashResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count *int `json:"count,omitempty"`
+		Files *[]struct {
+			// CreatedAt File upload timestamp
+			CreatedAt   time.Time  `json:"created_at"`
+			DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+			Description *string    `json:"description,omitempty"`
+
+			// DownloadCount Number of times file has been downloaded
+			DownloadCount int `json:"download_count"`
+
+			// ExpiresAt Expiration timestamp (null if no expiration)
+			ExpiresAt *time.Time `json:"expires_at"`
+
+			// FileId Unique file identifier
+			FileId string `json:"file_id"`
+
+			// FileName Original filename
+			FileName string `json:"file_name"`
+
+			// Folder Virtual folder path ("/" is the root)
+			Folder *string `json:"folder,omitempty"`
+
+			// LastDownloadedAt When the file was last downloaded
+			LastDownloadedAt *time.Time `json:"last_downloaded_at"`
+
+			// MimeType MIME type of the file
+			MimeType string `json:"mime_type"`
+
+			// ModifiedAt Modification time set by the client on upload or with PATCH (missing if never set)
+			ModifiedAt *time.Time `json:"modified_at"`
+
+			// PasswordProtected Whether downloads require the X-File-Password header
+			PasswordProtected *bool      `json:"password_protected,omitempty"`
+			PurgeAt           *time.Time `json:"purge_at,omitempty"`
+
+			// Quarantined Set while the file is held back for review by an admin (features.quarantine);
+			// its share links answer 404 until it is approved
+			Quarantined *bool `json:"quarantined,omitempty"`
+
+			// Sha256 Hex SHA-256 of the content (missing for files uploaded before checksums were recorded)
+			Sha256 *string `json:"sha256,omitempty"`
+
+			// Size File size in bytes
+			Size int64 `json:"size"`
+
+			// Starred Whether the owner starred the file
+			Starred *bool `json:"starred,omitempty"`
+
+			// Tags User-defined tags for categorization
+			Tags *[]string `json:"tags,omitempty"`
+		} `json:"files,omitempty"`
+		TotalSize *int64 `json:"total_size,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesTrashResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesTrashResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesFileIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FileDetails
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON410      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesFileIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesFileIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HeadFilesFileIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r HeadFilesFileIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HeadFilesFileIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchFilesFileIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FileMetadata
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON412      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchFilesFileIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchFilesFileIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesFileIDCopyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *FileMetadata
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON507      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesFileIDCopyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesFileIDCopyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesFileIDDownloadUrlResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		Path      *string    `json:"path,omitempty"`
+		Url       *string    `json:"url,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesFileIDDownloadUrlResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesFileIDDownloadUrlResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchFilesFileIDExpiryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		ExpiresAt *time.Time `json:"expires_at"`
+		FileId    *string    `json:"file_id,omitempty"`
+		Message   *string    `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON410 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchFilesFileIDExpiryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchFilesFileIDExpiryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesFileIDMoveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FileMetadata
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON412      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesFileIDMoveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesFileIDMoveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFilesFileIDPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFilesFileIDPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFilesFileIDPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutFilesFileIDPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutFilesFileIDPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutFilesFileIDPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesFileIDProcessingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		FileId *string      `json:"file_id,omitempty"`
+		Stages *[]FileStage `json:"stages,omitempty"`
+	}
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesFileIDProcessingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesFileIDProcessingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesFileIDRestoreResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FileMetadata
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesFileIDRestoreResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesFileIDRestoreResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesFileIDSharesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count  *int         `json:"count,omitempty"`
+		Shares *[]ShareLink `json:"shares,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesFileIDSharesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesFileIDSharesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesFileIDSharesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		Share *ShareLink `json:"share,omitempty"`
+		Url   *string    `json:"url,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON403 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesFileIDSharesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesFileIDSharesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesFileIDSharesShareIDStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ShareStats
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesFileIDSharesShareIDStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesFileIDSharesShareIDStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFilesFileIDStarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFilesFileIDStarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFilesFileIDStarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesFileIDStarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		FileId  *string `json:"file_id,omitempty"`
+		Starred *bool   `json:"starred,omitempty"`
+	}
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesFileIDStarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesFileIDStarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilesFileIDThumbnailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilesFileIDThumbnailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilesFileIDThumbnailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFilesFileIDTicketResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		Path      *string    `json:"path,omitempty"`
+		Purpose   *string    `json:"purpose,omitempty"`
+		Ticket    *string    `json:"ticket,omitempty"`
+	}
+	JSON404 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFilesFileIDTicketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFilesFileIDTicketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPermissionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Permissions *[]struct {
+			Allowed *bool `json:"allowed,omitempty"`
+
+			// HumanOnly Refused to service account keys
+			HumanOnly *bool                             `json:"human_only,omitempty"`
+			Method    *string                           `json:"method,omitempty"`
+			Path      *string                           `json:"path,omitempty"`
+			Public    *bool                             `json:"public,omitempty"`
+			Role      *GetPermissions200PermissionsRole `json:"role,omitempty"`
+			Scope     *string                           `json:"scope,omitempty"`
+		} `json:"permissions,omitempty"`
+		Role *string `json:"role,omitempty"`
+
+		// Scopes Scopes of the token used; null for sessions (not limited)
+		Scopes         *[]string `json:"scopes"`
+		ServiceAccount *bool     `json:"service_account,omitempty"`
+	}
+	JSON401 *ErrorResponse
+}
+type GetPermissions200PermissionsRole string
+
+// Status returns HTTPResponse.Status
+func (r GetPermissionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPermissionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON410      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSTokenReportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		Message  *string             `json:"message,omitempty"`
+		ReportId *openapi_types.UUID `json:"report_id,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON403 *ErrorResponse
+	JSON404 *ErrorResponse
+	JSON429 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSTokenReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSTokenReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSharesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSharesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSharesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchSharesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchSharesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchSharesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStreamIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON410      *ErrorResponse
+	JSON416      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStreamIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStreamIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUploadResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *FileMetadata
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON413      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON502      *ErrorResponse
+	JSON503      *ErrorResponse
+	JSON507      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUploadResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUploadResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUploadPrecheckResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Exists *bool `json:"exists,omitempty"`
+	}
+	JSON201 *FileMetadata
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON500 *ErrorResponse
+	JSON507 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUploadPrecheckResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUploadPrecheckResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteUserAvatarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Profile
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteUserAvatarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteUserAvatarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutUserAvatarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Profile
+	JSON400      *ErrorResponse
+	JSON413      *ErrorResponse
+	JSON415      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutUserAvatarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutUserAvatarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchUserPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchUserPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchUserPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUserPreferencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserPreferences
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUserPreferencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUserPreferencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchUserPreferencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserPreferences
+	JSON400      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchUserPreferencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchUserPreferencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchUserProfileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Profile
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON502      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchUserProfileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchUserProfileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUserProfileVerifyEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Profile
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUserProfileVerifyEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUserProfileVerifyEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUserTransferResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransferStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUserTransferResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUserTransferResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersIdAvatarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersIdAvatarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersIdAvatarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetVersionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VersionInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVersionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVersionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAdminAnnouncementsWithResponse request returning *GetAdminAnnouncementsResponse
+func (c *ClientWithResponses) GetAdminAnnouncementsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminAnnouncementsResponse, error) {
+	rsp, err := c.GetAdminAnnouncements(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminAnnouncementsResponse(rsp)
+}
+
+// PostAdminAnnouncementsWithBodyWithResponse request with arbitrary body returning *PostAdminAnnouncementsResponse
+func (c *ClientWithResponses) PostAdminAnnouncementsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminAnnouncementsResponse, error) {
+	rsp, err := c.PostAdminAnnouncementsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminAnnouncementsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminAnnouncementsWithResponse(ctx context.Context, body PostAdminAnnouncementsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminAnnouncementsResponse, error) {
+	rsp, err := c.PostAdminAnnouncements(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminAnnouncementsResponse(rsp)
+}
+
+// DeleteAdminAnnouncementsIdWithResponse request returning *DeleteAdminAnnouncementsIdResponse
+func (c *ClientWithResponses) DeleteAdminAnnouncementsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminAnnouncementsIdResponse, error) {
+	rsp, err := c.DeleteAdminAnnouncementsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminAnnouncementsIdResponse(rsp)
+}
+
+// GetAdminFilesWithResponse request returning *GetAdminFilesResponse
+func (c *ClientWithResponses) GetAdminFilesWithResponse(ctx context.Context, params *GetAdminFilesParams, reqEditors ...RequestEditorFn) (*GetAdminFilesResponse, error) {
+	rsp, err := c.GetAdminFiles(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminFilesResponse(rsp)
+}
+
+// DeleteAdminFilesIdWithResponse request returning *DeleteAdminFilesIdResponse
+func (c *ClientWithResponses) DeleteAdminFilesIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminFilesIdResponse, error) {
+	rsp, err := c.DeleteAdminFilesId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminFilesIdResponse(rsp)
+}
+
+// PostAdminFilesIdReencryptWithResponse request returning *PostAdminFilesIdReencryptResponse
+func (c *ClientWithResponses) PostAdminFilesIdReencryptWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminFilesIdReencryptResponse, error) {
+	rsp, err := c.PostAdminFilesIdReencrypt(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminFilesIdReencryptResponse(rsp)
+}
+
+// GetAdminIpAccessWithResponse request returning *GetAdminIpAccessResponse
+func (c *ClientWithResponses) GetAdminIpAccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminIpAccessResponse, error) {
+	rsp, err := c.GetAdminIpAccess(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminIpAccessResponse(rsp)
+}
+
+// PutAdminIpAccessWithBodyWithResponse request with arbitrary body returning *PutAdminIpAccessResponse
+func (c *ClientWithResponses) PutAdminIpAccessWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminIpAccessResponse, error) {
+	rsp, err := c.PutAdminIpAccessWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminIpAccessResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminIpAccessWithResponse(ctx context.Context, body PutAdminIpAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminIpAccessResponse, error) {
+	rsp, err := c.PutAdminIpAccess(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminIpAccessResponse(rsp)
+}
+
+// GetAdminJobsWithResponse request returning *GetAdminJobsResponse
+func (c *ClientWithResponses) GetAdminJobsWithResponse(ctx context.Context, params *GetAdminJobsParams, reqEditors ...RequestEditorFn) (*GetAdminJobsResponse, error) {
+	rsp, err := c.GetAdminJobs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminJobsResponse(rsp)
+}
+
+// PostAdminJobsIdRetryWithResponse request returning *PostAdminJobsIdRetryResponse
+func (c *ClientWithResponses) PostAdminJobsIdRetryWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminJobsIdRetryResponse, error) {
+	rsp, err := c.PostAdminJobsIdRetry(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminJobsIdRetryResponse(rsp)
+}
+
+// PostAdminKeysRotateWithBodyWithResponse request with arbitrary body returning *PostAdminKeysRotateResponse
+func (c *ClientWithResponses) PostAdminKeysRotateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminKeysRotateResponse, error) {
+	rsp, err := c.PostAdminKeysRotateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminKeysRotateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminKeysRotateWithResponse(ctx context.Context, body PostAdminKeysRotateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminKeysRotateResponse, error) {
+	rsp, err := c.PostAdminKeysRotate(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminKeysRotateResponse(rsp)
+}
+
+// GetAdminKeysRotationWithResponse request returning *GetAdminKeysRotationResponse
+func (c *ClientWithResponses) GetAdminKeysRotationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminKeysRotationResponse, error) {
+	rsp, err := c.GetAdminKeysRotation(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminKeysRotationResponse(rsp)
+}
+
+// GetAdminLogsWithResponse request returning *GetAdminLogsResponse
+func (c *ClientWithResponses) GetAdminLogsWithResponse(ctx context.Context, params *GetAdminLogsParams, reqEditors ...RequestEditorFn) (*GetAdminLogsResponse, error) {
+	rsp, err := c.GetAdminLogs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminLogsResponse(rsp)
+}
+
+// GetAdminMaintenanceWithResponse request returning *GetAdminMaintenanceResponse
+func (c *ClientWithResponses) GetAdminMaintenanceWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminMaintenanceResponse, error) {
+	rsp, err := c.GetAdminMaintenance(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminMaintenanceResponse(rsp)
+}
+
+// PutAdminMaintenanceWithBodyWithResponse request with arbitrary body returning *PutAdminMaintenanceResponse
+func (c *ClientWithResponses) PutAdminMaintenanceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminMaintenanceResponse, error) {
+	rsp, err := c.PutAdminMaintenanceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminMaintenanceResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminMaintenanceWithResponse(ctx context.Context, body PutAdminMaintenanceJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminMaintenanceResponse, error) {
+	rsp, err := c.PutAdminMaintenance(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminMaintenanceResponse(rsp)
+}
+
+// GetAdminNotificationsWithResponse request returning *GetAdminNotificationsResponse
+func (c *ClientWithResponses) GetAdminNotificationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminNotificationsResponse, error) {
+	rsp, err := c.GetAdminNotifications(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminNotificationsResponse(rsp)
+}
+
+// PostAdminNotificationsChannelTestWithResponse request returning *PostAdminNotificationsChannelTestResponse
+func (c *ClientWithResponses) PostAdminNotificationsChannelTestWithResponse(ctx context.Context, channel string, reqEditors ...RequestEditorFn) (*PostAdminNotificationsChannelTestResponse, error) {
+	rsp, err := c.PostAdminNotificationsChannelTest(ctx, channel, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminNotificationsChannelTestResponse(rsp)
+}
+
+// GetAdminQuarantineWithResponse request returning *GetAdminQuarantineResponse
+func (c *ClientWithResponses) GetAdminQuarantineWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminQuarantineResponse, error) {
+	rsp, err := c.GetAdminQuarantine(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminQuarantineResponse(rsp)
+}
+
+// PostAdminQuarantineIdApproveWithResponse request returning *PostAdminQuarantineIdApproveResponse
+func (c *ClientWithResponses) PostAdminQuarantineIdApproveWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminQuarantineIdApproveResponse, error) {
+	rsp, err := c.PostAdminQuarantineIdApprove(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminQuarantineIdApproveResponse(rsp)
+}
+
+// PostAdminQuarantineIdRejectWithResponse request returning *PostAdminQuarantineIdRejectResponse
+func (c *ClientWithResponses) PostAdminQuarantineIdRejectWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAdminQuarantineIdRejectResponse, error) {
+	rsp, err := c.PostAdminQuarantineIdReject(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminQuarantineIdRejectResponse(rsp)
+}
+
+// GetAdminReadOnlyWithResponse request returning *GetAdminReadOnlyResponse
+func (c *ClientWithResponses) GetAdminReadOnlyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminReadOnlyResponse, error) {
+	rsp, err := c.GetAdminReadOnly(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminReadOnlyResponse(rsp)
+}
+
+// PutAdminReadOnlyWithBodyWithResponse request with arbitrary body returning *PutAdminReadOnlyResponse
+func (c *ClientWithResponses) PutAdminReadOnlyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminReadOnlyResponse, error) {
+	rsp, err := c.PutAdminReadOnlyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminReadOnlyResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminReadOnlyWithResponse(ctx context.Context, body PutAdminReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminReadOnlyResponse, error) {
+	rsp, err := c.PutAdminReadOnly(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminReadOnlyResponse(rsp)
+}
+
+// GetAdminReplicationRemotesWithResponse request returning *GetAdminReplicationRemotesResponse
+func (c *ClientWithResponses) GetAdminReplicationRemotesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminReplicationRemotesResponse, error) {
+	rsp, err := c.GetAdminReplicationRemotes(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminReplicationRemotesResponse(rsp)
+}
+
+// PostAdminReplicationRemotesWithBodyWithResponse request with arbitrary body returning *PostAdminReplicationRemotesResponse
+func (c *ClientWithResponses) PostAdminReplicationRemotesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesResponse, error) {
+	rsp, err := c.PostAdminReplicationRemotesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminReplicationRemotesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminReplicationRemotesWithResponse(ctx context.Context, body PostAdminReplicationRemotesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesResponse, error) {
+	rsp, err := c.PostAdminReplicationRemotes(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminReplicationRemotesResponse(rsp)
+}
+
+// DeleteAdminReplicationRemotesIdWithResponse request returning *DeleteAdminReplicationRemotesIdResponse
+func (c *ClientWithResponses) DeleteAdminReplicationRemotesIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteAdminReplicationRemotesIdResponse, error) {
+	rsp, err := c.DeleteAdminReplicationRemotesId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminReplicationRemotesIdResponse(rsp)
+}
+
+// PostAdminReplicationRemotesIdSyncWithBodyWithResponse request with arbitrary body returning *PostAdminReplicationRemotesIdSyncResponse
+func (c *ClientWithResponses) PostAdminReplicationRemotesIdSyncWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesIdSyncResponse, error) {
+	rsp, err := c.PostAdminReplicationRemotesIdSyncWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminReplicationRemotesIdSyncResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminReplicationRemotesIdSyncWithResponse(ctx context.Context, id openapi_types.UUID, body PostAdminReplicationRemotesIdSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminReplicationRemotesIdSyncResponse, error) {
+	rsp, err := c.PostAdminReplicationRemotesIdSync(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminReplicationRemotesIdSyncResponse(rsp)
+}
+
+// GetAdminReportsWithResponse request returning *GetAdminReportsResponse
+func (c *ClientWithResponses) GetAdminReportsWithResponse(ctx context.Context, params *GetAdminReportsParams, reqEditors ...RequestEditorFn) (*GetAdminReportsResponse, error) {
+	rsp, err := c.GetAdminReports(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminReportsResponse(rsp)
+}
+
+// PostAdminReportsIdResolveWithBodyWithResponse request with arbitrary body returning *PostAdminReportsIdResolveResponse
+func (c *ClientWithResponses) PostAdminReportsIdResolveWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminReportsIdResolveResponse, error) {
+	rsp, err := c.PostAdminReportsIdResolveWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminReportsIdResolveResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminReportsIdResolveWithResponse(ctx context.Context, id openapi_types.UUID, body PostAdminReportsIdResolveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminReportsIdResolveResponse, error) {
+	rsp, err := c.PostAdminReportsIdResolve(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminReportsIdResolveResponse(rsp)
+}
+
+// GetAdminServiceAccountsWithResponse request returning *GetAdminServiceAccountsResponse
+func (c *ClientWithResponses) GetAdminServiceAccountsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminServiceAccountsResponse, error) {
+	rsp, err := c.GetAdminServiceAccounts(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminServiceAccountsResponse(rsp)
+}
+
+// PostAdminServiceAccountsWithBodyWithResponse request with arbitrary body returning *PostAdminServiceAccountsResponse
+func (c *ClientWithResponses) PostAdminServiceAccountsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsResponse, error) {
+	rsp, err := c.PostAdminServiceAccountsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminServiceAccountsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminServiceAccountsWithResponse(ctx context.Context, body PostAdminServiceAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsResponse, error) {
+	rsp, err := c.PostAdminServiceAccounts(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminServiceAccountsResponse(rsp)
+}
+
+// DeleteAdminServiceAccountsIdWithResponse request returning *DeleteAdminServiceAccountsIdResponse
+func (c *ClientWithResponses) DeleteAdminServiceAccountsIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminServiceAccountsIdResponse, error) {
+	rsp, err := c.DeleteAdminServiceAccountsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminServiceAccountsIdResponse(rsp)
+}
+
+// GetAdminServiceAccountsIdKeysWithResponse request returning *GetAdminServiceAccountsIdKeysResponse
+func (c *ClientWithResponses) GetAdminServiceAccountsIdKeysWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetAdminServiceAccountsIdKeysResponse, error) {
+	rsp, err := c.GetAdminServiceAccountsIdKeys(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminServiceAccountsIdKeysResponse(rsp)
+}
+
+// PostAdminServiceAccountsIdKeysWithBodyWithResponse request with arbitrary body returning *PostAdminServiceAccountsIdKeysResponse
+func (c *ClientWithResponses) PostAdminServiceAccountsIdKeysWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsIdKeysResponse, error) {
+	rsp, err := c.PostAdminServiceAccountsIdKeysWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminServiceAccountsIdKeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminServiceAccountsIdKeysWithResponse(ctx context.Context, id string, body PostAdminServiceAccountsIdKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminServiceAccountsIdKeysResponse, error) {
+	rsp, err := c.PostAdminServiceAccountsIdKeys(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminServiceAccountsIdKeysResponse(rsp)
+}
+
+// DeleteAdminServiceAccountsIdKeysKeyIDWithResponse request returning *DeleteAdminServiceAccountsIdKeysKeyIDResponse
+func (c *ClientWithResponses) DeleteAdminServiceAccountsIdKeysKeyIDWithResponse(ctx context.Context, id string, keyID string, reqEditors ...RequestEditorFn) (*DeleteAdminServiceAccountsIdKeysKeyIDResponse, error) {
+	rsp, err := c.DeleteAdminServiceAccountsIdKeysKeyID(ctx, id, keyID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminServiceAccountsIdKeysKeyIDResponse(rsp)
+}
+
+// GetAdminSessionsWithResponse request returning *GetAdminSessionsResponse
+func (c *ClientWithResponses) GetAdminSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSessionsResponse, error) {
+	rsp, err := c.GetAdminSessions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminSessionsResponse(rsp)
+}
+
+// PostAdminSessionsRevokeAllWithResponse request returning *PostAdminSessionsRevokeAllResponse
+func (c *ClientWithResponses) PostAdminSessionsRevokeAllWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminSessionsRevokeAllResponse, error) {
+	rsp, err := c.PostAdminSessionsRevokeAll(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminSessionsRevokeAllResponse(rsp)
+}
+
+// GetAdminSettingsWithResponse request returning *GetAdminSettingsResponse
+func (c *ClientWithResponses) GetAdminSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSettingsResponse, error) {
+	rsp, err := c.GetAdminSettings(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminSettingsResponse(rsp)
+}
+
+// PatchAdminSettingsWithBodyWithResponse request with arbitrary body returning *PatchAdminSettingsResponse
+func (c *ClientWithResponses) PatchAdminSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminSettingsResponse, error) {
+	rsp, err := c.PatchAdminSettingsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminSettingsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchAdminSettingsWithResponse(ctx context.Context, body PatchAdminSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminSettingsResponse, error) {
+	rsp, err := c.PatchAdminSettings(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminSettingsResponse(rsp)
+}
+
+// GetAdminSigningKeysWithResponse request returning *GetAdminSigningKeysResponse
+func (c *ClientWithResponses) GetAdminSigningKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSigningKeysResponse, error) {
+	rsp, err := c.GetAdminSigningKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminSigningKeysResponse(rsp)
+}
+
+// PostAdminSigningKeysRotateWithResponse request returning *PostAdminSigningKeysRotateResponse
+func (c *ClientWithResponses) PostAdminSigningKeysRotateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminSigningKeysRotateResponse, error) {
+	rsp, err := c.PostAdminSigningKeysRotate(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminSigningKeysRotateResponse(rsp)
+}
+
+// GetAdminSnapshotsWithResponse request returning *GetAdminSnapshotsResponse
+func (c *ClientWithResponses) GetAdminSnapshotsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminSnapshotsResponse, error) {
+	rsp, err := c.GetAdminSnapshots(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminSnapshotsResponse(rsp)
+}
+
+// PostAdminSnapshotsWithResponse request returning *PostAdminSnapshotsResponse
+func (c *ClientWithResponses) PostAdminSnapshotsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminSnapshotsResponse, error) {
+	rsp, err := c.PostAdminSnapshots(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminSnapshotsResponse(rsp)
+}
+
+// GetAdminSnapshotsIdRestorableWithResponse request returning *GetAdminSnapshotsIdRestorableResponse
+func (c *ClientWithResponses) GetAdminSnapshotsIdRestorableWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetAdminSnapshotsIdRestorableResponse, error) {
+	rsp, err := c.GetAdminSnapshotsIdRestorable(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminSnapshotsIdRestorableResponse(rsp)
+}
+
+// PostAdminSnapshotsIdRestoreWithBodyWithResponse request with arbitrary body returning *PostAdminSnapshotsIdRestoreResponse
+func (c *ClientWithResponses) PostAdminSnapshotsIdRestoreWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminSnapshotsIdRestoreResponse, error) {
+	rsp, err := c.PostAdminSnapshotsIdRestoreWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminSnapshotsIdRestoreResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminSnapshotsIdRestoreWithResponse(ctx context.Context, id string, body PostAdminSnapshotsIdRestoreJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminSnapshotsIdRestoreResponse, error) {
+	rsp, err := c.PostAdminSnapshotsIdRestore(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminSnapshotsIdRestoreResponse(rsp)
+}
+
+// GetAdminStatsWithResponse request returning *GetAdminStatsResponse
+func (c *ClientWithResponses) GetAdminStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStatsResponse, error) {
+	rsp, err := c.GetAdminStats(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminStatsResponse(rsp)
+}
+
+// GetAdminStatsContentTypesWithResponse request returning *GetAdminStatsContentTypesResponse
+func (c *ClientWithResponses) GetAdminStatsContentTypesWithResponse(ctx context.Context, params *GetAdminStatsContentTypesParams, reqEditors ...RequestEditorFn) (*GetAdminStatsContentTypesResponse, error) {
+	rsp, err := c.GetAdminStatsContentTypes(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminStatsContentTypesResponse(rsp)
+}
+
+// GetAdminStorageAnalyzeWithResponse request returning *GetAdminStorageAnalyzeResponse
+func (c *ClientWithResponses) GetAdminStorageAnalyzeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStorageAnalyzeResponse, error) {
+	rsp, err := c.GetAdminStorageAnalyze(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminStorageAnalyzeResponse(rsp)
+}
+
+// PostAdminStorageCleanupWithResponse request returning *PostAdminStorageCleanupResponse
+func (c *ClientWithResponses) PostAdminStorageCleanupWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAdminStorageCleanupResponse, error) {
+	rsp, err := c.PostAdminStorageCleanup(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminStorageCleanupResponse(rsp)
+}
+
+// GetAdminStorageReplicaWithResponse request returning *GetAdminStorageReplicaResponse
+func (c *ClientWithResponses) GetAdminStorageReplicaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStorageReplicaResponse, error) {
+	rsp, err := c.GetAdminStorageReplica(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminStorageReplicaResponse(rsp)
+}
+
+// PostAdminStorageReplicaReconcileWithBodyWithResponse request with arbitrary body returning *PostAdminStorageReplicaReconcileResponse
+func (c *ClientWithResponses) PostAdminStorageReplicaReconcileWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminStorageReplicaReconcileResponse, error) {
+	rsp, err := c.PostAdminStorageReplicaReconcileWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminStorageReplicaReconcileResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminStorageReplicaReconcileWithResponse(ctx context.Context, body PostAdminStorageReplicaReconcileJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminStorageReplicaReconcileResponse, error) {
+	rsp, err := c.PostAdminStorageReplicaReconcile(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminStorageReplicaReconcileResponse(rsp)
+}
+
+// GetAdminUsageWithResponse request returning *GetAdminUsageResponse
+func (c *ClientWithResponses) GetAdminUsageWithResponse(ctx context.Context, params *GetAdminUsageParams, reqEditors ...RequestEditorFn) (*GetAdminUsageResponse, error) {
+	rsp, err := c.GetAdminUsage(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsageResponse(rsp)
+}
+
+// GetAdminUsageReportsPeriodWithResponse request returning *GetAdminUsageReportsPeriodResponse
+func (c *ClientWithResponses) GetAdminUsageReportsPeriodWithResponse(ctx context.Context, period GetAdminUsageReportsPeriodParamsPeriod, reqEditors ...RequestEditorFn) (*GetAdminUsageReportsPeriodResponse, error) {
+	rsp, err := c.GetAdminUsageReportsPeriod(ctx, period, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsageReportsPeriodResponse(rsp)
+}
+
+// PostAdminUsageReportsPeriodSendWithResponse request returning *PostAdminUsageReportsPeriodSendResponse
+func (c *ClientWithResponses) PostAdminUsageReportsPeriodSendWithResponse(ctx context.Context, period PostAdminUsageReportsPeriodSendParamsPeriod, reqEditors ...RequestEditorFn) (*PostAdminUsageReportsPeriodSendResponse, error) {
+	rsp, err := c.PostAdminUsageReportsPeriodSend(ctx, period, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsageReportsPeriodSendResponse(rsp)
+}
+
+// GetAdminUsageExportWithResponse request returning *GetAdminUsageExportResponse
+func (c *ClientWithResponses) GetAdminUsageExportWithResponse(ctx context.Context, params *GetAdminUsageExportParams, reqEditors ...RequestEditorFn) (*GetAdminUsageExportResponse, error) {
+	rsp, err := c.GetAdminUsageExport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsageExportResponse(rsp)
+}
+
+// PostAdminUsageSendWithResponse request returning *PostAdminUsageSendResponse
+func (c *ClientWithResponses) PostAdminUsageSendWithResponse(ctx context.Context, params *PostAdminUsageSendParams, reqEditors ...RequestEditorFn) (*PostAdminUsageSendResponse, error) {
+	rsp, err := c.PostAdminUsageSend(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsageSendResponse(rsp)
+}
+
+// GetAdminUsersWithResponse request returning *GetAdminUsersResponse
+func (c *ClientWithResponses) GetAdminUsersWithResponse(ctx context.Context, params *GetAdminUsersParams, reqEditors ...RequestEditorFn) (*GetAdminUsersResponse, error) {
+	rsp, err := c.GetAdminUsers(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsersResponse(rsp)
+}
+
+// GetAdminUsersPendingWithResponse request returning *GetAdminUsersPendingResponse
+func (c *ClientWithResponses) GetAdminUsersPendingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminUsersPendingResponse, error) {
+	rsp, err := c.GetAdminUsersPending(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsersPendingResponse(rsp)
+}
+
+// DeleteAdminUsersIdWithResponse request returning *DeleteAdminUsersIdResponse
+func (c *ClientWithResponses) DeleteAdminUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAdminUsersIdResponse, error) {
+	rsp, err := c.DeleteAdminUsersId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminUsersIdResponse(rsp)
+}
+
+// PostAdminUsersIdApproveWithResponse request returning *PostAdminUsersIdApproveResponse
+func (c *ClientWithResponses) PostAdminUsersIdApproveWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdApproveResponse, error) {
+	rsp, err := c.PostAdminUsersIdApprove(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsersIdApproveResponse(rsp)
+}
+
+// GetAdminUsersIdFilesWithResponse request returning *GetAdminUsersIdFilesResponse
+func (c *ClientWithResponses) GetAdminUsersIdFilesWithResponse(ctx context.Context, id string, params *GetAdminUsersIdFilesParams, reqEditors ...RequestEditorFn) (*GetAdminUsersIdFilesResponse, error) {
+	rsp, err := c.GetAdminUsersIdFiles(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsersIdFilesResponse(rsp)
+}
+
+// PostAdminUsersIdLogoutWithResponse request returning *PostAdminUsersIdLogoutResponse
+func (c *ClientWithResponses) PostAdminUsersIdLogoutWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdLogoutResponse, error) {
+	rsp, err := c.PostAdminUsersIdLogout(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsersIdLogoutResponse(rsp)
+}
+
+// PatchAdminUsersIdProfileWithBodyWithResponse request with arbitrary body returning *PatchAdminUsersIdProfileResponse
+func (c *ClientWithResponses) PatchAdminUsersIdProfileWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdProfileResponse, error) {
+	rsp, err := c.PatchAdminUsersIdProfileWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminUsersIdProfileResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchAdminUsersIdProfileWithResponse(ctx context.Context, id string, body PatchAdminUsersIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdProfileResponse, error) {
+	rsp, err := c.PatchAdminUsersIdProfile(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminUsersIdProfileResponse(rsp)
+}
+
+// PutAdminUsersIdReadOnlyWithBodyWithResponse request with arbitrary body returning *PutAdminUsersIdReadOnlyResponse
+func (c *ClientWithResponses) PutAdminUsersIdReadOnlyWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminUsersIdReadOnlyResponse, error) {
+	rsp, err := c.PutAdminUsersIdReadOnlyWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminUsersIdReadOnlyResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminUsersIdReadOnlyWithResponse(ctx context.Context, id string, body PutAdminUsersIdReadOnlyJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminUsersIdReadOnlyResponse, error) {
+	rsp, err := c.PutAdminUsersIdReadOnly(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminUsersIdReadOnlyResponse(rsp)
+}
+
+// PostAdminUsersIdRejectWithResponse request returning *PostAdminUsersIdRejectResponse
+func (c *ClientWithResponses) PostAdminUsersIdRejectWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdRejectResponse, error) {
+	rsp, err := c.PostAdminUsersIdReject(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsersIdRejectResponse(rsp)
+}
+
+// PostAdminUsersIdResetPasswordWithResponse request returning *PostAdminUsersIdResetPasswordResponse
+func (c *ClientWithResponses) PostAdminUsersIdResetPasswordWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAdminUsersIdResetPasswordResponse, error) {
+	rsp, err := c.PostAdminUsersIdResetPassword(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsersIdResetPasswordResponse(rsp)
+}
+
+// PatchAdminUsersIdRoleWithBodyWithResponse request with arbitrary body returning *PatchAdminUsersIdRoleResponse
+func (c *ClientWithResponses) PatchAdminUsersIdRoleWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdRoleResponse, error) {
+	rsp, err := c.PatchAdminUsersIdRoleWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminUsersIdRoleResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchAdminUsersIdRoleWithResponse(ctx context.Context, id string, body PatchAdminUsersIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdRoleResponse, error) {
+	rsp, err := c.PatchAdminUsersIdRole(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminUsersIdRoleResponse(rsp)
+}
+
+// PatchAdminUsersIdStatusWithBodyWithResponse request with arbitrary body returning *PatchAdminUsersIdStatusResponse
+func (c *ClientWithResponses) PatchAdminUsersIdStatusWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdStatusResponse, error) {
+	rsp, err := c.PatchAdminUsersIdStatusWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminUsersIdStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchAdminUsersIdStatusWithResponse(ctx context.Context, id string, body PatchAdminUsersIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchAdminUsersIdStatusResponse, error) {
+	rsp, err := c.PatchAdminUsersIdStatus(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchAdminUsersIdStatusResponse(rsp)
+}
+
+// GetAdminUsersIdStorageWithResponse request returning *GetAdminUsersIdStorageResponse
+func (c *ClientWithResponses) GetAdminUsersIdStorageWithResponse(ctx context.Context, id string, params *GetAdminUsersIdStorageParams, reqEditors ...RequestEditorFn) (*GetAdminUsersIdStorageResponse, error) {
+	rsp, err := c.GetAdminUsersIdStorage(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsersIdStorageResponse(rsp)
+}
+
+// PostAdminUsersIdTransferFilesWithBodyWithResponse request with arbitrary body returning *PostAdminUsersIdTransferFilesResponse
+func (c *ClientWithResponses) PostAdminUsersIdTransferFilesWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminUsersIdTransferFilesResponse, error) {
+	rsp, err := c.PostAdminUsersIdTransferFilesWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsersIdTransferFilesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminUsersIdTransferFilesWithResponse(ctx context.Context, id string, body PostAdminUsersIdTransferFilesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminUsersIdTransferFilesResponse, error) {
+	rsp, err := c.PostAdminUsersIdTransferFiles(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminUsersIdTransferFilesResponse(rsp)
+}
+
+// GetAnnouncementsWithResponse request returning *GetAnnouncementsResponse
+func (c *ClientWithResponses) GetAnnouncementsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAnnouncementsResponse, error) {
+	rsp, err := c.GetAnnouncements(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAnnouncementsResponse(rsp)
+}
+
+// PostAnnouncementsIdDismissWithResponse request returning *PostAnnouncementsIdDismissResponse
+func (c *ClientWithResponses) PostAnnouncementsIdDismissWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostAnnouncementsIdDismissResponse, error) {
+	rsp, err := c.PostAnnouncementsIdDismiss(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAnnouncementsIdDismissResponse(rsp)
+}
+
+// PostAuthLoginWithBodyWithResponse request with arbitrary body returning *PostAuthLoginResponse
+func (c *ClientWithResponses) PostAuthLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error) {
+	rsp, err := c.PostAuthLoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthLoginWithResponse(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error) {
+	rsp, err := c.PostAuthLogin(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginResponse(rsp)
+}
+
+// PostAuthLogoutWithResponse request returning *PostAuthLogoutResponse
+func (c *ClientWithResponses) PostAuthLogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error) {
+	rsp, err := c.PostAuthLogout(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLogoutResponse(rsp)
+}
+
+// GetAuthMeWithResponse request returning *GetAuthMeResponse
+func (c *ClientWithResponses) GetAuthMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthMeResponse, error) {
+	rsp, err := c.GetAuthMe(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuthMeResponse(rsp)
+}
+
+// PostAuthRefreshWithBodyWithResponse request with arbitrary body returning *PostAuthRefreshResponse
+func (c *ClientWithResponses) PostAuthRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error) {
+	rsp, err := c.PostAuthRefreshWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRefreshResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthRefreshWithResponse(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error) {
+	rsp, err := c.PostAuthRefresh(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRefreshResponse(rsp)
+}
+
+// PostAuthRegisterWithBodyWithResponse request with arbitrary body returning *PostAuthRegisterResponse
+func (c *ClientWithResponses) PostAuthRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRegisterResponse, error) {
+	rsp, err := c.PostAuthRegisterWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRegisterResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthRegisterWithResponse(ctx context.Context, body PostAuthRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRegisterResponse, error) {
+	rsp, err := c.PostAuthRegister(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRegisterResponse(rsp)
+}
+
+// PostAuthRevokeWithBodyWithResponse request with arbitrary body returning *PostAuthRevokeResponse
+func (c *ClientWithResponses) PostAuthRevokeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRevokeResponse, error) {
+	rsp, err := c.PostAuthRevokeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRevokeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthRevokeWithResponse(ctx context.Context, body PostAuthRevokeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRevokeResponse, error) {
+	rsp, err := c.PostAuthRevoke(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRevokeResponse(rsp)
+}
+
+// GetAuthTokensWithResponse request returning *GetAuthTokensResponse
+func (c *ClientWithResponses) GetAuthTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthTokensResponse, error) {
+	rsp, err := c.GetAuthTokens(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuthTokensResponse(rsp)
+}
+
+// PostAuthTokensWithBodyWithResponse request with arbitrary body returning *PostAuthTokensResponse
+func (c *ClientWithResponses) PostAuthTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTokensResponse, error) {
+	rsp, err := c.PostAuthTokensWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTokensResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthTokensWithResponse(ctx context.Context, body PostAuthTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTokensResponse, error) {
+	rsp, err := c.PostAuthTokens(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTokensResponse(rsp)
+}
+
+// DeleteAuthTokensIdWithResponse request returning *DeleteAuthTokensIdResponse
+func (c *ClientWithResponses) DeleteAuthTokensIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAuthTokensIdResponse, error) {
+	rsp, err := c.DeleteAuthTokensId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAuthTokensIdResponse(rsp)
+}
+
+// GetDlTokenWithResponse request returning *GetDlTokenResponse
+func (c *ClientWithResponses) GetDlTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetDlTokenResponse, error) {
+	rsp, err := c.GetDlToken(ctx, token, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDlTokenResponse(rsp)
+}
+
+// GetDocsOpenapiYamlWithResponse request returning *GetDocsOpenapiYamlResponse
+func (c *ClientWithResponses) GetDocsOpenapiYamlWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDocsOpenapiYamlResponse, error) {
+	rsp, err := c.GetDocsOpenapiYaml(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDocsOpenapiYamlResponse(rsp)
+}
+
+// GetDownloadIdWithResponse request returning *GetDownloadIdResponse
+func (c *ClientWithResponses) GetDownloadIdWithResponse(ctx context.Context, id string, params *GetDownloadIdParams, reqEditors ...RequestEditorFn) (*GetDownloadIdResponse, error) {
+	rsp, err := c.GetDownloadId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDownloadIdResponse(rsp)
+}
+
+// DeleteFilesWithResponse request returning *DeleteFilesResponse
+func (c *ClientWithResponses) DeleteFilesWithResponse(ctx context.Context, params *DeleteFilesParams, reqEditors ...RequestEditorFn) (*DeleteFilesResponse, error) {
+	rsp, err := c.DeleteFiles(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFilesResponse(rsp)
+}
+
+// GetFilesWithResponse request returning *GetFilesResponse
+func (c *ClientWithResponses) GetFilesWithResponse(ctx context.Context, params *GetFilesParams, reqEditors ...RequestEditorFn) (*GetFilesResponse, error) {
+	rsp, err := c.GetFiles(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesResponse(rsp)
+}
+
+// PostFilesBatchUpdateWithBodyWithResponse request with arbitrary body returning *PostFilesBatchUpdateResponse
+func (c *ClientWithResponses) PostFilesBatchUpdateWithBodyWithResponse(ctx context.Context, params *PostFilesBatchUpdateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesBatchUpdateResponse, error) {
+	rsp, err := c.PostFilesBatchUpdateWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesBatchUpdateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostFilesBatchUpdateWithResponse(ctx context.Context, params *PostFilesBatchUpdateParams, body PostFilesBatchUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesBatchUpdateResponse, error) {
+	rsp, err := c.PostFilesBatchUpdate(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesBatchUpdateResponse(rsp)
+}
+
+// GetFilesExpiringWithResponse request returning *GetFilesExpiringResponse
+func (c *ClientWithResponses) GetFilesExpiringWithResponse(ctx context.Context, params *GetFilesExpiringParams, reqEditors ...RequestEditorFn) (*GetFilesExpiringResponse, error) {
+	rsp, err := c.GetFilesExpiring(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesExpiringResponse(rsp)
+}
+
+// GetFilesExportWithResponse request returning *GetFilesExportResponse
+func (c *ClientWithResponses) GetFilesExportWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFilesExportResponse, error) {
+	rsp, err := c.GetFilesExport(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesExportResponse(rsp)
+}
+
+// GetFilesRecentWithResponse request returning *GetFilesRecentResponse
+func (c *ClientWithResponses) GetFilesRecentWithResponse(ctx context.Context, params *GetFilesRecentParams, reqEditors ...RequestEditorFn) (*GetFilesRecentResponse, error) {
+	rsp, err := c.GetFilesRecent(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesRecentResponse(rsp)
+}
+
+// GetFilesSearchWithResponse request returning *GetFilesSearchResponse
+func (c *ClientWithResponses) GetFilesSearchWithResponse(ctx context.Context, params *GetFilesSearchParams, reqEditors ...RequestEditorFn) (*GetFilesSearchResponse, error) {
+	rsp, err := c.GetFilesSearch(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesSearchResponse(rsp)
+}
+
+// GetFilesStarredWithResponse request returning *GetFilesStarredResponse
+func (c *ClientWithResponses) GetFilesStarredWithResponse(ctx context.Context, params *GetFilesStarredParams, reqEditors ...RequestEditorFn) (*GetFilesStarredResponse, error) {
+	rsp, err := c.GetFilesStarred(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesStarredResponse(rsp)
+}
+
+// DeleteFilesTrashWithResponse request returning *DeleteFilesTrashResponse
+func (c *ClientWithResponses) DeleteFilesTrashWithResponse(ctx context.Context, params *DeleteFilesTrashParams, reqEditors ...RequestEditorFn) (*DeleteFilesTrashResponse, error) {
+	rsp, err := c.DeleteFilesTrash(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFilesTrashResponse(rsp)
+}
+
+// GetFilesTrashWithResponse request returning *GetFilesTrashResponse
+func (c *ClientWithResponses) GetFilesTrashWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFilesTrashResponse, error) {
+	rsp, err := c.GetFilesTrash(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesTrashResponse(rsp)
+}
+
+// GetFilesFileIDWithResponse request returning *GetFilesFileIDResponse
+func (c *ClientWithResponses) GetFilesFileIDWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDResponse, error) {
+	rsp, err := c.GetFilesFileID(ctx, fileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesFileIDResponse(rsp)
+}
+
+// HeadFilesFileIDWithResponse request returning *HeadFilesFileIDResponse
+func (c *ClientWithResponses) HeadFilesFileIDWithResponse(ctx context.Context, fileID string, params *HeadFilesFileIDParams, reqEditors ...RequestEditorFn) (*HeadFilesFileIDResponse, error) {
+	rsp, err := c.HeadFilesFileID(ctx, fileID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHeadFilesFileIDResponse(rsp)
+}
+
+// PatchFilesFileIDWithBodyWithResponse request with arbitrary body returning *PatchFilesFileIDResponse
+func (c *ClientWithResponses) PatchFilesFileIDWithBodyWithResponse(ctx context.Context, fileID string, params *PatchFilesFileIDParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchFilesFileIDResponse, error) {
+	rsp, err := c.PatchFilesFileIDWithBody(ctx, fileID, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchFilesFileIDResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchFilesFileIDWithResponse(ctx context.Context, fileID string, params *PatchFilesFileIDParams, body PatchFilesFileIDJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchFilesFileIDResponse, error) {
+	rsp, err := c.PatchFilesFileID(ctx, fileID, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchFilesFileIDResponse(rsp)
+}
+
+// PostFilesFileIDCopyWithBodyWithResponse request with arbitrary body returning *PostFilesFileIDCopyResponse
+func (c *ClientWithResponses) PostFilesFileIDCopyWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDCopyResponse, error) {
+	rsp, err := c.PostFilesFileIDCopyWithBody(ctx, fileID, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDCopyResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostFilesFileIDCopyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDCopyParams, body PostFilesFileIDCopyJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDCopyResponse, error) {
+	rsp, err := c.PostFilesFileIDCopy(ctx, fileID, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDCopyResponse(rsp)
+}
+
+// PostFilesFileIDDownloadUrlWithBodyWithResponse request with arbitrary body returning *PostFilesFileIDDownloadUrlResponse
+func (c *ClientWithResponses) PostFilesFileIDDownloadUrlWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDDownloadUrlResponse, error) {
+	rsp, err := c.PostFilesFileIDDownloadUrlWithBody(ctx, fileID, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDDownloadUrlResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostFilesFileIDDownloadUrlWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDDownloadUrlParams, body PostFilesFileIDDownloadUrlJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDDownloadUrlResponse, error) {
+	rsp, err := c.PostFilesFileIDDownloadUrl(ctx, fileID, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDDownloadUrlResponse(rsp)
+}
+
+// PatchFilesFileIDExpiryWithBodyWithResponse request with arbitrary body returning *PatchFilesFileIDExpiryResponse
+func (c *ClientWithResponses) PatchFilesFileIDExpiryWithBodyWithResponse(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchFilesFileIDExpiryResponse, error) {
+	rsp, err := c.PatchFilesFileIDExpiryWithBody(ctx, fileID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchFilesFileIDExpiryResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchFilesFileIDExpiryWithResponse(ctx context.Context, fileID string, body PatchFilesFileIDExpiryJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchFilesFileIDExpiryResponse, error) {
+	rsp, err := c.PatchFilesFileIDExpiry(ctx, fileID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchFilesFileIDExpiryResponse(rsp)
+}
+
+// PostFilesFileIDMoveWithBodyWithResponse request with arbitrary body returning *PostFilesFileIDMoveResponse
+func (c *ClientWithResponses) PostFilesFileIDMoveWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDMoveResponse, error) {
+	rsp, err := c.PostFilesFileIDMoveWithBody(ctx, fileID, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDMoveResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostFilesFileIDMoveWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDMoveParams, body PostFilesFileIDMoveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDMoveResponse, error) {
+	rsp, err := c.PostFilesFileIDMove(ctx, fileID, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDMoveResponse(rsp)
+}
+
+// DeleteFilesFileIDPasswordWithResponse request returning *DeleteFilesFileIDPasswordResponse
+func (c *ClientWithResponses) DeleteFilesFileIDPasswordWithResponse(ctx context.Context, fileID string, params *DeleteFilesFileIDPasswordParams, reqEditors ...RequestEditorFn) (*DeleteFilesFileIDPasswordResponse, error) {
+	rsp, err := c.DeleteFilesFileIDPassword(ctx, fileID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFilesFileIDPasswordResponse(rsp)
+}
+
+// PutFilesFileIDPasswordWithBodyWithResponse request with arbitrary body returning *PutFilesFileIDPasswordResponse
+func (c *ClientWithResponses) PutFilesFileIDPasswordWithBodyWithResponse(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutFilesFileIDPasswordResponse, error) {
+	rsp, err := c.PutFilesFileIDPasswordWithBody(ctx, fileID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFilesFileIDPasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutFilesFileIDPasswordWithResponse(ctx context.Context, fileID string, body PutFilesFileIDPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PutFilesFileIDPasswordResponse, error) {
+	rsp, err := c.PutFilesFileIDPassword(ctx, fileID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutFilesFileIDPasswordResponse(rsp)
+}
+
+// GetFilesFileIDProcessingWithResponse request returning *GetFilesFileIDProcessingResponse
+func (c *ClientWithResponses) GetFilesFileIDProcessingWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDProcessingResponse, error) {
+	rsp, err := c.GetFilesFileIDProcessing(ctx, fileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesFileIDProcessingResponse(rsp)
+}
+
+// PostFilesFileIDRestoreWithResponse request returning *PostFilesFileIDRestoreResponse
+func (c *ClientWithResponses) PostFilesFileIDRestoreWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*PostFilesFileIDRestoreResponse, error) {
+	rsp, err := c.PostFilesFileIDRestore(ctx, fileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDRestoreResponse(rsp)
+}
+
+// GetFilesFileIDSharesWithResponse request returning *GetFilesFileIDSharesResponse
+func (c *ClientWithResponses) GetFilesFileIDSharesWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDSharesResponse, error) {
+	rsp, err := c.GetFilesFileIDShares(ctx, fileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesFileIDSharesResponse(rsp)
+}
+
+// PostFilesFileIDSharesWithBodyWithResponse request with arbitrary body returning *PostFilesFileIDSharesResponse
+func (c *ClientWithResponses) PostFilesFileIDSharesWithBodyWithResponse(ctx context.Context, fileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDSharesResponse, error) {
+	rsp, err := c.PostFilesFileIDSharesWithBody(ctx, fileID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDSharesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostFilesFileIDSharesWithResponse(ctx context.Context, fileID string, body PostFilesFileIDSharesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDSharesResponse, error) {
+	rsp, err := c.PostFilesFileIDShares(ctx, fileID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDSharesResponse(rsp)
+}
+
+// GetFilesFileIDSharesShareIDStatsWithResponse request returning *GetFilesFileIDSharesShareIDStatsResponse
+func (c *ClientWithResponses) GetFilesFileIDSharesShareIDStatsWithResponse(ctx context.Context, fileID string, shareID string, reqEditors ...RequestEditorFn) (*GetFilesFileIDSharesShareIDStatsResponse, error) {
+	rsp, err := c.GetFilesFileIDSharesShareIDStats(ctx, fileID, shareID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesFileIDSharesShareIDStatsResponse(rsp)
+}
+
+// DeleteFilesFileIDStarWithResponse request returning *DeleteFilesFileIDStarResponse
+func (c *ClientWithResponses) DeleteFilesFileIDStarWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*DeleteFilesFileIDStarResponse, error) {
+	rsp, err := c.DeleteFilesFileIDStar(ctx, fileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFilesFileIDStarResponse(rsp)
+}
+
+// PostFilesFileIDStarWithResponse request returning *PostFilesFileIDStarResponse
+func (c *ClientWithResponses) PostFilesFileIDStarWithResponse(ctx context.Context, fileID string, reqEditors ...RequestEditorFn) (*PostFilesFileIDStarResponse, error) {
+	rsp, err := c.PostFilesFileIDStar(ctx, fileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDStarResponse(rsp)
+}
+
+// GetFilesFileIDThumbnailWithResponse request returning *GetFilesFileIDThumbnailResponse
+func (c *ClientWithResponses) GetFilesFileIDThumbnailWithResponse(ctx context.Context, fileID string, params *GetFilesFileIDThumbnailParams, reqEditors ...RequestEditorFn) (*GetFilesFileIDThumbnailResponse, error) {
+	rsp, err := c.GetFilesFileIDThumbnail(ctx, fileID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilesFileIDThumbnailResponse(rsp)
+}
+
+// PostFilesFileIDTicketWithBodyWithResponse request with arbitrary body returning *PostFilesFileIDTicketResponse
+func (c *ClientWithResponses) PostFilesFileIDTicketWithBodyWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFilesFileIDTicketResponse, error) {
+	rsp, err := c.PostFilesFileIDTicketWithBody(ctx, fileID, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDTicketResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostFilesFileIDTicketWithResponse(ctx context.Context, fileID string, params *PostFilesFileIDTicketParams, body PostFilesFileIDTicketJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFilesFileIDTicketResponse, error) {
+	rsp, err := c.PostFilesFileIDTicket(ctx, fileID, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostFilesFileIDTicketResponse(rsp)
+}
+
+// GetPermissionsWithResponse request returning *GetPermissionsResponse
+func (c *ClientWithResponses) GetPermissionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPermissionsResponse, error) {
+	rsp, err := c.GetPermissions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPermissionsResponse(rsp)
+}
+
+// GetSTokenWithResponse request returning *GetSTokenResponse
+func (c *ClientWithResponses) GetSTokenWithResponse(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*GetSTokenResponse, error) {
+	rsp, err := c.GetSToken(ctx, token, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSTokenResponse(rsp)
+}
+
+// PostSTokenReportWithBodyWithResponse request with arbitrary body returning *PostSTokenReportResponse
+func (c *ClientWithResponses) PostSTokenReportWithBodyWithResponse(ctx context.Context, token string, params *PostSTokenReportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSTokenReportResponse, error) {
+	rsp, err := c.PostSTokenReportWithBody(ctx, token, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSTokenReportResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSTokenReportWithResponse(ctx context.Context, token string, params *PostSTokenReportParams, body PostSTokenReportJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSTokenReportResponse, error) {
+	rsp, err := c.PostSTokenReport(ctx, token, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSTokenReportResponse(rsp)
+}
+
+// DeleteSharesIdWithResponse request returning *DeleteSharesIdResponse
+func (c *ClientWithResponses) DeleteSharesIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteSharesIdResponse, error) {
+	rsp, err := c.DeleteSharesId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSharesIdResponse(rsp)
+}
+
+// PatchSharesIdWithBodyWithResponse request with arbitrary body returning *PatchSharesIdResponse
+func (c *ClientWithResponses) PatchSharesIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchSharesIdResponse, error) {
+	rsp, err := c.PatchSharesIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchSharesIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchSharesIdWithResponse(ctx context.Context, id string, body PatchSharesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchSharesIdResponse, error) {
+	rsp, err := c.PatchSharesId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchSharesIdResponse(rsp)
+}
+
+// GetStreamIdWithResponse request returning *GetStreamIdResponse
+func (c *ClientWithResponses) GetStreamIdWithResponse(ctx context.Context, id string, params *GetStreamIdParams, reqEditors ...RequestEditorFn) (*GetStreamIdResponse, error) {
+	rsp, err := c.GetStreamId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStreamIdResponse(rsp)
+}
+
+// PostUploadWithBodyWithResponse request with arbitrary body returning *PostUploadResponse
+func (c *ClientWithResponses) PostUploadWithBodyWithResponse(ctx context.Context, params *PostUploadParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUploadResponse, error) {
+	rsp, err := c.PostUploadWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUploadResponse(rsp)
+}
+
+// PostUploadPrecheckWithBodyWithResponse request with arbitrary body returning *PostUploadPrecheckResponse
+func (c *ClientWithResponses) PostUploadPrecheckWithBodyWithResponse(ctx context.Context, params *PostUploadPrecheckParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUploadPrecheckResponse, error) {
+	rsp, err := c.PostUploadPrecheckWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUploadPrecheckResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUploadPrecheckWithResponse(ctx context.Context, params *PostUploadPrecheckParams, body PostUploadPrecheckJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUploadPrecheckResponse, error) {
+	rsp, err := c.PostUploadPrecheck(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUploadPrecheckResponse(rsp)
+}
+
+// DeleteUserAvatarWithResponse request returning *DeleteUserAvatarResponse
+func (c *ClientWithResponses) DeleteUserAvatarWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteUserAvatarResponse, error) {
+	rsp, err := c.DeleteUserAvatar(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteUserAvatarResponse(rsp)
+}
+
+// PutUserAvatarWithBodyWithResponse request with arbitrary body returning *PutUserAvatarResponse
+func (c *ClientWithResponses) PutUserAvatarWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutUserAvatarResponse, error) {
+	rsp, err := c.PutUserAvatarWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutUserAvatarResponse(rsp)
+}
+
+// PatchUserPasswordWithBodyWithResponse request with arbitrary body returning *PatchUserPasswordResponse
+func (c *ClientWithResponses) PatchUserPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUserPasswordResponse, error) {
+	rsp, err := c.PatchUserPasswordWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUserPasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchUserPasswordWithResponse(ctx context.Context, body PatchUserPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUserPasswordResponse, error) {
+	rsp, err := c.PatchUserPassword(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUserPasswordResponse(rsp)
+}
+
+// GetUserPreferencesWithResponse request returning *GetUserPreferencesResponse
+func (c *ClientWithResponses) GetUserPreferencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUserPreferencesResponse, error) {
+	rsp, err := c.GetUserPreferences(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUserPreferencesResponse(rsp)
+}
+
+// PatchUserPreferencesWithBodyWithResponse request with arbitrary body returning *PatchUserPreferencesResponse
+func (c *ClientWithResponses) PatchUserPreferencesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUserPreferencesResponse, error) {
+	rsp, err := c.PatchUserPreferencesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUserPreferencesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchUserPreferencesWithResponse(ctx context.Context, body PatchUserPreferencesJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUserPreferencesResponse, error) {
+	rsp, err := c.PatchUserPreferences(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUserPreferencesResponse(rsp)
+}
+
+// PatchUserProfileWithBodyWithResponse request with arbitrary body returning *PatchUserProfileResponse
+func (c *ClientWithResponses) PatchUserProfileWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUserProfileResponse, error) {
+	rsp, err := c.PatchUserProfileWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUserProfileResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchUserProfileWithResponse(ctx context.Context, body PatchUserProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUserProfileResponse, error) {
+	rsp, err := c.PatchUserProfile(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUserProfileResponse(rsp)
+}
+
+// PostUserProfileVerifyEmailWithBodyWithResponse request with arbitrary body returning *PostUserProfileVerifyEmailResponse
+func (c *ClientWithResponses) PostUserProfileVerifyEmailWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserProfileVerifyEmailResponse, error) {
+	rsp, err := c.PostUserProfileVerifyEmailWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUserProfileVerifyEmailResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUserProfileVerifyEmailWithResponse(ctx context.Context, body PostUserProfileVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserProfileVerifyEmailResponse, error) {
+	rsp, err := c.PostUserProfileVerifyEmail(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUserProfileVerifyEmailResponse(rsp)
+}
+
+// GetUserTransferWithResponse request returning *GetUserTransferResponse
+func (c *ClientWithResponses) GetUserTransferWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUserTransferResponse, error) {
+	rsp, err := c.GetUserTransfer(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUserTransferResponse(rsp)
+}
+
+// GetUsersIdAvatarWithResponse request returning *GetUsersIdAvatarResponse
+func (c *ClientWithResponses) GetUsersIdAvatarWithResponse(ctx context.Context, id string, params *GetUsersIdAvatarParams, reqEditors ...RequestEditorFn) (*GetUsersIdAvatarResponse, error) {
+	rsp, err := c.GetUsersIdAvatar(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersIdAvatarResponse(rsp)
+}
+
+// GetVersionWithResponse request returning *GetVersionResponse
+func (c *ClientWithResponses) GetVersionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVersionResponse, error) {
+	rsp, err := c.GetVersion(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVersionResponse(rsp)
+}
+
+// ParseGetAdminAnnouncementsResponse parses an HTTP response from a GetAdminAnnouncementsWithResponse call
+func ParseGetAdminAnnouncementsResponse(rsp *http.Response) (*GetAdminAnnouncementsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminAnnouncementsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Announcement
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminAnnouncementsResponse parses an HTTP response from a PostAdminAnnouncementsWithResponse call
+func ParsePostAdminAnnouncementsResponse(rsp *http.Response) (*PostAdminAnnouncementsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminAnnouncementsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Announcement
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAdminAnnouncementsIdResponse parses an HTTP response from a DeleteAdminAnnouncementsIdWithResponse call
+func ParseDeleteAdminAnnouncementsIdResponse(rsp *http.Response) (*DeleteAdminAnnouncementsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminAnnouncementsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminFilesResponse parses an HTTP response from a GetAdminFilesWithResponse call
+func ParseGetAdminFilesResponse(rsp *http.Response) (*GetAdminFilesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminFilesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminFileList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAdminFilesIdResponse parses an HTTP response from a DeleteAdminFilesIdWithResponse call
+func ParseDeleteAdminFilesIdResponse(rsp *http.Response) (*DeleteAdminFilesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminFilesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminFilesIdReencryptResponse parses an HTTP response from a PostAdminFilesIdReencryptWithResponse call
+func ParsePostAdminFilesIdReencryptResponse(rsp *http.Response) (*PostAdminFilesIdReencryptResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminFilesIdReencryptResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminIpAccessResponse parses an HTTP response from a GetAdminIpAccessWithResponse call
+func ParseGetAdminIpAccessResponse(rsp *http.Response) (*GetAdminIpAccessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminIpAccessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IPAccessRules
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAdminIpAccessResponse parses an HTTP response from a PutAdminIpAccessWithResponse call
+func ParsePutAdminIpAccessResponse(rsp *http.Response) (*PutAdminIpAccessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAdminIpAccessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IPAccessRules
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminJobsResponse parses an HTTP response from a GetAdminJobsWithResponse call
+func ParseGetAdminJobsResponse(rsp *http.Response) (*GetAdminJobsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminJobsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Counts *map[string]int `json:"counts,omitempty"`
+			Jobs   *[]Job          `json:"jobs,omitempty"`
+			Limit  *int            `json:"limit,omitempty"`
+
+			// NextCursor Cursor of the next page; only set when more jobs follow
+			NextCursor *string `json:"next_cursor,omitempty"`
+			Offset     *int    `json:"offset,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminJobsIdRetryResponse parses an HTTP response from a PostAdminJobsIdRetryWithResponse call
+func ParsePostAdminJobsIdRetryResponse(rsp *http.Response) (*PostAdminJobsIdRetryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminJobsIdRetryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminKeysRotateResponse parses an HTTP response from a PostAdminKeysRotateWithResponse call
+func ParsePostAdminKeysRotateResponse(rsp *http.Response) (*PostAdminKeysRotateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminKeysRotateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest KeyRotation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminKeysRotationResponse parses an HTTP response from a GetAdminKeysRotationWithResponse call
+func ParseGetAdminKeysRotationResponse(rsp *http.Response) (*GetAdminKeysRotationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminKeysRotationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			CurrentKekId *string      `json:"current_kek_id,omitempty"`
+			Rotation     *KeyRotation `json:"rotation,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminLogsResponse parses an HTTP response from a GetAdminLogsWithResponse call
+func ParseGetAdminLogsResponse(rsp *http.Response) (*GetAdminLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count *int `json:"count,omitempty"`
+			Limit *int `json:"limit,omitempty"`
+			Logs  *[]struct {
+				Action *string `json:"action,omitempty"`
+
+				// ActorId Empty for anonymous actions
+				ActorId       *string                       `json:"actor_id,omitempty"`
+				ActorType     *GetAdminLogs200LogsActorType `json:"actor_type,omitempty"`
+				ActorUsername *string                       `json:"actor_username,omitempty"`
+				CreatedAt     *string                       `json:"created_at,omitempty"`
+				Id            *string                       `json:"id,omitempty"`
+
+				// IpAddress An optional string as the audit log encodes it; String is only meaningful when Valid
+				IpAddress *NullString `json:"ip_address,omitempty"`
+
+				// Metadata Action details, a base64-encoded JSON object
+				Metadata *[]byte `json:"metadata,omitempty"`
+
+				// TargetId An optional string as the audit log encodes it; String is only meaningful when Valid
+				TargetId *NullString `json:"target_id,omitempty"`
+
+				// TargetType An optional string as the audit log encodes it; String is only meaningful when Valid
+				TargetType *NullString `json:"target_type,omitempty"`
+			} `json:"logs,omitempty"`
+
+			// NextCursor Cursor of the next page; only set when more entries follow
+			NextCursor *string `json:"next_cursor,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminMaintenanceResponse parses an HTTP response from a GetAdminMaintenanceWithResponse call
+func ParseGetAdminMaintenanceResponse(rsp *http.Response) (*GetAdminMaintenanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminMaintenanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MaintenanceState
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAdminMaintenanceResponse parses an HTTP response from a PutAdminMaintenanceWithResponse call
+func ParsePutAdminMaintenanceResponse(rsp *http.Response) (*PutAdminMaintenanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAdminMaintenanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MaintenanceState
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminNotificationsResponse parses an HTTP response from a GetAdminNotificationsWithResponse call
+func ParseGetAdminNotificationsResponse(rsp *http.Response) (*GetAdminNotificationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminNotificationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Channels *[]NotificationChannel `json:"channels,omitempty"`
+			Events   *[]string              `json:"events,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminNotificationsChannelTestResponse parses an HTTP response from a PostAdminNotificationsChannelTestWithResponse call
+func ParsePostAdminNotificationsChannelTestResponse(rsp *http.Response) (*PostAdminNotificationsChannelTestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminNotificationsChannelTestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest struct {
+			JobId   *openapi_types.UUID `json:"job_id,omitempty"`
+			Message *string             `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminQuarantineResponse parses an HTTP response from a GetAdminQuarantineWithResponse call
+func ParseGetAdminQuarantineResponse(rsp *http.Response) (*GetAdminQuarantineResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminQuarantineResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Files *[]QuarantinedFile `json:"files,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminQuarantineIdApproveResponse parses an HTTP response from a PostAdminQuarantineIdApproveWithResponse call
+func ParsePostAdminQuarantineIdApproveResponse(rsp *http.Response) (*PostAdminQuarantineIdApproveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminQuarantineIdApproveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminQuarantineIdRejectResponse parses an HTTP response from a PostAdminQuarantineIdRejectWithResponse call
+func ParsePostAdminQuarantineIdRejectResponse(rsp *http.Response) (*PostAdminQuarantineIdRejectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminQuarantineIdRejectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminReadOnlyResponse parses an HTTP response from a GetAdminReadOnlyWithResponse call
+func ParseGetAdminReadOnlyResponse(rsp *http.Response) (*GetAdminReadOnlyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminReadOnlyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReadOnlyState
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAdminReadOnlyResponse parses an HTTP response from a PutAdminReadOnlyWithResponse call
+func ParsePutAdminReadOnlyResponse(rsp *http.Response) (*PutAdminReadOnlyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAdminReadOnlyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReadOnlyState
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminReplicationRemotesResponse parses an HTTP response from a GetAdminReplicationRemotesWithResponse call
+func ParseGetAdminReplicationRemotesResponse(rsp *http.Response) (*GetAdminReplicationRemotesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminReplicationRemotesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Remotes *[]ReplicationRemote `json:"remotes,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminReplicationRemotesResponse parses an HTTP response from a PostAdminReplicationRemotesWithResponse call
+func ParsePostAdminReplicationRemotesResponse(rsp *http.Response) (*PostAdminReplicationRemotesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminReplicationRemotesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ReplicationRemote
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAdminReplicationRemotesIdResponse parses an HTTP response from a DeleteAdminReplicationRemotesIdWithResponse call
+func ParseDeleteAdminReplicationRemotesIdResponse(rsp *http.Response) (*DeleteAdminReplicationRemotesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminReplicationRemotesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminReplicationRemotesIdSyncResponse parses an HTTP response from a PostAdminReplicationRemotesIdSyncWithResponse call
+func ParsePostAdminReplicationRemotesIdSyncResponse(rsp *http.Response) (*PostAdminReplicationRemotesIdSyncResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminReplicationRemotesIdSyncResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+
+			// Queued Files queued; 0 when the remote has them all
+			Queued *int `json:"queued,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminReportsResponse parses an HTTP response from a GetAdminReportsWithResponse call
+func ParseGetAdminReportsResponse(rsp *http.Response) (*GetAdminReportsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminReportsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Reports *[]ShareReport `json:"reports,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminReportsIdResolveResponse parses an HTTP response from a PostAdminReportsIdResolveWithResponse call
+func ParsePostAdminReportsIdResolveResponse(rsp *http.Response) (*PostAdminReportsIdResolveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminReportsIdResolveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message       *string                             `json:"message,omitempty"`
+			ReportsClosed *int                                `json:"reports_closed,omitempty"`
+			Status        *PostAdminReportsIdResolve200Status `json:"status,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminServiceAccountsResponse parses an HTTP response from a GetAdminServiceAccountsWithResponse call
+func ParseGetAdminServiceAccountsResponse(rsp *http.Response) (*GetAdminServiceAccountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminServiceAccountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			ServiceAccounts *[]ServiceAccount `json:"service_accounts,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminServiceAccountsResponse parses an HTTP response from a PostAdminServiceAccountsWithResponse call
+func ParsePostAdminServiceAccountsResponse(rsp *http.Response) (*PostAdminServiceAccountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminServiceAccountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ServiceAccount
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAdminServiceAccountsIdResponse parses an HTTP response from a DeleteAdminServiceAccountsIdWithResponse call
+func ParseDeleteAdminServiceAccountsIdResponse(rsp *http.Response) (*DeleteAdminServiceAccountsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminServiceAccountsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminServiceAccountsIdKeysResponse parses an HTTP response from a GetAdminServiceAccountsIdKeysWithResponse call
+func ParseGetAdminServiceAccountsIdKeysResponse(rsp *http.Response) (*GetAdminServiceAccountsIdKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminServiceAccountsIdKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Keys *[]ServiceAccountKey `json:"keys,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminServiceAccountsIdKeysResponse parses an HTTP response from a PostAdminServiceAccountsIdKeysWithResponse call
+func ParsePostAdminServiceAccountsIdKeysResponse(rsp *http.Response) (*PostAdminServiceAccountsIdKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminServiceAccountsIdKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			ApiKey *string            `json:"api_key,omitempty"`
+			Key    *ServiceAccountKey `json:"key,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAdminServiceAccountsIdKeysKeyIDResponse parses an HTTP response from a DeleteAdminServiceAccountsIdKeysKeyIDWithResponse call
+func ParseDeleteAdminServiceAccountsIdKeysKeyIDResponse(rsp *http.Response) (*DeleteAdminServiceAccountsIdKeysKeyIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminServiceAccountsIdKeysKeyIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminSessionsResponse parses an HTTP response from a GetAdminSessionsWithResponse call
+func ParseGetAdminSessionsResponse(rsp *http.Response) (*GetAdminSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			OrphanedSessions *int `json:"orphaned_sessions,omitempty"`
+
+			// SigningKey ID of the key new tokens are signed with
+			SigningKey    *string `json:"signing_key,omitempty"`
+			TotalSessions *int    `json:"total_sessions,omitempty"`
+			Users         *[]struct {
+				Orphaned *bool                           `json:"orphaned,omitempty"`
+				Reason   *GetAdminSessions200UsersReason `json:"reason,omitempty"`
+				Sessions *[]struct {
+					ExpiresAt *time.Time `json:"expires_at,omitempty"`
+					Id        *string    `json:"id,omitempty"`
+				} `json:"sessions,omitempty"`
+				UserId   *string `json:"user_id,omitempty"`
+				Username *string `json:"username,omitempty"`
+			} `json:"users,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminSessionsRevokeAllResponse parses an HTTP response from a PostAdminSessionsRevokeAllWithResponse call
+func ParsePostAdminSessionsRevokeAllResponse(rsp *http.Response) (*PostAdminSessionsRevokeAllResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminSessionsRevokeAllResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			KeyRotated      *bool   `json:"key_rotated,omitempty"`
+			Message         *string `json:"message,omitempty"`
+			SessionsRevoked *int    `json:"sessions_revoked,omitempty"`
+			SigningKey      *string `json:"signing_key,omitempty"`
+			Warning         *string `json:"warning,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminSettingsResponse parses an HTTP response from a GetAdminSettingsWithResponse call
+func ParseGetAdminSettingsResponse(rsp *http.Response) (*GetAdminSettingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			DefaultFileExpiry *int                                 `json:"default_file_expiry,omitempty"`
+			MaxUploadSize     *int                                 `json:"max_upload_size,omitempty"`
+			RegistrationMode  *GetAdminSettings200RegistrationMode `json:"registration_mode,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchAdminSettingsResponse parses an HTTP response from a PatchAdminSettingsWithResponse call
+func ParsePatchAdminSettingsResponse(rsp *http.Response) (*PatchAdminSettingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchAdminSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminSigningKeysResponse parses an HTTP response from a GetAdminSigningKeysWithResponse call
+func ParseGetAdminSigningKeysResponse(rsp *http.Response) (*GetAdminSigningKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminSigningKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SigningKeys
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminSigningKeysRotateResponse parses an HTTP response from a PostAdminSigningKeysRotateWithResponse call
+func ParsePostAdminSigningKeysRotateResponse(rsp *http.Response) (*PostAdminSigningKeysRotateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminSigningKeysRotateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SigningKeys
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminSnapshotsResponse parses an HTTP response from a GetAdminSnapshotsWithResponse call
+func ParseGetAdminSnapshotsResponse(rsp *http.Response) (*GetAdminSnapshotsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminSnapshotsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Snapshots *[]Snapshot `json:"snapshots,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminSnapshotsResponse parses an HTTP response from a PostAdminSnapshotsWithResponse call
+func ParsePostAdminSnapshotsResponse(rsp *http.Response) (*PostAdminSnapshotsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminSnapshotsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Snapshot
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminSnapshotsIdRestorableResponse parses an HTTP response from a GetAdminSnapshotsIdRestorableWithResponse call
+func ParseGetAdminSnapshotsIdRestorableResponse(rsp *http.Response) (*GetAdminSnapshotsIdRestorableResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminSnapshotsIdRestorableResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Files *[]struct {
+				CreatedAt *time.Time `json:"created_at,omitempty"`
+
+				// DeletedAt Set if the file was in the trash when the snapshot was taken
+				DeletedAt    *time.Time          `json:"deleted_at,omitempty"`
+				FileId       *openapi_types.UUID `json:"file_id,omitempty"`
+				FileName     *string             `json:"file_name,omitempty"`
+				ObjectExists *bool               `json:"object_exists,omitempty"`
+				OwnerExists  *bool               `json:"owner_exists,omitempty"`
+				Size         *int64              `json:"size,omitempty"`
+				UserId       *openapi_types.UUID `json:"user_id,omitempty"`
+			} `json:"files,omitempty"`
+
+			// Snapshot A metadata snapshot
+			Snapshot *Snapshot `json:"snapshot,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminSnapshotsIdRestoreResponse parses an HTTP response from a PostAdminSnapshotsIdRestoreWithResponse call
+func ParsePostAdminSnapshotsIdRestoreResponse(rsp *http.Response) (*PostAdminSnapshotsIdRestoreResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminSnapshotsIdRestoreResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Failed   *map[string]string `json:"failed,omitempty"`
+			Restored *[]string          `json:"restored,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminStatsResponse parses an HTTP response from a GetAdminStatsWithResponse call
+func ParseGetAdminStatsResponse(rsp *http.Response) (*GetAdminStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			ActiveSessions *int `json:"active_sessions,omitempty"`
+
+			// ActiveUsers24h Users who uploaded or downloaded in the last 24 hours
+			ActiveUsers24h *int `json:"active_users_24h,omitempty"`
+
+			// Database Database queries run by this replica since it started
+			Database *struct {
+				// ByKind Keyed by select, insert, update, delete or other
+				ByKind *map[string]struct {
+					Count           *int     `json:"count,omitempty"`
+					Errors          *int     `json:"errors,omitempty"`
+					MaxDurationMs   *float32 `json:"max_duration_ms,omitempty"`
+					TotalDurationMs *float32 `json:"total_duration_ms,omitempty"`
+				} `json:"by_kind,omitempty"`
+				Errors  *int `json:"errors,omitempty"`
+				Queries *int `json:"queries,omitempty"`
+
+				// SlowQueries Queries at or above storage.database.slow_query_threshold
+				SlowQueries *int `json:"slow_queries,omitempty"`
+			} `json:"database,omitempty"`
+
+			// DatabasePool Postgres connection pool of this replica
+			DatabasePool *struct {
+				Idle              *int `json:"idle,omitempty"`
+				InUse             *int `json:"in_use,omitempty"`
+				MaxIdleClosed     *int `json:"max_idle_closed,omitempty"`
+				MaxLifetimeClosed *int `json:"max_lifetime_closed,omitempty"`
+				MaxOpenConns      *int `json:"max_open_conns,omitempty"`
+				OpenConns         *int `json:"open_conns,omitempty"`
+
+				// WaitCount Queries that had to wait for a free connection
+				WaitCount      *int     `json:"wait_count,omitempty"`
+				WaitDurationMs *float32 `json:"wait_duration_ms,omitempty"`
+			} `json:"database_pool,omitempty"`
+			DownloadBytes24h *int64 `json:"download_bytes_24h,omitempty"`
+			Downloads24h     *int   `json:"downloads_24h,omitempty"`
+
+			// RedisPool Redis connection pool of this replica
+			RedisPool *struct {
+				Hits       *int `json:"hits,omitempty"`
+				IdleConns  *int `json:"idle_conns,omitempty"`
+				Misses     *int `json:"misses,omitempty"`
+				StaleConns *int `json:"stale_conns,omitempty"`
+				Timeouts   *int `json:"timeouts,omitempty"`
+				TotalConns *int `json:"total_conns,omitempty"`
+			} `json:"redis_pool,omitempty"`
+
+			// StorageAlerts Usage against the thresholds of features.storage_alerts, measured when requested;
+			// omitted when no threshold is configured. alerts are the thresholds the monitor
+			// found exceeded and has reported.
+			StorageAlerts *StorageAlertStatus `json:"storage_alerts,omitempty"`
+
+			// StorageBreaker Circuit breaker in front of MinIO (storage.minio.breaker), omitted when it is off.
+			// While `open`, transfers get 503 with Retry-After and GET /health/ready answers 503.
+			StorageBreaker    *StorageBreaker `json:"storage_breaker,omitempty"`
+			TotalFiles        *int            `json:"total_files,omitempty"`
+			TotalStorageBytes *int            `json:"total_storage_bytes,omitempty"`
+			TotalUsers        *int            `json:"total_users,omitempty"`
+
+			// UploadCapacity Estimated room for uploads (features.uploads.space), omitted when nothing is
+			// checked: free bytes of the temp filesystem, and the storage capacity minus the
+			// stored files. While `low`, uploads get 507.
+			UploadCapacity *UploadCapacity `json:"upload_capacity,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminStatsContentTypesResponse parses an HTTP response from a GetAdminStatsContentTypesWithResponse call
+func ParseGetAdminStatsContentTypesResponse(rsp *http.Response) (*GetAdminStatsContentTypesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminStatsContentTypesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContentStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminStorageAnalyzeResponse parses an HTTP response from a GetAdminStorageAnalyzeWithResponse call
+func ParseGetAdminStorageAnalyzeResponse(rsp *http.Response) (*GetAdminStorageAnalyzeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminStorageAnalyzeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			ExpiredFiles   *int `json:"expired_files,omitempty"`
+			OrphanedFiles  *int `json:"orphaned_files,omitempty"`
+			TotalFiles     *int `json:"total_files,omitempty"`
+			TotalSizeBytes *int `json:"total_size_bytes,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminStorageCleanupResponse parses an HTTP response from a PostAdminStorageCleanupWithResponse call
+func ParsePostAdminStorageCleanupResponse(rsp *http.Response) (*PostAdminStorageCleanupResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminStorageCleanupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			FilesDeleted    *int    `json:"files_deleted,omitempty"`
+			Message         *string `json:"message,omitempty"`
+			SpaceFreedBytes *int    `json:"space_freed_bytes,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminStorageReplicaResponse parses an HTTP response from a GetAdminStorageReplicaWithResponse call
+func ParseGetAdminStorageReplicaResponse(rsp *http.Response) (*GetAdminStorageReplicaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminStorageReplicaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Enabled  *bool   `json:"enabled,omitempty"`
+			Endpoint *string `json:"endpoint,omitempty"`
+
+			// Failed Replications out of attempts (dead jobs); reconcile to catch up
+			Failed *int `json:"failed,omitempty"`
+
+			// LagSeconds Age of the oldest pending replication; 0 when in sync
+			LagSeconds       *float32   `json:"lag_seconds,omitempty"`
+			LastReplicatedAt *time.Time `json:"last_replicated_at,omitempty"`
+			OldestPendingAt  *time.Time `json:"oldest_pending_at,omitempty"`
+
+			// Pending Objects waiting to be replicated
+			Pending *int `json:"pending,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminStorageReplicaReconcileResponse parses an HTTP response from a PostAdminStorageReplicaReconcileWithResponse call
+func ParsePostAdminStorageReplicaReconcileResponse(rsp *http.Response) (*PostAdminStorageReplicaReconcileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminStorageReplicaReconcileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Different Paths whose size differs
+			Different *[]string `json:"different,omitempty"`
+			DryRun    *bool     `json:"dry_run,omitempty"`
+
+			// Extra Paths only on the standby
+			Extra *[]string `json:"extra,omitempty"`
+
+			// Missing Paths only on the primary
+			Missing        *[]string `json:"missing,omitempty"`
+			PrimaryObjects *int      `json:"primary_objects,omitempty"`
+			Queued         *int      `json:"queued,omitempty"`
+			ReplicaObjects *int      `json:"replica_objects,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsageResponse parses an HTTP response from a GetAdminUsageWithResponse call
+func ParseGetAdminUsageResponse(rsp *http.Response) (*GetAdminUsageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Finished *bool          `json:"finished,omitempty"`
+			Month    *string        `json:"month,omitempty"`
+			Records  *[]UsageRecord `json:"records,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsageReportsPeriodResponse parses an HTTP response from a GetAdminUsageReportsPeriodWithResponse call
+func ParseGetAdminUsageReportsPeriodResponse(rsp *http.Response) (*GetAdminUsageReportsPeriodResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsageReportsPeriodResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Report *UsageReport `json:"report,omitempty"`
+			Text   *string      `json:"text,omitempty"`
+			Title  *string      `json:"title,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminUsageReportsPeriodSendResponse parses an HTTP response from a PostAdminUsageReportsPeriodSendWithResponse call
+func ParsePostAdminUsageReportsPeriodSendResponse(rsp *http.Response) (*PostAdminUsageReportsPeriodSendResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminUsageReportsPeriodSendResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest struct {
+			JobId   *openapi_types.UUID `json:"job_id,omitempty"`
+			Message *string             `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsageExportResponse parses an HTTP response from a GetAdminUsageExportWithResponse call
+func ParseGetAdminUsageExportResponse(rsp *http.Response) (*GetAdminUsageExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsageExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminUsageSendResponse parses an HTTP response from a PostAdminUsageSendWithResponse call
+func ParsePostAdminUsageSendResponse(rsp *http.Response) (*PostAdminUsageSendResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminUsageSendResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest struct {
+			JobId   *openapi_types.UUID `json:"job_id,omitempty"`
+			Message *string             `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsersResponse parses an HTTP response from a GetAdminUsersWithResponse call
+func ParseGetAdminUsersResponse(rsp *http.Response) (*GetAdminUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Limit *int        `json:"limit,omitempty"`
+			Page  *int        `json:"page,omitempty"`
+			Total *int        `json:"total,omitempty"`
+			Users *[]UserInfo `json:"users,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsersPendingResponse parses an HTTP response from a GetAdminUsersPendingWithResponse call
+func ParseGetAdminUsersPendingResponse(rsp *http.Response) (*GetAdminUsersPendingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsersPendingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count *int `json:"count,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAdminUsersIdResponse parses an HTTP response from a DeleteAdminUsersIdWithResponse call
+func ParseDeleteAdminUsersIdResponse(rsp *http.Response) (*DeleteAdminUsersIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminUsersIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminUsersIdApproveResponse parses an HTTP response from a PostAdminUsersIdApproveWithResponse call
+func ParsePostAdminUsersIdApproveResponse(rsp *http.Response) (*PostAdminUsersIdApproveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminUsersIdApproveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsersIdFilesResponse parses an HTTP response from a GetAdminUsersIdFilesWithResponse call
+func ParseGetAdminUsersIdFilesResponse(rsp *http.Response) (*GetAdminUsersIdFilesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsersIdFilesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminFileList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminUsersIdLogoutResponse parses an HTTP response from a PostAdminUsersIdLogoutWithResponse call
+func ParsePostAdminUsersIdLogoutResponse(rsp *http.Response) (*PostAdminUsersIdLogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminUsersIdLogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchAdminUsersIdProfileResponse parses an HTTP response from a PatchAdminUsersIdProfileWithResponse call
+func ParsePatchAdminUsersIdProfileResponse(rsp *http.Response) (*PatchAdminUsersIdProfileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchAdminUsersIdProfileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Profile
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAdminUsersIdReadOnlyResponse parses an HTTP response from a PutAdminUsersIdReadOnlyWithResponse call
+func ParsePutAdminUsersIdReadOnlyResponse(rsp *http.Response) (*PutAdminUsersIdReadOnlyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAdminUsersIdReadOnlyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message  *string `json:"message,omitempty"`
+			ReadOnly *bool   `json:"read_only,omitempty"`
+			UserId   *string `json:"user_id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminUsersIdRejectResponse parses an HTTP response from a PostAdminUsersIdRejectWithResponse call
+func ParsePostAdminUsersIdRejectResponse(rsp *http.Response) (*PostAdminUsersIdRejectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminUsersIdRejectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminUsersIdResetPasswordResponse parses an HTTP response from a PostAdminUsersIdResetPasswordWithResponse call
+func ParsePostAdminUsersIdResetPasswordResponse(rsp *http.Response) (*PostAdminUsersIdResetPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminUsersIdResetPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message           *string `json:"message,omitempty"`
+			TemporaryPassword *string `json:"temporary_password,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchAdminUsersIdRoleResponse parses an HTTP response from a PatchAdminUsersIdRoleWithResponse call
+func ParsePatchAdminUsersIdRoleResponse(rsp *http.Response) (*PatchAdminUsersIdRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchAdminUsersIdRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchAdminUsersIdStatusResponse parses an HTTP response from a PatchAdminUsersIdStatusWithResponse call
+func ParsePatchAdminUsersIdStatusResponse(rsp *http.Response) (*PatchAdminUsersIdStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchAdminUsersIdStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsersIdStorageResponse parses an HTTP response from a GetAdminUsersIdStorageWithResponse call
+func ParseGetAdminUsersIdStorageResponse(rsp *http.Response) (*GetAdminUsersIdStorageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsersIdStorageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UserStorageReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminUsersIdTransferFilesResponse parses an HTTP response from a PostAdminUsersIdTransferFilesWithResponse call
+func ParsePostAdminUsersIdTransferFilesResponse(rsp *http.Response) (*PostAdminUsersIdTransferFilesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminUsersIdTransferFilesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			FileIds     *[]string `json:"file_ids,omitempty"`
+			Message     *string   `json:"message,omitempty"`
+			ToUserId    *string   `json:"to_user_id,omitempty"`
+			Transferred *int      `json:"transferred,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAnnouncementsResponse parses an HTTP response from a GetAnnouncementsWithResponse call
+func ParseGetAnnouncementsResponse(rsp *http.Response) (*GetAnnouncementsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAnnouncementsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Announcement
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAnnouncementsIdDismissResponse parses an HTTP response from a PostAnnouncementsIdDismissWithResponse call
+func ParsePostAnnouncementsIdDismissResponse(rsp *http.Response) (*PostAnnouncementsIdDismissResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAnnouncementsIdDismissResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthLoginResponse parses an HTTP response from a PostAuthLoginWithResponse call
+func ParsePostAuthLoginResponse(rsp *http.Response) (*PostAuthLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthLogoutResponse parses an HTTP response from a PostAuthLogoutWithResponse call
+func ParsePostAuthLogoutResponse(rsp *http.Response) (*PostAuthLogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthLogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAuthMeResponse parses an HTTP response from a GetAuthMeWithResponse call
+func ParseGetAuthMeResponse(rsp *http.Response) (*GetAuthMeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuthMeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UserInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthRefreshResponse parses an HTTP response from a PostAuthRefreshWithResponse call
+func ParsePostAuthRefreshResponse(rsp *http.Response) (*PostAuthRefreshResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthRefreshResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthRegisterResponse parses an HTTP response from a PostAuthRegisterWithResponse call
+func ParsePostAuthRegisterResponse(rsp *http.Response) (*PostAuthRegisterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthRegisterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest AuthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthRevokeResponse parses an HTTP response from a PostAuthRevokeWithResponse call
+func ParsePostAuthRevokeResponse(rsp *http.Response) (*PostAuthRevokeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthRevokeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAuthTokensResponse parses an HTTP response from a GetAuthTokensWithResponse call
+func ParseGetAuthTokensResponse(rsp *http.Response) (*GetAuthTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuthTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+			ExpiresAt *time.Time `json:"expires_at"`
+			Id        *string    `json:"id,omitempty"`
+			LastUsed  *time.Time `json:"last_used"`
+			Name      *string    `json:"name,omitempty"`
+			Scopes    *[]string  `json:"scopes,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthTokensResponse parses an HTTP response from a PostAuthTokensWithResponse call
+func ParsePostAuthTokensResponse(rsp *http.Response) (*PostAuthTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			ExpiresAt *time.Time `json:"expires_at"`
+			Name      *string    `json:"name,omitempty"`
+			Scopes    *[]string  `json:"scopes,omitempty"`
+			Token     *string    `json:"token,omitempty"`
+			TokenId   *string    `json:"token_id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAuthTokensIdResponse parses an HTTP response from a DeleteAuthTokensIdWithResponse call
+func ParseDeleteAuthTokensIdResponse(rsp *http.Response) (*DeleteAuthTokensIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAuthTokensIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDlTokenResponse parses an HTTP response from a GetDlTokenWithResponse call
+func ParseGetDlTokenResponse(rsp *http.Response) (*GetDlTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDlTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDocsOpenapiYamlResponse parses an HTTP response from a GetDocsOpenapiYamlWithResponse call
+func ParseGetDocsOpenapiYamlResponse(rsp *http.Response) (*GetDocsOpenapiYamlResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDocsOpenapiYamlResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "yaml") && rsp.StatusCode == 200:
+		var dest string
+		if err := yaml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.YAML200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDownloadIdResponse parses an HTTP response from a GetDownloadIdWithResponse call
+func ParseGetDownloadIdResponse(rsp *http.Response) (*GetDownloadIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDownloadIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFilesResponse parses an HTTP response from a DeleteFilesWithResponse call
+func ParseDeleteFilesResponse(rsp *http.Response) (*DeleteFilesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFilesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			FileId  *string `json:"file_id,omitempty"`
+			Message *string `json:"message,omitempty"`
+
+			// PurgeAt When the file will be deleted permanently (trash only)
+			PurgeAt *time.Time `json:"purge_at,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesResponse parses an HTTP response from a GetFilesWithResponse call
+func ParseGetFilesResponse(rsp *http.Response) (*GetFilesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FileListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesBatchUpdateResponse parses an HTTP response from a PostFilesBatchUpdateWithResponse call
+func ParsePostFilesBatchUpdateResponse(rsp *http.Response) (*PostFilesBatchUpdateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesBatchUpdateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Failed  *int `json:"failed,omitempty"`
+			Results *[]struct {
+				Error  *string       `json:"error,omitempty"`
+				File   *FileMetadata `json:"file,omitempty"`
+				FileId *string       `json:"file_id,omitempty"`
+				Ok     *bool         `json:"ok,omitempty"`
+			} `json:"results,omitempty"`
+			Updated *int `json:"updated,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesExpiringResponse parses an HTTP response from a GetFilesExpiringWithResponse call
+func ParseGetFilesExpiringResponse(rsp *http.Response) (*GetFilesExpiringResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesExpiringResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count  *int            `json:"count,omitempty"`
+			Files  *[]FileMetadata `json:"files,omitempty"`
+			Within *string         `json:"within,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesExportResponse parses an HTTP response from a GetFilesExportWithResponse call
+func ParseGetFilesExportResponse(rsp *http.Response) (*GetFilesExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesRecentResponse parses an HTTP response from a GetFilesRecentWithResponse call
+func ParseGetFilesRecentResponse(rsp *http.Response) (*GetFilesRecentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesRecentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FileListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesSearchResponse parses an HTTP response from a GetFilesSearchWithResponse call
+func ParseGetFilesSearchResponse(rsp *http.Response) (*GetFilesSearchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesSearchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count *int            `json:"count,omitempty"`
+			Files *[]FileMetadata `json:"files,omitempty"`
+
+			// NextCursor Cursor of the next page; only set when more results follow
+			NextCursor *string `json:"next_cursor,omitempty"`
+			Query      *string `json:"query,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesStarredResponse parses an HTTP response from a GetFilesStarredWithResponse call
+func ParseGetFilesStarredResponse(rsp *http.Response) (*GetFilesStarredResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesStarredResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FileListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFilesTrashResponse parses an HTTP response from a DeleteFilesTrashWithResponse call
+func ParseDeleteFilesTrashResponse(rsp *http.Response) (*DeleteFilesTrashResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFilesTrashResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Deleted    *int    `json:"deleted,omitempty"`
+			FreedBytes *int64  `json:"freed_bytes,omitempty"`
+			Message    *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesTrashResponse parses an HTTP response from a GetFilesTrashWithResponse call
+func ParseGetFilesTrashResponse(rsp *http.Response) (*GetFilesTrashResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesTrashResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count *int `json:"count,omitempty"`
+			Files *[]struct {
+				// CreatedAt File upload timestamp
+				CreatedAt   time.Time  `json:"created_at"`
+				DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+				Description *string    `json:"description,omitempty"`
+
+				// DownloadCount Number of times file has been downloaded
+				DownloadCount int `json:"download_count"`
+
+				// ExpiresAt Expiration timestamp (null if no expiration)
+				ExpiresAt *time.Time `json:"expires_at"`
+
+				// FileId Unique file identifier
+				FileId string `json:"file_id"`
+
+				// FileName Original filename
+				FileName string `json:"file_name"`
+
+				// Folder Virtual folder path ("/" is the root)
+				Folder *string `json:"folder,omitempty"`
+
+				// LastDownloadedAt When the file was last downloaded
+				LastDownloadedAt *time.Time `json:"last_downloaded_at"`
+
+				// MimeType MIME type of the file
+				MimeType string `json:"mime_type"`
+
+				// ModifiedAt Modification time set by the client on upload or with PATCH (missing if never set)
+				ModifiedAt *time.Time `json:"modified_at"`
+
+				// PasswordProtected Whether downloads require the X-File-Password header
+				PasswordProtected *bool      `json:"password_protected,omitempty"`
+				PurgeAt           *time.Time `json:"purge_at,omitempty"`
+
+				// Quarantined Set while the file is held back for review by an admin (features.quarantine);
+				// its share links answer 404 until it is approved
+				Quarantined *bool `json:"quarantined,omitempty"`
+
+				// Sha256 Hex SHA-256 of the content (missing for files uploaded before checksums were recorded)
+				Sha256 *string `json:"sha256,omitempty"`
+
+				// Size File size in bytes
+				Size int64 `json:"size"`
+
+				// Starred Whether the owner starred the file
+				Starred *bool `json:"starred,omitempty"`
+
+				// Tags User-defined tags for categorization
+				Tags *[]string `json:"tags,omitempty"`
+			} `json:"files,omitempty"`
+			TotalSize *int64 `json:"total_size,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesFileIDResponse parses an HTTP response from a GetFilesFileIDWithResponse call
+func ParseGetFilesFileIDResponse(rsp *http.Response) (*GetFilesFileIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesFileIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FileDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseHeadFilesFileIDResponse parses an HTTP response from a HeadFilesFileIDWithResponse call
+func ParseHeadFilesFileIDResponse(rsp *http.Response) (*HeadFilesFileIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HeadFilesFileIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePatchFilesFileIDResponse parses an HTTP response from a PatchFilesFileIDWithResponse call
+func ParsePatchFilesFileIDResponse(rsp *http.Response) (*PatchFilesFileIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchFilesFileIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FileMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesFileIDCopyResponse parses an HTTP response from a PostFilesFileIDCopyWithResponse call
+func ParsePostFilesFileIDCopyResponse(rsp *http.Response) (*PostFilesFileIDCopyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesFileIDCopyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest FileMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 507:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON507 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesFileIDDownloadUrlResponse parses an HTTP response from a PostFilesFileIDDownloadUrlWithResponse call
+func ParsePostFilesFileIDDownloadUrlResponse(rsp *http.Response) (*PostFilesFileIDDownloadUrlResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesFileIDDownloadUrlResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			ExpiresAt *time.Time `json:"expires_at,omitempty"`
+			Path      *string    `json:"path,omitempty"`
+			Url       *string    `json:"url,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchFilesFileIDExpiryResponse parses an HTTP response from a PatchFilesFileIDExpiryWithResponse call
+func ParsePatchFilesFileIDExpiryResponse(rsp *http.Response) (*PatchFilesFileIDExpiryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchFilesFileIDExpiryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			ExpiresAt *time.Time `json:"expires_at"`
+			FileId    *string    `json:"file_id,omitempty"`
+			Message   *string    `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesFileIDMoveResponse parses an HTTP response from a PostFilesFileIDMoveWithResponse call
+func ParsePostFilesFileIDMoveResponse(rsp *http.Response) (*PostFilesFileIDMoveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesFileIDMoveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FileMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFilesFileIDPasswordResponse parses an HTTP response from a DeleteFilesFileIDPasswordWithResponse call
+func ParseDeleteFilesFileIDPasswordResponse(rsp *http.Response) (*DeleteFilesFileIDPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFilesFileIDPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutFilesFileIDPasswordResponse parses an HTTP response from a PutFilesFileIDPasswordWithResponse call
+func ParsePutFilesFileIDPasswordResponse(rsp *http.Response) (*PutFilesFileIDPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutFilesFileIDPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesFileIDProcessingResponse parses an HTTP response from a GetFilesFileIDProcessingWithResponse call
+func ParseGetFilesFileIDProcessingResponse(rsp *http.Response) (*GetFilesFileIDProcessingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesFileIDProcessingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			FileId *string      `json:"file_id,omitempty"`
+			Stages *[]FileStage `json:"stages,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesFileIDRestoreResponse parses an HTTP response from a PostFilesFileIDRestoreWithResponse call
+func ParsePostFilesFileIDRestoreResponse(rsp *http.Response) (*PostFilesFileIDRestoreResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesFileIDRestoreResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FileMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesFileIDSharesResponse parses an HTTP response from a GetFilesFileIDSharesWithResponse call
+func ParseGetFilesFileIDSharesResponse(rsp *http.Response) (*GetFilesFileIDSharesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesFileIDSharesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count  *int         `json:"count,omitempty"`
+			Shares *[]ShareLink `json:"shares,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesFileIDSharesResponse parses an HTTP response from a PostFilesFileIDSharesWithResponse call
+func ParsePostFilesFileIDSharesResponse(rsp *http.Response) (*PostFilesFileIDSharesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesFileIDSharesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			Share *ShareLink `json:"share,omitempty"`
+			Url   *string    `json:"url,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesFileIDSharesShareIDStatsResponse parses an HTTP response from a GetFilesFileIDSharesShareIDStatsWithResponse call
+func ParseGetFilesFileIDSharesShareIDStatsResponse(rsp *http.Response) (*GetFilesFileIDSharesShareIDStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesFileIDSharesShareIDStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ShareStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFilesFileIDStarResponse parses an HTTP response from a DeleteFilesFileIDStarWithResponse call
+func ParseDeleteFilesFileIDStarResponse(rsp *http.Response) (*DeleteFilesFileIDStarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFilesFileIDStarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesFileIDStarResponse parses an HTTP response from a PostFilesFileIDStarWithResponse call
+func ParsePostFilesFileIDStarResponse(rsp *http.Response) (*PostFilesFileIDStarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesFileIDStarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			FileId  *string `json:"file_id,omitempty"`
+			Starred *bool   `json:"starred,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFilesFileIDThumbnailResponse parses an HTTP response from a GetFilesFileIDThumbnailWithResponse call
+func ParseGetFilesFileIDThumbnailResponse(rsp *http.Response) (*GetFilesFileIDThumbnailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilesFileIDThumbnailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostFilesFileIDTicketResponse parses an HTTP response from a PostFilesFileIDTicketWithResponse call
+func ParsePostFilesFileIDTicketResponse(rsp *http.Response) (*PostFilesFileIDTicketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostFilesFileIDTicketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			ExpiresAt *time.Time `json:"expires_at,omitempty"`
+			Path      *string    `json:"path,omitempty"`
+			Purpose   *string    `json:"purpose,omitempty"`
+			Ticket    *string    `json:"ticket,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPermissionsResponse parses an HTTP response from a GetPermissionsWithResponse call
+func ParseGetPermissionsResponse(rsp *http.Response) (*GetPermissionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPermissionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Permissions *[]struct {
+				Allowed *bool `json:"allowed,omitempty"`
+
+				// HumanOnly Refused to service account keys
+				HumanOnly *bool                             `json:"human_only,omitempty"`
+				Method    *string                           `json:"method,omitempty"`
+				Path      *string                           `json:"path,omitempty"`
+				Public    *bool                             `json:"public,omitempty"`
+				Role      *GetPermissions200PermissionsRole `json:"role,omitempty"`
+				Scope     *string                           `json:"scope,omitempty"`
+			} `json:"permissions,omitempty"`
+			Role *string `json:"role,omitempty"`
+
+			// Scopes Scopes of the token used; null for sessions (not limited)
+			Scopes         *[]string `json:"scopes"`
+			ServiceAccount *bool     `json:"service_account,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSTokenResponse parses an HTTP response from a GetSTokenWithResponse call
+func ParseGetSTokenResponse(rsp *http.Response) (*GetSTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSTokenReportResponse parses an HTTP response from a PostSTokenReportWithResponse call
+func ParsePostSTokenReportResponse(rsp *http.Response) (*PostSTokenReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSTokenReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest struct {
+			Message  *string             `json:"message,omitempty"`
+			ReportId *openapi_types.UUID `json:"report_id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSharesIdResponse parses an HTTP response from a DeleteSharesIdWithResponse call
+func ParseDeleteSharesIdResponse(rsp *http.Response) (*DeleteSharesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSharesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchSharesIdResponse parses an HTTP response from a PatchSharesIdWithResponse call
+func ParsePatchSharesIdResponse(rsp *http.Response) (*PatchSharesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchSharesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStreamIdResponse parses an HTTP response from a GetStreamIdWithResponse call
+func ParseGetStreamIdResponse(rsp *http.Response) (*GetStreamIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStreamIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 416:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON416 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUploadResponse parses an HTTP response from a PostUploadWithResponse call
+func ParsePostUploadResponse(rsp *http.Response) (*PostUploadResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUploadResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest FileMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 507:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON507 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUploadPrecheckResponse parses an HTTP response from a PostUploadPrecheckWithResponse call
+func ParsePostUploadPrecheckResponse(rsp *http.Response) (*PostUploadPrecheckResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUploadPrecheckResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Exists *bool `json:"exists,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest FileMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 507:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON507 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteUserAvatarResponse parses an HTTP response from a DeleteUserAvatarWithResponse call
+func ParseDeleteUserAvatarResponse(rsp *http.Response) (*DeleteUserAvatarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteUserAvatarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Profile
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutUserAvatarResponse parses an HTTP response from a PutUserAvatarWithResponse call
+func ParsePutUserAvatarResponse(rsp *http.Response) (*PutUserAvatarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutUserAvatarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Profile
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 415:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON415 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchUserPasswordResponse parses an HTTP response from a PatchUserPasswordWithResponse call
+func ParsePatchUserPasswordResponse(rsp *http.Response) (*PatchUserPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchUserPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Message *string `json:"message,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUserPreferencesResponse parses an HTTP response from a GetUserPreferencesWithResponse call
+func ParseGetUserPreferencesResponse(rsp *http.Response) (*GetUserPreferencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUserPreferencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UserPreferences
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchUserPreferencesResponse parses an HTTP response from a PatchUserPreferencesWithResponse call
+func ParsePatchUserPreferencesResponse(rsp *http.Response) (*PatchUserPreferencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchUserPreferencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UserPreferences
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchUserProfileResponse parses an HTTP response from a PatchUserProfileWithResponse call
+func ParsePatchUserProfileResponse(rsp *http.Response) (*PatchUserProfileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchUserProfileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Profile
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUserProfileVerifyEmailResponse parses an HTTP response from a PostUserProfileVerifyEmailWithResponse call
+func ParsePostUserProfileVerifyEmailResponse(rsp *http.Response) (*PostUserProfileVerifyEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUserProfileVerifyEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Profile
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUserTransferResponse parses an HTTP response from a GetUserTransferWithResponse call
+func ParseGetUserTransferResponse(rsp *http.Response) (*GetUserTransferResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUserTransferResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransferStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersIdAvatarResponse parses an HTTP response from a GetUsersIdAvatarWithResponse call
+func ParseGetUsersIdAvatarResponse(rsp *http.Response) (*GetUsersIdAvatarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersIdAvatarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetVersionResponse parses an HTTP response from a GetVersionWithResponse call
+func ParseGetVersionResponse(rsp *http.Response) (*GetVersionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetVersionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VersionInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
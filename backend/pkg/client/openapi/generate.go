@@ -0,0 +1,11 @@
+package openapi
+
+// This package is the low-level client generated from docs/openapi.yaml: one
+// method per operation, with the request and response types of the spec. Most
+// programs want the client in the parent package instead.
+//
+// The spec is first brought in line with the code, then the client is generated
+// from it. Run make generate after changing routes, error codes or the types in
+// cmd/openapigen's schemaTypes.
+//go:generate go run ../../../cmd/openapigen -root ../../..
+//go:generate go tool oapi-codegen -config oapi-codegen.yaml ../../../docs/openapi.yaml
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Share is a public link to a file
+type Share struct {
+	ID              string     `json:"id"`
+	FileID          string     `json:"file_id"`
+	CreatedBy       string     `json:"created_by"`
+	Token           string     `json:"token"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	MaxRequests     *int       `json:"max_requests,omitempty"`
+	MaxBytes        *int64     `json:"max_bytes,omitempty"`
+	AllowedReferers []string   `json:"allowed_referers"`
+	AllowedIPs      []string   `json:"allowed_ips"`
+	RequestCount    int        `json:"request_count"`
+	BytesServed     int64      `json:"bytes_served"`
+	LastAccessedAt  *time.Time `json:"last_accessed_at,omitempty"`
+	DisabledAt      *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason  string     `json:"disabled_reason,omitempty"` // owner, requests, bandwidth or spike
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ShareStats is how a share link has been used since it was created; unlike the
+// link's counters it is never reset
+type ShareStats struct {
+	ShareID           string     `json:"share_id"`
+	FileID            string     `json:"file_id"`
+	Views             int64      `json:"views"`     // requests with the link's token, refused ones included
+	Downloads         int64      `json:"downloads"` // requests that were served the file
+	UniqueIPs         int64      `json:"unique_ips"`
+	UniqueDownloadIPs int64      `json:"unique_download_ips"`
+	FirstAccessedAt   *time.Time `json:"first_accessed_at,omitempty"`
+	LastAccessedAt    *time.Time `json:"last_accessed_at,omitempty"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+}
+
+// ShareOptions limits a new share link
+type ShareOptions struct {
+	ExpiresInHours  int      `json:"expires_in_hours"` // 0 = never
+	MaxRequests     *int     `json:"max_requests,omitempty"`
+	MaxBytes        *int64   `json:"max_bytes,omitempty"`
+	AllowedReferers []string `json:"allowed_referers,omitempty"` // hostnames; subdomains match too
+	AllowedIPs      []string `json:"allowed_ips,omitempty"`      // IPs or CIDRs
+}
+
+// CreateShare creates a share link for a file and returns it with its public URL
+func (c *Client) CreateShare(ctx context.Context, fileID string, opts ShareOptions) (*Share, string, error) {
+	var result struct {
+		Share Share  `json:"share"`
+		URL   string `json:"url"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/files/"+url.PathEscape(fileID)+"/shares", opts, &result); err != nil {
+		return nil, "", err
+	}
+	return &result.Share, result.URL, nil
+}
+
+// ListShares returns the share links of a file
+func (c *Client) ListShares(ctx context.Context, fileID string) ([]Share, error) {
+	var result struct {
+		Shares []Share `json:"shares"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/files/"+url.PathEscape(fileID)+"/shares", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Shares, nil
+}
+
+// GetShareStats returns the views, downloads and unique visitors of a share link
+// of a file
+func (c *Client) GetShareStats(ctx context.Context, fileID, shareID string) (*ShareStats, error) {
+	var stats ShareStats
+	if err := c.do(ctx, http.MethodGet, "/files/"+url.PathEscape(fileID)+"/shares/"+url.PathEscape(shareID)+"/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// SetShareEnabled disables a share link or enables it again; resetCounters
+// starts its request and byte limits over
+func (c *Client) SetShareEnabled(ctx context.Context, shareID string, enabled, resetCounters bool) error {
+	return c.do(ctx, http.MethodPatch, "/shares/"+url.PathEscape(shareID), map[string]bool{
+		"enabled":        enabled,
+		"reset_counters": resetCounters,
+	}, nil)
+}
+
+// DeleteShare deletes a share link
+func (c *Client) DeleteShare(ctx context.Context, shareID string) error {
+	return c.do(ctx, http.MethodDelete, "/shares/"+url.PathEscape(shareID), nil, nil)
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrChecksumMismatch is returned (wrapped) when the SHA-256 of a transferred
+// file differs from the one the other side computed
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// UploadRequest describes a file to upload
+type UploadRequest struct {
+	FileName    string
+	Body        io.Reader
+	Size        int64 // passed to Progress; 0 if unknown
+	Description string
+	Tags        []string
+	ExpireAfter *int       // in hours; nil for the user's default expiry, 0 for never
+	Folder      string     // "" for the root
+	ModifiedAt  *time.Time // e.g. the mtime of the local file; kept and listed as File.ModifiedAt
+
+	// IdempotencyKey makes the server create the file at most once for all
+	// requests with this key, so an upload can be retried after a failure that
+	// left it unclear whether the server got it. Retries must send the same file.
+	IdempotencyKey string
+
+	// Progress, if set, is called with the number of bytes of Body sent so far
+	Progress func(sent, total int64)
+}
+
+// UploadResult is a file created by Upload or Precheck
+type UploadResult struct {
+	FileID     string     `json:"file_id"`
+	FileName   string     `json:"file_name"`
+	Size       int64      `json:"size"`
+	MimeType   string     `json:"mime_type"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	SHA256     string     `json:"sha256"`
+	Folder     string     `json:"folder"`
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+	Instant    bool       `json:"instant,omitempty"` // created from content the user already had
+
+	Quarantined bool `json:"quarantined,omitempty"` // held back for review by an admin
+
+	// Replayed is set when the server answered with the result of an earlier
+	// request with the same idempotency key
+	Replayed bool `json:"-"`
+}
+
+// Upload uploads a file, streaming Body. The SHA-256 of what was sent goes with
+// it; the server rejects the upload if it received something else, and Upload
+// returns ErrChecksumMismatch if the server stored a different checksum.
+func (c *Client) Upload(ctx context.Context, upload UploadRequest) (*UploadResult, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	hasher := sha256.New()
+	done := make(chan string, 1)
+
+	go func() {
+		part, err := writer.CreateFormFile("file", upload.FileName)
+		if err == nil {
+			body := io.TeeReader(upload.Body, hasher)
+			if upload.Progress != nil {
+				body = &progressReader{r: body, total: upload.Size, progress: upload.Progress}
+			}
+			_, err = io.Copy(part, body)
+		}
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			done <- ""
+			return
+		}
+
+		// After the file, so the server can check what it received
+		checksum := hex.EncodeToString(hasher.Sum(nil))
+		_ = writer.WriteField("sha256", checksum)
+		if upload.Description != "" {
+			_ = writer.WriteField("description", upload.Description)
+		}
+		if len(upload.Tags) > 0 {
+			_ = writer.WriteField("tags", strings.Join(upload.Tags, ","))
+		}
+		if upload.ExpireAfter != nil {
+			_ = writer.WriteField("expire_after", strconv.Itoa(*upload.ExpireAfter))
+		}
+		if upload.Folder != "" {
+			_ = writer.WriteField("folder", upload.Folder)
+		}
+		if upload.ModifiedAt != nil {
+			_ = writer.WriteField("modified_at", upload.ModifiedAt.UTC().Format(time.RFC3339Nano))
+		}
+		_ = pw.CloseWithError(writer.Close())
+		done <- checksum
+	}()
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/upload", pr)
+	if err != nil {
+		_ = pr.CloseWithError(err)
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if upload.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", upload.IdempotencyKey)
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		_ = pr.CloseWithError(errors.New("upload rejected"))
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result UploadResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid upload response: %w", err)
+	}
+
+	// A replayed response may come before the body was sent, and its checksum is
+	// that of the earlier attempt
+	if resp.Header.Get("Idempotent-Replayed") != "" {
+		_ = pr.CloseWithError(errors.New("upload replayed"))
+		result.Replayed = true
+		return &result, nil
+	}
+	sent := <-done
+	if sent != "" && result.SHA256 != "" && !strings.EqualFold(result.SHA256, sent) {
+		return &result, fmt.Errorf("%w: sent sha256 %s, server stored %s (file ID %s)", ErrChecksumMismatch, sent, result.SHA256, result.FileID)
+	}
+	return &result, nil
+}
+
+// PrecheckRequest describes a file about to be uploaded
+type PrecheckRequest struct {
+	SHA256      string     `json:"sha256"`
+	Size        int64      `json:"size"`
+	FileName    string     `json:"file_name"`
+	Description string     `json:"description,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	ExpireAfter *int       `json:"expire_after,omitempty"` // as in UploadRequest
+	Folder      string     `json:"folder,omitempty"`
+	ModifiedAt  *time.Time `json:"modified_at,omitempty"`
+}
+
+// Precheck creates a file from content the user already stores, without sending
+// it. It returns nil when the content is new and has to be uploaded. Servers
+// offer it when GET /version lists the upload_precheck feature.
+func (c *Client) Precheck(ctx context.Context, precheck PrecheckRequest) (*UploadResult, error) {
+	var result UploadResult
+	if err := c.do(ctx, http.MethodPost, "/upload/precheck", precheck, &result); err != nil {
+		return nil, err
+	}
+	if result.FileID == "" {
+		return nil, nil // {"exists": false}
+	}
+	return &result, nil
+}
+
+// Download is a file being downloaded
+type Download struct {
+	// Body is the content. Reading it to the end fails with ErrChecksumMismatch
+	// if it does not match the checksum taken on upload.
+	Body     io.ReadCloser
+	FileName string
+	Size     int64  // -1 if unknown
+	SHA256   string // "" for files uploaded before checksums were recorded
+}
+
+// Download starts downloading a file; the caller closes its Body
+func (c *Client) Download(ctx context.Context, fileID string) (*Download, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/download/"+url.PathEscape(fileID), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Download{
+		Body:   resp.Body,
+		Size:   resp.ContentLength,
+		SHA256: resp.Header.Get("X-Checksum-SHA256"),
+	}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		d.FileName = params["filename"]
+	}
+	if d.SHA256 != "" {
+		d.Body = &checksumReader{ReadCloser: resp.Body, hasher: sha256.New(), expected: d.SHA256}
+	}
+	return d, nil
+}
+
+// Range is part of a file read with ReadRange
+type Range struct {
+	Body  io.ReadCloser
+	Whole bool  // the server ignored the range and sends the whole file
+	Total int64 // size of the whole file; -1 if unknown
+}
+
+// ReadRange reads length bytes of a file from offset, so only that part is
+// decrypted and transferred. It returns io.EOF when offset is at or past the end.
+func (c *Client) ReadRange(ctx context.Context, fileID string, offset, length int64) (*Range, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/stream/"+url.PathEscape(fileID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	resp, err := c.send(req)
+	if IsStatus(err, http.StatusRequestedRangeNotSatisfiable) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Range{Body: resp.Body, Total: -1}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		// Content-Range: bytes start-end/size
+		if _, total, ok := strings.Cut(resp.Header.Get("Content-Range"), "/"); ok {
+			if size, err := strconv.ParseInt(total, 10, 64); err == nil {
+				r.Total = size
+			}
+		}
+	default:
+		r.Whole = true
+		r.Total = resp.ContentLength
+	}
+	return r, nil
+}
+
+// progressReader reports how much was read
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// checksumReader checks the SHA-256 of a body once it has been read to the end
+type checksumReader struct {
+	io.ReadCloser
+	hasher   hash.Hash
+	expected string
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hasher.Write(p[:n])
+	if err == io.EOF {
+		if got := hex.EncodeToString(r.hasher.Sum(nil)); !strings.EqualFold(got, r.expected) {
+			return n, fmt.Errorf("%w: expected sha256 %s, got %s", ErrChecksumMismatch, r.expected, got)
+		}
+	}
+	return n, err
+}
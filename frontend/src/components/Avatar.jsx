@@ -0,0 +1,53 @@
+import { useState, useEffect } from "preact/hooks";
+import api from "../utils/api";
+
+// Avatars need the Authorization header, so they are fetched as blobs rather than
+// linked with <img src>. Users without one get the initial of their name.
+export default function Avatar({
+  username = "",
+  avatarUrl,
+  size = 32,
+  background = "var(--primary-color)",
+}) {
+  const [src, setSrc] = useState(null);
+
+  useEffect(() => {
+    if (!avatarUrl) {
+      setSrc(null);
+      return;
+    }
+
+    let objectUrl = null;
+    let cancelled = false;
+    // avatar_url already carries the /api/v1 prefix
+    api
+      .get(avatarUrl, { baseURL: "", responseType: "blob" })
+      .then((res) => {
+        if (cancelled) return;
+        objectUrl = URL.createObjectURL(res.data);
+        setSrc(objectUrl);
+      })
+      .catch(() => {
+        if (!cancelled) setSrc(null);
+      });
+
+    return () => {
+      cancelled = true;
+      if (objectUrl) URL.revokeObjectURL(objectUrl);
+    };
+  }, [avatarUrl]);
+
+  const style = `width: ${size}px; height: ${size}px; border-radius: 50%; flex-shrink: 0;`;
+  if (src) {
+    return (
+      <img src={src} alt={username} style={`${style} object-fit: cover;`} />
+    );
+  }
+  return (
+    <div
+      style={`${style} background: ${background}; display: flex; align-items: center; justify-content: center; color: white; font-weight: 600; font-size: ${Math.round(size * 0.45)}px;`}
+    >
+      {username.charAt(0).toUpperCase()}
+    </div>
+  );
+}
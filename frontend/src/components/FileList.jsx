@@ -85,9 +85,16 @@ export default function FileList({ files, onDelete, onUpdate }) {
     }
   };
 
-  const handleStream = (fileId, filename) => {
+  const handleStream = async (fileId, filename) => {
     setStreamLoading(true);
-    setStreamingFile({ fileId, filename });
+    try {
+      const src = await getStreamUrl(fileId);
+      setStreamingFile({ fileId, filename, src });
+    } catch (err) {
+      console.error("Stream failed:", err);
+      alert("Failed to start stream");
+      setStreamLoading(false);
+    }
   };
 
   const closePlayer = () => {
@@ -238,7 +245,7 @@ export default function FileList({ files, onDelete, onUpdate }) {
               controls
               autoplay
               style="width: 100%; max-height: 70vh; background: #000;"
-              src={getStreamUrl(streamingFile.fileId)}
+              src={streamingFile.src}
               onLoadedData={() => setStreamLoading(false)}
               onError={() => setStreamLoading(false)}
             >
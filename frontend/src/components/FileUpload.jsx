@@ -188,7 +188,7 @@ export default function FileUpload({ onUploadComplete }) {
             <input
               type="number"
               class="form-input"
-              placeholder="Leave empty for no expiration"
+              placeholder="Leave empty for your default expiry"
               value={expiresIn}
               onChange={(e) => setExpiresIn(e.target.value)}
               min="1"
@@ -3,6 +3,7 @@ import { route } from "preact-router";
 import { getUser, getToken } from "../utils/auth";
 import api from "../utils/api";
 import Toast from "../components/Toast";
+import Avatar from "../components/Avatar";
 
 export default function Admin({ isAuthenticated }) {
   const [stats, setStats] = useState({
@@ -901,9 +902,7 @@ export default function Admin({ isAuthenticated }) {
                   >
                     <td style="padding: 1rem;">
                       <div style="display: flex; align-items: center; gap: 0.5rem;">
-                        <div style="width: 32px; height: 32px; border-radius: 50%; background: var(--primary-color); display: flex; align-items: center; justify-content: center; color: white; font-weight: 600;">
-                          {u.username.charAt(0).toUpperCase()}
-                        </div>
+                        <Avatar username={u.username} avatarUrl={u.avatar_url} />
                         <div>
                           <div style="display: flex; align-items: center; gap: 0.5rem;">
                             <span style="font-weight: 500;">{u.username}</span>
@@ -3,6 +3,7 @@ import { route } from "preact-router";
 import { getUser, getToken } from "../utils/auth";
 import api from "../utils/api";
 import Toast from "../components/Toast";
+import Avatar from "../components/Avatar";
 import ConfirmDialog from "../components/ConfirmDialog";
 
 export default function AdminUserDetails({ isAuthenticated, userId }) {
@@ -340,9 +341,12 @@ export default function AdminUserDetails({ isAuthenticated, userId }) {
       <div class="card" style="margin-bottom: 2rem;">
         <div style="display: flex; justify-content: space-between; align-items: flex-start; gap: 2rem; flex-wrap: wrap;">
           <div style="display: flex; align-items: start; gap: 1.5rem;">
-            <div style="width: 80px; height: 80px; border-radius: 50%; background: linear-gradient(135deg, var(--primary-color) 0%, #764ba2 100%); display: flex; align-items: center; justify-content: center; color: white; font-size: 2rem; font-weight: 700;">
-              {userDetails.username.charAt(0).toUpperCase()}
-            </div>
+            <Avatar
+              username={userDetails.username}
+              avatarUrl={userDetails.avatar_url}
+              size={80}
+              background="linear-gradient(135deg, var(--primary-color) 0%, #764ba2 100%)"
+            />
             <div>
               <div style="display: flex; align-items: center; gap: 0.75rem; margin-bottom: 0.5rem;">
                 <h2 style="margin: 0; font-size: 1.75rem;">
@@ -392,6 +396,14 @@ export default function AdminUserDetails({ isAuthenticated, userId }) {
                 {formatBytes(userDetails.total_storage)}
               </div>
             </div>
+            <div>
+              <div style="font-size: 0.85rem; color: var(--text-secondary); margin-bottom: 0.25rem;">
+                Sessions
+              </div>
+              <div style="font-size: 1.5rem; font-weight: 700; color: var(--text-color);">
+                {userDetails.session_count ?? 0}
+              </div>
+            </div>
             <div>
               <div style="font-size: 0.85rem; color: var(--text-secondary); margin-bottom: 0.25rem;">
                 Joined
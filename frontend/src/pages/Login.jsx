@@ -1,7 +1,12 @@
 import { useState, useEffect } from "preact/hooks";
 import { route } from "preact-router";
 import { login, getMe } from "../utils/api";
-import { saveToken, saveUser, getToken } from "../utils/auth";
+import {
+  saveToken,
+  saveRefreshToken,
+  saveUser,
+  getToken,
+} from "../utils/auth";
 
 export default function Login({ setIsAuthenticated }) {
   const [username, setUsername] = useState("");
@@ -23,9 +28,10 @@ export default function Login({ setIsAuthenticated }) {
 
     try {
       const response = await login(username, password);
-      const { token } = response.data;
+      const { token, refresh_token } = response.data;
 
       saveToken(token);
+      saveRefreshToken(refresh_token);
 
       // Fetch user info including role
       const userResponse = await getMe();
@@ -1,9 +1,10 @@
 import { useState, useEffect } from "preact/hooks";
 import { route } from "preact-router";
-import { getUser, getToken } from "../utils/auth";
-import api from "../utils/api";
+import { getUser, getToken, saveUser } from "../utils/auth";
+import api, { getPreferences, updatePreferences } from "../utils/api";
 import Toast from "../components/Toast";
 import ConfirmDialog from "../components/ConfirmDialog";
+import Avatar from "../components/Avatar";
 
 export default function Settings({ isAuthenticated, addNotification }) {
   const [currentPassword, setCurrentPassword] = useState("");
@@ -15,6 +16,15 @@ export default function Settings({ isAuthenticated, addNotification }) {
   const [toast, setToast] = useState(null);
   const [showShortcuts, setShowShortcuts] = useState(true);
   const user = getUser();
+  const [displayName, setDisplayName] = useState(user?.display_name || "");
+  const [email, setEmail] = useState(user?.email || "");
+  const [profilePassword, setProfilePassword] = useState("");
+  const [pendingEmail, setPendingEmail] = useState(user?.pending_email || "");
+  const [verifyCode, setVerifyCode] = useState("");
+  const [profileLoading, setProfileLoading] = useState(false);
+  const [avatarUrl, setAvatarUrl] = useState(user?.avatar_url || "");
+  const [preferences, setPreferences] = useState(null);
+  const [preferencesLoading, setPreferencesLoading] = useState(false);
 
   const showToast = (message, type = "info") => {
     setToast({ message, type });
@@ -33,6 +43,39 @@ export default function Settings({ isAuthenticated, addNotification }) {
     }
   }, [isAuthenticated]);
 
+  useEffect(() => {
+    if (!isAuthenticated || !getToken()) return;
+    getPreferences()
+      .then((res) => setPreferences(res.data))
+      .catch(() => showToast("Failed to load preferences", "error"));
+  }, [isAuthenticated]);
+
+  const savePreferences = async (changes) => {
+    setPreferencesLoading(true);
+    try {
+      const response = await updatePreferences(changes);
+      setPreferences(response.data);
+      showToast("Preferences saved", "success");
+    } catch (err) {
+      showToast(
+        err.response?.data?.error || "Failed to save preferences",
+        "error",
+      );
+    } finally {
+      setPreferencesLoading(false);
+    }
+  };
+
+  const handlePreferencesSave = (e) => {
+    e.preventDefault();
+    savePreferences({
+      timezone: preferences.timezone,
+      locale: preferences.locale,
+      default_expiry_hours: Number(preferences.default_expiry_hours) || 0,
+      page_size: Number(preferences.page_size) || 0,
+    });
+  };
+
   // Auto-hide keyboard shortcuts hint after 5 seconds
   useEffect(() => {
     const timer = setTimeout(() => {
@@ -42,6 +85,108 @@ export default function Settings({ isAuthenticated, addNotification }) {
     return () => clearTimeout(timer);
   }, []);
 
+  // Keeps the stored user (header, other pages) in step with the profile
+  const applyProfile = (profile) => {
+    saveUser({
+      ...user,
+      username: profile.username,
+      email: profile.email,
+      display_name: profile.display_name,
+      pending_email: profile.pending_email,
+      avatar_url: profile.avatar_url,
+    });
+    setAvatarUrl(profile.avatar_url || "");
+    setDisplayName(profile.display_name || "");
+    setEmail(profile.email);
+    setPendingEmail(profile.pending_email || "");
+  };
+
+  // The image is sent as the raw body; the server crops and resizes it
+  const handleAvatarChange = async (e) => {
+    const file = e.target.files?.[0];
+    e.target.value = "";
+    if (!file) return;
+    if (file.size > 2 * 1024 * 1024) {
+      showToast("Avatar must be at most 2 MB", "error");
+      return;
+    }
+
+    setProfileLoading(true);
+    try {
+      const response = await api.put("/user/avatar", file, {
+        headers: { "Content-Type": file.type || "application/octet-stream" },
+      });
+      applyProfile(response.data);
+      showToast("Avatar updated", "success");
+    } catch (err) {
+      showToast(err.response?.data?.error || "Failed to update avatar", "error");
+    } finally {
+      setProfileLoading(false);
+    }
+  };
+
+  const handleAvatarRemove = async () => {
+    setProfileLoading(true);
+    try {
+      const response = await api.delete("/user/avatar");
+      applyProfile(response.data);
+      showToast("Avatar removed", "success");
+    } catch (err) {
+      showToast(err.response?.data?.error || "Failed to remove avatar", "error");
+    } finally {
+      setProfileLoading(false);
+    }
+  };
+
+  const handleProfileSave = async (e) => {
+    e.preventDefault();
+    const emailChanged = email.trim() !== (user?.email || "");
+    setProfileLoading(true);
+
+    try {
+      const response = await api.patch("/user/profile", {
+        display_name: displayName,
+        ...(emailChanged && {
+          email: email.trim(),
+          current_password: profilePassword,
+        }),
+      });
+      applyProfile(response.data);
+      setProfilePassword("");
+      showToast(
+        emailChanged
+          ? `Profile saved. Enter the code sent to ${email.trim()} to confirm it.`
+          : "Profile saved",
+        "success",
+      );
+    } catch (err) {
+      showToast(err.response?.data?.error || "Failed to save profile", "error");
+    } finally {
+      setProfileLoading(false);
+    }
+  };
+
+  const handleVerifyEmail = async (e) => {
+    e.preventDefault();
+    setProfileLoading(true);
+
+    try {
+      const response = await api.post("/user/profile/verify-email", {
+        code: verifyCode.trim(),
+      });
+      applyProfile(response.data);
+      setVerifyCode("");
+      showToast("Email address changed", "success");
+    } catch (err) {
+      showToast(
+        err.response?.data?.error || "Failed to verify email address",
+        "error",
+      );
+    } finally {
+      setProfileLoading(false);
+    }
+  };
+
   const handlePasswordChange = async (e) => {
     e.preventDefault();
     setError("");
@@ -149,25 +294,116 @@ export default function Settings({ isAuthenticated, addNotification }) {
         <div class="card settings-section">
           <h3>Account Information</h3>
           <div class="settings-item">
-            <label>Username</label>
-            <input
-              type="text"
-              class="form-input"
-              disabled
-              value={user?.username || "N/A"}
-            />
-            <small>Username cannot be changed</small>
-          </div>
-          <div class="settings-item">
-            <label>Email</label>
-            <input
-              type="email"
-              class="form-input"
-              disabled
-              value={user?.email || "user@example.com"}
-            />
-            <small>Contact admin to change email</small>
+            <label>Avatar</label>
+            <div style="display: flex; align-items: center; gap: 1rem;">
+              <Avatar
+                username={user?.username}
+                avatarUrl={avatarUrl}
+                size={64}
+              />
+              <label class="btn btn-secondary" style="cursor: pointer;">
+                {avatarUrl ? "Change" : "Upload"}
+                <input
+                  type="file"
+                  accept="image/jpeg,image/png,image/gif"
+                  onChange={handleAvatarChange}
+                  disabled={profileLoading}
+                  style="display: none;"
+                />
+              </label>
+              {avatarUrl && (
+                <button
+                  type="button"
+                  class="btn btn-secondary"
+                  onClick={handleAvatarRemove}
+                  disabled={profileLoading}
+                >
+                  Remove
+                </button>
+              )}
+            </div>
+            <small>JPEG, PNG or GIF up to 2 MB; cropped to a square</small>
           </div>
+          <form onSubmit={handleProfileSave}>
+            <div class="settings-item">
+              <label>Username</label>
+              <input
+                type="text"
+                class="form-input"
+                disabled
+                value={user?.username || "N/A"}
+              />
+              <small>Contact admin to change your username</small>
+            </div>
+            <div class="settings-item">
+              <label>Display Name</label>
+              <input
+                type="text"
+                class="form-input"
+                value={displayName}
+                onChange={(e) => setDisplayName(e.target.value)}
+                placeholder="Name shown to others"
+                maxLength={100}
+                disabled={profileLoading}
+              />
+            </div>
+            <div class="settings-item">
+              <label>Email</label>
+              <input
+                type="email"
+                class="form-input"
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+                disabled={profileLoading}
+              />
+              <small>A new address must be confirmed with a code sent to it</small>
+            </div>
+            {email.trim() !== (user?.email || "") && (
+              <div class="settings-item">
+                <label>Current Password</label>
+                <input
+                  type="password"
+                  class="form-input"
+                  value={profilePassword}
+                  onChange={(e) => setProfilePassword(e.target.value)}
+                  placeholder="Required to change the email address"
+                  disabled={profileLoading}
+                  required
+                />
+              </div>
+            )}
+            <button
+              type="submit"
+              class="btn btn-primary"
+              disabled={profileLoading}
+            >
+              {profileLoading ? "Saving..." : "Save Profile"}
+            </button>
+          </form>
+
+          {pendingEmail && (
+            <form onSubmit={handleVerifyEmail} style="margin-top: 1.5rem;">
+              <div class="settings-item">
+                <label>Confirm {pendingEmail}</label>
+                <input
+                  type="text"
+                  class="form-input"
+                  value={verifyCode}
+                  onChange={(e) => setVerifyCode(e.target.value)}
+                  placeholder="Verification code from the email"
+                  disabled={profileLoading}
+                  required
+                />
+              </div>
+              <button
+                type="submit"
+                class="btn btn-secondary"
+                disabled={profileLoading}
+              >
+                Confirm Email
+              </button>
+            </form>
+          )}
         </div>
 
         {/* Security Settings */}
@@ -241,9 +477,101 @@ export default function Settings({ isAuthenticated, addNotification }) {
           </div>
         </div>
 
+        {/* Preferences */}
+        {preferences && (
+          <div class="card settings-section">
+            <h3>Preferences</h3>
+            <form onSubmit={handlePreferencesSave}>
+              <div class="settings-item">
+                <label>Timezone</label>
+                <input
+                  type="text"
+                  class="form-input"
+                  value={preferences.timezone}
+                  onChange={(e) =>
+                    setPreferences({ ...preferences, timezone: e.target.value })
+                  }
+                  placeholder="e.g. Europe/Berlin"
+                  disabled={preferencesLoading}
+                />
+                <small>
+                  Your browser uses{" "}
+                  {Intl.DateTimeFormat().resolvedOptions().timeZone}
+                </small>
+              </div>
+              <div class="settings-item">
+                <label>Language</label>
+                <input
+                  type="text"
+                  class="form-input"
+                  value={preferences.locale}
+                  onChange={(e) =>
+                    setPreferences({ ...preferences, locale: e.target.value })
+                  }
+                  placeholder="e.g. en-US"
+                  disabled={preferencesLoading}
+                />
+              </div>
+              <div class="settings-item">
+                <label>Default Expiry (hours)</label>
+                <input
+                  type="number"
+                  class="form-input"
+                  min="0"
+                  value={preferences.default_expiry_hours}
+                  onChange={(e) =>
+                    setPreferences({
+                      ...preferences,
+                      default_expiry_hours: e.target.value,
+                    })
+                  }
+                  disabled={preferencesLoading}
+                />
+                <small>Applies to uploads without an expiry; 0 = never</small>
+              </div>
+              <div class="settings-item">
+                <label>Files per Page</label>
+                <input
+                  type="number"
+                  class="form-input"
+                  min="0"
+                  max="1000"
+                  value={preferences.page_size}
+                  onChange={(e) =>
+                    setPreferences({ ...preferences, page_size: e.target.value })
+                  }
+                  disabled={preferencesLoading}
+                />
+                <small>Page size of file listings (CLI, API); 0 = all</small>
+              </div>
+              <button
+                type="submit"
+                class="btn btn-primary"
+                disabled={preferencesLoading}
+              >
+                {preferencesLoading ? "Saving..." : "Save Preferences"}
+              </button>
+            </form>
+          </div>
+        )}
+
         {/* Notifications */}
         <div class="card settings-section">
           <h3>Notifications</h3>
+          <div class="settings-item">
+            <label>Share Link Notices</label>
+            <input
+              type="checkbox"
+              checked={preferences?.notifications?.share_disabled ?? true}
+              onChange={(e) =>
+                savePreferences({
+                  notifications: { share_disabled: e.target.checked },
+                })
+              }
+              disabled={!preferences || preferencesLoading}
+            />
+            <small>Announce share links that were disabled automatically</small>
+          </div>
           <div class="settings-item">
             <label>Email Notifications</label>
             <input type="checkbox" disabled />
@@ -1,5 +1,10 @@
 import axios from "axios";
-import { getToken } from "./auth";
+import {
+  getToken,
+  getRefreshToken,
+  saveToken,
+  saveRefreshToken,
+} from "./auth";
 
 // Use relative URL - Nginx will proxy /api/* to backend
 const API_BASE_URL = "/api/v1";
@@ -18,6 +23,54 @@ api.interceptors.request.use((config) => {
   return config;
 });
 
+// Access tokens are short-lived: on a 401, renew them once with the refresh token
+// and retry. Concurrent requests share one refresh, since each refresh token works
+// only once.
+let refreshing = null;
+
+const refreshTokens = () => {
+  if (!refreshing) {
+    refreshing = axios
+      .post(`${API_BASE_URL}/auth/refresh`, {
+        refresh_token: getRefreshToken(),
+      })
+      .then((response) => {
+        saveToken(response.data.token);
+        saveRefreshToken(response.data.refresh_token);
+        return response.data.token;
+      })
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+};
+
+api.interceptors.response.use(
+  (response) => response,
+  async (error) => {
+    const config = error.config;
+    if (
+      error.response?.status !== 401 ||
+      !config ||
+      config._retried ||
+      !getRefreshToken() ||
+      config.url?.startsWith("/auth/")
+    ) {
+      return Promise.reject(error);
+    }
+
+    config._retried = true;
+    try {
+      const token = await refreshTokens();
+      config.headers.Authorization = `Bearer ${token}`;
+      return api(config);
+    } catch {
+      return Promise.reject(error);
+    }
+  },
+);
+
 // Auth APIs
 export const login = (username, password) => {
   return api.post("/auth/login", { username, password });
@@ -55,8 +108,26 @@ export const uploadFile = (
   });
 };
 
-export const listFiles = () => {
-  return api.get("/files");
+// Listings are paged when the user set a page size preference; the dashboard
+// shows every file, so follow the pages. Resolves like a single response.
+export const listFiles = async () => {
+  const response = await api.get("/files");
+  let files = response.data.files || [];
+  let cursor = response.data.next_cursor;
+  while (cursor) {
+    const page = await api.get("/files", { params: { cursor } });
+    files = files.concat(page.data.files || []);
+    cursor = page.data.next_cursor;
+  }
+  return { ...response, data: { files, count: files.length } };
+};
+
+export const getPreferences = () => {
+  return api.get("/user/preferences");
+};
+
+export const updatePreferences = (changes) => {
+  return api.patch("/user/preferences", changes);
 };
 
 export const searchFiles = (query) => {
@@ -78,14 +149,17 @@ export const downloadFile = (fileId, onProgress) => {
   });
 };
 
-export const getDownloadUrl = (fileId) => {
-  const token = getToken();
-  return `${API_BASE_URL}/download/${fileId}?token=${token}`;
+// Signed, expiring URL that works without the session token (e.g. for sharing
+// with a media player). Resolves to { url, path, expires_at }.
+export const createDownloadUrl = (fileId, options = {}) => {
+  return api.post(`/files/${fileId}/download-url`, options);
 };
 
-export const getStreamUrl = (fileId) => {
-  const token = getToken();
-  return `${API_BASE_URL}/stream/${fileId}?token=${token}`;
+// Media elements cannot send the Authorization header, so streams use a one-time
+// ticket. Resolves to the URL to put in <video src>.
+export const getStreamUrl = async (fileId) => {
+  const res = await api.post(`/files/${fileId}/ticket`, { purpose: "stream" });
+  return `${API_BASE_URL}/stream/${fileId}?ticket=${encodeURIComponent(res.data.ticket)}`;
 };
 
 export const exportAllFiles = (onProgress) => {
@@ -1,4 +1,5 @@
 const TOKEN_KEY = "filelocker_token";
+const REFRESH_TOKEN_KEY = "filelocker_refresh_token";
 const USER_KEY = "filelocker_user";
 
 export const saveToken = (token) => {
@@ -9,8 +10,18 @@ export const getToken = () => {
   return localStorage.getItem(TOKEN_KEY);
 };
 
+// The refresh token renews the short-lived access token (see utils/api.js)
+export const saveRefreshToken = (token) => {
+  localStorage.setItem(REFRESH_TOKEN_KEY, token);
+};
+
+export const getRefreshToken = () => {
+  return localStorage.getItem(REFRESH_TOKEN_KEY);
+};
+
 export const removeToken = () => {
   localStorage.removeItem(TOKEN_KEY);
+  localStorage.removeItem(REFRESH_TOKEN_KEY);
   localStorage.removeItem(USER_KEY);
 };
 